@@ -14,6 +14,7 @@ func main() {
 		Stdin:  os.Stdin,
 		Getwd:  os.Getwd,
 		IsTTY:  cli.DetectTTY(os.Stdout),
+		Getenv: os.Getenv,
 	}
 	os.Exit(app.Run(os.Args))
 }
@@ -4,7 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/leeovery/tick/internal/config"
+	"github.com/leeovery/tick/internal/task"
+	"github.com/leeovery/tick/tick"
 )
 
 // Version is set at build time via ldflags:
@@ -29,6 +36,13 @@ type App struct {
 	Getwd func() (string, error)
 	// IsTTY indicates whether stdout is a terminal. Set during flag parsing.
 	IsTTY bool
+	// Getenv looks up environment variables such as TICK_LOCK_TIMEOUT. Injected
+	// for testability; nil means no environment.
+	Getenv func(string) string
+
+	// config is the configuration of the project in the working directory,
+	// loaded once by Run. Nil when there is no project or its config is invalid.
+	config *config.Config
 }
 
 // Run parses args, dispatches subcommands, and returns an exit code (0 = success, 1 = error).
@@ -58,6 +72,8 @@ func (a *App) Run(args []string) int {
 		return 0
 	}
 
+	a.loadProjectConfig()
+
 	// Help bypasses format/formatter machinery — always plain text.
 	if subcmd == "help" {
 		return a.handleHelp(subArgs)
@@ -66,7 +82,7 @@ func (a *App) Run(args []string) int {
 		return a.handleHelp([]string{subcmd})
 	}
 
-	// Doctor and migrate bypass format/formatter machinery — always human-readable text.
+	// Doctor, migrate, and merge-driver bypass format/formatter machinery — always human-readable text.
 	if subcmd == "doctor" {
 		if err := ValidateFlags("doctor", subArgs, commandFlags); err != nil {
 			fmt.Fprintf(a.Stderr, "Error: %s\n", err)
@@ -81,6 +97,25 @@ func (a *App) Run(args []string) int {
 		}
 		return a.handleMigrate(subArgs)
 	}
+	if subcmd == "merge-driver" {
+		if err := ValidateFlags("merge-driver", subArgs, commandFlags); err != nil {
+			fmt.Fprintf(a.Stderr, "Error: %s\n", err)
+			return 1
+		}
+		return a.handleMergeDriver(subArgs)
+	}
+
+	// TICK_LOCK_TIMEOUT applies when --lock-timeout is not given.
+	if flags.lockTimeout == 0 && a.Getenv != nil {
+		if v := a.Getenv("TICK_LOCK_TIMEOUT"); v != "" {
+			d, err := parseLockTimeout(v)
+			if err != nil {
+				fmt.Fprintf(a.Stderr, "Error: TICK_LOCK_TIMEOUT: %s\n", err)
+				return 1
+			}
+			flags.lockTimeout = d
+		}
+	}
 
 	// Resolve format once in dispatcher.
 	fc, err := NewFormatConfig(flags, a.IsTTY)
@@ -94,7 +129,13 @@ func (a *App) Run(args []string) int {
 		fc.Logger = NewVerboseLogger(a.Stderr)
 	}
 
+	fc.Command = commandLine(args)
+	fc.Config = a.config
+
 	fmtr := NewFormatter(fc.Format)
+	if pf, ok := fmtr.(*PrettyFormatter); ok {
+		pf.AsOf = fc.AsOf
+	}
 
 	// Log format resolution if verbose.
 	if fc.Logger != nil {
@@ -116,6 +157,10 @@ func (a *App) Run(args []string) int {
 		fmt.Fprintf(a.Stderr, "Error: %s\n", err)
 		return 1
 	}
+	if !fc.AsOf.IsZero() && !slices.Contains(asOfCommands, subcmd) {
+		fmt.Fprintf(a.Stderr, "Error: --as-of is only supported by %s\n", strings.Join(asOfCommands, ", "))
+		return 1
+	}
 
 	switch subcmd {
 	case "init":
@@ -130,21 +175,62 @@ func (a *App) Run(args []string) int {
 		err = a.handleUpdate(fc, fmtr, subArgs)
 	case "start", "done", "cancel", "reopen":
 		err = a.handleTransition(subcmd, fc, fmtr, subArgs)
+	case "claim":
+		err = a.handleClaim(fc, fmtr, subArgs)
+	case "heartbeat":
+		err = a.handleHeartbeat(fc, fmtr, subArgs)
+	case "defer":
+		err = a.handleDefer(fc, fmtr, subArgs)
+	case "undefer":
+		err = a.handleUndefer(fc, fmtr, subArgs)
 	case "ready":
 		err = a.handleReady(fc, fmtr, subArgs)
 	case "blocked":
 		err = a.handleBlocked(fc, fmtr, subArgs)
+	case "mine":
+		err = a.handleMine(fc, fmtr, subArgs)
 	case "dep":
 		err = a.handleDep(fc, fmtr, subArgs)
+	case "critical-path":
+		err = a.handleCriticalPath(fc, fmtr, subArgs)
 	case "note":
 		err = a.handleNote(fc, fmtr, subArgs)
 	case "remove":
 		err = a.handleRemove(fc, fmtr, subArgs)
+	case "search":
+		err = a.handleSearch(fc, fmtr, subArgs)
+	case "undo":
+		err = a.handleUndo(fc, fmtr, subArgs)
+	case "redo":
+		err = a.handleRedo(fc, fmtr, subArgs)
+	case "journal":
+		err = a.handleJournal(fc, fmtr, subArgs)
+	case "archive":
+		err = a.handleArchive(fc, fmtr, subArgs)
+	case "unarchive":
+		err = a.handleUnarchive(fc, fmtr, subArgs)
+	case "batch":
+		err = a.handleBatch(fc, fmtr, subArgs)
+	case "storage":
+		err = a.handleStorage(fc, fmtr, subArgs)
+	case "lock":
+		err = a.handleLock(fc, fmtr, subArgs)
+	case "config":
+		err = a.handleConfig(fc, fmtr, subArgs)
+	case "upgrade":
+		err = a.handleUpgrade(fc, fmtr, subArgs)
 	case "stats":
-		err = a.handleStats(fc, fmtr)
+		err = a.handleStats(fc, fmtr, subArgs)
 	case "rebuild":
 		err = a.handleRebuild(fc, fmtr)
+	case "watch":
+		err = a.handleWatch(fc, subArgs)
 	default:
+		// Transition commands added by the project's workflow.
+		if _, ok := a.workflowCommand(subcmd); ok {
+			err = a.handleTransition(subcmd, fc, fmtr, subArgs)
+			break
+		}
 		fmt.Fprintf(a.Stderr, "Error: Unknown command '%s'. Run 'tick help' for usage.\n", subcmd)
 		return 1
 	}
@@ -159,13 +245,44 @@ func (a *App) Run(args []string) int {
 	return 0
 }
 
+// commandLine reconstructs the invoking command line for the mutation journal,
+// quoting arguments that contain whitespace or quotes.
+func commandLine(args []string) string {
+	parts := []string{"tick"}
+	for _, arg := range args[1:] {
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'") {
+			arg = strconv.Quote(arg)
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, " ")
+}
+
 // handleInit implements the init subcommand.
-func (a *App) handleInit(fc FormatConfig, fmtr Formatter, _ []string) error {
+// --layout selects the storage layout. With --merge-driver, it also registers the tick merge driver for tasks.jsonl
+// in .gitattributes and the local git config after initializing.
+func (a *App) handleInit(fc FormatConfig, fmtr Formatter, subArgs []string) error {
 	dir, err := a.Getwd()
 	if err != nil {
 		return fmt.Errorf("could not determine working directory: %w", err)
 	}
-	return RunInit(dir, fc, fmtr, a.Stdout)
+	opts, err := parseInitArgs(subArgs)
+	if err != nil {
+		return err
+	}
+	if err := RunInit(dir, opts.layout, fc, fmtr, a.Stdout); err != nil {
+		return err
+	}
+	if !opts.mergeDriver {
+		return nil
+	}
+	if err := registerMergeDriver(dir); err != nil {
+		return err
+	}
+	if !fc.Quiet {
+		fmt.Fprintln(a.Stdout, fmtr.FormatMessage("Registered tick merge driver for .tick/tasks.jsonl"))
+	}
+	return nil
 }
 
 // handleCreate implements the create subcommand.
@@ -187,6 +304,9 @@ func (a *App) handleList(fc FormatConfig, fmtr Formatter, subArgs []string) erro
 	if err != nil {
 		return err
 	}
+	if slices.Contains(subArgs, workspaceFlag) {
+		return RunWorkspaceList(dir, fc, fmtr, filter, a.Stdout)
+	}
 	return RunList(dir, fc, fmtr, filter, a.Stdout)
 }
 
@@ -218,6 +338,9 @@ func (a *App) handleReady(fc FormatConfig, fmtr Formatter, subArgs []string) err
 	if err != nil {
 		return err
 	}
+	if slices.Contains(subArgs, workspaceFlag) {
+		return RunWorkspaceList(dir, fc, fmtr, filter, a.Stdout)
+	}
 	return RunList(dir, fc, fmtr, filter, a.Stdout)
 }
 
@@ -231,15 +354,46 @@ func (a *App) handleBlocked(fc FormatConfig, fmtr Formatter, subArgs []string) e
 	if err != nil {
 		return err
 	}
+	if slices.Contains(subArgs, workspaceFlag) {
+		return RunWorkspaceList(dir, fc, fmtr, filter, a.Stdout)
+	}
+	return RunList(dir, fc, fmtr, filter, a.Stdout)
+}
+
+// handleMine implements the mine subcommand (alias for list --assignee with
+// the TICK_ACTOR environment variable).
+func (a *App) handleMine(fc FormatConfig, fmtr Formatter, subArgs []string) error {
+	var actor string
+	if a.Getenv != nil {
+		actor = task.NormalizeAssignee(a.Getenv("TICK_ACTOR"))
+	}
+	if actor == "" {
+		return fmt.Errorf("TICK_ACTOR is not set; set it to your name to list the tasks assigned to you")
+	}
+	dir, err := a.Getwd()
+	if err != nil {
+		return fmt.Errorf("could not determine working directory: %w", err)
+	}
+	filter, err := parseListFlags(subArgs)
+	if err != nil {
+		return err
+	}
+	filter.Assignee = actor
+	if slices.Contains(subArgs, workspaceFlag) {
+		return RunWorkspaceList(dir, fc, fmtr, filter, a.Stdout)
+	}
 	return RunList(dir, fc, fmtr, filter, a.Stdout)
 }
 
 // handleStats implements the stats subcommand.
-func (a *App) handleStats(fc FormatConfig, fmtr Formatter) error {
+func (a *App) handleStats(fc FormatConfig, fmtr Formatter, subArgs []string) error {
 	dir, err := a.Getwd()
 	if err != nil {
 		return fmt.Errorf("could not determine working directory: %w", err)
 	}
+	if slices.Contains(subArgs, workspaceFlag) {
+		return RunWorkspaceStats(dir, fc, fmtr, a.Stdout)
+	}
 	return RunStats(dir, fc, fmtr, a.Stdout)
 }
 
@@ -325,13 +479,53 @@ func (a *App) handleHelp(args []string) int {
 	}
 	cmd := findCommand(args[0])
 	if cmd == nil {
-		fmt.Fprintf(a.Stderr, "Error: Unknown command '%s'. Run 'tick help' for usage.\n", args[0])
-		return 1
+		def, ok := a.workflowCommand(args[0])
+		if !ok {
+			fmt.Fprintf(a.Stderr, "Error: Unknown command '%s'. Run 'tick help' for usage.\n", args[0])
+			return 1
+		}
+		cmd = workflowCommandInfo(def)
 	}
-	printCommandHelp(a.Stdout, cmd)
+	printCommandHelp(a.Stdout, cmd, a.projectConfig().Types)
 	return 0
 }
 
+// loadProjectConfig loads the configuration of the project in the working
+// directory once per Run, for the dispatcher and the store alike. A missing
+// project or invalid config leaves it unset; opening the store then reports
+// the problem.
+func (a *App) loadProjectConfig() {
+	a.config = nil
+	dir, err := a.Getwd()
+	if err != nil {
+		return
+	}
+	if cfg, err := tick.LoadConfig(dir); err == nil {
+		a.config = &cfg
+	}
+}
+
+// projectConfig returns the configuration of the project in the working
+// directory, or the defaults when there is no project or its config is invalid.
+func (a *App) projectConfig() config.Config {
+	if a.config == nil {
+		return config.Default()
+	}
+	return *a.config
+}
+
+// workflowCommand returns the transition command named name that the project
+// in the working directory adds to its workflow. Built-in tick commands of the
+// same name take precedence and never reach it.
+func (a *App) workflowCommand(name string) (config.CommandDef, bool) {
+	for _, def := range a.projectConfig().Workflow.Commands {
+		if def.Name == name {
+			return def, true
+		}
+	}
+	return config.CommandDef{}, false
+}
+
 // globalFlags holds parsed global CLI flags.
 type globalFlags struct {
 	quiet   bool
@@ -341,6 +535,13 @@ type globalFlags struct {
 	json    bool
 	help    bool
 	version bool
+	// includeArchived makes reads cover archived tasks (--include-archived).
+	includeArchived bool
+	// lockTimeout is how long to wait for the .tick lock (--lock-timeout).
+	// Zero means the store's default.
+	lockTimeout time.Duration
+	// asOf is the past instant reads reconstruct (--as-of). Zero means now.
+	asOf time.Time
 }
 
 // parseArgs separates global flags from the subcommand and its arguments.
@@ -355,7 +556,18 @@ func parseArgs(args []string) (globalFlags, string, []string, error) {
 	var rest []string
 
 	foundCmd := false
-	for _, arg := range args {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if example, ok := valuedGlobalFlags[arg]; ok {
+			if i+1 >= len(args) {
+				return flags, "", nil, fmt.Errorf("%s requires a value (e.g. %s)", arg, example)
+			}
+			i++
+			if err := applyValuedGlobalFlag(&flags, arg, args[i]); err != nil {
+				return flags, "", nil, err
+			}
+			continue
+		}
 		if applyGlobalFlag(&flags, arg) {
 			continue
 		}
@@ -372,31 +584,94 @@ func parseArgs(args []string) (globalFlags, string, []string, error) {
 	return flags, subcmd, rest, nil
 }
 
-// qualifyCommand determines the fully-qualified command name for validation.
-// For two-level commands (dep, note), it peeks at the first positional arg in
-// subArgs to form "dep add", "dep remove", etc. and returns the remaining args
-// after the sub-subcommand. If the sub-subcommand is not a known sub-subcommand,
-// it returns the top-level command and full subArgs (the handler will produce
-// its own error for unknown sub-subcommands).
-func qualifyCommand(subcmd string, subArgs []string) (string, []string) {
-	if subcmd != "dep" && subcmd != "note" {
-		return subcmd, subArgs
+// valuedGlobalFlags lists the global flags that take a value, with an example
+// value for the error when it is missing.
+var valuedGlobalFlags = map[string]string{
+	"--lock-timeout": "30s",
+	"--as-of":        "2026-01-30",
+}
+
+// applyValuedGlobalFlag parses value for the valued global flag arg and applies
+// it to flags.
+func applyValuedGlobalFlag(flags *globalFlags, arg, value string) error {
+	var err error
+	switch arg {
+	case "--lock-timeout":
+		flags.lockTimeout, err = parseLockTimeout(value)
+	case "--as-of":
+		flags.asOf, err = parseAsOf(value, time.Now())
 	}
-	if len(subArgs) == 0 {
-		return subcmd, subArgs
+	return err
+}
+
+// timeLayouts are the timestamp layouts --as-of, due and defer dates accept
+// besides a bare date. Layouts without a zone are read in local time.
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// parseAsOf parses an --as-of value: a timestamp, or a date meaning the end of
+// that day in local time. Timestamps after now, and dates after today, are
+// rejected; today's date reads as now.
+func parseAsOf(v string, now time.Time) (time.Time, error) {
+	if day, err := time.ParseInLocation("2006-01-02", v, time.Local); err == nil {
+		if day.After(now) {
+			return time.Time{}, fmt.Errorf("as-of time '%s' is in the future", v)
+		}
+		if end := day.AddDate(0, 0, 1).Add(-time.Nanosecond); end.Before(now) {
+			return end, nil
+		}
+		return now, nil
 	}
-	sub := subArgs[0]
-	switch sub {
-	case "add", "remove":
-		return subcmd + " " + sub, subArgs[1:]
-	case "tree":
-		if subcmd == "dep" {
-			return subcmd + " " + sub, subArgs[1:]
+	for _, layout := range timeLayouts {
+		at, err := time.ParseInLocation(layout, v, time.Local)
+		if err != nil {
+			continue
 		}
-		return subcmd, subArgs
-	default:
+		if at.After(now) {
+			return time.Time{}, fmt.Errorf("as-of time '%s' is in the future", v)
+		}
+		return at, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid as-of time '%s': use a date such as 2026-01-30 or a timestamp such as 2026-01-30T17:00:00Z", v)
+}
+
+// asOfCommands lists the commands that accept --as-of.
+var asOfCommands = []string{"list", "ready", "blocked", "mine", "stats", "show"}
+
+// parseLockTimeout parses a --lock-timeout or TICK_LOCK_TIMEOUT value.
+func parseLockTimeout(v string) (time.Duration, error) {
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid lock timeout '%s': use a duration such as 30s or 2m", v)
+	}
+	return d, nil
+}
+
+// subcommands lists the sub-subcommands of each two-level command.
+var subcommands = map[string][]string{
+	"dep":     {"add", "remove", "tree"},
+	"note":    {"add", "remove"},
+	"storage": {"convert"},
+	"lock":    {"status"},
+	"config":  {"get", "set", "list"},
+}
+
+// qualifyCommand determines the fully-qualified command name for validation.
+// For two-level commands (dep, note, storage, lock, config), it peeks at the first positional
+// arg in subArgs to form "dep add", "dep remove", etc. and returns the remaining
+// args after the sub-subcommand. If the sub-subcommand is not a known
+// sub-subcommand, it returns the top-level command and full subArgs (the handler
+// will produce its own error for unknown sub-subcommands).
+func qualifyCommand(subcmd string, subArgs []string) (string, []string) {
+	if len(subArgs) == 0 || !slices.Contains(subcommands[subcmd], subArgs[0]) {
 		return subcmd, subArgs
 	}
+	return subcmd + " " + subArgs[0], subArgs[1:]
 }
 
 // applyGlobalFlag checks if arg is a known global flag and applies it to flags.
@@ -417,6 +692,8 @@ func applyGlobalFlag(flags *globalFlags, arg string) bool {
 		flags.help = true
 	case "--version", "-V":
 		flags.version = true
+	case "--include-archived":
+		flags.includeArchived = true
 	default:
 		return false
 	}
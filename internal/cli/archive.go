@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/leeovery/tick/internal/storage"
+)
+
+// archiveOptions holds the parsed flags of the archive command.
+type archiveOptions struct {
+	// olderThan limits archiving to tasks closed at least this long ago. Zero means no limit.
+	olderThan time.Duration
+	dryRun    bool
+}
+
+// parseArchiveArgs parses the archive command's --older-than and --dry-run flags.
+func parseArchiveArgs(args []string) (archiveOptions, error) {
+	var opts archiveOptions
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--dry-run":
+			opts.dryRun = true
+		case "--older-than":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("--older-than requires a value (e.g. 30d)")
+			}
+			i++
+			d, err := parseAge(args[i])
+			if err != nil {
+				return opts, err
+			}
+			opts.olderThan = d
+		}
+	}
+	return opts, nil
+}
+
+// parseAge parses an age given as a number of days ("30d") or weeks ("2w"), or
+// as a Go duration ("36h").
+func parseAge(s string) (time.Duration, error) {
+	invalid := fmt.Errorf("invalid age '%s': use days or weeks, e.g. 30d or 2w", s)
+	var unit time.Duration
+	switch {
+	case strings.HasSuffix(s, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(s, "w"):
+		unit = 7 * 24 * time.Hour
+	default:
+		d, err := time.ParseDuration(s)
+		if err != nil || d <= 0 {
+			return 0, invalid
+		}
+		return d, nil
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n < 1 {
+		return 0, invalid
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// RunArchive executes the archive command: moves done and cancelled tasks (with
+// their fully closed subtrees) from the active tasks into .tick/archive.jsonl.
+// With --dry-run, the tasks that would be archived are listed instead.
+func RunArchive(dir string, fc FormatConfig, fmtr Formatter, opts archiveOptions, stdout io.Writer) error {
+	store, err := openStore(dir, fc)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	var cutoff time.Time
+	if opts.olderThan > 0 {
+		cutoff = time.Now().UTC().Add(-opts.olderThan)
+	}
+
+	result, err := store.Archive(cutoff, opts.dryRun)
+	if err != nil {
+		return err
+	}
+
+	if opts.dryRun {
+		if fc.Quiet {
+			for _, t := range result.Tasks {
+				fmt.Fprintln(stdout, t.ID)
+			}
+			return nil
+		}
+		fmt.Fprintln(stdout, fmtr.FormatTaskList(result.Tasks))
+		return nil
+	}
+
+	if fc.Quiet {
+		return nil
+	}
+	if len(result.Tasks) == 0 {
+		fmt.Fprintln(stdout, fmtr.FormatMessage("No tasks to archive."))
+		return nil
+	}
+	fmt.Fprintln(stdout, fmtr.FormatMessage(archiveMessage("Archived", result)))
+	return nil
+}
+
+// RunUnarchive executes the unarchive command: restores an archived task and its
+// archived subtree to the active tasks.
+func RunUnarchive(dir string, fc FormatConfig, fmtr Formatter, id string, stdout io.Writer) error {
+	store, err := openStore(dir, fc)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	result, err := store.Unarchive(id)
+	if err != nil {
+		return err
+	}
+
+	if !fc.Quiet {
+		fmt.Fprintln(stdout, fmtr.FormatMessage(archiveMessage("Unarchived", result)))
+	}
+	return nil
+}
+
+// archiveMessage builds the confirmation for archive and unarchive, e.g.
+// "Archived 2 tasks: tick-a1b2c3, tick-d4e5f6 (dependencies updated on tick-g7h8i9)".
+func archiveMessage(verb string, result storage.ArchiveResult) string {
+	ids := make([]string, len(result.Tasks))
+	for i, t := range result.Tasks {
+		ids[i] = t.ID
+	}
+	noun := "tasks"
+	if len(ids) == 1 {
+		noun = "task"
+	}
+	msg := fmt.Sprintf("%s %d %s: %s", verb, len(ids), noun, strings.Join(ids, ", "))
+	if len(result.DepsUpdated) > 0 {
+		msg += fmt.Sprintf(" (dependencies updated on %s)", strings.Join(result.DepsUpdated, ", "))
+	}
+	return msg
+}
+
+// handleArchive implements the archive subcommand.
+func (a *App) handleArchive(fc FormatConfig, fmtr Formatter, subArgs []string) error {
+	dir, err := a.Getwd()
+	if err != nil {
+		return fmt.Errorf("could not determine working directory: %w", err)
+	}
+	opts, err := parseArchiveArgs(subArgs)
+	if err != nil {
+		return err
+	}
+	return RunArchive(dir, fc, fmtr, opts, a.Stdout)
+}
+
+// handleUnarchive implements the unarchive subcommand.
+func (a *App) handleUnarchive(fc FormatConfig, fmtr Formatter, subArgs []string) error {
+	dir, err := a.Getwd()
+	if err != nil {
+		return fmt.Errorf("could not determine working directory: %w", err)
+	}
+	if len(subArgs) != 1 {
+		return fmt.Errorf("task ID is required. Usage: tick unarchive <id>")
+	}
+	return RunUnarchive(dir, fc, fmtr, subArgs[0], a.Stdout)
+}
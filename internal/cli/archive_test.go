@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+func TestArchive(t *testing.T) {
+	old := time.Now().UTC().AddDate(0, -3, 0).Truncate(time.Second)
+	recent := time.Now().UTC().AddDate(0, 0, -1).Truncate(time.Second)
+	seed := func() []task.Task {
+		return []task.Task{
+			{ID: "tick-old111", Title: "Old done", Status: task.StatusDone, Priority: 2, Created: old, Updated: old, Closed: &old},
+			{ID: "tick-new111", Title: "Recently done", Status: task.StatusDone, Priority: 2, Created: old, Updated: recent, Closed: &recent},
+			{ID: "tick-opn111", Title: "Still open", Status: task.StatusOpen, Priority: 2, BlockedBy: []string{"tick-old111"}, Created: old, Updated: old},
+		}
+	}
+
+	t.Run("it archives tasks closed before --older-than", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, seed())
+
+		stdout, stderr, code := runTick(t, dir, "archive", "--older-than", "30d")
+		if code != 0 {
+			t.Fatalf("archive failed: %s", stderr)
+		}
+		want := "Archived 1 task: tick-old111 (dependencies updated on tick-opn111)\n"
+		if stdout != want {
+			t.Errorf("stdout = %q, want %q", stdout, want)
+		}
+
+		active := readPersistedTasks(t, tickDir)
+		if len(active) != 2 {
+			t.Fatalf("active tasks = %d, want 2", len(active))
+		}
+		if _, err := os.Stat(filepath.Join(tickDir, "archive.jsonl")); err != nil {
+			t.Errorf("archive.jsonl not written: %v", err)
+		}
+
+		stdout, _, _ = runTick(t, dir, "list", "--quiet")
+		if strings.Contains(stdout, "tick-old111") {
+			t.Errorf("archived task still listed: %q", stdout)
+		}
+	})
+
+	t.Run("it lists candidates without archiving on --dry-run", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, seed())
+
+		stdout, _, code := runTick(t, dir, "archive", "--dry-run", "--quiet")
+		if code != 0 || stdout != "tick-old111\ntick-new111\n" {
+			t.Errorf("exit = %d, stdout = %q", code, stdout)
+		}
+		if len(readPersistedTasks(t, tickDir)) != 3 {
+			t.Error("dry run modified tasks")
+		}
+	})
+
+	t.Run("it reports when there is nothing to archive", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+
+		stdout, _, code := runTick(t, dir, "archive")
+		if code != 0 || stdout != "No tasks to archive.\n" {
+			t.Errorf("exit = %d, stdout = %q", code, stdout)
+		}
+	})
+
+	t.Run("it rejects an invalid --older-than", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+
+		_, stderr, code := runTick(t, dir, "archive", "--older-than", "soon")
+		if code != 1 || !strings.Contains(stderr, "invalid age 'soon'") {
+			t.Errorf("exit = %d, stderr = %q", code, stderr)
+		}
+	})
+
+	t.Run("it shows archived tasks with --include-archived", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, seed())
+		runTick(t, dir, "archive")
+
+		stdout, _, _ := runTick(t, dir, "list", "--quiet", "--include-archived")
+		if !strings.Contains(stdout, "tick-old111") || !strings.Contains(stdout, "tick-opn111") {
+			t.Errorf("list --include-archived = %q", stdout)
+		}
+		if _, stderr, code := runTick(t, dir, "show", "old111", "--include-archived"); code != 0 {
+			t.Errorf("show --include-archived failed: %s", stderr)
+		}
+		if _, _, code := runTick(t, dir, "show", "tick-old111"); code != 1 {
+			t.Error("show without --include-archived should not find archived task")
+		}
+		stdout, _, _ = runTick(t, dir, "stats", "--json", "--include-archived")
+		if !strings.Contains(stdout, `"total": 3`) {
+			t.Errorf("stats --include-archived = %q", stdout)
+		}
+
+		_, stderr, code := runTick(t, dir, "start", "tick-opn111", "--include-archived")
+		if code != 1 || !strings.Contains(stderr, "cannot modify tasks while archived tasks are included") {
+			t.Errorf("exit = %d, stderr = %q", code, stderr)
+		}
+	})
+
+	t.Run("it unarchives a task", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, seed())
+		runTick(t, dir, "archive")
+
+		stdout, stderr, code := runTick(t, dir, "unarchive", "old111")
+		if code != 0 {
+			t.Fatalf("unarchive failed: %s", stderr)
+		}
+		if stdout != "Unarchived 1 task: tick-old111\n" {
+			t.Errorf("stdout = %q", stdout)
+		}
+		if len(readPersistedTasks(t, tickDir)) != 2 {
+			t.Errorf("active tasks = %d, want 2", len(readPersistedTasks(t, tickDir)))
+		}
+	})
+
+	t.Run("it requires an ID for unarchive", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+
+		_, stderr, code := runTick(t, dir, "unarchive")
+		if code != 1 || stderr != "Error: task ID is required. Usage: tick unarchive <id>\n" {
+			t.Errorf("exit = %d, stderr = %q", code, stderr)
+		}
+	})
+}
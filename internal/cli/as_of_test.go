@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+func TestAsOf(t *testing.T) {
+	created := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	started := time.Date(2026, 1, 6, 9, 0, 0, 0, time.UTC)
+	finished := time.Date(2026, 1, 9, 9, 0, 0, 0, time.UTC)
+	seed := func() []task.Task {
+		return []task.Task{
+			{
+				ID: "tick-aaa111", Title: "Schema", Status: task.StatusDone, Priority: 2,
+				Created: created, Updated: finished, Closed: &finished,
+				Transitions: []task.TransitionRecord{
+					{From: task.StatusOpen, To: task.StatusInProgress, At: started},
+					{From: task.StatusInProgress, To: task.StatusDone, At: finished},
+				},
+			},
+			{
+				ID: "tick-bbb222", Title: "Endpoint", Status: task.StatusOpen, Priority: 2,
+				BlockedBy: []string{"tick-aaa111"}, Created: created, Updated: created,
+			},
+			{
+				ID: "tick-ccc333", Title: "Added later", Status: task.StatusOpen, Priority: 2,
+				Created: finished, Updated: finished,
+			},
+		}
+	}
+
+	t.Run("it lists tasks as they stood at a past timestamp", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, seed())
+
+		stdout, stderr, code := runTick(t, dir, "list", "--json", "--as-of", "2026-01-07T00:00:00Z")
+		if code != 0 {
+			t.Fatalf("exit = %d, stderr = %q", code, stderr)
+		}
+		if !strings.Contains(stdout, `"status": "in_progress"`) || strings.Contains(stdout, "tick-ccc333") {
+			t.Errorf("list --as-of = %s", stdout)
+		}
+
+		stdout, _, _ = runTick(t, dir, "blocked", "--quiet", "--as-of", "2026-01-07T00:00:00Z")
+		if stdout != "tick-bbb222\n" {
+			t.Errorf("blocked --as-of = %q, want tick-bbb222", stdout)
+		}
+		stdout, _, _ = runTick(t, dir, "ready", "--quiet")
+		if !strings.Contains(stdout, "tick-bbb222") {
+			t.Errorf("ready without --as-of = %q, want current view", stdout)
+		}
+	})
+
+	t.Run("it reads a bare date as the end of that day", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, seed())
+
+		stdout, stderr, code := runTick(t, dir, "show", "aaa111", "--as-of", "2026-01-08")
+		if code != 0 {
+			t.Fatalf("exit = %d, stderr = %q", code, stderr)
+		}
+		if !strings.Contains(stdout, "in_progress") {
+			t.Errorf("show --as-of = %s", stdout)
+		}
+
+		stdout, _, _ = runTick(t, dir, "stats", "--json", "--as-of", "2026-01-05")
+		if !strings.Contains(stdout, `"total": 2`) || !strings.Contains(stdout, `"open": 2`) {
+			t.Errorf("stats --as-of = %s", stdout)
+		}
+	})
+
+	t.Run("it rejects commands that do not support --as-of", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, seed())
+
+		_, stderr, code := runTick(t, dir, "start", "bbb222", "--as-of", "2026-01-07")
+		if code != 1 || !strings.Contains(stderr, "--as-of is only supported by list, ready, blocked, mine, stats, show") {
+			t.Errorf("exit = %d, stderr = %q", code, stderr)
+		}
+		if got := readPersistedTasks(t, tickDir)[1].Status; got != task.StatusOpen {
+			t.Errorf("status = %s, want open", got)
+		}
+	})
+
+	t.Run("it rejects invalid and future times", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, seed())
+
+		for _, tc := range []struct {
+			args []string
+			want string
+		}{
+			{[]string{"list", "--as-of", "last friday"}, "invalid as-of time 'last friday'"},
+			{[]string{"list", "--as-of", "2999-01-01"}, "as-of time '2999-01-01' is in the future"},
+			{[]string{"list", "--as-of"}, "--as-of requires a value"},
+		} {
+			_, stderr, code := runTick(t, dir, tc.args...)
+			if code != 1 || !strings.Contains(stderr, tc.want) {
+				t.Errorf("%v: exit = %d, stderr = %q, want %q", tc.args, code, stderr, tc.want)
+			}
+		}
+	})
+}
+
+func TestParseAsOf(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2026-03-01T08:30:00Z", time.Date(2026, 3, 1, 8, 30, 0, 0, time.UTC)},
+		{"2026-03-01 08:30", time.Date(2026, 3, 1, 8, 30, 0, 0, time.Local)},
+		{"2026-03-01", time.Date(2026, 3, 1, 23, 59, 59, 999999999, time.Local)},
+		{"2026-03-10", now},
+	}
+	for _, tc := range tests {
+		got, err := parseAsOf(tc.in, now)
+		if err != nil || !got.Equal(tc.want) {
+			t.Errorf("parseAsOf(%q) = %v, %v; want %v", tc.in, got, err, tc.want)
+		}
+	}
+
+	if _, err := parseAsOf("2026-03-10T12:00:01", now); err == nil {
+		t.Error("parseAsOf accepted a time after now")
+	}
+}
@@ -0,0 +1,203 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// runMine runs tick mine with TICK_ACTOR set to actor.
+func runMine(t *testing.T, dir, actor string, args ...string) (stdout string, stderr string, exitCode int) {
+	t.Helper()
+	var stdoutBuf, stderrBuf bytes.Buffer
+	app := &App{
+		Stdout: &stdoutBuf,
+		Stderr: &stderrBuf,
+		Getwd:  func() (string, error) { return dir, nil },
+		Getenv: func(key string) string {
+			if key == "TICK_ACTOR" {
+				return actor
+			}
+			return ""
+		},
+		IsTTY: true,
+	}
+	code := app.Run(append([]string{"tick", "mine"}, args...))
+	return stdoutBuf.String(), stderrBuf.String(), code
+}
+
+func TestAssignee(t *testing.T) {
+	now := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+	lease := now.Add(time.Hour)
+
+	seed := func() []task.Task {
+		return []task.Task{
+			{ID: "tick-ann111", Title: "Ann's task", Status: task.StatusOpen, Priority: 2, Assignee: "ann", Created: now, Updated: now},
+			{ID: "tick-bob111", Title: "Bob's task", Status: task.StatusInProgress, Priority: 2, Assignee: "bob",
+				Created: now.Add(time.Second), Updated: now.Add(time.Second)},
+			{ID: "tick-none11", Title: "Nobody's task", Status: task.StatusOpen, Priority: 2,
+				Created: now.Add(2 * time.Second), Updated: now.Add(2 * time.Second)},
+			{ID: "tick-done11", Title: "Ann's old task", Status: task.StatusDone, Priority: 2, Assignee: "ann",
+				Created: now.Add(3 * time.Second), Updated: now.Add(3 * time.Second), Closed: &now},
+		}
+	}
+
+	t.Run("it sets the assignee on create", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+
+		_, stderr, exitCode := runCreate(t, dir, "Add login", "--assignee", " ann ")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if got := readPersistedTasks(t, tickDir)[0].Assignee; got != "ann" {
+			t.Errorf("assignee = %q, want %q", got, "ann")
+		}
+	})
+
+	t.Run("it reassigns and drops the claim lease on update", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusInProgress, Priority: 2,
+				Assignee: "ann", LeaseExpires: &lease, Created: now, Updated: now},
+		})
+
+		_, stderr, exitCode := runUpdate(t, dir, "tick-aaa111", "--assignee", "bob")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		got := readPersistedTasks(t, tickDir)[0]
+		if got.Assignee != "bob" || got.LeaseExpires != nil {
+			t.Errorf("assignee = %q, lease = %v; want bob and no lease", got.Assignee, got.LeaseExpires)
+		}
+	})
+
+	t.Run("it unassigns on update", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, seed())
+
+		_, stderr, exitCode := runUpdate(t, dir, "tick-ann111", "--unassign")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if got := readPersistedTasks(t, tickDir)[0].Assignee; got != "" {
+			t.Errorf("assignee = %q, want empty", got)
+		}
+	})
+
+	t.Run("it rejects --assignee with --unassign", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, seed())
+
+		_, stderr, exitCode := runUpdate(t, dir, "tick-ann111", "--assignee", "bob", "--unassign")
+		if exitCode != 1 {
+			t.Fatalf("exit code = %d, want 1", exitCode)
+		}
+		if !strings.Contains(stderr, "mutually exclusive") {
+			t.Errorf("stderr = %q, want mutually exclusive error", stderr)
+		}
+	})
+
+	t.Run("it filters list by assignee and by no assignee", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, seed())
+
+		stdout, stderr, exitCode := runList(t, dir, "--assignee", "ann")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if !strings.Contains(stdout, "tick-ann111") || !strings.Contains(stdout, "tick-done11") || strings.Contains(stdout, "tick-bob111") {
+			t.Errorf("--assignee ann should list only ann's tasks, got %q", stdout)
+		}
+
+		stdout, _, _ = runList(t, dir, "--unassigned")
+		if !strings.Contains(stdout, "tick-none11") || strings.Contains(stdout, "tick-ann111") {
+			t.Errorf("--unassigned should list only tick-none11, got %q", stdout)
+		}
+
+		_, stderr, exitCode = runList(t, dir, "--assignee", "ann", "--unassigned")
+		if exitCode != 1 || !strings.Contains(stderr, "mutually exclusive") {
+			t.Errorf("exit = %d, stderr = %q; want mutually exclusive error", exitCode, stderr)
+		}
+	})
+
+	t.Run("it lists the tasks assigned to TICK_ACTOR with mine", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, seed())
+
+		stdout, stderr, exitCode := runMine(t, dir, "ann", "--status", "open")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if !strings.Contains(stdout, "tick-ann111") || strings.Contains(stdout, "tick-done11") || strings.Contains(stdout, "tick-bob111") {
+			t.Errorf("mine --status open should list only tick-ann111, got %q", stdout)
+		}
+	})
+
+	t.Run("it fails mine when TICK_ACTOR is not set", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, seed())
+
+		_, stderr, exitCode := runMine(t, dir, "")
+		if exitCode != 1 {
+			t.Fatalf("exit code = %d, want 1", exitCode)
+		}
+		if !strings.Contains(stderr, "TICK_ACTOR is not set") {
+			t.Errorf("stderr = %q, want TICK_ACTOR error", stderr)
+		}
+	})
+
+	t.Run("it shows an assignee column in list output only when a task is assigned", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, seed())
+
+		stdout, _, _ := runList(t, dir)
+		if !strings.Contains(stdout, "ASSIGNEE") || !strings.Contains(stdout, "ann") {
+			t.Errorf("pretty list should have an assignee column, got %q", stdout)
+		}
+
+		stdout, _, _ = runList(t, dir, "--unassigned")
+		if strings.Contains(stdout, "ASSIGNEE") {
+			t.Errorf("pretty list of unassigned tasks should have no assignee column, got %q", stdout)
+		}
+
+		stdout, _, _ = runTick(t, dir, "--toon", "list", "--assignee", "bob")
+		if !strings.Contains(stdout, "{id,title,status,priority,type,assignee}:\n  tick-bob111,Bob's task,in_progress,2,\"\",bob") {
+			t.Errorf("toon list should have an assignee column, got %q", stdout)
+		}
+
+		stdout, _, _ = runTick(t, dir, "--json", "list", "--assignee", "bob")
+		var items []struct {
+			Assignee string `json:"assignee"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &items); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+		}
+		if len(items) != 1 || items[0].Assignee != "bob" {
+			t.Errorf("json list = %+v, want bob's task", items)
+		}
+	})
+
+	t.Run("it counts live tasks by assignee in stats", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, seed())
+
+		stdout, stderr, exitCode := runTick(t, dir, "--json", "stats")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		var stats struct {
+			ByAssignee []struct {
+				Assignee string `json:"assignee"`
+				Count    int    `json:"count"`
+			} `json:"by_assignee"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &stats); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+		}
+		if len(stats.ByAssignee) != 2 || stats.ByAssignee[0].Assignee != "ann" || stats.ByAssignee[0].Count != 1 ||
+			stats.ByAssignee[1].Assignee != "bob" || stats.ByAssignee[1].Count != 1 {
+			t.Errorf("by_assignee = %+v, want ann 1 and bob 1", stats.ByAssignee)
+		}
+
+		stdout, _, _ = runTick(t, dir, "stats")
+		if !strings.Contains(stdout, "Assignee:") {
+			t.Errorf("pretty stats should have an assignee group, got %q", stdout)
+		}
+	})
+}
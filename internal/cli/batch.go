@@ -0,0 +1,328 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/leeovery/tick/tick"
+	toon "github.com/toon-format/toon-go"
+)
+
+// batchOpInput is one operation as read by the batch command, in JSONL or TOON.
+type batchOpInput struct {
+	Op          string            `json:"op"`
+	As          string            `json:"as"`
+	ID          string            `json:"id"`
+	Title       *string           `json:"title"`
+	Description *string           `json:"description"`
+	Priority    *int              `json:"priority"`
+	Type        *string           `json:"type"`
+	Tags        *stringList       `json:"tags"`
+	Refs        *stringList       `json:"refs"`
+	Fields      map[string]string `json:"fields"`
+	ClearFields stringList        `json:"clear_fields"`
+	Assignee    *string           `json:"assignee"`
+	Due         *string           `json:"due"`
+	Estimate    *float64          `json:"estimate"`
+	Recur       *string           `json:"recur"`
+	Parent      *string           `json:"parent"`
+	BlockedBy   stringList        `json:"blocked_by"`
+	Blocks      stringList        `json:"blocks"`
+	Action      string            `json:"action"`
+	Text        string            `json:"text"`
+}
+
+// stringList is a list field that also accepts a single comma-separated string.
+type stringList []string
+
+// UnmarshalJSON accepts either a JSON array of strings or a single string,
+// which is split on commas.
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*l = nil
+		for part := range strings.SplitSeq(s, ",") {
+			if trimmed := strings.TrimSpace(part); trimmed != "" {
+				*l = append(*l, trimmed)
+			}
+		}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return errors.New("must be a string or a list of strings")
+	}
+	*l = list
+	return nil
+}
+
+// batchOpFields lists the fields each batch operation accepts besides "op".
+var batchOpFields = map[string][]string{
+	"create":     {"as", "title", "description", "priority", "type", "tags", "refs", "fields", "assignee", "due", "estimate", "recur", "parent", "blocked_by", "blocks"},
+	"update":     {"id", "title", "description", "priority", "type", "tags", "refs", "fields", "clear_fields", "assignee", "due", "estimate", "recur", "parent", "blocks"},
+	"transition": {"id", "action"},
+	"dep add":    {"id", "blocked_by"},
+	"note add":   {"id", "text"},
+}
+
+// parseBatchInput reads batch operations from r. Input starting with "{" is
+// JSONL, one operation object per line; anything else is TOON, either an
+// "ops" list or a root list of operation objects. Empty TOON values count as
+// absent, so clearing a field needs JSONL.
+func parseBatchInput(r io.Reader) ([]tick.BatchOp, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read operations: %w", err)
+	}
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, errors.New("no operations on stdin. Usage: tick batch < ops.jsonl")
+	}
+	if trimmed[0] == '{' {
+		return parseBatchJSONL(data)
+	}
+	return parseBatchTOON(data)
+}
+
+// parseBatchJSONL parses one operation per non-blank line.
+func parseBatchJSONL(data []byte) ([]tick.BatchOp, error) {
+	var ops []tick.BatchOp
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(line, &fields); err != nil {
+			return nil, fmt.Errorf("line %d: invalid JSON: %w", lineNum, err)
+		}
+		op, err := decodeBatchOp(line, slices.Collect(maps.Keys(fields)))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		ops = append(ops, op)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read operations: %w", err)
+	}
+	return ops, nil
+}
+
+// parseBatchTOON parses a TOON document holding a list of operation objects.
+func parseBatchTOON(data []byte) ([]tick.BatchOp, error) {
+	decoded, err := toon.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TOON: %w", err)
+	}
+	if obj, ok := decoded.(map[string]any); ok && len(obj) == 1 {
+		decoded = obj["ops"]
+	}
+	items, ok := decoded.([]any)
+	if !ok {
+		return nil, errors.New(`TOON input must be an "ops" list of operations`)
+	}
+
+	ops := make([]tick.BatchOp, 0, len(items))
+	for i, item := range items {
+		fields, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("op %d: must be an object", i+1)
+		}
+		maps.DeleteFunc(fields, func(_ string, v any) bool { return v == nil || v == "" })
+		raw, err := json.Marshal(fields)
+		if err != nil {
+			return nil, fmt.Errorf("op %d: %w", i+1, err)
+		}
+		op, err := decodeBatchOp(raw, slices.Collect(maps.Keys(fields)))
+		if err != nil {
+			return nil, fmt.Errorf("op %d: %w", i+1, err)
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+// decodeBatchOp decodes one operation object, rejecting fields its op does not
+// take, and converts it to a tick.BatchOp.
+func decodeBatchOp(raw []byte, keys []string) (tick.BatchOp, error) {
+	var in batchOpInput
+	if err := json.Unmarshal(raw, &in); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return tick.BatchOp{}, fmt.Errorf("invalid %s", typeErr.Field)
+		}
+		return tick.BatchOp{}, err
+	}
+
+	allowed, ok := batchOpFields[in.Op]
+	if !ok {
+		if in.Op == "" {
+			return tick.BatchOp{}, errors.New(`"op" is required`)
+		}
+		return tick.BatchOp{}, fmt.Errorf("unknown op %q (use create, update, transition, dep add or note add)", in.Op)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		if key != "op" && !slices.Contains(allowed, key) {
+			return tick.BatchOp{}, fmt.Errorf("unknown field %q for %s", key, in.Op)
+		}
+	}
+
+	// An empty due clears it on update, like the other fields.
+	var due *time.Time
+	if in.Due != nil {
+		due = &time.Time{}
+		if *in.Due != "" {
+			parsed, err := parseDue(*in.Due, time.Now())
+			if err != nil {
+				return tick.BatchOp{}, err
+			}
+			due = &parsed
+		}
+	}
+
+	op := tick.BatchOp{
+		Kind:   tick.BatchKind(in.Op),
+		Ref:    in.As,
+		ID:     in.ID,
+		Action: in.Action,
+		Text:   in.Text,
+	}
+	switch op.Kind {
+	case tick.BatchCreate:
+		op.Create = tick.CreateOptions{
+			Title:       deref(in.Title),
+			Description: deref(in.Description),
+			Priority:    in.Priority,
+			Type:        deref(in.Type),
+			Fields:      in.Fields,
+			Assignee:    deref(in.Assignee),
+			Due:         due,
+			Recur:       deref(in.Recur),
+			Parent:      deref(in.Parent),
+			BlockedBy:   in.BlockedBy,
+			Blocks:      in.Blocks,
+		}
+		if in.Tags != nil {
+			op.Create.Tags = *in.Tags
+		}
+		if in.Refs != nil {
+			op.Create.Refs = *in.Refs
+		}
+		if in.Estimate != nil {
+			op.Create.Estimate = *in.Estimate
+		}
+	case tick.BatchUpdate:
+		op.Update = tick.UpdateOptions{
+			Title:       in.Title,
+			Description: in.Description,
+			Priority:    in.Priority,
+			Type:        in.Type,
+			Tags:        (*[]string)(in.Tags),
+			Refs:        (*[]string)(in.Refs),
+			Fields:      in.Fields,
+			ClearFields: in.ClearFields,
+			Assignee:    in.Assignee,
+			Due:         due,
+			Estimate:    in.Estimate,
+			Recur:       in.Recur,
+			Parent:      in.Parent,
+			Blocks:      in.Blocks,
+		}
+	case tick.BatchAddDep:
+		if len(in.BlockedBy) > 1 {
+			return tick.BatchOp{}, errors.New("dep add takes a single blocked_by")
+		}
+		op.BlockedBy = strings.Join(in.BlockedBy, ",")
+	}
+	return op, nil
+}
+
+// deref returns the string s points to, or "" for nil.
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// RunBatch executes the batch command: applies ops as a single all-or-nothing
+// change and outputs each op's task ID with the status changes the batch made.
+// In quiet mode it prints only the task IDs, one line per op.
+func RunBatch(dir string, fc FormatConfig, fmtr Formatter, ops []tick.BatchOp, stdout io.Writer) error {
+	p, err := openProject(dir, fc)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+
+	result, err := p.Batch(ops)
+	if err != nil {
+		return err
+	}
+	outputBatchResult(result, fc, fmtr, stdout)
+	return nil
+}
+
+// outputBatchResult outputs each op's task ID with the status changes the
+// batch made, or in quiet mode only the task IDs, one line per op.
+func outputBatchResult(result tick.BatchResult, fc FormatConfig, fmtr Formatter, stdout io.Writer) {
+	summary := BatchSummary{Rows: make([]BatchRow, len(result.Ops))}
+	for i, r := range result.Ops {
+		summary.Rows[i] = BatchRow{Op: string(r.Kind), ID: r.TaskID, Ref: r.Ref}
+		for _, cr := range []*CascadeResult{r.ParentReopened, r.Transition, r.ParentCompleted} {
+			if cr == nil {
+				continue
+			}
+			summary.StatusChanges = append(summary.StatusChanges, BatchStatusChange{
+				ID: cr.TaskID, From: cr.OldStatus, To: cr.NewStatus, Auto: cr != r.Transition,
+			})
+			for _, c := range cr.Cascaded {
+				summary.StatusChanges = append(summary.StatusChanges, BatchStatusChange{
+					ID: c.ID, From: c.OldStatus, To: c.NewStatus, Auto: true,
+				})
+			}
+		}
+	}
+
+	if fc.Quiet {
+		for _, row := range summary.Rows {
+			fmt.Fprintln(stdout, row.ID)
+		}
+		return
+	}
+
+	fmt.Fprintln(stdout, fmtr.FormatBatch(summary))
+}
+
+// handleBatch implements the batch subcommand, reading operations from stdin.
+func (a *App) handleBatch(fc FormatConfig, fmtr Formatter, subArgs []string) error {
+	dir, err := a.Getwd()
+	if err != nil {
+		return fmt.Errorf("could not determine working directory: %w", err)
+	}
+	if len(subArgs) > 0 {
+		return fmt.Errorf("batch reads operations from stdin and takes no arguments. Usage: tick batch < ops.jsonl")
+	}
+	if a.Stdin == nil {
+		return errors.New("no operations on stdin. Usage: tick batch < ops.jsonl")
+	}
+
+	ops, err := parseBatchInput(a.Stdin)
+	if err != nil {
+		return err
+	}
+	return RunBatch(dir, fc, fmtr, ops, a.Stdout)
+}
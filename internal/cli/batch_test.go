@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// runTickWithStdin runs tick like runTick, feeding stdin to the command.
+func runTickWithStdin(t *testing.T, dir string, stdin string, args ...string) (stdout string, stderr string, exitCode int) {
+	t.Helper()
+	var stdoutBuf, stderrBuf bytes.Buffer
+	app := &App{
+		Stdout: &stdoutBuf,
+		Stderr: &stderrBuf,
+		Stdin:  strings.NewReader(stdin),
+		Getwd:  func() (string, error) { return dir, nil },
+		IsTTY:  true,
+	}
+	code := app.Run(append([]string{"tick"}, args...))
+	return stdoutBuf.String(), stderrBuf.String(), code
+}
+
+func TestBatch(t *testing.T) {
+	now := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+
+	t.Run("it applies JSONL operations with references in one change", func(t *testing.T) {
+		tasks := []task.Task{
+			{ID: "tick-aaa111", Title: "Existing", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		}
+		dir, tickDir := setupTickProjectWithTasks(t, tasks)
+
+		ops := `{"op":"create","as":"epic","title":"Auth"}
+{"op":"create","as":"login","title":"Login","parent":"$epic","blocked_by":["$epic_dep"]}
+`
+		// An undefined reference fails validation before anything is written.
+		_, stderr, code := runTickWithStdin(t, dir, ops, "batch")
+		if code != 1 || !strings.Contains(stderr, "op 2 (create): $epic_dep does not refer to a task created by an earlier op") {
+			t.Fatalf("code = %d, stderr = %q", code, stderr)
+		}
+
+		ops = `{"op":"create","as":"epic","title":"Auth"}
+
+{"op":"create","as":"login","title":"Login","parent":"$epic","priority":1,"tags":"api,auth"}
+{"op":"dep add","id":"$login","blocked_by":"aaa111"}
+{"op":"note add","id":"$login","text":"Use sessions"}
+{"op":"transition","id":"$login","action":"start"}
+`
+		stdout, stderr, code := runTickWithStdin(t, dir, ops, "--json", "batch")
+		if code != 0 {
+			t.Fatalf("batch failed: %s", stderr)
+		}
+		var out struct {
+			Ops []struct {
+				Op  string `json:"op"`
+				ID  string `json:"id"`
+				Ref string `json:"ref"`
+			} `json:"ops"`
+			StatusChanges []struct {
+				ID   string `json:"id"`
+				From string `json:"from"`
+				To   string `json:"to"`
+				Auto bool   `json:"auto"`
+			} `json:"status_changes"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &out); err != nil {
+			t.Fatalf("invalid JSON %q: %v", stdout, err)
+		}
+		if len(out.Ops) != 5 || out.Ops[0].Ref != "epic" || out.Ops[4].ID != out.Ops[1].ID {
+			t.Fatalf("ops = %+v", out.Ops)
+		}
+		epic, login := out.Ops[0].ID, out.Ops[1].ID
+		if len(out.StatusChanges) != 2 || out.StatusChanges[0].ID != login || out.StatusChanges[1].ID != epic || !out.StatusChanges[1].Auto {
+			t.Errorf("status changes = %+v, want login started and epic auto-started", out.StatusChanges)
+		}
+
+		persisted := readPersistedTasks(t, tickDir)
+		if len(persisted) != 3 {
+			t.Fatalf("persisted %d tasks, want 3", len(persisted))
+		}
+		for _, tk := range persisted {
+			if tk.ID == login && (tk.Parent != epic || len(tk.BlockedBy) != 1 || len(tk.Tags) != 2 || len(tk.Notes) != 1) {
+				t.Errorf("login = %+v", tk)
+			}
+		}
+
+		// The whole batch is one journal entry.
+		if _, stderr, code := runTick(t, dir, "undo"); code != 0 {
+			t.Fatalf("undo failed: %s", stderr)
+		}
+		if persisted := readPersistedTasks(t, tickDir); len(persisted) != 1 {
+			t.Errorf("after undo: %d tasks, want 1", len(persisted))
+		}
+	})
+
+	t.Run("it accepts TOON input and prints IDs in quiet mode", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+
+		ops := "ops[3]:\n" +
+			"  - op: create\n    as: a\n    title: First\n" +
+			"  - op: create\n    title: Second\n    blocked_by: $a\n" +
+			"  - op: update\n    id: $a\n    priority: 0\n"
+		stdout, stderr, code := runTickWithStdin(t, dir, ops, "--quiet", "batch")
+		if code != 0 {
+			t.Fatalf("batch failed: %s", stderr)
+		}
+		lines := strings.Split(strings.TrimSpace(stdout), "\n")
+		if len(lines) != 3 || lines[0] != lines[2] || !strings.HasPrefix(lines[1], "tick-") {
+			t.Errorf("stdout = %q, want one ID per op", stdout)
+		}
+	})
+
+	t.Run("it shows operations and status changes in pretty format", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+
+		ops := `{"op":"create","as":"a","title":"First"}
+{"op":"transition","id":"$a","action":"start"}`
+		stdout, stderr, code := runTickWithStdin(t, dir, ops, "--pretty", "batch")
+		if code != 0 {
+			t.Fatalf("batch failed: %s", stderr)
+		}
+		if !strings.HasPrefix(stdout, "Applied 2 operations:\n  create      tick-") || !strings.Contains(stdout, "  $a\n  transition  tick-") {
+			t.Errorf("stdout = %q", stdout)
+		}
+		if !strings.Contains(stdout, "Status changes:\n  tick-") || !strings.Contains(stdout, ": open → in_progress\n") {
+			t.Errorf("stdout = %q, want the start listed", stdout)
+		}
+	})
+
+	t.Run("it rejects malformed operations with their line", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+
+		tests := []struct {
+			name, ops, want string
+		}{
+			{"empty input", "  \n", "no operations on stdin"},
+			{"invalid JSON", "{\"op\":\"create\"\n", "line 1: invalid JSON"},
+			{"unknown op", `{"op":"delete","id":"abc"}`, `line 1: unknown op "delete"`},
+			{"field of another op", `{"op":"transition","id":"abc","title":"x"}`, `line 1: unknown field "title" for transition`},
+			{"missing op", `{"title":"x"}`, `line 1: "op" is required`},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, stderr, code := runTickWithStdin(t, dir, tt.ops, "batch")
+				if code != 1 || !strings.Contains(stderr, tt.want) {
+					t.Errorf("code = %d, stderr = %q, want %q", code, stderr, tt.want)
+				}
+			})
+		}
+	})
+}
@@ -266,16 +266,79 @@ func TestBlocked(t *testing.T) {
 
 		// Check header (dynamic column widths: ID=14, STATUS=8, PRI=5)
 		header := lines[0]
-		if header != "ID            STATUS  PRI  TYPE  TITLE" {
-			t.Errorf("header = %q, want %q", header, "ID            STATUS  PRI  TYPE  TITLE")
+		if header != "ID            STATUS  PRI  TYPE  REASON      TITLE" {
+			t.Errorf("header = %q, want %q", header, "ID            STATUS  PRI  TYPE  REASON      TITLE")
 		}
 
 		// Check aligned rows
-		if lines[1] != "tick-aaa111   open    1    -     Setup Sanctum" {
-			t.Errorf("row 1 = %q, want %q", lines[1], "tick-aaa111   open    1    -     Setup Sanctum")
+		if lines[1] != "tick-aaa111   open    1    -     blocked_by  Setup Sanctum" {
+			t.Errorf("row 1 = %q, want %q", lines[1], "tick-aaa111   open    1    -     blocked_by  Setup Sanctum")
 		}
-		if lines[2] != "tick-bbb222   open    2    -     Login endpoint" {
-			t.Errorf("row 2 = %q, want %q", lines[2], "tick-bbb222   open    2    -     Login endpoint")
+		if lines[2] != "tick-bbb222   open    2    -     blocked_by  Login endpoint" {
+			t.Errorf("row 2 = %q, want %q", lines[2], "tick-bbb222   open    2    -     blocked_by  Login endpoint")
+		}
+	})
+
+	t.Run("it reports why each task is blocked", func(t *testing.T) {
+		future := time.Date(2099, 1, 1, 9, 0, 0, 0, time.UTC)
+		tasks := []task.Task{
+			{ID: "tick-blk000", Title: "Blocker", Status: task.StatusOpen, Priority: 0, Created: now, Updated: now},
+			{ID: "tick-dep111", Title: "Dependent", Status: task.StatusOpen, Priority: 1, BlockedBy: []string{"tick-blk000"}, Created: now.Add(time.Second), Updated: now.Add(time.Second)},
+			{ID: "tick-kid111", Title: "Child", Status: task.StatusOpen, Priority: 1, Parent: "tick-dep111", Created: now.Add(2 * time.Second), Updated: now.Add(2 * time.Second)},
+			{ID: "tick-par111", Title: "Parent", Status: task.StatusOpen, Priority: 2, Created: now.Add(3 * time.Second), Updated: now.Add(3 * time.Second)},
+			{ID: "tick-kid222", Title: "Open child", Status: task.StatusOpen, Priority: 0, Parent: "tick-par111", Created: now.Add(4 * time.Second), Updated: now.Add(4 * time.Second)},
+			{ID: "tick-lat111", Title: "Later", Status: task.StatusOpen, Priority: 3, DeferUntil: &future, Created: now.Add(5 * time.Second), Updated: now.Add(5 * time.Second)},
+		}
+		dir, _ := setupTickProjectWithTasks(t, tasks)
+
+		want := map[string]string{
+			"tick-dep111": "blocked_by",
+			"tick-kid111": "ancestor",
+			"tick-par111": "children",
+			"tick-lat111": "deferred",
+		}
+
+		stdout, _, exitCode := runBlocked(t, dir)
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0", exitCode)
+		}
+		lines := strings.Split(strings.TrimRight(stdout, "\n"), "\n")
+		if len(lines) != 5 || !strings.Contains(lines[0], "REASON") {
+			t.Fatalf("expected a REASON column and 4 blocked tasks, got %q", stdout)
+		}
+		col := strings.Index(lines[0], "REASON")
+		for _, line := range lines[1:] {
+			id, got := strings.Fields(line)[0], strings.Fields(line[col:])[0]
+			if got != want[id] {
+				t.Errorf("%s reason = %q, want %q", id, got, want[id])
+			}
+		}
+
+		stdout, _, _ = runTick(t, dir, "--toon", "blocked")
+		if !strings.Contains(stdout, ",reason}:") || !strings.Contains(stdout, "tick-lat111,Later,open,3,\"\",\"2099-01-01T09:00:00Z\",deferred") {
+			t.Errorf("toon blocked should include a reason column, got %q", stdout)
+		}
+
+		stdout, _, _ = runTick(t, dir, "--json", "blocked")
+		var items []struct {
+			ID     string `json:"id"`
+			Reason string `json:"reason"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &items); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+		}
+		if len(items) != 4 {
+			t.Fatalf("json blocked returned %d tasks, want 4", len(items))
+		}
+		for _, item := range items {
+			if item.Reason != want[item.ID] {
+				t.Errorf("json %s reason = %q, want %q", item.ID, item.Reason, want[item.ID])
+			}
+		}
+
+		stdout, _, _ = runTick(t, dir, "--json", "list")
+		if strings.Contains(stdout, `"reason"`) {
+			t.Errorf("list should not carry blocked reasons, got %s", stdout)
 		}
 	})
 
@@ -3,9 +3,6 @@ package cli
 import (
 	"encoding/json"
 	"testing"
-	"time"
-
-	"github.com/leeovery/tick/internal/task"
 )
 
 func TestToonFormatterCascadeTransition(t *testing.T) {
@@ -232,36 +229,6 @@ func TestJSONFormatterCascadeTransition(t *testing.T) {
 	})
 }
 
-func TestBuildCascadeResult(t *testing.T) {
-	now := time.Now()
-
-	t.Run("it populates ParentID on cascade entries", func(t *testing.T) {
-		parent := task.Task{ID: "tick-parent1", Title: "Parent", Status: task.StatusCancelled, Created: now, Updated: now}
-		child1 := task.Task{ID: "tick-child1", Title: "Login", Status: task.StatusCancelled, Parent: "tick-parent1", Created: now, Updated: now}
-		child2 := task.Task{ID: "tick-child2", Title: "Signup", Status: task.StatusCancelled, Parent: "tick-parent1", Created: now, Updated: now}
-		tasks := []task.Task{parent, child1, child2}
-
-		cascades := []task.CascadeChange{
-			{Task: &tasks[1], Action: "cancel", OldStatus: task.StatusInProgress, NewStatus: task.StatusCancelled},
-			{Task: &tasks[2], Action: "cancel", OldStatus: task.StatusOpen, NewStatus: task.StatusCancelled},
-		}
-
-		result := task.TransitionResult{OldStatus: task.StatusInProgress, NewStatus: task.StatusCancelled}
-		cr := buildCascadeResult("tick-parent1", "Parent", result, cascades, tasks)
-
-		if len(cr.Cascaded) != 2 {
-			t.Fatalf("cascaded length = %d, want 2", len(cr.Cascaded))
-		}
-		if cr.Cascaded[0].ParentID != "tick-parent1" {
-			t.Errorf("cascaded[0].ParentID = %q, want %q", cr.Cascaded[0].ParentID, "tick-parent1")
-		}
-		if cr.Cascaded[1].ParentID != "tick-parent1" {
-			t.Errorf("cascaded[1].ParentID = %q, want %q", cr.Cascaded[1].ParentID, "tick-parent1")
-		}
-	})
-
-}
-
 func TestAllFormattersCascadeEmptyArrays(t *testing.T) {
 	t.Run("all formatters handle empty cascaded", func(t *testing.T) {
 		result := CascadeResult{
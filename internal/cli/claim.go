@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+	"github.com/leeovery/tick/tick"
+)
+
+// leaseOptions holds the --agent and --lease flags shared by claim and heartbeat.
+type leaseOptions struct {
+	agent string
+	lease time.Duration
+}
+
+// parseLeaseArgs extracts --agent and --lease from args, returning them with the
+// remaining arguments in order. The lease defaults to tick.DefaultLease.
+func parseLeaseArgs(args []string) (leaseOptions, []string, error) {
+	opts := leaseOptions{lease: tick.DefaultLease}
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--agent":
+			if i+1 >= len(args) {
+				return opts, nil, fmt.Errorf("--agent requires a value")
+			}
+			i++
+			opts.agent = args[i]
+		case "--lease":
+			if i+1 >= len(args) {
+				return opts, nil, fmt.Errorf("--lease requires a value (e.g. 30m)")
+			}
+			i++
+			d, err := time.ParseDuration(args[i])
+			if err != nil || d <= 0 {
+				return opts, nil, fmt.Errorf("invalid lease '%s': use a duration such as 30m or 2h", args[i])
+			}
+			opts.lease = d
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+	return opts, rest, nil
+}
+
+// RunClaim executes the claim command: atomically starts the top ready task
+// matching the filter and assigns it to the agent under a lease, then outputs
+// the task's full detail (or just its ID in quiet mode). When no task can be
+// claimed it prints a message, or nothing in quiet mode.
+func RunClaim(dir string, fc FormatConfig, fmtr Formatter, filter ListFilter, opts leaseOptions, stdout io.Writer) error {
+	p, err := openProject(dir, fc)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+
+	result, err := p.Claim(filter, opts.agent, opts.lease)
+	if errors.Is(err, tick.ErrNothingToClaim) {
+		if !fc.Quiet {
+			fmt.Fprintln(stdout, fmtr.FormatMessage("No ready tasks to claim."))
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return outputMutationResult(p, result.Task.ID, fc, fmtr, stdout)
+}
+
+// RunHeartbeat executes the heartbeat command: extends the lease on a claimed
+// task and confirms the new expiry.
+func RunHeartbeat(dir string, fc FormatConfig, fmtr Formatter, id string, opts leaseOptions, stdout io.Writer) error {
+	p, err := openProject(dir, fc)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+
+	t, err := p.Heartbeat(id, opts.agent, opts.lease)
+	if err != nil {
+		return err
+	}
+
+	if !fc.Quiet {
+		msg := fmt.Sprintf("Lease on %s extended until %s", t.ID, task.FormatTimestamp(*t.LeaseExpires))
+		fmt.Fprintln(stdout, fmtr.FormatMessage(msg))
+	}
+	return nil
+}
+
+// handleClaim implements the claim subcommand.
+func (a *App) handleClaim(fc FormatConfig, fmtr Formatter, subArgs []string) error {
+	dir, err := a.Getwd()
+	if err != nil {
+		return fmt.Errorf("could not determine working directory: %w", err)
+	}
+	opts, rest, err := parseLeaseArgs(subArgs)
+	if err != nil {
+		return err
+	}
+	if opts.agent == "" {
+		return fmt.Errorf("--agent is required. Usage: tick claim --agent <name> [flags]")
+	}
+	filter, err := parseListFlags(rest)
+	if err != nil {
+		return err
+	}
+	return RunClaim(dir, fc, fmtr, filter, opts, a.Stdout)
+}
+
+// handleHeartbeat implements the heartbeat subcommand.
+func (a *App) handleHeartbeat(fc FormatConfig, fmtr Formatter, subArgs []string) error {
+	dir, err := a.Getwd()
+	if err != nil {
+		return fmt.Errorf("could not determine working directory: %w", err)
+	}
+	opts, rest, err := parseLeaseArgs(subArgs)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		return fmt.Errorf("task ID is required. Usage: tick heartbeat <id> [flags]")
+	}
+	return RunHeartbeat(dir, fc, fmtr, rest[0], opts, a.Stdout)
+}
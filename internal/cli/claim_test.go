@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+func TestClaim(t *testing.T) {
+	now := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+
+	t.Run("it claims the top ready task and shows it with its assignee", func(t *testing.T) {
+		tasks := []task.Task{
+			{ID: "tick-aaa111", Title: "Low", Status: task.StatusOpen, Priority: 3, Created: now, Updated: now},
+			{ID: "tick-bbb222", Title: "High", Status: task.StatusOpen, Priority: 1, Created: now, Updated: now},
+		}
+		dir, tickDir := setupTickProjectWithTasks(t, tasks)
+
+		stdout, stderr, code := runTick(t, dir, "--pretty", "claim", "--agent", "agent-1", "--lease", "10m")
+		if code != 0 {
+			t.Fatalf("claim failed: %s", stderr)
+		}
+		if !strings.Contains(stdout, "ID:       tick-bbb222") || !strings.Contains(stdout, "Assignee: agent-1") {
+			t.Errorf("stdout = %q, want tick-bbb222 assigned to agent-1", stdout)
+		}
+		if !strings.Contains(stdout, "Status:   in_progress") || !strings.Contains(stdout, "Lease:    until ") {
+			t.Errorf("stdout = %q, want in_progress with a lease", stdout)
+		}
+
+		persisted := readPersistedTasks(t, tickDir)
+		var claimed task.Task
+		for _, tk := range persisted {
+			if tk.ID == "tick-bbb222" {
+				claimed = tk
+			}
+		}
+		if claimed.Assignee != "agent-1" || claimed.LeaseExpires == nil {
+			t.Errorf("persisted task = %+v, want assignee and lease", claimed)
+		}
+	})
+
+	t.Run("it prints only the ID in quiet mode and nothing when no task is ready", func(t *testing.T) {
+		tasks := []task.Task{
+			{ID: "tick-aaa111", Title: "Only", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		}
+		dir, _ := setupTickProjectWithTasks(t, tasks)
+
+		stdout, stderr, code := runTick(t, dir, "--quiet", "claim", "--agent", "agent-1")
+		if code != 0 || stdout != "tick-aaa111\n" {
+			t.Errorf("claim = %q (code %d, stderr %q), want the ID", stdout, code, stderr)
+		}
+
+		stdout, _, code = runTick(t, dir, "--quiet", "claim", "--agent", "agent-2")
+		if code != 0 || stdout != "" {
+			t.Errorf("empty claim = %q (code %d), want no output", stdout, code)
+		}
+		stdout, _, _ = runTick(t, dir, "--pretty", "claim", "--agent", "agent-2")
+		if !strings.Contains(stdout, "No ready tasks to claim.") {
+			t.Errorf("stdout = %q, want no-task message", stdout)
+		}
+	})
+
+	t.Run("it reclaims a task whose lease expired", func(t *testing.T) {
+		expired := now
+		tasks := []task.Task{
+			{ID: "tick-aaa111", Title: "Stale", Status: task.StatusInProgress, Priority: 2, Assignee: "agent-1", LeaseExpires: &expired, Created: now, Updated: now},
+		}
+		dir, tickDir := setupTickProjectWithTasks(t, tasks)
+
+		stdout, stderr, code := runTick(t, dir, "--quiet", "claim", "--agent", "agent-2")
+		if code != 0 || stdout != "tick-aaa111\n" {
+			t.Fatalf("claim = %q (code %d, stderr %q), want the stale task", stdout, code, stderr)
+		}
+		persisted := readPersistedTasks(t, tickDir)
+		if persisted[0].Assignee != "agent-2" {
+			t.Errorf("assignee = %q, want agent-2", persisted[0].Assignee)
+		}
+		if len(persisted[0].Transitions) != 2 || !persisted[0].Transitions[0].Auto {
+			t.Errorf("transitions = %+v, want auto release then start", persisted[0].Transitions)
+		}
+	})
+
+	t.Run("it requires an agent", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+		_, stderr, code := runTick(t, dir, "claim")
+		if code != 1 || !strings.Contains(stderr, "--agent is required") {
+			t.Errorf("code = %d, stderr = %q", code, stderr)
+		}
+	})
+}
+
+func TestHeartbeat(t *testing.T) {
+	now := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+
+	t.Run("it extends the lease on a claimed task", func(t *testing.T) {
+		tasks := []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		}
+		dir, tickDir := setupTickProjectWithTasks(t, tasks)
+		if _, stderr, code := runTick(t, dir, "claim", "--agent", "agent-1", "--lease", "1m"); code != 0 {
+			t.Fatalf("claim failed: %s", stderr)
+		}
+
+		stdout, stderr, code := runTick(t, dir, "--pretty", "heartbeat", "aaa111", "--agent", "agent-1", "--lease", "2h")
+		if code != 0 {
+			t.Fatalf("heartbeat failed: %s", stderr)
+		}
+		if !strings.Contains(stdout, "Lease on tick-aaa111 extended until ") {
+			t.Errorf("stdout = %q", stdout)
+		}
+		persisted := readPersistedTasks(t, tickDir)
+		if persisted[0].LeaseExpires == nil || time.Until(*persisted[0].LeaseExpires) < time.Hour {
+			t.Errorf("LeaseExpires = %v, want about 2h from now", persisted[0].LeaseExpires)
+		}
+	})
+
+	t.Run("it fails for an unclaimed task", func(t *testing.T) {
+		tasks := []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		}
+		dir, _ := setupTickProjectWithTasks(t, tasks)
+		_, stderr, code := runTick(t, dir, "heartbeat", "tick-aaa111")
+		if code != 1 || !strings.Contains(stderr, "has no active lease") {
+			t.Errorf("code = %d, stderr = %q", code, stderr)
+		}
+	})
+}
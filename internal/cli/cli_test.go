@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/leeovery/tick/internal/storage"
 )
 
 func TestInit(t *testing.T) {
@@ -12,7 +14,7 @@ func TestInit(t *testing.T) {
 		dir := t.TempDir()
 		var stdout bytes.Buffer
 
-		err := RunInit(dir, FormatConfig{}, &PrettyFormatter{}, &stdout)
+		err := RunInit(dir, storage.LayoutJSONL, FormatConfig{}, &PrettyFormatter{}, &stdout)
 		if err != nil {
 			t.Fatalf("RunInit returned error: %v", err)
 		}
@@ -31,7 +33,7 @@ func TestInit(t *testing.T) {
 		dir := t.TempDir()
 		var stdout bytes.Buffer
 
-		err := RunInit(dir, FormatConfig{}, &PrettyFormatter{}, &stdout)
+		err := RunInit(dir, storage.LayoutJSONL, FormatConfig{}, &PrettyFormatter{}, &stdout)
 		if err != nil {
 			t.Fatalf("RunInit returned error: %v", err)
 		}
@@ -53,7 +55,7 @@ func TestInit(t *testing.T) {
 		dir := t.TempDir()
 		var stdout bytes.Buffer
 
-		err := RunInit(dir, FormatConfig{}, &PrettyFormatter{}, &stdout)
+		err := RunInit(dir, storage.LayoutJSONL, FormatConfig{}, &PrettyFormatter{}, &stdout)
 		if err != nil {
 			t.Fatalf("RunInit returned error: %v", err)
 		}
@@ -72,7 +74,7 @@ func TestInit(t *testing.T) {
 		dir := t.TempDir()
 		var stdout bytes.Buffer
 
-		err := RunInit(dir, FormatConfig{}, &PrettyFormatter{}, &stdout)
+		err := RunInit(dir, storage.LayoutJSONL, FormatConfig{}, &PrettyFormatter{}, &stdout)
 		if err != nil {
 			t.Fatalf("RunInit returned error: %v", err)
 		}
@@ -88,7 +90,7 @@ func TestInit(t *testing.T) {
 		dir := t.TempDir()
 		var stdout bytes.Buffer
 
-		err := RunInit(dir, FormatConfig{Quiet: true}, &PrettyFormatter{}, &stdout)
+		err := RunInit(dir, storage.LayoutJSONL, FormatConfig{Quiet: true}, &PrettyFormatter{}, &stdout)
 		if err != nil {
 			t.Fatalf("RunInit returned error: %v", err)
 		}
@@ -106,7 +108,7 @@ func TestInit(t *testing.T) {
 		}
 
 		var stdout bytes.Buffer
-		err := RunInit(dir, FormatConfig{}, &PrettyFormatter{}, &stdout)
+		err := RunInit(dir, storage.LayoutJSONL, FormatConfig{}, &PrettyFormatter{}, &stdout)
 		if err == nil {
 			t.Fatal("expected error when .tick/ already exists, got nil")
 		}
@@ -318,6 +320,16 @@ func TestParseArgs(t *testing.T) {
 					}
 				},
 			},
+			{
+				"--include-archived",
+				[]string{"--include-archived", "init"},
+				func(t *testing.T, f globalFlags) {
+					t.Helper()
+					if !f.includeArchived {
+						t.Error("includeArchived should be true")
+					}
+				},
+			},
 			{
 				"--pretty",
 				[]string{"--pretty", "init"},
@@ -409,44 +421,6 @@ func TestParseArgsGlobalFlagsAfterSubcommand(t *testing.T) {
 	})
 }
 
-func TestDiscoverTickDir(t *testing.T) {
-	t.Run("it discovers .tick/ directory by walking up from cwd", func(t *testing.T) {
-		root := t.TempDir()
-		tickDir := filepath.Join(root, ".tick")
-		if err := os.Mkdir(tickDir, 0755); err != nil {
-			t.Fatalf("failed to create .tick/: %v", err)
-		}
-
-		// Create a nested subdirectory
-		nested := filepath.Join(root, "sub", "deep")
-		if err := os.MkdirAll(nested, 0755); err != nil {
-			t.Fatalf("failed to create nested dir: %v", err)
-		}
-
-		found, err := DiscoverTickDir(nested)
-		if err != nil {
-			t.Fatalf("DiscoverTickDir returned error: %v", err)
-		}
-
-		absTickDir, _ := filepath.Abs(tickDir)
-		if found != absTickDir {
-			t.Errorf("found = %q, want %q", found, absTickDir)
-		}
-	})
-
-	t.Run("it errors when no .tick/ directory found (not a tick project)", func(t *testing.T) {
-		dir := t.TempDir()
-		_, err := DiscoverTickDir(dir)
-		if err == nil {
-			t.Fatal("expected error when no .tick/ found, got nil")
-		}
-		expected := "not a tick project (no .tick directory found)"
-		if err.Error() != expected {
-			t.Errorf("error = %q, want %q", err.Error(), expected)
-		}
-	})
-}
-
 func TestTTYDetection(t *testing.T) {
 	t.Run("it detects TTY vs non-TTY on stdout", func(t *testing.T) {
 		// A pipe (created by os.Pipe) is definitely not a TTY.
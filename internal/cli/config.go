@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/leeovery/tick/internal/config"
+	"github.com/leeovery/tick/tick"
+)
+
+// RunConfigGet executes the config get command: prints the value of one
+// setting from .tick/config.yaml, or its default, as plain text whatever the
+// output format, so scripts can read it directly. List values are
+// comma-separated.
+func RunConfigGet(dir string, key string, stdout io.Writer) error {
+	k, err := config.LookupKey(key)
+	if err != nil {
+		return err
+	}
+	cfg, err := tick.LoadConfig(dir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stdout, k.Get(cfg))
+	return nil
+}
+
+// RunConfigSet executes the config set command: changes one setting in
+// .tick/config.yaml, creating the file if needed. List values are given
+// comma-separated. The change is refused if it leaves the configuration invalid.
+func RunConfigSet(dir string, fc FormatConfig, fmtr Formatter, key, value string, stdout io.Writer) error {
+	tickDir, err := tick.DiscoverTickDir(dir)
+	if err != nil {
+		return err
+	}
+	if err := config.Set(tickDir, key, value); err != nil {
+		return err
+	}
+
+	if fc.Quiet {
+		return nil
+	}
+
+	cfg, err := config.Load(tickDir)
+	if err != nil {
+		return err
+	}
+	k, err := config.LookupKey(key)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, fmtr.FormatMessage(fmt.Sprintf("Set %s = %s", key, k.Get(cfg))))
+	return nil
+}
+
+// RunConfigList executes the config list command: shows every setting with its
+// effective value, marking those left at their default. In quiet mode it prints
+// only the keys, one per line.
+func RunConfigList(dir string, fc FormatConfig, fmtr Formatter, stdout io.Writer) error {
+	cfg, err := tick.LoadConfig(dir)
+	if err != nil {
+		return err
+	}
+
+	if fc.Quiet {
+		for _, k := range config.Keys {
+			fmt.Fprintln(stdout, k.Name)
+		}
+		return nil
+	}
+
+	defaults := config.Default()
+	entries := make([]ConfigEntry, len(config.Keys))
+	for i, k := range config.Keys {
+		entries[i] = ConfigEntry{
+			Key:     k.Name,
+			Value:   k.Get(cfg),
+			Default: k.Get(cfg) == k.Get(defaults),
+		}
+	}
+	fmt.Fprintln(stdout, fmtr.FormatConfig(entries))
+	return nil
+}
+
+// handleConfig implements the config subcommand.
+func (a *App) handleConfig(fc FormatConfig, fmtr Formatter, subArgs []string) error {
+	dir, err := a.Getwd()
+	if err != nil {
+		return fmt.Errorf("could not determine working directory: %w", err)
+	}
+
+	if len(subArgs) == 0 {
+		return fmt.Errorf("sub-command required. Usage: tick config <get|set|list>")
+	}
+
+	switch subArgs[0] {
+	case "get":
+		if len(subArgs) < 2 {
+			return fmt.Errorf("key is required. Usage: tick config get <key>")
+		}
+		return RunConfigGet(dir, subArgs[1], a.Stdout)
+	case "set":
+		if len(subArgs) < 3 {
+			return fmt.Errorf("key and value are required. Usage: tick config set <key> <value>")
+		}
+		return RunConfigSet(dir, fc, fmtr, subArgs[1], subArgs[2], a.Stdout)
+	case "list":
+		return RunConfigList(dir, fc, fmtr, a.Stdout)
+	default:
+		return fmt.Errorf("unknown config sub-command '%s'. Usage: tick config <get|set|list>", subArgs[0])
+	}
+}
@@ -0,0 +1,243 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+func TestConfig(t *testing.T) {
+	t.Run("it gets defaults when there is no config file", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+
+		stdout, stderr, code := runTick(t, dir, "--json", "config", "get", "types")
+		if code != 0 {
+			t.Fatalf("config get failed: %s", stderr)
+		}
+		if stdout != "bug,feature,task,chore\n" {
+			t.Errorf("stdout = %q, want the default types", stdout)
+		}
+	})
+
+	t.Run("it sets a value and reports it", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+
+		stdout, stderr, code := runTick(t, dir, "--pretty", "config", "set", "lock_timeout", "120s")
+		if code != 0 {
+			t.Fatalf("config set failed: %s", stderr)
+		}
+		if stdout != "Set lock_timeout = 2m0s\n" {
+			t.Errorf("stdout = %q", stdout)
+		}
+		data, err := os.ReadFile(filepath.Join(tickDir, "config.yaml"))
+		if err != nil {
+			t.Fatalf("config.yaml not written: %v", err)
+		}
+		if string(data) != "lock_timeout: 2m0s\n" {
+			t.Errorf("config.yaml = %q", data)
+		}
+
+		stdout, _, _ = runTick(t, dir, "config", "get", "lock_timeout")
+		if stdout != "2m0s\n" {
+			t.Errorf("config get = %q, want 2m0s", stdout)
+		}
+	})
+
+	t.Run("it prints nothing on set with --quiet", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+
+		stdout, _, code := runTick(t, dir, "--quiet", "config", "set", "create.priority", "1")
+		if code != 0 || stdout != "" {
+			t.Errorf("code = %d, stdout = %q; want 0 and no output", code, stdout)
+		}
+	})
+
+	t.Run("it lists every setting and marks defaults", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+		if _, stderr, code := runTick(t, dir, "config", "set", "id_prefix", "api-"); code != 0 {
+			t.Fatalf("config set failed: %s", stderr)
+		}
+
+		stdout, stderr, code := runTick(t, dir, "--json", "config", "list")
+		if code != 0 {
+			t.Fatalf("config list failed: %s", stderr)
+		}
+		var entries []struct {
+			Key     string `json:"key"`
+			Value   string `json:"value"`
+			Default bool   `json:"default"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &entries); err != nil {
+			t.Fatalf("invalid JSON %q: %v", stdout, err)
+		}
+		if len(entries) != 8 {
+			t.Fatalf("got %d entries, want 8", len(entries))
+		}
+		for _, e := range entries {
+			switch e.Key {
+			case "id_prefix":
+				if e.Value != "api-" || e.Default {
+					t.Errorf("id_prefix = %+v, want api- and not default", e)
+				}
+			case "create.priority":
+				if e.Value != "2" || !e.Default {
+					t.Errorf("create.priority = %+v, want default 2", e)
+				}
+			}
+		}
+
+		stdout, _, _ = runTick(t, dir, "--pretty", "config", "list")
+		if !strings.Contains(stdout, "id_prefix          api-\n") || !strings.Contains(stdout, "(default)") {
+			t.Errorf("pretty list = %q", stdout)
+		}
+	})
+
+	t.Run("it rejects unknown keys and invalid values", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+
+		_, stderr, code := runTick(t, dir, "config", "get", "colour")
+		if code != 1 || !strings.Contains(stderr, "unknown config key 'colour'") {
+			t.Errorf("code = %d, stderr = %q", code, stderr)
+		}
+		_, stderr, code = runTick(t, dir, "config", "set", "create.type", "epic")
+		if code != 1 || !strings.Contains(stderr, `create.type: invalid type "epic"`) {
+			t.Errorf("code = %d, stderr = %q", code, stderr)
+		}
+		_, stderr, code = runTick(t, dir, "config", "set", "create.priority")
+		if code != 1 || !strings.Contains(stderr, "key and value are required") {
+			t.Errorf("code = %d, stderr = %q", code, stderr)
+		}
+	})
+
+	t.Run("it applies create defaults and custom types", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+		for _, kv := range [][2]string{{"types", "bug,spike"}, {"create.type", "spike"}, {"create.priority", "1"}, {"create.tags", "backend"}} {
+			if _, stderr, code := runTick(t, dir, "config", "set", kv[0], kv[1]); code != 0 {
+				t.Fatalf("config set %s failed: %s", kv[0], stderr)
+			}
+		}
+
+		if _, stderr, code := runTick(t, dir, "create", "Investigate"); code != 0 {
+			t.Fatalf("create failed: %s", stderr)
+		}
+		if _, stderr, code := runTick(t, dir, "create", "Crash", "--type", "bug", "--priority", "0", "--tags", "ui"); code != 0 {
+			t.Fatalf("create failed: %s", stderr)
+		}
+		_, stderr, code := runTick(t, dir, "create", "Tidy", "--type", "chore")
+		if code != 1 || !strings.Contains(stderr, `invalid type "chore": must be one of bug, spike`) {
+			t.Errorf("create --type chore: code = %d, stderr = %q", code, stderr)
+		}
+
+		tasks := readPersistedTasks(t, tickDir)
+		if len(tasks) != 2 {
+			t.Fatalf("got %d tasks, want 2", len(tasks))
+		}
+		if tasks[0].Type != "spike" || tasks[0].Priority != 1 || strings.Join(tasks[0].Tags, ",") != "backend" {
+			t.Errorf("defaulted task = %+v", tasks[0])
+		}
+		if tasks[1].Type != "bug" || tasks[1].Priority != 0 || strings.Join(tasks[1].Tags, ",") != "ui" {
+			t.Errorf("explicit task = %+v", tasks[1])
+		}
+
+		stdout, _, _ := runTick(t, dir, "--quiet", "list", "--type", "spike")
+		if stdout != tasks[0].ID+"\n" {
+			t.Errorf("list --type spike = %q, want %s", stdout, tasks[0].ID)
+		}
+	})
+
+	t.Run("it defaults the priority of new tasks to their type's priority", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+		if err := os.WriteFile(filepath.Join(tickDir, "config.yaml"), []byte("types:\n  - name: incident\n    priority: 0\n  - story\n"), 0644); err != nil {
+			t.Fatalf("failed to write config.yaml: %v", err)
+		}
+
+		for _, args := range [][]string{
+			{"create", "Outage", "--type", "incident"},
+			{"create", "Minor outage", "--type", "incident", "--priority", "3"},
+			{"create", "Login page", "--type", "story"},
+		} {
+			if _, stderr, code := runTick(t, dir, args...); code != 0 {
+				t.Fatalf("%v failed: %s", args, stderr)
+			}
+		}
+
+		tasks := readPersistedTasks(t, tickDir)
+		for i, want := range []int{0, 3, 2} {
+			if tasks[i].Priority != want {
+				t.Errorf("task %q priority = %d, want %d", tasks[i].Title, tasks[i].Priority, want)
+			}
+		}
+	})
+
+	t.Run("it enforces configured tag limits", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+		if _, stderr, code := runTick(t, dir, "config", "set", "tags.max_per_task", "1"); code != 0 {
+			t.Fatalf("config set failed: %s", stderr)
+		}
+
+		_, stderr, code := runTick(t, dir, "create", "Tagged", "--tags", "ui,api")
+		if code != 1 || !strings.Contains(stderr, "too many tags: 2 exceeds maximum of 1") {
+			t.Errorf("code = %d, stderr = %q", code, stderr)
+		}
+	})
+
+	t.Run("it gives new tasks the configured ID prefix and still resolves old IDs", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+		if _, stderr, code := runTick(t, dir, "create", "Old"); code != 0 {
+			t.Fatalf("create failed: %s", stderr)
+		}
+		if _, stderr, code := runTick(t, dir, "config", "set", "id_prefix", "api-"); code != 0 {
+			t.Fatalf("config set failed: %s", stderr)
+		}
+		if _, stderr, code := runTick(t, dir, "create", "New"); code != 0 {
+			t.Fatalf("create failed: %s", stderr)
+		}
+
+		tasks := readPersistedTasks(t, tickDir)
+		if !strings.HasPrefix(tasks[0].ID, "tick-") {
+			t.Errorf("old ID = %q, want tick- prefix", tasks[0].ID)
+		}
+		if !regexp.MustCompile(`^api-[0-9a-f]{6}$`).MatchString(tasks[1].ID) {
+			t.Errorf("new ID = %q, want api-{6 hex}", tasks[1].ID)
+		}
+
+		for _, tk := range tasks {
+			_, hex := task.SplitIDInput(tk.ID)
+			for _, input := range []string{tk.ID, hex, strings.ToUpper(tk.ID)} {
+				stdout, stderr, code := runTick(t, dir, "--quiet", "show", input)
+				if code != 0 || !strings.Contains(stdout, tk.ID) {
+					t.Errorf("show %s: code = %d, stdout = %q, stderr = %q", input, code, stdout, stderr)
+				}
+			}
+		}
+
+		_, stderr, code := runTick(t, dir, "show", "tick-"+strings.TrimPrefix(tasks[1].ID, "api-"))
+		if code != 1 || !strings.Contains(stderr, "not found") {
+			t.Errorf("show with the wrong prefix: code = %d, stderr = %q", code, stderr)
+		}
+	})
+
+	t.Run("it refuses to run commands while the config file is invalid", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+		if err := os.WriteFile(filepath.Join(tickDir, "config.yaml"), []byte("types: []\n"), 0644); err != nil {
+			t.Fatalf("failed to write config.yaml: %v", err)
+		}
+
+		_, stderr, code := runTick(t, dir, "list")
+		if code != 1 || !strings.Contains(stderr, "invalid .tick/config.yaml: types must list at least one type") {
+			t.Errorf("code = %d, stderr = %q", code, stderr)
+		}
+
+		if _, stderr, code := runTick(t, dir, "config", "set", "types", "bug"); code != 0 {
+			t.Fatalf("config set should repair the file: %s", stderr)
+		}
+		if _, stderr, code := runTick(t, dir, "list"); code != 0 {
+			t.Errorf("list after repair failed: %s", stderr)
+		}
+	})
+}
@@ -3,17 +3,20 @@ package cli
 import (
 	"fmt"
 	"io"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/leeovery/tick/internal/task"
+	"github.com/leeovery/tick/tick"
 )
 
-// createOpts holds parsed options for the create command.
+// createOpts holds parsed options for the create command. priority is nil
+// unless --priority is given, leaving the project default to apply.
 type createOpts struct {
 	title       string
-	priority    int
+	priority    *int
 	description string
 	blockedBy   []string
 	blocks      []string
@@ -24,16 +27,30 @@ type createOpts struct {
 	hasTags     bool
 	refs        []string
 	hasRefs     bool
+	fields      map[string]string
+	assignee    string
+	due         *time.Time
+	estimate    float64
+	recur       string
+	// template and vars instantiate a template instead of creating one task;
+	// flags lists the flags given, which a template rejects except --var and
+	// --parent.
+	template string
+	vars     map[string]string
+	flags    []string
 }
 
 // parseCreateArgs parses the subcommand arguments for `tick create`.
 // It extracts the title (first positional arg) and command-specific flags.
 func parseCreateArgs(args []string) (createOpts, error) {
-	opts := createOpts{priority: 2}
+	var opts createOpts
 
 	i := 0
 	for i < len(args) {
 		arg := args[i]
+		if strings.HasPrefix(arg, "--") {
+			opts.flags = append(opts.flags, arg)
+		}
 		switch arg {
 		case "--priority":
 			i++
@@ -44,7 +61,7 @@ func parseCreateArgs(args []string) (createOpts, error) {
 			if err != nil {
 				return opts, fmt.Errorf("--priority must be an integer, got %q", args[i])
 			}
-			opts.priority = p
+			opts.priority = &p
 		case "--description":
 			i++
 			if i >= len(args) {
@@ -90,6 +107,69 @@ func parseCreateArgs(args []string) (createOpts, error) {
 			}
 			opts.refs = strings.Split(args[i], ",")
 			opts.hasRefs = true
+		case "--assignee":
+			i++
+			if i >= len(args) {
+				return opts, fmt.Errorf("--assignee requires a value")
+			}
+			opts.assignee = args[i]
+		case "--due":
+			i++
+			if i >= len(args) {
+				return opts, fmt.Errorf("--due requires a value")
+			}
+			due, err := parseDue(args[i], time.Now())
+			if err != nil {
+				return opts, err
+			}
+			opts.due = &due
+		case "--estimate":
+			i++
+			if i >= len(args) {
+				return opts, fmt.Errorf("--estimate requires a value")
+			}
+			estimate, err := task.ParseEstimate(args[i])
+			if err != nil {
+				return opts, err
+			}
+			opts.estimate = estimate
+		case "--recur":
+			i++
+			if i >= len(args) {
+				return opts, fmt.Errorf("--recur requires a value")
+			}
+			opts.recur = args[i]
+		case "--field":
+			i++
+			if i >= len(args) {
+				return opts, fmt.Errorf("--field requires a value")
+			}
+			var err error
+			if opts.fields, err = addFieldFlag(opts.fields, args[i]); err != nil {
+				return opts, err
+			}
+		case "--template":
+			i++
+			if i >= len(args) {
+				return opts, fmt.Errorf("--template requires a value")
+			}
+			opts.template = strings.TrimSpace(args[i])
+			if opts.template == "" {
+				return opts, fmt.Errorf("--template cannot be empty")
+			}
+		case "--var":
+			i++
+			if i >= len(args) {
+				return opts, fmt.Errorf("--var requires a value")
+			}
+			name, value, ok := strings.Cut(args[i], "=")
+			if !ok || strings.TrimSpace(name) == "" {
+				return opts, fmt.Errorf("invalid --var %q: use name=value", args[i])
+			}
+			if opts.vars == nil {
+				opts.vars = map[string]string{}
+			}
+			opts.vars[strings.TrimSpace(name)] = value
 		default:
 			// Positional argument: title (first one wins)
 			if opts.title == "" {
@@ -101,27 +181,35 @@ func parseCreateArgs(args []string) (createOpts, error) {
 	return opts, nil
 }
 
-// RunCreate executes the create command: validates inputs, generates an ID,
-// persists via the storage engine, and outputs the created task via the Formatter.
+// RunCreate executes the create command: validates flags, creates the task via
+// tick.Project, and outputs the created task via the Formatter.
 func RunCreate(dir string, fc FormatConfig, fmtr Formatter, args []string, stdout io.Writer) error {
 	opts, err := parseCreateArgs(args)
 	if err != nil {
 		return err
 	}
 
+	if opts.template != "" {
+		return runCreateFromTemplate(dir, fc, fmtr, opts, stdout)
+	}
+	if opts.vars != nil {
+		return fmt.Errorf("--var requires --template")
+	}
+
 	// Validate title presence.
 	if opts.title == "" {
 		return fmt.Errorf("title is required. Usage: tick create \"<title>\" [options]")
 	}
 
-	trimmedTitle := task.TrimTitle(opts.title)
-	if err := task.ValidateTitle(trimmedTitle); err != nil {
+	if err := task.ValidateTitle(task.TrimTitle(opts.title)); err != nil {
 		return err
 	}
 
-	// Validate priority.
-	if err := task.ValidatePriority(opts.priority); err != nil {
-		return err
+	// Validate priority if provided.
+	if opts.priority != nil {
+		if err := task.ValidatePriority(*opts.priority); err != nil {
+			return err
+		}
 	}
 
 	// Validate type if provided.
@@ -148,170 +236,67 @@ func RunCreate(dir string, fc FormatConfig, fmtr Formatter, args []string, stdou
 		}
 	}
 
-	// Open store.
-	store, err := openStore(dir, fc)
+	p, err := openProject(dir, fc)
 	if err != nil {
 		return err
 	}
-	defer store.Close()
-
-	// Resolve partial IDs via store.ResolveID.
-	if opts.parent != "" {
-		opts.parent, err = store.ResolveID(opts.parent)
-		if err != nil {
-			return err
-		}
-	}
-	for i, id := range opts.blockedBy {
-		opts.blockedBy[i], err = store.ResolveID(id)
-		if err != nil {
-			return err
-		}
-	}
-	for i, id := range opts.blocks {
-		opts.blocks[i], err = store.ResolveID(id)
-		if err != nil {
-			return err
-		}
-	}
-
-	var createdTask task.Task
-	var parentReopened bool
-	var parentResult task.TransitionResult
-	var parentCascadeResult *CascadeResult
-
-	err = store.Mutate(func(tasks []task.Task) ([]task.Task, error) {
-		// Build an ID existence checker with normalized keys.
-		idSet := make(map[string]bool, len(tasks))
-		for _, t := range tasks {
-			idSet[task.NormalizeID(t.ID)] = true
-		}
-		exists := func(id string) bool {
-			return idSet[id]
-		}
-
-		// Generate unique ID.
-		id, err := task.GenerateID(exists)
-		if err != nil {
-			return nil, err
-		}
-
-		// Validate all referenced IDs exist and no self-references.
-		if err := validateRefs(id, opts, idSet); err != nil {
-			return nil, err
-		}
-
-		now := time.Now().UTC().Truncate(time.Second)
-
-		newTask := task.Task{
-			ID:          id,
-			Title:       trimmedTitle,
-			Status:      task.StatusOpen,
-			Priority:    opts.priority,
-			Type:        opts.taskType,
-			Tags:        opts.tags,
-			Refs:        opts.refs,
-			Description: task.TrimDescription(opts.description),
-			BlockedBy:   opts.blockedBy,
-			Parent:      opts.parent,
-			Created:     now,
-			Updated:     now,
-		}
-
-		var sm task.StateMachine
-
-		// Validate parent allows adding children (Rule 7: blocks cancelled parent).
-		// If parent is done, trigger reopen cascade (Rule 6).
-		if opts.parent != "" {
-			r, c, reopened, err := validateAndReopenParent(tasks, opts.parent, &sm)
-			if err != nil {
-				return nil, err
-			}
-			if reopened {
-				parentReopened = true
-				parentResult = r
-				// Find parent title and build cascade result while tasks slice is valid.
-				normalizedParent := task.NormalizeID(opts.parent)
-				var parentTitle string
-				for _, tk := range tasks {
-					if task.NormalizeID(tk.ID) == normalizedParent {
-						parentTitle = tk.Title
-						break
-					}
-				}
-				cr := buildCascadeResult(opts.parent, parentTitle, r, c, tasks)
-				parentCascadeResult = &cr
-			}
-		}
-
-		// For --blocks: add new task's ID to target tasks' blocked_by and refresh updated.
-		if len(opts.blocks) > 0 {
-			applyBlocks(tasks, id, opts.blocks, now)
-		}
+	defer p.Close()
 
-		tasks = append(tasks, newTask)
-
-		// Validate dependencies (cycle detection + child-blocked-by-parent + cancelled blocker) against full task list.
-		if len(opts.blockedBy) > 0 {
-			for _, depID := range opts.blockedBy {
-				if err := sm.ValidateAddDep(tasks, id, depID); err != nil {
-					return nil, err
-				}
-			}
-		}
-		for _, blockID := range opts.blocks {
-			if err := sm.ValidateAddDep(tasks, blockID, id); err != nil {
-				return nil, err
-			}
-		}
-
-		createdTask = newTask
-		return tasks, nil
+	result, err := p.Create(tick.CreateOptions{
+		Title:       opts.title,
+		Description: opts.description,
+		Priority:    opts.priority,
+		Type:        opts.taskType,
+		Tags:        opts.tags,
+		Refs:        opts.refs,
+		Fields:      opts.fields,
+		Assignee:    opts.assignee,
+		Due:         opts.due,
+		Estimate:    opts.estimate,
+		Recur:       opts.recur,
+		Parent:      opts.parent,
+		BlockedBy:   opts.blockedBy,
+		Blocks:      opts.blocks,
 	})
-
 	if err != nil {
 		return err
 	}
 
 	// Output created task detail first.
-	if err := outputMutationResult(store, createdTask.ID, fc, fmtr, stdout); err != nil {
+	if err := outputMutationResult(p, result.Task.ID, fc, fmtr, stdout); err != nil {
 		return err
 	}
 
 	// Output cascade info if parent was reopened (and not quiet mode).
-	if parentReopened && !fc.Quiet {
-		outputTransitionOrCascade(stdout, fmtr, opts.parent, string(parentResult.OldStatus), string(parentResult.NewStatus), parentCascadeResult)
+	if cr := result.ParentReopened; cr != nil && !fc.Quiet {
+		outputTransitionOrCascade(stdout, fmtr, cr.TaskID, cr.OldStatus, cr.NewStatus, cr)
 	}
 
 	return nil
 }
 
-// validateRefs checks that all referenced IDs (blocked-by, blocks, parent) exist
-// in the task set and that none reference the new task itself.
-func validateRefs(newID string, opts createOpts, idSet map[string]bool) error {
-	for _, depID := range opts.blockedBy {
-		if depID == newID {
-			return fmt.Errorf("task %s cannot be blocked by itself", newID)
-		}
-		if !idSet[depID] {
-			return fmt.Errorf("task %q not found (referenced in --blocked-by)", depID)
-		}
+// runCreateFromTemplate creates the task tree of the --template named in opts,
+// filling in its --var values, and outputs the created tasks like batch does.
+func runCreateFromTemplate(dir string, fc FormatConfig, fmtr Formatter, opts createOpts, stdout io.Writer) error {
+	if opts.title != "" {
+		return fmt.Errorf("--template takes its titles from the template; remove the title argument")
 	}
-	for _, blockID := range opts.blocks {
-		if blockID == newID {
-			return fmt.Errorf("task %s cannot block itself", newID)
-		}
-		if !idSet[blockID] {
-			return fmt.Errorf("task %q not found (referenced in --blocks)", blockID)
+	for _, flag := range opts.flags {
+		if !slices.Contains([]string{"--template", "--var", "--parent"}, flag) {
+			return fmt.Errorf("%s cannot be used with --template; set it in the template instead", flag)
 		}
 	}
-	if opts.parent != "" {
-		if opts.parent == newID {
-			return fmt.Errorf("task %s cannot be its own parent", newID)
-		}
-		if !idSet[opts.parent] {
-			return fmt.Errorf("task %q not found (referenced in --parent)", opts.parent)
-		}
+
+	p, err := openProject(dir, fc)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+
+	result, err := p.CreateFromTemplate(opts.template, tick.TemplateOptions{Vars: opts.vars, Parent: opts.parent})
+	if err != nil {
+		return err
 	}
+	outputBatchResult(result, fc, fmtr, stdout)
 	return nil
 }
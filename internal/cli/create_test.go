@@ -13,8 +13,8 @@ import (
 	"github.com/leeovery/tick/internal/task"
 )
 
-// setupTickProject creates a .tick/ directory with an empty tasks.jsonl file
-// and returns the temp directory path and the .tick/ path.
+// setupTickProject creates a .tick/ directory with an empty tasks.jsonl file in
+// the current format and returns the temp directory path and the .tick/ path.
 func setupTickProject(t *testing.T) (string, string) {
 	t.Helper()
 	dir := t.TempDir()
@@ -26,6 +26,9 @@ func setupTickProject(t *testing.T) (string, string) {
 	if err := os.WriteFile(jsonlPath, []byte{}, 0644); err != nil {
 		t.Fatalf("failed to create tasks.jsonl: %v", err)
 	}
+	if err := storage.WriteFormatVersion(tickDir, storage.FormatVersion); err != nil {
+		t.Fatalf("failed to write format file: %v", err)
+	}
 	return dir, tickDir
 }
 
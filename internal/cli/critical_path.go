@@ -0,0 +1,203 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// RunCriticalPath executes the critical-path command: lists the longest chain
+// of remaining work, weighted by estimate, across the project or leading to a
+// specific task.
+func RunCriticalPath(dir string, fc FormatConfig, fmtr Formatter, args []string, stdout io.Writer) error {
+	if fc.Quiet {
+		return nil
+	}
+
+	store, err := openStore(dir, fc)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	tasks, err := store.ReadTasks()
+	if err != nil {
+		return err
+	}
+
+	var targetID string
+	if len(args) > 0 {
+		targetID, err = store.ResolveID(task.NormalizeID(args[0]))
+		if err != nil {
+			return err
+		}
+	}
+
+	result, err := BuildCriticalPath(tasks, targetID)
+	if err != nil {
+		return err
+	}
+
+	if len(result.Tasks) == 0 {
+		fmt.Fprintln(stdout, fmtr.FormatMessage(result.Message))
+		return nil
+	}
+
+	fmt.Fprintln(stdout, fmtr.FormatCriticalPath(result))
+	return nil
+}
+
+// pathCost is the weight of a chain of tasks: the sum of their estimates, with
+// the number of tasks breaking ties so unestimated chains still compare by length.
+type pathCost struct {
+	estimate float64
+	tasks    int
+}
+
+// less reports whether c is a lighter chain than other.
+func (c pathCost) less(other pathCost) bool {
+	if c.estimate != other.estimate {
+		return c.estimate < other.estimate
+	}
+	return c.tasks < other.tasks
+}
+
+// criticalPathWalker finds, for each open task, the heaviest chain of open
+// tasks linked by blocked_by that ends with it. Results are memoized, so each
+// task is walked once.
+type criticalPathWalker struct {
+	taskIdx map[string]task.Task
+	chains  map[string][]string
+	costs   map[string]pathCost
+	// visiting tracks the current walk to stop on a dependency cycle.
+	visiting map[string]bool
+}
+
+// chain returns the heaviest chain of open tasks ending with id, first task
+// first, and its cost. Closed and missing blockers are already done and add
+// nothing; on equal cost the blocker listed first wins.
+func (w *criticalPathWalker) chain(id string) ([]string, pathCost) {
+	if cost, ok := w.costs[id]; ok {
+		return w.chains[id], cost
+	}
+	if w.visiting[id] {
+		return nil, pathCost{}
+	}
+	w.visiting[id] = true
+
+	var best []string
+	var bestCost pathCost
+	for _, depID := range w.taskIdx[id].BlockedBy {
+		dep, exists := w.taskIdx[depID]
+		if !exists || dep.Closed != nil {
+			continue
+		}
+		chain, cost := w.chain(depID)
+		if bestCost.less(cost) {
+			best, bestCost = chain, cost
+		}
+	}
+	delete(w.visiting, id)
+
+	chain := append(slices.Clip(best), id)
+	cost := pathCost{estimate: bestCost.estimate + w.taskIdx[id].Estimate, tasks: bestCost.tasks + 1}
+	w.chains[id] = chain
+	w.costs[id] = cost
+	return chain, cost
+}
+
+// openSubtree returns the open tasks among the task with the given ID and all
+// its descendants, the task first and then its descendants in file order.
+func openSubtree(tasks []task.Task, taskIdx map[string]task.Task, id string) []string {
+	inSubtree := map[string]bool{id: true}
+	for changed := true; changed; {
+		changed = false
+		for _, t := range tasks {
+			if !inSubtree[t.ID] && inSubtree[t.Parent] {
+				inSubtree[t.ID] = true
+				changed = true
+			}
+		}
+	}
+
+	var ids []string
+	if taskIdx[id].Closed == nil {
+		ids = append(ids, id)
+	}
+	for _, t := range tasks {
+		if t.ID != id && inSubtree[t.ID] && t.Closed == nil {
+			ids = append(ids, t.ID)
+		}
+	}
+	return ids
+}
+
+// BuildCriticalPath finds the longest chain of remaining work: open tasks,
+// each blocked by the one before it, with the largest total estimate. With a
+// targetID the chain ends at that task or one of its open descendants, since
+// the task cannot close until they do; otherwise it may end anywhere. Ties go
+// to the chain with more tasks, then to the task found first. Returns an error
+// if the target task ID is not found.
+func BuildCriticalPath(tasks []task.Task, targetID string) (CriticalPathResult, error) {
+	taskIdx := buildTaskIndex(tasks)
+
+	var result CriticalPathResult
+	var ends []string
+	if targetID != "" {
+		target, exists := taskIdx[targetID]
+		if !exists {
+			return CriticalPathResult{}, fmt.Errorf("task %q not found", targetID)
+		}
+		targetDTT := toDepTreeTask(target)
+		result.Target = &targetDTT
+		ends = openSubtree(tasks, taskIdx, targetID)
+	} else {
+		for _, t := range tasks {
+			if t.Closed == nil {
+				ends = append(ends, t.ID)
+			}
+		}
+	}
+
+	w := &criticalPathWalker{
+		taskIdx:  taskIdx,
+		chains:   make(map[string][]string),
+		costs:    make(map[string]pathCost),
+		visiting: make(map[string]bool),
+	}
+	var best []string
+	var bestCost pathCost
+	for _, id := range ends {
+		chain, cost := w.chain(id)
+		if bestCost.less(cost) {
+			best, bestCost = chain, cost
+		}
+	}
+
+	if len(best) == 0 {
+		result.Message = "No remaining work."
+		return result, nil
+	}
+	for _, id := range best {
+		t := taskIdx[id]
+		result.Tasks = append(result.Tasks, CriticalPathTask{
+			ID:       t.ID,
+			Title:    t.Title,
+			Status:   string(t.Status),
+			Estimate: t.Estimate,
+		})
+	}
+	result.Total = bestCost.estimate
+	return result, nil
+}
+
+// handleCriticalPath implements the critical-path subcommand.
+func (a *App) handleCriticalPath(fc FormatConfig, fmtr Formatter, subArgs []string) error {
+	dir, err := a.Getwd()
+	if err != nil {
+		return fmt.Errorf("could not determine working directory: %w", err)
+	}
+	return RunCriticalPath(dir, fc, fmtr, subArgs, a.Stdout)
+}
@@ -0,0 +1,257 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// estimated returns t with the given estimate.
+func estimated(t task.Task, estimate float64) task.Task {
+	t.Estimate = estimate
+	return t
+}
+
+// pathIDs returns the IDs of the tasks on a critical path, in order.
+func pathIDs(result CriticalPathResult) []string {
+	ids := make([]string, len(result.Tasks))
+	for i, t := range result.Tasks {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+func TestBuildCriticalPath(t *testing.T) {
+	t.Run("it follows the chain with the largest total estimate", func(t *testing.T) {
+		// A(1) -> B(5) -> D(1) and A(1) -> C(2) -> D(1): the path through B wins
+		// even though both chains have three tasks.
+		tasks := []task.Task{
+			estimated(makeTask("tick-aaa111", "Task A", task.StatusOpen), 1),
+			estimated(makeTask("tick-ccc333", "Task C", task.StatusOpen, "tick-aaa111"), 2),
+			estimated(makeTask("tick-bbb222", "Task B", task.StatusOpen, "tick-aaa111"), 5),
+			estimated(makeTask("tick-ddd444", "Task D", task.StatusOpen, "tick-ccc333", "tick-bbb222"), 1),
+		}
+
+		result, err := BuildCriticalPath(tasks, "")
+		if err != nil {
+			t.Fatalf("BuildCriticalPath returned error: %v", err)
+		}
+
+		want := []string{"tick-aaa111", "tick-bbb222", "tick-ddd444"}
+		if got := pathIDs(result); strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("path = %v, want %v", got, want)
+		}
+		if result.Total != 7 {
+			t.Errorf("Total = %v, want 7", result.Total)
+		}
+		if result.Target != nil {
+			t.Errorf("Target = %+v, want nil", result.Target)
+		}
+	})
+
+	t.Run("it prefers a heavier chain over a longer one", func(t *testing.T) {
+		tasks := []task.Task{
+			estimated(makeTask("tick-aaa111", "Task A", task.StatusOpen), 1),
+			estimated(makeTask("tick-bbb222", "Task B", task.StatusOpen, "tick-aaa111"), 1),
+			estimated(makeTask("tick-ccc333", "Task C", task.StatusOpen, "tick-bbb222"), 1),
+			estimated(makeTask("tick-big111", "Big", task.StatusOpen), 8),
+		}
+
+		result, _ := BuildCriticalPath(tasks, "")
+
+		if got := pathIDs(result); len(got) != 1 || got[0] != "tick-big111" {
+			t.Errorf("path = %v, want [tick-big111]", got)
+		}
+	})
+
+	t.Run("it falls back to the longest chain when nothing is estimated", func(t *testing.T) {
+		tasks := []task.Task{
+			makeTask("tick-aaa111", "Task A", task.StatusOpen),
+			makeTask("tick-bbb222", "Task B", task.StatusOpen, "tick-aaa111"),
+			makeTask("tick-ccc333", "Task C", task.StatusOpen),
+		}
+
+		result, _ := BuildCriticalPath(tasks, "")
+
+		if got := pathIDs(result); strings.Join(got, ",") != "tick-aaa111,tick-bbb222" {
+			t.Errorf("path = %v, want [tick-aaa111 tick-bbb222]", got)
+		}
+		if result.Total != 0 {
+			t.Errorf("Total = %v, want 0", result.Total)
+		}
+	})
+
+	t.Run("it leaves closed tasks off the path", func(t *testing.T) {
+		closed := time.Date(2026, 3, 27, 12, 0, 0, 0, time.UTC)
+		done := estimated(makeTask("tick-aaa111", "Task A", task.StatusDone), 10)
+		done.Closed = &closed
+		tasks := []task.Task{
+			done,
+			estimated(makeTask("tick-bbb222", "Task B", task.StatusOpen, "tick-aaa111"), 2),
+			estimated(makeTask("tick-ccc333", "Task C", task.StatusOpen), 1),
+		}
+
+		result, _ := BuildCriticalPath(tasks, "")
+
+		if got := pathIDs(result); len(got) != 1 || got[0] != "tick-bbb222" {
+			t.Errorf("path = %v, want [tick-bbb222]", got)
+		}
+	})
+
+	t.Run("it ends the path at the target or one of its open descendants", func(t *testing.T) {
+		epic := makeTask("tick-epic11", "Epic", task.StatusOpen)
+		story := estimated(makeTask("tick-story1", "Story", task.StatusOpen, "tick-aaa111"), 2)
+		story.Parent = epic.ID
+		tasks := []task.Task{
+			estimated(makeTask("tick-aaa111", "Task A", task.StatusOpen), 3),
+			epic,
+			story,
+			estimated(makeTask("tick-big111", "Big", task.StatusOpen), 20),
+		}
+
+		result, err := BuildCriticalPath(tasks, "tick-epic11")
+		if err != nil {
+			t.Fatalf("BuildCriticalPath returned error: %v", err)
+		}
+
+		if got := pathIDs(result); strings.Join(got, ",") != "tick-aaa111,tick-story1" {
+			t.Errorf("path = %v, want [tick-aaa111 tick-story1]", got)
+		}
+		if result.Target == nil || result.Target.ID != "tick-epic11" {
+			t.Errorf("Target = %+v, want tick-epic11", result.Target)
+		}
+		if result.Total != 5 {
+			t.Errorf("Total = %v, want 5", result.Total)
+		}
+	})
+
+	t.Run("it reports no remaining work when every task is closed", func(t *testing.T) {
+		closed := time.Date(2026, 3, 27, 12, 0, 0, 0, time.UTC)
+		done := makeTask("tick-aaa111", "Task A", task.StatusDone)
+		done.Closed = &closed
+
+		result, err := BuildCriticalPath([]task.Task{done}, "tick-aaa111")
+		if err != nil {
+			t.Fatalf("BuildCriticalPath returned error: %v", err)
+		}
+		if len(result.Tasks) != 0 || result.Message != "No remaining work." {
+			t.Errorf("result = %+v, want an empty path with a message", result)
+		}
+	})
+
+	t.Run("it stops on a dependency cycle", func(t *testing.T) {
+		tasks := []task.Task{
+			estimated(makeTask("tick-aaa111", "Task A", task.StatusOpen, "tick-bbb222"), 1),
+			estimated(makeTask("tick-bbb222", "Task B", task.StatusOpen, "tick-aaa111"), 1),
+		}
+
+		result, _ := BuildCriticalPath(tasks, "")
+
+		if len(result.Tasks) == 0 || len(result.Tasks) > 2 {
+			t.Errorf("path = %v, want one or two tasks", pathIDs(result))
+		}
+	})
+
+	t.Run("it returns an error for an unknown target", func(t *testing.T) {
+		if _, err := BuildCriticalPath(nil, "tick-zzz999"); err == nil {
+			t.Error("BuildCriticalPath returned nil, want error")
+		}
+	})
+}
+
+func TestRunCriticalPath(t *testing.T) {
+	now := time.Date(2026, 3, 27, 12, 0, 0, 0, time.UTC)
+	chain := []task.Task{
+		{ID: "tick-aaa111", Title: "Design schema", Status: task.StatusOpen, Priority: 2, Estimate: 3, Created: now, Updated: now},
+		{ID: "tick-bbb222", Title: "Build API", Status: task.StatusInProgress, Priority: 2, Estimate: 5, BlockedBy: []string{"tick-aaa111"}, Created: now, Updated: now},
+		{ID: "tick-ccc333", Title: "Write docs", Status: task.StatusOpen, Priority: 2, BlockedBy: []string{"tick-bbb222"}, Created: now, Updated: now},
+	}
+
+	t.Run("it lists the path in order with the total", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, chain)
+
+		stdout, stderr, exitCode := runTick(t, dir, "--pretty", "critical-path")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		want := "#  ID           EST  STATUS       TITLE\n" +
+			"1  tick-aaa111  3    open         Design schema\n" +
+			"2  tick-bbb222  5    in_progress  Build API\n" +
+			"3  tick-ccc333  -    open         Write docs\n" +
+			"\n" +
+			"Total: 8 across 3 tasks\n"
+		if stdout != want {
+			t.Errorf("stdout = %q, want %q", stdout, want)
+		}
+	})
+
+	t.Run("it heads the path with a partially matched target", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, chain)
+
+		stdout, stderr, exitCode := runTick(t, dir, "--pretty", "critical-path", "bbb2")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if !strings.HasPrefix(stdout, "Critical path to tick-bbb222 (Build API):\n\n") || strings.Contains(stdout, "tick-ccc333") {
+			t.Errorf("stdout = %q, want the path up to tick-bbb222", stdout)
+		}
+	})
+
+	t.Run("it renders the path in toon and json", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, chain)
+
+		stdout, _, _ := runTick(t, dir, "--toon", "critical-path")
+		want := "critical_path[3]{id,title,status,estimate}:\n" +
+			"  tick-aaa111,Design schema,open,3\n" +
+			"  tick-bbb222,Build API,in_progress,5\n" +
+			"  tick-ccc333,Write docs,open,0\n" +
+			"\n" +
+			"summary{total,tasks}:\n" +
+			"  8,3\n"
+		if stdout != want {
+			t.Errorf("toon stdout = %q, want %q", stdout, want)
+		}
+
+		stdout, _, _ = runTick(t, dir, "--json", "critical-path", "tick-ccc333")
+		var path struct {
+			Target string  `json:"target"`
+			Total  float64 `json:"total"`
+			Path   []struct {
+				ID string `json:"id"`
+			} `json:"path"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &path); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+		}
+		if path.Target != "tick-ccc333" || path.Total != 8 || len(path.Path) != 3 {
+			t.Errorf("json = %+v, want target tick-ccc333, total 8 and three tasks", path)
+		}
+	})
+
+	t.Run("it reports no remaining work", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+
+		stdout, stderr, exitCode := runTick(t, dir, "--pretty", "critical-path")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if strings.TrimSpace(stdout) != "No remaining work." {
+			t.Errorf("stdout = %q, want %q", stdout, "No remaining work.")
+		}
+	})
+
+	t.Run("it returns an error for a nonexistent task", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, chain)
+
+		_, stderr, exitCode := runTick(t, dir, "critical-path", "tick-zzz999")
+		if exitCode != 1 {
+			t.Fatalf("exit code = %d, want 1", exitCode)
+		}
+		if !strings.Contains(stderr, "not found") {
+			t.Errorf("stderr = %q, want not found error", stderr)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// RunDefer executes the defer command: keeps a task out of the ready list until
+// the given date or duration passes, then outputs the task's details.
+func RunDefer(dir string, fc FormatConfig, fmtr Formatter, args []string, stdout io.Writer) error {
+	if len(args) < 2 {
+		return fmt.Errorf("task ID and date are required. Usage: tick defer <id> <date|duration>")
+	}
+	until, err := parseDeferUntil(args[1], time.Now())
+	if err != nil {
+		return err
+	}
+
+	p, err := openProject(dir, fc)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+
+	t, err := p.Defer(args[0], until)
+	if err != nil {
+		return err
+	}
+	return outputMutationResult(p, t.ID, fc, fmtr, stdout)
+}
+
+// RunUndefer executes the undefer command: makes a deferred task eligible for
+// the ready list again, then outputs the task's details.
+func RunUndefer(dir string, fc FormatConfig, fmtr Formatter, args []string, stdout io.Writer) error {
+	if len(args) < 1 {
+		return fmt.Errorf("task ID is required. Usage: tick undefer <id>")
+	}
+
+	p, err := openProject(dir, fc)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+
+	t, err := p.Undefer(args[0])
+	if err != nil {
+		return err
+	}
+	return outputMutationResult(p, t.ID, fc, fmtr, stdout)
+}
+
+// handleDefer implements the defer subcommand.
+func (a *App) handleDefer(fc FormatConfig, fmtr Formatter, subArgs []string) error {
+	dir, err := a.Getwd()
+	if err != nil {
+		return fmt.Errorf("could not determine working directory: %w", err)
+	}
+	return RunDefer(dir, fc, fmtr, subArgs, a.Stdout)
+}
+
+// handleUndefer implements the undefer subcommand.
+func (a *App) handleUndefer(fc FormatConfig, fmtr Formatter, subArgs []string) error {
+	dir, err := a.Getwd()
+	if err != nil {
+		return fmt.Errorf("could not determine working directory: %w", err)
+	}
+	return RunUndefer(dir, fc, fmtr, subArgs, a.Stdout)
+}
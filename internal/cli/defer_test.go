@@ -0,0 +1,238 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/storage"
+	"github.com/leeovery/tick/internal/task"
+)
+
+func TestParseDeferUntil(t *testing.T) {
+	now := time.Date(2026, 1, 19, 10, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"2026-02-01", time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local)},
+		{"2026-02-01T09:00:00Z", time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)},
+		{"3d", time.Date(2026, 1, 22, 10, 30, 0, 0, time.Local)},
+		{"+3d", time.Date(2026, 1, 22, 10, 30, 0, 0, time.Local)},
+		{"2w", time.Date(2026, 2, 2, 10, 30, 0, 0, time.Local)},
+		{"4h", time.Date(2026, 1, 19, 14, 30, 0, 0, time.Local)},
+		{"90m", time.Date(2026, 1, 19, 12, 0, 0, 0, time.Local)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := parseDeferUntil(tc.input, now)
+			if err != nil {
+				t.Fatalf("parseDeferUntil(%q) returned error: %v", tc.input, err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("parseDeferUntil(%q) = %v, want %v", tc.input, got, tc.want)
+			}
+		})
+	}
+
+	t.Run("it rejects anything else", func(t *testing.T) {
+		for _, input := range []string{"", "later", "-3d", "3x", "0s", "01/02/2026"} {
+			if _, err := parseDeferUntil(input, now); err == nil {
+				t.Errorf("parseDeferUntil(%q) returned nil, want error", input)
+			}
+		}
+	})
+
+	t.Run("it defers a day late in the evening by a full day", func(t *testing.T) {
+		late := time.Date(2026, 1, 19, 23, 0, 0, 0, time.Local)
+		got, err := parseDeferUntil("1d", late)
+		if err != nil {
+			t.Fatalf("parseDeferUntil returned error: %v", err)
+		}
+		if want := time.Date(2026, 1, 20, 23, 0, 0, 0, time.Local); !got.Equal(want) {
+			t.Errorf("parseDeferUntil(\"1d\") at 23:00 = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("it accepts durations up to about a century and rejects longer ones", func(t *testing.T) {
+		for _, input := range []string{"5218w", "36600d", "878400h"} {
+			if _, err := parseDeferUntil(input, now); err != nil {
+				t.Errorf("parseDeferUntil(%q) returned error: %v", input, err)
+			}
+		}
+		for _, input := range []string{"5229w", "36601d", "878401h", "9223372036854775807m", "99999999999999999999d"} {
+			if _, err := parseDeferUntil(input, now); err == nil {
+				t.Errorf("parseDeferUntil(%q) returned nil, want error", input)
+			}
+		}
+	})
+}
+
+func TestDeferCommand(t *testing.T) {
+	now := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+	past := time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC)
+	future := time.Date(2099, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("it defers a task out of ready and into blocked", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Wait for vendor", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+			{ID: "tick-bbb222", Title: "Other", Status: task.StatusOpen, Priority: 2, Created: now.Add(time.Second), Updated: now.Add(time.Second)},
+		})
+
+		stdout, stderr, exitCode := runTick(t, dir, "defer", "tick-aaa111", "2099-01-01T09:00:00Z")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if !strings.Contains(stdout, "Deferred: until 2099-01-01T09:00:00Z") {
+			t.Errorf("defer should show the task with its defer date, got %q", stdout)
+		}
+		if got := readPersistedTasks(t, tickDir)[0].DeferUntil; got == nil || !got.Equal(future) {
+			t.Errorf("defer_until = %v, want %v", got, future)
+		}
+
+		stdout, _, _ = runTick(t, dir, "ready")
+		if strings.Contains(stdout, "tick-aaa111") || !strings.Contains(stdout, "tick-bbb222") {
+			t.Errorf("ready should skip the deferred task, got %q", stdout)
+		}
+
+		stdout, _, _ = runTick(t, dir, "blocked")
+		if !strings.Contains(stdout, "DEFERRED") || !strings.Contains(stdout, "tick-aaa111") || strings.Contains(stdout, "tick-bbb222") {
+			t.Errorf("blocked should list the deferred task with a deferred column, got %q", stdout)
+		}
+	})
+
+	t.Run("it includes deferred tasks in ready with --include-deferred", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Later", Status: task.StatusOpen, Priority: 2, DeferUntil: &future, Created: now, Updated: now},
+		})
+
+		stdout, stderr, exitCode := runTick(t, dir, "ready", "--include-deferred")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if !strings.Contains(stdout, "tick-aaa111") {
+			t.Errorf("ready --include-deferred should list the deferred task, got %q", stdout)
+		}
+
+		stdout, _, _ = runTick(t, dir, "blocked", "--include-deferred")
+		if strings.Contains(stdout, "tick-aaa111") {
+			t.Errorf("blocked --include-deferred should not list the task, got %q", stdout)
+		}
+	})
+
+	t.Run("it treats a task whose defer date has passed as ready", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Was deferred", Status: task.StatusOpen, Priority: 2, DeferUntil: &past, Created: now, Updated: now},
+		})
+
+		stdout, _, _ := runTick(t, dir, "ready")
+		if !strings.Contains(stdout, "tick-aaa111") || strings.Contains(stdout, "DEFERRED") {
+			t.Errorf("ready should list the task without a deferred column, got %q", stdout)
+		}
+	})
+
+	t.Run("it undefers a task", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Later", Status: task.StatusOpen, Priority: 2, DeferUntil: &future, Created: now, Updated: now},
+		})
+
+		stdout, stderr, exitCode := runTick(t, dir, "--quiet", "undefer", "tick-aaa111")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if stdout != "tick-aaa111\n" {
+			t.Errorf("stdout = %q, want the task ID", stdout)
+		}
+		if got := readPersistedTasks(t, tickDir)[0].DeferUntil; got != nil {
+			t.Errorf("defer_until = %v, want nil", got)
+		}
+
+		stdout, _, _ = runTick(t, dir, "ready")
+		if !strings.Contains(stdout, "tick-aaa111") {
+			t.Errorf("ready should list the undeferred task, got %q", stdout)
+		}
+	})
+
+	t.Run("it rejects a missing or invalid date", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		})
+
+		_, stderr, exitCode := runTick(t, dir, "defer", "tick-aaa111")
+		if exitCode != 1 || !strings.Contains(stderr, "Usage: tick defer <id> <date|duration>") {
+			t.Errorf("exit code = %d, stderr = %q; want usage error", exitCode, stderr)
+		}
+
+		_, stderr, exitCode = runTick(t, dir, "defer", "tick-aaa111", "someday")
+		if exitCode != 1 || !strings.Contains(stderr, "invalid defer date 'someday'") {
+			t.Errorf("exit code = %d, stderr = %q; want invalid defer date error", exitCode, stderr)
+		}
+
+		_, stderr, exitCode = runTick(t, dir, "defer", "tick-aaa111", "2020-01-01")
+		if exitCode != 1 || !strings.Contains(stderr, "is not in the future") {
+			t.Errorf("exit code = %d, stderr = %q; want not-in-the-future error", exitCode, stderr)
+		}
+	})
+
+	t.Run("it refuses to defer in a project not yet upgraded to defer dates", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		})
+		if err := storage.WriteFormatVersion(tickDir, 3); err != nil {
+			t.Fatalf("failed to write format file: %v", err)
+		}
+
+		_, stderr, exitCode := runTick(t, dir, "defer", "tick-aaa111", "2099-01-01")
+		if exitCode != 1 || !strings.Contains(stderr, "run tick upgrade before changing tasks") {
+			t.Errorf("exit code = %d, stderr = %q; want upgrade error", exitCode, stderr)
+		}
+		if got := readPersistedTasks(t, tickDir)[0].DeferUntil; got != nil {
+			t.Errorf("defer_until = %v, want none", got)
+		}
+	})
+
+	t.Run("it shows the defer date in toon and json", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Later", Status: task.StatusOpen, Priority: 2, DeferUntil: &future, Created: now, Updated: now},
+		})
+
+		stdout, _, _ := runTick(t, dir, "--toon", "blocked")
+		if !strings.Contains(stdout, "{id,title,status,priority,type,defer_until,reason}:\n  tick-aaa111,Later,open,2,\"\",\"2099-01-01T09:00:00Z\",deferred") {
+			t.Errorf("toon blocked should include a defer_until column, got %q", stdout)
+		}
+
+		stdout, _, _ = runTick(t, dir, "--json", "show", "tick-aaa111")
+		var detail struct {
+			DeferUntil string `json:"defer_until"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &detail); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+		}
+		if detail.DeferUntil != "2099-01-01T09:00:00Z" {
+			t.Errorf("json defer_until = %q, want %q", detail.DeferUntil, "2099-01-01T09:00:00Z")
+		}
+	})
+
+	t.Run("it counts deferred tasks as blocked in stats", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Later", Status: task.StatusOpen, Priority: 2, DeferUntil: &future, Created: now, Updated: now},
+			{ID: "tick-bbb222", Title: "Now", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		})
+
+		stdout, _, _ := runTick(t, dir, "--json", "stats")
+		var stats struct {
+			Workflow struct {
+				Ready   int `json:"ready"`
+				Blocked int `json:"blocked"`
+			} `json:"workflow"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &stats); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+		}
+		if stats.Workflow.Ready != 1 || stats.Workflow.Blocked != 1 {
+			t.Errorf("ready = %d, blocked = %d; want 1 and 1", stats.Workflow.Ready, stats.Workflow.Blocked)
+		}
+	})
+}
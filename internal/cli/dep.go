@@ -3,8 +3,6 @@ package cli
 import (
 	"fmt"
 	"io"
-	"slices"
-	"time"
 
 	"github.com/leeovery/tick/internal/task"
 )
@@ -51,71 +49,29 @@ func parseDepArgs(args []string, subCmd string) (string, string, error) {
 }
 
 // RunDepAdd executes the dep add command: validates inputs, resolves partial IDs,
-// adds the dependency, persists via the storage engine, and outputs confirmation via the Formatter.
+// adds the dependency via tick.Project, and outputs confirmation via the Formatter.
 func RunDepAdd(dir string, fc FormatConfig, fmtr Formatter, args []string, stdout io.Writer) error {
 	taskID, blockedByID, err := parseDepArgs(args, "add")
 	if err != nil {
 		return err
 	}
 
-	store, err := openStore(dir, fc)
+	p, err := openProject(dir, fc)
 	if err != nil {
 		return err
 	}
-	defer store.Close()
+	defer p.Close()
 
-	taskID, err = store.ResolveID(taskID)
+	taskID, err = p.ResolveID(taskID)
 	if err != nil {
 		return err
 	}
-	blockedByID, err = store.ResolveID(blockedByID)
+	blockedByID, err = p.ResolveID(blockedByID)
 	if err != nil {
 		return err
 	}
 
-	err = store.Mutate(func(tasks []task.Task) ([]task.Task, error) {
-		// Find task_id.
-		taskIdx := -1
-		for i := range tasks {
-			if tasks[i].ID == taskID {
-				taskIdx = i
-				break
-			}
-		}
-		if taskIdx == -1 {
-			return nil, fmt.Errorf("task '%s' not found", taskID)
-		}
-
-		// Find blocked_by_id.
-		blockedByFound := false
-		for i := range tasks {
-			if tasks[i].ID == blockedByID {
-				blockedByFound = true
-				break
-			}
-		}
-		if !blockedByFound {
-			return nil, fmt.Errorf("task '%s' not found", blockedByID)
-		}
-
-		// Check duplicate.
-		if slices.Contains(tasks[taskIdx].BlockedBy, blockedByID) {
-			return nil, fmt.Errorf("dependency already exists: %s is already blocked by %s", taskID, blockedByID)
-		}
-
-		// Validate dependency (self-ref, cycle, child-blocked-by-parent, cancelled blocker).
-		var sm task.StateMachine
-		if err := sm.ValidateAddDep(tasks, taskID, blockedByID); err != nil {
-			return nil, err
-		}
-
-		// Add dependency and update timestamp.
-		tasks[taskIdx].BlockedBy = append(tasks[taskIdx].BlockedBy, blockedByID)
-		tasks[taskIdx].Updated = time.Now().UTC().Truncate(time.Second)
-
-		return tasks, nil
-	})
-	if err != nil {
+	if err := p.AddDep(taskID, blockedByID); err != nil {
 		return err
 	}
 
@@ -126,64 +82,30 @@ func RunDepAdd(dir string, fc FormatConfig, fmtr Formatter, args []string, stdou
 	return nil
 }
 
-// RunDepRemove executes the dep remove command: resolves partial IDs, finds the task, removes the
-// dependency from blocked_by, persists via the storage engine, and outputs confirmation via the Formatter.
+// RunDepRemove executes the dep remove command: resolves partial IDs, removes the dependency
+// from blocked_by via tick.Project, and outputs confirmation via the Formatter.
 func RunDepRemove(dir string, fc FormatConfig, fmtr Formatter, args []string, stdout io.Writer) error {
 	taskID, blockedByID, err := parseDepArgs(args, "remove")
 	if err != nil {
 		return err
 	}
 
-	store, err := openStore(dir, fc)
+	p, err := openProject(dir, fc)
 	if err != nil {
 		return err
 	}
-	defer store.Close()
+	defer p.Close()
 
-	taskID, err = store.ResolveID(taskID)
+	taskID, err = p.ResolveID(taskID)
 	if err != nil {
 		return err
 	}
-	blockedByID, err = store.ResolveID(blockedByID)
+	blockedByID, err = p.ResolveID(blockedByID)
 	if err != nil {
 		return err
 	}
 
-	err = store.Mutate(func(tasks []task.Task) ([]task.Task, error) {
-		// Find task_id.
-		taskIdx := -1
-		for i := range tasks {
-			if tasks[i].ID == taskID {
-				taskIdx = i
-				break
-			}
-		}
-		if taskIdx == -1 {
-			return nil, fmt.Errorf("task '%s' not found", taskID)
-		}
-
-		// Check blocked_by_id exists in the blocked_by array.
-		depIdx := -1
-		for i, dep := range tasks[taskIdx].BlockedBy {
-			if dep == blockedByID {
-				depIdx = i
-				break
-			}
-		}
-		if depIdx == -1 {
-			return nil, fmt.Errorf("%s is not a dependency of %s", blockedByID, taskID)
-		}
-
-		// Remove from blocked_by (preserve order).
-		tasks[taskIdx].BlockedBy = append(
-			tasks[taskIdx].BlockedBy[:depIdx],
-			tasks[taskIdx].BlockedBy[depIdx+1:]...,
-		)
-		tasks[taskIdx].Updated = time.Now().UTC().Truncate(time.Second)
-
-		return tasks, nil
-	})
-	if err != nil {
+	if err := p.RemoveDep(taskID, blockedByID); err != nil {
 		return err
 	}
 
@@ -6,12 +6,14 @@ import (
 	"io"
 
 	"github.com/leeovery/tick/internal/doctor"
+	"github.com/leeovery/tick/tick"
 )
 
 // RunDoctor executes the doctor diagnostic command. It creates a DiagnosticRunner,
-// registers all 10 checks (CacheStalenessCheck, JsonlSyntaxCheck, IdFormatCheck,
+// registers all 15 checks (CacheStalenessCheck, JsonlSyntaxCheck, IdFormatCheck,
 // DuplicateIdCheck, OrphanedParentCheck, OrphanedDependencyCheck, SelfReferentialDepCheck,
-// DependencyCycleCheck, ChildBlockedByParentCheck, ParentDoneWithOpenChildrenCheck),
+// DependencyCycleCheck, ChildBlockedByParentCheck, ParentDoneWithOpenChildrenCheck,
+// UnknownFieldsCheck, StaleLockCheck, ConfigCheck, UnknownTypeCheck, UnknownStatusCheck),
 // runs all checks, formats the output to stdout, and returns the appropriate exit code.
 // Doctor is read-only and never modifies data.
 func RunDoctor(stdout io.Writer, stderr io.Writer, tickDir string) int {
@@ -26,6 +28,11 @@ func RunDoctor(stdout io.Writer, stderr io.Writer, tickDir string) int {
 	runner.Register(&doctor.DependencyCycleCheck{})
 	runner.Register(&doctor.ChildBlockedByParentCheck{})
 	runner.Register(&doctor.ParentDoneWithOpenChildrenCheck{})
+	runner.Register(&doctor.UnknownFieldsCheck{})
+	runner.Register(&doctor.StaleLockCheck{})
+	runner.Register(&doctor.ConfigCheck{})
+	runner.Register(&doctor.UnknownTypeCheck{})
+	runner.Register(&doctor.UnknownStatusCheck{})
 
 	ctx := context.Background()
 
@@ -51,7 +58,7 @@ func (a *App) handleDoctor() int {
 		return 1
 	}
 
-	tickDir, err := DiscoverTickDir(dir)
+	tickDir, err := tick.DiscoverTickDir(dir)
 	if err != nil {
 		fmt.Fprintf(a.Stderr, "Error: Not a tick project (no .tick directory found)\n")
 		return 1
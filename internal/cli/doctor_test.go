@@ -322,10 +322,10 @@ func TestDoctorFourChecks(t *testing.T) {
 
 		stdout, _, _ := runDoctor(t, dir)
 
-		// Count check marks — should have 10 passing checks (4 original + 6 relationship/hierarchy).
+		// Count check marks — should have 15 passing checks.
 		checkCount := strings.Count(stdout, "\u2713")
-		if checkCount != 10 {
-			t.Errorf("expected 10 check marks, got %d; stdout = %q", checkCount, stdout)
+		if checkCount != 15 {
+			t.Errorf("expected 15 check marks, got %d; stdout = %q", checkCount, stdout)
 		}
 	})
 
@@ -538,9 +538,10 @@ func TestDoctorFourChecks(t *testing.T) {
 	})
 }
 
-// healthyTenCheckContent returns tasks.jsonl content that passes all 10 checks:
+// healthyTenCheckContent returns tasks.jsonl content that passes all 15 checks:
 // valid IDs, no duplicates, valid JSON, no orphaned parents/deps, no self-refs,
-// no cycles, no child-blocked-by-parent, and no done parent with open children.
+// no cycles, no child-blocked-by-parent, no done parent with open children, no
+// unrecognized fields, and no lock records left by dead processes.
 func healthyTenCheckContent() string {
 	return `{"id":"tick-aaa111","title":"Parent","status":"open"}
 {"id":"tick-bbb222","title":"Child","status":"open","parent":"tick-aaa111"}
@@ -553,9 +554,10 @@ func TestDoctorTenChecks(t *testing.T) {
 		"Orphaned parents", "Orphaned dependencies",
 		"Self-referential dependencies", "Dependency cycles",
 		"Child blocked by parent", "Parent done with open children",
+		"Unknown fields", "Lock", "Config", "Task types", "Task statuses",
 	}
 
-	t.Run("it registers all 10 checks (4 existing + 6 new relationship/hierarchy checks)", func(t *testing.T) {
+	t.Run("it registers all 15 checks", func(t *testing.T) {
 		dir, _ := setupDoctorProjectWithContent(t, healthyTenCheckContent())
 
 		stdout, _, _ := runDoctor(t, dir)
@@ -567,18 +569,18 @@ func TestDoctorTenChecks(t *testing.T) {
 		}
 	})
 
-	t.Run("it runs all 10 checks in a single tick doctor invocation", func(t *testing.T) {
+	t.Run("it runs all 15 checks in a single tick doctor invocation", func(t *testing.T) {
 		dir, _ := setupDoctorProjectWithContent(t, healthyTenCheckContent())
 
 		stdout, _, _ := runDoctor(t, dir)
 
 		checkCount := strings.Count(stdout, "\u2713")
-		if checkCount != 10 {
-			t.Errorf("expected 10 check marks, got %d; stdout = %q", checkCount, stdout)
+		if checkCount != 15 {
+			t.Errorf("expected 15 check marks, got %d; stdout = %q", checkCount, stdout)
 		}
 	})
 
-	t.Run("it exits 0 when all 10 checks pass (healthy store)", func(t *testing.T) {
+	t.Run("it exits 0 when all 15 checks pass (healthy store)", func(t *testing.T) {
 		dir, _ := setupDoctorProjectWithContent(t, healthyTenCheckContent())
 
 		_, _, exitCode := runDoctor(t, dir)
@@ -588,7 +590,7 @@ func TestDoctorTenChecks(t *testing.T) {
 		}
 	})
 
-	t.Run("it exits 1 when only the orphaned parent check fails (other 9 pass)", func(t *testing.T) {
+	t.Run("it exits 1 when only the orphaned parent check fails (other 14 pass)", func(t *testing.T) {
 		content := `{"id":"tick-aaa111","title":"Task","status":"open","parent":"tick-ffffff"}`
 		dir, _ := setupDoctorProjectWithContent(t, content)
 
@@ -599,7 +601,7 @@ func TestDoctorTenChecks(t *testing.T) {
 		}
 	})
 
-	t.Run("it exits 1 when only the orphaned dependency check fails (other 9 pass)", func(t *testing.T) {
+	t.Run("it exits 1 when only the orphaned dependency check fails (other 14 pass)", func(t *testing.T) {
 		content := `{"id":"tick-aaa111","title":"Task","status":"open","blocked_by":["tick-ffffff"]}`
 		dir, _ := setupDoctorProjectWithContent(t, content)
 
@@ -610,7 +612,7 @@ func TestDoctorTenChecks(t *testing.T) {
 		}
 	})
 
-	t.Run("it exits 1 when only the self-referential dependency check fails (other 9 pass)", func(t *testing.T) {
+	t.Run("it exits 1 when only the self-referential dependency check fails (other 14 pass)", func(t *testing.T) {
 		content := `{"id":"tick-aaa111","title":"Task","status":"open","blocked_by":["tick-aaa111"]}`
 		dir, _ := setupDoctorProjectWithContent(t, content)
 
@@ -621,7 +623,7 @@ func TestDoctorTenChecks(t *testing.T) {
 		}
 	})
 
-	t.Run("it exits 1 when only the dependency cycle check fails (other 9 pass)", func(t *testing.T) {
+	t.Run("it exits 1 when only the dependency cycle check fails (other 14 pass)", func(t *testing.T) {
 		content := `{"id":"tick-aaa111","title":"Task A","status":"open","blocked_by":["tick-bbb222"]}
 {"id":"tick-bbb222","title":"Task B","status":"open","blocked_by":["tick-aaa111"]}`
 		dir, _ := setupDoctorProjectWithContent(t, content)
@@ -633,7 +635,7 @@ func TestDoctorTenChecks(t *testing.T) {
 		}
 	})
 
-	t.Run("it exits 1 when only the child-blocked-by-parent check fails (other 9 pass)", func(t *testing.T) {
+	t.Run("it exits 1 when only the child-blocked-by-parent check fails (other 14 pass)", func(t *testing.T) {
 		content := `{"id":"tick-aaa111","title":"Parent","status":"open"}
 {"id":"tick-bbb222","title":"Child","status":"open","parent":"tick-aaa111","blocked_by":["tick-aaa111"]}`
 		dir, _ := setupDoctorProjectWithContent(t, content)
@@ -722,7 +724,7 @@ func TestDoctorTenChecks(t *testing.T) {
 		}
 	})
 
-	t.Run("it displays results for all 10 checks in output (10 check labels visible when all pass)", func(t *testing.T) {
+	t.Run("it displays results for all 15 checks in output (15 check labels visible when all pass)", func(t *testing.T) {
 		dir, _ := setupDoctorProjectWithContent(t, healthyTenCheckContent())
 
 		stdout, _, _ := runDoctor(t, dir)
@@ -762,8 +764,8 @@ func TestDoctorTenChecks(t *testing.T) {
 		}
 	})
 
-	t.Run("it runs all 10 checks even when early checks fail (no short-circuit)", func(t *testing.T) {
-		// Stale cache (first check fails), but all 10 should still run.
+	t.Run("it runs all 15 checks even when early checks fail (no short-circuit)", func(t *testing.T) {
+		// Stale cache (first check fails), but all 15 should still run.
 		dir, _ := setupDoctorProjectWithContentStale(t, healthyTenCheckContent())
 
 		stdout, _, _ := runDoctor(t, dir)
@@ -775,7 +777,7 @@ func TestDoctorTenChecks(t *testing.T) {
 		}
 	})
 
-	t.Run("it handles empty tasks.jsonl - all 10 checks report their respective passing/failing results", func(t *testing.T) {
+	t.Run("it handles empty tasks.jsonl - all 15 checks report their respective passing/failing results", func(t *testing.T) {
 		dir, _ := setupDoctorProject(t) // Empty tasks.jsonl, fresh cache.
 
 		stdout, _, exitCode := runDoctor(t, dir)
@@ -790,7 +792,7 @@ func TestDoctorTenChecks(t *testing.T) {
 		}
 	})
 
-	t.Run("it does not modify tasks.jsonl or cache.db (read-only invariant preserved with 10 checks)", func(t *testing.T) {
+	t.Run("it does not modify tasks.jsonl or cache.db (read-only invariant preserved with 15 checks)", func(t *testing.T) {
 		dir, tickDir := setupDoctorProjectWithContent(t, healthyTenCheckContent())
 
 		jsonlPath := filepath.Join(tickDir, "tasks.jsonl")
@@ -817,14 +819,14 @@ func TestDoctorTenChecks(t *testing.T) {
 		}
 
 		if string(jsonlBefore) != string(jsonlAfter) {
-			t.Error("tasks.jsonl was modified by doctor with 10 checks")
+			t.Error("tasks.jsonl was modified by doctor with 15 checks")
 		}
 		if string(cacheBefore) != string(cacheAfter) {
-			t.Error("cache.db was modified by doctor with 10 checks")
+			t.Error("cache.db was modified by doctor with 15 checks")
 		}
 	})
 
-	t.Run("it shows 'No issues found.' summary when all 10 checks pass", func(t *testing.T) {
+	t.Run("it shows 'No issues found.' summary when all 15 checks pass", func(t *testing.T) {
 		dir, _ := setupDoctorProjectWithContent(t, healthyTenCheckContent())
 
 		stdout, _, _ := runDoctor(t, dir)
@@ -0,0 +1,252 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+func TestParseDue(t *testing.T) {
+	now := time.Date(2026, 1, 19, 10, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"2026-01-30", time.Date(2026, 1, 30, 23, 59, 59, 0, time.Local)},
+		{"2026-01-30T17:00:00Z", time.Date(2026, 1, 30, 17, 0, 0, 0, time.UTC)},
+		{"+3d", time.Date(2026, 1, 22, 23, 59, 59, 0, time.Local)},
+		{"+0d", time.Date(2026, 1, 19, 23, 59, 59, 0, time.Local)},
+		{"+2w", time.Date(2026, 2, 2, 23, 59, 59, 0, time.Local)},
+		{"+4h", time.Date(2026, 1, 19, 14, 30, 0, 0, time.Local)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := parseDue(tc.input, now)
+			if err != nil {
+				t.Fatalf("parseDue(%q) returned error: %v", tc.input, err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("parseDue(%q) = %v, want %v", tc.input, got, tc.want)
+			}
+		})
+	}
+
+	t.Run("it rejects anything else", func(t *testing.T) {
+		for _, input := range []string{"", "tomorrow", "+3", "+d", "+-1d", "++3d", "+3s", "+3m", "3d", "30/01/2026"} {
+			if _, err := parseDue(input, now); err == nil {
+				t.Errorf("parseDue(%q) returned nil, want error", input)
+			}
+		}
+	})
+
+	t.Run("it accepts offsets up to about a century and rejects longer ones", func(t *testing.T) {
+		for _, input := range []string{"+5218w", "+36600d", "+878400h"} {
+			if _, err := parseDue(input, now); err != nil {
+				t.Errorf("parseDue(%q) returned error: %v", input, err)
+			}
+		}
+		for _, input := range []string{"+5229w", "+36601d", "+878401h", "+9223372036854775807h", "+99999999999999999999d"} {
+			if _, err := parseDue(input, now); err == nil {
+				t.Errorf("parseDue(%q) returned nil, want error", input)
+			}
+		}
+	})
+}
+
+func TestDue(t *testing.T) {
+	now := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+	past := time.Date(2020, 1, 1, 17, 0, 0, 0, time.UTC)
+	future := time.Date(2099, 1, 1, 17, 0, 0, 0, time.UTC)
+
+	t.Run("it sets a due date on create", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+
+		_, stderr, exitCode := runCreate(t, dir, "Ship release", "--due", "2026-01-30T17:00:00Z")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+
+		got := readPersistedTasks(t, tickDir)[0].Due
+		want := time.Date(2026, 1, 30, 17, 0, 0, 0, time.UTC)
+		if got == nil || !got.Equal(want) {
+			t.Errorf("due = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("it rejects an invalid due date", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+
+		_, stderr, exitCode := runCreate(t, dir, "Ship release", "--due", "someday")
+		if exitCode != 1 {
+			t.Fatalf("exit code = %d, want 1", exitCode)
+		}
+		if !strings.Contains(stderr, "invalid due date 'someday'") {
+			t.Errorf("stderr = %q, want invalid due date error", stderr)
+		}
+		if tasks := readPersistedTasks(t, tickDir); len(tasks) != 0 {
+			t.Errorf("persisted %d tasks, want 0", len(tasks))
+		}
+	})
+
+	t.Run("it changes and clears the due date on update", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Due: &past, Created: now, Updated: now},
+		})
+
+		_, stderr, exitCode := runUpdate(t, dir, "tick-aaa111", "--due", "2099-01-01T17:00:00Z")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if got := readPersistedTasks(t, tickDir)[0].Due; got == nil || !got.Equal(future) {
+			t.Errorf("due = %v, want %v", got, future)
+		}
+
+		_, stderr, exitCode = runUpdate(t, dir, "tick-aaa111", "--clear-due")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if got := readPersistedTasks(t, tickDir)[0].Due; got != nil {
+			t.Errorf("due = %v, want nil", got)
+		}
+	})
+
+	t.Run("it rejects --due with --clear-due", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		})
+
+		_, stderr, exitCode := runUpdate(t, dir, "tick-aaa111", "--due", "+1d", "--clear-due")
+		if exitCode != 1 {
+			t.Fatalf("exit code = %d, want 1", exitCode)
+		}
+		if !strings.Contains(stderr, "--due and --clear-due are mutually exclusive") {
+			t.Errorf("stderr = %q, want mutual exclusion error", stderr)
+		}
+	})
+
+	t.Run("it filters list by overdue and due range", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-late11", Title: "Late", Status: task.StatusOpen, Priority: 2, Due: &past, Created: now, Updated: now},
+			{ID: "tick-done11", Title: "Late but done", Status: task.StatusDone, Priority: 2, Due: &past,
+				Created: now.Add(time.Second), Updated: now.Add(time.Second), Closed: new(now.Add(time.Second))},
+			{ID: "tick-soon11", Title: "Later", Status: task.StatusOpen, Priority: 2, Due: &future,
+				Created: now.Add(2 * time.Second), Updated: now.Add(2 * time.Second)},
+			{ID: "tick-none11", Title: "Whenever", Status: task.StatusOpen, Priority: 2,
+				Created: now.Add(3 * time.Second), Updated: now.Add(3 * time.Second)},
+		})
+
+		stdout, stderr, exitCode := runList(t, dir, "--overdue")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if !strings.Contains(stdout, "tick-late11") || strings.Contains(stdout, "tick-done11") ||
+			strings.Contains(stdout, "tick-soon11") || strings.Contains(stdout, "tick-none11") {
+			t.Errorf("--overdue should list only tick-late11, got %q", stdout)
+		}
+
+		stdout, _, _ = runList(t, dir, "--due-before", "2050-01-01")
+		if !strings.Contains(stdout, "tick-late11") || !strings.Contains(stdout, "tick-done11") ||
+			strings.Contains(stdout, "tick-soon11") || strings.Contains(stdout, "tick-none11") {
+			t.Errorf("--due-before should list the tasks due before 2050, got %q", stdout)
+		}
+
+		stdout, _, _ = runList(t, dir, "--due-after", "2050-01-01")
+		if !strings.Contains(stdout, "tick-soon11") || strings.Contains(stdout, "tick-late11") || strings.Contains(stdout, "tick-none11") {
+			t.Errorf("--due-after should list only tick-soon11, got %q", stdout)
+		}
+	})
+
+	t.Run("it judges overdue at the --as-of instant", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-late11", Title: "Late", Status: task.StatusOpen, Priority: 2, Due: &past, Created: now, Updated: now},
+		})
+
+		stdout, stderr, exitCode := runList(t, dir, "--overdue", "--as-of", "2019-12-31")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if strings.Contains(stdout, "tick-late11") {
+			t.Errorf("task due in 2020 should not be overdue as of 2019, got %q", stdout)
+		}
+	})
+
+	t.Run("it shows a due column that marks overdue tasks", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-late11", Title: "Late", Status: task.StatusOpen, Priority: 2, Due: &past, Created: now, Updated: now},
+			{ID: "tick-none11", Title: "Whenever", Status: task.StatusOpen, Priority: 2,
+				Created: now.Add(time.Second), Updated: now.Add(time.Second)},
+		})
+
+		stdout, stderr, exitCode := runList(t, dir)
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if !strings.Contains(stdout, "DUE") || !strings.Contains(stdout, past.Local().Format("2006-01-02")+" (overdue)") {
+			t.Errorf("pretty list should show a due column marking the overdue task, got %q", stdout)
+		}
+
+		stdout, _, _ = runShow(t, dir, "tick-late11")
+		if !strings.Contains(stdout, "(overdue)") {
+			t.Errorf("pretty show should mark the task overdue, got %q", stdout)
+		}
+	})
+
+	t.Run("it omits the due column when no task has a due date", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-none11", Title: "Whenever", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		})
+
+		stdout, _, _ := runList(t, dir)
+		if strings.Contains(stdout, "DUE") {
+			t.Errorf("pretty list should have no due column, got %q", stdout)
+		}
+	})
+
+	t.Run("it shows the raw due timestamp in toon and json", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-late11", Title: "Late", Status: task.StatusOpen, Priority: 2, Due: &past, Created: now, Updated: now},
+		})
+
+		stdout, _, _ := runTick(t, dir, "--toon", "list")
+		if !strings.Contains(stdout, "{id,title,status,priority,type,due}:\n  tick-late11,Late,open,2,\"\",\"2020-01-01T17:00:00Z\"") {
+			t.Errorf("toon list should include a due column, got %q", stdout)
+		}
+
+		stdout, _, _ = runTick(t, dir, "--json", "show", "tick-late11")
+		var detail struct {
+			Due string `json:"due"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &detail); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+		}
+		if detail.Due != "2020-01-01T17:00:00Z" {
+			t.Errorf("json due = %q, want %q", detail.Due, "2020-01-01T17:00:00Z")
+		}
+	})
+
+	t.Run("it counts overdue tasks in stats", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-late11", Title: "Late", Status: task.StatusOpen, Priority: 2, Due: &past, Created: now, Updated: now},
+			{ID: "tick-done11", Title: "Late but done", Status: task.StatusDone, Priority: 2, Due: &past,
+				Created: now, Updated: now, Closed: &now},
+			{ID: "tick-soon11", Title: "Later", Status: task.StatusOpen, Priority: 2, Due: &future, Created: now, Updated: now},
+		})
+
+		stdout, _, _ := runTick(t, dir, "--json", "stats")
+		var stats struct {
+			Workflow struct {
+				Overdue int `json:"overdue"`
+			} `json:"workflow"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &stats); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+		}
+		if stats.Workflow.Overdue != 1 {
+			t.Errorf("overdue = %d, want 1", stats.Workflow.Overdue)
+		}
+	})
+}
@@ -0,0 +1,201 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+func TestEstimate(t *testing.T) {
+	now := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+
+	// epicTasks is an epic with a story, a closed story and a subtask, plus a
+	// second, smaller epic and an unrelated estimated task.
+	epicTasks := func() []task.Task {
+		return []task.Task{
+			{ID: "tick-epic11", Title: "Auth epic", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+			{ID: "tick-story1", Title: "Login form", Status: task.StatusOpen, Priority: 2, Parent: "tick-epic11", Estimate: 5, Created: now, Updated: now},
+			{ID: "tick-sub111", Title: "Validation", Status: task.StatusOpen, Priority: 2, Parent: "tick-story1", Estimate: 1.5, Created: now, Updated: now},
+			{ID: "tick-done11", Title: "Schema", Status: task.StatusDone, Priority: 2, Parent: "tick-epic11", Estimate: 3, Created: now, Updated: now, Closed: &now},
+			{ID: "tick-epic22", Title: "Docs epic", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+			{ID: "tick-page11", Title: "Guide", Status: task.StatusOpen, Priority: 2, Parent: "tick-epic22", Estimate: 2, Created: now, Updated: now},
+			{ID: "tick-solo11", Title: "Standalone", Status: task.StatusOpen, Priority: 2, Estimate: 4, Created: now, Updated: now},
+		}
+	}
+
+	t.Run("it sets an estimate on create", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+
+		_, stderr, exitCode := runCreate(t, dir, "Add login", "--estimate", "2.5")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if got := readPersistedTasks(t, tickDir)[0].Estimate; got != 2.5 {
+			t.Errorf("estimate = %v, want 2.5", got)
+		}
+	})
+
+	t.Run("it rejects an invalid estimate", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+
+		for _, value := range []string{"3d", "-1"} {
+			_, stderr, exitCode := runCreate(t, dir, "Add login", "--estimate", value)
+			if exitCode != 1 {
+				t.Fatalf("--estimate %s: exit code = %d, want 1", value, exitCode)
+			}
+			if !strings.Contains(stderr, "estimate") {
+				t.Errorf("--estimate %s: stderr = %q, want estimate error", value, stderr)
+			}
+		}
+		if tasks := readPersistedTasks(t, tickDir); len(tasks) != 0 {
+			t.Errorf("persisted %d tasks, want 0", len(tasks))
+		}
+	})
+
+	t.Run("it changes and clears the estimate on update", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Estimate: 3, Created: now, Updated: now},
+		})
+
+		_, stderr, exitCode := runUpdate(t, dir, "tick-aaa111", "--estimate", "8")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if got := readPersistedTasks(t, tickDir)[0].Estimate; got != 8 {
+			t.Errorf("estimate = %v, want 8", got)
+		}
+
+		_, stderr, exitCode = runUpdate(t, dir, "tick-aaa111", "--clear-estimate")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if got := readPersistedTasks(t, tickDir)[0].Estimate; got != 0 {
+			t.Errorf("estimate = %v, want 0", got)
+		}
+	})
+
+	t.Run("it rejects --estimate with --clear-estimate", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		})
+
+		_, stderr, exitCode := runUpdate(t, dir, "tick-aaa111", "--estimate", "2", "--clear-estimate")
+		if exitCode != 1 {
+			t.Fatalf("exit code = %d, want 1", exitCode)
+		}
+		if !strings.Contains(stderr, "--estimate and --clear-estimate are mutually exclusive") {
+			t.Errorf("stderr = %q, want mutual exclusion error", stderr)
+		}
+	})
+
+	t.Run("it shows the estimate and the remaining rollup in every format", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, epicTasks())
+
+		stdout, stderr, exitCode := runShow(t, dir, "tick-story1")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if !strings.Contains(stdout, "Estimate: 5\nRollup:   6.5 remaining of 6.5\n") {
+			t.Errorf("pretty output should show estimate and rollup, got %q", stdout)
+		}
+
+		stdout, _, _ = runShow(t, dir, "tick-epic11")
+		if strings.Contains(stdout, "Estimate:") || !strings.Contains(stdout, "Rollup:   6.5 remaining of 9.5\n") {
+			t.Errorf("pretty output should show only the rollup for an unestimated epic, got %q", stdout)
+		}
+
+		stdout, _, _ = runTick(t, dir, "--toon", "show", "tick-epic11")
+		if !strings.Contains(stdout, "rollup{total,remaining}:\n  9.5,6.5") {
+			t.Errorf("toon output should contain a rollup section, got %q", stdout)
+		}
+
+		stdout, _, _ = runTick(t, dir, "--json", "show", "tick-story1")
+		var detail struct {
+			Estimate float64 `json:"estimate"`
+			Rollup   *struct {
+				Total     float64 `json:"total"`
+				Remaining float64 `json:"remaining"`
+			} `json:"rollup"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &detail); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+		}
+		if detail.Estimate != 5 || detail.Rollup == nil || detail.Rollup.Total != 6.5 || detail.Rollup.Remaining != 6.5 {
+			t.Errorf("json estimate = %v, rollup = %+v, want 5 and 6.5 of 6.5", detail.Estimate, detail.Rollup)
+		}
+	})
+
+	t.Run("it omits the rollup for a task without children", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, epicTasks())
+
+		stdout, _, _ := runShow(t, dir, "tick-solo11")
+		if !strings.Contains(stdout, "Estimate: 4\n") || strings.Contains(stdout, "Rollup:") {
+			t.Errorf("pretty output should show the estimate without a rollup, got %q", stdout)
+		}
+
+		stdout, _, _ = runTick(t, dir, "--json", "show", "tick-solo11")
+		if strings.Contains(stdout, `"rollup"`) {
+			t.Errorf("json output should omit the rollup, got %q", stdout)
+		}
+	})
+
+	t.Run("it rolls up estimates under each top-level task in stats", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, epicTasks())
+
+		stdout, stderr, exitCode := runTick(t, dir, "--pretty", "stats")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		want := "Estimates:\n" +
+			"  Total:     15.5\n" +
+			"  Remaining: 12.5\n" +
+			"\n" +
+			"Remaining by parent:\n" +
+			"  tick-epic11  6.5 of 9.5  Auth epic\n" +
+			"  tick-epic22  2 of 2      Docs epic"
+		if !strings.Contains(stdout, want) {
+			t.Errorf("pretty stats should end with the estimates, got %q", stdout)
+		}
+
+		stdout, _, _ = runTick(t, dir, "--toon", "stats")
+		if !strings.Contains(stdout, "estimates{total,remaining}:\n  15.5,12.5\n\nrollups[2]{id,title,total,remaining}:\n  tick-epic11,Auth epic,9.5,6.5\n  tick-epic22,Docs epic,2,2") {
+			t.Errorf("toon stats should contain estimates and rollups, got %q", stdout)
+		}
+
+		stdout, _, _ = runTick(t, dir, "--json", "stats")
+		var stats struct {
+			Estimates struct {
+				Total     float64 `json:"total"`
+				Remaining float64 `json:"remaining"`
+				Rollups   []struct {
+					ID        string  `json:"id"`
+					Remaining float64 `json:"remaining"`
+				} `json:"rollups"`
+			} `json:"estimates"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &stats); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+		}
+		if stats.Estimates.Total != 15.5 || stats.Estimates.Remaining != 12.5 || len(stats.Estimates.Rollups) != 2 || stats.Estimates.Rollups[0].ID != "tick-epic11" {
+			t.Errorf("json estimates = %+v, want 15.5 total, 12.5 remaining and two rollups", stats.Estimates)
+		}
+	})
+
+	t.Run("it omits the estimates from stats when nothing is estimated", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		})
+
+		stdout, _, _ := runTick(t, dir, "--pretty", "stats")
+		if strings.Contains(stdout, "Estimates:") {
+			t.Errorf("pretty stats should have no estimates group, got %q", stdout)
+		}
+		stdout, _, _ = runTick(t, dir, "--toon", "stats")
+		if strings.Contains(stdout, "estimates") || strings.Contains(stdout, "rollups") {
+			t.Errorf("toon stats should have no estimate sections, got %q", stdout)
+		}
+	})
+}
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+func TestFields(t *testing.T) {
+	now := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+
+	t.Run("it sets fields on create with repeated --field", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+
+		_, stderr, exitCode := runCreate(t, dir, "Add login", "--field", "estimate=3d", "--field", "PR_URL=https://example.com/pull/1")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+
+		tasks := readPersistedTasks(t, tickDir)
+		got := tasks[0].Fields
+		if len(got) != 2 || got["estimate"] != "3d" || got["pr_url"] != "https://example.com/pull/1" {
+			t.Errorf("fields = %v, want estimate=3d and pr_url", got)
+		}
+	})
+
+	t.Run("it rejects --field without a value", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+
+		_, stderr, exitCode := runCreate(t, dir, "Add login", "--field", "estimate")
+		if exitCode != 1 {
+			t.Fatalf("exit code = %d, want 1", exitCode)
+		}
+		if !strings.Contains(stderr, "use key=value") {
+			t.Errorf("stderr = %q, want key=value usage", stderr)
+		}
+		if tasks := readPersistedTasks(t, tickDir); len(tasks) != 0 {
+			t.Errorf("persisted %d tasks, want 0", len(tasks))
+		}
+	})
+
+	t.Run("it sets, replaces and clears fields on update", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2,
+				Fields: map[string]string{"estimate": "3d", "sprint": "12"}, Created: now, Updated: now},
+		})
+
+		_, stderr, exitCode := runUpdate(t, dir, "tick-aaa111", "--field", "estimate=5d", "--field", "component=api", "--clear-field", "sprint")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+
+		got := readPersistedTasks(t, tickDir)[0].Fields
+		if len(got) != 2 || got["estimate"] != "5d" || got["component"] != "api" {
+			t.Errorf("fields = %v, want estimate=5d and component=api", got)
+		}
+	})
+
+	t.Run("it drops the fields key when the last field is cleared", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2,
+				Fields: map[string]string{"estimate": "3d"}, Created: now, Updated: now},
+		})
+
+		_, stderr, exitCode := runUpdate(t, dir, "tick-aaa111", "--clear-field", "estimate")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+
+		if got := readPersistedTasks(t, tickDir)[0].Fields; got != nil {
+			t.Errorf("fields = %v, want nil", got)
+		}
+	})
+
+	t.Run("it rejects setting and clearing the same field", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		})
+
+		_, stderr, exitCode := runUpdate(t, dir, "tick-aaa111", "--field", "estimate=3d", "--clear-field", "estimate")
+		if exitCode != 1 {
+			t.Fatalf("exit code = %d, want 1", exitCode)
+		}
+		if !strings.Contains(stderr, "cannot be both set and cleared") {
+			t.Errorf("stderr = %q, want set-and-cleared error", stderr)
+		}
+	})
+
+	t.Run("it filters list by field value and by field presence", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-api111", Title: "API", Status: task.StatusOpen, Priority: 2,
+				Fields: map[string]string{"component": "api", "sprint": "12"}, Created: now, Updated: now},
+			{ID: "tick-web111", Title: "Web", Status: task.StatusOpen, Priority: 2,
+				Fields: map[string]string{"component": "web"}, Created: now.Add(time.Second), Updated: now.Add(time.Second)},
+			{ID: "tick-none11", Title: "None", Status: task.StatusOpen, Priority: 2,
+				Created: now.Add(2 * time.Second), Updated: now.Add(2 * time.Second)},
+		})
+
+		stdout, stderr, exitCode := runList(t, dir, "--field", "component=api")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if !strings.Contains(stdout, "tick-api111") || strings.Contains(stdout, "tick-web111") || strings.Contains(stdout, "tick-none11") {
+			t.Errorf("--field component=api should list only tick-api111, got %q", stdout)
+		}
+
+		stdout, _, _ = runList(t, dir, "--field", "component")
+		if !strings.Contains(stdout, "tick-api111") || !strings.Contains(stdout, "tick-web111") || strings.Contains(stdout, "tick-none11") {
+			t.Errorf("--field component should list both tasks with the field, got %q", stdout)
+		}
+
+		stdout, _, _ = runList(t, dir, "--field", "component", "--field", "sprint=12")
+		if !strings.Contains(stdout, "tick-api111") || strings.Contains(stdout, "tick-web111") {
+			t.Errorf("repeated --field should AND the filters, got %q", stdout)
+		}
+	})
+
+	t.Run("it rejects an invalid field key in a filter", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+
+		_, stderr, exitCode := runList(t, dir, "--field", "Pr-Url")
+		if exitCode != 1 {
+			t.Fatalf("exit code = %d, want 1", exitCode)
+		}
+		if !strings.Contains(stderr, "invalid field key") {
+			t.Errorf("stderr = %q, want invalid field key error", stderr)
+		}
+	})
+
+	t.Run("it shows fields in every format", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2,
+				Fields: map[string]string{"pr_url": "https://example.com/pull/1", "estimate": "3d"}, Created: now, Updated: now},
+		})
+
+		stdout, stderr, exitCode := runShow(t, dir, "tick-aaa111")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if !strings.Contains(stdout, "\n\nFields:\n  estimate: 3d\n  pr_url:   https://example.com/pull/1") {
+			t.Errorf("pretty output should list aligned fields, got %q", stdout)
+		}
+
+		stdout, _, _ = runTick(t, dir, "--toon", "show", "tick-aaa111")
+		if !strings.Contains(stdout, "fields[2]{key,value}:\n  estimate,3d\n  pr_url,") {
+			t.Errorf("toon output should contain a fields section, got %q", stdout)
+		}
+
+		stdout, _, _ = runTick(t, dir, "--json", "show", "tick-aaa111")
+		var detail struct {
+			Fields map[string]string `json:"fields"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &detail); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+		}
+		if len(detail.Fields) != 2 || detail.Fields["estimate"] != "3d" {
+			t.Errorf("json fields = %v, want estimate and pr_url", detail.Fields)
+		}
+	})
+
+	t.Run("it shows an empty fields object in JSON when the task has none", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		})
+
+		stdout, _, _ := runTick(t, dir, "--json", "show", "tick-aaa111")
+		if !strings.Contains(stdout, `"fields": {}`) {
+			t.Errorf("json output should contain an empty fields object, got %q", stdout)
+		}
+	})
+}
@@ -14,6 +14,121 @@ func TestFlagValidationAllCommands(t *testing.T) {
 	}
 
 	commandsWithFlags := []commandTestCase{
+		{
+			command: "init",
+			validArgs: []string{
+				"--merge-driver",
+				"--layout", "files",
+			},
+			flagCount: 2,
+		},
+		{
+			command: "storage convert",
+			validArgs: []string{
+				"--layout", "files",
+			},
+			flagCount: 1,
+		},
+		{
+			command: "undo",
+			validArgs: []string{
+				"--entry", "3",
+			},
+			flagCount: 1,
+		},
+		{
+			command: "journal",
+			validArgs: []string{
+				"--count", "5",
+			},
+			flagCount: 1,
+		},
+		{
+			command: "archive",
+			validArgs: []string{
+				"--older-than", "30d",
+				"--dry-run",
+			},
+			flagCount: 2,
+		},
+		{
+			command: "upgrade",
+			validArgs: []string{
+				"--dry-run",
+			},
+			flagCount: 1,
+		},
+		{
+			command: "search",
+			validArgs: []string{
+				"login",
+				"--ready",
+				"--blocked",
+				"--include-deferred",
+				"--status", "open",
+				"--type", "bug",
+				"--tag", "ui",
+				"--field", "component=api",
+				"--assignee", "agent-1",
+				"--unassigned",
+				"--parent", "tick-aaa111",
+				"--count", "5",
+				"--workspace",
+			},
+			flagCount: 12,
+		},
+		{
+			command: "claim",
+			validArgs: []string{
+				"--agent", "agent-1",
+				"--lease", "10m",
+				"--priority", "1",
+				"--type", "bug",
+				"--tag", "ui",
+				"--field", "component=api",
+				"--parent", "tick-aaa111",
+			},
+			flagCount: 7,
+		},
+		{
+			command: "heartbeat",
+			validArgs: []string{
+				"tick-aaa111",
+				"--agent", "agent-1",
+				"--lease", "10m",
+			},
+			flagCount: 2,
+		},
+		{
+			command:   "defer",
+			validArgs: []string{"tick-aaa111", "3d"},
+			flagCount: 0,
+		},
+		{
+			command:   "undefer",
+			validArgs: []string{"tick-aaa111"},
+			flagCount: 0,
+		},
+		{
+			command:   "critical-path",
+			validArgs: []string{"tick-aaa111"},
+			flagCount: 0,
+		},
+		{
+			command: "watch",
+			validArgs: []string{
+				"--status", "open",
+				"--priority", "1",
+				"--type", "bug",
+				"--tag", "ui",
+				"--field", "component=api",
+				"--assignee", "agent-1",
+				"--unassigned",
+				"--parent", "tick-aaa111",
+				"--interval", "2s",
+			},
+			flagCount: 9,
+		},
 		{
 			command: "create",
 			validArgs: []string{
@@ -26,8 +141,15 @@ func TestFlagValidationAllCommands(t *testing.T) {
 				"--type", "bug",
 				"--tags", "frontend,backend",
 				"--refs", "https://example.com",
+				"--field", "sprint=12",
+				"--assignee", "agent-1",
+				"--due", "2026-02-01",
+				"--estimate", "3",
+				"--recur", "weekly",
+				"--template", "endpoint",
+				"--var", "name=login",
 			},
-			flagCount: 8,
+			flagCount: 15,
 		},
 		{
 			command: "update",
@@ -44,9 +166,19 @@ func TestFlagValidationAllCommands(t *testing.T) {
 				"--clear-tags",
 				"--refs", "https://example.com",
 				"--clear-refs",
+				"--field", "sprint=12",
+				"--clear-field", "component",
+				"--assignee", "agent-1",
+				"--unassign",
+				"--due", "+3d",
+				"--clear-due",
+				"--estimate", "2.5",
+				"--clear-estimate",
+				"--recur", "every 2 weeks",
+				"--clear-recur",
 				"--blocks", "tick-bbb222",
 			},
-			flagCount: 12,
+			flagCount: 22,
 		},
 		{
 			command: "list",
@@ -58,9 +190,18 @@ func TestFlagValidationAllCommands(t *testing.T) {
 				"--parent", "tick-aaa111",
 				"--type", "bug",
 				"--tag", "frontend",
+				"--field", "component=api",
+				"--assignee", "agent-1",
+				"--unassigned",
+				"--overdue",
+				"--recurring",
+				"--due-before", "2026-02-01",
+				"--due-after", "2026-01-01",
+				"--include-deferred",
 				"--count", "10",
+				"--workspace",
 			},
-			flagCount: 8,
+			flagCount: 17,
 		},
 		{
 			command: "ready",
@@ -70,9 +211,18 @@ func TestFlagValidationAllCommands(t *testing.T) {
 				"--parent", "tick-aaa111",
 				"--type", "bug",
 				"--tag", "frontend",
+				"--field", "component=api",
+				"--assignee", "agent-1",
+				"--unassigned",
+				"--overdue",
+				"--recurring",
+				"--due-before", "2026-02-01",
+				"--due-after", "2026-01-01",
+				"--include-deferred",
 				"--count", "10",
+				"--workspace",
 			},
-			flagCount: 6,
+			flagCount: 15,
 		},
 		{
 			command: "blocked",
@@ -82,9 +232,39 @@ func TestFlagValidationAllCommands(t *testing.T) {
 				"--parent", "tick-aaa111",
 				"--type", "bug",
 				"--tag", "frontend",
+				"--field", "component=api",
+				"--assignee", "agent-1",
+				"--unassigned",
+				"--overdue",
+				"--recurring",
+				"--due-before", "2026-02-01",
+				"--due-after", "2026-01-01",
+				"--include-deferred",
 				"--count", "10",
+				"--workspace",
 			},
-			flagCount: 6,
+			flagCount: 15,
+		},
+		{
+			command: "mine",
+			validArgs: []string{
+				"--ready",
+				"--blocked",
+				"--status", "open",
+				"--priority", "1",
+				"--parent", "tick-aaa111",
+				"--type", "bug",
+				"--tag", "frontend",
+				"--field", "component=api",
+				"--overdue",
+				"--recurring",
+				"--due-before", "2026-02-01",
+				"--due-after", "2026-01-01",
+				"--include-deferred",
+				"--count", "10",
+				"--workspace",
+			},
+			flagCount: 15,
 		},
 		{
 			command: "remove",
@@ -103,6 +283,11 @@ func TestFlagValidationAllCommands(t *testing.T) {
 			},
 			flagCount: 3,
 		},
+		{
+			command:   "stats",
+			validArgs: []string{"--workspace"},
+			flagCount: 1,
+		},
 	}
 
 	for _, tc := range commandsWithFlags {
@@ -134,9 +319,10 @@ func TestFlagValidationAllCommands(t *testing.T) {
 
 	// Commands with no flags — every unknown flag must be rejected.
 	noFlagCommands := []string{
-		"init", "show", "start", "done", "cancel", "reopen",
+		"show", "start", "done", "cancel", "reopen",
 		"dep add", "dep remove", "dep tree", "note add", "note remove",
-		"stats", "doctor", "rebuild",
+		"doctor", "rebuild", "merge-driver", "redo",
+		"unarchive", "config get", "config set", "config list",
 	}
 
 	for _, cmd := range noFlagCommands {
@@ -187,8 +373,8 @@ func TestBlockedRejectsBlocked(t *testing.T) {
 }
 
 func TestGlobalFlagsAcceptedOnAnyCommand(t *testing.T) {
-	globalFlags := []string{"--quiet", "-q", "--verbose", "-v", "--toon", "--pretty", "--json", "--help", "-h", "--version", "-V"}
-	commands := []string{"create", "list", "show", "dep add", "dep remove", "dep tree", "update", "remove", "ready", "blocked", "migrate", "start", "done", "cancel", "reopen", "init", "stats", "doctor", "rebuild", "note add", "note remove"}
+	globalFlags := []string{"--quiet", "-q", "--verbose", "-v", "--toon", "--pretty", "--json", "--help", "-h", "--version", "-V", "--include-archived"}
+	commands := []string{"create", "list", "show", "dep add", "dep remove", "dep tree", "update", "remove", "ready", "blocked", "mine", "migrate", "start", "done", "cancel", "reopen", "init", "stats", "doctor", "rebuild", "note add", "note remove", "merge-driver", "search", "undo", "redo", "journal", "storage convert", "lock status", "config get", "config set", "config list", "batch", "archive", "unarchive", "upgrade", "watch", "claim", "heartbeat", "defer", "undefer", "critical-path"}
 
 	for _, cmd := range commands {
 		for _, gf := range globalFlags {
@@ -19,22 +19,28 @@ type CommandFlags map[string]map[string]FlagDef
 // These are stripped by parseArgs before dispatch but may appear in subArgs
 // when validation runs before global stripping.
 var globalFlagSet = map[string]bool{
-	"--quiet":   true,
-	"-q":        true,
-	"--verbose": true,
-	"-v":        true,
-	"--toon":    true,
-	"--pretty":  true,
-	"--json":    true,
-	"--help":    true,
-	"-h":        true,
-	"--version": true,
-	"-V":        true,
+	"--quiet":            true,
+	"-q":                 true,
+	"--verbose":          true,
+	"-v":                 true,
+	"--toon":             true,
+	"--pretty":           true,
+	"--json":             true,
+	"--help":             true,
+	"-h":                 true,
+	"--version":          true,
+	"-V":                 true,
+	"--include-archived": true,
+	"--lock-timeout":     true,
+	"--as-of":            true,
 }
 
 // commandFlags is the central registry of valid per-command flags.
 var commandFlags = CommandFlags{
-	"init": {},
+	"init": {
+		"--merge-driver": {TakesValue: false},
+		"--layout":       {TakesValue: true},
+	},
 	"create": {
 		"--priority":    {TakesValue: true},
 		"--description": {TakesValue: true},
@@ -44,6 +50,13 @@ var commandFlags = CommandFlags{
 		"--type":        {TakesValue: true},
 		"--tags":        {TakesValue: true},
 		"--refs":        {TakesValue: true},
+		"--field":       {TakesValue: true},
+		"--assignee":    {TakesValue: true},
+		"--due":         {TakesValue: true},
+		"--estimate":    {TakesValue: true},
+		"--recur":       {TakesValue: true},
+		"--template":    {TakesValue: true},
+		"--var":         {TakesValue: true},
 	},
 	"update": {
 		"--title":             {TakesValue: true},
@@ -57,17 +70,36 @@ var commandFlags = CommandFlags{
 		"--clear-tags":        {TakesValue: false},
 		"--refs":              {TakesValue: true},
 		"--clear-refs":        {TakesValue: false},
+		"--field":             {TakesValue: true},
+		"--clear-field":       {TakesValue: true},
+		"--assignee":          {TakesValue: true},
+		"--unassign":          {TakesValue: false},
+		"--due":               {TakesValue: true},
+		"--clear-due":         {TakesValue: false},
+		"--estimate":          {TakesValue: true},
+		"--clear-estimate":    {TakesValue: false},
+		"--recur":             {TakesValue: true},
+		"--clear-recur":       {TakesValue: false},
 		"--blocks":            {TakesValue: true},
 	},
 	"list": {
-		"--ready":    {TakesValue: false},
-		"--blocked":  {TakesValue: false},
-		"--status":   {TakesValue: true},
-		"--priority": {TakesValue: true},
-		"--parent":   {TakesValue: true},
-		"--type":     {TakesValue: true},
-		"--tag":      {TakesValue: true},
-		"--count":    {TakesValue: true},
+		"--ready":            {TakesValue: false},
+		"--blocked":          {TakesValue: false},
+		"--include-deferred": {TakesValue: false},
+		"--status":           {TakesValue: true},
+		"--priority":         {TakesValue: true},
+		"--parent":           {TakesValue: true},
+		"--type":             {TakesValue: true},
+		"--tag":              {TakesValue: true},
+		"--field":            {TakesValue: true},
+		"--assignee":         {TakesValue: true},
+		"--unassigned":       {TakesValue: false},
+		"--overdue":          {TakesValue: false},
+		"--recurring":        {TakesValue: false},
+		"--due-before":       {TakesValue: true},
+		"--due-after":        {TakesValue: true},
+		"--count":            {TakesValue: true},
+		"--workspace":        {TakesValue: false},
 	},
 	"show":        {},
 	"start":       {},
@@ -83,19 +115,87 @@ var commandFlags = CommandFlags{
 		"--force": {TakesValue: false},
 		"-f":      {TakesValue: false},
 	},
-	"stats":   {},
+	"search": {
+		"--ready":            {TakesValue: false},
+		"--blocked":          {TakesValue: false},
+		"--include-deferred": {TakesValue: false},
+		"--status":           {TakesValue: true},
+		"--parent":           {TakesValue: true},
+		"--type":             {TakesValue: true},
+		"--tag":              {TakesValue: true},
+		"--field":            {TakesValue: true},
+		"--assignee":         {TakesValue: true},
+		"--unassigned":       {TakesValue: false},
+		"--count":            {TakesValue: true},
+		"--workspace":        {TakesValue: false},
+	},
+	"claim": {
+		"--agent":    {TakesValue: true},
+		"--lease":    {TakesValue: true},
+		"--priority": {TakesValue: true},
+		"--parent":   {TakesValue: true},
+		"--type":     {TakesValue: true},
+		"--tag":      {TakesValue: true},
+		"--field":    {TakesValue: true},
+	},
+	"heartbeat": {
+		"--agent": {TakesValue: true},
+		"--lease": {TakesValue: true},
+	},
+	"defer":         {},
+	"undefer":       {},
+	"critical-path": {},
+	"archive": {
+		"--older-than": {TakesValue: true},
+		"--dry-run":    {TakesValue: false},
+	},
+	"unarchive": {},
+	"batch":     {},
+	"storage convert": {
+		"--layout": {TakesValue: true},
+	},
+	"lock status": {},
+	"config get":  {},
+	"config set":  {},
+	"config list": {},
+	"upgrade": {
+		"--dry-run": {TakesValue: false},
+	},
+	"undo": {
+		"--entry": {TakesValue: true},
+	},
+	"redo": {},
+	"journal": {
+		"--count": {TakesValue: true},
+	},
+	"stats": {
+		"--workspace": {TakesValue: false},
+	},
 	"doctor":  {},
 	"rebuild": {},
+	"watch": {
+		"--status":     {TakesValue: true},
+		"--priority":   {TakesValue: true},
+		"--parent":     {TakesValue: true},
+		"--type":       {TakesValue: true},
+		"--tag":        {TakesValue: true},
+		"--field":      {TakesValue: true},
+		"--assignee":   {TakesValue: true},
+		"--unassigned": {TakesValue: false},
+		"--interval":   {TakesValue: true},
+	},
 	"migrate": {
 		"--from":         {TakesValue: true},
 		"--dry-run":      {TakesValue: false},
 		"--pending-only": {TakesValue: false},
 	},
+	"merge-driver": {},
 }
 
 func init() {
 	commandFlags["ready"] = copyFlagsExcept(commandFlags["list"], "--ready", "--blocked")
 	commandFlags["blocked"] = copyFlagsExcept(commandFlags["list"], "--blocked", "--ready")
+	commandFlags["mine"] = copyFlagsExcept(commandFlags["list"], "--assignee", "--unassigned")
 }
 
 // copyFlagsExcept returns a shallow copy of source with the excluded keys removed.
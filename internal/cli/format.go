@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/leeovery/tick/internal/config"
+	"github.com/leeovery/tick/internal/query"
 	"github.com/leeovery/tick/internal/task"
+	"github.com/leeovery/tick/tick"
 )
 
 // Format represents the selected output format for CLI responses.
@@ -69,6 +73,29 @@ type FormatConfig struct {
 	Verbose bool
 	// Logger is the verbose logger. Nil when verbose is disabled.
 	Logger *VerboseLogger
+	// Command is the invoking command line, recorded in the mutation journal.
+	Command string
+	// IncludeArchived makes reads cover archived tasks; mutations are refused.
+	IncludeArchived bool
+	// LockTimeout is how long to wait for the .tick lock. Zero means the
+	// project's lock_timeout setting.
+	LockTimeout time.Duration
+	// AsOf, when non-zero, makes reads show the tasks as they stood at that
+	// instant; mutations are refused.
+	AsOf time.Time
+	// Config is the configuration of the project in the working directory,
+	// loaded once by Run and handed to its store. Nil when there is no project
+	// or its config is invalid, so opening the store reports the problem.
+	Config *config.Config
+}
+
+// now returns the instant reads are judged at, such as whether a task is
+// overdue: the --as-of time if given, otherwise the current time.
+func (fc FormatConfig) now() time.Time {
+	if !fc.AsOf.IsZero() {
+		return fc.AsOf
+	}
+	return time.Now()
 }
 
 // NewFormatConfig builds a FormatConfig from parsed global flags and TTY state.
@@ -78,30 +105,24 @@ func NewFormatConfig(flags globalFlags, isTTY bool) (FormatConfig, error) {
 		return FormatConfig{}, err
 	}
 	return FormatConfig{
-		Format:  f,
-		Quiet:   flags.quiet,
-		Verbose: flags.verbose,
+		Format:          f,
+		Quiet:           flags.quiet,
+		Verbose:         flags.verbose,
+		IncludeArchived: flags.includeArchived,
+		LockTimeout:     flags.lockTimeout,
+		AsOf:            flags.asOf,
 	}, nil
 }
 
 // RelatedTask represents a task referenced in blocked_by or children sections of show output.
-type RelatedTask struct {
-	ID     string
-	Title  string
-	Status string
-}
+type RelatedTask = tick.RelatedTask
+
+// TaskField is a custom key/value field in the fields section of show output.
+type TaskField = tick.Field
 
 // TaskDetail holds all data needed to render the show command output,
-// including the task itself plus related context (blockers, children, parent title, tags, refs, notes).
-type TaskDetail struct {
-	Task        task.Task
-	BlockedBy   []RelatedTask
-	Children    []RelatedTask
-	ParentTitle string
-	Tags        []string
-	Refs        []string
-	Notes       []task.Note
-}
+// including the task itself plus related context (blockers, children, parent title, tags, refs, fields, notes).
+type TaskDetail = tick.TaskDetail
 
 // Stats holds typed task statistics for rendering by formatters.
 type Stats struct {
@@ -110,9 +131,53 @@ type Stats struct {
 	InProgress int
 	Done       int
 	Cancelled  int
+	// ByState counts tasks in the project's own workflow states, in workflow
+	// order. Every state is listed, even with no tasks.
+	ByState    []StateCount
 	Ready      int
 	Blocked    int
 	ByPriority [5]int // index 0-4 maps to priority P0-P4
+	// ByType counts tasks by type: every type the project declares, in order,
+	// then any undeclared types found on tasks, by name. Untyped tasks are not
+	// counted.
+	ByType []TypeCount
+	// ByAssignee counts live tasks, in an active or waiting state, by
+	// assignee, by name. Unassigned tasks are not counted.
+	ByAssignee []AssigneeCount
+	// Overdue counts the tasks that are not closed and past their due date.
+	Overdue int
+	// Estimates totals the estimates of every task.
+	Estimates Rollup
+	// Rollups totals the estimates under each top-level task that has
+	// children, most remaining work first. Subtrees without an estimate are
+	// left out.
+	Rollups []TaskRollup
+}
+
+// Rollup totals estimates, and those of the tasks not yet closed.
+type Rollup = tick.Rollup
+
+// TaskRollup is the estimate rollup of a task and all its descendants.
+type TaskRollup = query.TaskRollup
+
+// AssigneeCount is the number of live tasks assigned to one owner.
+type AssigneeCount struct {
+	Assignee string
+	Count    int
+}
+
+// StateCount is the number of tasks in one of the project's own workflow
+// states.
+type StateCount struct {
+	State    string
+	Category string
+	Count    int
+}
+
+// TypeCount is the number of tasks of one type.
+type TypeCount struct {
+	Type  string
+	Count int
 }
 
 // RemovedTask holds the ID and title of a task that was removed.
@@ -128,23 +193,11 @@ type RemovalResult struct {
 }
 
 // CascadeEntry holds a single cascaded status change for display.
-type CascadeEntry struct {
-	ID        string
-	Title     string
-	ParentID  string
-	OldStatus string
-	NewStatus string
-}
+type CascadeEntry = tick.CascadeEntry
 
 // CascadeResult holds all data needed to render a cascade transition:
 // the primary transition and cascaded changes.
-type CascadeResult struct {
-	TaskID    string
-	TaskTitle string
-	OldStatus string
-	NewStatus string
-	Cascaded  []CascadeEntry
-}
+type CascadeResult = tick.CascadeResult
 
 // DepTreeTask holds the minimal task data needed for dependency tree rendering.
 type DepTreeTask struct {
@@ -179,6 +232,93 @@ type DepTreeResult struct {
 	Message string
 }
 
+// CriticalPathTask is a task on the critical path. A zero Estimate means the
+// task is unestimated.
+type CriticalPathTask struct {
+	ID       string
+	Title    string
+	Status   string
+	Estimate float64
+}
+
+// CriticalPathResult holds all data needed to render the critical-path command
+// output: the longest chain of remaining work by estimate, in the order it has
+// to be done.
+type CriticalPathResult struct {
+	// Target is the task the path leads to; nil for the whole project.
+	Target *DepTreeTask
+	Tasks  []CriticalPathTask
+	// Total sums the estimates of Tasks.
+	Total float64
+	// Message explains an empty path (e.g., "No remaining work.").
+	Message string
+}
+
+// JournalRow holds a single journal entry for display by the journal command.
+// Target is the entry an undo or redo applies to; Undone marks mutations that
+// are currently reverted.
+type JournalRow struct {
+	Seq     int
+	At      time.Time
+	Op      string
+	Target  int
+	Command string
+	TaskIDs []string
+	Undone  bool
+}
+
+// BatchSummary holds the outcome of a batch for display by the batch command:
+// one row per operation, in order, and every status change the batch made.
+type BatchSummary struct {
+	Rows          []BatchRow
+	StatusChanges []BatchStatusChange
+}
+
+// BatchRow describes one applied batch operation. Ref is the operation's "as"
+// name, for creates that set one.
+type BatchRow struct {
+	Op  string
+	ID  string
+	Ref string
+}
+
+// BatchStatusChange is a status change made by a batch. Auto marks changes
+// cascaded from an operation rather than requested by it.
+type BatchStatusChange struct {
+	ID   string
+	From string
+	To   string
+	Auto bool
+}
+
+// LockStatus holds the state of the .tick lock for display by the lock status
+// command. Held reports whether some process holds the lock right now; Holders
+// lists the recorded holders, oldest first, including any left by processes
+// that died (Alive false).
+type LockStatus struct {
+	Held    bool
+	Holders []LockHolderRow
+}
+
+// LockHolderRow describes one recorded holder of the .tick lock.
+type LockHolderRow struct {
+	PID      int
+	Hostname string
+	Command  string
+	Mode     string
+	Started  time.Time
+	Alive    bool
+}
+
+// ConfigEntry holds one configuration setting for display by the config list
+// command. List values are comma-separated; Default marks settings the project
+// has not changed.
+type ConfigEntry struct {
+	Key     string
+	Value   string
+	Default bool
+}
+
 // Formatter defines the interface for rendering CLI output in different formats.
 // Concrete implementations (Toon, Pretty, JSON) are provided by tasks 4-2 through 4-4.
 type Formatter interface {
@@ -200,6 +340,18 @@ type Formatter interface {
 	FormatCascadeTransition(result CascadeResult) string
 	// FormatDepTree renders a dependency tree visualization.
 	FormatDepTree(result DepTreeResult) string
+	// FormatCriticalPath renders the longest chain of remaining work.
+	FormatCriticalPath(result CriticalPathResult) string
+	// FormatSearchResults renders ranked full-text search matches with snippets.
+	FormatSearchResults(results []SearchResult) string
+	// FormatJournal renders mutation journal entries, newest first.
+	FormatJournal(rows []JournalRow) string
+	// FormatLockStatus renders the state of the .tick lock and its holders.
+	FormatLockStatus(status LockStatus) string
+	// FormatBatch renders the operations and status changes of a batch.
+	FormatBatch(summary BatchSummary) string
+	// FormatConfig renders the project's configuration settings.
+	FormatConfig(entries []ConfigEntry) string
 }
 
 // baseFormatter provides shared implementations of FormatTransition, FormatDepChange,
@@ -274,6 +426,24 @@ func (s *StubFormatter) FormatCascadeTransition(_ CascadeResult) string { return
 // FormatDepTree returns an empty string (stub).
 func (s *StubFormatter) FormatDepTree(_ DepTreeResult) string { return "" }
 
+// FormatCriticalPath returns an empty string (stub).
+func (s *StubFormatter) FormatCriticalPath(_ CriticalPathResult) string { return "" }
+
+// FormatSearchResults returns an empty string (stub).
+func (s *StubFormatter) FormatSearchResults(_ []SearchResult) string { return "" }
+
+// FormatJournal returns an empty string (stub).
+func (s *StubFormatter) FormatJournal(_ []JournalRow) string { return "" }
+
+// FormatLockStatus returns an empty string (stub).
+func (s *StubFormatter) FormatLockStatus(_ LockStatus) string { return "" }
+
+// FormatBatch returns an empty string (stub).
+func (s *StubFormatter) FormatBatch(_ BatchSummary) string { return "" }
+
+// FormatConfig returns an empty string (stub).
+func (s *StubFormatter) FormatConfig(_ []ConfigEntry) string { return "" }
+
 // NewFormatter creates a Formatter for the given Format.
 func NewFormatter(f Format) Formatter {
 	switch f {
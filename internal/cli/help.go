@@ -3,6 +3,9 @@ package cli
 import (
 	"fmt"
 	"io"
+	"strings"
+
+	"github.com/leeovery/tick/internal/config"
 )
 
 // flagInfo describes a single command flag for help output.
@@ -20,6 +23,8 @@ type commandInfo struct {
 	Usage       string // "tick create <title> [flags]"
 	Description string // multi-line detail
 	Flags       []flagInfo
+	// Types adds the project's task types to the command's detailed help.
+	Types bool
 }
 
 // commands is the ordered registry of all tick commands.
@@ -27,50 +32,76 @@ var commands = []commandInfo{
 	{
 		Name:    "init",
 		Summary: "Initialize a new tick project",
-		Usage:   "tick init",
+		Usage:   "tick init [flags]",
 		Description: "Creates a .tick/ directory in the current working directory with an\n" +
-			"empty tasks.jsonl file. Errors if already initialized.",
+			"empty tasks.jsonl file, or an empty tasks/ directory with --layout files.\n" +
+			"Errors if already initialized.",
+		Flags: []flagInfo{
+			{"--layout", "<jsonl|files>", "Storage layout (default: jsonl)", false},
+			{"--merge-driver", "", "Register the tick merge driver in .gitattributes and git config", false},
+		},
 	},
 	{
 		Name:    "create",
 		Summary: "Create a new task",
 		Usage:   "tick create <title> [flags]",
 		Description: "Creates a new task with the given title. A unique ID is generated\n" +
-			"automatically. Priority defaults to 2 (medium).\n" +
-			"If the parent task is done, it is automatically reopened.",
+			"automatically. Priority defaults to the type's priority if the project\n" +
+			"configures one, otherwise to create.priority, 2 (medium) by default.\n" +
+			"If the parent task is done, it is automatically reopened.\n" +
+			"With --template, creates the task tree of .tick/templates/<name>.yaml\n" +
+			"instead, taking no title; only --var and --parent apply.",
 		Flags: []flagInfo{
 			{"--priority", "<0-4>", "Task priority (default: 2)", false},
 			{"--description", "<text>", "Task description", false},
-			{"--type", "<bug|feature|task|chore>", "Task type", false},
+			{"--type", "<type>", "Task type", false},
 			{"--tags", "<tag,...>", "Comma-separated tags (kebab-case)", false},
 			{"--refs", "<ref,...>", "Comma-separated external references", false},
+			{"--field", "<key=value>", "Set a custom field (repeatable)", false},
+			{"--assignee", "<name>", "Who owns the task", false},
+			{"--due", "<date>", "Due date: 2026-01-30, a timestamp, or an offset such as +3d", false},
+			{"--estimate", "<n>", "Expected effort in points or hours, such as 3 or 0.5", false},
+			{"--recur", "<rule>", "Repeat on completion: daily, weekly, monthly, or every N days/weeks/months", false},
 			{"--parent", "<id>", "Parent task ID (creates a subtask)", false},
 			{"--blocked-by", "<id,...>", "Task IDs this is blocked by", false},
 			{"--blocks", "<id,...>", "Task IDs this blocks", false},
+			{"--template", "<name>", "Create the task tree of a template in .tick/templates/", false},
+			{"--var", "<name=value>", "Set a template variable (repeatable)", false},
 		},
+		Types: true,
 	},
 	{
 		Name:    "list",
 		Summary: "List tasks with optional filters",
 		Usage:   "tick list [flags]",
 		Description: "Lists tasks with optional filtering by status, priority, parent,\n" +
-			"or dependency state. --ready and --blocked are mutually exclusive.",
+			"or dependency state. --ready and --blocked are mutually exclusive.\n" +
+			"With --workspace, IDs are prefixed with their project (billing/tick-a1b2).",
 		Flags: []flagInfo{
-			{"--status", "<open|in_progress|done|cancelled>", "Filter by status", false},
+			{"--status", "<status>", "Filter by status, built-in or from the workflow", false},
 			{"--priority", "<0-4>", "Filter by priority", false},
-			{"--type", "<bug|feature|task|chore>", "Filter by type", false},
+			{"--type", "<type>", "Filter by type", false},
 			{"--tag", "<tag,...>", "Filter by tag (AND within flag, OR across flags)", false},
+			{"--field", "<key[=value]>", "Filter by field value, or by having the field (repeatable)", false},
+			{"--assignee", "<name>", "Filter by assignee", false},
+			{"--unassigned", "", "Show only tasks with no assignee", false},
+			{"--overdue", "", "Show only open tasks past their due date", false},
+			{"--recurring", "", "Show only recurring tasks", false},
+			{"--due-before", "<date>", "Show only tasks due on or before a date or offset (+7d)", false},
+			{"--due-after", "<date>", "Show only tasks due after a date or offset", false},
 			{"--parent", "<id>", "Filter by parent task", false},
 			{"--ready", "", "Show only ready tasks (no blockers, children, or blocked ancestor)", false},
 			{"--blocked", "", "Show only blocked tasks", false},
+			{"--include-deferred", "", "Treat deferred tasks as ready rather than blocked", false},
 			{"--count", "<n>", "Limit results to N tasks", false},
+			{"--workspace", "", "Query every project in the .tick-workspace file", false},
 		},
 	},
 	{
 		Name:        "show",
 		Summary:     "Show full task detail",
 		Usage:       "tick show <task-id>",
-		Description: "Displays complete details for a single task including description,\ndependencies, subtasks, and timestamps.",
+		Description: "Displays complete details for a single task including description,\ndependencies, subtasks, and timestamps. Accepts workspace-qualified IDs\nsuch as billing/tick-a1b2.",
 	},
 	{
 		Name:    "update",
@@ -85,15 +116,26 @@ var commands = []commandInfo{
 			{"--description", "<text>", "New description", false},
 			{"--clear-description", "", "Remove description", false},
 			{"--priority", "<0-4>", "New priority", false},
-			{"--type", "<bug|feature|task|chore>", "Set task type", false},
+			{"--type", "<type>", "Set task type", false},
 			{"--clear-type", "", "Remove type", false},
 			{"--tags", "<tag,...>", "Replace tags (comma-separated)", false},
 			{"--clear-tags", "", "Remove all tags", false},
 			{"--refs", "<ref,...>", "Replace refs (comma-separated)", false},
 			{"--clear-refs", "", "Remove all refs", false},
+			{"--field", "<key=value>", "Set a custom field (repeatable)", false},
+			{"--clear-field", "<key>", "Remove a custom field (repeatable)", false},
+			{"--assignee", "<name>", "Reassign the task (drops any claim lease)", false},
+			{"--unassign", "", "Remove the assignee", false},
+			{"--due", "<date>", "Set the due date: 2026-01-30, a timestamp, or an offset such as +3d", false},
+			{"--clear-due", "", "Remove the due date", false},
+			{"--estimate", "<n>", "Set the expected effort in points or hours", false},
+			{"--clear-estimate", "", "Remove the estimate", false},
+			{"--recur", "<rule>", "Set the recurrence rule: daily, weekly, monthly, or every N days/weeks/months", false},
+			{"--clear-recur", "", "Stop the task recurring", false},
 			{"--parent", "<id>", "New parent task ID", false},
 			{"--blocks", "<id,...>", "Task IDs this blocks", false},
 		},
+		Types: true,
 	},
 	{
 		Name:    "start",
@@ -108,7 +150,9 @@ var commands = []commandInfo{
 		Usage:   "tick done <task-id>",
 		Description: "Transitions a task from in_progress to done.\n" +
 			"Cascades: marks all non-terminal descendants as done. If all siblings\n" +
-			"are now terminal, auto-completes the parent (and upward recursively).",
+			"are now terminal, auto-completes the parent (and upward recursively).\n" +
+			"A recurring task (see --recur) completed by it or its cascades is\n" +
+			"recreated as its next occurrence.",
 	},
 	{
 		Name:    "cancel",
@@ -133,8 +177,9 @@ var commands = []commandInfo{
 			"Removing a parent task cascades to all its descendants — children,\n" +
 			"grandchildren, etc. are removed together. Dependency references to\n" +
 			"removed tasks are automatically cleaned from surviving tasks.\n\n" +
-			"Recovery: since tasks.jsonl is tracked in Git, accidental removals\n" +
-			"can be recovered from Git history.",
+			"Recovery: run 'tick undo' to restore the removed tasks and their\n" +
+			"dependency references. Since tasks.jsonl is tracked in Git, older\n" +
+			"removals can also be recovered from Git history.",
 		Flags: []flagInfo{
 			{"--force, -f", "", "Skip confirmation prompt", false},
 		},
@@ -159,43 +204,302 @@ var commands = []commandInfo{
 			"Prevents self-references, dependency cycles, and adding a\n" +
 			"cancelled task as a dependency.",
 	},
+	{
+		Name:    "critical-path",
+		Summary: "Show the longest chain of remaining work",
+		Usage:   "tick critical-path [task-id]",
+		Description: "Follows blocked_by dependencies between open tasks to find the chain\n" +
+			"with the largest total estimate, and lists its tasks in the order they\n" +
+			"must be done. Unestimated tasks count as zero; ties go to the longer\n" +
+			"chain. With a task ID, the chain ends at that task or one of its open\n" +
+			"descendants.",
+	},
+	{
+		Name:    "batch",
+		Summary: "Apply many operations from stdin in one change",
+		Usage:   "tick batch < ops.jsonl",
+		Description: "Reads operations from stdin as JSONL (one object per line) or as a\n" +
+			"TOON \"ops\" list, validates them all, and applies them as a single\n" +
+			"all-or-nothing change that one `tick undo` reverts. Each object has an\n" +
+			"\"op\": create, update, transition, dep add or note add, plus the\n" +
+			"fields of the matching command (title, priority, parent, blocked_by,\n" +
+			"action, text, ...). A create with \"as\": \"api\" can be referenced by\n" +
+			"later ops as \"$api\" wherever a task ID is expected.",
+	},
 	{
 		Name:    "ready",
 		Summary: "List ready tasks (alias: list --ready)",
 		Usage:   "tick ready [flags]",
-		Description: "Lists tasks with no unresolved blockers, no open children, and no\n" +
-			"dependency-blocked ancestor. Alias for list --ready.\n" +
+		Description: "Lists tasks with no unresolved blockers, no open children, no\n" +
+			"dependency-blocked ancestor, and no future defer date. Alias for\n" +
+			"list --ready.\n" +
 			"Accepts the same additional filters as list.",
 		Flags: []flagInfo{
-			{"--status", "<open|in_progress|done|cancelled>", "Filter by status", false},
+			{"--status", "<status>", "Filter by status, built-in or from the workflow", false},
 			{"--priority", "<0-4>", "Filter by priority", false},
-			{"--type", "<bug|feature|task|chore>", "Filter by type", false},
+			{"--type", "<type>", "Filter by type", false},
 			{"--tag", "<tag,...>", "Filter by tag (AND within flag, OR across flags)", false},
+			{"--field", "<key[=value]>", "Filter by field value, or by having the field (repeatable)", false},
+			{"--assignee", "<name>", "Filter by assignee", false},
+			{"--unassigned", "", "Show only tasks with no assignee", false},
+			{"--overdue", "", "Show only open tasks past their due date", false},
+			{"--recurring", "", "Show only recurring tasks", false},
+			{"--due-before", "<date>", "Show only tasks due on or before a date or offset (+7d)", false},
+			{"--due-after", "<date>", "Show only tasks due after a date or offset", false},
 			{"--parent", "<id>", "Filter by parent task", false},
+			{"--include-deferred", "", "Treat deferred tasks as ready rather than blocked", false},
 			{"--count", "<n>", "Limit results to N tasks", false},
+			{"--workspace", "", "Query every project in the .tick-workspace file", false},
 		},
 	},
 	{
 		Name:    "blocked",
 		Summary: "List blocked tasks (alias: list --blocked)",
 		Usage:   "tick blocked [flags]",
-		Description: "Lists tasks that have unresolved blockers, open children, or a\n" +
-			"dependency-blocked ancestor. Alias for list --blocked.\n" +
+		Description: "Lists tasks that have unresolved blockers, open children, a\n" +
+			"dependency-blocked ancestor, or a future defer date (see tick defer).\n" +
+			"A reason column says why: waiting, blocked_by, children, ancestor\n" +
+			"or deferred, the first that applies.\n" +
+			"Alias for list --blocked.\n" +
+			"Accepts the same additional filters as list.",
+		Flags: []flagInfo{
+			{"--status", "<status>", "Filter by status, built-in or from the workflow", false},
+			{"--priority", "<0-4>", "Filter by priority", false},
+			{"--type", "<type>", "Filter by type", false},
+			{"--tag", "<tag,...>", "Filter by tag (AND within flag, OR across flags)", false},
+			{"--field", "<key[=value]>", "Filter by field value, or by having the field (repeatable)", false},
+			{"--assignee", "<name>", "Filter by assignee", false},
+			{"--unassigned", "", "Show only tasks with no assignee", false},
+			{"--overdue", "", "Show only open tasks past their due date", false},
+			{"--recurring", "", "Show only recurring tasks", false},
+			{"--due-before", "<date>", "Show only tasks due on or before a date or offset (+7d)", false},
+			{"--due-after", "<date>", "Show only tasks due after a date or offset", false},
+			{"--parent", "<id>", "Filter by parent task", false},
+			{"--include-deferred", "", "Treat deferred tasks as ready rather than blocked", false},
+			{"--count", "<n>", "Limit results to N tasks", false},
+			{"--workspace", "", "Query every project in the .tick-workspace file", false},
+		},
+	},
+	{
+		Name:    "mine",
+		Summary: "List tasks assigned to you (alias: list --assignee $TICK_ACTOR)",
+		Usage:   "tick mine [flags]",
+		Description: "Lists the tasks assigned to the name in the TICK_ACTOR environment\n" +
+			"variable. Alias for list --assignee $TICK_ACTOR.\n" +
 			"Accepts the same additional filters as list.",
 		Flags: []flagInfo{
-			{"--status", "<open|in_progress|done|cancelled>", "Filter by status", false},
+			{"--status", "<status>", "Filter by status, built-in or from the workflow", false},
+			{"--priority", "<0-4>", "Filter by priority", false},
+			{"--type", "<type>", "Filter by type", false},
+			{"--tag", "<tag,...>", "Filter by tag (AND within flag, OR across flags)", false},
+			{"--field", "<key[=value]>", "Filter by field value, or by having the field (repeatable)", false},
+			{"--overdue", "", "Show only open tasks past their due date", false},
+			{"--recurring", "", "Show only recurring tasks", false},
+			{"--due-before", "<date>", "Show only tasks due on or before a date or offset (+7d)", false},
+			{"--due-after", "<date>", "Show only tasks due after a date or offset", false},
+			{"--parent", "<id>", "Filter by parent task", false},
+			{"--ready", "", "Show only ready tasks", false},
+			{"--blocked", "", "Show only blocked tasks", false},
+			{"--include-deferred", "", "Treat deferred tasks as ready rather than blocked", false},
+			{"--count", "<n>", "Limit results to N tasks", false},
+			{"--workspace", "", "Query every project in the .tick-workspace file", false},
+		},
+	},
+	{
+		Name:    "claim",
+		Summary: "Atomically start the top ready task for an agent",
+		Usage:   "tick claim --agent <name> [flags]",
+		Description: "Picks the highest-priority ready task, starts it, and assigns it to\n" +
+			"the agent with a lease, all under one lock so parallel agents never\n" +
+			"claim the same task. Open tasks and started tasks with an expired\n" +
+			"lease are claimable. An expired lease is released back to open (as an\n" +
+			"auto transition) by the next command that changes tasks, such as a\n" +
+			"claim, remove, archive or undo.\n" +
+			"Extend a lease with tick heartbeat.",
+		Flags: []flagInfo{
+			{"--agent", "<name>", "Agent claiming the task (required)", true},
+			{"--lease", "<duration>", "Lease length (default 30m)", false},
 			{"--priority", "<0-4>", "Filter by priority", false},
-			{"--type", "<bug|feature|task|chore>", "Filter by type", false},
+			{"--type", "<type>", "Filter by type", false},
+			{"--tag", "<tag,...>", "Filter by tag (AND within flag, OR across flags)", false},
+			{"--field", "<key[=value]>", "Filter by field value, or by having the field (repeatable)", false},
+			{"--parent", "<id>", "Filter by parent task", false},
+		},
+	},
+	{
+		Name:    "heartbeat",
+		Summary: "Extend the lease on a claimed task",
+		Usage:   "tick heartbeat <task-id> [flags]",
+		Description: "Renews the lease on a task claimed with tick claim so that it expires\n" +
+			"the lease length from now. Fails if the task has no lease.",
+		Flags: []flagInfo{
+			{"--agent", "<name>", "Fail unless the task is claimed by this agent", false},
+			{"--lease", "<duration>", "Lease length (default 30m)", false},
+		},
+	},
+	{
+		Name:    "defer",
+		Summary: "Keep a task out of the ready list until a date",
+		Usage:   "tick defer <task-id> <date|duration>",
+		Description: "Defers a task until a date (2026-02-01, the start of that day), a\n" +
+			"timestamp, or a duration from now (3d, 2w, 4h). Until then the task\n" +
+			"is listed by tick blocked rather than tick ready. Deferring again\n" +
+			"moves the date; tick undefer clears it.",
+	},
+	{
+		Name:        "undefer",
+		Summary:     "Make a deferred task ready again",
+		Usage:       "tick undefer <task-id>",
+		Description: "Clears the date set with tick defer, so the task is ready again if\nnothing else blocks it.",
+	},
+	{
+		Name:    "search",
+		Summary: "Full-text search task titles, descriptions, and notes",
+		Usage:   "tick search <query> [flags]",
+		Description: "Searches task titles, descriptions, and notes, ranked by relevance\n" +
+			"with a highlighted snippet per match. Multiple words must all match.\n" +
+			"Supports phrases (\"exact words\"), prefixes (auth*), boolean\n" +
+			"operators (AND, OR, NOT), and field scoping (title:login).",
+		Flags: []flagInfo{
+			{"--ready", "", "Show only ready tasks", false},
+			{"--blocked", "", "Show only blocked tasks", false},
+			{"--include-deferred", "", "Treat deferred tasks as ready rather than blocked", false},
+			{"--status", "<status>", "Filter by status, built-in or from the workflow", false},
+			{"--type", "<type>", "Filter by type", false},
 			{"--tag", "<tag,...>", "Filter by tag (AND within flag, OR across flags)", false},
+			{"--field", "<key[=value]>", "Filter by field value, or by having the field (repeatable)", false},
+			{"--assignee", "<name>", "Filter by assignee", false},
+			{"--unassigned", "", "Show only tasks with no assignee", false},
 			{"--parent", "<id>", "Filter by parent task", false},
 			{"--count", "<n>", "Limit results to N tasks", false},
+			{"--workspace", "", "Query every project in the .tick-workspace file", false},
+		},
+	},
+	{
+		Name:    "watch",
+		Summary: "Stream task changes as JSON events",
+		Usage:   "tick watch [flags]",
+		Description: "Polls the task data and prints one JSON object per change, one per\n" +
+			"line, until interrupted: created, updated (with the changed fields),\n" +
+			"transition (with an auto flag for cascades), note_added, and removed.\n" +
+			"Tasks that exist when watch starts are not reported. Filters match a\n" +
+			"task before or after the change.",
+		Flags: []flagInfo{
+			{"--status", "<status>", "Filter by status, built-in or from the workflow", false},
+			{"--priority", "<0-4>", "Filter by priority", false},
+			{"--type", "<type>", "Filter by type", false},
+			{"--tag", "<tag,...>", "Filter by tag (AND within flag, OR across flags)", false},
+			{"--field", "<key[=value]>", "Filter by field value, or by having the field (repeatable)", false},
+			{"--assignee", "<name>", "Filter by assignee", false},
+			{"--unassigned", "", "Show only tasks with no assignee", false},
+			{"--parent", "<id>", "Filter by parent task", false},
+			{"--interval", "<duration>", "Time between polls (default 1s)", false},
+		},
+	},
+	{
+		Name:    "archive",
+		Summary: "Move closed tasks to the archive",
+		Usage:   "tick archive [flags]",
+		Description: "Moves done and cancelled tasks into .tick/archive.jsonl so everyday\n" +
+			"commands no longer read them. A task is archived only together with\n" +
+			"its whole subtree, once every task in it is closed. Dependencies on\n" +
+			"archived tasks are removed from the remaining tasks.\n" +
+			"Archived tasks are visible with the --include-archived global flag.",
+		Flags: []flagInfo{
+			{"--older-than", "<age>", "Only tasks closed at least this long ago (e.g. 30d, 2w)", false},
+			{"--dry-run", "", "List the tasks that would be archived", false},
+		},
+	},
+	{
+		Name:    "unarchive",
+		Summary: "Restore an archived task and its subtree",
+		Usage:   "tick unarchive <id>",
+		Description: "Moves an archived task and its archived descendants back into the\n" +
+			"active tasks. Refused when the task's parent is also archived.",
+	},
+	{
+		Name:    "storage",
+		Summary: "Convert between storage layouts",
+		Usage:   "tick storage convert --layout <jsonl|files>",
+		Description: "Converts the project's tasks to the given layout:\n" +
+			"  jsonl   all tasks in .tick/tasks.jsonl, one per line\n" +
+			"  files   one .tick/tasks/<id>.json file per task, so branches\n" +
+			"          touching different tasks merge without conflicts\n" +
+			"The old layout's data is removed after the new one is written.",
+		Flags: []flagInfo{
+			{"--layout", "<jsonl|files>", "Target storage layout", true},
+		},
+	},
+	{
+		Name:    "lock",
+		Summary: "Show who holds the .tick lock",
+		Usage:   "tick lock status",
+		Description: "Reports whether the .tick lock is held and lists its recorded holders\n" +
+			"with their pid, host, command, mode, and start time, readers included.\n" +
+			"Holders whose process has died are marked; `tick doctor` warns about them.\n" +
+			"Wait longer for a busy lock with --lock-timeout or TICK_LOCK_TIMEOUT.",
+	},
+	{
+		Name:    "config",
+		Summary: "Get or set project settings in .tick/config.yaml",
+		Usage:   "tick config <get|set|list> [<key>] [<value>]",
+		Description: "Reads and changes the project settings in .tick/config.yaml:\n" +
+			"  get <key>           Print a setting's value\n" +
+			"  set <key> <value>   Change a setting (lists comma-separated)\n" +
+			"  list                Show every setting and whether it is the default\n" +
+			"Keys: create.priority, create.type, create.tags, types,\n" +
+			"tags.max_per_task, tags.max_length, id_prefix, lock_timeout.\n" +
+			"Invalid settings are refused; `tick doctor` checks a hand-edited file.",
+	},
+	{
+		Name:    "upgrade",
+		Summary: "Upgrade task data to the current format version",
+		Usage:   "tick upgrade [flags]",
+		Description: "Migrates .tick/ task data, including archived tasks, from the format\n" +
+			"version recorded in .tick/format to the version this tick writes,\n" +
+			"one step at a time. Projects without .tick/format are at version 1.\n" +
+			"Older tick versions refuse to open projects in a newer format; this\n" +
+			"one reads projects in an older format but changes no tasks in them\n" +
+			"until they are upgraded.",
+		Flags: []flagInfo{
+			{"--dry-run", "", "List the upgrade steps without applying them", false},
+		},
+	},
+	{
+		Name:    "undo",
+		Summary: "Revert a mutation recorded in the journal",
+		Usage:   "tick undo [<count>] [flags]",
+		Description: "Reverts the last <count> mutations (default 1), newest first, or the\n" +
+			"journal entry given with --entry, restoring every task each changed\n" +
+			"(including cascaded changes). Refused when a later mutation has touched\n" +
+			"any of those tasks; the last <count> are undone all together or not at all.",
+		Flags: []flagInfo{
+			{"--entry", "<n>", "Revert journal entry <n> instead of the latest mutations", false},
+		},
+	},
+	{
+		Name:        "redo",
+		Summary:     "Re-apply the most recently undone mutation",
+		Usage:       "tick redo",
+		Description: "Re-applies the most recently undone mutation. Refused when any task it\nchanged has been modified since the undo.",
+	},
+	{
+		Name:        "journal",
+		Summary:     "List recorded mutations, newest first",
+		Usage:       "tick journal [flags]",
+		Description: "Lists journal entries from .tick/journal.jsonl with the command that\nmade each change, the tasks it touched, and whether it is undone.",
+		Flags: []flagInfo{
+			{"--count", "<n>", "Limit output to the N most recent entries", false},
 		},
 	},
 	{
 		Name:        "stats",
 		Summary:     "Show task statistics",
-		Usage:       "tick stats",
-		Description: "Displays summary statistics: task counts by status and priority.",
+		Usage:       "tick stats [--workspace]",
+		Description: "Displays summary statistics: task counts by status and priority,\nand the total and remaining estimates rolled up under each top-level task.",
+		Flags: []flagInfo{
+			{"--workspace", "", "Sum the counts of every project in the .tick-workspace file", false},
+		},
 	},
 	{
 		Name:        "rebuild",
@@ -207,7 +511,7 @@ var commands = []commandInfo{
 		Name:        "doctor",
 		Summary:     "Run diagnostic checks",
 		Usage:       "tick doctor",
-		Description: "Runs diagnostic checks on the tick data: JSONL syntax, ID format,\nduplicates, orphaned references, dependency cycles, and cache staleness.",
+		Description: "Runs diagnostic checks on the tick data: JSONL syntax, ID format,\nduplicates, orphaned references, dependency cycles, cache staleness,\nand the settings in .tick/config.yaml.",
 	},
 	{
 		Name:    "migrate",
@@ -221,6 +525,17 @@ var commands = []commandInfo{
 			{"--pending-only", "", "Import only pending/open tasks", false},
 		},
 	},
+	{
+		Name:    "merge-driver",
+		Summary: "Three-way merge tasks.jsonl (git merge driver)",
+		Usage:   "tick merge-driver <base> <ours> <theirs>",
+		Description: "Merges tasks.jsonl by task ID. Invoked by git as %O %A %B once\n" +
+			"registered with 'tick init --merge-driver'. Scalar fields take the side\n" +
+			"that changed; notes, transitions, tags, refs, and dependencies are\n" +
+			"merged as sets. The result is written to <ours> as canonical JSONL.\n" +
+			"Conflicting scalar edits keep ours and, like invalid merged\n" +
+			"relationships, are reported on stderr with exit code 1.",
+	},
 	{
 		Name:        "version",
 		Summary:     "Show tick version",
@@ -262,6 +577,15 @@ func printTopLevelHelp(w io.Writer) {
 	fmt.Fprintln(w, "  --pretty        Force pretty output format")
 	fmt.Fprintln(w, "  --json          Force JSON output format")
 	fmt.Fprintln(w, "  --version, -V   Show tick version")
+	fmt.Fprintln(w, "  --include-archived")
+	fmt.Fprintln(w, "                  Include archived tasks in reads (read-only)")
+	fmt.Fprintln(w, "  --lock-timeout <duration>")
+	fmt.Fprintln(w, "                  How long to wait for the .tick lock (default")
+	fmt.Fprintln(w, "                  TICK_LOCK_TIMEOUT, then lock_timeout in")
+	fmt.Fprintln(w, "                  .tick/config.yaml, then 5s)")
+	fmt.Fprintln(w, "  --as-of <date|timestamp>")
+	fmt.Fprintln(w, "                  Show tasks as they stood at a past time (list,")
+	fmt.Fprintln(w, "                  ready, blocked, stats, show)")
 	fmt.Fprintln(w)
 	fmt.Fprintln(w, "Run 'tick help <command>' for detailed help on a command.")
 	fmt.Fprintln(w, "Run 'tick help --all' for complete reference of all commands and flags.")
@@ -270,7 +594,7 @@ func printTopLevelHelp(w io.Writer) {
 // printAllHelp writes compact, concatenated help for every command to w.
 // Designed for AI agents to discover the full CLI surface in one call.
 func printAllHelp(w io.Writer) {
-	fmt.Fprintln(w, "Global flags: --help/-h --quiet/-q --verbose/-v --toon --pretty --json --version/-V")
+	fmt.Fprintln(w, "Global flags: --help/-h --quiet/-q --verbose/-v --toon --pretty --json --version/-V --include-archived --lock-timeout <duration> --as-of <date|timestamp>")
 	fmt.Fprintln(w)
 	for i, cmd := range commands {
 		fmt.Fprintln(w, cmd.Usage)
@@ -288,8 +612,21 @@ func printAllHelp(w io.Writer) {
 	}
 }
 
-// printCommandHelp writes detailed help for a single command to w.
-func printCommandHelp(w io.Writer, cmd *commandInfo) {
+// workflowCommandInfo describes a transition command of the project's workflow
+// for tick help.
+func workflowCommandInfo(def config.CommandDef) *commandInfo {
+	return &commandInfo{
+		Name:    def.Name,
+		Summary: fmt.Sprintf("Move a task to %s", def.To),
+		Usage:   fmt.Sprintf("tick %s <task-id>", def.Name),
+		Description: fmt.Sprintf("Transitions a task from %s to %s.\n", strings.Join(def.From, " or "), def.To) +
+			"Defined by the workflow in .tick/config.yaml.",
+	}
+}
+
+// printCommandHelp writes detailed help for a single command to w. Commands
+// that set a task's type also list types, the project's allowed types.
+func printCommandHelp(w io.Writer, cmd *commandInfo, types []config.TypeDef) {
 	fmt.Fprintf(w, "Usage: %s\n", cmd.Usage)
 	fmt.Fprintln(w)
 	fmt.Fprintln(w, cmd.Description)
@@ -305,4 +642,20 @@ func printCommandHelp(w io.Writer, cmd *commandInfo) {
 			fmt.Fprintf(w, "  %-24s%s\n", label, f.Desc)
 		}
 	}
+
+	if cmd.Types && len(types) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Types:")
+		for _, t := range types {
+			desc := t.Description
+			if t.Priority != nil {
+				desc = strings.TrimSpace(fmt.Sprintf("%s (default priority %d)", desc, *t.Priority))
+			}
+			if desc == "" {
+				fmt.Fprintf(w, "  %s\n", t.Name)
+				continue
+			}
+			fmt.Fprintf(w, "  %-24s%s\n", t.Name, desc)
+		}
+	}
 }
@@ -2,8 +2,14 @@ package cli
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+
+	"github.com/leeovery/tick/internal/config"
+	"github.com/leeovery/tick/internal/task"
 )
 
 // runHelp runs the app with the given args and returns stdout, stderr, and exit code.
@@ -106,6 +112,33 @@ func TestHelp(t *testing.T) {
 		}
 	})
 
+	t.Run("tick help create lists the built-in types outside a project", func(t *testing.T) {
+		stdout, _, code := runHelp(t, "help", "create")
+		if code != 0 {
+			t.Fatalf("exit code = %d, want 0", code)
+		}
+		if !strings.Contains(stdout, "Types:\n  bug                     Something is broken\n") {
+			t.Errorf("stdout missing built-in types:\n%s", stdout)
+		}
+	})
+
+	t.Run("tick help create lists the project's types", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+		config := "types:\n  - name: story\n    description: User-facing change\n    priority: 1\n  - spike\n"
+		if err := os.WriteFile(filepath.Join(tickDir, "config.yaml"), []byte(config), 0644); err != nil {
+			t.Fatalf("failed to write config.yaml: %v", err)
+		}
+
+		stdout, _, code := runTick(t, dir, "help", "create")
+		if code != 0 {
+			t.Fatalf("exit code = %d, want 0", code)
+		}
+		want := "Types:\n  story                   User-facing change (default priority 1)\n  spike\n"
+		if !strings.HasSuffix(stdout, want) {
+			t.Errorf("stdout does not end with %q:\n%s", want, stdout)
+		}
+	})
+
 	t.Run("tick help list shows flags", func(t *testing.T) {
 		stdout, _, code := runHelp(t, "help", "list")
 		if code != 0 {
@@ -282,4 +315,16 @@ func TestHelp(t *testing.T) {
 			t.Error("stdout missing Git recovery mention")
 		}
 	})
+
+	t.Run("it keeps workflow commands off every documented command", func(t *testing.T) {
+		builtin := task.DefaultWorkflow()
+		for _, cmd := range commands {
+			if _, ok := builtin.Command(cmd.Name); ok {
+				continue
+			}
+			if !slices.Contains(config.CLICommands, cmd.Name) {
+				t.Errorf("config.CLICommands is missing %q", cmd.Name)
+			}
+		}
+	})
 }
@@ -3,28 +3,29 @@ package cli
 import (
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/leeovery/tick/internal/storage"
 	"github.com/leeovery/tick/internal/task"
+	"github.com/leeovery/tick/tick"
 )
 
 // outputMutationResult handles post-mutation output for create and update commands.
 // In quiet mode it prints only the task ID; otherwise it queries the full task detail
-// from the store and formats it via the Formatter.
-func outputMutationResult(store *storage.Store, id string, fc FormatConfig, fmtr Formatter, stdout io.Writer) error {
+// from the project and formats it via the Formatter.
+func outputMutationResult(p *tick.Project, id string, fc FormatConfig, fmtr Formatter, stdout io.Writer) error {
 	if fc.Quiet {
 		fmt.Fprintln(stdout, id)
 		return nil
 	}
 
-	data, err := queryShowData(store, id)
+	detail, err := p.Show(id)
 	if err != nil {
 		return err
 	}
 
-	detail := showDataToTaskDetail(data)
 	fmt.Fprintln(stdout, fmtr.FormatTaskDetail(detail))
 	return nil
 }
@@ -32,13 +33,20 @@ func outputMutationResult(store *storage.Store, id string, fc FormatConfig, fmtr
 // openStore discovers the .tick directory from the given dir and opens a Store.
 // Callers must defer store.Close() themselves since Go defers are scope-bound.
 func openStore(dir string, fc FormatConfig) (*storage.Store, error) {
-	tickDir, err := DiscoverTickDir(dir)
+	tickDir, err := tick.DiscoverTickDir(dir)
 	if err != nil {
 		return nil, err
 	}
 	return storage.NewStore(tickDir, storeOpts(fc)...)
 }
 
+// openProject discovers the .tick directory from the given dir and opens it as a
+// tick.Project, configured from the FormatConfig like openStore. Callers must
+// defer p.Close() themselves.
+func openProject(dir string, fc FormatConfig) (*tick.Project, error) {
+	return tick.Open(dir, projectOpts(fc)...)
+}
+
 // parseCommaSeparatedIDs splits a comma-separated string of task IDs,
 // trims whitespace, lowercases, and filters empty values.
 // Does not normalize to full IDs — callers resolve via store.ResolveID.
@@ -53,30 +61,26 @@ func parseCommaSeparatedIDs(s string) []string {
 	return ids
 }
 
-// validateTypeFlag normalizes the type value, checks it is non-empty, and validates
-// it against the allowed type set. Returns the normalized value or an error.
+// validateTypeFlag normalizes the type value and checks it is non-empty. Whether
+// the type is allowed depends on the project's configuration, so it is checked
+// when the task is written. Returns the normalized value or an error.
 func validateTypeFlag(value string) (string, error) {
 	normalized := task.NormalizeType(value)
 	if err := task.ValidateTypeNotEmpty(normalized); err != nil {
 		return "", err
 	}
-	if err := task.ValidateType(normalized); err != nil {
-		return "", err
-	}
 	return normalized, nil
 }
 
-// validateTagsFlag deduplicates tags, checks the result is non-empty (using emptyErr
-// as the error message), and validates all tag values. Returns the deduplicated slice
-// or an error.
+// validateTagsFlag deduplicates tags and checks the result is non-empty (using
+// emptyErr as the error message). The tag limits depend on the project's
+// configuration, so tags are validated when the task is written. Returns the
+// deduplicated slice or an error.
 func validateTagsFlag(tags []string, emptyErr string) ([]string, error) {
 	deduped := task.DeduplicateTags(tags)
 	if len(deduped) == 0 {
 		return nil, fmt.Errorf("%s", emptyErr)
 	}
-	if err := task.ValidateTags(deduped); err != nil {
-		return nil, err
-	}
 	return deduped, nil
 }
 
@@ -94,62 +98,119 @@ func validateRefsFlag(refs []string, emptyErr string) ([]string, error) {
 	return deduped, nil
 }
 
-// outputTransitionOrCascade writes a transition or cascade-transition to stdout.
-// When cr is nil or has no cascaded entries it uses FormatTransition; otherwise it
-// uses FormatCascadeTransition with the pre-built CascadeResult. Callers must build
-// the CascadeResult inside the Mutate closure where the tasks slice is still valid.
-func outputTransitionOrCascade(stdout io.Writer, fmtr Formatter, id, oldStatus, newStatus string, cr *CascadeResult) {
-	if cr == nil || len(cr.Cascaded) == 0 {
-		fmt.Fprintln(stdout, fmtr.FormatTransition(id, oldStatus, newStatus))
-	} else {
-		fmt.Fprintln(stdout, fmtr.FormatCascadeTransition(*cr))
+// addFieldFlag parses a --field key=value argument into fields, allocating the
+// map on first use. A repeated key takes the later value.
+func addFieldFlag(fields map[string]string, input string) (map[string]string, error) {
+	key, value, err := task.ParseField(input)
+	if err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		fields = map[string]string{}
 	}
+	fields[key] = value
+	return fields, nil
 }
 
-// validateAndReopenParent finds the parent task in tasks by parentID, validates that
-// a child can be added (Rule 7: blocks cancelled parent), and if the parent is done,
-// triggers a reopen cascade (Rule 6). Returns the transition result, cascade changes,
-// whether a reopen occurred, and any error.
-func validateAndReopenParent(tasks []task.Task, parentID string, sm *task.StateMachine) (task.TransitionResult, []task.CascadeChange, bool, error) {
-	normalizedParent := task.NormalizeID(parentID)
-	for i := range tasks {
-		if task.NormalizeID(tasks[i].ID) != normalizedParent {
-			continue
-		}
-		if err := sm.ValidateAddChild(&tasks[i]); err != nil {
-			return task.TransitionResult{}, nil, false, err
-		}
-		if tasks[i].Status == task.StatusDone {
-			r, c, err := sm.ApplySystemTransition(tasks, &tasks[i], "reopen")
-			if err != nil {
-				return task.TransitionResult{}, nil, false, err
+// parseDue parses a --due value: a timestamp, a date meaning the end of that
+// day in local time, or an offset from now such as +4h, +3d or +2w. Day and
+// week offsets land on the end of the day, like a date.
+func parseDue(v string, now time.Time) (time.Time, error) {
+	if offset, ok := strings.CutPrefix(v, "+"); ok {
+		if n, unit, ok := parseOffset(offset, "hdw"); ok {
+			switch unit {
+			case 'h':
+				return now.Add(time.Duration(n) * time.Hour), nil
+			case 'd':
+				return endOfDay(now.AddDate(0, 0, n)), nil
+			case 'w':
+				return endOfDay(now.AddDate(0, 0, 7*n)), nil
 			}
-			return r, c, true, nil
 		}
-		return task.TransitionResult{}, nil, false, nil
 	}
-	return task.TransitionResult{}, nil, false, nil
+	if day, err := time.ParseInLocation("2006-01-02", v, time.Local); err == nil {
+		return endOfDay(day), nil
+	}
+	for _, layout := range timeLayouts {
+		if at, err := time.ParseInLocation(layout, v, time.Local); err == nil {
+			return at, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid due date '%s': use a date such as 2026-01-30, a timestamp such as 2026-01-30T17:00:00Z, or an offset such as +3d", v)
 }
 
-// applyBlocks iterates tasks and for each task whose ID appears in blockIDs,
-// appends sourceID to its BlockedBy slice and sets its Updated timestamp.
-// Skips the append if sourceID is already present in BlockedBy.
-func applyBlocks(tasks []task.Task, sourceID string, blockIDs []string, now time.Time) {
-	for i := range tasks {
-		for _, blockID := range blockIDs {
-			if task.NormalizeID(tasks[i].ID) == task.NormalizeID(blockID) {
-				alreadyPresent := false
-				for _, dep := range tasks[i].BlockedBy {
-					if task.NormalizeID(dep) == task.NormalizeID(sourceID) {
-						alreadyPresent = true
-						break
-					}
-				}
-				if !alreadyPresent {
-					tasks[i].BlockedBy = append(tasks[i].BlockedBy, sourceID)
-					tasks[i].Updated = now
-				}
-			}
+// parseDeferUntil parses the date argument of tick defer: a timestamp, a date
+// meaning the start of that day in local time, or a duration from now such as
+// 3d, 2w or 90m (a leading + is allowed). Durations are exact: 1d is 24 hours
+// from now, not the start of tomorrow.
+func parseDeferUntil(v string, now time.Time) (time.Time, error) {
+	offset := strings.TrimPrefix(v, "+")
+	if n, unit, ok := parseOffset(offset, "dw"); ok {
+		switch unit {
+		case 'd':
+			return now.AddDate(0, 0, n), nil
+		case 'w':
+			return now.AddDate(0, 0, 7*n), nil
 		}
 	}
+	if d, err := time.ParseDuration(offset); err == nil && d > 0 && d <= maxOffset {
+		return now.Add(d), nil
+	}
+	if day, err := time.ParseInLocation("2006-01-02", v, time.Local); err == nil {
+		return day, nil
+	}
+	for _, layout := range timeLayouts {
+		if at, err := time.ParseInLocation(layout, v, time.Local); err == nil {
+			return at, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid defer date '%s': use a date such as 2026-02-01, a timestamp such as 2026-02-01T09:00:00Z, or a duration such as 3d or 2w", v)
+}
+
+// maxOffset is the furthest ahead an offset can reach, about a century. It
+// keeps every count well clear of overflowing a time.Duration.
+const maxOffset = 100 * 366 * 24 * time.Hour
+
+// offsetUnits is the length of each unit an offset can count in.
+var offsetUnits = map[byte]time.Duration{
+	'm': time.Minute,
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
+// parseOffset splits an offset such as 3d into its count and unit letter,
+// accepting only the unit letters in units. The count must be a non-negative
+// integer that reaches no further than maxOffset.
+func parseOffset(v string, units string) (int, byte, bool) {
+	if len(v) < 2 || v[0] < '0' || v[0] > '9' {
+		return 0, 0, false
+	}
+	unit := v[len(v)-1]
+	if !strings.ContainsRune(units, rune(unit)) {
+		return 0, 0, false
+	}
+	n, err := strconv.Atoi(v[:len(v)-1])
+	if err != nil || n > int(maxOffset/offsetUnits[unit]) {
+		return 0, 0, false
+	}
+	return n, unit, true
+}
+
+// endOfDay returns the last second of the local day containing t.
+func endOfDay(t time.Time) time.Time {
+	y, m, d := t.In(time.Local).Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, time.Local).Add(-time.Second)
+}
+
+// outputTransitionOrCascade writes a transition or cascade-transition to stdout.
+// When cr is nil or has no cascaded entries it uses FormatTransition; otherwise it
+// uses FormatCascadeTransition with the pre-built CascadeResult. Callers must build
+// the CascadeResult inside the Mutate closure where the tasks slice is still valid.
+func outputTransitionOrCascade(stdout io.Writer, fmtr Formatter, id, oldStatus, newStatus string, cr *CascadeResult) {
+	if cr == nil || len(cr.Cascaded) == 0 {
+		fmt.Fprintln(stdout, fmtr.FormatTransition(id, oldStatus, newStatus))
+	} else {
+		fmt.Fprintln(stdout, fmtr.FormatCascadeTransition(*cr))
+	}
 }
@@ -71,136 +71,6 @@ func TestParseCommaSeparatedIDs(t *testing.T) {
 	}
 }
 
-func TestApplyBlocks(t *testing.T) {
-	t.Run("it appends sourceID to matching tasks BlockedBy", func(t *testing.T) {
-		now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
-		tasks := []task.Task{
-			{ID: "tick-aaa111", Title: "Task A", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
-			{ID: "tick-bbb222", Title: "Task B", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
-		}
-
-		applyNow := time.Date(2026, 2, 10, 13, 0, 0, 0, time.UTC)
-		applyBlocks(tasks, "tick-src001", []string{"tick-aaa111"}, applyNow)
-
-		if len(tasks[0].BlockedBy) != 1 || tasks[0].BlockedBy[0] != "tick-src001" {
-			t.Errorf("tasks[0].BlockedBy = %v, want [tick-src001]", tasks[0].BlockedBy)
-		}
-		// Unmatched task should not be modified
-		if len(tasks[1].BlockedBy) != 0 {
-			t.Errorf("tasks[1].BlockedBy = %v, want empty", tasks[1].BlockedBy)
-		}
-	})
-
-	t.Run("it sets Updated timestamp on modified tasks", func(t *testing.T) {
-		now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
-		tasks := []task.Task{
-			{ID: "tick-aaa111", Title: "Task A", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
-			{ID: "tick-bbb222", Title: "Task B", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
-		}
-
-		applyNow := time.Date(2026, 2, 10, 13, 0, 0, 0, time.UTC)
-		applyBlocks(tasks, "tick-src001", []string{"tick-aaa111"}, applyNow)
-
-		if !tasks[0].Updated.Equal(applyNow) {
-			t.Errorf("tasks[0].Updated = %v, want %v", tasks[0].Updated, applyNow)
-		}
-		// Unmatched task should retain original Updated
-		if !tasks[1].Updated.Equal(now) {
-			t.Errorf("tasks[1].Updated = %v, want %v (unchanged)", tasks[1].Updated, now)
-		}
-	})
-
-	t.Run("it is a no-op with non-existent blockIDs", func(t *testing.T) {
-		now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
-		tasks := []task.Task{
-			{ID: "tick-aaa111", Title: "Task A", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
-		}
-
-		applyNow := time.Date(2026, 2, 10, 13, 0, 0, 0, time.UTC)
-		applyBlocks(tasks, "tick-src001", []string{"tick-nonexist"}, applyNow)
-
-		if len(tasks[0].BlockedBy) != 0 {
-			t.Errorf("tasks[0].BlockedBy = %v, want empty", tasks[0].BlockedBy)
-		}
-		if !tasks[0].Updated.Equal(now) {
-			t.Errorf("tasks[0].Updated = %v, want %v (unchanged)", tasks[0].Updated, now)
-		}
-	})
-
-	t.Run("it skips duplicate when sourceID already in BlockedBy", func(t *testing.T) {
-		now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
-		tasks := []task.Task{
-			{ID: "tick-aaa111", Title: "Task A", Status: task.StatusOpen, Priority: 2,
-				BlockedBy: []string{"tick-src001"}, Created: now, Updated: now},
-		}
-
-		applyNow := time.Date(2026, 2, 10, 13, 0, 0, 0, time.UTC)
-		applyBlocks(tasks, "tick-src001", []string{"tick-aaa111"}, applyNow)
-
-		if len(tasks[0].BlockedBy) != 1 {
-			t.Errorf("tasks[0].BlockedBy = %v, want [tick-src001] (no duplicate)", tasks[0].BlockedBy)
-		}
-		if tasks[0].BlockedBy[0] != "tick-src001" {
-			t.Errorf("tasks[0].BlockedBy[0] = %q, want %q", tasks[0].BlockedBy[0], "tick-src001")
-		}
-		// Updated should NOT be changed since no new dependency was added
-		if !tasks[0].Updated.Equal(now) {
-			t.Errorf("tasks[0].Updated = %v, want %v (unchanged)", tasks[0].Updated, now)
-		}
-	})
-
-	t.Run("it matches blockIDs case-insensitively", func(t *testing.T) {
-		now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
-		tasks := []task.Task{
-			{ID: "tick-aaa111", Title: "Task A", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
-		}
-
-		applyNow := time.Date(2026, 2, 10, 13, 0, 0, 0, time.UTC)
-		applyBlocks(tasks, "tick-src001", []string{"TICK-AAA111"}, applyNow)
-
-		if len(tasks[0].BlockedBy) != 1 || tasks[0].BlockedBy[0] != "tick-src001" {
-			t.Errorf("tasks[0].BlockedBy = %v, want [tick-src001]", tasks[0].BlockedBy)
-		}
-	})
-
-	t.Run("it detects existing dep case-insensitively", func(t *testing.T) {
-		now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
-		tasks := []task.Task{
-			{ID: "tick-aaa111", Title: "Task A", Status: task.StatusOpen, Priority: 2,
-				BlockedBy: []string{"TICK-SRC001"}, Created: now, Updated: now},
-		}
-
-		applyNow := time.Date(2026, 2, 10, 13, 0, 0, 0, time.UTC)
-		applyBlocks(tasks, "tick-src001", []string{"tick-aaa111"}, applyNow)
-
-		if len(tasks[0].BlockedBy) != 1 {
-			t.Errorf("tasks[0].BlockedBy = %v, want [TICK-SRC001] (no duplicate)", tasks[0].BlockedBy)
-		}
-	})
-
-	t.Run("it handles multiple blockIDs", func(t *testing.T) {
-		now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
-		tasks := []task.Task{
-			{ID: "tick-aaa111", Title: "Task A", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
-			{ID: "tick-bbb222", Title: "Task B", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
-			{ID: "tick-ccc333", Title: "Task C", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
-		}
-
-		applyNow := time.Date(2026, 2, 10, 13, 0, 0, 0, time.UTC)
-		applyBlocks(tasks, "tick-src001", []string{"tick-aaa111", "tick-ccc333"}, applyNow)
-
-		if len(tasks[0].BlockedBy) != 1 || tasks[0].BlockedBy[0] != "tick-src001" {
-			t.Errorf("tasks[0].BlockedBy = %v, want [tick-src001]", tasks[0].BlockedBy)
-		}
-		if len(tasks[1].BlockedBy) != 0 {
-			t.Errorf("tasks[1].BlockedBy = %v, want empty", tasks[1].BlockedBy)
-		}
-		if len(tasks[2].BlockedBy) != 1 || tasks[2].BlockedBy[0] != "tick-src001" {
-			t.Errorf("tasks[2].BlockedBy = %v, want [tick-src001]", tasks[2].BlockedBy)
-		}
-	})
-}
-
 func TestOutputMutationResult(t *testing.T) {
 	t.Run("it outputs only the ID in quiet mode", func(t *testing.T) {
 		now := time.Now().UTC().Truncate(time.Second)
@@ -209,17 +79,17 @@ func TestOutputMutationResult(t *testing.T) {
 		}
 		dir, _ := setupTickProjectWithTasks(t, tasks)
 
-		store, err := openStore(dir, FormatConfig{})
+		p, err := openProject(dir, FormatConfig{})
 		if err != nil {
-			t.Fatalf("openStore error: %v", err)
+			t.Fatalf("openProject error: %v", err)
 		}
-		defer store.Close()
+		defer p.Close()
 
 		var buf strings.Builder
 		fc := FormatConfig{Quiet: true}
 		fmtr := &PrettyFormatter{}
 
-		err = outputMutationResult(store, "tick-aaa111", fc, fmtr, &buf)
+		err = outputMutationResult(p, "tick-aaa111", fc, fmtr, &buf)
 		if err != nil {
 			t.Fatalf("outputMutationResult error: %v", err)
 		}
@@ -237,17 +107,17 @@ func TestOutputMutationResult(t *testing.T) {
 		}
 		dir, _ := setupTickProjectWithTasks(t, tasks)
 
-		store, err := openStore(dir, FormatConfig{})
+		p, err := openProject(dir, FormatConfig{})
 		if err != nil {
-			t.Fatalf("openStore error: %v", err)
+			t.Fatalf("openProject error: %v", err)
 		}
-		defer store.Close()
+		defer p.Close()
 
 		var buf strings.Builder
 		fc := FormatConfig{Quiet: false}
 		fmtr := &PrettyFormatter{}
 
-		err = outputMutationResult(store, "tick-aaa111", fc, fmtr, &buf)
+		err = outputMutationResult(p, "tick-aaa111", fc, fmtr, &buf)
 		if err != nil {
 			t.Fatalf("outputMutationResult error: %v", err)
 		}
@@ -270,17 +140,17 @@ func TestOutputMutationResult(t *testing.T) {
 	t.Run("it returns error for non-existent task ID", func(t *testing.T) {
 		dir, _ := setupTickProject(t)
 
-		store, err := openStore(dir, FormatConfig{})
+		p, err := openProject(dir, FormatConfig{})
 		if err != nil {
-			t.Fatalf("openStore error: %v", err)
+			t.Fatalf("openProject error: %v", err)
 		}
-		defer store.Close()
+		defer p.Close()
 
 		var buf strings.Builder
 		fc := FormatConfig{Quiet: false}
 		fmtr := &PrettyFormatter{}
 
-		err = outputMutationResult(store, "tick-nonexist", fc, fmtr, &buf)
+		err = outputMutationResult(p, "tick-nonexist", fc, fmtr, &buf)
 		if err == nil {
 			t.Fatal("expected error for non-existent task ID")
 		}
@@ -291,8 +161,6 @@ func TestOutputMutationResult(t *testing.T) {
 }
 
 func TestOutputTransitionOrCascade(t *testing.T) {
-	now := time.Now()
-
 	t.Run("it uses FormatTransition when cascade result is nil", func(t *testing.T) {
 		var buf strings.Builder
 		fmtr := &ToonFormatter{}
@@ -330,18 +198,17 @@ func TestOutputTransitionOrCascade(t *testing.T) {
 		var buf strings.Builder
 		fmtr := &ToonFormatter{}
 
-		parent := task.Task{ID: "tick-parent1", Title: "Parent", Status: task.StatusCancelled, Created: now, Updated: now}
-		child := task.Task{ID: "tick-child1", Title: "Child", Status: task.StatusCancelled, Parent: "tick-parent1", Created: now, Updated: now}
-		tasks := []task.Task{parent, child}
-
-		result := task.TransitionResult{OldStatus: task.StatusInProgress, NewStatus: task.StatusCancelled}
-		cascades := []task.CascadeChange{
-			{Task: &tasks[1], Action: "cancel", OldStatus: task.StatusOpen, NewStatus: task.StatusCancelled},
+		cr := CascadeResult{
+			TaskID:    "tick-parent1",
+			TaskTitle: "Parent",
+			OldStatus: "in_progress",
+			NewStatus: "cancelled",
+			Cascaded: []CascadeEntry{
+				{ID: "tick-child1", Title: "Child", ParentID: "tick-parent1", OldStatus: "open", NewStatus: "cancelled"},
+			},
 		}
 
-		cr := buildCascadeResult("tick-parent1", "Parent", result, cascades, tasks)
-
-		outputTransitionOrCascade(&buf, fmtr, "tick-parent1", string(result.OldStatus), string(result.NewStatus), &cr)
+		outputTransitionOrCascade(&buf, fmtr, "tick-parent1", cr.OldStatus, cr.NewStatus, &cr)
 
 		output := buf.String()
 		if !strings.Contains(output, "tick-parent1: in_progress → cancelled") {
@@ -371,23 +238,23 @@ func TestOutputTransitionOrCascade(t *testing.T) {
 	t.Run("it produces identical output to inline pattern for cascade transition", func(t *testing.T) {
 		fmtr := &PrettyFormatter{}
 
-		parent := task.Task{ID: "tick-parent1", Title: "Parent", Status: task.StatusCancelled, Created: now, Updated: now}
-		child := task.Task{ID: "tick-child1", Title: "Child", Status: task.StatusCancelled, Parent: "tick-parent1", Created: now, Updated: now}
-		tasks := []task.Task{parent, child}
-
-		result := task.TransitionResult{OldStatus: task.StatusInProgress, NewStatus: task.StatusCancelled}
-		cascades := []task.CascadeChange{
-			{Task: &tasks[1], Action: "cancel", OldStatus: task.StatusOpen, NewStatus: task.StatusCancelled},
+		cr := CascadeResult{
+			TaskID:    "tick-parent1",
+			TaskTitle: "Parent",
+			OldStatus: "in_progress",
+			NewStatus: "cancelled",
+			Cascaded: []CascadeEntry{
+				{ID: "tick-child1", Title: "Child", ParentID: "tick-parent1", OldStatus: "open", NewStatus: "cancelled"},
+			},
 		}
 
 		// Inline pattern
 		var inlineBuf strings.Builder
-		cr := buildCascadeResult("tick-parent1", "Parent", result, cascades, tasks)
 		fmt.Fprintln(&inlineBuf, fmtr.FormatCascadeTransition(cr))
 
 		// Helper function
 		var helperBuf strings.Builder
-		outputTransitionOrCascade(&helperBuf, fmtr, "tick-parent1", string(result.OldStatus), string(result.NewStatus), &cr)
+		outputTransitionOrCascade(&helperBuf, fmtr, "tick-parent1", cr.OldStatus, cr.NewStatus, &cr)
 
 		if helperBuf.String() != inlineBuf.String() {
 			t.Errorf("helper output = %q, inline output = %q", helperBuf.String(), inlineBuf.String())
@@ -407,109 +274,6 @@ func TestOutputTransitionOrCascade(t *testing.T) {
 	})
 }
 
-func TestValidateAndReopenParent(t *testing.T) {
-	now := time.Date(2026, 3, 7, 12, 0, 0, 0, time.UTC)
-
-	t.Run("it returns no-op when parent is open", func(t *testing.T) {
-		tasks := []task.Task{
-			{ID: "tick-parent1", Title: "Parent", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
-		}
-		var sm task.StateMachine
-
-		_, _, reopened, err := validateAndReopenParent(tasks, "tick-parent1", &sm)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if reopened {
-			t.Error("reopened should be false for open parent")
-		}
-	})
-
-	t.Run("it returns no-op when parent is in_progress", func(t *testing.T) {
-		tasks := []task.Task{
-			{ID: "tick-parent1", Title: "Parent", Status: task.StatusInProgress, Priority: 2, Created: now, Updated: now},
-		}
-		var sm task.StateMachine
-
-		_, _, reopened, err := validateAndReopenParent(tasks, "tick-parent1", &sm)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if reopened {
-			t.Error("reopened should be false for in_progress parent")
-		}
-	})
-
-	t.Run("it returns error when parent is cancelled (Rule 7)", func(t *testing.T) {
-		tasks := []task.Task{
-			{ID: "tick-parent1", Title: "Parent", Status: task.StatusCancelled, Priority: 2, Created: now, Updated: now},
-		}
-		var sm task.StateMachine
-
-		_, _, _, err := validateAndReopenParent(tasks, "tick-parent1", &sm)
-		if err == nil {
-			t.Fatal("expected error for cancelled parent")
-		}
-		if !strings.Contains(err.Error(), "cancelled") {
-			t.Errorf("error = %q, want it to mention cancelled", err.Error())
-		}
-	})
-
-	t.Run("it reopens done parent (Rule 6)", func(t *testing.T) {
-		tasks := []task.Task{
-			{ID: "tick-parent1", Title: "Parent", Status: task.StatusDone, Priority: 2, Created: now, Updated: now},
-		}
-		var sm task.StateMachine
-
-		result, _, reopened, err := validateAndReopenParent(tasks, "tick-parent1", &sm)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if !reopened {
-			t.Error("reopened should be true for done parent")
-		}
-		if result.OldStatus != task.StatusDone {
-			t.Errorf("result.OldStatus = %v, want done", result.OldStatus)
-		}
-		if result.NewStatus != task.StatusOpen {
-			t.Errorf("result.NewStatus = %v, want open", result.NewStatus)
-		}
-		if tasks[0].Status != task.StatusOpen {
-			t.Errorf("tasks[0].Status = %v, want open after reopen", tasks[0].Status)
-		}
-	})
-
-	t.Run("it finds parent by normalized ID", func(t *testing.T) {
-		tasks := []task.Task{
-			{ID: "tick-parent1", Title: "Parent", Status: task.StatusDone, Priority: 2, Created: now, Updated: now},
-		}
-		var sm task.StateMachine
-
-		_, _, reopened, err := validateAndReopenParent(tasks, "TICK-PARENT1", &sm)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if !reopened {
-			t.Error("reopened should be true")
-		}
-	})
-
-	t.Run("it returns no-op when parent ID not found in tasks", func(t *testing.T) {
-		tasks := []task.Task{
-			{ID: "tick-parent1", Title: "Parent", Status: task.StatusDone, Priority: 2, Created: now, Updated: now},
-		}
-		var sm task.StateMachine
-
-		_, _, reopened, err := validateAndReopenParent(tasks, "tick-nonexist", &sm)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if reopened {
-			t.Error("reopened should be false when parent not found")
-		}
-	})
-}
-
 func TestOpenStore(t *testing.T) {
 	t.Run("it returns a valid store for a valid tick directory", func(t *testing.T) {
 		dir, _ := setupTickProject(t)
@@ -6,12 +6,16 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+
+	"github.com/leeovery/tick/internal/storage"
 )
 
 // RunInit initializes a new tick project in the given directory.
-// It creates the .tick/ directory and an empty tasks.jsonl file.
+// It creates the .tick/ directory, the format file recording the current data
+// format version, and either an empty tasks.jsonl file or, for the files layout,
+// an empty tasks/ directory.
 // If quiet, no output is produced on success. Otherwise, a message is formatted via the Formatter.
-func RunInit(dir string, fc FormatConfig, fmtr Formatter, stdout io.Writer) error {
+func RunInit(dir string, layout storage.Layout, fc FormatConfig, fmtr Formatter, stdout io.Writer) error {
 	absDir, err := filepath.Abs(dir)
 	if err != nil {
 		return fmt.Errorf("could not resolve absolute path: %w", err)
@@ -27,9 +31,19 @@ func RunInit(dir string, fc FormatConfig, fmtr Formatter, stdout io.Writer) erro
 		return fmt.Errorf("could not create .tick/ directory: %w", err)
 	}
 
-	jsonlPath := filepath.Join(tickDir, "tasks.jsonl")
-	if err := os.WriteFile(jsonlPath, []byte{}, 0644); err != nil {
-		return fmt.Errorf("could not create tasks.jsonl: %w", err)
+	if layout == storage.LayoutFiles {
+		if err := os.Mkdir(filepath.Join(tickDir, storage.TasksDirName), 0755); err != nil {
+			return fmt.Errorf("could not create tasks/ directory: %w", err)
+		}
+	} else {
+		jsonlPath := filepath.Join(tickDir, "tasks.jsonl")
+		if err := os.WriteFile(jsonlPath, []byte{}, 0644); err != nil {
+			return fmt.Errorf("could not create tasks.jsonl: %w", err)
+		}
+	}
+
+	if err := storage.WriteFormatVersion(tickDir, storage.FormatVersion); err != nil {
+		return fmt.Errorf("could not create format file: %w", err)
 	}
 
 	if !fc.Quiet {
@@ -39,3 +53,31 @@ func RunInit(dir string, fc FormatConfig, fmtr Formatter, stdout io.Writer) erro
 
 	return nil
 }
+
+// initOptions holds the parsed flags of the init command.
+type initOptions struct {
+	layout      storage.Layout
+	mergeDriver bool
+}
+
+// parseInitArgs parses init flags. The layout defaults to jsonl.
+func parseInitArgs(args []string) (initOptions, error) {
+	opts := initOptions{layout: storage.LayoutJSONL}
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--merge-driver":
+			opts.mergeDriver = true
+		case "--layout":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("--layout requires a value (jsonl or files)")
+			}
+			i++
+			layout, err := storage.ParseLayout(args[i])
+			if err != nil {
+				return opts, err
+			}
+			opts.layout = layout
+		}
+	}
+	return opts, nil
+}
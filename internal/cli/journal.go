@@ -0,0 +1,216 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/leeovery/tick/internal/storage"
+)
+
+// parseUndoArgs parses undo arguments: an optional count of recent mutations to
+// revert (default 1), or --entry naming a single journal entry. Returns the count
+// and the entry number, which is 0 when --entry is not given. A leading "#" is
+// accepted on the entry so numbers can be copied from journal output.
+func parseUndoArgs(args []string) (int, int, error) {
+	count, entry := 0, 0
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--entry" {
+			if i+1 >= len(args) {
+				return 0, 0, fmt.Errorf("--entry requires a value")
+			}
+			i++
+			seq, err := strconv.Atoi(strings.TrimPrefix(args[i], "#"))
+			if err != nil || seq < 1 {
+				return 0, 0, fmt.Errorf("invalid journal entry '%s': must be a positive integer", args[i])
+			}
+			entry = seq
+			continue
+		}
+		if count != 0 {
+			return 0, 0, fmt.Errorf("too many arguments. Usage: tick undo [<count>] [--entry <n>]")
+		}
+		c, err := strconv.Atoi(arg)
+		if err != nil || c < 1 {
+			return 0, 0, fmt.Errorf("invalid count '%s': must be a positive integer", arg)
+		}
+		count = c
+	}
+	if count != 0 && entry != 0 {
+		return 0, 0, fmt.Errorf("cannot combine a count with --entry")
+	}
+	if count == 0 {
+		count = 1
+	}
+	return count, entry, nil
+}
+
+// parseJournalArgs parses the journal command's --count flag. Returns 0 when unset.
+func parseJournalArgs(args []string) (int, error) {
+	count := 0
+	for i := 0; i < len(args); i++ {
+		if args[i] != "--count" {
+			continue
+		}
+		if i+1 >= len(args) {
+			return 0, fmt.Errorf("--count requires a value")
+		}
+		i++
+		c, err := strconv.Atoi(args[i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid count '%s': must be an integer", args[i])
+		}
+		if c < 1 {
+			return 0, fmt.Errorf("invalid count '%d': must be >= 1", c)
+		}
+		count = c
+	}
+	return count, nil
+}
+
+// RunUndo executes the undo command: reverts the given journal entry, or the last
+// count mutations (newest first) when entry is 0, restoring every task they
+// changed, including cascaded changes. Refuses when a later mutation touched any
+// of those tasks; the last count mutations are undone together under one lock,
+// so a refusal leaves them all in place.
+func RunUndo(dir string, fc FormatConfig, fmtr Formatter, count, entry int, stdout io.Writer) error {
+	store, err := openStore(dir, fc)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	var undone []storage.JournalEntry
+	if entry != 0 {
+		target, err := store.Undo(entry)
+		if err != nil {
+			return err
+		}
+		undone = append(undone, target)
+	} else if undone, err = store.UndoLast(count); err != nil {
+		return err
+	}
+
+	if !fc.Quiet {
+		for _, e := range undone {
+			fmt.Fprintln(stdout, fmtr.FormatMessage(journalMessage("Undid", e)))
+		}
+	}
+	return nil
+}
+
+// RunRedo executes the redo command: re-applies the most recently undone mutation.
+// Refuses when any task it changed has been modified since the undo.
+func RunRedo(dir string, fc FormatConfig, fmtr Formatter, stdout io.Writer) error {
+	store, err := openStore(dir, fc)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	entry, err := store.Redo()
+	if err != nil {
+		return err
+	}
+
+	if !fc.Quiet {
+		fmt.Fprintln(stdout, fmtr.FormatMessage(journalMessage("Redid", entry)))
+	}
+	return nil
+}
+
+// journalMessage builds the confirmation line for undo and redo, e.g.
+// "Undid #3 (tick done tick-a1b2c3): tick-a1b2c3, tick-d4e5f6".
+func journalMessage(verb string, entry storage.JournalEntry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s #%d", verb, entry.Seq)
+	if entry.Command != "" {
+		fmt.Fprintf(&b, " (%s)", entry.Command)
+	}
+	fmt.Fprintf(&b, ": %s", strings.Join(entry.TaskIDs(), ", "))
+	return b.String()
+}
+
+// RunJournal executes the journal command: lists recorded mutations, undos, and
+// redos newest first, limited to count entries when count > 0. In quiet mode only
+// entry numbers are printed.
+func RunJournal(dir string, fc FormatConfig, fmtr Formatter, count int, stdout io.Writer) error {
+	store, err := openStore(dir, fc)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	entries, err := store.Journal()
+	if err != nil {
+		return err
+	}
+
+	state := storage.NewJournalState(entries)
+	rows := make([]JournalRow, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		if count > 0 && len(rows) == count {
+			break
+		}
+		e := entries[i]
+		rows = append(rows, JournalRow{
+			Seq:     e.Seq,
+			At:      e.At,
+			Op:      string(e.Op),
+			Target:  e.Target,
+			Command: e.Command,
+			TaskIDs: e.TaskIDs(),
+			Undone:  e.Op.Undoable() && state.Undone(e.Seq),
+		})
+	}
+
+	if fc.Quiet {
+		for _, r := range rows {
+			fmt.Fprintln(stdout, r.Seq)
+		}
+		return nil
+	}
+
+	fmt.Fprintln(stdout, fmtr.FormatJournal(rows))
+	return nil
+}
+
+// handleUndo implements the undo subcommand.
+func (a *App) handleUndo(fc FormatConfig, fmtr Formatter, subArgs []string) error {
+	dir, err := a.Getwd()
+	if err != nil {
+		return fmt.Errorf("could not determine working directory: %w", err)
+	}
+	count, entry, err := parseUndoArgs(subArgs)
+	if err != nil {
+		return err
+	}
+	return RunUndo(dir, fc, fmtr, count, entry, a.Stdout)
+}
+
+// handleRedo implements the redo subcommand.
+func (a *App) handleRedo(fc FormatConfig, fmtr Formatter, subArgs []string) error {
+	dir, err := a.Getwd()
+	if err != nil {
+		return fmt.Errorf("could not determine working directory: %w", err)
+	}
+	if len(subArgs) > 0 {
+		return fmt.Errorf("redo takes no arguments. Usage: tick redo")
+	}
+	return RunRedo(dir, fc, fmtr, a.Stdout)
+}
+
+// handleJournal implements the journal subcommand.
+func (a *App) handleJournal(fc FormatConfig, fmtr Formatter, subArgs []string) error {
+	dir, err := a.Getwd()
+	if err != nil {
+		return fmt.Errorf("could not determine working directory: %w", err)
+	}
+	count, err := parseJournalArgs(subArgs)
+	if err != nil {
+		return err
+	}
+	return RunJournal(dir, fc, fmtr, count, a.Stdout)
+}
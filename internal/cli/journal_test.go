@@ -0,0 +1,320 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/storage"
+	"github.com/leeovery/tick/internal/task"
+)
+
+// runTick runs an arbitrary tick command line and returns stdout, stderr, and exit code.
+// Uses IsTTY=true to default to PrettyFormatter for consistent test output.
+func runTick(t *testing.T, dir string, args ...string) (stdout string, stderr string, exitCode int) {
+	t.Helper()
+	var stdoutBuf, stderrBuf bytes.Buffer
+	app := &App{
+		Stdout: &stdoutBuf,
+		Stderr: &stderrBuf,
+		Getwd:  func() (string, error) { return dir, nil },
+		IsTTY:  true,
+	}
+	code := app.Run(append([]string{"tick"}, args...))
+	return stdoutBuf.String(), stderrBuf.String(), code
+}
+
+func TestUndoRedo(t *testing.T) {
+	now := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+
+	t.Run("it undoes a done that cascaded through a subtree", func(t *testing.T) {
+		tasks := []task.Task{
+			{ID: "tick-par111", Title: "Parent", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+			{ID: "tick-chd111", Title: "Child one", Status: task.StatusOpen, Priority: 2, Parent: "tick-par111", Created: now, Updated: now},
+			{ID: "tick-chd222", Title: "Child two", Status: task.StatusInProgress, Priority: 2, Parent: "tick-par111", Created: now, Updated: now},
+		}
+		dir, tickDir := setupTickProjectWithTasks(t, tasks)
+
+		if _, stderr, code := runTick(t, dir, "done", "tick-par111"); code != 0 {
+			t.Fatalf("done failed: %s", stderr)
+		}
+		for _, tk := range readPersistedTasks(t, tickDir) {
+			if tk.Status != task.StatusDone {
+				t.Fatalf("precondition: %s status = %s, want done after cascade", tk.ID, tk.Status)
+			}
+		}
+
+		stdout, stderr, code := runTick(t, dir, "undo")
+		if code != 0 {
+			t.Fatalf("undo exit code = %d; stderr = %q", code, stderr)
+		}
+		want := "Undid #1 (tick done tick-par111): tick-chd111, tick-chd222, tick-par111\n"
+		if stdout != want {
+			t.Errorf("stdout = %q, want %q", stdout, want)
+		}
+
+		got := map[string]task.Status{}
+		for _, tk := range readPersistedTasks(t, tickDir) {
+			got[tk.ID] = tk.Status
+			if len(tk.Transitions) != 0 || tk.Closed != nil {
+				t.Errorf("%s not fully restored: transitions=%v closed=%v", tk.ID, tk.Transitions, tk.Closed)
+			}
+		}
+		if got["tick-par111"] != task.StatusOpen || got["tick-chd111"] != task.StatusOpen || got["tick-chd222"] != task.StatusInProgress {
+			t.Errorf("statuses after undo = %v", got)
+		}
+	})
+
+	t.Run("it restores a force-removed subtree and its dependency references", func(t *testing.T) {
+		tasks := []task.Task{
+			{ID: "tick-par111", Title: "Parent", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+			{ID: "tick-chd111", Title: "Child", Status: task.StatusOpen, Priority: 2, Parent: "tick-par111", Created: now, Updated: now},
+			{ID: "tick-dep111", Title: "Dependent", Status: task.StatusOpen, Priority: 2, BlockedBy: []string{"tick-chd111"}, Created: now, Updated: now},
+		}
+		dir, tickDir := setupTickProjectWithTasks(t, tasks)
+
+		if _, stderr, code := runTick(t, dir, "remove", "-f", "tick-par111"); code != 0 {
+			t.Fatalf("remove failed: %s", stderr)
+		}
+		if _, stderr, code := runTick(t, dir, "undo"); code != 0 {
+			t.Fatalf("undo failed: %s", stderr)
+		}
+
+		restored := readPersistedTasks(t, tickDir)
+		if len(restored) != 3 {
+			t.Fatalf("tasks after undo = %d, want 3", len(restored))
+		}
+		for _, tk := range restored {
+			if tk.ID == "tick-dep111" && (len(tk.BlockedBy) != 1 || tk.BlockedBy[0] != "tick-chd111") {
+				t.Errorf("blocked_by = %v, want [tick-chd111]", tk.BlockedBy)
+			}
+		}
+
+		// The restored tasks are queryable again.
+		if _, stderr, code := runTick(t, dir, "show", "tick-chd111"); code != 0 {
+			t.Errorf("show after undo failed: %s", stderr)
+		}
+	})
+
+	t.Run("it redoes an undone mutation", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		})
+
+		runTick(t, dir, "start", "tick-aaa111")
+		runTick(t, dir, "undo")
+		stdout, stderr, code := runTick(t, dir, "redo")
+		if code != 0 {
+			t.Fatalf("redo exit code = %d; stderr = %q", code, stderr)
+		}
+		if stdout != "Redid #1 (tick start tick-aaa111): tick-aaa111\n" {
+			t.Errorf("stdout = %q", stdout)
+		}
+		if got := readPersistedTasks(t, tickDir)[0].Status; got != task.StatusInProgress {
+			t.Errorf("status = %s, want in_progress", got)
+		}
+	})
+
+	t.Run("it refuses to undo an entry whose tasks were changed later", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		})
+
+		runTick(t, dir, "start", "tick-aaa111")
+		runTick(t, dir, "update", "tick-aaa111", "--title", "Renamed task")
+
+		_, stderr, code := runTick(t, dir, "undo", "--entry", "1")
+		if code != 1 {
+			t.Fatalf("exit code = %d, want 1", code)
+		}
+		want := `Error: cannot undo #1: task tick-aaa111 was changed by #2 (tick update tick-aaa111 --title "Renamed task")` + "\n"
+		if stderr != want {
+			t.Errorf("stderr = %q, want %q", stderr, want)
+		}
+		if got := readPersistedTasks(t, tickDir)[0]; got.Status != task.StatusInProgress || got.Title != "Renamed task" {
+			t.Errorf("refused undo modified task: %+v", got)
+		}
+	})
+
+	t.Run("it rejects an invalid entry number", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+
+		_, stderr, code := runTick(t, dir, "undo", "--entry", "abc")
+		if code != 1 || !strings.Contains(stderr, "invalid journal entry 'abc'") {
+			t.Errorf("exit = %d, stderr = %q", code, stderr)
+		}
+	})
+
+	t.Run("it rejects an invalid count", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+
+		_, stderr, code := runTick(t, dir, "undo", "0")
+		if code != 1 || !strings.Contains(stderr, "invalid count '0'") {
+			t.Errorf("exit = %d, stderr = %q", code, stderr)
+		}
+	})
+
+	t.Run("it rejects a count combined with --entry", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+
+		_, stderr, code := runTick(t, dir, "undo", "2", "--entry", "1")
+		if code != 1 || !strings.Contains(stderr, "cannot combine a count with --entry") {
+			t.Errorf("exit = %d, stderr = %q", code, stderr)
+		}
+	})
+
+	t.Run("it undoes the last N mutations newest first", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		})
+
+		runTick(t, dir, "start", "tick-aaa111")
+		runTick(t, dir, "update", "tick-aaa111", "--title", "Renamed task")
+		stdout, stderr, code := runTick(t, dir, "undo", "2")
+		if code != 0 {
+			t.Fatalf("undo exit code = %d; stderr = %q", code, stderr)
+		}
+		want := `Undid #2 (tick update tick-aaa111 --title "Renamed task"): tick-aaa111` + "\n" +
+			"Undid #1 (tick start tick-aaa111): tick-aaa111\n"
+		if stdout != want {
+			t.Errorf("stdout = %q, want %q", stdout, want)
+		}
+		if got := readPersistedTasks(t, tickDir)[0]; got.Status != task.StatusOpen || got.Title != "Task" {
+			t.Errorf("task not fully restored: %+v", got)
+		}
+	})
+
+	t.Run("it undoes nothing when there are fewer mutations than asked", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		})
+
+		runTick(t, dir, "start", "tick-aaa111")
+		_, stderr, code := runTick(t, dir, "undo", "3")
+		if code != 1 || stderr != "Error: cannot undo 3 mutations: only 1 can be undone\n" {
+			t.Errorf("exit = %d, stderr = %q", code, stderr)
+		}
+		if got := readPersistedTasks(t, tickDir)[0].Status; got != task.StatusInProgress {
+			t.Errorf("status = %s, want in_progress", got)
+		}
+	})
+
+	t.Run("it undoes nothing when one of the last N is refused", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+			{ID: "tick-bbb222", Title: "Other", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		})
+
+		runTick(t, dir, "start", "tick-aaa111")
+		runTick(t, dir, "start", "tick-bbb222")
+		tasks := readPersistedTasks(t, tickDir)
+		tasks[0].Title = "Edited by hand"
+		data, err := storage.MarshalJSONL(tasks)
+		if err != nil {
+			t.Fatalf("failed to marshal tasks: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(tickDir, "tasks.jsonl"), data, 0644); err != nil {
+			t.Fatalf("failed to write tasks.jsonl: %v", err)
+		}
+
+		_, stderr, code := runTick(t, dir, "undo", "2")
+		if want := "Error: cannot undo 2 mutations: cannot undo #1: task tick-aaa111 was modified outside tick\n"; code != 1 || stderr != want {
+			t.Errorf("exit = %d, stderr = %q, want %q", code, stderr, want)
+		}
+		if got := readPersistedTasks(t, tickDir)[1].Status; got != task.StatusInProgress {
+			t.Errorf("tick-bbb222 status = %s, want in_progress: #2 should not be undone", got)
+		}
+	})
+
+	t.Run("it reports nothing to undo on a fresh project", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+
+		_, stderr, code := runTick(t, dir, "undo")
+		if code != 1 || stderr != "Error: nothing to undo\n" {
+			t.Errorf("exit = %d, stderr = %q", code, stderr)
+		}
+	})
+}
+
+func TestJournalCommand(t *testing.T) {
+	now := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+
+	t.Run("it lists entries newest first with undone markers", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		})
+		runTick(t, dir, "start", "tick-aaa111")
+		runTick(t, dir, "undo")
+
+		stdout, stderr, code := runTick(t, dir, "journal")
+		if code != 0 {
+			t.Fatalf("exit code = %d; stderr = %q", code, stderr)
+		}
+		lines := strings.Split(strings.TrimRight(stdout, "\n"), "\n")
+		if len(lines) != 3 {
+			t.Fatalf("lines = %d, want header + 2; stdout = %q", len(lines), stdout)
+		}
+		if !strings.HasPrefix(lines[0], "#") || !strings.Contains(lines[0], "COMMAND") {
+			t.Errorf("header = %q", lines[0])
+		}
+		if !strings.Contains(lines[1], "undo #1") || !strings.Contains(lines[1], "tick undo") {
+			t.Errorf("newest row = %q, want undo #1", lines[1])
+		}
+		if !strings.Contains(lines[2], "mutate (undone)") || !strings.Contains(lines[2], "tick start tick-aaa111") {
+			t.Errorf("oldest row = %q, want undone mutate", lines[2])
+		}
+	})
+
+	t.Run("it limits entries with --count and prints numbers in quiet mode", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		})
+		runTick(t, dir, "start", "tick-aaa111")
+		runTick(t, dir, "done", "tick-aaa111")
+
+		stdout, _, code := runTick(t, dir, "journal", "--count", "1", "--quiet")
+		if code != 0 || stdout != "2\n" {
+			t.Errorf("exit = %d, stdout = %q, want 2", code, stdout)
+		}
+	})
+
+	t.Run("it outputs entries as JSON", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		})
+		runTick(t, dir, "start", "tick-aaa111")
+
+		stdout, _, code := runTick(t, dir, "journal", "--json")
+		if code != 0 {
+			t.Fatalf("exit code = %d", code)
+		}
+		var entries []map[string]any
+		if err := json.Unmarshal([]byte(stdout), &entries); err != nil {
+			t.Fatalf("invalid JSON: %v; stdout = %q", err, stdout)
+		}
+		if len(entries) != 1 || entries[0]["op"] != "mutate" || entries[0]["undone"] != false {
+			t.Errorf("entries = %v", entries)
+		}
+		if ids, _ := entries[0]["task_ids"].([]any); len(ids) != 1 || ids[0] != "tick-aaa111" {
+			t.Errorf("task_ids = %v", entries[0]["task_ids"])
+		}
+	})
+
+	t.Run("it shows an empty journal", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+
+		stdout, _, code := runTick(t, dir, "journal")
+		if code != 0 || strings.TrimSpace(stdout) != "No journal entries." {
+			t.Errorf("exit = %d, stdout = %q", code, stdout)
+		}
+
+		stdout, _, _ = runTick(t, dir, "journal", "--toon")
+		if strings.TrimSpace(stdout) != "journal[0]{seq,at,op,target,undone,tasks,command}:" {
+			t.Errorf("toon stdout = %q", stdout)
+		}
+	})
+}
@@ -1,7 +1,9 @@
 package cli
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 
 	"github.com/leeovery/tick/internal/task"
 )
@@ -13,13 +15,20 @@ type JSONFormatter struct{}
 // Compile-time interface verification.
 var _ Formatter = (*JSONFormatter)(nil)
 
-// jsonTaskListItem represents a task in list output.
+// jsonTaskListItem represents a task in list output. assignee, due,
+// defer_until and recur are omitted when the task is unassigned, has no due
+// date, is not deferred or does not recur, and reason outside blocked lists.
 type jsonTaskListItem struct {
-	ID       string `json:"id"`
-	Title    string `json:"title"`
-	Status   string `json:"status"`
-	Priority int    `json:"priority"`
-	Type     string `json:"type"`
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Status     string `json:"status"`
+	Priority   int    `json:"priority"`
+	Type       string `json:"type"`
+	Assignee   string `json:"assignee,omitempty"`
+	Due        string `json:"due,omitempty"`
+	DeferUntil string `json:"defer_until,omitempty"`
+	Recur      string `json:"recur,omitempty"`
+	Reason     string `json:"reason,omitempty"`
 }
 
 // FormatTaskList renders a list of tasks as a JSON array.
@@ -27,17 +36,174 @@ type jsonTaskListItem struct {
 func (f *JSONFormatter) FormatTaskList(tasks []task.Task) string {
 	items := make([]jsonTaskListItem, 0, len(tasks))
 	for _, t := range tasks {
-		items = append(items, jsonTaskListItem{
+		item := jsonTaskListItem{
 			ID:       t.ID,
 			Title:    t.Title,
 			Status:   string(t.Status),
 			Priority: t.Priority,
 			Type:     t.Type,
+			Assignee: t.Assignee,
+			Recur:    t.Recur,
+			Reason:   t.BlockedReason,
+		}
+		if t.Due != nil {
+			item.Due = task.FormatTimestamp(*t.Due)
+		}
+		if t.DeferUntil != nil {
+			item.DeferUntil = task.FormatTimestamp(*t.DeferUntil)
+		}
+		items = append(items, item)
+	}
+	return marshalIndentJSON(items)
+}
+
+// jsonSearchResult represents a search match in JSON output.
+type jsonSearchResult struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Priority int    `json:"priority"`
+	Type     string `json:"type"`
+	Snippet  string `json:"snippet"`
+}
+
+// FormatSearchResults renders search matches as a JSON array in rank order.
+// Empty input produces "[]", never "null".
+func (f *JSONFormatter) FormatSearchResults(results []SearchResult) string {
+	items := make([]jsonSearchResult, 0, len(results))
+	for _, r := range results {
+		items = append(items, jsonSearchResult{
+			ID:       r.Task.ID,
+			Title:    r.Task.Title,
+			Status:   string(r.Task.Status),
+			Priority: r.Task.Priority,
+			Type:     r.Task.Type,
+			Snippet:  r.Snippet,
+		})
+	}
+	return marshalIndentJSON(items)
+}
+
+// jsonJournalEntry represents a journal entry in JSON output.
+// Target is omitted for mutate entries.
+type jsonJournalEntry struct {
+	Seq     int      `json:"seq"`
+	At      string   `json:"at"`
+	Op      string   `json:"op"`
+	Target  int      `json:"target,omitempty"`
+	Undone  bool     `json:"undone"`
+	TaskIDs []string `json:"task_ids"`
+	Command string   `json:"command"`
+}
+
+// FormatJournal renders journal entries as a JSON array, newest first.
+// Empty input produces "[]", never "null".
+func (f *JSONFormatter) FormatJournal(rows []JournalRow) string {
+	items := make([]jsonJournalEntry, 0, len(rows))
+	for _, r := range rows {
+		ids := r.TaskIDs
+		if ids == nil {
+			ids = []string{}
+		}
+		items = append(items, jsonJournalEntry{
+			Seq:     r.Seq,
+			At:      task.FormatTimestamp(r.At),
+			Op:      r.Op,
+			Target:  r.Target,
+			Undone:  r.Undone,
+			TaskIDs: ids,
+			Command: r.Command,
 		})
 	}
 	return marshalIndentJSON(items)
 }
 
+// jsonLockStatus represents the lock status in JSON output.
+type jsonLockStatus struct {
+	Held    bool             `json:"held"`
+	Holders []jsonLockHolder `json:"holders"`
+}
+
+// jsonLockHolder represents a recorded lock holder in JSON output.
+type jsonLockHolder struct {
+	PID      int    `json:"pid"`
+	Hostname string `json:"hostname"`
+	Command  string `json:"command"`
+	Mode     string `json:"mode"`
+	Started  string `json:"started"`
+	Alive    bool   `json:"alive"`
+}
+
+// FormatLockStatus renders the lock status as a JSON object.
+// No holders produces "holders": [], never null.
+func (f *JSONFormatter) FormatLockStatus(status LockStatus) string {
+	out := jsonLockStatus{Held: status.Held, Holders: make([]jsonLockHolder, 0, len(status.Holders))}
+	for _, h := range status.Holders {
+		out.Holders = append(out.Holders, jsonLockHolder{
+			PID:      h.PID,
+			Hostname: h.Hostname,
+			Command:  h.Command,
+			Mode:     h.Mode,
+			Started:  task.FormatTimestamp(h.Started),
+			Alive:    h.Alive,
+		})
+	}
+	return marshalIndentJSON(out)
+}
+
+// jsonConfigEntry represents a configuration setting in JSON output.
+type jsonConfigEntry struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Default bool   `json:"default"`
+}
+
+// FormatConfig renders the configuration settings as a JSON array.
+func (f *JSONFormatter) FormatConfig(entries []ConfigEntry) string {
+	out := make([]jsonConfigEntry, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, jsonConfigEntry(e))
+	}
+	return marshalIndentJSON(out)
+}
+
+// jsonBatch represents the outcome of a batch in JSON output.
+type jsonBatch struct {
+	Ops           []jsonBatchOp           `json:"ops"`
+	StatusChanges []jsonBatchStatusChange `json:"status_changes"`
+}
+
+// jsonBatchOp represents one applied batch operation in JSON output.
+type jsonBatchOp struct {
+	Op  string `json:"op"`
+	ID  string `json:"id"`
+	Ref string `json:"ref,omitempty"`
+}
+
+// jsonBatchStatusChange represents a status change made by a batch in JSON output.
+type jsonBatchStatusChange struct {
+	ID   string `json:"id"`
+	From string `json:"from"`
+	To   string `json:"to"`
+	Auto bool   `json:"auto"`
+}
+
+// FormatBatch renders the batch outcome as a JSON object. Empty lists produce
+// [], never null.
+func (f *JSONFormatter) FormatBatch(summary BatchSummary) string {
+	out := jsonBatch{
+		Ops:           make([]jsonBatchOp, 0, len(summary.Rows)),
+		StatusChanges: make([]jsonBatchStatusChange, 0, len(summary.StatusChanges)),
+	}
+	for _, r := range summary.Rows {
+		out.Ops = append(out.Ops, jsonBatchOp{Op: r.Op, ID: r.ID, Ref: r.Ref})
+	}
+	for _, c := range summary.StatusChanges {
+		out.StatusChanges = append(out.StatusChanges, jsonBatchStatusChange(c))
+	}
+	return marshalIndentJSON(out)
+}
+
 // jsonRelatedTask represents a related task (blocker or child) in JSON output.
 type jsonRelatedTask struct {
 	ID     string `json:"id"`
@@ -51,26 +217,44 @@ type jsonNote struct {
 	Created string `json:"created"`
 }
 
+// jsonRollup represents an estimate rollup in JSON output.
+type jsonRollup struct {
+	Total     float64 `json:"total"`
+	Remaining float64 `json:"remaining"`
+}
+
 // jsonTaskDetail represents the full task detail in JSON output.
-// parent and closed use omitempty to omit when zero/nil.
-// blocked_by, children, tags, refs, and notes are always present as arrays.
+// parent, due, defer_until, estimate, rollup, recur, recurred_from, assignee, lease_expires and closed use omitempty to omit when zero/nil.
+// blocked_by, children, tags, refs, and notes are always present as arrays,
+// and fields always as an object, keyed in sorted order.
 // description is always present (empty string, not null/omitted).
+// extra holds fields tick does not recognize and is omitted when there are none.
 type jsonTaskDetail struct {
-	ID          string            `json:"id"`
-	Title       string            `json:"title"`
-	Status      string            `json:"status"`
-	Priority    int               `json:"priority"`
-	Type        string            `json:"type"`
-	Tags        []string          `json:"tags"`
-	Refs        []string          `json:"refs"`
-	Notes       []jsonNote        `json:"notes"`
-	Description string            `json:"description"`
-	Parent      string            `json:"parent,omitempty"`
-	Created     string            `json:"created"`
-	Updated     string            `json:"updated"`
-	Closed      string            `json:"closed,omitempty"`
-	BlockedBy   []jsonRelatedTask `json:"blocked_by"`
-	Children    []jsonRelatedTask `json:"children"`
+	ID           string                     `json:"id"`
+	Title        string                     `json:"title"`
+	Status       string                     `json:"status"`
+	Priority     int                        `json:"priority"`
+	Type         string                     `json:"type"`
+	Tags         []string                   `json:"tags"`
+	Refs         []string                   `json:"refs"`
+	Fields       map[string]string          `json:"fields"`
+	Notes        []jsonNote                 `json:"notes"`
+	Description  string                     `json:"description"`
+	Parent       string                     `json:"parent,omitempty"`
+	Due          string                     `json:"due,omitempty"`
+	DeferUntil   string                     `json:"defer_until,omitempty"`
+	Estimate     float64                    `json:"estimate,omitempty"`
+	Rollup       *jsonRollup                `json:"rollup,omitempty"`
+	Recur        string                     `json:"recur,omitempty"`
+	RecurredFrom string                     `json:"recurred_from,omitempty"`
+	Assignee     string                     `json:"assignee,omitempty"`
+	LeaseExpires string                     `json:"lease_expires,omitempty"`
+	Created      string                     `json:"created"`
+	Updated      string                     `json:"updated"`
+	Closed       string                     `json:"closed,omitempty"`
+	BlockedBy    []jsonRelatedTask          `json:"blocked_by"`
+	Children     []jsonRelatedTask          `json:"children"`
+	Extra        map[string]json.RawMessage `json:"extra,omitempty"`
 }
 
 // FormatTaskDetail renders a single task with full details as a JSON object.
@@ -84,12 +268,32 @@ func (f *JSONFormatter) FormatTaskDetail(detail TaskDetail) string {
 		closedStr = task.FormatTimestamp(*t.Closed)
 	}
 
+	var dueStr string
+	if t.Due != nil {
+		dueStr = task.FormatTimestamp(*t.Due)
+	}
+
+	var deferStr string
+	if t.DeferUntil != nil {
+		deferStr = task.FormatTimestamp(*t.DeferUntil)
+	}
+
+	var leaseStr string
+	if t.LeaseExpires != nil {
+		leaseStr = task.FormatTimestamp(*t.LeaseExpires)
+	}
+
 	tags := make([]string, 0, len(detail.Tags))
 	tags = append(tags, detail.Tags...)
 
 	refs := make([]string, 0, len(detail.Refs))
 	refs = append(refs, detail.Refs...)
 
+	fields := make(map[string]string, len(detail.Fields))
+	for _, field := range detail.Fields {
+		fields[field.Key] = field.Value
+	}
+
 	notes := make([]jsonNote, 0, len(detail.Notes))
 	for _, n := range detail.Notes {
 		notes = append(notes, jsonNote{
@@ -99,21 +303,33 @@ func (f *JSONFormatter) FormatTaskDetail(detail TaskDetail) string {
 	}
 
 	obj := jsonTaskDetail{
-		ID:          t.ID,
-		Title:       t.Title,
-		Status:      string(t.Status),
-		Priority:    t.Priority,
-		Type:        t.Type,
-		Tags:        tags,
-		Refs:        refs,
-		Notes:       notes,
-		Description: t.Description,
-		Parent:      t.Parent,
-		Created:     task.FormatTimestamp(t.Created),
-		Updated:     task.FormatTimestamp(t.Updated),
-		Closed:      closedStr,
-		BlockedBy:   toJSONRelated(detail.BlockedBy),
-		Children:    toJSONRelated(detail.Children),
+		ID:           t.ID,
+		Title:        t.Title,
+		Status:       string(t.Status),
+		Priority:     t.Priority,
+		Type:         t.Type,
+		Tags:         tags,
+		Refs:         refs,
+		Fields:       fields,
+		Notes:        notes,
+		Description:  t.Description,
+		Parent:       t.Parent,
+		Due:          dueStr,
+		DeferUntil:   deferStr,
+		Estimate:     t.Estimate,
+		Recur:        t.Recur,
+		RecurredFrom: t.RecurredFrom,
+		Assignee:     t.Assignee,
+		LeaseExpires: leaseStr,
+		Created:      task.FormatTimestamp(t.Created),
+		Updated:      task.FormatTimestamp(t.Updated),
+		Closed:       closedStr,
+		BlockedBy:    toJSONRelated(detail.BlockedBy),
+		Children:     toJSONRelated(detail.Children),
+		Extra:        t.Extra,
+	}
+	if detail.Rollup != nil {
+		obj.Rollup = &jsonRollup{Total: detail.Rollup.Total, Remaining: detail.Rollup.Remaining}
 	}
 
 	return marshalIndentJSON(obj)
@@ -161,18 +377,33 @@ func (f *JSONFormatter) FormatDepChange(action string, taskID string, depID stri
 	})
 }
 
-// jsonStatusCounts represents the by_status section in stats output.
-type jsonStatusCounts struct {
-	Open       int `json:"open"`
-	InProgress int `json:"in_progress"`
-	Done       int `json:"done"`
-	Cancelled  int `json:"cancelled"`
+// jsonStatusCounts represents the by_status section in stats output: an
+// object keyed by status, in workflow order.
+type jsonStatusCounts []StateCount
+
+// MarshalJSON encodes the counts as an object, keeping their order.
+func (c jsonStatusCounts) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, sc := range c {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(sc.State)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&b, "%s:%d", key, sc.Count)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
 }
 
 // jsonWorkflow represents the workflow section in stats output.
 type jsonWorkflow struct {
 	Ready   int `json:"ready"`
 	Blocked int `json:"blocked"`
+	Overdue int `json:"overdue"`
 }
 
 // jsonPriorityEntry represents a single priority entry in by_priority array.
@@ -187,11 +418,45 @@ type jsonStats struct {
 	ByStatus   jsonStatusCounts    `json:"by_status"`
 	Workflow   jsonWorkflow        `json:"workflow"`
 	ByPriority []jsonPriorityEntry `json:"by_priority"`
+	ByType     []jsonTypeEntry     `json:"by_type"`
+	ByAssignee []jsonAssigneeEntry `json:"by_assignee"`
+	Estimates  jsonEstimates       `json:"estimates"`
+}
+
+// jsonEstimates represents the estimates section in stats output: the totals
+// over every task and the rollup under each top-level task.
+type jsonEstimates struct {
+	Total     float64          `json:"total"`
+	Remaining float64          `json:"remaining"`
+	Rollups   []jsonTaskRollup `json:"rollups"`
+}
+
+// jsonTaskRollup represents a single top-level task in the rollups array.
+type jsonTaskRollup struct {
+	ID        string  `json:"id"`
+	Title     string  `json:"title"`
+	Total     float64 `json:"total"`
+	Remaining float64 `json:"remaining"`
+}
+
+// jsonAssigneeEntry represents a single assignee count in the by_assignee array.
+type jsonAssigneeEntry struct {
+	Assignee string `json:"assignee"`
+	Count    int    `json:"count"`
+}
+
+// jsonTypeEntry represents a single type count in the by_type array.
+type jsonTypeEntry struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
 }
 
 // FormatStats renders task statistics as a nested JSON object with
-// total, by_status, workflow, and by_priority sections.
-// by_priority always contains 5 entries (P0-P4), even when counts are zero.
+// total, by_status, workflow, by_priority, by_type, by_assignee and estimates
+// sections. by_status lists the project's own workflow states between
+// in_progress and done. by_priority always contains 5 entries (P0-P4), even
+// when counts are zero; by_type, by_assignee and estimates.rollups are []
+// rather than null when empty.
 func (f *JSONFormatter) FormatStats(stats Stats) string {
 	priorities := make([]jsonPriorityEntry, 5)
 	for i := range 5 {
@@ -202,18 +467,30 @@ func (f *JSONFormatter) FormatStats(stats Stats) string {
 	}
 
 	obj := jsonStats{
-		Total: stats.Total,
-		ByStatus: jsonStatusCounts{
-			Open:       stats.Open,
-			InProgress: stats.InProgress,
-			Done:       stats.Done,
-			Cancelled:  stats.Cancelled,
-		},
+		Total:    stats.Total,
+		ByStatus: statusCounts(stats),
 		Workflow: jsonWorkflow{
 			Ready:   stats.Ready,
 			Blocked: stats.Blocked,
+			Overdue: stats.Overdue,
 		},
 		ByPriority: priorities,
+		ByType:     make([]jsonTypeEntry, 0, len(stats.ByType)),
+	}
+	for _, tc := range stats.ByType {
+		obj.ByType = append(obj.ByType, jsonTypeEntry(tc))
+	}
+	obj.ByAssignee = make([]jsonAssigneeEntry, 0, len(stats.ByAssignee))
+	for _, ac := range stats.ByAssignee {
+		obj.ByAssignee = append(obj.ByAssignee, jsonAssigneeEntry(ac))
+	}
+	obj.Estimates = jsonEstimates{
+		Total:     stats.Estimates.Total,
+		Remaining: stats.Estimates.Remaining,
+		Rollups:   make([]jsonTaskRollup, 0, len(stats.Rollups)),
+	}
+	for _, r := range stats.Rollups {
+		obj.Estimates.Rollups = append(obj.Estimates.Rollups, jsonTaskRollup{ID: r.ID, Title: r.Title, Total: r.Total, Remaining: r.Remaining})
 	}
 
 	return marshalIndentJSON(obj)
@@ -407,6 +684,38 @@ func (f *JSONFormatter) formatFocusedDepTreeJSON(result DepTreeResult) string {
 	return marshalIndentJSON(obj)
 }
 
+// jsonCriticalPathTask represents a task on the critical path in JSON output.
+type jsonCriticalPathTask struct {
+	ID       string  `json:"id"`
+	Title    string  `json:"title"`
+	Status   string  `json:"status"`
+	Estimate float64 `json:"estimate"`
+}
+
+// jsonCriticalPath represents the critical-path output. target is omitted for
+// the whole project.
+type jsonCriticalPath struct {
+	Target string                 `json:"target,omitempty"`
+	Total  float64                `json:"total"`
+	Path   []jsonCriticalPathTask `json:"path"`
+}
+
+// FormatCriticalPath renders the critical path as a JSON object with the tasks
+// in the order the work has to be done. path is [] rather than null when empty.
+func (f *JSONFormatter) FormatCriticalPath(result CriticalPathResult) string {
+	obj := jsonCriticalPath{
+		Total: result.Total,
+		Path:  make([]jsonCriticalPathTask, 0, len(result.Tasks)),
+	}
+	if result.Target != nil {
+		obj.Target = result.Target.ID
+	}
+	for _, t := range result.Tasks {
+		obj.Path = append(obj.Path, jsonCriticalPathTask(t))
+	}
+	return marshalIndentJSON(obj)
+}
+
 // marshalIndentJSON marshals v as 2-space indented JSON.
 // Returns "null" on marshal failure (should not happen with controlled types).
 func marshalIndentJSON(v any) string {
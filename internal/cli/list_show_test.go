@@ -2,6 +2,9 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -530,7 +533,7 @@ func TestShow(t *testing.T) {
 		}
 	})
 
-	t.Run("queryShowData populates RelatedTask fields for blockers and children", func(t *testing.T) {
+	t.Run("Show populates RelatedTask fields for blockers and children", func(t *testing.T) {
 		now := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
 		tasks := []task.Task{
 			{ID: "tick-parent", Title: "Parent task", Status: task.StatusOpen, Priority: 1, Created: now, Updated: now},
@@ -541,21 +544,21 @@ func TestShow(t *testing.T) {
 		dir, _ := setupTickProjectWithTasks(t, tasks)
 
 		fc := FormatConfig{Format: FormatPretty}
-		store, err := openStore(dir, fc)
+		p, err := openProject(dir, fc)
 		if err != nil {
-			t.Fatalf("openStore failed: %v", err)
+			t.Fatalf("openProject failed: %v", err)
 		}
-		defer store.Close()
+		defer p.Close()
 
 		// Verify children are populated as RelatedTask with exported fields.
-		parentData, err := queryShowData(store, "tick-parent")
+		parentData, err := p.Show("tick-parent")
 		if err != nil {
-			t.Fatalf("queryShowData for parent failed: %v", err)
+			t.Fatalf("Show for parent failed: %v", err)
 		}
-		if len(parentData.children) != 1 {
-			t.Fatalf("expected 1 child, got %d", len(parentData.children))
+		if len(parentData.Children) != 1 {
+			t.Fatalf("expected 1 child, got %d", len(parentData.Children))
 		}
-		child := parentData.children[0]
+		child := parentData.Children[0]
 		if child.ID != "tick-child1" {
 			t.Errorf("child.ID = %q, want %q", child.ID, "tick-child1")
 		}
@@ -567,14 +570,14 @@ func TestShow(t *testing.T) {
 		}
 
 		// Verify blockedBy are populated as RelatedTask with exported fields.
-		blockedData, err := queryShowData(store, "tick-blocked")
+		blockedData, err := p.Show("tick-blocked")
 		if err != nil {
-			t.Fatalf("queryShowData for blocked task failed: %v", err)
+			t.Fatalf("Show for blocked task failed: %v", err)
 		}
-		if len(blockedData.blockedBy) != 1 {
-			t.Fatalf("expected 1 blocker, got %d", len(blockedData.blockedBy))
+		if len(blockedData.BlockedBy) != 1 {
+			t.Fatalf("expected 1 blocker, got %d", len(blockedData.BlockedBy))
 		}
-		blocker := blockedData.blockedBy[0]
+		blocker := blockedData.BlockedBy[0]
 		if blocker.ID != "tick-blocker" {
 			t.Errorf("blocker.ID = %q, want %q", blocker.ID, "tick-blocker")
 		}
@@ -740,4 +743,34 @@ func TestShow(t *testing.T) {
 			t.Errorf("stdout should contain 'Type:     feature', got %q", stdout)
 		}
 	})
+
+	t.Run("it preserves unrecognized fields through updates and shows them with --json", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+		line := `{"id":"tick-aaa111","title":"Task","status":"open","priority":2,"created":"2026-01-19T10:00:00Z","updated":"2026-01-19T10:00:00Z","sprint":12,"owner":{"team":"api"}}` + "\n"
+		if err := os.WriteFile(filepath.Join(tickDir, "tasks.jsonl"), []byte(line), 0644); err != nil {
+			t.Fatalf("failed to write tasks.jsonl: %v", err)
+		}
+
+		if _, stderr, exitCode := runUpdate(t, dir, "tick-aaa111", "--title", "Renamed"); exitCode != 0 {
+			t.Fatalf("update exit code = %d; stderr = %q", exitCode, stderr)
+		}
+		data, _ := os.ReadFile(filepath.Join(tickDir, "tasks.jsonl"))
+		if !strings.Contains(string(data), `"owner":{"team":"api"},"sprint":12}`) {
+			t.Errorf("tasks.jsonl lost unrecognized fields: %s", data)
+		}
+
+		stdout, stderr, exitCode := runShow(t, dir, "tick-aaa111", "--json")
+		if exitCode != 0 {
+			t.Fatalf("show exit code = %d; stderr = %q", exitCode, stderr)
+		}
+		var parsed struct {
+			Extra map[string]json.RawMessage `json:"extra"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &parsed); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+		}
+		if string(parsed.Extra["sprint"]) != "12" || len(parsed.Extra) != 2 {
+			t.Errorf("extra = %v, want owner and sprint", parsed.Extra)
+		}
+	})
 }
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/leeovery/tick/internal/storage"
+	"github.com/leeovery/tick/tick"
+)
+
+// RunLockStatus executes the lock status command: reports whether the .tick
+// lock is held right now and who holds it, including holders recorded by
+// processes that died without releasing it. In quiet mode it prints only the
+// PIDs of the recorded holders, one per line.
+func RunLockStatus(dir string, fc FormatConfig, fmtr Formatter, stdout io.Writer) error {
+	tickDir, err := tick.DiscoverTickDir(dir)
+	if err != nil {
+		return err
+	}
+
+	held, holders, err := storage.LockStatus(tickDir)
+	if err != nil {
+		return err
+	}
+
+	status := LockStatus{Held: held, Holders: make([]LockHolderRow, len(holders))}
+	for i, h := range holders {
+		status.Holders[i] = LockHolderRow{
+			PID:      h.PID,
+			Hostname: h.Hostname,
+			Command:  h.Command,
+			Mode:     string(h.Mode),
+			Started:  h.Started,
+			Alive:    h.Alive(),
+		}
+	}
+
+	if fc.Quiet {
+		for _, h := range status.Holders {
+			fmt.Fprintln(stdout, strconv.Itoa(h.PID))
+		}
+		return nil
+	}
+
+	fmt.Fprintln(stdout, fmtr.FormatLockStatus(status))
+	return nil
+}
+
+// handleLock implements the lock subcommand.
+func (a *App) handleLock(fc FormatConfig, fmtr Formatter, subArgs []string) error {
+	dir, err := a.Getwd()
+	if err != nil {
+		return fmt.Errorf("could not determine working directory: %w", err)
+	}
+
+	if len(subArgs) == 0 || subArgs[0] != "status" {
+		if len(subArgs) > 0 {
+			return fmt.Errorf("unknown lock sub-command '%s'. Usage: tick lock status", subArgs[0])
+		}
+		return fmt.Errorf("sub-command required. Usage: tick lock status")
+	}
+
+	return RunLockStatus(dir, fc, fmtr, a.Stdout)
+}
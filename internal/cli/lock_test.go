@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/leeovery/tick/internal/storage"
+)
+
+func TestLockStatus(t *testing.T) {
+	hostname, _ := os.Hostname()
+	started := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+
+	writeHolder := func(t *testing.T, tickDir string, h storage.LockHolder) {
+		t.Helper()
+		dir := filepath.Join(tickDir, "lock.holders")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create holders dir: %v", err)
+		}
+		data, _ := json.Marshal(h)
+		if err := os.WriteFile(filepath.Join(dir, "holder.json"), data, 0644); err != nil {
+			t.Fatalf("failed to write holder: %v", err)
+		}
+	}
+
+	t.Run("it reports a free lock", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+
+		stdout, stderr, code := runTick(t, dir, "--pretty", "lock", "status")
+		if code != 0 {
+			t.Fatalf("lock status failed: %s", stderr)
+		}
+		if stdout != "Lock: free\n" {
+			t.Errorf("stdout = %q, want %q", stdout, "Lock: free\n")
+		}
+	})
+
+	t.Run("it lists holders and marks dead ones", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+		writeHolder(t, tickDir, storage.LockHolder{PID: 0, Hostname: hostname, Command: "tick rebuild", Mode: storage.LockExclusive, Started: started})
+
+		stdout, _, code := runTick(t, dir, "--pretty", "lock", "status")
+		if code != 0 {
+			t.Fatalf("exit code = %d", code)
+		}
+		want := "pid 0 on " + hostname + ", exclusive since 2026-01-19 10:00:00 (dead)\n    tick rebuild"
+		if !strings.Contains(stdout, want) {
+			t.Errorf("stdout = %q, want it to contain %q", stdout, want)
+		}
+
+		stdout, _, _ = runTick(t, dir, "--json", "lock", "status")
+		var status struct {
+			Held    bool `json:"held"`
+			Holders []struct {
+				PID   int  `json:"pid"`
+				Alive bool `json:"alive"`
+			} `json:"holders"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &status); err != nil {
+			t.Fatalf("invalid JSON %q: %v", stdout, err)
+		}
+		if status.Held || len(status.Holders) != 1 || status.Holders[0].Alive {
+			t.Errorf("status = %+v, want free with one dead holder", status)
+		}
+
+		stdout, _, _ = runTick(t, dir, "--quiet", "lock", "status")
+		if stdout != "0\n" {
+			t.Errorf("quiet stdout = %q, want the holder PID", stdout)
+		}
+	})
+
+	t.Run("it requires the status sub-command", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+		_, stderr, code := runTick(t, dir, "lock")
+		if code != 1 || !strings.Contains(stderr, "Usage: tick lock status") {
+			t.Errorf("code = %d, stderr = %q", code, stderr)
+		}
+	})
+}
+
+func TestLockTimeout(t *testing.T) {
+	// holdLock holds the project's lock for the rest of the test.
+	holdLock := func(t *testing.T, tickDir string) {
+		t.Helper()
+		fl := flock.New(filepath.Join(tickDir, "lock"))
+		if err := fl.Lock(); err != nil {
+			t.Fatalf("failed to acquire lock: %v", err)
+		}
+		t.Cleanup(func() { _ = fl.Unlock() })
+	}
+
+	t.Run("it waits for the --lock-timeout before failing", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+		holdLock(t, tickDir)
+
+		start := time.Now()
+		_, stderr, code := runTick(t, dir, "list", "--lock-timeout", "200ms")
+		if code != 1 || !strings.Contains(stderr, "could not acquire lock") {
+			t.Fatalf("code = %d, stderr = %q", code, stderr)
+		}
+		if elapsed := time.Since(start); elapsed > 3*time.Second {
+			t.Errorf("took %v, want about 200ms", elapsed)
+		}
+	})
+
+	t.Run("it reads TICK_LOCK_TIMEOUT and lets the flag override it", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+		holdLock(t, tickDir)
+
+		run := func(env string, args ...string) (string, time.Duration) {
+			var stdout, stderr bytes.Buffer
+			app := &App{
+				Stdout: &stdout,
+				Stderr: &stderr,
+				Getwd:  func() (string, error) { return dir, nil },
+				Getenv: func(key string) string {
+					if key == "TICK_LOCK_TIMEOUT" {
+						return env
+					}
+					return ""
+				},
+			}
+			start := time.Now()
+			app.Run(append([]string{"tick"}, args...))
+			return stderr.String(), time.Since(start)
+		}
+
+		if stderr, elapsed := run("100ms", "list"); !strings.Contains(stderr, "could not acquire lock") || elapsed > 3*time.Second {
+			t.Errorf("env timeout: stderr = %q after %v", stderr, elapsed)
+		}
+		if stderr, elapsed := run("1h", "--lock-timeout", "100ms", "list"); !strings.Contains(stderr, "could not acquire lock") || elapsed > 3*time.Second {
+			t.Errorf("flag override: stderr = %q after %v", stderr, elapsed)
+		}
+		if stderr, _ := run("soon", "list"); !strings.Contains(stderr, "TICK_LOCK_TIMEOUT: invalid lock timeout 'soon'") {
+			t.Errorf("invalid env: stderr = %q", stderr)
+		}
+	})
+
+	t.Run("it rejects an invalid or missing flag value", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+		if _, stderr, code := runTick(t, dir, "list", "--lock-timeout", "-1s"); code != 1 || !strings.Contains(stderr, "invalid lock timeout '-1s'") {
+			t.Errorf("code = %d, stderr = %q", code, stderr)
+		}
+		if _, stderr, code := runTick(t, dir, "list", "--lock-timeout"); code != 1 || !strings.Contains(stderr, "--lock-timeout requires a value") {
+			t.Errorf("code = %d, stderr = %q", code, stderr)
+		}
+	})
+}
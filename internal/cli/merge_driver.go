@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/leeovery/tick/internal/config"
+	"github.com/leeovery/tick/internal/merge"
+	"github.com/leeovery/tick/internal/storage"
+	"github.com/leeovery/tick/internal/task"
+	"github.com/leeovery/tick/tick"
+)
+
+// mergeDriverAttribute is the .gitattributes line that routes tasks.jsonl merges
+// through the tick merge driver.
+const mergeDriverAttribute = ".tick/tasks.jsonl merge=tick"
+
+// RunMergeDriver performs a three-way merge of the base (%O), ours (%A), and
+// theirs (%B) files git passes to a merge driver. The merged, canonical JSONL is
+// always written to oursPath. Conflicts and validation problems are reported on
+// stderr, one per line, and produce exit code 1 so git marks the file conflicted
+// while leaving a parseable result for the user to review. Leases and checks
+// follow the workflow of the project containing dir, where git runs the driver;
+// outside a project, the default workflow.
+func RunMergeDriver(stderr io.Writer, dir, basePath, oursPath, theirsPath string) int {
+	w, err := mergeWorkflow(dir)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+	base, err := readMergeInput(basePath, "base")
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+	ours, err := readMergeInput(oursPath, "ours")
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+	theirs, err := readMergeInput(theirsPath, "theirs")
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	result := merge.Merge(base, ours, theirs, w)
+
+	if err := storage.WriteJSONL(oursPath, result.Tasks); err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	if len(result.Conflicts) == 0 {
+		return 0
+	}
+
+	fmt.Fprintf(stderr, "tick merge-driver: %d conflict(s) in tasks.jsonl\n", len(result.Conflicts))
+	for _, c := range result.Conflicts {
+		fmt.Fprintf(stderr, "  %s\n", c)
+	}
+	return 1
+}
+
+// mergeWorkflow returns the workflow of the project containing dir, or the
+// default workflow when dir is in no project. An invalid config is an error:
+// checking the merge against the wrong states would hide real conflicts.
+func mergeWorkflow(dir string) (task.Workflow, error) {
+	tickDir, err := tick.DiscoverTickDir(dir)
+	if err != nil {
+		return task.DefaultWorkflow(), nil
+	}
+	cfg, err := config.Load(tickDir)
+	if err != nil {
+		return task.Workflow{}, err
+	}
+	return cfg.Rules().Workflow, nil
+}
+
+// readMergeInput reads and parses one side of a merge. A missing or empty base
+// file (no common ancestor) yields an empty task list.
+func readMergeInput(path, side string) ([]task.Task, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if side == "base" && os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not read %s file: %w", side, err)
+	}
+	tasks, err := storage.ParseJSONL(data)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s file: %w", side, err)
+	}
+	return tasks, nil
+}
+
+// handleMergeDriver implements the merge-driver subcommand. Like doctor and
+// migrate, it bypasses the format/formatter machinery: git captures its output.
+func (a *App) handleMergeDriver(subArgs []string) int {
+	if len(subArgs) != 3 {
+		fmt.Fprintf(a.Stderr, "Error: merge-driver requires three file arguments. Usage: tick merge-driver %%O %%A %%B\n")
+		return 1
+	}
+	dir, err := a.Getwd()
+	if err != nil {
+		fmt.Fprintf(a.Stderr, "Error: could not determine working directory: %s\n", err)
+		return 1
+	}
+	return RunMergeDriver(a.Stderr, dir, subArgs[0], subArgs[1], subArgs[2])
+}
+
+// registerMergeDriver wires the tick merge driver into the git repository
+// containing dir: it appends the tasks.jsonl attribute to dir/.gitattributes
+// (if not already present) and sets merge.tick.name and merge.tick.driver in
+// the repository's local git config.
+func registerMergeDriver(dir string) error {
+	attrPath := filepath.Join(dir, ".gitattributes")
+	existing, err := os.ReadFile(attrPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not read .gitattributes: %w", err)
+	}
+
+	if !hasLine(string(existing), mergeDriverAttribute) {
+		content := string(existing)
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		content += mergeDriverAttribute + "\n"
+		if err := os.WriteFile(attrPath, []byte(content), 0644); err != nil {
+			return fmt.Errorf("could not write .gitattributes: %w", err)
+		}
+	}
+
+	settings := [][2]string{
+		{"merge.tick.name", "tick task merge driver"},
+		{"merge.tick.driver", "tick merge-driver %O %A %B"},
+	}
+	for _, kv := range settings {
+		cmd := exec.Command("git", "config", kv[0], kv[1])
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("could not set git config %s: %s", kv[0], strings.TrimSpace(string(out)))
+		}
+	}
+
+	return nil
+}
+
+// hasLine reports whether content contains line as a complete, trimmed line.
+func hasLine(content, line string) bool {
+	for l := range strings.SplitSeq(content, "\n") {
+		if strings.TrimSpace(l) == line {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runMergeDriver(t *testing.T, args ...string) (stdout string, stderr string, exitCode int) {
+	t.Helper()
+	var stdoutBuf, stderrBuf bytes.Buffer
+	app := &App{
+		Stdout: &stdoutBuf,
+		Stderr: &stderrBuf,
+		Getwd:  func() (string, error) { return t.TempDir(), nil },
+	}
+	fullArgs := append([]string{"tick", "merge-driver"}, args...)
+	code := app.Run(fullArgs)
+	return stdoutBuf.String(), stderrBuf.String(), code
+}
+
+// writeMergeFiles writes base/ours/theirs JSONL files to a temp dir and returns their paths.
+func writeMergeFiles(t *testing.T, base, ours, theirs string) (string, string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	paths := make([]string, 3)
+	for i, content := range []string{base, ours, theirs} {
+		paths[i] = filepath.Join(dir, []string{"base", "ours", "theirs"}[i])
+		if err := os.WriteFile(paths[i], []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write merge input: %v", err)
+		}
+	}
+	return paths[0], paths[1], paths[2]
+}
+
+func TestMergeDriver(t *testing.T) {
+	const ts = `"created":"2026-01-01T10:00:00Z","updated":"2026-01-01T10:00:00Z"`
+
+	t.Run("it merges tasks added on both branches into ours and exits 0", func(t *testing.T) {
+		base := `{"id":"tick-aaa111","title":"Base","status":"open","priority":2,` + ts + "}\n"
+		ours := base + `{"id":"tick-bbb222","title":"Ours","status":"open","priority":2,` + ts + "}\n"
+		theirs := base + `{"id":"tick-ccc333","title":"Theirs","status":"open","priority":2,` + ts + "}\n"
+		basePath, oursPath, theirsPath := writeMergeFiles(t, base, ours, theirs)
+
+		_, stderr, code := runMergeDriver(t, basePath, oursPath, theirsPath)
+
+		if code != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", code, stderr)
+		}
+		merged, err := os.ReadFile(oursPath)
+		if err != nil {
+			t.Fatalf("failed to read merged file: %v", err)
+		}
+		lines := strings.Split(strings.TrimSpace(string(merged)), "\n")
+		if len(lines) != 3 {
+			t.Fatalf("merged lines = %d, want 3; content = %q", len(lines), merged)
+		}
+		if !strings.Contains(lines[2], "tick-ccc333") {
+			t.Errorf("line 3 = %q, want theirs task appended", lines[2])
+		}
+	})
+
+	t.Run("it reports scalar conflicts on stderr and exits 1 with parseable output", func(t *testing.T) {
+		base := `{"id":"tick-aaa111","title":"Base","status":"open","priority":2,` + ts + "}\n"
+		ours := `{"id":"tick-aaa111","title":"Ours","status":"open","priority":2,` + ts + "}\n"
+		theirs := `{"id":"tick-aaa111","title":"Theirs","status":"open","priority":2,` + ts + "}\n"
+		basePath, oursPath, theirsPath := writeMergeFiles(t, base, ours, theirs)
+
+		_, stderr, code := runMergeDriver(t, basePath, oursPath, theirsPath)
+
+		if code != 1 {
+			t.Fatalf("exit code = %d, want 1", code)
+		}
+		if !strings.Contains(stderr, "conflicting title") {
+			t.Errorf("stderr = %q, want title conflict", stderr)
+		}
+		merged, _ := os.ReadFile(oursPath)
+		if !strings.Contains(string(merged), `"title":"Ours"`) {
+			t.Errorf("merged = %q, want ours title kept", merged)
+		}
+	})
+
+	t.Run("it treats a missing base file as no common ancestor", func(t *testing.T) {
+		ours := `{"id":"tick-aaa111","title":"Ours","status":"open","priority":2,` + ts + "}\n"
+		theirs := `{"id":"tick-bbb222","title":"Theirs","status":"open","priority":2,` + ts + "}\n"
+		basePath, oursPath, theirsPath := writeMergeFiles(t, "", ours, theirs)
+		os.Remove(basePath)
+
+		_, stderr, code := runMergeDriver(t, basePath, oursPath, theirsPath)
+
+		if code != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", code, stderr)
+		}
+	})
+
+	t.Run("it errors without modifying ours when an input cannot be parsed", func(t *testing.T) {
+		ours := `{"id":"tick-aaa111","title":"Ours","status":"open","priority":2,` + ts + "}\n"
+		basePath, oursPath, theirsPath := writeMergeFiles(t, "", ours, "not json\n")
+
+		_, stderr, code := runMergeDriver(t, basePath, oursPath, theirsPath)
+
+		if code != 1 {
+			t.Fatalf("exit code = %d, want 1", code)
+		}
+		if !strings.Contains(stderr, "could not parse theirs file") {
+			t.Errorf("stderr = %q, want parse error for theirs", stderr)
+		}
+		got, _ := os.ReadFile(oursPath)
+		if string(got) != ours {
+			t.Errorf("ours was modified: %q", got)
+		}
+	})
+
+	t.Run("it checks the merge against the project's workflow", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+		if err := os.WriteFile(filepath.Join(tickDir, "config.yaml"), []byte(reviewWorkflowConfig), 0644); err != nil {
+			t.Fatalf("failed to write config.yaml: %v", err)
+		}
+		parent := `{"id":"tick-aaa111","title":"Parent","status":"%s","priority":2,` + ts + "}\n"
+		child := `{"id":"tick-bbb222","title":"Child","status":"in_review","priority":2,"parent":"tick-aaa111",` + ts + "}\n"
+		base := fmt.Sprintf(parent, "open")
+		basePath, oursPath, theirsPath := writeMergeFiles(t, base, base+child, fmt.Sprintf(parent, "done"))
+
+		_, stderr, code := runTick(t, dir, "merge-driver", basePath, oursPath, theirsPath)
+
+		if code != 1 || !strings.Contains(stderr, "parent tick-aaa111 is done but child is in_review") {
+			t.Errorf("exit code = %d, stderr = %q; want done-parent conflict", code, stderr)
+		}
+	})
+
+	t.Run("it requires exactly three file arguments", func(t *testing.T) {
+		_, stderr, code := runMergeDriver(t, "only-one")
+
+		if code != 1 {
+			t.Fatalf("exit code = %d, want 1", code)
+		}
+		if !strings.Contains(stderr, "Usage: tick merge-driver %O %A %B") {
+			t.Errorf("stderr = %q, want usage", stderr)
+		}
+	})
+}
+
+func TestInitMergeDriver(t *testing.T) {
+	t.Run("it registers the merge driver in .gitattributes and git config", func(t *testing.T) {
+		if _, err := exec.LookPath("git"); err != nil {
+			t.Skip("git not available")
+		}
+		dir := t.TempDir()
+		if out, err := exec.Command("git", "init", dir).CombinedOutput(); err != nil {
+			t.Fatalf("git init failed: %v: %s", err, out)
+		}
+		if err := os.WriteFile(filepath.Join(dir, ".gitattributes"), []byte("*.png binary"), 0644); err != nil {
+			t.Fatalf("failed to write .gitattributes: %v", err)
+		}
+
+		var stdout, stderr bytes.Buffer
+		app := &App{
+			Stdout: &stdout,
+			Stderr: &stderr,
+			Getwd:  func() (string, error) { return dir, nil },
+		}
+		if code := app.Run([]string{"tick", "init", "--merge-driver"}); code != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", code, stderr.String())
+		}
+
+		attrs, err := os.ReadFile(filepath.Join(dir, ".gitattributes"))
+		if err != nil {
+			t.Fatalf("failed to read .gitattributes: %v", err)
+		}
+		if string(attrs) != "*.png binary\n.tick/tasks.jsonl merge=tick\n" {
+			t.Errorf(".gitattributes = %q", attrs)
+		}
+
+		cmd := exec.Command("git", "config", "merge.tick.driver")
+		cmd.Dir = dir
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("git config read failed: %v", err)
+		}
+		if strings.TrimSpace(string(out)) != "tick merge-driver %O %A %B" {
+			t.Errorf("merge.tick.driver = %q", out)
+		}
+		if !strings.Contains(stdout.String(), "Registered tick merge driver") {
+			t.Errorf("stdout = %q, want registration message", stdout.String())
+		}
+	})
+
+	t.Run("it does not duplicate an existing .gitattributes entry", func(t *testing.T) {
+		if _, err := exec.LookPath("git"); err != nil {
+			t.Skip("git not available")
+		}
+		dir := t.TempDir()
+		if out, err := exec.Command("git", "init", dir).CombinedOutput(); err != nil {
+			t.Fatalf("git init failed: %v: %s", err, out)
+		}
+		existing := ".tick/tasks.jsonl merge=tick\n"
+		if err := os.WriteFile(filepath.Join(dir, ".gitattributes"), []byte(existing), 0644); err != nil {
+			t.Fatalf("failed to write .gitattributes: %v", err)
+		}
+
+		if err := registerMergeDriver(dir); err != nil {
+			t.Fatalf("registerMergeDriver returned error: %v", err)
+		}
+
+		attrs, _ := os.ReadFile(filepath.Join(dir, ".gitattributes"))
+		if string(attrs) != existing {
+			t.Errorf(".gitattributes = %q, want unchanged", attrs)
+		}
+	})
+}
@@ -5,7 +5,6 @@ import (
 	"io"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/leeovery/tick/internal/task"
 )
@@ -53,37 +52,22 @@ func RunNoteAdd(dir string, fc FormatConfig, fmtr Formatter, args []string, stdo
 		return err
 	}
 
-	store, err := openStore(dir, fc)
+	p, err := openProject(dir, fc)
 	if err != nil {
 		return err
 	}
-	defer store.Close()
+	defer p.Close()
 
-	id, err := store.ResolveID(rawID)
+	id, err := p.ResolveID(rawID)
 	if err != nil {
 		return err
 	}
 
-	err = store.Mutate(func(tasks []task.Task) ([]task.Task, error) {
-		for i := range tasks {
-			if tasks[i].ID == id {
-				now := time.Now().UTC().Truncate(time.Second)
-				note := task.Note{
-					Text:    text,
-					Created: now,
-				}
-				tasks[i].Notes = append(tasks[i].Notes, note)
-				tasks[i].Updated = now
-				return tasks, nil
-			}
-		}
-		return nil, fmt.Errorf("task '%s' not found", id)
-	})
-	if err != nil {
+	if err := p.AddNote(id, text); err != nil {
 		return err
 	}
 
-	return outputMutationResult(store, id, fc, fmtr, stdout)
+	return outputMutationResult(p, id, fc, fmtr, stdout)
 }
 
 // RunNoteRemove executes the note remove command: parses args (task ID and 1-based index),
@@ -107,37 +91,20 @@ func RunNoteRemove(dir string, fc FormatConfig, fmtr Formatter, args []string, s
 		return fmt.Errorf("index must be >= 1, got %d", index)
 	}
 
-	store, err := openStore(dir, fc)
+	p, err := openProject(dir, fc)
 	if err != nil {
 		return err
 	}
-	defer store.Close()
+	defer p.Close()
 
-	id, err := store.ResolveID(rawID)
+	id, err := p.ResolveID(rawID)
 	if err != nil {
 		return err
 	}
 
-	err = store.Mutate(func(tasks []task.Task) ([]task.Task, error) {
-		for i := range tasks {
-			if tasks[i].ID == id {
-				if len(tasks[i].Notes) == 0 {
-					return nil, fmt.Errorf("task has no notes to remove")
-				}
-				if index > len(tasks[i].Notes) {
-					return nil, fmt.Errorf("index %d out of range: task has %d note(s)", index, len(tasks[i].Notes))
-				}
-				idx := index - 1
-				tasks[i].Notes = append(tasks[i].Notes[:idx], tasks[i].Notes[idx+1:]...)
-				tasks[i].Updated = time.Now().UTC().Truncate(time.Second)
-				return tasks, nil
-			}
-		}
-		return nil, fmt.Errorf("task '%s' not found", id)
-	})
-	if err != nil {
+	if err := p.RemoveNote(id, index); err != nil {
 		return err
 	}
 
-	return outputMutationResult(store, id, fc, fmtr, stdout)
+	return outputMutationResult(p, id, fc, fmtr, stdout)
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/leeovery/tick/internal/task"
 )
@@ -48,14 +49,61 @@ const maxListTitleLen = 50
 // for terminal display. No borders, no colors, no icons.
 type PrettyFormatter struct {
 	baseFormatter
+	// AsOf is the instant overdue and deferred tasks are judged at; zero means
+	// now.
+	AsOf time.Time
 }
 
 // Compile-time interface verification.
 var _ Formatter = (*PrettyFormatter)(nil)
 
+// now returns f.AsOf, or the current time when it is zero.
+func (f *PrettyFormatter) now() time.Time {
+	if f.AsOf.IsZero() {
+		return time.Now()
+	}
+	return f.AsOf
+}
+
+// isOverdue reports whether t is overdue at f.AsOf, or now when it is zero.
+func (f *PrettyFormatter) isOverdue(t task.Task) bool {
+	return t.IsOverdue(f.now())
+}
+
+// isDeferred reports whether t is deferred past f.AsOf, or now when it is zero.
+func (f *PrettyFormatter) isDeferred(t task.Task) bool {
+	return t.IsDeferred(f.now())
+}
+
+// dueCell renders the due date of t for the DUE column: the local date, marked
+// "(overdue)" when past due, or "-" when there is none.
+func (f *PrettyFormatter) dueCell(t task.Task) string {
+	if t.Due == nil {
+		return "-"
+	}
+	date := t.Due.Local().Format("2006-01-02")
+	if f.isOverdue(t) {
+		return date + " (overdue)"
+	}
+	return date
+}
+
+// deferredCell renders the DEFERRED column for t: the local date it is
+// deferred until, or "-" when it is not deferred.
+func (f *PrettyFormatter) deferredCell(t task.Task) string {
+	if !f.isDeferred(t) {
+		return "-"
+	}
+	return t.DeferUntil.Local().Format("2006-01-02")
+}
+
 // FormatTaskList renders a list of tasks as an aligned-column table with header.
 // Empty input returns "No tasks found." with no headers.
 // Long titles are truncated to maxListTitleLen characters with "..." appended.
+// An ASSIGNEE column is added before TITLE when any task has an assignee, a DUE
+// column when any task has a due date, with overdue tasks marked, a DEFERRED
+// column when any task is deferred, and a REASON column when the tasks carry
+// the reason they are blocked.
 func (f *PrettyFormatter) FormatTaskList(tasks []task.Task) string {
 	if len(tasks) == 0 {
 		return "No tasks found."
@@ -66,6 +114,13 @@ func (f *PrettyFormatter) FormatTaskList(tasks []task.Task) string {
 	statusWidth := len("STATUS")
 	priWidth := len("PRI")
 	typeWidth := len("TYPE")
+	// The ASSIGNEE, DUE, DEFERRED, RECUR and REASON columns only appear when
+	// some task is assigned, has a due date, is deferred, recurs or is blocked.
+	assigneeWidth := 0
+	dueWidth := 0
+	deferredWidth := 0
+	recurWidth := 0
+	reasonWidth := 0
 
 	for _, t := range tasks {
 		if len(t.ID) > idWidth {
@@ -83,6 +138,21 @@ func (f *PrettyFormatter) FormatTaskList(tasks []task.Task) string {
 		if len(tv) > typeWidth {
 			typeWidth = len(tv)
 		}
+		if t.Assignee != "" {
+			assigneeWidth = max(assigneeWidth, len("ASSIGNEE"), len(t.Assignee))
+		}
+		if t.Due != nil {
+			dueWidth = max(dueWidth, len(f.dueCell(t)))
+		}
+		if f.isDeferred(t) {
+			deferredWidth = max(deferredWidth, len("DEFERRED"), len(f.deferredCell(t)))
+		}
+		if t.Recur != "" {
+			recurWidth = max(recurWidth, len("RECUR"), len(t.Recur))
+		}
+		if t.BlockedReason != "" {
+			reasonWidth = max(reasonWidth, len("REASON"), len(t.BlockedReason))
+		}
 	}
 
 	// Add gutter spacing (3 spaces between columns).
@@ -90,30 +160,231 @@ func (f *PrettyFormatter) FormatTaskList(tasks []task.Task) string {
 	statusCol := statusWidth + 2
 	priCol := priWidth + 2
 	typeCol := typeWidth + 2
+	assigneeCol := 0
+	if assigneeWidth > 0 {
+		assigneeCol = assigneeWidth + 2
+	}
+	dueCol := 0
+	if dueWidth > 0 {
+		dueCol = max(dueWidth, len("DUE")) + 2
+	}
+	deferredCol := 0
+	if deferredWidth > 0 {
+		deferredCol = deferredWidth + 2
+	}
+	recurCol := 0
+	if recurWidth > 0 {
+		recurCol = recurWidth + 2
+	}
+	reasonCol := 0
+	if reasonWidth > 0 {
+		reasonCol = reasonWidth + 2
+	}
 
 	var b strings.Builder
 	// Header
-	fmt.Fprintf(&b, "%-*s%-*s%-*s%-*s%s", idCol, "ID", statusCol, "STATUS", priCol, "PRI", typeCol, "TYPE", "TITLE")
+	fmt.Fprintf(&b, "%-*s%-*s%-*s%-*s", idCol, "ID", statusCol, "STATUS", priCol, "PRI", typeCol, "TYPE")
+	if assigneeCol > 0 {
+		fmt.Fprintf(&b, "%-*s", assigneeCol, "ASSIGNEE")
+	}
+	if dueCol > 0 {
+		fmt.Fprintf(&b, "%-*s", dueCol, "DUE")
+	}
+	if deferredCol > 0 {
+		fmt.Fprintf(&b, "%-*s", deferredCol, "DEFERRED")
+	}
+	if recurCol > 0 {
+		fmt.Fprintf(&b, "%-*s", recurCol, "RECUR")
+	}
+	if reasonCol > 0 {
+		fmt.Fprintf(&b, "%-*s", reasonCol, "REASON")
+	}
+	b.WriteString("TITLE")
 
 	// Rows
 	for _, t := range tasks {
 		title := truncateTitle(t.Title)
 		tv := typeOrDash(t.Type)
 		b.WriteString("\n")
-		fmt.Fprintf(&b, "%-*s%-*s%-*d%-*s%s",
+		fmt.Fprintf(&b, "%-*s%-*s%-*d%-*s",
 			idCol, t.ID,
 			statusCol, string(t.Status),
 			priCol, t.Priority,
 			typeCol, tv,
-			title,
 		)
+		if assigneeCol > 0 {
+			fmt.Fprintf(&b, "%-*s", assigneeCol, cmp.Or(t.Assignee, "-"))
+		}
+		if dueCol > 0 {
+			fmt.Fprintf(&b, "%-*s", dueCol, f.dueCell(t))
+		}
+		if deferredCol > 0 {
+			fmt.Fprintf(&b, "%-*s", deferredCol, f.deferredCell(t))
+		}
+		if recurCol > 0 {
+			fmt.Fprintf(&b, "%-*s", recurCol, cmp.Or(t.Recur, "-"))
+		}
+		if reasonCol > 0 {
+			fmt.Fprintf(&b, "%-*s", reasonCol, cmp.Or(t.BlockedReason, "-"))
+		}
+		b.WriteString(title)
+	}
+
+	return b.String()
+}
+
+// FormatSearchResults renders search matches as the task list table, in rank order,
+// with each row followed by an indented snippet line. Empty input returns
+// "No matching tasks." with no headers.
+func (f *PrettyFormatter) FormatSearchResults(results []SearchResult) string {
+	if len(results) == 0 {
+		return "No matching tasks."
+	}
+
+	tasks := make([]task.Task, len(results))
+	for i, r := range results {
+		tasks[i] = r.Task
+	}
+	rows := strings.Split(f.FormatTaskList(tasks), "\n")
+
+	var b strings.Builder
+	b.WriteString(rows[0])
+	for i, r := range results {
+		b.WriteString("\n")
+		b.WriteString(rows[i+1])
+		if r.Snippet != "" {
+			fmt.Fprintf(&b, "\n    %s", strings.Join(strings.Fields(r.Snippet), " "))
+		}
+	}
+	return b.String()
+}
+
+// FormatJournal renders journal entries as an aligned-column table, newest first.
+// Undo and redo entries show their target in the OP column; undone mutations are
+// marked "(undone)". Empty input returns "No journal entries." with no headers.
+func (f *PrettyFormatter) FormatJournal(rows []JournalRow) string {
+	if len(rows) == 0 {
+		return "No journal entries."
+	}
+
+	ops := make([]string, len(rows))
+	seqWidth := len("#")
+	opWidth := len("OP")
+	for i, r := range rows {
+		op := r.Op
+		if r.Target != 0 {
+			op = fmt.Sprintf("%s #%d", r.Op, r.Target)
+		}
+		if r.Undone {
+			op += " (undone)"
+		}
+		ops[i] = op
+		seqWidth = max(seqWidth, len(fmt.Sprintf("%d", r.Seq)))
+		opWidth = max(opWidth, len(op))
+	}
+
+	seqCol := seqWidth + 3
+	atCol := len("2006-01-02 15:04") + 2
+	opCol := opWidth + 2
+	tasksCol := len("TASKS") + 2
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-*s%-*s%-*s%-*s%s", seqCol, "#", atCol, "AT", opCol, "OP", tasksCol, "TASKS", "COMMAND")
+	for i, r := range rows {
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "%-*d%-*s%-*s%-*d%s",
+			seqCol, r.Seq,
+			atCol, r.At.Format("2006-01-02 15:04"),
+			opCol, ops[i],
+			tasksCol, len(r.TaskIDs),
+			cmp.Or(r.Command, "-"),
+		)
+	}
+	return b.String()
+}
+
+// FormatBatch renders the batch outcome as an aligned list of operations with
+// their task IDs, followed by the status changes, cascaded ones marked "(auto)".
+func (f *PrettyFormatter) FormatBatch(summary BatchSummary) string {
+	opWidth := len("OP")
+	for _, r := range summary.Rows {
+		opWidth = max(opWidth, len(r.Op))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Applied %d operations:", len(summary.Rows))
+	for _, r := range summary.Rows {
+		fmt.Fprintf(&b, "\n  %-*s  %s", opWidth, r.Op, r.ID)
+		if r.Ref != "" {
+			fmt.Fprintf(&b, "  $%s", r.Ref)
+		}
+	}
+	if len(summary.StatusChanges) == 0 {
+		return b.String()
 	}
 
+	b.WriteString("\n\nStatus changes:")
+	for _, c := range summary.StatusChanges {
+		fmt.Fprintf(&b, "\n  %s: %s \u2192 %s", c.ID, c.From, c.To)
+		if c.Auto {
+			b.WriteString(" (auto)")
+		}
+	}
+	return b.String()
+}
+
+// FormatConfig renders the configuration settings as aligned key and value
+// columns. Settings left at their default are marked "(default)".
+func (f *PrettyFormatter) FormatConfig(entries []ConfigEntry) string {
+	keyWidth := len("KEY")
+	valueWidth := len("VALUE")
+	for _, e := range entries {
+		keyWidth = max(keyWidth, len(e.Key))
+		valueWidth = max(valueWidth, len(e.Value))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-*s%s", keyWidth+2, "KEY", "VALUE")
+	for _, e := range entries {
+		b.WriteString("\n")
+		if e.Default {
+			fmt.Fprintf(&b, "%-*s%-*s(default)", keyWidth+2, e.Key, valueWidth+2, e.Value)
+		} else {
+			fmt.Fprintf(&b, "%-*s%s", keyWidth+2, e.Key, e.Value)
+		}
+	}
+	return b.String()
+}
+
+// FormatLockStatus renders the lock status as a state line followed by one
+// line per recorded holder. Holders whose process is gone are marked "(dead)".
+func (f *PrettyFormatter) FormatLockStatus(status LockStatus) string {
+	var b strings.Builder
+	if status.Held {
+		b.WriteString("Lock: held")
+	} else {
+		b.WriteString("Lock: free")
+	}
+	if len(status.Holders) == 0 {
+		return b.String()
+	}
+
+	b.WriteString("\n\nHolders:")
+	for _, h := range status.Holders {
+		fmt.Fprintf(&b, "\n  pid %d on %s, %s since %s", h.PID, h.Hostname, h.Mode, h.Started.Format("2006-01-02 15:04:05"))
+		if !h.Alive {
+			b.WriteString(" (dead)")
+		}
+		if h.Command != "" {
+			fmt.Fprintf(&b, "\n    %s", h.Command)
+		}
+	}
 	return b.String()
 }
 
 // FormatTaskDetail renders a single task with full details in key-value format.
-// Sections (Blocked by, Children, Description) are omitted when empty.
+// Sections (Blocked by, Children, Refs, Fields, Notes, Description) are omitted when empty,
+// as are the estimate and rollup lines.
 func (f *PrettyFormatter) FormatTaskDetail(detail TaskDetail) string {
 	t := detail.Task
 	var b strings.Builder
@@ -137,6 +408,42 @@ func (f *PrettyFormatter) FormatTaskDetail(detail TaskDetail) string {
 		}
 	}
 
+	if t.Assignee != "" {
+		fmt.Fprintf(&b, "Assignee: %s\n", t.Assignee)
+	}
+
+	if t.Estimate != 0 {
+		fmt.Fprintf(&b, "Estimate: %s\n", task.FormatEstimate(t.Estimate))
+	}
+
+	if r := detail.Rollup; r != nil {
+		fmt.Fprintf(&b, "Rollup:   %s remaining of %s\n", task.FormatEstimate(r.Remaining), task.FormatEstimate(r.Total))
+	}
+
+	if t.Due != nil {
+		fmt.Fprintf(&b, "Due:      %s", task.FormatTimestamp(*t.Due))
+		if f.isOverdue(t) {
+			b.WriteString(" (overdue)")
+		}
+		b.WriteString("\n")
+	}
+
+	if f.isDeferred(t) {
+		fmt.Fprintf(&b, "Deferred: until %s\n", task.FormatTimestamp(*t.DeferUntil))
+	}
+
+	if t.Recur != "" {
+		fmt.Fprintf(&b, "Recurs:   %s\n", t.Recur)
+	}
+
+	if t.RecurredFrom != "" {
+		fmt.Fprintf(&b, "Recurred: from %s\n", t.RecurredFrom)
+	}
+
+	if t.LeaseExpires != nil {
+		fmt.Fprintf(&b, "Lease:    until %s\n", task.FormatTimestamp(*t.LeaseExpires))
+	}
+
 	fmt.Fprintf(&b, "Created:  %s\n", task.FormatTimestamp(t.Created))
 	fmt.Fprintf(&b, "Updated:  %s", task.FormatTimestamp(t.Updated))
 
@@ -165,6 +472,18 @@ func (f *PrettyFormatter) FormatTaskDetail(detail TaskDetail) string {
 		}
 	}
 
+	if len(detail.Fields) > 0 {
+		// Values align one space past the longest key.
+		width := 0
+		for _, field := range detail.Fields {
+			width = max(width, len(field.Key)+1)
+		}
+		b.WriteString("\n\nFields:")
+		for _, field := range detail.Fields {
+			fmt.Fprintf(&b, "\n  %-*s %s", width, field.Key+":", field.Value)
+		}
+	}
+
 	if len(detail.Notes) > 0 {
 		b.WriteString("\n\nNotes:")
 		for _, note := range detail.Notes {
@@ -194,16 +513,18 @@ func (f *PrettyFormatter) FormatStats(stats Stats) string {
 	fmt.Fprintf(&b, "Total: %8d", stats.Total)
 
 	// Status group: all lines total width 17 from line start.
+	// Every status in workflow order, labelled like "In Progress".
 	b.WriteString("\n\nStatus:")
-	fmt.Fprintf(&b, "\n  Open:%10d", stats.Open)
-	fmt.Fprintf(&b, "\n  In Progress: %2d", stats.InProgress)
-	fmt.Fprintf(&b, "\n  Done:%10d", stats.Done)
-	fmt.Fprintf(&b, "\n  Cancelled:%5d", stats.Cancelled)
+	for _, sc := range statusCounts(stats) {
+		label := statusLabel(sc.State)
+		fmt.Fprintf(&b, "\n  %s:%*d", label, max(14-len(label), 3), sc.Count)
+	}
 
 	// Workflow group: lines total width 17.
 	b.WriteString("\n\nWorkflow:")
 	fmt.Fprintf(&b, "\n  Ready:%9d", stats.Ready)
 	fmt.Fprintf(&b, "\n  Blocked:%7d", stats.Blocked)
+	fmt.Fprintf(&b, "\n  Overdue:%7d", stats.Overdue)
 
 	// Priority group: lines total width 19.
 	b.WriteString("\n\nPriority:")
@@ -213,9 +534,70 @@ func (f *PrettyFormatter) FormatStats(stats Stats) string {
 	fmt.Fprintf(&b, "\n  P3 (low):      %2d", stats.ByPriority[3])
 	fmt.Fprintf(&b, "\n  P4 (backlog):  %2d", stats.ByPriority[4])
 
+	// Type group: names left-aligned, counts in a column after the longest.
+	if len(stats.ByType) > 0 {
+		width := 0
+		for _, tc := range stats.ByType {
+			width = max(width, len(tc.Type)+1)
+		}
+		b.WriteString("\n\nType:")
+		for _, tc := range stats.ByType {
+			fmt.Fprintf(&b, "\n  %-*s %2d", width, tc.Type+":", tc.Count)
+		}
+	}
+
+	// Assignee group: live tasks per assignee, laid out like the type group.
+	if len(stats.ByAssignee) > 0 {
+		width := 0
+		for _, ac := range stats.ByAssignee {
+			width = max(width, len(ac.Assignee)+1)
+		}
+		b.WriteString("\n\nAssignee:")
+		for _, ac := range stats.ByAssignee {
+			fmt.Fprintf(&b, "\n  %-*s %2d", width, ac.Assignee+":", ac.Count)
+		}
+	}
+
+	// Estimates group: totals right-aligned to the longer of the two, then the
+	// rollup under each top-level task. Omitted when nothing is estimated.
+	if stats.Estimates.Total > 0 {
+		total := task.FormatEstimate(stats.Estimates.Total)
+		remaining := task.FormatEstimate(stats.Estimates.Remaining)
+		width := max(len(total), len(remaining))
+		b.WriteString("\n\nEstimates:")
+		fmt.Fprintf(&b, "\n  Total:     %*s", width, total)
+		fmt.Fprintf(&b, "\n  Remaining: %*s", width, remaining)
+	}
+
+	if len(stats.Rollups) > 0 {
+		amounts := make([]string, len(stats.Rollups))
+		idWidth, amountWidth := 0, 0
+		for i, r := range stats.Rollups {
+			amounts[i] = fmt.Sprintf("%s of %s", task.FormatEstimate(r.Remaining), task.FormatEstimate(r.Total))
+			idWidth = max(idWidth, len(r.ID))
+			amountWidth = max(amountWidth, len(amounts[i]))
+		}
+		b.WriteString("\n\nRemaining by parent:")
+		for i, r := range stats.Rollups {
+			fmt.Fprintf(&b, "\n  %-*s  %-*s  %s", idWidth, r.ID, amountWidth, amounts[i], truncateTitle(r.Title))
+		}
+	}
+
 	return b.String()
 }
 
+// statusLabel turns a status name into a title-case label: in_progress
+// becomes "In Progress".
+func statusLabel(status string) string {
+	words := strings.Split(status, "_")
+	for i, w := range words {
+		if w != "" {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
 // FormatMessage renders a general-purpose message as plain text.
 func (f *PrettyFormatter) FormatMessage(msg string) string {
 	return msg
@@ -403,6 +785,43 @@ func truncateDepTreeTitle(title string, depth int) string {
 	return title[:available-3] + "..."
 }
 
+// FormatCriticalPath renders the critical path as a numbered, aligned table in
+// the order the work has to be done, headed by the target task when there is
+// one and followed by the total estimate. Unestimated tasks show "-".
+func (f *PrettyFormatter) FormatCriticalPath(result CriticalPathResult) string {
+	estimates := make([]string, len(result.Tasks))
+	seqWidth := len("#")
+	idWidth := len("ID")
+	estimateWidth := len("EST")
+	statusWidth := len("STATUS")
+	for i, t := range result.Tasks {
+		estimates[i] = "-"
+		if t.Estimate != 0 {
+			estimates[i] = task.FormatEstimate(t.Estimate)
+		}
+		seqWidth = max(seqWidth, len(fmt.Sprintf("%d", i+1)))
+		idWidth = max(idWidth, len(t.ID))
+		estimateWidth = max(estimateWidth, len(estimates[i]))
+		statusWidth = max(statusWidth, len(t.Status))
+	}
+
+	var b strings.Builder
+	if t := result.Target; t != nil {
+		fmt.Fprintf(&b, "Critical path to %s (%s):\n\n", t.ID, t.Title)
+	}
+	fmt.Fprintf(&b, "%-*s%-*s%-*s%-*s%s", seqWidth+2, "#", idWidth+2, "ID", estimateWidth+2, "EST", statusWidth+2, "STATUS", "TITLE")
+	for i, t := range result.Tasks {
+		fmt.Fprintf(&b, "\n%-*d%-*s%-*s%-*s%s", seqWidth+2, i+1, idWidth+2, t.ID, estimateWidth+2, estimates[i], statusWidth+2, t.Status, truncateTitle(t.Title))
+	}
+
+	taskWord := "tasks"
+	if len(result.Tasks) == 1 {
+		taskWord = "task"
+	}
+	fmt.Fprintf(&b, "\n\nTotal: %s across %d %s", task.FormatEstimate(result.Total), len(result.Tasks), taskWord)
+	return b.String()
+}
+
 // typeOrDash returns the type string or "-" if empty, for Pretty formatter display.
 func typeOrDash(typ string) string {
 	return cmp.Or(typ, "-")
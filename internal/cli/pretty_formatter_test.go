@@ -214,6 +214,7 @@ func TestPrettyFormatter(t *testing.T) {
 			"Workflow:\n" +
 			"  Ready:        8\n" +
 			"  Blocked:      4\n" +
+			"  Overdue:      0\n" +
 			"\n" +
 			"Priority:\n" +
 			"  P0 (critical):  2\n" +
@@ -251,6 +252,7 @@ func TestPrettyFormatter(t *testing.T) {
 			"Workflow:\n" +
 			"  Ready:        0\n" +
 			"  Blocked:      0\n" +
+			"  Overdue:      0\n" +
 			"\n" +
 			"Priority:\n" +
 			"  P0 (critical):  0\n" +
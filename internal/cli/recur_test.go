@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+func TestRecur(t *testing.T) {
+	now := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+
+	t.Run("it sets a normalized recurrence rule on create", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+
+		_, stderr, exitCode := runCreate(t, dir, "Rotate credentials", "--recur", "every 14 days")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if got := readPersistedTasks(t, tickDir)[0].Recur; got != "every 2 weeks" {
+			t.Errorf("recur = %q, want %q", got, "every 2 weeks")
+		}
+	})
+
+	t.Run("it rejects an invalid recurrence rule", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+
+		_, stderr, exitCode := runCreate(t, dir, "Rotate credentials", "--recur", "hourly")
+		if exitCode != 1 {
+			t.Fatalf("exit code = %d, want 1", exitCode)
+		}
+		if !strings.Contains(stderr, "invalid recurrence 'hourly'") {
+			t.Errorf("stderr = %q, want invalid recurrence error", stderr)
+		}
+		if tasks := readPersistedTasks(t, tickDir); len(tasks) != 0 {
+			t.Errorf("persisted %d tasks, want 0", len(tasks))
+		}
+	})
+
+	t.Run("it changes and clears the rule on update", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Recur: "daily", Created: now, Updated: now},
+		})
+
+		_, stderr, exitCode := runUpdate(t, dir, "tick-aaa111", "--recur", "Monthly")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if got := readPersistedTasks(t, tickDir)[0].Recur; got != "monthly" {
+			t.Errorf("recur = %q, want monthly", got)
+		}
+
+		_, stderr, exitCode = runUpdate(t, dir, "tick-aaa111", "--clear-recur")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if got := readPersistedTasks(t, tickDir)[0].Recur; got != "" {
+			t.Errorf("recur = %q, want empty", got)
+		}
+	})
+
+	t.Run("it rejects --recur with --clear-recur and an empty --recur", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		})
+
+		_, stderr, exitCode := runUpdate(t, dir, "tick-aaa111", "--recur", "daily", "--clear-recur")
+		if exitCode != 1 || !strings.Contains(stderr, "mutually exclusive") {
+			t.Errorf("exit code = %d, stderr = %q, want mutually exclusive error", exitCode, stderr)
+		}
+
+		_, stderr, exitCode = runUpdate(t, dir, "tick-aaa111", "--recur", " ")
+		if exitCode != 1 || !strings.Contains(stderr, "use --clear-recur") {
+			t.Errorf("exit code = %d, stderr = %q, want --clear-recur hint", exitCode, stderr)
+		}
+	})
+
+	t.Run("it reports the next occurrence when a recurring task is done", func(t *testing.T) {
+		due := time.Now().UTC().Truncate(time.Second).Add(-24 * time.Hour)
+		dir, tickDir := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Rotate credentials", Status: task.StatusInProgress, Priority: 1, Type: "chore",
+				Tags: []string{"security"}, Due: &due, Recur: "weekly", Created: now, Updated: now},
+		})
+
+		stdout, stderr, exitCode := runTick(t, dir, "--pretty", "done", "tick-aaa111")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+
+		tasks := readPersistedTasks(t, tickDir)
+		if len(tasks) != 2 {
+			t.Fatalf("persisted %d tasks, want 2", len(tasks))
+		}
+		next := tasks[1]
+		if next.RecurredFrom != "tick-aaa111" || next.Recur != "weekly" || next.Status != task.StatusOpen || next.Type != "chore" {
+			t.Errorf("next occurrence = %+v", next)
+		}
+		if tasks[0].Recur != "" {
+			t.Errorf("completed task recur = %q, want empty", tasks[0].Recur)
+		}
+		want := "tick-aaa111: in_progress → done\nNext occurrence: " + next.ID + " (due " + task.FormatTimestamp(task.Recurrence{Days: 7}.Add(due, 1)) + ")\n"
+		if stdout != want {
+			t.Errorf("stdout = %q, want %q", stdout, want)
+		}
+
+		stdout, _, _ = runTick(t, dir, "--quiet", "done", next.ID)
+		if stdout != "" {
+			t.Errorf("quiet stdout = %q, want empty", stdout)
+		}
+	})
+
+	t.Run("it reports the next occurrence of a task completed by a cascade", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Ops", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+			{ID: "tick-bbb222", Title: "Rotate credentials", Status: task.StatusOpen, Priority: 2, Parent: "tick-aaa111", Recur: "weekly", Created: now, Updated: now},
+		})
+
+		stdout, stderr, exitCode := runTick(t, dir, "--pretty", "done", "tick-aaa111")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		tasks := readPersistedTasks(t, tickDir)
+		if len(tasks) != 3 || tasks[2].RecurredFrom != "tick-bbb222" || tasks[1].Recur != "" {
+			t.Fatalf("tasks = %+v, want tick-bbb222 completed with a next occurrence", tasks)
+		}
+		if !strings.Contains(stdout, "Next occurrence: "+tasks[2].ID+" (deferred until ") {
+			t.Errorf("stdout = %q, want the next occurrence reported", stdout)
+		}
+	})
+
+	t.Run("it lists only recurring tasks with --recurring", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Rotate credentials", Status: task.StatusOpen, Priority: 2, Recur: "every 3 months", Created: now, Updated: now},
+			{ID: "tick-bbb222", Title: "One-off", Status: task.StatusOpen, Priority: 2, Created: now.Add(time.Second), Updated: now.Add(time.Second)},
+		})
+
+		stdout, stderr, exitCode := runList(t, dir, "--recurring")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		want := "ID            STATUS  PRI  TYPE  RECUR           TITLE\n" +
+			"tick-aaa111   open    2    -     every 3 months  Rotate credentials\n"
+		if stdout != want {
+			t.Errorf("stdout = %q, want %q", stdout, want)
+		}
+
+		stdout, _, _ = runTick(t, dir, "--toon", "list", "--recurring")
+		if !strings.Contains(stdout, "tasks[1]{id,title,status,priority,type,recur}:\n  tick-aaa111,Rotate credentials,open,2,\"\",every 3 months") {
+			t.Errorf("toon stdout = %q, want a recur column", stdout)
+		}
+	})
+
+	t.Run("it shows the rule and origin in every format", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Rotate credentials", Status: task.StatusDone, Priority: 2, Created: now, Updated: now, Closed: &now},
+			{ID: "tick-bbb222", Title: "Rotate credentials", Status: task.StatusOpen, Priority: 2, Recur: "weekly", RecurredFrom: "tick-aaa111", Created: now, Updated: now},
+		})
+
+		stdout, stderr, exitCode := runShow(t, dir, "tick-bbb222")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if !strings.Contains(stdout, "Recurs:   weekly\nRecurred: from tick-aaa111\n") {
+			t.Errorf("pretty output should show the rule and origin, got %q", stdout)
+		}
+
+		stdout, _, _ = runTick(t, dir, "--toon", "show", "tick-bbb222")
+		if !strings.Contains(stdout, "recur,recurred_from,") || !strings.Contains(stdout, "weekly,tick-aaa111,") {
+			t.Errorf("toon output should contain recur and recurred_from, got %q", stdout)
+		}
+
+		stdout, _, _ = runTick(t, dir, "--json", "show", "tick-bbb222")
+		var detail struct {
+			Recur        string `json:"recur"`
+			RecurredFrom string `json:"recurred_from"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &detail); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+		}
+		if detail.Recur != "weekly" || detail.RecurredFrom != "tick-aaa111" {
+			t.Errorf("json recur, recurred_from = %q, %q", detail.Recur, detail.RecurredFrom)
+		}
+	})
+}
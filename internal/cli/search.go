@@ -0,0 +1,194 @@
+package cli
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/leeovery/tick/internal/query"
+	"github.com/leeovery/tick/internal/storage"
+	"github.com/leeovery/tick/internal/task"
+)
+
+// searchSnippetTokens is the approximate number of tokens in each result snippet.
+const searchSnippetTokens = 12
+
+// SearchResult holds a single full-text search match with its highlighted snippet.
+type SearchResult struct {
+	Task task.Task
+	// Snippet is an excerpt of the best-matching field with matched terms wrapped in "**".
+	Snippet string
+}
+
+// parseSearchArgs splits search subcommand args into the query text and the
+// ListFilter flags. Positional args are joined with spaces to form the FTS5 query,
+// so unquoted multi-word searches behave as an implicit AND.
+func parseSearchArgs(args []string) (string, ListFilter, error) {
+	var terms []string
+	var flagArgs []string
+	searchFlags := commandFlags["search"]
+	for i := 0; i < len(args); i++ {
+		def, isFlag := searchFlags[args[i]]
+		if !isFlag {
+			terms = append(terms, args[i])
+			continue
+		}
+		flagArgs = append(flagArgs, args[i])
+		if def.TakesValue && i+1 < len(args) {
+			i++
+			flagArgs = append(flagArgs, args[i])
+		}
+	}
+
+	query := strings.TrimSpace(strings.Join(terms, " "))
+	if query == "" {
+		return "", ListFilter{}, fmt.Errorf("search query is required. Usage: tick search <query> [flags]")
+	}
+
+	filter, err := parseListFlags(flagArgs)
+	if err != nil {
+		return "", ListFilter{}, err
+	}
+	return query, filter, nil
+}
+
+// RunSearch executes the search command: runs an FTS5 match over task titles,
+// descriptions, and notes, narrowed by the structured filter, and outputs results
+// ranked by relevance via the Formatter.
+func RunSearch(dir string, fc FormatConfig, fmtr Formatter, text string, filter ListFilter, stdout io.Writer) error {
+	store, err := openStore(dir, fc)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	results, err := searchStore(store, text, filter)
+	if err != nil {
+		return err
+	}
+
+	if fc.Quiet {
+		for _, r := range results {
+			fmt.Fprintln(stdout, r.Task.ID)
+		}
+		return nil
+	}
+
+	fmt.Fprintln(stdout, fmtr.FormatSearchResults(results))
+	return nil
+}
+
+// searchStore runs the search for text over the tasks in store, narrowed by
+// filter, and returns the matches ranked by relevance.
+func searchStore(store *storage.Store, text string, filter ListFilter) ([]SearchResult, error) {
+	rules := store.Config().Rules()
+	if err := filter.ValidateFor(rules); err != nil {
+		return nil, err
+	}
+	if filter.Parent != "" {
+		var err error
+		filter.Parent, err = store.ResolveID(filter.Parent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var results []SearchResult
+
+	err := store.Query(func(db *sql.DB) error {
+		var descendantIDs []string
+		if filter.Parent != "" {
+			var err error
+			descendantIDs, err = query.DescendantIDs(db, filter.Parent)
+			if err != nil {
+				return err
+			}
+		}
+
+		sqlQuery, queryArgs := buildSearchQuery(text, filter, descendantIDs, rules.Workflow)
+
+		rows, err := db.Query(sqlQuery, queryArgs...)
+		if err != nil {
+			if isFTSQueryError(err) {
+				return fmt.Errorf("invalid search query %q: %s", text, err)
+			}
+			return fmt.Errorf("failed to search tasks: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var r SearchResult
+			var status string
+			var taskType *string
+			if err := rows.Scan(&r.Task.ID, &status, &r.Task.Priority, &r.Task.Title, &taskType, &r.Snippet); err != nil {
+				return fmt.Errorf("failed to scan search row: %w", err)
+			}
+			r.Task.Status = task.Status(status)
+			if taskType != nil {
+				r.Task.Type = *taskType
+			}
+			results = append(results, r)
+		}
+		return rows.Err()
+	})
+	return results, err
+}
+
+// buildSearchQuery composes the FTS5 search SQL. Results are ranked by bm25 with
+// title matches weighted above description and notes, then by priority and creation
+// time. --ready and --blocked narrow the matches like they narrow list, following
+// the state categories of w, but do not change the order: search keeps ranking by
+// relevance.
+func buildSearchQuery(text string, f ListFilter, descendantIDs []string, w task.Workflow) (string, []any) {
+	conditions, filterArgs := query.Conditions(f, descendantIDs, w)
+
+	sqlQuery := fmt.Sprintf(`SELECT t.id, t.status, t.priority, t.title, t.type,
+		snippet(tasks_fts, -1, '**', '**', '...', %d)
+		FROM tasks_fts JOIN tasks t ON t.rowid = tasks_fts.rowid
+		WHERE tasks_fts MATCH ?`, searchSnippetTokens)
+	args := []any{text}
+
+	if len(conditions) > 0 {
+		sqlQuery += " AND " + strings.Join(conditions, " AND ")
+		args = append(args, filterArgs...)
+	}
+
+	sqlQuery += " ORDER BY bm25(tasks_fts, 10.0, 5.0, 1.0), t.priority ASC, t.created ASC"
+
+	if f.HasCount {
+		sqlQuery += " LIMIT ?"
+		args = append(args, f.Count)
+	}
+
+	return sqlQuery, args
+}
+
+// isFTSQueryError reports whether err is an FTS5 query syntax error rather than
+// a storage failure, so it can be surfaced as a user input problem.
+func isFTSQueryError(err error) bool {
+	msg := err.Error()
+	for _, marker := range []string{"fts5", "syntax error", "unterminated string", "no such column"} {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleSearch implements the search subcommand.
+func (a *App) handleSearch(fc FormatConfig, fmtr Formatter, subArgs []string) error {
+	dir, err := a.Getwd()
+	if err != nil {
+		return fmt.Errorf("could not determine working directory: %w", err)
+	}
+	query, filter, err := parseSearchArgs(subArgs)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(subArgs, workspaceFlag) {
+		return RunWorkspaceSearch(dir, fc, fmtr, query, filter, a.Stdout)
+	}
+	return RunSearch(dir, fc, fmtr, query, filter, a.Stdout)
+}
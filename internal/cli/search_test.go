@@ -0,0 +1,228 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// runSearch runs the tick search command with the given args and returns stdout, stderr, and exit code.
+// Uses IsTTY=true to default to PrettyFormatter for consistent test output.
+func runSearch(t *testing.T, dir string, args ...string) (stdout string, stderr string, exitCode int) {
+	t.Helper()
+	var stdoutBuf, stderrBuf bytes.Buffer
+	app := &App{
+		Stdout: &stdoutBuf,
+		Stderr: &stderrBuf,
+		Getwd:  func() (string, error) { return dir, nil },
+		IsTTY:  true,
+	}
+	fullArgs := append([]string{"tick", "search"}, args...)
+	code := app.Run(fullArgs)
+	return stdoutBuf.String(), stderrBuf.String(), code
+}
+
+func TestSearch(t *testing.T) {
+	now := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+	searchTasks := func() []task.Task {
+		return []task.Task{
+			{ID: "tick-aaa111", Title: "Fix login redirect", Status: task.StatusOpen, Priority: 2, Type: "bug", Created: now, Updated: now},
+			{ID: "tick-bbb222", Title: "Session handling", Description: "Users are logged out after login expires", Status: task.StatusOpen, Priority: 1, Created: now.Add(time.Second), Updated: now.Add(time.Second)},
+			{ID: "tick-ccc333", Title: "Rate limiting", Status: task.StatusInProgress, Priority: 2, Tags: []string{"api"}, Notes: []task.Note{{Text: "Investigate the token bucket approach", Created: now}}, Created: now.Add(2 * time.Second), Updated: now.Add(2 * time.Second)},
+			{ID: "tick-ddd444", Title: "Authentication audit", Status: task.StatusDone, Priority: 3, Created: now.Add(3 * time.Second), Updated: now.Add(3 * time.Second)},
+		}
+	}
+
+	t.Run("it matches terms in titles, descriptions, and notes", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, searchTasks())
+
+		for _, tc := range []struct {
+			query string
+			want  string
+		}{
+			{"redirect", "tick-aaa111"},
+			{"expires", "tick-bbb222"},
+			{"bucket", "tick-ccc333"},
+		} {
+			stdout, stderr, exitCode := runSearch(t, dir, "--quiet", tc.query)
+			if exitCode != 0 {
+				t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+			}
+			if strings.TrimSpace(stdout) != tc.want {
+				t.Errorf("search %q = %q, want %q", tc.query, stdout, tc.want)
+			}
+		}
+	})
+
+	t.Run("it ranks title matches above description matches", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, searchTasks())
+
+		stdout, stderr, exitCode := runSearch(t, dir, "--quiet", "login")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		if got := strings.Fields(stdout); strings.Join(got, ",") != "tick-aaa111,tick-bbb222" {
+			t.Errorf("ids = %v, want title match first", got)
+		}
+	})
+
+	t.Run("it supports phrase, prefix, and boolean queries", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, searchTasks())
+
+		for _, tc := range []struct {
+			args []string
+			want string
+		}{
+			{[]string{`"token bucket"`}, "tick-ccc333"},
+			{[]string{`"bucket token"`}, ""},
+			{[]string{"auth*"}, "tick-ddd444"},
+			{[]string{"login", "NOT", "redirect"}, "tick-bbb222"},
+			{[]string{"redirect OR bucket"}, "tick-aaa111 tick-ccc333"},
+			{[]string{"title:login"}, "tick-aaa111"},
+		} {
+			stdout, stderr, exitCode := runSearch(t, dir, append([]string{"--quiet"}, tc.args...)...)
+			if exitCode != 0 {
+				t.Fatalf("search %v: exit code = %d, want 0; stderr = %q", tc.args, exitCode, stderr)
+			}
+			if got := strings.Join(strings.Fields(stdout), " "); got != tc.want {
+				t.Errorf("search %v = %q, want %q", tc.args, got, tc.want)
+			}
+		}
+	})
+
+	t.Run("it narrows results with list filter flags", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, searchTasks())
+
+		stdout, _, exitCode := runSearch(t, dir, "--quiet", "login", "--type", "bug")
+		if exitCode != 0 || strings.TrimSpace(stdout) != "tick-aaa111" {
+			t.Errorf("--type bug: exit = %d, stdout = %q", exitCode, stdout)
+		}
+
+		stdout, _, exitCode = runSearch(t, dir, "--quiet", "login", "--count", "1")
+		if exitCode != 0 || strings.TrimSpace(stdout) != "tick-aaa111" {
+			t.Errorf("--count 1: exit = %d, stdout = %q", exitCode, stdout)
+		}
+
+		stdout, _, exitCode = runSearch(t, dir, "--quiet", "bucket", "--status", "open")
+		if exitCode != 0 || strings.TrimSpace(stdout) != "" {
+			t.Errorf("--status open: exit = %d, stdout = %q", exitCode, stdout)
+		}
+	})
+
+	t.Run("it narrows results to ready or blocked tasks", func(t *testing.T) {
+		tasks := searchTasks()
+		tasks[0].BlockedBy = []string{"tick-ccc333"}
+		dir, _ := setupTickProjectWithTasks(t, tasks)
+
+		stdout, stderr, exitCode := runSearch(t, dir, "--quiet", "login", "--ready")
+		if exitCode != 0 || strings.TrimSpace(stdout) != "tick-bbb222" {
+			t.Errorf("--ready: exit = %d, stdout = %q, stderr = %q", exitCode, stdout, stderr)
+		}
+
+		stdout, stderr, exitCode = runSearch(t, dir, "--quiet", "login", "--blocked")
+		if exitCode != 0 || strings.TrimSpace(stdout) != "tick-aaa111" {
+			t.Errorf("--blocked: exit = %d, stdout = %q, stderr = %q", exitCode, stdout, stderr)
+		}
+	})
+
+	t.Run("it finds tasks updated after the cache was built", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, searchTasks())
+		if _, _, code := runSearch(t, dir, "login"); code != 0 {
+			t.Fatal("initial search failed")
+		}
+
+		var stderr bytes.Buffer
+		app := &App{Stdout: &bytes.Buffer{}, Stderr: &stderr, Getwd: func() (string, error) { return dir, nil }}
+		if code := app.Run([]string{"tick", "note", "add", "tick-ddd444", "Covered password reset flows"}); code != 0 {
+			t.Fatalf("note add failed: %s", stderr.String())
+		}
+
+		stdout, _, exitCode := runSearch(t, dir, "--quiet", "password")
+		if exitCode != 0 || strings.TrimSpace(stdout) != "tick-ddd444" {
+			t.Errorf("exit = %d, stdout = %q, want tick-ddd444", exitCode, stdout)
+		}
+	})
+
+	t.Run("it shows a highlighted snippet in pretty output", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, searchTasks())
+
+		stdout, _, exitCode := runSearch(t, dir, "bucket")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0", exitCode)
+		}
+		if !strings.Contains(stdout, "tick-ccc333") || !strings.Contains(stdout, "**bucket**") {
+			t.Errorf("stdout = %q, want row and highlighted snippet", stdout)
+		}
+	})
+
+	t.Run("it prints No matching tasks. when nothing matches", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, searchTasks())
+
+		stdout, _, exitCode := runSearch(t, dir, "nonexistent")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0", exitCode)
+		}
+		if strings.TrimSpace(stdout) != "No matching tasks." {
+			t.Errorf("stdout = %q, want %q", stdout, "No matching tasks.")
+		}
+	})
+
+	t.Run("it outputs ranked results with snippets as JSON", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, searchTasks())
+
+		stdout, _, exitCode := runSearch(t, dir, "--json", "login")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0", exitCode)
+		}
+		var results []map[string]any
+		if err := json.Unmarshal([]byte(stdout), &results); err != nil {
+			t.Fatalf("invalid JSON: %v; stdout = %q", err, stdout)
+		}
+		if len(results) != 2 || results[0]["id"] != "tick-aaa111" {
+			t.Fatalf("results = %v, want 2 with tick-aaa111 first", results)
+		}
+		if snippet, _ := results[1]["snippet"].(string); !strings.Contains(snippet, "**login**") {
+			t.Errorf("snippet = %q, want highlighted match", snippet)
+		}
+	})
+
+	t.Run("it outputs results in TOON format", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, searchTasks())
+
+		stdout, _, exitCode := runSearch(t, dir, "--toon", "redirect")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0", exitCode)
+		}
+		if !strings.HasPrefix(stdout, "results[1]{id,title,status,priority,type,snippet}:") {
+			t.Errorf("stdout = %q, want TOON results header", stdout)
+		}
+	})
+
+	t.Run("it errors when no query is given", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, searchTasks())
+
+		_, stderr, exitCode := runSearch(t, dir, "--status", "open")
+		if exitCode != 1 {
+			t.Fatalf("exit code = %d, want 1", exitCode)
+		}
+		if !strings.Contains(stderr, "search query is required") {
+			t.Errorf("stderr = %q, want query required error", stderr)
+		}
+	})
+
+	t.Run("it reports an invalid query as a user error", func(t *testing.T) {
+		dir, _ := setupTickProjectWithTasks(t, searchTasks())
+
+		_, stderr, exitCode := runSearch(t, dir, `"unterminated`)
+		if exitCode != 1 {
+			t.Fatalf("exit code = %d, want 1", exitCode)
+		}
+		if !strings.Contains(stderr, "invalid search query") {
+			t.Errorf("stderr = %q, want invalid query error", stderr)
+		}
+	})
+}
@@ -4,10 +4,18 @@ import (
 	"database/sql"
 	"fmt"
 	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/leeovery/tick/internal/query"
+	"github.com/leeovery/tick/internal/storage"
+	"github.com/leeovery/tick/internal/task"
 )
 
 // RunStats executes the stats command: queries aggregate counts by status, priority,
-// and workflow state (ready/blocked), then outputs via the Formatter interface.
+// type, assignee, and workflow state (ready/blocked/overdue), and the estimate
+// rollups, then outputs via the Formatter interface.
 func RunStats(dir string, fc FormatConfig, fmtr Formatter, stdout io.Writer) error {
 	if fc.Quiet {
 		return nil
@@ -19,9 +27,90 @@ func RunStats(dir string, fc FormatConfig, fmtr Formatter, stdout io.Writer) err
 	}
 	defer store.Close()
 
+	stats, err := queryStats(store, fc.now())
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stdout, fmtr.FormatStats(stats))
+	return nil
+}
+
+// addTypeCount adds tc to the count of its type in counts, appending the type
+// if it is not there yet.
+func addTypeCount(counts []TypeCount, tc TypeCount) []TypeCount {
+	for i := range counts {
+		if counts[i].Type == tc.Type {
+			counts[i].Count += tc.Count
+			return counts
+		}
+	}
+	return append(counts, tc)
+}
+
+// addAssigneeCount adds ac to the count of its assignee in counts, keeping
+// counts sorted by name.
+func addAssigneeCount(counts []AssigneeCount, ac AssigneeCount) []AssigneeCount {
+	i, found := slices.BinarySearchFunc(counts, ac.Assignee, func(c AssigneeCount, name string) int {
+		return strings.Compare(c.Assignee, name)
+	})
+	if found {
+		counts[i].Count += ac.Count
+		return counts
+	}
+	return slices.Insert(counts, i, ac)
+}
+
+// addStateCount adds sc to the count of its state in counts, appending the
+// state if it is not there yet.
+func addStateCount(counts []StateCount, sc StateCount) []StateCount {
+	for i := range counts {
+		if counts[i].State == sc.State {
+			counts[i].Count += sc.Count
+			return counts
+		}
+	}
+	return append(counts, sc)
+}
+
+// statusCounts returns the count of every status in workflow order: open,
+// in_progress, the project's own states, done and cancelled.
+func statusCounts(s Stats) []StateCount {
+	counts := []StateCount{
+		{State: string(task.StatusOpen), Category: string(task.CategoryActive), Count: s.Open},
+		{State: string(task.StatusInProgress), Category: string(task.CategoryActive), Count: s.InProgress},
+	}
+	counts = append(counts, s.ByState...)
+	return append(counts,
+		StateCount{State: string(task.StatusDone), Category: string(task.CategoryTerminal), Count: s.Done},
+		StateCount{State: string(task.StatusCancelled), Category: string(task.CategoryTerminal), Count: s.Cancelled},
+	)
+}
+
+// live returns the number of tasks in non-terminal states: the ones that are
+// either ready or blocked.
+func (s Stats) live() int {
+	n := s.Open + s.InProgress
+	for _, sc := range s.ByState {
+		if task.Category(sc.Category) != task.CategoryTerminal {
+			n += sc.Count
+		}
+	}
+	return n
+}
+
+// queryStats computes the aggregate counts of the tasks in store, counting the
+// tasks overdue at now.
+func queryStats(store *storage.Store, now time.Time) (Stats, error) {
 	var stats Stats
+	wf := store.Config().Rules().Workflow
+	for _, st := range wf.States {
+		if !task.IsBuiltinStatus(st.Name) {
+			stats.ByState = append(stats.ByState, StateCount{State: string(st.Name), Category: string(st.Category)})
+		}
+	}
 
-	err = store.Query(func(db *sql.DB) error {
+	err := store.Query(func(db *sql.DB) error {
 		// Total count.
 		if err := db.QueryRow("SELECT COUNT(*) FROM tasks").Scan(&stats.Total); err != nil {
 			return fmt.Errorf("failed to query total count: %w", err)
@@ -49,6 +138,12 @@ func RunStats(dir string, fc FormatConfig, fmtr Formatter, stdout io.Writer) err
 				stats.Done = count
 			case "cancelled":
 				stats.Cancelled = count
+			default:
+				for i := range stats.ByState {
+					if stats.ByState[i].State == status {
+						stats.ByState[i].Count = count
+					}
+				}
 			}
 		}
 		if err := rows.Err(); err != nil {
@@ -75,21 +170,76 @@ func RunStats(dir string, fc FormatConfig, fmtr Formatter, stdout io.Writer) err
 			return fmt.Errorf("failed to iterate priority counts: %w", err)
 		}
 
-		// Ready count: open or in_progress, no unclosed blockers, no open/in-progress children, no blocked ancestor.
-		readyQuery := "\n\t\t\tSELECT COUNT(*) FROM tasks t\n\t\t\tWHERE " + ReadyWhereClause()
+		// Counts by type, in the project's type order.
+		for _, typ := range store.Config().TypeNames() {
+			stats.ByType = append(stats.ByType, TypeCount{Type: typ})
+		}
+		typeRows, err := db.Query("SELECT type, COUNT(*) FROM tasks WHERE type IS NOT NULL AND type != '' GROUP BY type ORDER BY type")
+		if err != nil {
+			return fmt.Errorf("failed to query type counts: %w", err)
+		}
+		defer typeRows.Close()
+
+		for typeRows.Next() {
+			var tc TypeCount
+			if err := typeRows.Scan(&tc.Type, &tc.Count); err != nil {
+				return fmt.Errorf("failed to scan type count: %w", err)
+			}
+			stats.ByType = addTypeCount(stats.ByType, tc)
+		}
+		if err := typeRows.Err(); err != nil {
+			return fmt.Errorf("failed to iterate type counts: %w", err)
+		}
+
+		// Counts by assignee, over live tasks.
+		live := wf.StatesIn(task.CategoryActive, task.CategoryWaiting)
+		placeholders := make([]string, len(live))
+		liveArgs := make([]any, len(live))
+		for i, s := range live {
+			placeholders[i] = "?"
+			liveArgs[i] = string(s)
+		}
+		assigneeRows, err := db.Query("SELECT assignee, COUNT(*) FROM tasks WHERE assignee IS NOT NULL AND assignee != '' AND status IN ("+strings.Join(placeholders, ", ")+") GROUP BY assignee ORDER BY assignee", liveArgs...)
+		if err != nil {
+			return fmt.Errorf("failed to query assignee counts: %w", err)
+		}
+		defer assigneeRows.Close()
+
+		for assigneeRows.Next() {
+			var ac AssigneeCount
+			if err := assigneeRows.Scan(&ac.Assignee, &ac.Count); err != nil {
+				return fmt.Errorf("failed to scan assignee count: %w", err)
+			}
+			stats.ByAssignee = append(stats.ByAssignee, ac)
+		}
+		if err := assigneeRows.Err(); err != nil {
+			return fmt.Errorf("failed to iterate assignee counts: %w", err)
+		}
+
+		// Ready count: in an active state, no unclosed blockers, no live children, no blocked ancestor, not deferred.
+		readyQuery := "\n\t\t\tSELECT COUNT(*) FROM tasks t\n\t\t\tWHERE " + query.ReadyWhereClause(wf, now)
 		if err := db.QueryRow(readyQuery).Scan(&stats.Ready); err != nil {
 			return fmt.Errorf("failed to query ready count: %w", err)
 		}
 
-		// Blocked count: live (open or in_progress) AND NOT ready, derived as (Open + InProgress) − Ready.
-		stats.Blocked = (stats.Open + stats.InProgress) - stats.Ready
+		// Blocked count: live (in an active or waiting state) AND NOT ready.
+		stats.Blocked = stats.live() - stats.Ready
+
+		// Overdue count: not closed and past the due date.
+		overdue, overdueArgs := query.OverdueConditions(now)
+		if err := db.QueryRow("SELECT COUNT(*) FROM tasks t WHERE "+strings.Join(overdue, " AND "), overdueArgs...).Scan(&stats.Overdue); err != nil {
+			return fmt.Errorf("failed to query overdue count: %w", err)
+		}
+
+		// Estimates: over every task, then per top-level task.
+		if stats.Estimates, err = query.EstimateRollup(db); err != nil {
+			return err
+		}
+		if stats.Rollups, err = query.RootRollups(db); err != nil {
+			return err
+		}
 
 		return nil
 	})
-	if err != nil {
-		return err
-	}
-
-	fmt.Fprintln(stdout, fmtr.FormatStats(stats))
-	return nil
+	return stats, err
 }
@@ -3,6 +3,9 @@ package cli
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -262,18 +265,18 @@ func TestStats(t *testing.T) {
 
 		output := strings.TrimRight(stdout, "\n")
 		sections := strings.Split(output, "\n\n")
-		if len(sections) != 2 {
-			t.Fatalf("expected 2 sections, got %d: %q", len(sections), output)
+		if len(sections) != 3 {
+			t.Fatalf("expected 3 sections, got %d: %q", len(sections), output)
 		}
 
 		// Section 1: stats summary
 		summaryLines := strings.Split(sections[0], "\n")
-		expectedHeader := "stats{total,open,in_progress,done,cancelled,ready,blocked}:"
+		expectedHeader := "stats{total,open,in_progress,done,cancelled,ready,blocked,overdue}:"
 		if summaryLines[0] != expectedHeader {
 			t.Errorf("stats header = %q, want %q", summaryLines[0], expectedHeader)
 		}
-		// Total=2, Open=1, InProgress=0, Done=1, Cancelled=0, Ready=1, Blocked=0
-		expectedRow := "  2,1,0,1,0,1,0"
+		// Total=2, Open=1, InProgress=0, Done=1, Cancelled=0, Ready=1, Blocked=0, Overdue=0
+		expectedRow := "  2,1,0,1,0,1,0,0"
 		if summaryLines[1] != expectedRow {
 			t.Errorf("stats row = %q, want %q", summaryLines[1], expectedRow)
 		}
@@ -333,6 +336,47 @@ func TestStats(t *testing.T) {
 		}
 	})
 
+	t.Run("it counts tasks by type in configured order with undeclared types last", func(t *testing.T) {
+		tasks := []task.Task{
+			{ID: "tick-aaa111", Title: "Spike one", Status: task.StatusOpen, Priority: 2, Type: "spike", Created: now, Updated: now},
+			{ID: "tick-aaa222", Title: "Spike two", Status: task.StatusOpen, Priority: 2, Type: "spike", Created: now, Updated: now},
+			{ID: "tick-bbb111", Title: "Old bug", Status: task.StatusOpen, Priority: 2, Type: "bug", Created: now, Updated: now},
+			{ID: "tick-ccc111", Title: "Untyped", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		}
+		dir, tickDir := setupTickProjectWithTasks(t, tasks)
+		if err := os.WriteFile(filepath.Join(tickDir, "config.yaml"), []byte("types: [story, spike]\n"), 0644); err != nil {
+			t.Fatalf("failed to write config.yaml: %v", err)
+		}
+
+		stdout, stderr, exitCode := runStats(t, dir, "--json")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+
+		var parsed struct {
+			ByType []struct {
+				Type  string `json:"type"`
+				Count int    `json:"count"`
+			} `json:"by_type"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &parsed); err != nil {
+			t.Fatalf("invalid JSON: %v\noutput: %s", err, stdout)
+		}
+		var got []string
+		for _, tc := range parsed.ByType {
+			got = append(got, fmt.Sprintf("%s=%d", tc.Type, tc.Count))
+		}
+		want := "story=0,spike=2,bug=1"
+		if strings.Join(got, ",") != want {
+			t.Errorf("by_type = %s, want %s", strings.Join(got, ","), want)
+		}
+
+		stdout, _, _ = runStats(t, dir, "--pretty")
+		if !strings.Contains(stdout, "Type:\n  story:  0\n  spike:  2\n  bug:    1") {
+			t.Errorf("Type group not rendered as expected in:\n%s", stdout)
+		}
+	})
+
 	t.Run("it formats stats in JSON format with nested structure", func(t *testing.T) {
 		tasks := []task.Task{
 			{ID: "tick-aaa111", Title: "Open task", Status: task.StatusOpen, Priority: 1, Created: now, Updated: now},
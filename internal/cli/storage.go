@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/leeovery/tick/internal/storage"
+)
+
+// parseConvertArgs extracts the required --layout value from storage convert arguments.
+func parseConvertArgs(args []string) (storage.Layout, error) {
+	for i := 0; i < len(args); i++ {
+		if args[i] != "--layout" {
+			continue
+		}
+		if i+1 >= len(args) {
+			return "", fmt.Errorf("--layout requires a value (jsonl or files)")
+		}
+		return storage.ParseLayout(args[i+1])
+	}
+	return "", fmt.Errorf("--layout is required. Usage: tick storage convert --layout <jsonl|files>")
+}
+
+// RunStorageConvert executes the storage convert command: rewrites all tasks into
+// the target layout and removes the previous layout's data.
+func RunStorageConvert(dir string, fc FormatConfig, fmtr Formatter, layout storage.Layout, stdout io.Writer) error {
+	store, err := openStore(dir, fc)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	count, err := store.Convert(layout)
+	if err != nil {
+		return err
+	}
+
+	if !fc.Quiet {
+		msg := fmt.Sprintf("Converted %d tasks to the %s layout (.tick/%s)", count, layout, layout.SourceName())
+		fmt.Fprintln(stdout, fmtr.FormatMessage(msg))
+	}
+	return nil
+}
+
+// handleStorage implements the storage subcommand.
+func (a *App) handleStorage(fc FormatConfig, fmtr Formatter, subArgs []string) error {
+	dir, err := a.Getwd()
+	if err != nil {
+		return fmt.Errorf("could not determine working directory: %w", err)
+	}
+
+	if len(subArgs) == 0 || subArgs[0] != "convert" {
+		if len(subArgs) > 0 {
+			return fmt.Errorf("unknown storage sub-command '%s'. Usage: tick storage convert --layout <jsonl|files>", subArgs[0])
+		}
+		return fmt.Errorf("sub-command required. Usage: tick storage convert --layout <jsonl|files>")
+	}
+
+	layout, err := parseConvertArgs(subArgs[1:])
+	if err != nil {
+		return err
+	}
+	return RunStorageConvert(dir, fc, fmtr, layout, a.Stdout)
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+func TestInitLayout(t *testing.T) {
+	t.Run("it creates a tasks directory with --layout files", func(t *testing.T) {
+		dir := t.TempDir()
+
+		if _, stderr, code := runTick(t, dir, "init", "--layout", "files"); code != 0 {
+			t.Fatalf("init failed: %s", stderr)
+		}
+		if info, err := os.Stat(filepath.Join(dir, ".tick", "tasks")); err != nil || !info.IsDir() {
+			t.Fatalf(".tick/tasks/ not created: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".tick", "tasks.jsonl")); !os.IsNotExist(err) {
+			t.Error("tasks.jsonl should not exist in the files layout")
+		}
+
+		stdout, stderr, code := runTick(t, dir, "create", "First task", "--quiet")
+		if code != 0 {
+			t.Fatalf("create failed: %s", stderr)
+		}
+		id := strings.TrimSpace(stdout)
+		if _, err := os.Stat(filepath.Join(dir, ".tick", "tasks", id+".json")); err != nil {
+			t.Errorf("task file for %s not written: %v", id, err)
+		}
+
+		stdout, _, _ = runTick(t, dir, "list", "--quiet")
+		if strings.TrimSpace(stdout) != id {
+			t.Errorf("list = %q, want %s", stdout, id)
+		}
+	})
+
+	t.Run("it rejects an unknown layout", func(t *testing.T) {
+		dir := t.TempDir()
+
+		_, stderr, code := runTick(t, dir, "init", "--layout", "sqlite")
+		if code != 1 || stderr != "Error: invalid layout 'sqlite': must be one of jsonl, files\n" {
+			t.Errorf("exit = %d, stderr = %q", code, stderr)
+		}
+	})
+}
+
+func TestStorageConvert(t *testing.T) {
+	now := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+
+	t.Run("it converts to the files layout and back", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Alpha", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+			{ID: "tick-bbb222", Title: "Beta", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		})
+
+		stdout, stderr, code := runTick(t, dir, "storage", "convert", "--layout", "files")
+		if code != 0 {
+			t.Fatalf("convert failed: %s", stderr)
+		}
+		if stdout != "Converted 2 tasks to the files layout (.tick/tasks/)\n" {
+			t.Errorf("stdout = %q", stdout)
+		}
+		for _, id := range []string{"tick-aaa111", "tick-bbb222"} {
+			if _, err := os.Stat(filepath.Join(tickDir, "tasks", id+".json")); err != nil {
+				t.Errorf("task file for %s missing: %v", id, err)
+			}
+		}
+
+		if _, stderr, code := runTick(t, dir, "start", "tick-aaa111"); code != 0 {
+			t.Fatalf("start after convert failed: %s", stderr)
+		}
+
+		if _, stderr, code := runTick(t, dir, "storage", "convert", "--layout", "jsonl"); code != 0 {
+			t.Fatalf("convert back failed: %s", stderr)
+		}
+		tasks := readPersistedTasks(t, tickDir)
+		if len(tasks) != 2 || tasks[0].Status != task.StatusInProgress {
+			t.Errorf("tasks after round trip = %+v", tasks)
+		}
+	})
+
+	t.Run("it requires --layout", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+
+		_, stderr, code := runTick(t, dir, "storage", "convert")
+		if code != 1 || !strings.Contains(stderr, "--layout is required") {
+			t.Errorf("exit = %d, stderr = %q", code, stderr)
+		}
+	})
+
+	t.Run("it rejects converting to the current layout", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+
+		_, stderr, code := runTick(t, dir, "storage", "convert", "--layout", "jsonl")
+		if code != 1 || stderr != "Error: already using the jsonl layout\n" {
+			t.Errorf("exit = %d, stderr = %q", code, stderr)
+		}
+	})
+
+	t.Run("it rejects unknown storage sub-commands", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+
+		_, stderr, code := runTick(t, dir, "storage", "compact")
+		if code != 1 || !strings.Contains(stderr, "unknown storage sub-command 'compact'") {
+			t.Errorf("exit = %d, stderr = %q", code, stderr)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// writeTemplate writes content as .tick/templates/<name>.yaml.
+func writeTemplate(t *testing.T, tickDir, name, content string) {
+	t.Helper()
+	dir := filepath.Join(tickDir, "templates")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create templates/: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".yaml"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+}
+
+const endpointTemplate = `vars:
+  name: {}
+tasks:
+  - key: endpoint
+    title: "Endpoint: {{name}}"
+    tags: [api]
+    children:
+      - key: design
+        title: Design {{name}}
+      - key: implement
+        title: Implement {{name}}
+        blocked_by: [design]
+`
+
+func TestCreateTemplate(t *testing.T) {
+	t.Run("it creates the task tree of a template", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+		writeTemplate(t, tickDir, "endpoint", endpointTemplate)
+
+		stdout, stderr, exitCode := runCreate(t, dir, "--template", "endpoint", "--var", "name=login")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+
+		tasks := readPersistedTasks(t, tickDir)
+		if len(tasks) != 3 {
+			t.Fatalf("persisted %d tasks, want 3", len(tasks))
+		}
+		root, design, implement := tasks[0], tasks[1], tasks[2]
+		if root.Title != "Endpoint: login" || len(root.Tags) != 1 || root.Tags[0] != "api" {
+			t.Errorf("root = %+v", root)
+		}
+		if design.Parent != root.ID || implement.Parent != root.ID || len(implement.BlockedBy) != 1 || implement.BlockedBy[0] != design.ID {
+			t.Errorf("design = %+v, implement = %+v, want children of %s with implement blocked by design", design, implement, root.ID)
+		}
+
+		want := "Applied 3 operations:\n" +
+			"  create  " + root.ID + "  $endpoint\n" +
+			"  create  " + design.ID + "  $design\n" +
+			"  create  " + implement.ID + "  $implement\n"
+		if stdout != want {
+			t.Errorf("stdout = %q, want %q", stdout, want)
+		}
+	})
+
+	t.Run("it places the tree under --parent", func(t *testing.T) {
+		now := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+		dir, tickDir := setupTickProjectWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "API", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		})
+		writeTemplate(t, tickDir, "endpoint", endpointTemplate)
+
+		stdout, stderr, exitCode := runTick(t, dir, "--quiet", "create", "--template", "endpoint", "--var", "name=login", "--parent", "aaa111")
+		if exitCode != 0 {
+			t.Fatalf("exit code = %d, want 0; stderr = %q", exitCode, stderr)
+		}
+		tasks := readPersistedTasks(t, tickDir)
+		if len(tasks) != 4 || tasks[1].Parent != "tick-aaa111" {
+			t.Fatalf("tasks = %+v, want the template root under tick-aaa111", tasks)
+		}
+		if want := tasks[1].ID + "\n" + tasks[2].ID + "\n" + tasks[3].ID + "\n"; stdout != want {
+			t.Errorf("quiet stdout = %q, want %q", stdout, want)
+		}
+	})
+
+	t.Run("it rejects a title, task flags and --var without --template", func(t *testing.T) {
+		dir, tickDir := setupTickProject(t)
+		writeTemplate(t, tickDir, "endpoint", endpointTemplate)
+
+		for _, tc := range []struct {
+			args []string
+			want string
+		}{
+			{[]string{"Login", "--template", "endpoint", "--var", "name=login"}, "remove the title argument"},
+			{[]string{"--template", "endpoint", "--var", "name=login", "--priority", "1"}, "--priority cannot be used with --template"},
+			{[]string{"Login", "--var", "name=login"}, "--var requires --template"},
+			{[]string{"--template", "endpoint", "--var", "login"}, `invalid --var "login": use name=value`},
+			{[]string{"--template", "endpoint"}, "needs a value for variable 'name'"},
+			{[]string{"--template", "missing"}, "template 'missing' not found (available: endpoint)"},
+		} {
+			_, stderr, exitCode := runCreate(t, dir, tc.args...)
+			if exitCode != 1 || !strings.Contains(stderr, tc.want) {
+				t.Errorf("create %v: exit code = %d, stderr = %q, want %q", tc.args, exitCode, stderr, tc.want)
+			}
+		}
+		if tasks := readPersistedTasks(t, tickDir); len(tasks) != 0 {
+			t.Errorf("persisted %d tasks, want 0", len(tasks))
+		}
+	})
+}
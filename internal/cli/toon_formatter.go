@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	toon "github.com/toon-format/toon-go"
@@ -34,34 +35,100 @@ type toonRelatedRow struct {
 	Status string `toon:"status"`
 }
 
-// toonStatsSummary is a TOON-serializable row for the stats summary section.
-type toonStatsSummary struct {
-	Total      int `toon:"total"`
-	Open       int `toon:"open"`
-	InProgress int `toon:"in_progress"`
-	Done       int `toon:"done"`
-	Cancelled  int `toon:"cancelled"`
-	Ready      int `toon:"ready"`
-	Blocked    int `toon:"blocked"`
-}
-
 // toonNoteRow is a TOON-serializable row for the notes section in show output.
 type toonNoteRow struct {
 	Text    string `toon:"text"`
 	Created string `toon:"created"`
 }
 
+// toonFieldRow is a TOON-serializable row for the fields section.
+type toonFieldRow struct {
+	Key   string `toon:"key"`
+	Value string `toon:"value"`
+}
+
 // toonPriorityRow is a TOON-serializable row for the by_priority section.
 type toonPriorityRow struct {
 	Priority int `toon:"priority"`
 	Count    int `toon:"count"`
 }
 
-// FormatTaskList renders a list of tasks in TOON tabular format.
+// toonAssigneeRow is a TOON-serializable row for the by_assignee stats section.
+type toonAssigneeRow struct {
+	Assignee string `toon:"assignee"`
+	Count    int    `toon:"count"`
+}
+
+// toonTypeRow is a TOON-serializable row for the by_type stats section.
+type toonTypeRow struct {
+	Type  string `toon:"type"`
+	Count int    `toon:"count"`
+}
+
+// toonRollupRow is a TOON-serializable row for an estimate rollup: the rollup
+// section of show and the estimates section of stats.
+type toonRollupRow struct {
+	Total     float64 `toon:"total"`
+	Remaining float64 `toon:"remaining"`
+}
+
+// toonTaskRollupRow is a TOON-serializable row for the rollups stats section.
+type toonTaskRollupRow struct {
+	ID        string  `toon:"id"`
+	Title     string  `toon:"title"`
+	Total     float64 `toon:"total"`
+	Remaining float64 `toon:"remaining"`
+}
+
+// FormatTaskList renders a list of tasks in TOON tabular format. Assignee, due,
+// defer_until, recur and reason columns are added when any task has an
+// assignee, a due date, a defer date, a recurrence rule or a blocked reason.
 func (f *ToonFormatter) FormatTaskList(tasks []task.Task) string {
 	if len(tasks) == 0 {
 		return "tasks[0]{id,title,status,priority,type}:"
 	}
+	withAssignee := slices.ContainsFunc(tasks, func(t task.Task) bool { return t.Assignee != "" })
+	withDue := slices.ContainsFunc(tasks, func(t task.Task) bool { return t.Due != nil })
+	withDefer := slices.ContainsFunc(tasks, func(t task.Task) bool { return t.DeferUntil != nil })
+	withRecur := slices.ContainsFunc(tasks, func(t task.Task) bool { return t.Recur != "" })
+	withReason := slices.ContainsFunc(tasks, func(t task.Task) bool { return t.BlockedReason != "" })
+	if withAssignee || withDue || withDefer || withRecur || withReason {
+		rows := make([]toon.Object, len(tasks))
+		for i, t := range tasks {
+			fields := []toon.Field{
+				{Key: "id", Value: t.ID},
+				{Key: "title", Value: t.Title},
+				{Key: "status", Value: string(t.Status)},
+				{Key: "priority", Value: t.Priority},
+				{Key: "type", Value: t.Type},
+			}
+			if withAssignee {
+				fields = append(fields, toon.Field{Key: "assignee", Value: t.Assignee})
+			}
+			if withDue {
+				var due string
+				if t.Due != nil {
+					due = task.FormatTimestamp(*t.Due)
+				}
+				fields = append(fields, toon.Field{Key: "due", Value: due})
+			}
+			if withDefer {
+				var until string
+				if t.DeferUntil != nil {
+					until = task.FormatTimestamp(*t.DeferUntil)
+				}
+				fields = append(fields, toon.Field{Key: "defer_until", Value: until})
+			}
+			if withRecur {
+				fields = append(fields, toon.Field{Key: "recur", Value: t.Recur})
+			}
+			if withReason {
+				fields = append(fields, toon.Field{Key: "reason", Value: t.BlockedReason})
+			}
+			rows[i] = toon.NewObject(fields...)
+		}
+		return encodeToonSection("tasks", rows)
+	}
 	rows := make([]toonTaskRow, len(tasks))
 	for i, t := range tasks {
 		rows[i] = toonTaskRow{
@@ -88,6 +155,11 @@ func (f *ToonFormatter) FormatTaskDetail(detail TaskDetail) string {
 	// Section 3: children (always present, even with count 0)
 	sections = append(sections, buildRelatedSection("children", detail.Children))
 
+	// Section 3b: rollup of the subtree's estimates (omitted when absent)
+	if detail.Rollup != nil {
+		sections = append(sections, encodeToonSingleObject("rollup", toonRollupRow(*detail.Rollup)))
+	}
+
 	// Section 4: tags (omitted when empty)
 	if len(detail.Tags) > 0 {
 		sections = append(sections, buildTagsSection(detail.Tags))
@@ -98,10 +170,15 @@ func (f *ToonFormatter) FormatTaskDetail(detail TaskDetail) string {
 		sections = append(sections, buildRefsSection(detail.Refs))
 	}
 
-	// Section 6: notes (always present, even with count 0)
+	// Section 6: fields (omitted when empty)
+	if len(detail.Fields) > 0 {
+		sections = append(sections, buildFieldsSection(detail.Fields))
+	}
+
+	// Section 7: notes (always present, even with count 0)
 	sections = append(sections, buildNotesSection(detail.Notes))
 
-	// Section 7: description (omitted when empty)
+	// Section 8: description (omitted when empty)
 	if detail.Task.Description != "" {
 		sections = append(sections, buildDescriptionSection(detail.Task.Description))
 	}
@@ -113,17 +190,18 @@ func (f *ToonFormatter) FormatTaskDetail(detail TaskDetail) string {
 func (f *ToonFormatter) FormatStats(stats Stats) string {
 	var sections []string
 
-	// Section 1: stats summary (single-object scope)
-	summary := toonStatsSummary{
-		Total:      stats.Total,
-		Open:       stats.Open,
-		InProgress: stats.InProgress,
-		Done:       stats.Done,
-		Cancelled:  stats.Cancelled,
-		Ready:      stats.Ready,
-		Blocked:    stats.Blocked,
+	// Section 1: stats summary (single-object scope), with a count for every
+	// status in workflow order.
+	fields := []toon.Field{{Key: "total", Value: stats.Total}}
+	for _, sc := range statusCounts(stats) {
+		fields = append(fields, toon.Field{Key: sc.State, Value: sc.Count})
 	}
-	sections = append(sections, encodeToonSingleObject("stats", summary))
+	fields = append(fields,
+		toon.Field{Key: "ready", Value: stats.Ready},
+		toon.Field{Key: "blocked", Value: stats.Blocked},
+		toon.Field{Key: "overdue", Value: stats.Overdue},
+	)
+	sections = append(sections, encodeToonSingleObject("stats", toon.NewObject(fields...)))
 
 	// Section 2: by_priority (always 5 rows, 0-4)
 	rows := make([]toonPriorityRow, 5)
@@ -132,6 +210,39 @@ func (f *ToonFormatter) FormatStats(stats Stats) string {
 	}
 	sections = append(sections, encodeToonSection("by_priority", rows))
 
+	// Section 3: by_type (declared types, then undeclared ones in use)
+	if len(stats.ByType) == 0 {
+		sections = append(sections, "by_type[0]{type,count}:")
+	} else {
+		typeRows := make([]toonTypeRow, len(stats.ByType))
+		for i, tc := range stats.ByType {
+			typeRows[i] = toonTypeRow(tc)
+		}
+		sections = append(sections, encodeToonSection("by_type", typeRows))
+	}
+
+	// Section 4: by_assignee (live tasks per assignee, omitted when none)
+	if len(stats.ByAssignee) > 0 {
+		assigneeRows := make([]toonAssigneeRow, len(stats.ByAssignee))
+		for i, ac := range stats.ByAssignee {
+			assigneeRows[i] = toonAssigneeRow(ac)
+		}
+		sections = append(sections, encodeToonSection("by_assignee", assigneeRows))
+	}
+
+	// Section 5: estimates and the rollup per top-level task (omitted when
+	// nothing is estimated)
+	if stats.Estimates.Total > 0 {
+		sections = append(sections, encodeToonSingleObject("estimates", toonRollupRow(stats.Estimates)))
+	}
+	if len(stats.Rollups) > 0 {
+		rollupRows := make([]toonTaskRollupRow, len(stats.Rollups))
+		for i, r := range stats.Rollups {
+			rollupRows[i] = toonTaskRollupRow{ID: r.ID, Title: r.Title, Total: r.Total, Remaining: r.Remaining}
+		}
+		sections = append(sections, encodeToonSection("rollups", rollupRows))
+	}
+
 	return strings.Join(sections, "\n\n")
 }
 
@@ -154,6 +265,152 @@ func (f *ToonFormatter) FormatCascadeTransition(result CascadeResult) string {
 	return strings.Join(lines, "\n")
 }
 
+// toonSearchRow is a TOON-serializable row for search result output.
+type toonSearchRow struct {
+	ID       string `toon:"id"`
+	Title    string `toon:"title"`
+	Status   string `toon:"status"`
+	Priority int    `toon:"priority"`
+	Type     string `toon:"type"`
+	Snippet  string `toon:"snippet"`
+}
+
+// FormatSearchResults renders search matches in TOON tabular format, in rank order.
+func (f *ToonFormatter) FormatSearchResults(results []SearchResult) string {
+	if len(results) == 0 {
+		return "results[0]{id,title,status,priority,type,snippet}:"
+	}
+	rows := make([]toonSearchRow, len(results))
+	for i, r := range results {
+		rows[i] = toonSearchRow{
+			ID:       r.Task.ID,
+			Title:    r.Task.Title,
+			Status:   string(r.Task.Status),
+			Priority: r.Task.Priority,
+			Type:     r.Task.Type,
+			Snippet:  r.Snippet,
+		}
+	}
+	return encodeToonSection("results", rows)
+}
+
+// toonJournalRow is a TOON-serializable row for journal output.
+// Tasks holds the changed task IDs joined by commas.
+type toonJournalRow struct {
+	Seq     int    `toon:"seq"`
+	At      string `toon:"at"`
+	Op      string `toon:"op"`
+	Target  int    `toon:"target"`
+	Undone  bool   `toon:"undone"`
+	Tasks   string `toon:"tasks"`
+	Command string `toon:"command"`
+}
+
+// FormatJournal renders journal entries in TOON tabular format, newest first.
+func (f *ToonFormatter) FormatJournal(rows []JournalRow) string {
+	if len(rows) == 0 {
+		return "journal[0]{seq,at,op,target,undone,tasks,command}:"
+	}
+	out := make([]toonJournalRow, len(rows))
+	for i, r := range rows {
+		out[i] = toonJournalRow{
+			Seq:     r.Seq,
+			At:      task.FormatTimestamp(r.At),
+			Op:      r.Op,
+			Target:  r.Target,
+			Undone:  r.Undone,
+			Tasks:   strings.Join(r.TaskIDs, ","),
+			Command: r.Command,
+		}
+	}
+	return encodeToonSection("journal", out)
+}
+
+// toonBatchRow is a TOON-serializable row for one applied batch operation.
+type toonBatchRow struct {
+	Op  string `toon:"op"`
+	ID  string `toon:"id"`
+	Ref string `toon:"ref"`
+}
+
+// toonStatusChangeRow is a TOON-serializable row for a status change made by a batch.
+type toonStatusChangeRow struct {
+	ID   string `toon:"id"`
+	From string `toon:"from"`
+	To   string `toon:"to"`
+	Auto bool   `toon:"auto"`
+}
+
+// FormatBatch renders the batch outcome as an ops section followed by a
+// status_changes section.
+func (f *ToonFormatter) FormatBatch(summary BatchSummary) string {
+	rows := make([]toonBatchRow, len(summary.Rows))
+	for i, r := range summary.Rows {
+		rows[i] = toonBatchRow(r)
+	}
+	ops := encodeToonSection("ops", rows)
+	if len(summary.StatusChanges) == 0 {
+		return ops + "\n\nstatus_changes[0]{id,from,to,auto}:"
+	}
+	changes := make([]toonStatusChangeRow, len(summary.StatusChanges))
+	for i, c := range summary.StatusChanges {
+		changes[i] = toonStatusChangeRow(c)
+	}
+	return ops + "\n\n" + encodeToonSection("status_changes", changes)
+}
+
+// toonLockSummary is a TOON-serializable row for the lock status summary.
+type toonLockSummary struct {
+	Held bool `toon:"held"`
+}
+
+// toonLockHolderRow is a TOON-serializable row for a recorded lock holder.
+type toonLockHolderRow struct {
+	PID      int    `toon:"pid"`
+	Hostname string `toon:"hostname"`
+	Command  string `toon:"command"`
+	Mode     string `toon:"mode"`
+	Started  string `toon:"started"`
+	Alive    bool   `toon:"alive"`
+}
+
+// FormatLockStatus renders the lock status as a single-object lock section
+// followed by a holders section.
+func (f *ToonFormatter) FormatLockStatus(status LockStatus) string {
+	lock := encodeToonSingleObject("lock", toonLockSummary{Held: status.Held})
+	if len(status.Holders) == 0 {
+		return lock + "\n\nholders[0]{pid,hostname,command,mode,started,alive}:"
+	}
+	rows := make([]toonLockHolderRow, len(status.Holders))
+	for i, h := range status.Holders {
+		rows[i] = toonLockHolderRow{
+			PID:      h.PID,
+			Hostname: h.Hostname,
+			Command:  h.Command,
+			Mode:     h.Mode,
+			Started:  task.FormatTimestamp(h.Started),
+			Alive:    h.Alive,
+		}
+	}
+	return lock + "\n\n" + encodeToonSection("holders", rows)
+}
+
+// toonConfigRow is a TOON-serializable row for a configuration setting.
+type toonConfigRow struct {
+	Key     string `toon:"key"`
+	Value   string `toon:"value"`
+	Default bool   `toon:"default"`
+}
+
+// FormatConfig renders the configuration settings as a config section.
+func (f *ToonFormatter) FormatConfig(entries []ConfigEntry) string {
+	rows := make([]toonConfigRow, len(entries))
+	for i, e := range entries {
+		rows[i] = toonConfigRow(e)
+	}
+	return encodeToonSection("config", rows)
+}
+
 // toonEdgeRow is a TOON-serializable row for dep tree edge list output.
 type toonEdgeRow struct {
 	From string `toon:"from"`
@@ -256,7 +513,38 @@ func buildEdgeSection(name string, edges []toonEdgeRow) string {
 	return encodeToonSection(name, edges)
 }
 
-// buildTaskSection builds the task section with dynamic schema (omitting parent/closed when null).
+// toonCriticalPathRow is a TOON-serializable row for critical path output.
+type toonCriticalPathRow struct {
+	ID       string  `toon:"id"`
+	Title    string  `toon:"title"`
+	Status   string  `toon:"status"`
+	Estimate float64 `toon:"estimate"`
+}
+
+// FormatCriticalPath renders the critical path as a critical_path[N]{id,title,
+// status,estimate}: section in the order the work has to be done, followed by
+// a summary{target,total,tasks}: section. target is omitted for the whole
+// project.
+func (f *ToonFormatter) FormatCriticalPath(result CriticalPathResult) string {
+	rows := make([]toonCriticalPathRow, len(result.Tasks))
+	for i, t := range result.Tasks {
+		rows[i] = toonCriticalPathRow(t)
+	}
+
+	var fields []toon.Field
+	if result.Target != nil {
+		fields = append(fields, toon.Field{Key: "target", Value: result.Target.ID})
+	}
+	fields = append(fields,
+		toon.Field{Key: "total", Value: result.Total},
+		toon.Field{Key: "tasks", Value: len(result.Tasks)},
+	)
+
+	return encodeToonSection("critical_path", rows) + "\n\n" + encodeToonSingleObject("summary", toon.NewObject(fields...))
+}
+
+// buildTaskSection builds the task section with dynamic schema (omitting parent, assignee,
+// lease_expires and closed when null).
 func buildTaskSection(t task.Task) string {
 	var fields []toon.Field
 
@@ -275,6 +563,34 @@ func buildTaskSection(t task.Task) string {
 		fields = append(fields, toon.Field{Key: "parent", Value: t.Parent})
 	}
 
+	if t.Due != nil {
+		fields = append(fields, toon.Field{Key: "due", Value: task.FormatTimestamp(*t.Due)})
+	}
+
+	if t.DeferUntil != nil {
+		fields = append(fields, toon.Field{Key: "defer_until", Value: task.FormatTimestamp(*t.DeferUntil)})
+	}
+
+	if t.Assignee != "" {
+		fields = append(fields, toon.Field{Key: "assignee", Value: t.Assignee})
+	}
+
+	if t.Estimate != 0 {
+		fields = append(fields, toon.Field{Key: "estimate", Value: t.Estimate})
+	}
+
+	if t.Recur != "" {
+		fields = append(fields, toon.Field{Key: "recur", Value: t.Recur})
+	}
+
+	if t.RecurredFrom != "" {
+		fields = append(fields, toon.Field{Key: "recurred_from", Value: t.RecurredFrom})
+	}
+
+	if t.LeaseExpires != nil {
+		fields = append(fields, toon.Field{Key: "lease_expires", Value: task.FormatTimestamp(*t.LeaseExpires)})
+	}
+
 	fields = append(fields,
 		toon.Field{Key: "created", Value: task.FormatTimestamp(t.Created)},
 		toon.Field{Key: "updated", Value: task.FormatTimestamp(t.Updated)},
@@ -316,6 +632,15 @@ func buildRefsSection(refs []string) string {
 	return buildStringListSection("refs", refs)
 }
 
+// buildFieldsSection builds the fields section as a TOON tabular section.
+func buildFieldsSection(fields []TaskField) string {
+	rows := make([]toonFieldRow, len(fields))
+	for i, f := range fields {
+		rows[i] = toonFieldRow(f)
+	}
+	return encodeToonSection("fields", rows)
+}
+
 // buildStringListSection builds a named TOON section from a list of strings.
 func buildStringListSection(name string, items []string) string {
 	var b strings.Builder
@@ -265,16 +265,16 @@ func TestToonFormatter(t *testing.T) {
 		}
 		result := f.FormatStats(stats)
 		sections := strings.Split(result, "\n\n")
-		if len(sections) != 2 {
-			t.Fatalf("expected 2 sections, got %d: %q", len(sections), result)
+		if len(sections) != 3 {
+			t.Fatalf("expected 3 sections, got %d: %q", len(sections), result)
 		}
 		// Section 1: stats summary
 		summaryLines := strings.Split(sections[0], "\n")
-		expectedHeader := "stats{total,open,in_progress,done,cancelled,ready,blocked}:"
+		expectedHeader := "stats{total,open,in_progress,done,cancelled,ready,blocked,overdue}:"
 		if summaryLines[0] != expectedHeader {
 			t.Errorf("stats header = %q, want %q", summaryLines[0], expectedHeader)
 		}
-		expectedRow := "  47,12,3,28,4,8,4"
+		expectedRow := "  47,12,3,28,4,8,4,0"
 		if summaryLines[1] != expectedRow {
 			t.Errorf("stats row = %q, want %q", summaryLines[1], expectedRow)
 		}
@@ -289,8 +289,8 @@ func TestToonFormatter(t *testing.T) {
 		}
 		result := f.FormatStats(stats)
 		sections := strings.Split(result, "\n\n")
-		if len(sections) != 2 {
-			t.Fatalf("expected 2 sections, got %d: %q", len(sections), result)
+		if len(sections) != 3 {
+			t.Fatalf("expected 3 sections, got %d: %q", len(sections), result)
 		}
 		priorityLines := strings.Split(sections[1], "\n")
 		expectedPriorityHeader := "by_priority[5]{priority,count}:"
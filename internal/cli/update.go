@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/leeovery/tick/internal/task"
+	"github.com/leeovery/tick/tick"
 )
 
 // updateOpts holds parsed options for the update command.
@@ -26,11 +27,21 @@ type updateOpts struct {
 	clearTags        bool
 	refs             *[]string
 	clearRefs        bool
+	fields           map[string]string
+	clearFields      []string
+	assignee         *string
+	unassign         bool
+	due              *time.Time
+	clearDue         bool
+	estimate         *float64
+	clearEstimate    bool
+	recur            *string
+	clearRecur       bool
 }
 
 // hasChanges reports whether at least one update flag was provided.
 func (o updateOpts) hasChanges() bool {
-	return o.title != nil || o.description != nil || o.priority != nil || o.parent != nil || len(o.blocks) > 0 || o.clearDescription || o.taskType != nil || o.clearType || o.tags != nil || o.clearTags || o.refs != nil || o.clearRefs
+	return o.title != nil || o.description != nil || o.priority != nil || o.parent != nil || len(o.blocks) > 0 || o.clearDescription || o.taskType != nil || o.clearType || o.tags != nil || o.clearTags || o.refs != nil || o.clearRefs || len(o.fields) > 0 || len(o.clearFields) > 0 || o.assignee != nil || o.unassign || o.due != nil || o.clearDue || o.estimate != nil || o.clearEstimate || o.recur != nil || o.clearRecur
 }
 
 // parseUpdateArgs parses the subcommand arguments for `tick update`.
@@ -96,6 +107,61 @@ func parseUpdateArgs(args []string) (updateOpts, error) {
 			opts.refs = new(strings.Split(args[i], ","))
 		case "--clear-refs":
 			opts.clearRefs = true
+		case "--assignee":
+			i++
+			if i >= len(args) {
+				return opts, fmt.Errorf("--assignee requires a value")
+			}
+			opts.assignee = new(args[i])
+		case "--unassign":
+			opts.unassign = true
+		case "--due":
+			i++
+			if i >= len(args) {
+				return opts, fmt.Errorf("--due requires a value")
+			}
+			due, err := parseDue(args[i], time.Now())
+			if err != nil {
+				return opts, err
+			}
+			opts.due = &due
+		case "--clear-due":
+			opts.clearDue = true
+		case "--estimate":
+			i++
+			if i >= len(args) {
+				return opts, fmt.Errorf("--estimate requires a value")
+			}
+			estimate, err := task.ParseEstimate(args[i])
+			if err != nil {
+				return opts, err
+			}
+			opts.estimate = &estimate
+		case "--clear-estimate":
+			opts.clearEstimate = true
+		case "--recur":
+			i++
+			if i >= len(args) {
+				return opts, fmt.Errorf("--recur requires a value")
+			}
+			opts.recur = &args[i]
+		case "--clear-recur":
+			opts.clearRecur = true
+		case "--field":
+			i++
+			if i >= len(args) {
+				return opts, fmt.Errorf("--field requires a value")
+			}
+			var err error
+			if opts.fields, err = addFieldFlag(opts.fields, args[i]); err != nil {
+				return opts, err
+			}
+		case "--clear-field":
+			i++
+			if i >= len(args) {
+				return opts, fmt.Errorf("--clear-field requires a value")
+			}
+			opts.clearFields = append(opts.clearFields, args[i])
 		case "--blocks":
 			i++
 			if i >= len(args) {
@@ -113,55 +179,7 @@ func parseUpdateArgs(args []string) (updateOpts, error) {
 	return opts, nil
 }
 
-// rule3Result holds the output of a Rule 3 evaluation for cascade display.
-type rule3Result struct {
-	parentID    string
-	parentTitle string
-	result      task.TransitionResult
-	cascades    []task.CascadeChange
-}
-
-// autoCompleteParentIfTerminal checks if the original parent's remaining children are all
-// terminal after a child was reparented away. If so, it triggers auto-completion via
-// ApplySystemTransition: done if at least one child is done, cancelled if all children
-// are cancelled. Returns nil if auto-completion does not apply.
-func autoCompleteParentIfTerminal(tasks []task.Task, origParentID string, sm *task.StateMachine) *rule3Result {
-	action, shouldComplete := task.EvaluateParentCompletion(tasks, origParentID)
-	if !shouldComplete {
-		return nil
-	}
-
-	// Find the parent to apply the transition.
-	normalizedParentID := task.NormalizeID(origParentID)
-	parentIdx := -1
-	for i := range tasks {
-		if task.NormalizeID(tasks[i].ID) == normalizedParentID {
-			parentIdx = i
-			break
-		}
-	}
-	if parentIdx < 0 {
-		return nil
-	}
-
-	oldStatus := tasks[parentIdx].Status
-	_, cascades, err := sm.ApplySystemTransition(tasks, &tasks[parentIdx], action)
-	if err != nil {
-		return nil
-	}
-
-	return &rule3Result{
-		parentID:    tasks[parentIdx].ID,
-		parentTitle: tasks[parentIdx].Title,
-		result: task.TransitionResult{
-			OldStatus: oldStatus,
-			NewStatus: tasks[parentIdx].Status,
-		},
-		cascades: cascades,
-	}
-}
-
-// RunUpdate executes the update command: validates inputs, applies changes via the storage engine,
+// RunUpdate executes the update command: validates flags, applies changes via tick.Project,
 // and outputs the updated task details via the Formatter.
 func RunUpdate(dir string, fc FormatConfig, fmtr Formatter, args []string, stdout io.Writer) error {
 	opts, err := parseUpdateArgs(args)
@@ -174,7 +192,7 @@ func RunUpdate(dir string, fc FormatConfig, fmtr Formatter, args []string, stdou
 	}
 
 	if !opts.hasChanges() {
-		return fmt.Errorf("at least one flag is required: --title, --description, --clear-description, --priority, --type, --clear-type, --tags, --clear-tags, --refs, --clear-refs, --parent, --blocks")
+		return fmt.Errorf("at least one flag is required: --title, --description, --clear-description, --priority, --type, --clear-type, --tags, --clear-tags, --refs, --clear-refs, --field, --clear-field, --assignee, --unassign, --due, --clear-due, --estimate, --clear-estimate, --recur, --clear-recur, --parent, --blocks")
 	}
 
 	// Validate title if provided.
@@ -232,6 +250,36 @@ func RunUpdate(dir string, fc FormatConfig, fmtr Formatter, args []string, stdou
 		opts.refs = &deduped
 	}
 
+	// Validate assignee flags.
+	if opts.assignee != nil && opts.unassign {
+		return fmt.Errorf("--assignee and --unassign are mutually exclusive")
+	}
+	if opts.assignee != nil {
+		normalized := task.NormalizeAssignee(*opts.assignee)
+		if err := task.ValidateAssignee(normalized); err != nil {
+			return err
+		}
+		opts.assignee = &normalized
+	}
+
+	// Validate due flags.
+	if opts.due != nil && opts.clearDue {
+		return fmt.Errorf("--due and --clear-due are mutually exclusive")
+	}
+
+	// Validate estimate flags.
+	if opts.estimate != nil && opts.clearEstimate {
+		return fmt.Errorf("--estimate and --clear-estimate are mutually exclusive")
+	}
+
+	// Validate recurrence flags.
+	if opts.recur != nil && opts.clearRecur {
+		return fmt.Errorf("--recur and --clear-recur are mutually exclusive")
+	}
+	if opts.recur != nil && strings.TrimSpace(*opts.recur) == "" {
+		return fmt.Errorf("--recur cannot be empty; use --clear-recur to stop the task recurring")
+	}
+
 	// Validate priority if provided.
 	if opts.priority != nil {
 		if err := task.ValidatePriority(*opts.priority); err != nil {
@@ -239,185 +287,72 @@ func RunUpdate(dir string, fc FormatConfig, fmtr Formatter, args []string, stdou
 		}
 	}
 
-	store, err := openStore(dir, fc)
+	p, err := openProject(dir, fc)
 	if err != nil {
 		return err
 	}
-	defer store.Close()
+	defer p.Close()
 
-	// Resolve partial IDs via store.ResolveID.
-	opts.id, err = store.ResolveID(opts.id)
-	if err != nil {
-		return err
+	// The clear flags map to empty values, which clear the field.
+	if opts.clearDescription {
+		opts.description = new("")
 	}
-	if opts.parent != nil && *opts.parent != "" {
-		resolved, resolveErr := store.ResolveID(*opts.parent)
-		if resolveErr != nil {
-			return resolveErr
-		}
-		opts.parent = &resolved
+	if opts.clearType {
+		opts.taskType = new("")
 	}
-	for i, blockID := range opts.blocks {
-		opts.blocks[i], err = store.ResolveID(blockID)
-		if err != nil {
-			return err
-		}
+	if opts.clearTags {
+		opts.tags = &[]string{}
+	}
+	if opts.clearRefs {
+		opts.refs = &[]string{}
+	}
+	if opts.unassign {
+		opts.assignee = new("")
+	}
+	if opts.clearDue {
+		opts.due = &time.Time{}
+	}
+	if opts.clearEstimate {
+		opts.estimate = new(0.0)
+	}
+	if opts.clearRecur {
+		opts.recur = new("")
 	}
 
-	var updatedID string
-
-	// Rule 6: reopen of done parent when reparenting to it.
-	var r6Triggered bool
-	var r6ParentID string
-	var r6Result task.TransitionResult
-	var r6CascadeResult *CascadeResult
-
-	// Rule 3: auto-completion of original parent when reparenting away.
-	var r3Result task.TransitionResult
-	var r3ParentID string
-	var r3CascadeResult *CascadeResult
-
-	err = store.Mutate(func(tasks []task.Task) ([]task.Task, error) {
-		// Build ID set for reference validation with normalized keys.
-		idSet := make(map[string]bool, len(tasks))
-		for _, t := range tasks {
-			idSet[task.NormalizeID(t.ID)] = true
-		}
-
-		var sm task.StateMachine
-
-		// Validate referenced IDs exist and handle Rule 6 (reopen done parent).
-		if opts.parent != nil && *opts.parent != "" {
-			if *opts.parent == opts.id {
-				return nil, fmt.Errorf("task %s cannot be its own parent", opts.id)
-			}
-			if !idSet[*opts.parent] {
-				return nil, fmt.Errorf("task %q not found (referenced in --parent)", *opts.parent)
-			}
-			// Validate parent allows adding children (Rule 7: blocks cancelled parent).
-			// If parent is done, trigger reopen cascade (Rule 6).
-			r, c, reopened, valErr := validateAndReopenParent(tasks, *opts.parent, &sm)
-			if valErr != nil {
-				return nil, valErr
-			}
-			if reopened {
-				r6Triggered = true
-				r6Result = r
-				// Find parent title and build cascade result while tasks slice is valid.
-				normalizedParent := task.NormalizeID(*opts.parent)
-				var parentTitle string
-				for _, tk := range tasks {
-					if task.NormalizeID(tk.ID) == normalizedParent {
-						r6ParentID = tk.ID
-						parentTitle = tk.Title
-						break
-					}
-				}
-				cr := buildCascadeResult(r6ParentID, parentTitle, r, c, tasks)
-				r6CascadeResult = &cr
-			}
-		}
-		for _, blockID := range opts.blocks {
-			if !idSet[blockID] {
-				return nil, fmt.Errorf("task %q not found (referenced in --blocks)", blockID)
-			}
-		}
-
-		now := time.Now().UTC().Truncate(time.Second)
-
-		// Find and update the target task.
-		found := false
-		for i := range tasks {
-			if task.NormalizeID(tasks[i].ID) != opts.id {
-				continue
-			}
-			found = true
-
-			if opts.title != nil {
-				tasks[i].Title = task.TrimTitle(*opts.title)
-			}
-			if opts.clearDescription {
-				tasks[i].Description = ""
-			} else if opts.description != nil {
-				tasks[i].Description = task.TrimDescription(*opts.description)
-			}
-			if opts.priority != nil {
-				tasks[i].Priority = *opts.priority
-			}
-			if opts.clearType {
-				tasks[i].Type = ""
-			} else if opts.taskType != nil {
-				tasks[i].Type = *opts.taskType
-			}
-			if opts.clearTags {
-				tasks[i].Tags = nil
-			} else if opts.tags != nil {
-				tasks[i].Tags = *opts.tags
-			}
-			if opts.clearRefs {
-				tasks[i].Refs = nil
-			} else if opts.refs != nil {
-				tasks[i].Refs = *opts.refs
-			}
-
-			// Capture original parent before updating.
-			originalParent := tasks[i].Parent
-
-			if opts.parent != nil {
-				tasks[i].Parent = *opts.parent
-			}
-			tasks[i].Updated = now
-			updatedID = tasks[i].ID
-
-			// Evaluate Rule 3 on original parent if parent changed and original was non-empty.
-			if opts.parent != nil && originalParent != *opts.parent && originalParent != "" {
-				r3 := autoCompleteParentIfTerminal(tasks, originalParent, &sm)
-				if r3 != nil {
-					r3ParentID = r3.parentID
-					r3Result = r3.result
-					cr := buildCascadeResult(r3.parentID, r3.parentTitle, r3.result, r3.cascades, tasks)
-					r3CascadeResult = &cr
-				}
-			}
-
-			break
-		}
-
-		if !found {
-			return nil, fmt.Errorf("task '%s' not found", opts.id)
-		}
-
-		// For --blocks: add this task's ID to target tasks' blocked_by and refresh updated.
-		if len(opts.blocks) > 0 {
-			applyBlocks(tasks, opts.id, opts.blocks, now)
-
-			// Validate dependencies (cycle detection + child-blocked-by-parent + cancelled blocker) against full task list.
-			for _, blockID := range opts.blocks {
-				if err := sm.ValidateAddDep(tasks, blockID, opts.id); err != nil {
-					return nil, err
-				}
-			}
-		}
-
-		return tasks, nil
+	result, err := p.Update(opts.id, tick.UpdateOptions{
+		Title:       opts.title,
+		Description: opts.description,
+		Priority:    opts.priority,
+		Type:        opts.taskType,
+		Tags:        opts.tags,
+		Refs:        opts.refs,
+		Fields:      opts.fields,
+		ClearFields: opts.clearFields,
+		Assignee:    opts.assignee,
+		Due:         opts.due,
+		Estimate:    opts.estimate,
+		Recur:       opts.recur,
+		Parent:      opts.parent,
+		Blocks:      opts.blocks,
 	})
 	if err != nil {
 		return err
 	}
 
 	// Output updated task detail.
-	if err := outputMutationResult(store, updatedID, fc, fmtr, stdout); err != nil {
+	if err := outputMutationResult(p, result.Task.ID, fc, fmtr, stdout); err != nil {
 		return err
 	}
 
 	// Output Rule 6 cascade info (reopen of done parent).
-	if r6Triggered && !fc.Quiet {
-		outputTransitionOrCascade(stdout, fmtr, r6ParentID, string(r6Result.OldStatus), string(r6Result.NewStatus), r6CascadeResult)
+	if cr := result.ParentReopened; cr != nil && !fc.Quiet {
+		outputTransitionOrCascade(stdout, fmtr, cr.TaskID, cr.OldStatus, cr.NewStatus, cr)
 	}
 
 	// Output Rule 3 cascade info (auto-completion of original parent).
-	if r3CascadeResult != nil && !fc.Quiet {
-		outputTransitionOrCascade(stdout, fmtr, r3ParentID, string(r3Result.OldStatus), string(r3Result.NewStatus), r3CascadeResult)
+	if cr := result.ParentCompleted; cr != nil && !fc.Quiet {
+		outputTransitionOrCascade(stdout, fmtr, cr.TaskID, cr.OldStatus, cr.NewStatus, cr)
+		outputNextOccurrences(stdout, fmtr, cr)
 	}
 
 	return nil
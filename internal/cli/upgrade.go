@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"slices"
+)
+
+// RunUpgrade executes the upgrade command: migrates the project's task data to
+// the current format version. With dryRun, the steps are listed without writing.
+func RunUpgrade(dir string, fc FormatConfig, fmtr Formatter, dryRun bool, stdout io.Writer) error {
+	store, err := openStore(dir, fc)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	result, err := store.Upgrade(dryRun)
+	if err != nil {
+		return err
+	}
+
+	if fc.Quiet {
+		return nil
+	}
+	if len(result.Steps) == 0 {
+		fmt.Fprintln(stdout, fmtr.FormatMessage(fmt.Sprintf("Already at format version %d", result.To)))
+		return nil
+	}
+	verb := "Upgraded"
+	if dryRun {
+		verb = "Would upgrade"
+	}
+	msg := fmt.Sprintf("%s from format version %d to %d:", verb, result.From, result.To)
+	for _, step := range result.Steps {
+		msg += "\n  " + step
+	}
+	fmt.Fprintln(stdout, fmtr.FormatMessage(msg))
+	return nil
+}
+
+// handleUpgrade implements the upgrade subcommand.
+func (a *App) handleUpgrade(fc FormatConfig, fmtr Formatter, subArgs []string) error {
+	dir, err := a.Getwd()
+	if err != nil {
+		return fmt.Errorf("could not determine working directory: %w", err)
+	}
+	return RunUpgrade(dir, fc, fmtr, slices.Contains(subArgs, "--dry-run"), a.Stdout)
+}
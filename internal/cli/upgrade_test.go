@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/storage"
+	"github.com/leeovery/tick/internal/task"
+)
+
+func TestUpgrade(t *testing.T) {
+	now := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+	seed := []task.Task{
+		{ID: "tick-aaa111", Title: "Alpha", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+	}
+
+	t.Run("it records the current format version on init", func(t *testing.T) {
+		dir := t.TempDir()
+
+		if _, stderr, code := runTick(t, dir, "init"); code != 0 {
+			t.Fatalf("init failed: %s", stderr)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, ".tick", "format"))
+		want := fmt.Sprintf("%d\n", storage.FormatVersion)
+		if err != nil || string(data) != want {
+			t.Errorf("format = %q, %v; want %q", data, err, want)
+		}
+
+		stdout, _, code := runTick(t, dir, "upgrade")
+		if code != 0 || stdout != fmt.Sprintf("Already at format version %d\n", storage.FormatVersion) {
+			t.Errorf("exit = %d, stdout = %q", code, stdout)
+		}
+	})
+
+	t.Run("it upgrades a project without a format file", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, seed)
+		if err := os.Remove(filepath.Join(tickDir, "format")); err != nil {
+			t.Fatalf("failed to remove format file: %v", err)
+		}
+
+		stdout, stderr, code := runTick(t, dir, "upgrade", "--dry-run")
+		if code != 0 {
+			t.Fatalf("upgrade --dry-run failed: %s", stderr)
+		}
+		if !strings.HasPrefix(stdout, fmt.Sprintf("Would upgrade from format version 1 to %d:\n  1 → 2: ", storage.FormatVersion)) {
+			t.Errorf("stdout = %q", stdout)
+		}
+		if _, err := os.Stat(filepath.Join(tickDir, "format")); !os.IsNotExist(err) {
+			t.Error("dry run wrote the format file")
+		}
+
+		stdout, stderr, code = runTick(t, dir, "upgrade")
+		if code != 0 {
+			t.Fatalf("upgrade failed: %s", stderr)
+		}
+		if !strings.HasPrefix(stdout, fmt.Sprintf("Upgraded from format version 1 to %d:\n", storage.FormatVersion)) {
+			t.Errorf("stdout = %q", stdout)
+		}
+		if len(readPersistedTasks(t, tickDir)) != 1 {
+			t.Error("upgrade lost tasks")
+		}
+	})
+
+	t.Run("it refuses to change tasks until an older format is upgraded", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, seed)
+		if err := os.Remove(filepath.Join(tickDir, "format")); err != nil {
+			t.Fatalf("failed to remove format file: %v", err)
+		}
+
+		if _, stderr, code := runTick(t, dir, "list"); code != 0 {
+			t.Errorf("list failed on an older format: %s", stderr)
+		}
+		_, stderr, code := runTick(t, dir, "defer", "tick-aaa111", "3d")
+		want := fmt.Sprintf("Error: this project uses tick data format 1, but this version of tick writes format %d; run tick upgrade before changing tasks\n", storage.FormatVersion)
+		if code != 1 || stderr != want {
+			t.Errorf("exit = %d, stderr = %q", code, stderr)
+		}
+		if readPersistedTasks(t, tickDir)[0].DeferUntil != nil {
+			t.Error("refused defer wrote defer_until")
+		}
+
+		if _, stderr, code := runTick(t, dir, "upgrade"); code != 0 {
+			t.Fatalf("upgrade failed: %s", stderr)
+		}
+		if _, stderr, code := runTick(t, dir, "defer", "tick-aaa111", "3d"); code != 0 {
+			t.Errorf("defer after upgrade failed: %s", stderr)
+		}
+	})
+
+	t.Run("it refuses to work on a project in a newer format", func(t *testing.T) {
+		dir, tickDir := setupTickProjectWithTasks(t, seed)
+		newer := storage.FormatVersion + 1
+		if err := os.WriteFile(filepath.Join(tickDir, "format"), []byte(fmt.Sprintf("%d\n", newer)), 0644); err != nil {
+			t.Fatalf("failed to write format file: %v", err)
+		}
+
+		_, stderr, code := runTick(t, dir, "list")
+		want := fmt.Sprintf("Error: this project uses tick data format %d, but this version of tick supports up to format %d; upgrade tick to use it\n", newer, storage.FormatVersion)
+		if code != 1 || stderr != want {
+			t.Errorf("exit = %d, stderr = %q", code, stderr)
+		}
+	})
+}
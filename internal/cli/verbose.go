@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/leeovery/tick/internal/storage"
+	"github.com/leeovery/tick/tick"
 )
 
 // VerboseLogger writes verbose debug messages to a writer (intended for stderr).
@@ -27,13 +28,53 @@ func (vl *VerboseLogger) Log(msg string) {
 	fmt.Fprintf(vl.w, "verbose: %s\n", msg)
 }
 
-// storeOpts returns storage.StoreOption(s) that configure verbose logging
-// based on the FormatConfig. Returns nil if verbose is not enabled.
+// storeOpts returns storage.StoreOption(s) that configure verbose logging, the
+// journaled command line, the archived and as-of views, and the lock timeout
+// based on the FormatConfig.
+// Returns nil if none is set.
 func storeOpts(fc FormatConfig) []storage.StoreOption {
-	if fc.Logger == nil {
-		return nil
+	var opts []storage.StoreOption
+	if fc.IncludeArchived {
+		opts = append(opts, storage.WithArchived())
 	}
-	return []storage.StoreOption{
-		storage.WithVerbose(fc.Logger.Log),
+	if !fc.AsOf.IsZero() {
+		opts = append(opts, storage.WithAsOf(fc.AsOf))
 	}
+	if fc.Command != "" {
+		opts = append(opts, storage.WithCommand(fc.Command))
+	}
+	if fc.LockTimeout > 0 {
+		opts = append(opts, storage.WithLockTimeout(fc.LockTimeout))
+	}
+	if fc.Logger != nil {
+		opts = append(opts, storage.WithVerbose(fc.Logger.Log))
+	}
+	if fc.Config != nil {
+		opts = append(opts, storage.WithConfig(*fc.Config))
+	}
+	return opts
+}
+
+// projectOpts returns the tick.Option equivalents of storeOpts.
+func projectOpts(fc FormatConfig) []tick.Option {
+	var opts []tick.Option
+	if fc.IncludeArchived {
+		opts = append(opts, tick.WithArchived())
+	}
+	if !fc.AsOf.IsZero() {
+		opts = append(opts, tick.WithAsOf(fc.AsOf))
+	}
+	if fc.Command != "" {
+		opts = append(opts, tick.WithCommand(fc.Command))
+	}
+	if fc.LockTimeout > 0 {
+		opts = append(opts, tick.WithLockTimeout(fc.LockTimeout))
+	}
+	if fc.Logger != nil {
+		opts = append(opts, tick.WithVerbose(fc.Logger.Log))
+	}
+	if fc.Config != nil {
+		opts = append(opts, tick.WithConfig(*fc.Config))
+	}
+	return opts
 }
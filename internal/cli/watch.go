@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/leeovery/tick/tick"
+)
+
+// defaultWatchInterval is how often watch polls the task data when --interval
+// is not given.
+const defaultWatchInterval = time.Second
+
+// parseWatchArgs parses the watch command's --interval flag and passes the
+// remaining filter flags to parseListFlags.
+func parseWatchArgs(args []string) (ListFilter, time.Duration, error) {
+	interval := defaultWatchInterval
+	var filterArgs []string
+	for i := 0; i < len(args); i++ {
+		if args[i] != "--interval" {
+			filterArgs = append(filterArgs, args[i])
+			continue
+		}
+		if i+1 >= len(args) {
+			return ListFilter{}, 0, fmt.Errorf("--interval requires a value (e.g. 2s)")
+		}
+		i++
+		d, err := time.ParseDuration(args[i])
+		if err != nil || d <= 0 {
+			return ListFilter{}, 0, fmt.Errorf("invalid interval '%s': use a duration such as 500ms or 2s", args[i])
+		}
+		interval = d
+	}
+
+	filter, err := parseListFlags(filterArgs)
+	if err != nil {
+		return ListFilter{}, 0, err
+	}
+	return filter, interval, nil
+}
+
+// RunWatch executes the watch command: polls the task data every interval and
+// writes one JSON object per change to stdout, one per line, until ctx is done.
+// The output is always newline-delimited JSON, whatever the format flags.
+func RunWatch(ctx context.Context, dir string, fc FormatConfig, filter ListFilter, interval time.Duration, stdout io.Writer) error {
+	p, err := openProject(dir, fc)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+
+	enc := json.NewEncoder(stdout)
+	return p.Watch(ctx, filter, interval, func(e tick.Event) error {
+		return enc.Encode(e)
+	})
+}
+
+// handleWatch implements the watch subcommand. It runs until interrupted and
+// exits cleanly on SIGINT or SIGTERM.
+func (a *App) handleWatch(fc FormatConfig, subArgs []string) error {
+	dir, err := a.Getwd()
+	if err != nil {
+		return fmt.Errorf("could not determine working directory: %w", err)
+	}
+	filter, interval, err := parseWatchArgs(subArgs)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return RunWatch(ctx, dir, fc, filter, interval, a.Stdout)
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// syncBuffer is a bytes.Buffer safe for a writer goroutine and a reading test.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestWatch(t *testing.T) {
+	now := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+
+	t.Run("it streams one JSON event per line for each change", func(t *testing.T) {
+		tasks := []task.Task{
+			{ID: "tick-par111", Title: "Parent", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+			{ID: "tick-chd111", Title: "Child", Status: task.StatusOpen, Priority: 2, Parent: "tick-par111", Created: now, Updated: now},
+		}
+		dir, _ := setupTickProjectWithTasks(t, tasks)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		var out syncBuffer
+		done := make(chan error, 1)
+		go func() {
+			done <- RunWatch(ctx, dir, FormatConfig{}, ListFilter{}, 10*time.Millisecond, &out)
+		}()
+
+		// Let the baseline snapshot be taken before changing anything.
+		time.Sleep(50 * time.Millisecond)
+		if _, stderr, code := runTick(t, dir, "done", "tick-chd111"); code != 0 {
+			t.Fatalf("done failed: %s", stderr)
+		}
+		if _, stderr, code := runTick(t, dir, "note", "add", "tick-par111", "Wrapped up"); code != 0 {
+			t.Fatalf("note add failed: %s", stderr)
+		}
+
+		deadline := time.Now().Add(2 * time.Second)
+		for strings.Count(out.String(), "\n") < 3 && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		cancel()
+		if err := <-done; err != nil {
+			t.Fatalf("RunWatch returned error: %v", err)
+		}
+
+		var events []map[string]any
+		for line := range strings.SplitSeq(strings.TrimSpace(out.String()), "\n") {
+			var e map[string]any
+			if err := json.Unmarshal([]byte(line), &e); err != nil {
+				t.Fatalf("line %q is not JSON: %v", line, err)
+			}
+			events = append(events, e)
+		}
+		if len(events) != 3 {
+			t.Fatalf("got %d events, want 3:\n%s", len(events), out.String())
+		}
+
+		// The parent's auto-completion comes first in file order; polls may
+		// split the changes, so find each event by task and kind.
+		find := func(id, kind string) map[string]any {
+			for _, e := range events {
+				if e["id"] == id && e["event"] == kind {
+					return e
+				}
+			}
+			t.Fatalf("no %s event for %s in:\n%s", kind, id, out.String())
+			return nil
+		}
+		child := find("tick-chd111", "transition")
+		if child["from"] != "open" || child["to"] != "done" || child["auto"] != false {
+			t.Errorf("child transition = %v", child)
+		}
+		parent := find("tick-par111", "transition")
+		if parent["to"] != "done" || parent["auto"] != true {
+			t.Errorf("parent transition = %v", parent)
+		}
+		if note := find("tick-par111", "note_added"); note["note"] != "Wrapped up" {
+			t.Errorf("note event = %v", note)
+		}
+	})
+
+	t.Run("it rejects an invalid interval", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+		_, stderr, code := runTick(t, dir, "watch", "--interval", "soon")
+		if code != 1 || !strings.Contains(stderr, "invalid interval") {
+			t.Errorf("code = %d, stderr = %q", code, stderr)
+		}
+	})
+
+	t.Run("it rejects the ready filter", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+		_, stderr, code := runTick(t, dir, "watch", "--ready")
+		if code != 1 || !strings.Contains(stderr, "unknown flag") {
+			t.Errorf("code = %d, stderr = %q", code, stderr)
+		}
+	})
+}
@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/leeovery/tick/internal/storage"
+)
+
+const reviewWorkflowConfig = `workflow:
+  states:
+    - name: in_review
+      category: active
+    - name: blocked_external
+      category: waiting
+  commands:
+    - name: review
+      from: [in_progress]
+      to: in_review
+    - name: wait
+      from: [open, in_progress]
+      to: blocked_external
+    - name: resume
+      from: [blocked_external]
+      to: open
+    - name: done
+      from: [in_progress, in_review]
+      to: done
+`
+
+func TestWorkflowCommands(t *testing.T) {
+	setup := func(t *testing.T) (string, string) {
+		t.Helper()
+		dir, tickDir := setupTickProject(t)
+		if err := os.WriteFile(filepath.Join(tickDir, "config.yaml"), []byte(reviewWorkflowConfig), 0644); err != nil {
+			t.Fatalf("failed to write config.yaml: %v", err)
+		}
+		for _, title := range []string{"Review me", "Wait on vendor", "Untouched"} {
+			if _, stderr, code := runTick(t, dir, "create", title); code != 0 {
+				t.Fatalf("create failed: %s", stderr)
+			}
+		}
+		return dir, tickDir
+	}
+
+	t.Run("it runs project commands and derives ready and blocked from categories", func(t *testing.T) {
+		dir, tickDir := setup(t)
+		ids := []string{}
+		for _, tk := range readPersistedTasks(t, tickDir) {
+			ids = append(ids, tk.ID)
+		}
+
+		for _, args := range [][]string{{"start", ids[0]}, {"review", ids[0]}, {"wait", ids[1]}} {
+			if _, stderr, code := runTick(t, dir, args...); code != 0 {
+				t.Fatalf("%v failed: %s", args, stderr)
+			}
+		}
+
+		tasks := readPersistedTasks(t, tickDir)
+		if tasks[0].Status != "in_review" || tasks[1].Status != "blocked_external" {
+			t.Fatalf("statuses = %s, %s; want in_review, blocked_external", tasks[0].Status, tasks[1].Status)
+		}
+
+		stdout, _, _ := runTick(t, dir, "--quiet", "ready")
+		if stdout != ids[0]+"\n"+ids[2]+"\n" {
+			t.Errorf("ready = %q, want the in_review and open tasks", stdout)
+		}
+		stdout, _, _ = runTick(t, dir, "--quiet", "blocked")
+		if stdout != ids[1]+"\n" {
+			t.Errorf("blocked = %q, want the blocked_external task", stdout)
+		}
+		stdout, _, _ = runTick(t, dir, "--quiet", "list", "--status", "in_review")
+		if stdout != ids[0]+"\n" {
+			t.Errorf("list --status in_review = %q", stdout)
+		}
+	})
+
+	t.Run("it enforces the from-states of project and redefined commands", func(t *testing.T) {
+		dir, tickDir := setup(t)
+		id := readPersistedTasks(t, tickDir)[0].ID
+
+		_, stderr, code := runTick(t, dir, "review", id)
+		if code != 1 || !strings.Contains(stderr, "cannot review task "+id+" — status is 'open'") {
+			t.Errorf("review from open: code = %d, stderr = %q", code, stderr)
+		}
+		_, stderr, code = runTick(t, dir, "done", id)
+		if code != 1 || !strings.Contains(stderr, "cannot done task "+id) {
+			t.Errorf("done from open: code = %d, stderr = %q", code, stderr)
+		}
+		_, stderr, code = runTick(t, dir, "list", "--status", "reviewing")
+		if code != 1 || !strings.Contains(stderr, "invalid status 'reviewing': must be one of open, in_progress, in_review, blocked_external, done, cancelled") {
+			t.Errorf("list --status reviewing: code = %d, stderr = %q", code, stderr)
+		}
+	})
+
+	t.Run("it refuses project states in a project not yet upgraded to them", func(t *testing.T) {
+		dir, tickDir := setup(t)
+		id := readPersistedTasks(t, tickDir)[1].ID
+		if err := storage.WriteFormatVersion(tickDir, 2); err != nil {
+			t.Fatalf("failed to write format file: %v", err)
+		}
+
+		_, stderr, code := runTick(t, dir, "wait", id)
+		if code != 1 || !strings.Contains(stderr, "run tick upgrade before changing tasks") {
+			t.Errorf("wait: code = %d, stderr = %q", code, stderr)
+		}
+		if status := readPersistedTasks(t, tickDir)[1].Status; status != "open" {
+			t.Errorf("status = %s, want open", status)
+		}
+	})
+
+	t.Run("it counts project states in stats", func(t *testing.T) {
+		dir, tickDir := setup(t)
+		id := readPersistedTasks(t, tickDir)[1].ID
+		if _, stderr, code := runTick(t, dir, "wait", id); code != 0 {
+			t.Fatalf("wait failed: %s", stderr)
+		}
+
+		stdout, stderr, code := runTick(t, dir, "--json", "stats")
+		if code != 0 {
+			t.Fatalf("stats failed: %s", stderr)
+		}
+		var stats struct {
+			ByStatus json.RawMessage `json:"by_status"`
+			Workflow struct {
+				Ready   int `json:"ready"`
+				Blocked int `json:"blocked"`
+			} `json:"workflow"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &stats); err != nil {
+			t.Fatalf("invalid JSON %q: %v", stdout, err)
+		}
+		if got := string(stats.ByStatus); !strings.Contains(strings.Join(strings.Fields(got), ""), `"in_progress":0,"in_review":0,"blocked_external":1,"done":0`) {
+			t.Errorf("by_status = %s, want project states between in_progress and done", got)
+		}
+		if stats.Workflow.Ready != 2 || stats.Workflow.Blocked != 1 {
+			t.Errorf("workflow = %+v, want 2 ready and 1 blocked", stats.Workflow)
+		}
+
+		stdout, _, _ = runTick(t, dir, "--pretty", "stats")
+		if !strings.Contains(stdout, "  In Progress:  0\n  In Review:    0\n  Blocked External:  1\n  Done:") {
+			t.Errorf("pretty stats = %q", stdout)
+		}
+		stdout, _, _ = runTick(t, dir, "--toon", "stats")
+		if !strings.HasPrefix(stdout, "stats{total,open,in_progress,in_review,blocked_external,done,cancelled,ready,blocked,overdue}:\n  3,2,0,0,1,0,0,2,1,0\n") {
+			t.Errorf("toon stats = %q", stdout)
+		}
+	})
+
+	t.Run("it shows help for project commands", func(t *testing.T) {
+		dir, _ := setup(t)
+
+		stdout, stderr, code := runTick(t, dir, "help", "review")
+		if code != 0 {
+			t.Fatalf("help review failed: %s", stderr)
+		}
+		if !strings.HasPrefix(stdout, "Usage: tick review <task-id>\n\nTransitions a task from in_progress to in_review.\n") {
+			t.Errorf("help review = %q", stdout)
+		}
+
+		_, stderr, code = runTick(t, t.TempDir(), "review", "tick-aaa111")
+		if code != 1 || !strings.Contains(stderr, "Unknown command 'review'") {
+			t.Errorf("review outside the project: code = %d, stderr = %q", code, stderr)
+		}
+	})
+}
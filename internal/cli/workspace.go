@@ -0,0 +1,227 @@
+package cli
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/leeovery/tick/internal/task"
+	"github.com/leeovery/tick/tick"
+)
+
+// workspaceFlag switches list, ready, blocked, stats and search to query every
+// project of the .tick-workspace file above the working directory.
+const workspaceFlag = "--workspace"
+
+// workspaceMembers discovers the workspace above dir and returns the members a
+// query with filter covers. A --parent in workspace mode must be qualified, as
+// in billing/tick-a1b2; it limits the query to that project, and the returned
+// filter carries the bare parent ID.
+func workspaceMembers(dir string, filter ListFilter) ([]tick.WorkspaceMember, ListFilter, error) {
+	ws, err := tick.DiscoverWorkspace(dir)
+	if err != nil {
+		return nil, filter, err
+	}
+	if filter.Parent == "" {
+		return ws.Members, filter, nil
+	}
+
+	project, parent, ok := tick.SplitQualifiedID(filter.Parent)
+	if !ok {
+		return nil, filter, fmt.Errorf("--parent needs a qualified ID such as %s with %s", tick.QualifyID("billing", filter.Parent), workspaceFlag)
+	}
+	m, err := ws.Member(project)
+	if err != nil {
+		return nil, filter, err
+	}
+	filter.Parent = parent
+	return []tick.WorkspaceMember{m}, filter, nil
+}
+
+// RunWorkspaceList executes list, ready and blocked across a workspace: each
+// project is queried with filter and the IDs are qualified with the project
+// name. Tasks are ordered as in a single project, by priority (after tasks in a
+// started state of their project's workflow for ready lists), with ties kept in
+// workspace order.
+func RunWorkspaceList(dir string, fc FormatConfig, fmtr Formatter, filter ListFilter, stdout io.Writer) error {
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+	members, filter, err := workspaceMembers(dir, filter)
+	if err != nil {
+		return err
+	}
+	filter.Now = fc.now()
+
+	var tasks []task.Task
+	started := map[string]bool{}
+	for _, m := range members {
+		p, err := m.Open(projectOpts(memberFormat(fc))...)
+		if err != nil {
+			return fmt.Errorf("%s: %w", m.Name, err)
+		}
+		memberTasks, err := p.List(filter)
+		wf := p.Workflow()
+		p.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", m.Name, err)
+		}
+		for _, t := range memberTasks {
+			t.ID = tick.QualifyID(m.Name, t.ID)
+			started[t.ID] = wf.IsStarted(t.Status)
+			tasks = append(tasks, t)
+		}
+	}
+
+	slices.SortStableFunc(tasks, func(a, b task.Task) int {
+		if filter.Ready {
+			aStarted, bStarted := started[a.ID], started[b.ID]
+			if aStarted != bStarted {
+				if aStarted {
+					return -1
+				}
+				return 1
+			}
+		}
+		return cmp.Compare(a.Priority, b.Priority)
+	})
+	if filter.HasCount && len(tasks) > filter.Count {
+		tasks = tasks[:filter.Count]
+	}
+
+	if fc.Quiet {
+		for _, t := range tasks {
+			fmt.Fprintln(stdout, t.ID)
+		}
+		return nil
+	}
+
+	fmt.Fprintln(stdout, fmtr.FormatTaskList(tasks))
+	return nil
+}
+
+// RunWorkspaceStats executes stats across a workspace, summing the counts of
+// every project. Types and workflow states are listed in the order the projects
+// first declare or use them; estimate rollups are qualified with the project
+// name and merged, most remaining work first.
+func RunWorkspaceStats(dir string, fc FormatConfig, fmtr Formatter, stdout io.Writer) error {
+	if fc.Quiet {
+		return nil
+	}
+	members, _, err := workspaceMembers(dir, ListFilter{})
+	if err != nil {
+		return err
+	}
+
+	var total Stats
+	for _, m := range members {
+		store, err := openStore(m.Dir, memberFormat(fc))
+		if err != nil {
+			return fmt.Errorf("%s: %w", m.Name, err)
+		}
+		stats, err := queryStats(store, fc.now())
+		store.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", m.Name, err)
+		}
+
+		total.Total += stats.Total
+		total.Open += stats.Open
+		total.InProgress += stats.InProgress
+		total.Done += stats.Done
+		total.Cancelled += stats.Cancelled
+		for _, sc := range stats.ByState {
+			total.ByState = addStateCount(total.ByState, sc)
+		}
+		total.Ready += stats.Ready
+		total.Blocked += stats.Blocked
+		total.Overdue += stats.Overdue
+		for i, n := range stats.ByPriority {
+			total.ByPriority[i] += n
+		}
+		for _, tc := range stats.ByType {
+			total.ByType = addTypeCount(total.ByType, tc)
+		}
+		for _, ac := range stats.ByAssignee {
+			total.ByAssignee = addAssigneeCount(total.ByAssignee, ac)
+		}
+		total.Estimates.Total += stats.Estimates.Total
+		total.Estimates.Remaining += stats.Estimates.Remaining
+		for _, r := range stats.Rollups {
+			r.ID = tick.QualifyID(m.Name, r.ID)
+			total.Rollups = append(total.Rollups, r)
+		}
+	}
+	slices.SortStableFunc(total.Rollups, func(a, b TaskRollup) int { return cmp.Compare(b.Remaining, a.Remaining) })
+
+	fmt.Fprintln(stdout, fmtr.FormatStats(total))
+	return nil
+}
+
+// RunWorkspaceSearch executes search across a workspace. Each project's matches
+// keep their relevance order, projects follow workspace order, and the IDs are
+// qualified with the project name.
+func RunWorkspaceSearch(dir string, fc FormatConfig, fmtr Formatter, text string, filter ListFilter, stdout io.Writer) error {
+	members, filter, err := workspaceMembers(dir, filter)
+	if err != nil {
+		return err
+	}
+
+	var results []SearchResult
+	for _, m := range members {
+		store, err := openStore(m.Dir, memberFormat(fc))
+		if err != nil {
+			return fmt.Errorf("%s: %w", m.Name, err)
+		}
+		memberResults, err := searchStore(store, text, filter)
+		store.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", m.Name, err)
+		}
+		for _, r := range memberResults {
+			r.Task.ID = tick.QualifyID(m.Name, r.Task.ID)
+			results = append(results, r)
+		}
+	}
+	if filter.HasCount && len(results) > filter.Count {
+		results = results[:filter.Count]
+	}
+
+	if fc.Quiet {
+		for _, r := range results {
+			fmt.Fprintln(stdout, r.Task.ID)
+		}
+		return nil
+	}
+
+	fmt.Fprintln(stdout, fmtr.FormatSearchResults(results))
+	return nil
+}
+
+// resolveQualifiedID maps a workspace-qualified task ID such as
+// "billing/tick-a1b2" to the directory of its project, found through the
+// .tick-workspace file above dir, and the bare task ID. An unqualified ID is
+// returned with dir unchanged.
+func resolveQualifiedID(dir, id string) (string, string, error) {
+	project, taskID, ok := tick.SplitQualifiedID(id)
+	if !ok {
+		return dir, id, nil
+	}
+	ws, err := tick.DiscoverWorkspace(dir)
+	if err != nil {
+		return "", "", err
+	}
+	m, err := ws.Member(project)
+	if err != nil {
+		return "", "", err
+	}
+	return m.Dir, taskID, nil
+}
+
+// memberFormat returns fc for opening a workspace member, which loads its own
+// configuration rather than that of the project in the working directory.
+func memberFormat(fc FormatConfig) FormatConfig {
+	fc.Config = nil
+	return fc
+}
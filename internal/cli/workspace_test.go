@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/storage"
+	"github.com/leeovery/tick/internal/task"
+	"github.com/leeovery/tick/tick"
+)
+
+// setupWorkspace creates a workspace of two projects, billing and search, and
+// returns the workspace root.
+func setupWorkspace(t *testing.T) string {
+	t.Helper()
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	projects := map[string][]task.Task{
+		"services/billing": {
+			{ID: "tick-aaa111", Title: "Invoice totals", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+			{ID: "tick-aaa222", Title: "Refund flow", Status: task.StatusInProgress, Priority: 1, Created: now, Updated: now},
+		},
+		"services/search": {
+			{ID: "tick-bbb111", Title: "Invoice index", Status: task.StatusOpen, Priority: 0, Created: now, Updated: now},
+			{ID: "tick-bbb222", Title: "Ranking", Status: task.StatusDone, Priority: 3, Created: now, Updated: now, Closed: &now},
+		},
+	}
+
+	root := t.TempDir()
+	for dir, tasks := range projects {
+		tickDir := filepath.Join(root, dir, ".tick")
+		if err := os.MkdirAll(tickDir, 0755); err != nil {
+			t.Fatalf("failed to create %s: %v", tickDir, err)
+		}
+		data, err := storage.MarshalJSONL(tasks)
+		if err != nil {
+			t.Fatalf("failed to marshal tasks: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(tickDir, "tasks.jsonl"), data, 0644); err != nil {
+			t.Fatalf("failed to write tasks.jsonl: %v", err)
+		}
+		if err := storage.WriteFormatVersion(tickDir, storage.FormatVersion); err != nil {
+			t.Fatalf("failed to write format file: %v", err)
+		}
+	}
+	file := "services/billing\nservices/search\n"
+	if err := os.WriteFile(filepath.Join(root, tick.WorkspaceFileName), []byte(file), 0644); err != nil {
+		t.Fatalf("failed to write workspace file: %v", err)
+	}
+	return root
+}
+
+func TestWorkspace(t *testing.T) {
+	t.Run("it lists tasks across projects with qualified IDs", func(t *testing.T) {
+		root := setupWorkspace(t)
+
+		stdout, stderr, code := runTick(t, root, "list", "--workspace", "--quiet")
+		if code != 0 {
+			t.Fatalf("exit = %d, stderr = %q", code, stderr)
+		}
+		want := "search/tick-bbb111\nbilling/tick-aaa222\nbilling/tick-aaa111\nsearch/tick-bbb222\n"
+		if stdout != want {
+			t.Errorf("stdout = %q, want %q", stdout, want)
+		}
+
+		stdout, _, _ = runTick(t, root, "ready", "--workspace", "--quiet", "--count", "2")
+		if stdout != "billing/tick-aaa222\nsearch/tick-bbb111\n" {
+			t.Errorf("ready --workspace = %q", stdout)
+		}
+	})
+
+	t.Run("it works from inside a member project", func(t *testing.T) {
+		root := setupWorkspace(t)
+
+		stdout, _, code := runTick(t, filepath.Join(root, "services", "billing"), "blocked", "--workspace", "--quiet")
+		if code != 0 || stdout != "" {
+			t.Errorf("exit = %d, stdout = %q", code, stdout)
+		}
+		stdout, _, _ = runTick(t, filepath.Join(root, "services", "billing"), "list", "--quiet")
+		if stdout != "tick-aaa222\ntick-aaa111\n" {
+			t.Errorf("list without --workspace = %q, want the member only", stdout)
+		}
+	})
+
+	t.Run("it sums stats across projects", func(t *testing.T) {
+		root := setupWorkspace(t)
+
+		stdout, stderr, code := runTick(t, root, "stats", "--workspace", "--json")
+		if code != 0 {
+			t.Fatalf("exit = %d, stderr = %q", code, stderr)
+		}
+		for _, want := range []string{`"total": 4`, `"open": 2`, `"in_progress": 1`, `"done": 1`, `"ready": 3`} {
+			if !strings.Contains(stdout, want) {
+				t.Errorf("stats --workspace missing %s: %s", want, stdout)
+			}
+		}
+	})
+
+	t.Run("it searches across projects", func(t *testing.T) {
+		root := setupWorkspace(t)
+
+		stdout, stderr, code := runTick(t, root, "search", "invoice", "--workspace", "--quiet")
+		if code != 0 {
+			t.Fatalf("exit = %d, stderr = %q", code, stderr)
+		}
+		if stdout != "billing/tick-aaa111\nsearch/tick-bbb111\n" {
+			t.Errorf("stdout = %q", stdout)
+		}
+	})
+
+	t.Run("it requires a qualified parent in workspace mode", func(t *testing.T) {
+		root := setupWorkspace(t)
+
+		_, stderr, code := runTick(t, root, "list", "--workspace", "--parent", "tick-aaa111")
+		if code != 1 || !strings.Contains(stderr, "--parent needs a qualified ID such as billing/tick-aaa111") {
+			t.Errorf("exit = %d, stderr = %q", code, stderr)
+		}
+		stdout, stderr, code := runTick(t, root, "list", "--workspace", "--parent", "billing/tick-aaa111", "--quiet")
+		if code != 0 || stdout != "" {
+			t.Errorf("exit = %d, stdout = %q, stderr = %q", code, stdout, stderr)
+		}
+	})
+
+	t.Run("it shows and transitions tasks by qualified ID", func(t *testing.T) {
+		root := setupWorkspace(t)
+
+		stdout, stderr, code := runTick(t, root, "show", "search/bbb111", "--quiet")
+		if code != 0 || stdout != "tick-bbb111\n" {
+			t.Errorf("show = %q, exit %d, stderr %q", stdout, code, stderr)
+		}
+
+		_, stderr, code = runTick(t, root, "start", "search/bbb111")
+		if code != 0 {
+			t.Fatalf("start failed: %s", stderr)
+		}
+		tasks := readPersistedTasks(t, filepath.Join(root, "services", "search", ".tick"))
+		if tasks[0].Status != task.StatusInProgress {
+			t.Errorf("status = %s, want in_progress", tasks[0].Status)
+		}
+
+		_, stderr, code = runTick(t, root, "done", "web/tick-aaa111")
+		if code != 1 || !strings.Contains(stderr, "no project 'web' in workspace (projects: billing, search)") {
+			t.Errorf("exit = %d, stderr = %q", code, stderr)
+		}
+	})
+
+	t.Run("it errors outside a workspace", func(t *testing.T) {
+		dir, _ := setupTickProject(t)
+
+		_, stderr, code := runTick(t, dir, "list", "--workspace")
+		if code != 1 || !strings.Contains(stderr, "not in a tick workspace") {
+			t.Errorf("exit = %d, stderr = %q", code, stderr)
+		}
+	})
+}
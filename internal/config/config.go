@@ -0,0 +1,223 @@
+// Package config loads and edits a tick project's configuration file,
+// .tick/config.yaml, which overrides the built-in defaults for new tasks, the
+// allowed types, tag limits, the ID prefix and the lock timeout, and extends
+// the task workflow.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the configuration file under .tick/. It is optional: a project
+// without one uses Default.
+const FileName = "config.yaml"
+
+// DefaultLockTimeout is how long commands wait for the .tick lock unless the
+// project configures lock_timeout.
+const DefaultLockTimeout = 5 * time.Second
+
+// Config is a project's configuration. Keys missing from the file keep their
+// Default values.
+type Config struct {
+	// Create holds the defaults applied to new tasks.
+	Create CreateDefaults `yaml:"create"`
+	// Types are the allowed task types, in the order help and stats list them.
+	Types []TypeDef `yaml:"types"`
+	// Tags holds the tag limits.
+	Tags TagLimits `yaml:"tags"`
+	// IDPrefix starts the IDs of new tasks. Existing tasks keep their IDs.
+	IDPrefix string `yaml:"id_prefix"`
+	// LockTimeout is how long to wait for the .tick lock. The --lock-timeout
+	// flag and TICK_LOCK_TIMEOUT take precedence.
+	LockTimeout time.Duration `yaml:"lock_timeout"`
+	// Workflow adds states and transition commands to the built-in ones.
+	Workflow WorkflowConfig `yaml:"workflow"`
+}
+
+// CreateDefaults are the values tick create uses for fields it is not given.
+type CreateDefaults struct {
+	Priority int      `yaml:"priority"`
+	Type     string   `yaml:"type"`
+	Tags     []string `yaml:"tags"`
+}
+
+// TypeDef declares a task type. In config.yaml a type is either just its name
+// or a mapping with a name, a description shown by tick help create, and a
+// priority that tasks of the type default to.
+type TypeDef struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	// Priority, when set, overrides create.priority for tasks of this type.
+	Priority *int `yaml:"priority,omitempty"`
+}
+
+// UnmarshalYAML decodes a type given as a bare name or as a mapping, rejecting
+// unknown keys in the mapping.
+func (t *TypeDef) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*t = TypeDef{Name: value.Value}
+		return nil
+	}
+	if value.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: a type must be a name or a mapping with name, description and priority", value.Line)
+	}
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		if key := value.Content[i].Value; !slices.Contains([]string{"name", "description", "priority"}, key) {
+			return fmt.Errorf("line %d: field %s not found in type", value.Content[i].Line, key)
+		}
+	}
+	type plain TypeDef
+	return value.Decode((*plain)(t))
+}
+
+// defaultTypes are the types of a project without configuration.
+var defaultTypes = []TypeDef{
+	{Name: "bug", Description: "Something is broken"},
+	{Name: "feature", Description: "New functionality"},
+	{Name: "task", Description: "General work"},
+	{Name: "chore", Description: "Maintenance and housekeeping"},
+}
+
+// TagLimits bound the tags of a task.
+type TagLimits struct {
+	MaxPerTask int `yaml:"max_per_task"`
+	MaxLength  int `yaml:"max_length"`
+}
+
+// Default returns the configuration of a project without a config file.
+func Default() Config {
+	rules := task.DefaultRules()
+	return Config{
+		Create:      CreateDefaults{Priority: rules.DefaultPriority},
+		Types:       slices.Clone(defaultTypes),
+		Tags:        TagLimits{MaxPerTask: rules.MaxTags, MaxLength: rules.MaxTagLength},
+		IDPrefix:    rules.IDPrefix,
+		LockTimeout: DefaultLockTimeout,
+	}
+}
+
+// Rules returns the task rules the configuration sets.
+func (c Config) Rules() task.Rules {
+	rules := task.Rules{
+		IDPrefix:        c.IDPrefix,
+		DefaultPriority: c.Create.Priority,
+		Types:           c.TypeNames(),
+		MaxTags:         c.Tags.MaxPerTask,
+		MaxTagLength:    c.Tags.MaxLength,
+		Workflow:        c.Workflow.build(),
+	}
+	for _, t := range c.Types {
+		if t.Priority != nil {
+			if rules.TypePriority == nil {
+				rules.TypePriority = map[string]int{}
+			}
+			rules.TypePriority[t.Name] = *t.Priority
+		}
+	}
+	return rules
+}
+
+// TypeNames returns the names of the allowed types, in order.
+func (c Config) TypeNames() []string {
+	names := make([]string, len(c.Types))
+	for i, t := range c.Types {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// typePattern matches a task type: kebab-case like tags.
+var typePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Validate checks that every value is usable and consistent: the default type
+// and tags of new tasks must themselves be allowed.
+func (c Config) Validate() error {
+	if len(c.Types) == 0 {
+		return errors.New("types must list at least one type")
+	}
+	names := c.TypeNames()
+	for i, t := range c.Types {
+		if !typePattern.MatchString(t.Name) {
+			return fmt.Errorf("types: invalid type %q: must be kebab-case (lowercase alphanumeric segments separated by single hyphens)", t.Name)
+		}
+		if slices.Contains(names[:i], t.Name) {
+			return fmt.Errorf("types: %q is listed twice", t.Name)
+		}
+		if t.Priority != nil {
+			if err := task.ValidatePriority(*t.Priority); err != nil {
+				return fmt.Errorf("types: %s: %w", t.Name, err)
+			}
+		}
+	}
+	if c.Tags.MaxPerTask < 1 {
+		return fmt.Errorf("tags.max_per_task must be at least 1, got %d", c.Tags.MaxPerTask)
+	}
+	if c.Tags.MaxLength < 1 {
+		return fmt.Errorf("tags.max_length must be at least 1, got %d", c.Tags.MaxLength)
+	}
+	if err := task.ValidateIDPrefix(c.IDPrefix); err != nil {
+		return fmt.Errorf("id_prefix: %w", err)
+	}
+	if c.LockTimeout < time.Millisecond {
+		return fmt.Errorf("lock_timeout must be a duration such as 5s or 1m, got %s", c.LockTimeout)
+	}
+	if err := c.validateWorkflow(); err != nil {
+		return err
+	}
+
+	rules := c.Rules()
+	if err := task.ValidatePriority(c.Create.Priority); err != nil {
+		return fmt.Errorf("create.priority: %w", err)
+	}
+	if err := rules.ValidateType(c.Create.Type); err != nil {
+		return fmt.Errorf("create.type: %w", err)
+	}
+	if err := rules.ValidateTags(c.Create.Tags); err != nil {
+		return fmt.Errorf("create.tags: %w", err)
+	}
+	return nil
+}
+
+// Load reads and validates the configuration of the project in tickDir. A
+// missing file yields Default.
+func Load(tickDir string) (Config, error) {
+	cfg, err := read(tickDir)
+	if err != nil {
+		return Config{}, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, fmt.Errorf("invalid .tick/%s: %w", FileName, err)
+	}
+	return cfg, nil
+}
+
+// read decodes the config file in tickDir over Default, without validating the
+// result. Unknown keys are rejected.
+func read(tickDir string) (Config, error) {
+	cfg := Default()
+	data, err := os.ReadFile(filepath.Join(tickDir, FileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return Config{}, fmt.Errorf("failed to read .tick/%s: %w", FileName, err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return Config{}, fmt.Errorf("invalid .tick/%s: %w", FileName, err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,265 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, tickDir, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(tickDir, FileName), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", FileName, err)
+	}
+}
+
+func readConfigFile(t *testing.T, tickDir string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(tickDir, FileName))
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", FileName, err)
+	}
+	return string(data)
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("it returns the defaults when there is no config file", func(t *testing.T) {
+		cfg, err := Load(t.TempDir())
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		if cfg.Create.Priority != 2 || cfg.IDPrefix != "tick-" || cfg.LockTimeout != 5*time.Second {
+			t.Errorf("cfg = %+v, want defaults", cfg)
+		}
+		if strings.Join(cfg.TypeNames(), ",") != "bug,feature,task,chore" {
+			t.Errorf("Types = %v, want the built-in types", cfg.Types)
+		}
+		if cfg.Tags.MaxPerTask != 10 || cfg.Tags.MaxLength != 30 {
+			t.Errorf("Tags = %+v, want 10 and 30", cfg.Tags)
+		}
+	})
+
+	t.Run("it returns the defaults for an empty config file", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfigFile(t, dir, "# nothing set yet\n")
+
+		cfg, err := Load(dir)
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		if cfg.IDPrefix != "tick-" {
+			t.Errorf("IDPrefix = %q, want tick-", cfg.IDPrefix)
+		}
+	})
+
+	t.Run("it overrides only the keys the file sets", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfigFile(t, dir, "create:\n  priority: 1\n  type: spike\ntypes: [bug, spike]\nid_prefix: api-\nlock_timeout: 30s\n")
+
+		cfg, err := Load(dir)
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		if cfg.Create.Priority != 1 || cfg.Create.Type != "spike" || cfg.IDPrefix != "api-" || cfg.LockTimeout != 30*time.Second {
+			t.Errorf("cfg = %+v, want the file's values", cfg)
+		}
+		if cfg.Tags.MaxPerTask != 10 {
+			t.Errorf("Tags.MaxPerTask = %d, want the default 10", cfg.Tags.MaxPerTask)
+		}
+		rules := cfg.Rules()
+		if err := rules.ValidateType("spike"); err != nil {
+			t.Errorf("Rules().ValidateType(spike): %v", err)
+		}
+		if err := rules.ValidateType("feature"); err == nil {
+			t.Error("Rules().ValidateType(feature) should fail when types omits it")
+		}
+	})
+
+	t.Run("it reads types given as mappings with a description and priority", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfigFile(t, dir, "types:\n  - name: incident\n    description: Production is down\n    priority: 0\n  - story\n")
+
+		cfg, err := Load(dir)
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		if len(cfg.Types) != 2 || cfg.Types[0].Description != "Production is down" || cfg.Types[1].Name != "story" {
+			t.Fatalf("Types = %+v", cfg.Types)
+		}
+		rules := cfg.Rules()
+		if got := rules.PriorityFor("incident"); got != 0 {
+			t.Errorf("PriorityFor(incident) = %d, want 0", got)
+		}
+		if got := rules.PriorityFor("story"); got != 2 {
+			t.Errorf("PriorityFor(story) = %d, want the create.priority 2", got)
+		}
+	})
+
+	t.Run("it adds workflow states and commands to the built-in ones", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfigFile(t, dir, "workflow:\n  states:\n    - {name: in_review, category: active}\n    - {name: blocked_external, category: waiting}\n"+
+			"  commands:\n    - {name: review, from: [in_progress], to: in_review}\n    - {name: done, from: [in_review], to: done}\n")
+
+		cfg, err := Load(dir)
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		wf := cfg.Rules().Workflow
+		if got := strings.Join(wf.StateNames(), ","); got != "open,in_progress,in_review,blocked_external,done,cancelled" {
+			t.Errorf("states = %s", got)
+		}
+		review, ok := wf.Command("review")
+		if !ok || review.To != "in_review" {
+			t.Errorf("review = %+v, %v", review, ok)
+		}
+		done, _ := wf.Command("done")
+		if len(done.From) != 1 || done.From[0] != "in_review" {
+			t.Errorf("done.From = %v, want the configured [in_review]", done.From)
+		}
+		if _, ok := wf.Command("start"); !ok {
+			t.Error("built-in start command missing")
+		}
+	})
+
+	invalid := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"an unknown key", "colour: blue\n", "field colour not found"},
+		{"a misplaced key", "create:\n  max_length: 3\n", "field max_length not found"},
+		{"an empty type list", "types: []\n", "types must list at least one type"},
+		{"a type that is not kebab-case", "types: [Bug]\n", "must be kebab-case"},
+		{"a repeated type", "types: [bug, bug]\n", "listed twice"},
+		{"an unknown key in a type", "types:\n  - name: bug\n    colour: red\n", "field colour not found in type"},
+		{"an out-of-range type priority", "types:\n  - name: bug\n    priority: 5\n", "types: bug: priority"},
+		{"a default type that is not allowed", "types: [bug]\ncreate:\n  type: chore\n", "create.type: invalid type"},
+		{"an out-of-range default priority", "create:\n  priority: 7\n", "create.priority"},
+		{"too many default tags", "tags:\n  max_per_task: 1\ncreate:\n  tags: [a, b]\n", "create.tags: too many tags"},
+		{"a zero tag limit", "tags:\n  max_length: 0\n", "tags.max_length must be at least 1"},
+		{"an invalid ID prefix", "id_prefix: tick\n", "id_prefix: invalid ID prefix"},
+		{"a non-duration lock timeout", "lock_timeout: soon\n", "invalid .tick/config.yaml"},
+		{"a zero lock timeout", "lock_timeout: 0s\n", "lock_timeout must be"},
+		{"a state that is not snake_case", "workflow:\n  states:\n    - {name: In-Review, category: active}\n", "must be snake_case"},
+		{"a redefined built-in state", "workflow:\n  states:\n    - {name: done, category: active}\n", `"done" is a built-in state`},
+		{"a state without a known category", "workflow:\n  states:\n    - {name: in_review, category: paused}\n", `invalid category "paused"`},
+		{"a repeated state", "workflow:\n  states:\n    - {name: in_review, category: active}\n    - {name: in_review, category: waiting}\n", `"in_review" is listed twice`},
+		{"a command to an unknown state", "workflow:\n  states:\n    - {name: in_review, category: active}\n  commands:\n    - {name: review, from: [in_progress], to: reviewing}\n", `review: unknown state "reviewing"`},
+		{"a command without from states", "workflow:\n  states:\n    - {name: in_review, category: active}\n  commands:\n    - {name: review, to: in_review}\n", "review: from must list at least one state"},
+		{"a command that is not kebab-case", "workflow:\n  states:\n    - {name: in_review, category: active}\n  commands:\n    - {name: Review, from: [open], to: in_review}\n", "must be kebab-case"},
+		{"a built-in command moving elsewhere", "workflow:\n  states:\n    - {name: in_review, category: active}\n  commands:\n    - {name: done, from: [open], to: in_review}\n", "done is built in and must move to done"},
+		{"a command named like a tick command", "workflow:\n  states:\n    - {name: in_review, category: active}\n  commands:\n    - {name: archive, from: [done], to: in_review}\n", `"archive" is a tick command`},
+		{"a redefined release command", "workflow:\n  states:\n    - {name: in_review, category: active}\n  commands:\n    - {name: release, from: [in_review], to: open}\n", "release is used by tick claim"},
+	}
+	for _, tt := range invalid {
+		t.Run("it rejects "+tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeConfigFile(t, dir, tt.content)
+
+			_, err := Load(dir)
+			if err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+			if !strings.HasPrefix(err.Error(), "invalid .tick/config.yaml") || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %q, want to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSet(t *testing.T) {
+	t.Run("it creates the config file with the setting", func(t *testing.T) {
+		dir := t.TempDir()
+
+		if err := Set(dir, "create.priority", "1"); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+
+		if got := readConfigFile(t, dir); got != "create:\n  priority: 1\n" {
+			t.Errorf("config.yaml = %q", got)
+		}
+	})
+
+	t.Run("it keeps comments and other settings", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfigFile(t, dir, "# team settings\nid_prefix: api- # short IDs\ncreate:\n  priority: 3\n")
+
+		if err := Set(dir, "types", "bug, feature,spike"); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+		if err := Set(dir, "create.priority", "1"); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+
+		want := "# team settings\nid_prefix: api- # short IDs\ncreate:\n  priority: 1\ntypes: [bug, feature, spike]\n"
+		if got := readConfigFile(t, dir); got != want {
+			t.Errorf("config.yaml = %q, want %q", got, want)
+		}
+		cfg, err := Load(dir)
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		if cfg.IDPrefix != "api-" || cfg.Create.Priority != 1 || len(cfg.Types) != 3 {
+			t.Errorf("cfg = %+v", cfg)
+		}
+	})
+
+	t.Run("it keeps the description and priority of types that stay", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfigFile(t, dir, "types:\n  - name: incident\n    description: Production is down\n    priority: 0\n  - story\n")
+
+		if err := Set(dir, "types", "story,incident,spike"); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+
+		want := "types:\n  - story\n  - name: incident\n    description: Production is down\n    priority: 0\n  - spike\n"
+		if got := readConfigFile(t, dir); got != want {
+			t.Errorf("config.yaml = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("it normalizes values before writing", func(t *testing.T) {
+		dir := t.TempDir()
+
+		if err := Set(dir, "create.type", "Bug"); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+		if err := Set(dir, "lock_timeout", "90s"); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+
+		key, err := LookupKey("lock_timeout")
+		if err != nil {
+			t.Fatalf("LookupKey: %v", err)
+		}
+		cfg, err := Load(dir)
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		if cfg.Create.Type != "bug" || key.Get(cfg) != "1m30s" {
+			t.Errorf("create.type = %q, lock_timeout = %q", cfg.Create.Type, key.Get(cfg))
+		}
+	})
+
+	t.Run("it refuses a value that makes the configuration invalid", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfigFile(t, dir, "create:\n  type: chore\n")
+
+		err := Set(dir, "types", "bug,feature")
+		if err == nil || !strings.Contains(err.Error(), "create.type") {
+			t.Fatalf("error = %v, want a create.type error", err)
+		}
+		if got := readConfigFile(t, dir); got != "create:\n  type: chore\n" {
+			t.Errorf("config.yaml changed to %q", got)
+		}
+	})
+
+	t.Run("it rejects an unknown key", func(t *testing.T) {
+		err := Set(t.TempDir(), "priority", "1")
+		if err == nil || !strings.Contains(err.Error(), "unknown config key 'priority'") {
+			t.Errorf("error = %v, want unknown config key", err)
+		}
+	})
+}
@@ -0,0 +1,313 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+	"gopkg.in/yaml.v3"
+)
+
+// Key is a configuration setting addressable by tick config get and set.
+type Key struct {
+	// Name is the dotted path of the setting in config.yaml, such as
+	// "create.priority".
+	Name string
+	// Description says what the setting controls.
+	Description string
+	// list marks settings whose value is a list, given to Set comma-separated
+	// and written as a YAML sequence.
+	list bool
+	// tag is the YAML tag of a scalar value.
+	tag string
+	get func(c Config) string
+	set func(c *Config, value string) error
+	// encode, when set, builds the YAML node of the value instead of the
+	// list and tag fields.
+	encode func(c Config) *yaml.Node
+}
+
+// Keys lists every configuration setting, in file order.
+var Keys = []Key{
+	{
+		Name:        "create.priority",
+		Description: "Priority of tasks created without --priority (0-4)",
+		tag:         "!!int",
+		get:         func(c Config) string { return strconv.Itoa(c.Create.Priority) },
+		set: func(c *Config, v string) error {
+			p, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("invalid priority '%s': must be 0-4", v)
+			}
+			c.Create.Priority = p
+			return nil
+		},
+	},
+	{
+		Name:        "create.type",
+		Description: "Type of tasks created without --type (empty for none)",
+		tag:         "!!str",
+		get:         func(c Config) string { return c.Create.Type },
+		set: func(c *Config, v string) error {
+			c.Create.Type = task.NormalizeType(v)
+			return nil
+		},
+	},
+	{
+		Name:        "create.tags",
+		Description: "Tags added to tasks created without --tags",
+		list:        true,
+		get:         func(c Config) string { return strings.Join(c.Create.Tags, ",") },
+		set: func(c *Config, v string) error {
+			c.Create.Tags = task.DeduplicateTags(splitList(v))
+			return nil
+		},
+	},
+	{
+		Name:        "types",
+		Description: "Allowed task types",
+		list:        true,
+		get:         func(c Config) string { return strings.Join(c.TypeNames(), ",") },
+		set: func(c *Config, v string) error {
+			// Types that stay keep their description and priority, unless
+			// those are just the built-in ones, which need not be written out.
+			old := c.Types
+			c.Types = nil
+			for _, name := range splitList(v) {
+				def := TypeDef{Name: task.NormalizeType(name)}
+				if i := slices.IndexFunc(old, func(t TypeDef) bool { return t.Name == def.Name }); i >= 0 && !slices.Contains(defaultTypes, old[i]) {
+					def = old[i]
+				}
+				c.Types = append(c.Types, def)
+			}
+			return nil
+		},
+		encode: encodeTypes,
+	},
+	{
+		Name:        "tags.max_per_task",
+		Description: "Maximum number of tags per task",
+		tag:         "!!int",
+		get:         func(c Config) string { return strconv.Itoa(c.Tags.MaxPerTask) },
+		set: func(c *Config, v string) error {
+			return setInt(&c.Tags.MaxPerTask, v)
+		},
+	},
+	{
+		Name:        "tags.max_length",
+		Description: "Maximum length of a tag in characters",
+		tag:         "!!int",
+		get:         func(c Config) string { return strconv.Itoa(c.Tags.MaxLength) },
+		set: func(c *Config, v string) error {
+			return setInt(&c.Tags.MaxLength, v)
+		},
+	},
+	{
+		Name:        "id_prefix",
+		Description: "Prefix of new task IDs, ending in '-'",
+		tag:         "!!str",
+		get:         func(c Config) string { return c.IDPrefix },
+		set: func(c *Config, v string) error {
+			c.IDPrefix = strings.ToLower(strings.TrimSpace(v))
+			return nil
+		},
+	},
+	{
+		Name:        "lock_timeout",
+		Description: "How long to wait for the .tick lock",
+		tag:         "!!str",
+		get:         func(c Config) string { return c.LockTimeout.String() },
+		set: func(c *Config, v string) error {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("invalid lock timeout '%s': use a duration such as 30s or 2m", v)
+			}
+			c.LockTimeout = d
+			return nil
+		},
+	},
+}
+
+// splitList splits a comma-separated value, trimming whitespace and dropping
+// empty items.
+func splitList(v string) []string {
+	var items []string
+	for part := range strings.SplitSeq(v, ",") {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			items = append(items, trimmed)
+		}
+	}
+	return items
+}
+
+// setInt parses v into *n.
+func setInt(n *int, v string) error {
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return fmt.Errorf("invalid number '%s'", v)
+	}
+	*n = i
+	return nil
+}
+
+// LookupKey returns the setting named name.
+func LookupKey(name string) (Key, error) {
+	names := make([]string, len(Keys))
+	for i, k := range Keys {
+		if k.Name == name {
+			return k, nil
+		}
+		names[i] = k.Name
+	}
+	return Key{}, fmt.Errorf("unknown config key '%s' (keys: %s)", name, strings.Join(names, ", "))
+}
+
+// Get returns the value of the setting in c, with lists comma-separated.
+func (k Key) Get(c Config) string {
+	return k.get(c)
+}
+
+// Set changes the setting named name in the config file of tickDir to value,
+// creating the file if needed. The resulting configuration must be valid.
+// Comments and the other settings in the file are kept.
+func Set(tickDir, name, value string) error {
+	key, err := LookupKey(name)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := read(tickDir)
+	if err != nil {
+		return err
+	}
+	if err := key.set(&cfg, value); err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	path := filepath.Join(tickDir, FileName)
+	var doc yaml.Node
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read .tick/%s: %w", FileName, err)
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("invalid .tick/%s: %w", FileName, err)
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("invalid .tick/%s: expected a mapping of settings", FileName)
+	}
+
+	setNode(root, strings.Split(key.Name, "."), key.node(cfg))
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to encode .tick/%s: %w", FileName, err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("failed to encode .tick/%s: %w", FileName, err)
+	}
+	return writeAtomic(path, buf.Bytes())
+}
+
+// node returns the YAML node holding the setting's value in c.
+func (k Key) node(c Config) *yaml.Node {
+	if k.encode != nil {
+		return k.encode(c)
+	}
+	if !k.list {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: k.tag, Value: k.get(c)}
+	}
+	seq := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+	for _, item := range splitList(k.get(c)) {
+		seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: item})
+	}
+	return seq
+}
+
+// encodeTypes builds the node of the types setting: a flow sequence of names
+// when no type has a description or priority, and otherwise a block sequence in
+// which those types are mappings.
+func encodeTypes(c Config) *yaml.Node {
+	seq := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+	for _, t := range c.Types {
+		if t.Description == "" && t.Priority == nil {
+			seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: t.Name})
+			continue
+		}
+		seq.Style = 0
+		def := &yaml.Node{Kind: yaml.MappingNode}
+		setNode(def, []string{"name"}, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: t.Name})
+		if t.Description != "" {
+			setNode(def, []string{"description"}, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: t.Description})
+		}
+		if t.Priority != nil {
+			setNode(def, []string{"priority"}, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(*t.Priority)})
+		}
+		seq.Content = append(seq.Content, def)
+	}
+	return seq
+}
+
+// setNode sets the value at path in the mapping m to value, adding mappings
+// for missing path segments.
+func setNode(m *yaml.Node, path []string, value *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value != path[0] {
+			continue
+		}
+		if len(path) == 1 {
+			m.Content[i+1] = value
+			return
+		}
+		if m.Content[i+1].Kind != yaml.MappingNode {
+			m.Content[i+1] = &yaml.Node{Kind: yaml.MappingNode}
+		}
+		setNode(m.Content[i+1], path[1:], value)
+		return
+	}
+
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Value: path[0]}
+	if len(path) == 1 {
+		m.Content = append(m.Content, keyNode, value)
+		return
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	m.Content = append(m.Content, keyNode, child)
+	setNode(child, path[1:], value)
+}
+
+// writeAtomic writes data to path through a temporary file in the same
+// directory, so readers never see a partial file.
+func writeAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// WorkflowConfig adds states and transition commands to the built-in ones:
+// the statuses open, in_progress, done and cancelled and the commands start,
+// done, cancel and reopen.
+type WorkflowConfig struct {
+	// States are the project's own states, listed after in_progress and before
+	// done.
+	States []StateDef `yaml:"states"`
+	// Commands are run as tick <name> <id>. A command named like a built-in one
+	// changes the states it applies from.
+	Commands []CommandDef `yaml:"commands"`
+}
+
+// StateDef declares a workflow state.
+type StateDef struct {
+	Name string `yaml:"name"`
+	// Category is active, waiting or terminal; see task.Category.
+	Category string `yaml:"category"`
+}
+
+// CommandDef declares a transition command moving tasks in any of the From
+// states to the To state.
+type CommandDef struct {
+	Name string   `yaml:"name"`
+	From []string `yaml:"from"`
+	To   string   `yaml:"to"`
+}
+
+// CLICommands are the names of tick's own commands, other than the built-in
+// workflow commands start, done, cancel and reopen. A workflow command cannot
+// take one, as tick would run its own command instead.
+var CLICommands = []string{
+	"init", "create", "list", "show", "update", "remove", "note", "dep", "critical-path",
+	"batch", "ready", "blocked", "mine", "claim", "heartbeat", "defer", "undefer", "search",
+	"watch", "archive", "unarchive", "storage", "lock", "config", "upgrade", "undo", "redo",
+	"journal", "stats", "rebuild", "doctor", "migrate", "merge-driver", "version", "help",
+}
+
+// statePattern matches a state name: snake_case like in_progress.
+var statePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+
+// build returns the task workflow: the built-in states and commands with the
+// project's added, and built-in commands redefined.
+func (w WorkflowConfig) build() task.Workflow {
+	wf := task.DefaultWorkflow()
+	done := slices.IndexFunc(wf.States, func(s task.State) bool { return s.Name == task.StatusDone })
+	var added []task.State
+	for _, s := range w.States {
+		added = append(added, task.State{Name: task.Status(s.Name), Category: task.Category(s.Category)})
+	}
+	wf.States = slices.Insert(wf.States, done, added...)
+
+	for _, def := range w.Commands {
+		cmd := task.Command{Name: def.Name, To: task.Status(def.To)}
+		for _, from := range def.From {
+			cmd.From = append(cmd.From, task.Status(from))
+		}
+		if i := slices.IndexFunc(wf.Commands, func(b task.Command) bool { return b.Name == def.Name }); i >= 0 {
+			wf.Commands[i] = cmd
+			continue
+		}
+		wf.Commands = append(wf.Commands, cmd)
+	}
+	return wf
+}
+
+// validateWorkflow checks that states are new, well-named and categorized, and
+// that commands move between known states. Commands cannot take the name of a
+// CLI command, built-in commands may only change their from-states, and
+// release, which tick claim uses internally, cannot be redefined.
+func (c Config) validateWorkflow() error {
+	var states []string
+	for _, s := range c.Workflow.States {
+		if !statePattern.MatchString(s.Name) {
+			return fmt.Errorf("workflow.states: invalid state %q: must be snake_case (lowercase alphanumeric words separated by underscores)", s.Name)
+		}
+		if task.IsBuiltinStatus(task.Status(s.Name)) {
+			return fmt.Errorf("workflow.states: %q is a built-in state", s.Name)
+		}
+		if slices.Contains(states, s.Name) {
+			return fmt.Errorf("workflow.states: %q is listed twice", s.Name)
+		}
+		switch task.Category(s.Category) {
+		case task.CategoryActive, task.CategoryWaiting, task.CategoryTerminal:
+		default:
+			return fmt.Errorf("workflow.states: %s: invalid category %q: must be active, waiting or terminal", s.Name, s.Category)
+		}
+		states = append(states, s.Name)
+	}
+
+	builtin := task.DefaultWorkflow()
+	wf := c.Workflow.build()
+	var names []string
+	for _, def := range c.Workflow.Commands {
+		if !typePattern.MatchString(def.Name) {
+			return fmt.Errorf("workflow.commands: invalid command %q: must be kebab-case (lowercase alphanumeric segments separated by single hyphens)", def.Name)
+		}
+		if slices.Contains(names, def.Name) {
+			return fmt.Errorf("workflow.commands: %q is listed twice", def.Name)
+		}
+		names = append(names, def.Name)
+		if slices.Contains(CLICommands, def.Name) {
+			return fmt.Errorf("workflow.commands: %q is a tick command; choose another name", def.Name)
+		}
+		if def.Name == "release" {
+			return errors.New("workflow.commands: release is used by tick claim and cannot be redefined")
+		}
+		if len(def.From) == 0 {
+			return fmt.Errorf("workflow.commands: %s: from must list at least one state", def.Name)
+		}
+		for _, s := range append(slices.Clone(def.From), def.To) {
+			if _, ok := wf.State(task.Status(s)); !ok {
+				return fmt.Errorf("workflow.commands: %s: unknown state %q", def.Name, s)
+			}
+		}
+		if slices.Contains(def.From, def.To) {
+			return fmt.Errorf("workflow.commands: %s: cannot move from %s to itself", def.Name, def.To)
+		}
+		if b, ok := builtin.Command(def.Name); ok && string(b.To) != def.To {
+			return fmt.Errorf("workflow.commands: %s is built in and must move to %s", def.Name, b.To)
+		}
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/leeovery/tick/internal/storage"
 	_ "modernc.org/sqlite"
 )
 
@@ -20,17 +21,16 @@ type CacheStalenessCheck struct{}
 // Run executes the cache staleness check. It computes the SHA256 hash of
 // tasks.jsonl and compares it to the hash stored in cache.db's metadata table.
 func (c *CacheStalenessCheck) Run(_ context.Context, tickDir string) []CheckResult {
-	jsonlPath := filepath.Join(tickDir, "tasks.jsonl")
 	cachePath := filepath.Join(tickDir, "cache.db")
 
-	// Step 1: Read tasks.jsonl.
-	rawJSONL, err := os.ReadFile(jsonlPath)
+	// Step 1: Read tasks.jsonl (or the task files, in their hashed JSONL form).
+	rawJSONL, err := storage.ReadRawTasks(tickDir)
 	if err != nil {
 		return []CheckResult{{
 			Name:       "Cache",
 			Passed:     false,
 			Severity:   SeverityError,
-			Details:    fmt.Sprintf("tasks.jsonl not found or unreadable: %v", err),
+			Details:    fmt.Sprintf("%s not found or unreadable: %v", storage.DetectLayout(tickDir).SourceName(), err),
 			Suggestion: "Run tick init or verify .tick directory",
 		}}
 	}
@@ -517,4 +517,17 @@ func TestCacheStalenessCheck(t *testing.T) {
 			t.Errorf("unexpected Details: %s", results[0].Details)
 		}
 	})
+
+	t.Run("it hashes task files in the files layout", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		writeTaskFile(t, tickDir, "tick-aaa111.json", []byte("{\n  \"id\": \"tick-aaa111\"\n}\n"))
+		createCacheWithHash(t, tickDir, computeTestHash([]byte("{\"id\":\"tick-aaa111\"}\n")))
+
+		check := &CacheStalenessCheck{}
+		results := check.Run(ctxWithTickDir(tickDir), tickDir)
+
+		if len(results) != 1 || !results[0].Passed {
+			t.Errorf("expected passing result, got %+v", results)
+		}
+	})
 }
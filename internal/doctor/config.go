@@ -0,0 +1,32 @@
+package doctor
+
+import (
+	"context"
+
+	"github.com/leeovery/tick/internal/config"
+)
+
+// ConfigCheck validates .tick/config.yaml: that it parses, has no unknown keys,
+// and holds usable, consistent values. A project without the file passes, as it
+// uses the defaults. An invalid file is an error because every tick command
+// refuses to open the project until it is fixed. It is read-only and never
+// modifies any files.
+type ConfigCheck struct{}
+
+// Run executes the config check, returning a single result.
+func (c *ConfigCheck) Run(_ context.Context, tickDir string) []CheckResult {
+	if _, err := config.Load(tickDir); err != nil {
+		return []CheckResult{{
+			Name:       "Config",
+			Passed:     false,
+			Severity:   SeverityError,
+			Details:    err.Error(),
+			Suggestion: "Fix .tick/config.yaml by hand or with `tick config set`; `tick config list` shows the keys",
+		}}
+	}
+
+	return []CheckResult{{
+		Name:   "Config",
+		Passed: true,
+	}}
+}
@@ -0,0 +1,72 @@
+package doctor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigCheck(t *testing.T) {
+	writeConfig := func(t *testing.T, tickDir, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(tickDir, "config.yaml"), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write config.yaml: %v", err)
+		}
+	}
+
+	t.Run("it returns passing result when there is no config file", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+
+		check := &ConfigCheck{}
+		results := check.Run(context.Background(), tickDir)
+
+		if len(results) != 1 || !results[0].Passed || results[0].Name != "Config" {
+			t.Errorf("results = %+v, want one passing Config result", results)
+		}
+	})
+
+	t.Run("it returns passing result for a valid config file", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		writeConfig(t, tickDir, "# project settings\ntypes: [bug, feature, spike]\ncreate:\n  type: spike\n")
+
+		check := &ConfigCheck{}
+		results := check.Run(context.Background(), tickDir)
+
+		if len(results) != 1 || !results[0].Passed {
+			t.Errorf("results = %+v, want one passing result", results)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"unknown key", "colour: blue\n", "field colour not found"},
+		{"malformed YAML", "types: [bug\n", "invalid .tick/config.yaml"},
+		{"default type not allowed", "types: [bug]\ncreate:\n  type: chore\n", "create.type"},
+		{"invalid ID prefix", "id_prefix: Tick\n", "id_prefix"},
+	}
+	for _, tt := range tests {
+		t.Run("it returns failing result for "+tt.name, func(t *testing.T) {
+			tickDir := setupTickDir(t)
+			writeConfig(t, tickDir, tt.content)
+
+			check := &ConfigCheck{}
+			results := check.Run(context.Background(), tickDir)
+
+			if len(results) != 1 {
+				t.Fatalf("expected 1 result, got %d", len(results))
+			}
+			r := results[0]
+			if r.Passed || r.Severity != SeverityError {
+				t.Errorf("result = %+v, want a failing error", r)
+			}
+			if !strings.Contains(r.Details, tt.want) {
+				t.Errorf("Details = %q, want to contain %q", r.Details, tt.want)
+			}
+		})
+	}
+}
@@ -6,10 +6,19 @@ import (
 	"strings"
 )
 
-// idOccurrence records a single occurrence of an ID with its original case and line number.
+// idOccurrence records a single occurrence of an ID with its original case and
+// location ("line N", or the task file path in the files layout).
 type idOccurrence struct {
 	originalID string
-	lineNumber int
+	location   string
+}
+
+// occurrenceLocation formats where an ID occurrence was found.
+func occurrenceLocation(line JSONLine) string {
+	if line.File != "" {
+		return line.File
+	}
+	return fmt.Sprintf("line %d", line.LineNum)
 }
 
 // DuplicateIdCheck validates that no two tasks in tasks.jsonl share the same ID
@@ -54,7 +63,7 @@ func (c *DuplicateIdCheck) Run(ctx context.Context, tickDir string) []CheckResul
 		}
 		groups[key] = append(groups[key], idOccurrence{
 			originalID: idStr,
-			lineNumber: line.LineNum,
+			location:   occurrenceLocation(line),
 		})
 	}
 
@@ -67,7 +76,7 @@ func (c *DuplicateIdCheck) Run(ctx context.Context, tickDir string) []CheckResul
 
 		parts := make([]string, len(occurrences))
 		for i, occ := range occurrences {
-			parts[i] = fmt.Sprintf("%s (line %d)", occ.originalID, occ.lineNumber)
+			parts[i] = fmt.Sprintf("%s (%s)", occ.originalID, occ.location)
 		}
 
 		details := fmt.Sprintf("Duplicate ID %s: %s", key, strings.Join(parts, ", "))
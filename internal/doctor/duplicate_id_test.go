@@ -81,6 +81,23 @@ func TestDuplicateIdCheck(t *testing.T) {
 		}
 	})
 
+	t.Run("it reports task file paths for duplicates in the files layout", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		writeTaskFile(t, tickDir, "tick-abc123.json", []byte("{\"id\":\"tick-abc123\"}\n"))
+		writeTaskFile(t, tickDir, "tick-copy11.json", []byte("{\"id\":\"tick-abc123\"}\n"))
+
+		check := &DuplicateIdCheck{}
+		results := check.Run(ctxWithTickDir(tickDir), tickDir)
+
+		if len(results) != 1 {
+			t.Fatalf("expected 1 result, got %d", len(results))
+		}
+		want := "Duplicate ID tick-abc123: tick-abc123 (tasks/tick-abc123.json), tick-abc123 (tasks/tick-copy11.json)"
+		if results[0].Details != want {
+			t.Errorf("expected details %q, got %q", want, results[0].Details)
+		}
+	})
+
 	t.Run("it reports more than two duplicates of the same ID in a single result", func(t *testing.T) {
 		tickDir := setupTickDir(t)
 		content := "{\"id\":\"tick-abc123\"}\n{\"id\":\"tick-abc123\"}\n{\"id\":\"tick-abc123\"}\n"
@@ -6,11 +6,13 @@ import (
 	"regexp"
 )
 
-// idFormatRegex matches valid tick IDs: tick- followed by exactly 6 lowercase hex chars.
-var idFormatRegex = regexp.MustCompile(`^tick-[0-9a-f]{6}$`)
+// idFormatRegex matches valid tick IDs: an ID prefix such as tick- followed by
+// exactly 6 lowercase hex chars. Any valid prefix is accepted, since tasks keep
+// their IDs when a project's id_prefix changes.
+var idFormatRegex = regexp.MustCompile(`^[a-z][a-z0-9]{0,15}-[0-9a-f]{6}$`)
 
 // IdFormatCheck validates that every task in tasks.jsonl has an id field matching
-// the required pattern tick-{6 hex}. Each invalid ID is reported as an individual
+// the required pattern {prefix}-{6 hex}. Each invalid ID is reported as an individual
 // error with its 1-based line number. It is read-only and never modifies the file.
 type IdFormatCheck struct{}
 
@@ -38,7 +40,7 @@ func (c *IdFormatCheck) Run(ctx context.Context, tickDir string) []CheckResult {
 				Name:       "ID format",
 				Passed:     false,
 				Severity:   SeverityError,
-				Details:    fmt.Sprintf("Line %d: missing id field", line.LineNum),
+				Details:    fmt.Sprintf("%s: missing id field", line.Location()),
 				Suggestion: "Manual fix required",
 			})
 			continue
@@ -52,7 +54,7 @@ func (c *IdFormatCheck) Run(ctx context.Context, tickDir string) []CheckResult {
 				Name:       "ID format",
 				Passed:     false,
 				Severity:   SeverityError,
-				Details:    fmt.Sprintf("Line %d: invalid ID '%s' — expected format tick-{6 hex}", line.LineNum, display),
+				Details:    fmt.Sprintf("%s: invalid ID '%s' — expected format {prefix}-{6 hex}, such as tick-a1b2c3", line.Location(), display),
 				Suggestion: "Manual fix required",
 			})
 			continue
@@ -64,7 +66,7 @@ func (c *IdFormatCheck) Run(ctx context.Context, tickDir string) []CheckResult {
 				Name:       "ID format",
 				Passed:     false,
 				Severity:   SeverityError,
-				Details:    fmt.Sprintf("Line %d: invalid ID '%s' — expected format tick-{6 hex}", line.LineNum, idStr),
+				Details:    fmt.Sprintf("%s: invalid ID '%s' — expected format {prefix}-{6 hex}, such as tick-a1b2c3", line.Location(), idStr),
 				Suggestion: "Manual fix required",
 			})
 			continue
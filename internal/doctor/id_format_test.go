@@ -49,7 +49,7 @@ func TestIdFormatCheck(t *testing.T) {
 		if results[0].Passed {
 			t.Error("expected Passed false for empty ID")
 		}
-		if results[0].Details != "Line 1: invalid ID '' — expected format tick-{6 hex}" {
+		if results[0].Details != "Line 1: invalid ID '' — expected format {prefix}-{6 hex}, such as tick-a1b2c3" {
 			t.Errorf("unexpected Details: %s", results[0].Details)
 		}
 	})
@@ -85,7 +85,7 @@ func TestIdFormatCheck(t *testing.T) {
 		if results[0].Passed {
 			t.Error("expected Passed false for uppercase hex")
 		}
-		if results[0].Details != "Line 1: invalid ID 'tick-A1B2C3' — expected format tick-{6 hex}" {
+		if results[0].Details != "Line 1: invalid ID 'tick-A1B2C3' — expected format {prefix}-{6 hex}, such as tick-a1b2c3" {
 			t.Errorf("unexpected Details: %s", results[0].Details)
 		}
 	})
@@ -103,7 +103,7 @@ func TestIdFormatCheck(t *testing.T) {
 		if results[0].Passed {
 			t.Error("expected Passed false for mixed-case hex")
 		}
-		if results[0].Details != "Line 1: invalid ID 'tick-a1B2c3' — expected format tick-{6 hex}" {
+		if results[0].Details != "Line 1: invalid ID 'tick-a1B2c3' — expected format {prefix}-{6 hex}, such as tick-a1b2c3" {
 			t.Errorf("unexpected Details: %s", results[0].Details)
 		}
 	})
@@ -121,7 +121,7 @@ func TestIdFormatCheck(t *testing.T) {
 		if results[0].Passed {
 			t.Error("expected Passed false for extra hex chars")
 		}
-		if results[0].Details != "Line 1: invalid ID 'tick-a1b2c3d4' — expected format tick-{6 hex}" {
+		if results[0].Details != "Line 1: invalid ID 'tick-a1b2c3d4' — expected format {prefix}-{6 hex}, such as tick-a1b2c3" {
 			t.Errorf("unexpected Details: %s", results[0].Details)
 		}
 	})
@@ -139,14 +139,29 @@ func TestIdFormatCheck(t *testing.T) {
 		if results[0].Passed {
 			t.Error("expected Passed false for fewer hex chars")
 		}
-		if results[0].Details != "Line 1: invalid ID 'tick-a1b' — expected format tick-{6 hex}" {
+		if results[0].Details != "Line 1: invalid ID 'tick-a1b' — expected format {prefix}-{6 hex}, such as tick-a1b2c3" {
 			t.Errorf("unexpected Details: %s", results[0].Details)
 		}
 	})
 
-	t.Run("it returns failing result for wrong prefix (e.g., task-a1b2c3)", func(t *testing.T) {
+	t.Run("it returns passing result for a custom prefix (e.g., task-a1b2c3)", func(t *testing.T) {
 		tickDir := setupTickDir(t)
-		writeJSONL(t, tickDir, []byte("{\"id\":\"task-a1b2c3\"}\n"))
+		writeJSONL(t, tickDir, []byte("{\"id\":\"task-a1b2c3\"}\n{\"id\":\"ops2-d4e5f6\"}\n"))
+
+		check := &IdFormatCheck{}
+		results := check.Run(ctxWithTickDir(tickDir), tickDir)
+
+		if len(results) != 1 {
+			t.Fatalf("expected 1 result, got %d", len(results))
+		}
+		if !results[0].Passed {
+			t.Errorf("expected Passed true for custom prefixes, got %s", results[0].Details)
+		}
+	})
+
+	t.Run("it returns failing result for invalid prefix (e.g., 9ab-a1b2c3)", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		writeJSONL(t, tickDir, []byte("{\"id\":\"9ab-a1b2c3\"}\n"))
 
 		check := &IdFormatCheck{}
 		results := check.Run(ctxWithTickDir(tickDir), tickDir)
@@ -155,9 +170,9 @@ func TestIdFormatCheck(t *testing.T) {
 			t.Fatalf("expected 1 result, got %d", len(results))
 		}
 		if results[0].Passed {
-			t.Error("expected Passed false for wrong prefix")
+			t.Error("expected Passed false for invalid prefix")
 		}
-		if results[0].Details != "Line 1: invalid ID 'task-a1b2c3' — expected format tick-{6 hex}" {
+		if results[0].Details != "Line 1: invalid ID '9ab-a1b2c3' — expected format {prefix}-{6 hex}, such as tick-a1b2c3" {
 			t.Errorf("unexpected Details: %s", results[0].Details)
 		}
 	})
@@ -175,7 +190,7 @@ func TestIdFormatCheck(t *testing.T) {
 		if results[0].Passed {
 			t.Error("expected Passed false for missing prefix")
 		}
-		if results[0].Details != "Line 1: invalid ID 'a1b2c3' — expected format tick-{6 hex}" {
+		if results[0].Details != "Line 1: invalid ID 'a1b2c3' — expected format {prefix}-{6 hex}, such as tick-a1b2c3" {
 			t.Errorf("unexpected Details: %s", results[0].Details)
 		}
 	})
@@ -8,23 +8,43 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/leeovery/tick/internal/storage"
 )
 
-// JSONLine represents a single line from tasks.jsonl.
+// JSONLine represents a single line from tasks.jsonl, or a single task file in
+// the files storage layout.
 type JSONLine struct {
-	// LineNum is the 1-based line number in the file.
+	// LineNum is the 1-based line number in the file. Always 1 for task files.
 	LineNum int
-	// Raw is the original line text.
+	// File is the task file path relative to .tick/ (e.g. "tasks/tick-a1b2c3.json")
+	// in the files layout, or empty for tasks.jsonl.
+	File string
+	// Raw is the original line text, or the whole content of a task file.
 	Raw string
 	// Parsed is the parsed JSON map, or nil if parsing failed.
 	Parsed map[string]any
 }
 
-// ScanJSONLines reads tasks.jsonl from the given tick directory and returns
-// all non-blank lines with their line numbers and parse results.
-// Lines that fail JSON parsing have Parsed set to nil (Raw is still populated).
+// Location describes where the line came from for check details:
+// "Line N" for tasks.jsonl, or the task file path in the files layout.
+func (l JSONLine) Location() string {
+	if l.File != "" {
+		return l.File
+	}
+	return fmt.Sprintf("Line %d", l.LineNum)
+}
+
+// ScanJSONLines reads the tasks of the given tick directory and returns all
+// non-blank lines of tasks.jsonl (or all non-empty task files in the files
+// layout) with their locations and parse results.
+// Entries that fail JSON parsing have Parsed set to nil (Raw is still populated).
 // Returns error only for file-open failures.
 func ScanJSONLines(tickDir string) ([]JSONLine, error) {
+	if storage.DetectLayout(tickDir) == storage.LayoutFiles {
+		return scanTaskFiles(tickDir)
+	}
+
 	jsonlPath := filepath.Join(tickDir, "tasks.jsonl")
 
 	f, err := os.Open(jsonlPath)
@@ -65,6 +85,42 @@ func ScanJSONLines(tickDir string) ([]JSONLine, error) {
 	return lines, nil
 }
 
+// scanTaskFiles reads every task file of a files-layout tick directory as one
+// JSONLine each, in file name order. Empty files are skipped.
+func scanTaskFiles(tickDir string) ([]JSONLine, error) {
+	names, err := storage.TaskFileNames(tickDir)
+	if err != nil {
+		return nil, fmt.Errorf("read tasks directory: %w", err)
+	}
+
+	lines := []JSONLine{}
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(tickDir, name))
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", name, err)
+		}
+		text := strings.TrimSpace(string(data))
+		if text == "" {
+			continue
+		}
+
+		line := JSONLine{
+			LineNum: 1,
+			File:    name,
+			Raw:     text,
+		}
+
+		var obj map[string]any
+		if err := json.Unmarshal([]byte(text), &obj); err == nil {
+			line.Parsed = obj
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
 // jsonLinesKeyType is an unexported type for the context key used to
 // pass pre-scanned JSONL lines to checks.
 type jsonLinesKeyType struct{}
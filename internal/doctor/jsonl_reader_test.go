@@ -2,9 +2,24 @@ package doctor
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
+// writeTaskFile writes a task file into .tick/tasks/, creating the directory and
+// thereby switching the .tick directory to the files layout.
+func writeTaskFile(t *testing.T, tickDir, name string, content []byte) {
+	t.Helper()
+	dir := filepath.Join(tickDir, "tasks")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create tasks dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+		t.Fatalf("failed to write task file: %v", err)
+	}
+}
+
 func TestScanJSONLines(t *testing.T) {
 	t.Run("it returns error for missing file", func(t *testing.T) {
 		tickDir := setupTickDir(t)
@@ -30,6 +45,31 @@ func TestScanJSONLines(t *testing.T) {
 		}
 	})
 
+	t.Run("it reads one entry per task file in the files layout", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		writeTaskFile(t, tickDir, "tick-bbb222.json", []byte("{\n  \"id\": \"tick-bbb222\"\n}\n"))
+		writeTaskFile(t, tickDir, "tick-aaa111.json", []byte("{\n  \"id\": \"tick-aaa111\"\n}\n"))
+		writeTaskFile(t, tickDir, "notes.txt", []byte("ignored"))
+
+		lines, err := ScanJSONLines(tickDir)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(lines) != 2 {
+			t.Fatalf("expected 2 lines, got %d", len(lines))
+		}
+		if lines[0].File != "tasks/tick-aaa111.json" || lines[1].File != "tasks/tick-bbb222.json" {
+			t.Errorf("expected files in name order, got %q and %q", lines[0].File, lines[1].File)
+		}
+		if lines[0].Parsed == nil || lines[0].Parsed["id"] != "tick-aaa111" {
+			t.Errorf("expected parsed id tick-aaa111, got %v", lines[0].Parsed)
+		}
+		if lines[1].Location() != "tasks/tick-bbb222.json" {
+			t.Errorf("expected Location to be the file path, got %q", lines[1].Location())
+		}
+	})
+
 	t.Run("it skips blank lines and maintains correct line numbers", func(t *testing.T) {
 		tickDir := setupTickDir(t)
 		// Line 1: valid JSON, Line 2: blank, Line 3: blank, Line 4: valid JSON
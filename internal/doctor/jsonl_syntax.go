@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
-// JsonlSyntaxCheck validates that every non-blank line in tasks.jsonl is
-// syntactically valid JSON. It reports each malformed line individually with
-// its 1-based line number. It is read-only and never modifies the file.
+// JsonlSyntaxCheck validates that every non-blank line in tasks.jsonl (or every
+// task file in the files layout) is syntactically valid JSON. It reports each
+// malformed line individually with its 1-based line number or file path. It is
+// read-only and never modifies the file.
 type JsonlSyntaxCheck struct{}
 
 // Run executes the JSONL syntax check. It reads tasks.jsonl from the given
@@ -33,6 +35,10 @@ func (c *JsonlSyntaxCheck) Run(ctx context.Context, tickDir string) []CheckResul
 		// actual syntax errors.
 		if !json.Valid([]byte(line.Raw)) {
 			preview := line.Raw
+			if line.File != "" {
+				// Task files are multi-line; collapse to a single-line preview.
+				preview = strings.Join(strings.Fields(preview), " ")
+			}
 			if len(preview) > 80 {
 				preview = preview[:80] + "..."
 			}
@@ -40,7 +46,7 @@ func (c *JsonlSyntaxCheck) Run(ctx context.Context, tickDir string) []CheckResul
 				Name:       "JSONL syntax",
 				Passed:     false,
 				Severity:   SeverityError,
-				Details:    fmt.Sprintf("Line %d: invalid JSON — %s", line.LineNum, preview),
+				Details:    fmt.Sprintf("%s: invalid JSON — %s", line.Location(), preview),
 				Suggestion: "Manual fix required",
 			})
 		}
@@ -154,6 +154,23 @@ func TestJsonlSyntaxCheck(t *testing.T) {
 		}
 	})
 
+	t.Run("it reports malformed task files by path in the files layout", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		writeTaskFile(t, tickDir, "tick-aaa111.json", []byte("{\n  \"id\": \"tick-aaa111\"\n}\n"))
+		writeTaskFile(t, tickDir, "tick-bbb222.json", []byte("{\n  \"id\": \"tick-bbb222\",\n}\n"))
+
+		check := &JsonlSyntaxCheck{}
+		results := check.Run(ctxWithTickDir(tickDir), tickDir)
+
+		if len(results) != 1 {
+			t.Fatalf("expected 1 result, got %d", len(results))
+		}
+		want := `tasks/tick-bbb222.json: invalid JSON — { "id": "tick-bbb222", }`
+		if results[0].Passed || results[0].Details != want {
+			t.Errorf("expected failing result %q, got passed=%v details=%q", want, results[0].Passed, results[0].Details)
+		}
+	})
+
 	t.Run("it returns failing result when tasks.jsonl does not exist", func(t *testing.T) {
 		tickDir := setupTickDir(t)
 		// No tasks.jsonl created
@@ -5,12 +5,16 @@ import (
 	"fmt"
 	"maps"
 	"slices"
+
+	"github.com/leeovery/tick/internal/config"
+	"github.com/leeovery/tick/internal/task"
 )
 
 // ParentDoneWithOpenChildrenCheck validates that no parent task marked "done"
-// has children that are still open (status "open" or "in_progress"). This is
-// the only warning-severity check in the doctor suite — it flags suspicious
-// but allowed states. It is read-only and never modifies the file.
+// has children that are still open: in an active or waiting state of the
+// project's workflow, such as "open" or "in_progress". It is a warning-severity
+// check — it flags suspicious but allowed states. It is
+// read-only and never modifies the file.
 type ParentDoneWithOpenChildrenCheck struct{}
 
 // Run executes the parent-done-with-open-children check. It parses task
@@ -24,6 +28,14 @@ func (c *ParentDoneWithOpenChildrenCheck) Run(ctx context.Context, tickDir strin
 		return fileNotFoundResult("Parent done with open children")
 	}
 
+	// An invalid config is reported by the config check; fall back to the
+	// built-in workflow.
+	wf := task.DefaultWorkflow()
+	if cfg, err := config.Load(tickDir); err == nil {
+		wf = cfg.Rules().Workflow
+	}
+	live := wf.StatesIn(task.CategoryActive, task.CategoryWaiting)
+
 	statusMap := make(map[string]string, len(tasks))
 	childrenMap := make(map[string][]string)
 
@@ -52,7 +64,7 @@ func (c *ParentDoneWithOpenChildrenCheck) Run(ctx context.Context, tickDir strin
 
 		for _, childID := range children {
 			childStatus := statusMap[childID]
-			if childStatus == "open" || childStatus == "in_progress" {
+			if slices.Contains(live, task.Status(childStatus)) {
 				failures = append(failures, CheckResult{
 					Name:       "Parent done with open children",
 					Passed:     false,
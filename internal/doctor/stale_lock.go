@@ -0,0 +1,55 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/leeovery/tick/internal/storage"
+)
+
+// StaleLockCheck warns about lock holder records left by processes that are no
+// longer running, usually because they crashed while holding the .tick lock.
+// The lock itself is released by the operating system when its holder exits,
+// so this is a warning rather than an error; the stale record only misleads
+// `tick lock status` and lock timeout messages until the next tick command
+// removes it. It is read-only and never modifies any files.
+type StaleLockCheck struct{}
+
+// Run executes the stale lock check. It reports one failing result per holder
+// record whose process is gone. Holders on other hosts cannot be checked and
+// are never reported.
+func (c *StaleLockCheck) Run(_ context.Context, tickDir string) []CheckResult {
+	_, holders, err := storage.LockStatus(tickDir)
+	if err != nil {
+		return []CheckResult{{
+			Name:       "Lock",
+			Passed:     false,
+			Severity:   SeverityWarning,
+			Details:    fmt.Sprintf("could not inspect lock: %v", err),
+			Suggestion: "Verify .tick/lock and .tick/lock.holders are readable",
+		}}
+	}
+
+	var failures []CheckResult
+	for _, h := range holders {
+		if h.Alive() {
+			continue
+		}
+		failures = append(failures, CheckResult{
+			Name:       "Lock",
+			Passed:     false,
+			Severity:   SeverityWarning,
+			Details:    fmt.Sprintf("lock held by dead process: %s", h),
+			Suggestion: "The process exited without releasing the lock record; the next tick command clears it",
+		})
+	}
+
+	if len(failures) > 0 {
+		return failures
+	}
+
+	return []CheckResult{{
+		Name:   "Lock",
+		Passed: true,
+	}}
+}
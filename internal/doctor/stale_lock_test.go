@@ -0,0 +1,66 @@
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/storage"
+)
+
+// writeLockHolder writes a lock holder record into the tick directory.
+func writeLockHolder(t *testing.T, tickDir string, name string, h storage.LockHolder) {
+	t.Helper()
+	dir := filepath.Join(tickDir, "lock.holders")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create holders dir: %v", err)
+	}
+	data, err := json.Marshal(h)
+	if err != nil {
+		t.Fatalf("failed to marshal holder: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+		t.Fatalf("failed to write holder: %v", err)
+	}
+}
+
+func TestStaleLockCheck(t *testing.T) {
+	hostname, _ := os.Hostname()
+	started := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+
+	t.Run("it returns passing result when no holders are recorded", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+
+		check := &StaleLockCheck{}
+		results := check.Run(context.Background(), tickDir)
+
+		if len(results) != 1 || !results[0].Passed || results[0].Name != "Lock" {
+			t.Errorf("results = %+v, want one passing Lock result", results)
+		}
+	})
+
+	t.Run("it warns about holders whose process is gone", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		writeLockHolder(t, tickDir, "dead.json", storage.LockHolder{PID: 0, Hostname: hostname, Command: "tick rebuild", Mode: storage.LockExclusive, Started: started})
+		writeLockHolder(t, tickDir, "live.json", storage.LockHolder{PID: os.Getpid(), Hostname: hostname, Mode: storage.LockShared, Started: started})
+		writeLockHolder(t, tickDir, "remote.json", storage.LockHolder{PID: 0, Hostname: "elsewhere-" + hostname, Mode: storage.LockShared, Started: started})
+
+		check := &StaleLockCheck{}
+		results := check.Run(context.Background(), tickDir)
+
+		if len(results) != 1 {
+			t.Fatalf("expected 1 result, got %d: %+v", len(results), results)
+		}
+		r := results[0]
+		if r.Passed || r.Severity != SeverityWarning {
+			t.Errorf("result = %+v, want a failing warning", r)
+		}
+		if !strings.Contains(r.Details, "pid 0 on "+hostname+" (tick rebuild)") {
+			t.Errorf("Details = %q, want the dead holder", r.Details)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// UnknownFieldsCheck warns about top-level task fields this version of tick
+// does not recognize, such as fields written by a newer tick, a script, or a
+// migration tool. Such fields are preserved on write, so this is a warning
+// rather than an error. It is read-only and never modifies the file.
+type UnknownFieldsCheck struct{}
+
+// Run executes the unknown fields check. It reads the tasks of the given tick
+// directory and reports one failing result per task with unrecognized fields,
+// listing them in sorted order. Unparseable lines are skipped; the JSONL syntax
+// check reports those.
+func (c *UnknownFieldsCheck) Run(ctx context.Context, tickDir string) []CheckResult {
+	lines, err := getJSONLines(ctx, tickDir)
+	if err != nil {
+		return fileNotFoundResult("Unknown fields")
+	}
+
+	var failures []CheckResult
+	for _, line := range lines {
+		if line.Parsed == nil {
+			continue
+		}
+
+		var unknown []string
+		for _, name := range slices.Sorted(maps.Keys(line.Parsed)) {
+			if !task.IsKnownField(name) {
+				unknown = append(unknown, name)
+			}
+		}
+		if len(unknown) == 0 {
+			continue
+		}
+
+		subject := "task"
+		if id, ok := line.Parsed["id"].(string); ok && id != "" {
+			subject = id
+		}
+		failures = append(failures, CheckResult{
+			Name:       "Unknown fields",
+			Passed:     false,
+			Severity:   SeverityWarning,
+			Details:    fmt.Sprintf("%s: %s has unrecognized fields: %s", line.Location(), subject, strings.Join(unknown, ", ")),
+			Suggestion: "Fields are preserved as-is; upgrade tick if they come from a newer version",
+		})
+	}
+
+	if len(failures) > 0 {
+		return failures
+	}
+
+	return []CheckResult{{
+		Name:   "Unknown fields",
+		Passed: true,
+	}}
+}
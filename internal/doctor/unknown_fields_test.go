@@ -0,0 +1,66 @@
+package doctor
+
+import "testing"
+
+func TestUnknownFieldsCheck(t *testing.T) {
+	t.Run("it returns passing result when all fields are recognized", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		content := `{"id":"tick-aaa111","title":"Task","status":"open","priority":2,"type":"bug","tags":["api"],"transitions":[],"created":"2026-01-19T10:00:00Z","updated":"2026-01-19T10:00:00Z"}` + "\n"
+		writeJSONL(t, tickDir, []byte(content))
+
+		check := &UnknownFieldsCheck{}
+		results := check.Run(ctxWithTickDir(tickDir), tickDir)
+
+		if len(results) != 1 {
+			t.Fatalf("expected 1 result, got %d", len(results))
+		}
+		if !results[0].Passed {
+			t.Errorf("expected Passed true; details: %s", results[0].Details)
+		}
+	})
+
+	t.Run("it warns once per task listing unrecognized fields in sorted order", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		content := `{"id":"tick-aaa111","title":"Task","sprint":12,"owner":"ann"}` + "\n" +
+			`{"id":"tick-bbb222","title":"Other"}` + "\n" +
+			`not json` + "\n" +
+			`{"id":"tick-ccc333","milestone":3}` + "\n"
+		writeJSONL(t, tickDir, []byte(content))
+
+		check := &UnknownFieldsCheck{}
+		results := check.Run(ctxWithTickDir(tickDir), tickDir)
+
+		if len(results) != 2 {
+			t.Fatalf("expected 2 results, got %d: %+v", len(results), results)
+		}
+		want := []string{
+			"Line 1: tick-aaa111 has unrecognized fields: owner, sprint",
+			"Line 4: tick-ccc333 has unrecognized fields: milestone",
+		}
+		for i, r := range results {
+			if r.Passed {
+				t.Errorf("result %d: expected Passed false", i)
+			}
+			if r.Severity != SeverityWarning {
+				t.Errorf("result %d: severity = %q, want warning", i, r.Severity)
+			}
+			if r.Name != "Unknown fields" {
+				t.Errorf("result %d: name = %q", i, r.Name)
+			}
+			if r.Details != want[i] {
+				t.Errorf("result %d: details = %q, want %q", i, r.Details, want[i])
+			}
+		}
+	})
+
+	t.Run("it returns failing result when tasks.jsonl is missing", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+
+		check := &UnknownFieldsCheck{}
+		results := check.Run(ctxWithTickDir(tickDir), tickDir)
+
+		if len(results) != 1 || results[0].Passed {
+			t.Fatalf("expected 1 failing result, got %+v", results)
+		}
+	})
+}
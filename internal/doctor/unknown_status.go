@@ -0,0 +1,71 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/leeovery/tick/internal/config"
+	"github.com/leeovery/tick/internal/task"
+)
+
+// UnknownStatusCheck warns about tasks whose status is neither built in nor a
+// state of the workflow in .tick/config.yaml, such as tasks left in a state
+// that was later removed from the config. Such tasks still load, but are never
+// ready or blocked and no command moves them, so this is a warning. It is
+// read-only and never modifies any files.
+type UnknownStatusCheck struct{}
+
+// Run executes the unknown status check. It reports one failing result per
+// task with a status the workflow does not define. Missing or non-string
+// statuses and unparseable lines are skipped. When the config is invalid the
+// check passes, since the config check reports that.
+func (c *UnknownStatusCheck) Run(ctx context.Context, tickDir string) []CheckResult {
+	lines, err := getJSONLines(ctx, tickDir)
+	if err != nil {
+		return fileNotFoundResult("Task statuses")
+	}
+	cfg, err := config.Load(tickDir)
+	if err != nil {
+		return []CheckResult{{
+			Name:   "Task statuses",
+			Passed: true,
+		}}
+	}
+	wf := cfg.Rules().Workflow
+
+	var failures []CheckResult
+	for _, line := range lines {
+		if line.Parsed == nil {
+			continue
+		}
+		status, ok := line.Parsed["status"].(string)
+		if !ok || status == "" {
+			continue
+		}
+		if _, ok := wf.State(task.Status(status)); ok {
+			continue
+		}
+
+		subject := "task"
+		if id, ok := line.Parsed["id"].(string); ok && id != "" {
+			subject = id
+		}
+		failures = append(failures, CheckResult{
+			Name:       "Task statuses",
+			Passed:     false,
+			Severity:   SeverityWarning,
+			Details:    fmt.Sprintf("%s: %s has status '%s', which is not one of: %s", line.Location(), subject, status, strings.Join(wf.StateNames(), ", ")),
+			Suggestion: fmt.Sprintf("Add '%s' to workflow.states in .tick/config.yaml, or edit the task's status", status),
+		})
+	}
+
+	if len(failures) > 0 {
+		return failures
+	}
+
+	return []CheckResult{{
+		Name:   "Task statuses",
+		Passed: true,
+	}}
+}
@@ -0,0 +1,70 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUnknownStatusCheck(t *testing.T) {
+	t.Run("it returns passing result when every status is in the workflow", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		if err := os.WriteFile(filepath.Join(tickDir, "config.yaml"), []byte("workflow:\n  states:\n    - {name: in_review, category: active}\n"), 0644); err != nil {
+			t.Fatalf("failed to write config.yaml: %v", err)
+		}
+		content := `{"id":"tick-aaa111","status":"open"}` + "\n" +
+			`{"id":"tick-bbb222","status":"in_review"}` + "\n"
+		writeJSONL(t, tickDir, []byte(content))
+
+		check := &UnknownStatusCheck{}
+		results := check.Run(ctxWithTickDir(tickDir), tickDir)
+
+		if len(results) != 1 {
+			t.Fatalf("expected 1 result, got %d", len(results))
+		}
+		if !results[0].Passed {
+			t.Errorf("expected Passed true; details: %s", results[0].Details)
+		}
+	})
+
+	t.Run("it warns once per task whose status the workflow does not define", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		content := `{"id":"tick-aaa111","status":"done"}` + "\n" +
+			`{"id":"tick-bbb222","status":"in_review"}` + "\n" +
+			`not json` + "\n" +
+			`{"id":"tick-ccc333"}` + "\n"
+		writeJSONL(t, tickDir, []byte(content))
+
+		check := &UnknownStatusCheck{}
+		results := check.Run(ctxWithTickDir(tickDir), tickDir)
+
+		if len(results) != 1 {
+			t.Fatalf("expected 1 result, got %d: %+v", len(results), results)
+		}
+		r := results[0]
+		if r.Passed || r.Severity != SeverityWarning {
+			t.Errorf("result = %+v, want a failing warning", r)
+		}
+		if want := "Line 2: tick-bbb222 has status 'in_review', which is not one of: open, in_progress, done, cancelled"; r.Details != want {
+			t.Errorf("details = %q, want %q", r.Details, want)
+		}
+		if want := "Add 'in_review' to workflow.states in .tick/config.yaml, or edit the task's status"; r.Suggestion != want {
+			t.Errorf("suggestion = %q, want %q", r.Suggestion, want)
+		}
+	})
+
+	t.Run("it passes when the config is invalid", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		if err := os.WriteFile(filepath.Join(tickDir, "config.yaml"), []byte("types: []\n"), 0644); err != nil {
+			t.Fatalf("failed to write config.yaml: %v", err)
+		}
+		writeJSONL(t, tickDir, []byte(`{"id":"tick-aaa111","status":"in_review"}`+"\n"))
+
+		check := &UnknownStatusCheck{}
+		results := check.Run(ctxWithTickDir(tickDir), tickDir)
+
+		if len(results) != 1 || !results[0].Passed {
+			t.Errorf("expected a single passing result, got %+v", results)
+		}
+	})
+}
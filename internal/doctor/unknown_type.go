@@ -0,0 +1,67 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/leeovery/tick/internal/config"
+)
+
+// UnknownTypeCheck warns about tasks whose type is not one of the types
+// .tick/config.yaml allows, such as tasks created before a type was removed
+// from the list. Such tasks still load and can be edited, so this is a warning
+// rather than an error. It is read-only and never modifies any files.
+type UnknownTypeCheck struct{}
+
+// Run executes the unknown type check. It reports one failing result per task
+// with a type the project does not allow. Untyped tasks, non-string types and
+// unparseable lines are skipped. When the config is invalid the check passes,
+// since the config check reports that.
+func (c *UnknownTypeCheck) Run(ctx context.Context, tickDir string) []CheckResult {
+	lines, err := getJSONLines(ctx, tickDir)
+	if err != nil {
+		return fileNotFoundResult("Task types")
+	}
+	cfg, err := config.Load(tickDir)
+	if err != nil {
+		return []CheckResult{{
+			Name:   "Task types",
+			Passed: true,
+		}}
+	}
+	types := cfg.TypeNames()
+
+	var failures []CheckResult
+	for _, line := range lines {
+		if line.Parsed == nil {
+			continue
+		}
+		typ, ok := line.Parsed["type"].(string)
+		if !ok || typ == "" || slices.Contains(types, typ) {
+			continue
+		}
+
+		subject := "task"
+		if id, ok := line.Parsed["id"].(string); ok && id != "" {
+			subject = id
+		}
+		failures = append(failures, CheckResult{
+			Name:       "Task types",
+			Passed:     false,
+			Severity:   SeverityWarning,
+			Details:    fmt.Sprintf("%s: %s has type '%s', which is not one of: %s", line.Location(), subject, typ, strings.Join(types, ", ")),
+			Suggestion: fmt.Sprintf("Change it with `tick update %s --type <type>` or add '%s' to types in .tick/config.yaml", subject, typ),
+		})
+	}
+
+	if len(failures) > 0 {
+		return failures
+	}
+
+	return []CheckResult{{
+		Name:   "Task types",
+		Passed: true,
+	}}
+}
@@ -0,0 +1,78 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUnknownTypeCheck(t *testing.T) {
+	t.Run("it returns passing result when every type is allowed", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		content := `{"id":"tick-aaa111","type":"bug"}` + "\n" +
+			`{"id":"tick-bbb222"}` + "\n"
+		writeJSONL(t, tickDir, []byte(content))
+
+		check := &UnknownTypeCheck{}
+		results := check.Run(ctxWithTickDir(tickDir), tickDir)
+
+		if len(results) != 1 {
+			t.Fatalf("expected 1 result, got %d", len(results))
+		}
+		if !results[0].Passed {
+			t.Errorf("expected Passed true; details: %s", results[0].Details)
+		}
+	})
+
+	t.Run("it warns once per task whose type the config does not allow", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		if err := os.WriteFile(filepath.Join(tickDir, "config.yaml"), []byte("types: [story, bug]\n"), 0644); err != nil {
+			t.Fatalf("failed to write config.yaml: %v", err)
+		}
+		content := `{"id":"tick-aaa111","type":"bug"}` + "\n" +
+			`{"id":"tick-bbb222","type":"chore"}` + "\n" +
+			`not json` + "\n" +
+			`{"id":"tick-ccc333","type":"spike"}` + "\n"
+		writeJSONL(t, tickDir, []byte(content))
+
+		check := &UnknownTypeCheck{}
+		results := check.Run(ctxWithTickDir(tickDir), tickDir)
+
+		if len(results) != 2 {
+			t.Fatalf("expected 2 results, got %d: %+v", len(results), results)
+		}
+		want := []string{
+			"Line 2: tick-bbb222 has type 'chore', which is not one of: story, bug",
+			"Line 4: tick-ccc333 has type 'spike', which is not one of: story, bug",
+		}
+		for i, r := range results {
+			if r.Passed {
+				t.Errorf("result %d: expected Passed false", i)
+			}
+			if r.Severity != SeverityWarning {
+				t.Errorf("result %d: severity = %q, want warning", i, r.Severity)
+			}
+			if r.Details != want[i] {
+				t.Errorf("result %d: details = %q, want %q", i, r.Details, want[i])
+			}
+		}
+		if got := results[0].Suggestion; got != "Change it with `tick update tick-bbb222 --type <type>` or add 'chore' to types in .tick/config.yaml" {
+			t.Errorf("suggestion = %q", got)
+		}
+	})
+
+	t.Run("it passes when the config is invalid", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		if err := os.WriteFile(filepath.Join(tickDir, "config.yaml"), []byte("types: []\n"), 0644); err != nil {
+			t.Fatalf("failed to write config.yaml: %v", err)
+		}
+		writeJSONL(t, tickDir, []byte(`{"id":"tick-aaa111","type":"spike"}`+"\n"))
+
+		check := &UnknownTypeCheck{}
+		results := check.Run(ctxWithTickDir(tickDir), tickDir)
+
+		if len(results) != 1 || !results[0].Passed {
+			t.Errorf("expected a single passing result, got %+v", results)
+		}
+	})
+}
@@ -0,0 +1,340 @@
+// Package merge implements a three-way, per-task merge of tasks.jsonl content.
+// It backs the tick merge-driver command, which git invokes in place of its
+// line-based merge so that concurrent edits on different branches combine by
+// task ID rather than producing conflict markers inside the JSONL file.
+package merge
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// Conflict describes a field that both sides changed to different values, or a
+// merged state that violates a task invariant. The merged output keeps "ours"
+// for scalar conflicts; Conflict records what was discarded.
+type Conflict struct {
+	ID     string
+	Field  string
+	Ours   string
+	Theirs string
+	// Reason is set for validation problems instead of Ours/Theirs.
+	Reason string
+}
+
+// String renders the conflict as a single human-readable line.
+func (c Conflict) String() string {
+	if c.Reason != "" {
+		return fmt.Sprintf("%s: %s", c.ID, c.Reason)
+	}
+	return fmt.Sprintf("%s: conflicting %s (ours %q, theirs %q); kept ours", c.ID, c.Field, c.Ours, c.Theirs)
+}
+
+// Result holds the merged task list and any conflicts encountered.
+type Result struct {
+	Tasks     []task.Task
+	Conflicts []Conflict
+}
+
+// Merge performs a three-way merge of base, ours, and theirs keyed by task ID.
+// Output order follows ours, with tasks added only in theirs appended in their
+// original order. Tasks deleted on one side and unchanged on the other are
+// dropped; tasks deleted on one side but modified on the other are kept and
+// reported as a conflict so that no edits are silently lost. Leases and the
+// validation of the merged tasks follow the states of workflow w.
+func Merge(base, ours, theirs []task.Task, w task.Workflow) Result {
+	baseIdx := indexByID(base)
+	oursIdx := indexByID(ours)
+	theirsIdx := indexByID(theirs)
+
+	var result Result
+
+	order := make([]string, 0, len(ours)+len(theirs))
+	seen := make(map[string]bool, len(ours)+len(theirs))
+	for _, t := range ours {
+		id := task.NormalizeID(t.ID)
+		if !seen[id] {
+			seen[id] = true
+			order = append(order, id)
+		}
+	}
+	for _, t := range theirs {
+		id := task.NormalizeID(t.ID)
+		if !seen[id] {
+			seen[id] = true
+			order = append(order, id)
+		}
+	}
+
+	for _, id := range order {
+		b, inBase := baseIdx[id]
+		o, inOurs := oursIdx[id]
+		th, inTheirs := theirsIdx[id]
+
+		switch {
+		case inOurs && inTheirs:
+			if !inBase {
+				b = task.Task{}
+			}
+			merged, conflicts := mergeTask(b, o, th, w)
+			result.Tasks = append(result.Tasks, merged)
+			result.Conflicts = append(result.Conflicts, conflicts...)
+		case inOurs && !inBase:
+			result.Tasks = append(result.Tasks, o)
+		case inTheirs && !inBase:
+			result.Tasks = append(result.Tasks, th)
+		case inOurs:
+			// Deleted in theirs.
+			if !sameTask(b, o) {
+				result.Tasks = append(result.Tasks, o)
+				result.Conflicts = append(result.Conflicts, Conflict{
+					ID:     o.ID,
+					Reason: "removed in theirs but modified in ours; kept ours",
+				})
+			}
+		case inTheirs:
+			// Deleted in ours.
+			if !sameTask(b, th) {
+				result.Tasks = append(result.Tasks, th)
+				result.Conflicts = append(result.Conflicts, Conflict{
+					ID:     th.ID,
+					Reason: "removed in ours but modified in theirs; kept theirs",
+				})
+			}
+		}
+	}
+
+	result.Conflicts = append(result.Conflicts, Validate(result.Tasks, ours, theirs, w)...)
+
+	return result
+}
+
+// mergeTask merges a single task field by field. Scalar fields take whichever
+// side changed relative to base; when both changed to different values ours is
+// kept and a Conflict is reported. Set-like fields (tags, refs, blocked_by,
+// notes, transitions) keep base entries still present on both sides plus any
+// entries added on either side. Updated and LeaseExpires take the later of the
+// two timestamps; a lease is kept only while the merged status is one of w's
+// started states.
+// Custom fields and unrecognized fields merge as scalars, one per key.
+func mergeTask(base, ours, theirs task.Task, w task.Workflow) (task.Task, []Conflict) {
+	merged := ours
+	var conflicts []Conflict
+
+	conflict := func(field, o, t string) {
+		conflicts = append(conflicts, Conflict{ID: ours.ID, Field: field, Ours: o, Theirs: t})
+	}
+
+	merged.Title = mergeScalar(base.Title, ours.Title, theirs.Title, func(o, t string) { conflict("title", o, t) })
+	merged.Description = mergeScalar(base.Description, ours.Description, theirs.Description, func(o, t string) { conflict("description", o, t) })
+	merged.Type = mergeScalar(base.Type, ours.Type, theirs.Type, func(o, t string) { conflict("type", o, t) })
+	merged.Parent = mergeScalar(base.Parent, ours.Parent, theirs.Parent, func(o, t string) { conflict("parent", o, t) })
+	merged.Priority = mergeScalar(base.Priority, ours.Priority, theirs.Priority, func(o, t int) {
+		conflict("priority", fmt.Sprint(o), fmt.Sprint(t))
+	})
+	merged.Estimate = mergeScalar(base.Estimate, ours.Estimate, theirs.Estimate, func(o, t float64) {
+		conflict("estimate", task.FormatEstimate(o), task.FormatEstimate(t))
+	})
+	merged.Recur = mergeScalar(base.Recur, ours.Recur, theirs.Recur, func(o, t string) { conflict("recur", o, t) })
+	merged.RecurredFrom = mergeScalar(base.RecurredFrom, ours.RecurredFrom, theirs.RecurredFrom, func(o, t string) { conflict("recurred_from", o, t) })
+
+	// Status and Closed move together: Closed follows whichever side's status won.
+	merged.Status = mergeScalar(base.Status, ours.Status, theirs.Status, func(o, t task.Status) {
+		conflict("status", string(o), string(t))
+	})
+	if merged.Status == theirs.Status && ours.Status != theirs.Status {
+		merged.Closed = theirs.Closed
+	}
+
+	// Due and defer_until merge as scalars on their timestamps; the result is
+	// one side's value.
+	due := mergeScalar(formatTime(base.Due), formatTime(ours.Due), formatTime(theirs.Due), func(o, t string) { conflict("due", o, t) })
+	if due != formatTime(ours.Due) {
+		merged.Due = theirs.Due
+	}
+	deferUntil := mergeScalar(formatTime(base.DeferUntil), formatTime(ours.DeferUntil), formatTime(theirs.DeferUntil), func(o, t string) { conflict("defer_until", o, t) })
+	if deferUntil != formatTime(ours.DeferUntil) {
+		merged.DeferUntil = theirs.DeferUntil
+	}
+
+	// A lease only applies to a started task; when both sides hold one (say, a
+	// heartbeat on each), the later expiry wins.
+	merged.Assignee = mergeScalar(base.Assignee, ours.Assignee, theirs.Assignee, func(o, t string) { conflict("assignee", o, t) })
+	merged.LeaseExpires = nil
+	if w.IsStarted(merged.Status) {
+		merged.LeaseExpires = ours.LeaseExpires
+		if theirs.LeaseExpires != nil && (ours.LeaseExpires == nil || theirs.LeaseExpires.After(*ours.LeaseExpires)) {
+			merged.LeaseExpires = theirs.LeaseExpires
+		}
+	}
+
+	merged.Created = ours.Created
+	if ours.Created.IsZero() || (!theirs.Created.IsZero() && theirs.Created.Before(ours.Created)) {
+		merged.Created = theirs.Created
+	}
+	merged.Updated = ours.Updated
+	if theirs.Updated.After(ours.Updated) {
+		merged.Updated = theirs.Updated
+	}
+
+	merged.Tags = mergeSet(base.Tags, ours.Tags, theirs.Tags, task.NormalizeTag)
+	merged.Refs = mergeSet(base.Refs, ours.Refs, theirs.Refs, func(s string) string { return s })
+	merged.BlockedBy = mergeSet(base.BlockedBy, ours.BlockedBy, theirs.BlockedBy, task.NormalizeID)
+
+	merged.Notes = mergeSet(base.Notes, ours.Notes, theirs.Notes, noteKey)
+	slices.SortStableFunc(merged.Notes, func(a, b task.Note) int { return a.Created.Compare(b.Created) })
+
+	merged.Transitions = mergeSet(base.Transitions, ours.Transitions, theirs.Transitions, transitionKey)
+	slices.SortStableFunc(merged.Transitions, func(a, b task.TransitionRecord) int { return a.At.Compare(b.At) })
+
+	merged.Fields = mergeFields(base.Fields, ours.Fields, theirs.Fields, conflict)
+	merged.Extra = mergeExtra(base.Extra, ours.Extra, theirs.Extra, conflict)
+
+	return merged, conflicts
+}
+
+// formatTime returns at as a timestamp, or "" when it is not set.
+func formatTime(at *time.Time) string {
+	if at == nil {
+		return ""
+	}
+	return task.FormatTimestamp(*at)
+}
+
+// mergeFields merges custom fields as scalars, one per key. A field absent on
+// one side counts as the empty value, so removals merge like any other change.
+// Conflicts are reported against "fields.<key>".
+func mergeFields(base, ours, theirs map[string]string, onConflict func(name, ours, theirs string)) map[string]string {
+	keys := slices.Concat(slices.Collect(maps.Keys(ours)), slices.Collect(maps.Keys(theirs)))
+	slices.Sort(keys)
+	keys = slices.Compact(keys)
+
+	var merged map[string]string
+	for _, key := range keys {
+		value := mergeScalar(base[key], ours[key], theirs[key], func(o, t string) {
+			onConflict("fields."+key, o, t)
+		})
+		if value == "" {
+			continue
+		}
+		if merged == nil {
+			merged = map[string]string{}
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+// mergeExtra merges unrecognized fields as scalars, each keyed by name and
+// compared by its raw JSON value. A field absent on one side counts as the empty
+// value, so removals merge like any other change.
+func mergeExtra(base, ours, theirs map[string]json.RawMessage, onConflict func(name, ours, theirs string)) map[string]json.RawMessage {
+	names := slices.Concat(slices.Collect(maps.Keys(ours)), slices.Collect(maps.Keys(theirs)))
+	slices.Sort(names)
+	names = slices.Compact(names)
+
+	var merged map[string]json.RawMessage
+	for _, name := range names {
+		value := mergeScalar(string(base[name]), string(ours[name]), string(theirs[name]), func(o, t string) {
+			onConflict(name, o, t)
+		})
+		if value == "" {
+			continue
+		}
+		if merged == nil {
+			merged = map[string]json.RawMessage{}
+		}
+		merged[name] = json.RawMessage(value)
+	}
+	return merged
+}
+
+// mergeScalar resolves a single comparable value three ways. If only one side
+// changed it, that side wins. If both changed it to different values, onConflict
+// is called and ours is returned.
+func mergeScalar[T comparable](base, ours, theirs T, onConflict func(ours, theirs T)) T {
+	switch {
+	case ours == theirs:
+		return ours
+	case ours == base:
+		return theirs
+	case theirs == base:
+		return ours
+	default:
+		onConflict(ours, theirs)
+		return ours
+	}
+}
+
+// mergeSet merges a list of items as a set keyed by key. An item survives if it
+// was added on either side, or if it existed in base and neither side removed it.
+// Ours order is preserved, followed by additions from theirs.
+func mergeSet[T any](base, ours, theirs []T, key func(T) string) []T {
+	inBase := keySet(base, key)
+	inOurs := keySet(ours, key)
+	inTheirs := keySet(theirs, key)
+
+	var result []T
+	emitted := make(map[string]bool)
+	keep := func(item T) {
+		k := key(item)
+		if emitted[k] {
+			return
+		}
+		if inBase[k] && (!inOurs[k] || !inTheirs[k]) {
+			return
+		}
+		emitted[k] = true
+		result = append(result, item)
+	}
+	for _, item := range ours {
+		keep(item)
+	}
+	for _, item := range theirs {
+		keep(item)
+	}
+	return result
+}
+
+// keySet builds a membership set of the keys of items.
+func keySet[T any](items []T, key func(T) string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, item := range items {
+		set[key(item)] = true
+	}
+	return set
+}
+
+// noteKey identifies a note by its timestamp and text.
+func noteKey(n task.Note) string {
+	return task.FormatTimestamp(n.Created) + "\x00" + n.Text
+}
+
+// transitionKey identifies a transition record by all of its fields.
+func transitionKey(tr task.TransitionRecord) string {
+	return fmt.Sprintf("%s\x00%s\x00%s\x00%t", tr.From, tr.To, task.FormatTimestamp(tr.At), tr.Auto)
+}
+
+// sameTask reports whether two tasks serialize identically.
+func sameTask(a, b task.Task) bool {
+	aj, aErr := a.MarshalJSON()
+	bj, bErr := b.MarshalJSON()
+	return aErr == nil && bErr == nil && string(aj) == string(bj)
+}
+
+// indexByID builds a lookup of tasks keyed by normalized ID. When an ID appears
+// more than once, the first occurrence wins.
+func indexByID(tasks []task.Task) map[string]task.Task {
+	idx := make(map[string]task.Task, len(tasks))
+	for _, t := range tasks {
+		id := task.NormalizeID(t.ID)
+		if _, ok := idx[id]; !ok {
+			idx[id] = t
+		}
+	}
+	return idx
+}
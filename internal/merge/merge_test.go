@@ -0,0 +1,443 @@
+package merge
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+var (
+	t0 = time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	t1 = time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	t2 = time.Date(2026, 1, 3, 10, 0, 0, 0, time.UTC)
+)
+
+func newTask(id, title string) task.Task {
+	return task.Task{
+		ID:       id,
+		Title:    title,
+		Status:   task.StatusOpen,
+		Priority: 2,
+		Created:  t0,
+		Updated:  t0,
+	}
+}
+
+// reviewWorkflow is the default workflow with an active in_review state and a
+// terminal shipped state added.
+func reviewWorkflow() task.Workflow {
+	w := task.DefaultWorkflow()
+	w.States = []task.State{
+		{Name: task.StatusOpen, Category: task.CategoryActive},
+		{Name: task.StatusInProgress, Category: task.CategoryActive},
+		{Name: "in_review", Category: task.CategoryActive},
+		{Name: "shipped", Category: task.CategoryTerminal},
+		{Name: task.StatusDone, Category: task.CategoryTerminal},
+		{Name: task.StatusCancelled, Category: task.CategoryTerminal},
+	}
+	return w
+}
+
+func findTask(t *testing.T, tasks []task.Task, id string) task.Task {
+	t.Helper()
+	for _, tk := range tasks {
+		if tk.ID == id {
+			return tk
+		}
+	}
+	t.Fatalf("task %s not found in merged result", id)
+	return task.Task{}
+}
+
+func TestMerge(t *testing.T) {
+	t.Run("it keeps tasks added on both sides", func(t *testing.T) {
+		base := []task.Task{newTask("tick-aaa111", "Base")}
+		ours := append(base, newTask("tick-bbb222", "Ours"))
+		theirs := append([]task.Task{base[0]}, newTask("tick-ccc333", "Theirs"))
+
+		result := Merge(base, ours, theirs, task.DefaultWorkflow())
+
+		if len(result.Conflicts) != 0 {
+			t.Fatalf("conflicts = %v, want none", result.Conflicts)
+		}
+		var ids []string
+		for _, tk := range result.Tasks {
+			ids = append(ids, tk.ID)
+		}
+		want := "tick-aaa111,tick-bbb222,tick-ccc333"
+		if got := strings.Join(ids, ","); got != want {
+			t.Errorf("ids = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("it merges different scalar fields changed on each side", func(t *testing.T) {
+		base := newTask("tick-aaa111", "Base")
+		ours := base
+		ours.Title = "Renamed"
+		ours.Updated = t1
+		theirs := base
+		theirs.Priority = 0
+		theirs.Updated = t2
+
+		result := Merge([]task.Task{base}, []task.Task{ours}, []task.Task{theirs}, task.DefaultWorkflow())
+
+		if len(result.Conflicts) != 0 {
+			t.Fatalf("conflicts = %v, want none", result.Conflicts)
+		}
+		got := result.Tasks[0]
+		if got.Title != "Renamed" {
+			t.Errorf("title = %q, want %q", got.Title, "Renamed")
+		}
+		if got.Priority != 0 {
+			t.Errorf("priority = %d, want 0", got.Priority)
+		}
+		if !got.Updated.Equal(t2) {
+			t.Errorf("updated = %v, want later timestamp %v", got.Updated, t2)
+		}
+	})
+
+	t.Run("it reports a conflict and keeps ours when both sides change the title", func(t *testing.T) {
+		base := newTask("tick-aaa111", "Base")
+		ours := base
+		ours.Title = "Ours"
+		theirs := base
+		theirs.Title = "Theirs"
+
+		result := Merge([]task.Task{base}, []task.Task{ours}, []task.Task{theirs}, task.DefaultWorkflow())
+
+		if result.Tasks[0].Title != "Ours" {
+			t.Errorf("title = %q, want %q", result.Tasks[0].Title, "Ours")
+		}
+		if len(result.Conflicts) != 1 || result.Conflicts[0].Field != "title" {
+			t.Fatalf("conflicts = %v, want one title conflict", result.Conflicts)
+		}
+		want := `tick-aaa111: conflicting title (ours "Ours", theirs "Theirs"); kept ours`
+		if got := result.Conflicts[0].String(); got != want {
+			t.Errorf("conflict = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("it takes closed from theirs when theirs status wins", func(t *testing.T) {
+		base := newTask("tick-aaa111", "Task")
+		ours := base
+		theirs := base
+		theirs.Status = task.StatusDone
+		closed := t1
+		theirs.Closed = &closed
+
+		result := Merge([]task.Task{base}, []task.Task{ours}, []task.Task{theirs}, task.DefaultWorkflow())
+
+		got := result.Tasks[0]
+		if got.Status != task.StatusDone {
+			t.Errorf("status = %q, want done", got.Status)
+		}
+		if got.Closed == nil || !got.Closed.Equal(t1) {
+			t.Errorf("closed = %v, want %v", got.Closed, t1)
+		}
+	})
+
+	t.Run("it takes a due date set on one side and reports conflicting due dates", func(t *testing.T) {
+		base := newTask("tick-aaa111", "Task")
+		ours := base
+		theirs := base
+		theirs.Due = &t1
+
+		result := Merge([]task.Task{base}, []task.Task{ours}, []task.Task{theirs}, task.DefaultWorkflow())
+
+		if len(result.Conflicts) != 0 {
+			t.Fatalf("conflicts = %v, want none", result.Conflicts)
+		}
+		if got := result.Tasks[0].Due; got == nil || !got.Equal(t1) {
+			t.Errorf("due = %v, want %v", got, t1)
+		}
+
+		ours.Due = &t2
+		result = Merge([]task.Task{base}, []task.Task{ours}, []task.Task{theirs}, task.DefaultWorkflow())
+
+		if len(result.Conflicts) != 1 || result.Conflicts[0].Field != "due" {
+			t.Fatalf("conflicts = %v, want one due conflict", result.Conflicts)
+		}
+		if got := result.Tasks[0].Due; got == nil || !got.Equal(t2) {
+			t.Errorf("due = %v, want ours %v", got, t2)
+		}
+	})
+
+	t.Run("it takes an estimate changed on one side and reports conflicting estimates", func(t *testing.T) {
+		base := newTask("tick-aaa111", "Task")
+		base.Estimate = 3
+		ours := base
+		theirs := base
+		theirs.Estimate = 5
+
+		result := Merge([]task.Task{base}, []task.Task{ours}, []task.Task{theirs}, task.DefaultWorkflow())
+
+		if len(result.Conflicts) != 0 {
+			t.Fatalf("conflicts = %v, want none", result.Conflicts)
+		}
+		if got := result.Tasks[0].Estimate; got != 5 {
+			t.Errorf("estimate = %v, want 5", got)
+		}
+
+		ours.Estimate = 2.5
+		result = Merge([]task.Task{base}, []task.Task{ours}, []task.Task{theirs}, task.DefaultWorkflow())
+
+		if len(result.Conflicts) != 1 || result.Conflicts[0].Field != "estimate" {
+			t.Fatalf("conflicts = %v, want one estimate conflict", result.Conflicts)
+		}
+		if result.Conflicts[0].Ours != "2.5" || result.Conflicts[0].Theirs != "5" {
+			t.Errorf("conflict = %+v, want ours 2.5 and theirs 5", result.Conflicts[0])
+		}
+		if got := result.Tasks[0].Estimate; got != 2.5 {
+			t.Errorf("estimate = %v, want ours 2.5", got)
+		}
+	})
+
+	t.Run("it keeps the later lease and drops leases once the task is closed", func(t *testing.T) {
+		base := newTask("tick-aaa111", "Task")
+		base.Status = task.StatusInProgress
+		base.Assignee = "agent-1"
+		lease0, lease1, lease2 := t0, t1, t2
+		base.LeaseExpires = &lease0
+		ours := base
+		ours.LeaseExpires = &lease2
+		theirs := base
+		theirs.LeaseExpires = &lease1
+
+		got := Merge([]task.Task{base}, []task.Task{ours}, []task.Task{theirs}, task.DefaultWorkflow()).Tasks[0]
+		if got.Assignee != "agent-1" || got.LeaseExpires == nil || !got.LeaseExpires.Equal(t2) {
+			t.Errorf("assignee = %q, lease = %v; want agent-1 until %v", got.Assignee, got.LeaseExpires, t2)
+		}
+
+		theirs.Status = task.StatusDone
+		theirs.LeaseExpires = nil
+		got = Merge([]task.Task{base}, []task.Task{ours}, []task.Task{theirs}, task.DefaultWorkflow()).Tasks[0]
+		if got.Status != task.StatusDone || got.LeaseExpires != nil {
+			t.Errorf("status = %q, lease = %v; want done without a lease", got.Status, got.LeaseExpires)
+		}
+	})
+
+	t.Run("it keeps the lease in any started state of the workflow", func(t *testing.T) {
+		base := newTask("tick-aaa111", "Task")
+		base.Status = task.StatusInProgress
+		base.Assignee = "agent-1"
+		lease := t1
+		base.LeaseExpires = &lease
+		ours := base
+		ours.Status = "in_review"
+		theirs := base
+		theirs.Title = "Renamed"
+
+		got := Merge([]task.Task{base}, []task.Task{ours}, []task.Task{theirs}, reviewWorkflow()).Tasks[0]
+		if got.Status != "in_review" || got.LeaseExpires == nil || !got.LeaseExpires.Equal(t1) {
+			t.Errorf("status = %q, lease = %v; want in_review until %v", got.Status, got.LeaseExpires, t1)
+		}
+	})
+
+	t.Run("it unions notes and transitions from both sides in time order", func(t *testing.T) {
+		base := newTask("tick-aaa111", "Task")
+		base.Notes = []task.Note{{Text: "base", Created: t0}}
+		ours := base
+		ours.Notes = append([]task.Note{}, base.Notes...)
+		ours.Notes = append(ours.Notes, task.Note{Text: "ours", Created: t2})
+		ours.Transitions = []task.TransitionRecord{{From: task.StatusOpen, To: task.StatusInProgress, At: t2}}
+		theirs := base
+		theirs.Notes = append([]task.Note{}, base.Notes...)
+		theirs.Notes = append(theirs.Notes, task.Note{Text: "theirs", Created: t1})
+
+		result := Merge([]task.Task{base}, []task.Task{ours}, []task.Task{theirs}, task.DefaultWorkflow())
+
+		got := result.Tasks[0]
+		var texts []string
+		for _, n := range got.Notes {
+			texts = append(texts, n.Text)
+		}
+		if strings.Join(texts, ",") != "base,theirs,ours" {
+			t.Errorf("notes = %v, want base,theirs,ours", texts)
+		}
+		if len(got.Transitions) != 1 {
+			t.Errorf("transitions = %d, want 1", len(got.Transitions))
+		}
+	})
+
+	t.Run("it honours a tag removal on one side while keeping additions from the other", func(t *testing.T) {
+		base := newTask("tick-aaa111", "Task")
+		base.Tags = []string{"api", "backend"}
+		ours := base
+		ours.Tags = []string{"backend"}
+		theirs := base
+		theirs.Tags = []string{"api", "backend", "urgent"}
+
+		result := Merge([]task.Task{base}, []task.Task{ours}, []task.Task{theirs}, task.DefaultWorkflow())
+
+		if got := strings.Join(result.Tasks[0].Tags, ","); got != "backend,urgent" {
+			t.Errorf("tags = %s, want backend,urgent", got)
+		}
+	})
+
+	t.Run("it merges unrecognized fields per field and reports conflicting values", func(t *testing.T) {
+		base := newTask("tick-aaa111", "Task")
+		base.Extra = map[string]json.RawMessage{"milestone": json.RawMessage(`3`), "owner": json.RawMessage(`"ann"`)}
+		ours := base
+		ours.Extra = map[string]json.RawMessage{"milestone": json.RawMessage(`5`), "owner": json.RawMessage(`"ann"`), "sprint": json.RawMessage(`12`)}
+		theirs := base
+		theirs.Extra = map[string]json.RawMessage{"milestone": json.RawMessage(`8`)}
+
+		result := Merge([]task.Task{base}, []task.Task{ours}, []task.Task{theirs}, task.DefaultWorkflow())
+
+		got := result.Tasks[0].Extra
+		if len(got) != 2 || string(got["milestone"]) != "5" || string(got["sprint"]) != "12" {
+			t.Errorf("extra = %v, want milestone 5 and sprint 12 with owner removed", got)
+		}
+		if len(result.Conflicts) != 1 || result.Conflicts[0].Field != "milestone" {
+			t.Errorf("conflicts = %v, want one milestone conflict", result.Conflicts)
+		}
+	})
+
+	t.Run("it merges custom fields per key and reports conflicting values", func(t *testing.T) {
+		base := newTask("tick-aaa111", "Task")
+		base.Fields = map[string]string{"estimate": "3d", "sprint": "12"}
+		ours := base
+		ours.Fields = map[string]string{"estimate": "5d", "sprint": "12", "component": "api"}
+		theirs := base
+		theirs.Fields = map[string]string{"estimate": "8d"}
+
+		result := Merge([]task.Task{base}, []task.Task{ours}, []task.Task{theirs}, task.DefaultWorkflow())
+
+		got := result.Tasks[0].Fields
+		if len(got) != 2 || got["estimate"] != "5d" || got["component"] != "api" {
+			t.Errorf("fields = %v, want estimate 5d and component api with sprint removed", got)
+		}
+		if len(result.Conflicts) != 1 || result.Conflicts[0].Field != "fields.estimate" {
+			t.Errorf("conflicts = %v, want one fields.estimate conflict", result.Conflicts)
+		}
+	})
+
+	t.Run("it drops a task removed on one side and unchanged on the other", func(t *testing.T) {
+		base := []task.Task{newTask("tick-aaa111", "Keep"), newTask("tick-bbb222", "Gone")}
+		ours := []task.Task{base[0]}
+
+		result := Merge(base, ours, base, task.DefaultWorkflow())
+
+		if len(result.Tasks) != 1 || result.Tasks[0].ID != "tick-aaa111" {
+			t.Errorf("tasks = %v, want only tick-aaa111", result.Tasks)
+		}
+		if len(result.Conflicts) != 0 {
+			t.Errorf("conflicts = %v, want none", result.Conflicts)
+		}
+	})
+
+	t.Run("it keeps a task removed on one side but modified on the other and reports it", func(t *testing.T) {
+		base := []task.Task{newTask("tick-aaa111", "Task")}
+		theirs := []task.Task{base[0]}
+		theirs[0].Title = "Edited"
+
+		result := Merge(base, nil, theirs, task.DefaultWorkflow())
+
+		findTask(t, result.Tasks, "tick-aaa111")
+		if len(result.Conflicts) != 1 || !strings.Contains(result.Conflicts[0].Reason, "removed in ours") {
+			t.Errorf("conflicts = %v, want removed-in-ours conflict", result.Conflicts)
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("it reports a cycle formed by dependencies added on different sides", func(t *testing.T) {
+		a := newTask("tick-aaa111", "A")
+		b := newTask("tick-bbb222", "B")
+		base := []task.Task{a, b}
+		oursA := a
+		oursA.BlockedBy = []string{"tick-bbb222"}
+		theirsB := b
+		theirsB.BlockedBy = []string{"tick-aaa111"}
+
+		result := Merge(base, []task.Task{oursA, b}, []task.Task{a, theirsB}, task.DefaultWorkflow())
+
+		found := false
+		for _, c := range result.Conflicts {
+			if strings.Contains(c.Reason, "creates cycle") {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("conflicts = %v, want a cycle conflict", result.Conflicts)
+		}
+	})
+
+	t.Run("it reports a dependency on a task removed by the other side", func(t *testing.T) {
+		a := newTask("tick-aaa111", "A")
+		b := newTask("tick-bbb222", "B")
+		base := []task.Task{a, b}
+		oursA := a
+		oursA.BlockedBy = []string{"tick-bbb222"}
+
+		result := Merge(base, []task.Task{oursA, b}, []task.Task{a}, task.DefaultWorkflow())
+
+		found := false
+		for _, c := range result.Conflicts {
+			if strings.Contains(c.Reason, "missing task tick-bbb222") {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("conflicts = %v, want missing-task conflict", result.Conflicts)
+		}
+	})
+
+	t.Run("it reports an open child added under a parent completed on the other side", func(t *testing.T) {
+		parent := newTask("tick-aaa111", "Parent")
+		child := newTask("tick-bbb222", "Child")
+		child.Parent = "tick-aaa111"
+		theirsParent := parent
+		theirsParent.Status = task.StatusDone
+
+		result := Merge([]task.Task{parent}, []task.Task{parent, child}, []task.Task{theirsParent}, task.DefaultWorkflow())
+
+		found := false
+		for _, c := range result.Conflicts {
+			if strings.Contains(c.Reason, "is done but child is open") {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("conflicts = %v, want done-parent conflict", result.Conflicts)
+		}
+	})
+
+	t.Run("it reports a live child under a parent in a completing workflow state", func(t *testing.T) {
+		parent := newTask("tick-aaa111", "Parent")
+		child := newTask("tick-bbb222", "Child")
+		child.Parent = "tick-aaa111"
+		child.Status = "in_review"
+		theirsParent := parent
+		theirsParent.Status = "shipped"
+
+		result := Merge([]task.Task{parent}, []task.Task{parent, child}, []task.Task{theirsParent}, reviewWorkflow())
+
+		found := false
+		for _, c := range result.Conflicts {
+			if strings.Contains(c.Reason, "parent tick-aaa111 is shipped but child is in_review") {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("conflicts = %v, want completed-parent conflict", result.Conflicts)
+		}
+	})
+
+	t.Run("it does not re-validate dependencies present on both sides", func(t *testing.T) {
+		a := newTask("tick-aaa111", "A")
+		b := newTask("tick-bbb222", "B")
+		b.Status = task.StatusCancelled
+		a.BlockedBy = []string{"tick-bbb222"}
+		tasks := []task.Task{a, b}
+
+		result := Merge(tasks, tasks, tasks, task.DefaultWorkflow())
+
+		if len(result.Conflicts) != 0 {
+			t.Errorf("conflicts = %v, want none", result.Conflicts)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package merge
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// Validate checks merged tasks against the state machine rules of workflow w
+// that could only have been broken by combining both sides: each side was valid
+// on its own, so only relationships introduced by exactly one side are
+// re-checked against the merged graph. Checked rules:
+//   - blocked_by and parent must reference existing tasks
+//   - new dependencies must pass StateMachine.ValidateAddDep (no cycles, no
+//     child blocked by its parent, no cancelled blocker)
+//   - new children must pass StateMachine.ValidateAddChild (no cancelled parent)
+//   - a completed parent must not have non-terminal children
+func Validate(merged, ours, theirs []task.Task, w task.Workflow) []Conflict {
+	sm := task.StateMachine{Workflow: w}
+	var conflicts []Conflict
+
+	idx := indexByID(merged)
+	oursIdx := indexByID(ours)
+	theirsIdx := indexByID(theirs)
+
+	for i := range merged {
+		t := &merged[i]
+		id := task.NormalizeID(t.ID)
+		o, inOurs := oursIdx[id]
+		th, inTheirs := theirsIdx[id]
+		fromBoth := inOurs && inTheirs
+
+		for _, dep := range t.BlockedBy {
+			depID := task.NormalizeID(dep)
+			if _, ok := idx[depID]; !ok {
+				conflicts = append(conflicts, Conflict{
+					ID:     t.ID,
+					Reason: fmt.Sprintf("blocked_by references missing task %s", dep),
+				})
+				continue
+			}
+			if fromBoth && containsID(o.BlockedBy, depID) && containsID(th.BlockedBy, depID) {
+				continue
+			}
+			others := withoutDep(merged, i, depID)
+			if err := sm.ValidateAddDep(others, t.ID, dep); err != nil {
+				conflicts = append(conflicts, Conflict{ID: t.ID, Reason: err.Error()})
+			}
+		}
+
+		if t.Parent == "" {
+			continue
+		}
+		parent, ok := idx[task.NormalizeID(t.Parent)]
+		if !ok {
+			conflicts = append(conflicts, Conflict{
+				ID:     t.ID,
+				Reason: fmt.Sprintf("parent references missing task %s", t.Parent),
+			})
+			continue
+		}
+		sameParent := fromBoth && task.NormalizeID(o.Parent) == task.NormalizeID(th.Parent)
+		if !sameParent {
+			if err := sm.ValidateAddChild(&parent); err != nil && !w.IsCancelled(t.Status) {
+				conflicts = append(conflicts, Conflict{ID: t.ID, Reason: err.Error()})
+			}
+		}
+		if w.IsCompleted(parent.Status) && !w.IsTerminal(t.Status) {
+			conflicts = append(conflicts, Conflict{
+				ID:     t.ID,
+				Reason: fmt.Sprintf("parent %s is %s but child is %s", parent.ID, parent.Status, t.Status),
+			})
+		}
+	}
+
+	return conflicts
+}
+
+// containsID reports whether ids contains id, compared case-insensitively.
+func containsID(ids []string, id string) bool {
+	return slices.ContainsFunc(ids, func(s string) bool { return task.NormalizeID(s) == id })
+}
+
+// withoutDep returns a copy of tasks where tasks[i] no longer lists depID in
+// blocked_by, so the edge can be re-validated as if it were being added.
+func withoutDep(tasks []task.Task, i int, depID string) []task.Task {
+	out := slices.Clone(tasks)
+	out[i].BlockedBy = slices.DeleteFunc(slices.Clone(out[i].BlockedBy), func(s string) bool {
+		return task.NormalizeID(s) == depID
+	})
+	return out
+}
@@ -24,7 +24,8 @@ var statusMap = map[string]task.Status{
 
 // beadsIssue is the intermediate struct for JSON unmarshalling of a single
 // beads issue line. Fields with no tick equivalent are parsed but discarded
-// during mapping.
+// during mapping. The issue type maps to the task type as is, so a project
+// that declares beads types such as epic keeps them.
 type beadsIssue struct {
 	ID           string `json:"id"`
 	Title        string `json:"title"`
@@ -124,6 +125,7 @@ func mapToMigratedTask(issue beadsIssue) migrate.MigratedTask {
 		Title:       issue.Title,
 		Description: issue.Description,
 		Status:      status,
+		Type:        task.NormalizeType(issue.IssueType),
 		Created:     created,
 		Updated:     updated,
 		Closed:      closed,
@@ -405,7 +405,7 @@ not valid json
 		}
 	})
 
-	t.Run("Tasks discards id issue_type close_reason created_by dependencies fields", func(t *testing.T) {
+	t.Run("Tasks discards id close_reason created_by dependencies fields", func(t *testing.T) {
 		content := `{"id":"b-001","title":"Preserved task","description":"kept","status":"pending","priority":1,"issue_type":"epic","close_reason":"completed","created_by":"alice","dependencies":["b-002"]}`
 		baseDir := setupBeadsDir(t, content)
 		p := NewBeadsProvider(baseDir)
@@ -417,7 +417,7 @@ not valid json
 		if len(tasks) != 1 {
 			t.Fatalf("expected 1 task, got %d", len(tasks))
 		}
-		// MigratedTask has no fields for id, issue_type, close_reason, created_by, or dependencies.
+		// MigratedTask has no fields for id, close_reason, created_by, or dependencies.
 		// We verify the kept fields are correct, which implicitly proves discarded fields didn't interfere.
 		tk := tasks[0]
 		if tk.Title != "Preserved task" {
@@ -432,6 +432,9 @@ not valid json
 		if tk.Priority == nil || *tk.Priority != 1 {
 			t.Errorf("Priority = %v, want 1", tk.Priority)
 		}
+		if tk.Type != "epic" {
+			t.Errorf("Type = %q, want %q", tk.Type, "epic")
+		}
 	})
 
 	t.Run("Tasks maps description field to MigratedTask Description", func(t *testing.T) {
@@ -32,6 +32,7 @@ type MigratedTask struct {
 	Status      task.Status
 	Priority    *int // nil means "not provided"; defaults applied at insertion time
 	Description string
+	Type        string // source type such as "bug"; dropped if the project does not allow it
 	Created     time.Time
 	Updated     time.Time
 	Closed      time.Time
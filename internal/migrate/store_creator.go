@@ -4,6 +4,7 @@ import (
 	"cmp"
 	"time"
 
+	"github.com/leeovery/tick/internal/config"
 	"github.com/leeovery/tick/internal/task"
 )
 
@@ -17,19 +18,34 @@ type Mutator interface {
 // into tick's data store via the Mutator interface.
 type StoreTaskCreator struct {
 	store Mutator
+	// rules give the ID prefix and default priority of created tasks.
+	rules task.Rules
+}
+
+// configured is implemented by stores that carry a project configuration, such
+// as storage.Store.
+type configured interface {
+	Config() config.Config
 }
 
 // Compile-time check that StoreTaskCreator satisfies TaskCreator.
 var _ TaskCreator = (*StoreTaskCreator)(nil)
 
 // NewStoreTaskCreator creates a StoreTaskCreator that writes to the given store.
+// Tasks follow the store's configuration when it has one, and the default rules
+// otherwise.
 func NewStoreTaskCreator(store Mutator) *StoreTaskCreator {
-	return &StoreTaskCreator{store: store}
+	rules := task.DefaultRules()
+	if cs, ok := store.(configured); ok {
+		rules = cs.Config().Rules()
+	}
+	return &StoreTaskCreator{store: store, rules: rules}
 }
 
-// CreateTask generates a tick ID, applies defaults to the MigratedTask fields,
-// builds a tick-core Task, and persists it via the store. Returns the generated
-// ID or an error if persistence fails.
+// CreateTask generates a tick ID, applies defaults to the MigratedTask fields
+// (dropping a type the project does not allow), builds a tick-core Task, and
+// persists it via the store. Returns the generated ID or an error if
+// persistence fails.
 func (c *StoreTaskCreator) CreateTask(mt MigratedTask) (string, error) {
 	var generatedID string
 
@@ -43,7 +59,7 @@ func (c *StoreTaskCreator) CreateTask(mt MigratedTask) (string, error) {
 			return idSet[id]
 		}
 
-		id, err := task.GenerateID(exists)
+		id, err := c.rules.GenerateID(exists)
 		if err != nil {
 			return nil, err
 		}
@@ -52,7 +68,12 @@ func (c *StoreTaskCreator) CreateTask(mt MigratedTask) (string, error) {
 		// Apply defaults.
 		status := cmp.Or(mt.Status, task.StatusOpen)
 
-		priority := 2
+		taskType := task.NormalizeType(mt.Type)
+		if c.rules.ValidateType(taskType) != nil {
+			taskType = ""
+		}
+
+		priority := c.rules.PriorityFor(taskType)
 		if mt.Priority != nil {
 			priority = *mt.Priority
 		}
@@ -78,6 +99,7 @@ func (c *StoreTaskCreator) CreateTask(mt MigratedTask) (string, error) {
 			Title:       mt.Title,
 			Status:      status,
 			Priority:    priority,
+			Type:        taskType,
 			Description: mt.Description,
 			Created:     created,
 			Updated:     updated,
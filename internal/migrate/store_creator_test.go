@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/leeovery/tick/internal/config"
 	"github.com/leeovery/tick/internal/task"
 )
 
@@ -258,4 +259,40 @@ func TestStoreTaskCreator(t *testing.T) {
 			t.Errorf("priority = %d, want 0", tk.Priority)
 		}
 	})
+	t.Run("StoreTaskCreator keeps types the project allows and drops the rest", func(t *testing.T) {
+		cfg := config.Default()
+		cfg.Types = append(cfg.Types, config.TypeDef{Name: "epic", Priority: new(1)})
+		store := &configuredStore{config: cfg}
+		creator := NewStoreTaskCreator(store)
+
+		for _, mt := range []MigratedTask{
+			{Title: "Epic", Type: "Epic"},
+			{Title: "Molecule", Type: "molecule"},
+			{Title: "Bug", Type: "bug", Priority: new(0)},
+		} {
+			if _, err := creator.CreateTask(mt); err != nil {
+				t.Fatalf("CreateTask returned error: %v", err)
+			}
+			store.tasks = store.mutated
+		}
+
+		got := store.mutated
+		if got[0].Type != "epic" || got[0].Priority != 1 {
+			t.Errorf("epic task = type %q priority %d, want epic with its type's priority 1", got[0].Type, got[0].Priority)
+		}
+		if got[1].Type != "" || got[1].Priority != 2 {
+			t.Errorf("molecule task = type %q priority %d, want no type and priority 2", got[1].Type, got[1].Priority)
+		}
+		if got[2].Type != "bug" || got[2].Priority != 0 {
+			t.Errorf("bug task = type %q priority %d, want bug and priority 0", got[2].Type, got[2].Priority)
+		}
+	})
 }
+
+// configuredStore is a mockStore that carries a project configuration.
+type configuredStore struct {
+	mockStore
+	config config.Config
+}
+
+func (s *configuredStore) Config() config.Config { return s.config }
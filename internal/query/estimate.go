@@ -0,0 +1,90 @@
+package query
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Rollup totals the estimates of a group of tasks.
+type Rollup struct {
+	// Total sums every estimate, closed tasks included.
+	Total float64
+	// Remaining sums the estimates of the tasks that are not closed.
+	Remaining float64
+}
+
+// TaskRollup is the rollup of a task together with all of its descendants.
+type TaskRollup struct {
+	ID    string
+	Title string
+	Rollup
+}
+
+// rollupColumns sums the estimate column of the tasks aliased as "t" into the
+// Total and Remaining of a Rollup. Unestimated tasks are NULL and add nothing.
+const rollupColumns = `COALESCE(SUM(t.estimate), 0), COALESCE(SUM(CASE WHEN t.closed IS NULL THEN t.estimate END), 0)`
+
+// EstimateRollup sums the estimates of every task in the cache.
+func EstimateRollup(db *sql.DB) (Rollup, error) {
+	var r Rollup
+	if err := db.QueryRow(`SELECT `+rollupColumns+` FROM tasks t`).Scan(&r.Total, &r.Remaining); err != nil {
+		return Rollup{}, fmt.Errorf("failed to query estimates: %w", err)
+	}
+	return r, nil
+}
+
+// SubtreeRollup sums the estimates of the task with the given ID and all of
+// its descendants.
+func SubtreeRollup(db *sql.DB, id string) (Rollup, error) {
+	const subtreeCTE = `
+		WITH RECURSIVE subtree(id) AS (
+			SELECT id FROM tasks WHERE id = ?
+			UNION
+			SELECT t.id FROM tasks t
+			JOIN subtree s ON t.parent = s.id
+		)
+		SELECT ` + rollupColumns + ` FROM subtree s JOIN tasks t ON t.id = s.id`
+
+	var r Rollup
+	if err := db.QueryRow(subtreeCTE, id).Scan(&r.Total, &r.Remaining); err != nil {
+		return Rollup{}, fmt.Errorf("failed to query estimate rollup: %w", err)
+	}
+	return r, nil
+}
+
+// RootRollups returns the rollup of every top-level task that has children
+// and an estimate somewhere in its subtree, most remaining work first, then by
+// ID. A task whose parent is missing counts as top-level.
+func RootRollups(db *sql.DB) ([]TaskRollup, error) {
+	const rootCTE = `
+		WITH RECURSIVE subtree(id, root) AS (
+			SELECT id, id FROM tasks
+			WHERE parent IS NULL OR parent NOT IN (SELECT id FROM tasks)
+			UNION ALL
+			SELECT t.id, s.root FROM tasks t
+			JOIN subtree s ON t.parent = s.id
+		)
+		SELECT root.id, root.title, ` + rollupColumns + `
+		FROM subtree s
+		JOIN tasks t ON t.id = s.id
+		JOIN tasks root ON root.id = s.root
+		GROUP BY root.id
+		HAVING COUNT(*) > 1 AND SUM(t.estimate) > 0
+		ORDER BY 4 DESC, root.id`
+
+	rows, err := db.Query(rootCTE)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query estimate rollups: %w", err)
+	}
+	defer rows.Close()
+
+	var rollups []TaskRollup
+	for rows.Next() {
+		var r TaskRollup
+		if err := rows.Scan(&r.ID, &r.Title, &r.Total, &r.Remaining); err != nil {
+			return nil, fmt.Errorf("failed to scan estimate rollup: %w", err)
+		}
+		rollups = append(rollups, r)
+	}
+	return rollups, rows.Err()
+}
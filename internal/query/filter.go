@@ -0,0 +1,287 @@
+package query
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// Filter holds the structured filters for listing tasks.
+type Filter struct {
+	Ready    bool
+	Blocked  bool
+	Status   string
+	Priority int
+	// HasPriority indicates whether Priority was explicitly set.
+	HasPriority bool
+	// Parent restricts results to descendants of the specified task ID.
+	Parent string
+	// Type restricts results to tasks of the specified type (e.g. "bug", "feature").
+	Type string
+	// TagGroups holds tag filter groups. Tags within a group are AND'd; the
+	// groups are OR'd together.
+	TagGroups [][]string
+	// Fields restricts results to tasks matching every field filter.
+	Fields []FieldFilter
+	// Assignee restricts results to tasks assigned to the named owner.
+	Assignee string
+	// Unassigned restricts results to tasks with no assignee.
+	Unassigned bool
+	// IncludeDeferred counts tasks deferred into the future as ready rather
+	// than blocked.
+	IncludeDeferred bool
+	// Overdue restricts results to tasks that are not closed and whose due date
+	// is before Now.
+	Overdue bool
+	// Now is the instant deferral and overdue are judged at; zero means the
+	// current time.
+	Now time.Time
+	// DueBefore restricts results to tasks due at or before it.
+	DueBefore time.Time
+	// DueAfter restricts results to tasks due after it.
+	DueAfter time.Time
+	// Recurring restricts results to tasks with a recurrence rule.
+	Recurring bool
+	// Count limits the number of results returned.
+	Count int
+	// HasCount indicates whether Count was explicitly set.
+	HasCount bool
+}
+
+// DeferredAt returns the instant the filter judges deferral at, given now as
+// the current time: now, or zero when IncludeDeferred ignores deferral.
+func (f Filter) DeferredAt(now time.Time) time.Time {
+	if f.IncludeDeferred {
+		return time.Time{}
+	}
+	return now
+}
+
+// FieldFilter matches tasks by a custom field. An empty Value matches any task
+// that has the field set.
+type FieldFilter struct {
+	Key   string
+	Value string
+}
+
+// Validate checks the filter's values: Ready and Blocked are mutually
+// exclusive, as are Assignee and Unassigned, and the priority, count and
+// assignee must be valid. The status, type and
+// tags depend on the project; see ValidateFor.
+func (f Filter) Validate() error {
+	if f.Ready && f.Blocked {
+		return fmt.Errorf("--ready and --blocked are mutually exclusive")
+	}
+
+	if f.Assignee != "" && f.Unassigned {
+		return fmt.Errorf("--assignee and --unassigned are mutually exclusive")
+	}
+	if f.Assignee != "" {
+		if err := task.ValidateAssignee(f.Assignee); err != nil {
+			return err
+		}
+	}
+
+	if f.HasPriority {
+		if f.Priority < 0 || f.Priority > 4 {
+			return fmt.Errorf("invalid priority '%d': must be 0-4", f.Priority)
+		}
+	}
+
+	if f.HasCount && f.Count < 1 {
+		return fmt.Errorf("invalid count '%d': must be >= 1", f.Count)
+	}
+
+	return nil
+}
+
+// ValidateFor checks the filter as Validate does, and that its status, type
+// and tags are valid under the rules of the project it queries. Field keys
+// must be valid; see task.ValidateFieldKey.
+func (f Filter) ValidateFor(rules task.Rules) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+
+	if f.Status != "" {
+		if _, ok := rules.Workflow.State(task.Status(f.Status)); !ok {
+			return fmt.Errorf("invalid status '%s': must be one of %s", f.Status, strings.Join(rules.Workflow.StateNames(), ", "))
+		}
+	}
+
+	if err := rules.ValidateType(f.Type); err != nil {
+		return err
+	}
+
+	for _, group := range f.TagGroups {
+		for _, tag := range group {
+			if err := rules.ValidateTag(tag); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, ff := range f.Fields {
+		if err := task.ValidateFieldKey(ff.Key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Conditions returns the WHERE conditions and args for the filter's structured
+// fields, against the tasks table aliased as t. descendantIDs restricts results
+// when Parent is set; see DescendantIDs. Ready and blocked follow the state
+// categories of w.
+func Conditions(f Filter, descendantIDs []string, w task.Workflow) ([]string, []any) {
+	var conditions []string
+	var args []any
+
+	now := f.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	deferredAt := f.DeferredAt(now)
+
+	if f.Ready {
+		conditions = append(conditions, ReadyConditions(w, deferredAt)...)
+	}
+
+	if f.Blocked {
+		conditions = append(conditions, BlockedConditions(w, deferredAt)...)
+	}
+
+	if f.Status != "" {
+		conditions = append(conditions, `t.status = ?`)
+		args = append(args, f.Status)
+	}
+
+	if f.HasPriority {
+		conditions = append(conditions, `t.priority = ?`)
+		args = append(args, f.Priority)
+	}
+
+	if f.Type != "" {
+		conditions = append(conditions, `t.type = ?`)
+		args = append(args, f.Type)
+	}
+
+	if len(f.TagGroups) > 0 {
+		tagCondition, tagArgs := tagFilterSQL(f.TagGroups)
+		conditions = append(conditions, tagCondition)
+		args = append(args, tagArgs...)
+	}
+
+	if f.Assignee != "" {
+		conditions = append(conditions, `t.assignee = ?`)
+		args = append(args, f.Assignee)
+	}
+
+	if f.Unassigned {
+		conditions = append(conditions, `(t.assignee IS NULL OR t.assignee = '')`)
+	}
+
+	if f.Overdue {
+		overdue, overdueArgs := OverdueConditions(now)
+		conditions = append(conditions, overdue...)
+		args = append(args, overdueArgs...)
+	}
+
+	if !f.DueBefore.IsZero() {
+		conditions = append(conditions, `t.due <= ?`)
+		args = append(args, task.FormatTimestamp(f.DueBefore))
+	}
+
+	if !f.DueAfter.IsZero() {
+		conditions = append(conditions, `t.due > ?`)
+		args = append(args, task.FormatTimestamp(f.DueAfter))
+	}
+
+	if f.Recurring {
+		conditions = append(conditions, `t.recur IS NOT NULL`)
+	}
+
+	for _, ff := range f.Fields {
+		if ff.Value == "" {
+			conditions = append(conditions, `t.id IN (SELECT task_id FROM task_fields WHERE key = ?)`)
+			args = append(args, ff.Key)
+			continue
+		}
+		conditions = append(conditions, `t.id IN (SELECT task_id FROM task_fields WHERE key = ? AND value = ?)`)
+		args = append(args, ff.Key, ff.Value)
+	}
+
+	if len(descendantIDs) > 0 {
+		placeholders := make([]string, len(descendantIDs))
+		for i, id := range descendantIDs {
+			placeholders[i] = "?"
+			args = append(args, id)
+		}
+		conditions = append(conditions, `t.id IN (`+strings.Join(placeholders, ",")+`)`)
+	} else if f.Parent != "" {
+		// Parent exists but has no descendants: use impossible condition.
+		conditions = append(conditions, `1 = 0`)
+	}
+
+	return conditions, args
+}
+
+// tagFilterSQL generates a SQL condition and args for tag group filtering.
+// Each group is an AND (task must have all tags in the group). Multiple groups
+// are OR'd together: (group1) OR (group2).
+func tagFilterSQL(tagGroups [][]string) (string, []any) {
+	var groupClauses []string
+	var args []any
+
+	for _, group := range tagGroups {
+		placeholders := make([]string, len(group))
+		for i, tag := range group {
+			placeholders[i] = "?"
+			args = append(args, tag)
+		}
+		subquery := fmt.Sprintf(
+			`t.id IN (SELECT task_id FROM task_tags WHERE tag IN (%s) GROUP BY task_id HAVING COUNT(DISTINCT tag) = ?)`,
+			strings.Join(placeholders, ","),
+		)
+		args = append(args, len(group))
+		groupClauses = append(groupClauses, subquery)
+	}
+
+	if len(groupClauses) == 1 {
+		return groupClauses[0], args
+	}
+	return "(" + strings.Join(groupClauses, " OR ") + ")", args
+}
+
+// DescendantIDs executes a recursive CTE to collect all descendant task IDs
+// of the given parent ID. The parent itself is excluded from results.
+func DescendantIDs(db *sql.DB, parentID string) ([]string, error) {
+	const descendantCTE = `
+		WITH RECURSIVE descendants(id) AS (
+			SELECT id FROM tasks WHERE parent = ?
+			UNION ALL
+			SELECT t.id FROM tasks t
+			JOIN descendants d ON t.parent = d.id
+		)
+		SELECT id FROM descendants`
+
+	rows, err := db.Query(descendantCTE, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query descendants: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan descendant ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
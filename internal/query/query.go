@@ -0,0 +1,208 @@
+// Package query builds the SQL conditions over the SQLite cache that define
+// ready and blocked tasks and filter task lists.
+package query
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// statusList returns statuses as a SQL list of string literals, such as
+// ('open', 'in_progress'). Status names are validated snake_case, so they need
+// no escaping.
+func statusList(statuses []task.Status) string {
+	quoted := make([]string, len(statuses))
+	for i, s := range statuses {
+		quoted[i] = "'" + string(s) + "'"
+	}
+	return "(" + strings.Join(quoted, ", ") + ")"
+}
+
+// ReadyNoUnclosedBlockers returns the SQL NOT EXISTS subquery condition
+// that excludes tasks with unclosed (not terminal) blockers.
+// Assumes the outer query aliases the tasks table as "t".
+func ReadyNoUnclosedBlockers(w task.Workflow) string {
+	return `NOT EXISTS (
+				SELECT 1 FROM dependencies d
+				JOIN tasks blocker ON blocker.id = d.blocked_by
+				WHERE d.task_id = t.id
+				  AND blocker.status NOT IN ` + statusList(w.StatesIn(task.CategoryTerminal)) + `
+			)`
+}
+
+// ReadyNoOpenChildren returns the SQL NOT EXISTS subquery condition
+// that excludes tasks with live (active or waiting) children.
+// Assumes the outer query aliases the tasks table as "t".
+func ReadyNoOpenChildren(w task.Workflow) string {
+	return `NOT EXISTS (
+				SELECT 1 FROM tasks child
+				WHERE child.parent = t.id
+				  AND child.status IN ` + statusList(w.StatesIn(task.CategoryActive, task.CategoryWaiting)) + `
+			)`
+}
+
+// ReadyNoBlockedAncestor returns a SQL NOT EXISTS subquery with a recursive
+// CTE that walks the ancestor chain via parent pointers. It excludes tasks
+// where any ancestor has an unclosed dependency blocker.
+// Assumes the outer query aliases the tasks table as "t".
+func ReadyNoBlockedAncestor(w task.Workflow) string {
+	return `NOT EXISTS (
+				WITH RECURSIVE ancestors(id) AS (
+					SELECT parent FROM tasks WHERE id = t.id AND parent IS NOT NULL
+					UNION ALL
+					SELECT t2.parent FROM tasks t2
+					JOIN ancestors a ON t2.id = a.id
+					WHERE t2.parent IS NOT NULL
+				)
+				SELECT 1 FROM ancestors a
+				JOIN dependencies d ON d.task_id = a.id
+				JOIN tasks blocker ON blocker.id = d.blocked_by
+				WHERE blocker.status NOT IN ` + statusList(w.StatesIn(task.CategoryTerminal)) + `
+			)`
+}
+
+// ReadyNotDeferred returns the SQL condition that excludes tasks deferred past
+// the instant at. Timestamps are generated in a fixed format, so at is inlined
+// as a literal like the status lists.
+// Assumes the outer query aliases the tasks table as "t".
+func ReadyNotDeferred(at time.Time) string {
+	return `(t.defer_until IS NULL OR t.defer_until <= '` + task.FormatTimestamp(at) + `')`
+}
+
+// ReadyConditions returns the complete set of SQL WHERE conditions that
+// define a "ready" task at the instant at: an active status (open or
+// in_progress by default), no unclosed blockers, no live children, no
+// dependency-blocked ancestor, and not deferred past at. A zero at ignores
+// deferral.
+func ReadyConditions(w task.Workflow, at time.Time) []string {
+	conditions := []string{
+		`t.status IN ` + statusList(w.StatesIn(task.CategoryActive)),
+		ReadyNoUnclosedBlockers(w),
+		ReadyNoOpenChildren(w),
+		ReadyNoBlockedAncestor(w),
+	}
+	if !at.IsZero() {
+		conditions = append(conditions, ReadyNotDeferred(at))
+	}
+	return conditions
+}
+
+// negateNotExists converts a "NOT EXISTS (...)" condition to "EXISTS (...)"
+// by stripping the leading "NOT " prefix.
+func negateNotExists(s string) string {
+	return strings.TrimPrefix(s, "NOT ")
+}
+
+// The reasons a live task is blocked, as reported by BlockedReason.
+const (
+	// ReasonWaiting is a task in a waiting state.
+	ReasonWaiting = "waiting"
+	// ReasonBlockedBy is a task with unclosed blockers.
+	ReasonBlockedBy = "blocked_by"
+	// ReasonChildren is a task with live children.
+	ReasonChildren = "children"
+	// ReasonAncestor is a task under a dependency-blocked ancestor.
+	ReasonAncestor = "ancestor"
+	// ReasonDeferred is a task deferred into the future.
+	ReasonDeferred = "deferred"
+)
+
+// blockedCause pairs a reason a task can be blocked with the SQL condition
+// that detects it.
+type blockedCause struct {
+	reason    string
+	condition string
+}
+
+// blockedCauses returns the causes of a blocked task at the instant at, in the
+// order BlockedReason reports them: waiting state, unclosed blockers, live
+// children, dependency-blocked ancestor, then deferral. A zero at ignores
+// deferral.
+func blockedCauses(w task.Workflow, at time.Time) []blockedCause {
+	var causes []blockedCause
+	if waiting := w.StatesIn(task.CategoryWaiting); len(waiting) > 0 {
+		causes = append(causes, blockedCause{ReasonWaiting, `t.status IN ` + statusList(waiting)})
+	}
+	causes = append(causes,
+		blockedCause{ReasonBlockedBy, negateNotExists(ReadyNoUnclosedBlockers(w))},
+		blockedCause{ReasonChildren, negateNotExists(ReadyNoOpenChildren(w))},
+		blockedCause{ReasonAncestor, negateNotExists(ReadyNoBlockedAncestor(w))},
+	)
+	if !at.IsZero() {
+		causes = append(causes, blockedCause{ReasonDeferred, DeferredCondition(at)})
+	}
+	return causes
+}
+
+// BlockedConditions returns the SQL WHERE conditions that define a "blocked"
+// task at the instant at: live status (active or waiting) AND (in a waiting
+// state OR has unclosed blockers OR has live children OR has dependency-blocked
+// ancestor OR is deferred past at). This is the De Morgan inverse of the ready
+// conditions, derived from the ReadyNo*() helpers over the live status gate,
+// so every live task is either ready or blocked. A zero at ignores deferral.
+func BlockedConditions(w task.Workflow, at time.Time) []string {
+	var parts []string
+	for _, c := range blockedCauses(w, at) {
+		parts = append(parts, c.condition)
+	}
+	return []string{
+		`t.status IN ` + statusList(w.StatesIn(task.CategoryActive, task.CategoryWaiting)),
+		"(" + strings.Join(parts, "\n\t\t\t\tOR ") + ")",
+	}
+}
+
+// BlockedReason returns a SQL CASE expression that evaluates to the reason a
+// task matching BlockedConditions(w, at) is blocked: one of the Reason*
+// constants, taking the first that applies in the order waiting, blocked_by,
+// children, ancestor, deferred. It is NULL for a task that is not blocked.
+// Assumes the outer query aliases the tasks table as "t".
+func BlockedReason(w task.Workflow, at time.Time) string {
+	var b strings.Builder
+	b.WriteString("CASE")
+	for _, c := range blockedCauses(w, at) {
+		b.WriteString("\n\t\t\tWHEN " + c.condition + " THEN '" + c.reason + "'")
+	}
+	b.WriteString("\n\t\tEND")
+	return b.String()
+}
+
+// DeferredCondition returns the SQL condition that matches tasks deferred
+// past the instant at: the negation of ReadyNotDeferred.
+func DeferredCondition(at time.Time) string {
+	return `t.defer_until > '` + task.FormatTimestamp(at) + `'`
+}
+
+// OverdueConditions returns the SQL WHERE conditions, and their args, that
+// define a task overdue at the instant at: not closed, with a due date before
+// at. Due dates are stored as UTC timestamps, so they compare as strings.
+func OverdueConditions(at time.Time) ([]string, []any) {
+	return []string{`t.closed IS NULL`, `t.due < ?`}, []any{task.FormatTimestamp(at)}
+}
+
+// StartedFirst returns the SQL ORDER BY term that puts tasks in a started state
+// of w (see Workflow.StartedStates), such as in_progress, before the others.
+// Assumes the outer query aliases the tasks table as "t".
+func StartedFirst(w task.Workflow) string {
+	return `(t.status IN ` + statusList(w.StartedStates()) + `) DESC`
+}
+
+// ClaimableCondition returns the SQL condition, and its args, that matches tasks
+// agent can claim at the instant at: open and unassigned or assigned to agent,
+// or in a started state with a lease expired at at.
+// Assumes the outer query aliases the tasks table as "t".
+func ClaimableCondition(w task.Workflow, agent string, at time.Time) (string, []any) {
+	unstarted := slices.DeleteFunc(w.StatesIn(task.CategoryActive), w.IsStarted)
+	return `((t.status IN ` + statusList(unstarted) + ` AND (t.assignee IS NULL OR t.assignee = '' OR t.assignee = ?))` +
+			` OR (t.status IN ` + statusList(w.StartedStates()) + ` AND t.lease_expires <= ?))`,
+		[]any{agent, task.FormatTimestamp(at)}
+}
+
+// ReadyWhereClause returns the ready conditions at the instant at joined as a
+// single SQL WHERE clause fragment (without the WHERE keyword), suitable for
+// embedding in larger queries like the stats ready count.
+func ReadyWhereClause(w task.Workflow, at time.Time) string {
+	return strings.Join(ReadyConditions(w, at), "\n\t\t\t  AND ")
+}
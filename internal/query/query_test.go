@@ -0,0 +1,217 @@
+package query
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+func TestReadyConditions(t *testing.T) {
+	wf := task.DefaultWorkflow()
+
+	t.Run("it provides a non-empty no-unclosed-blockers condition", func(t *testing.T) {
+		cond := ReadyNoUnclosedBlockers(wf)
+		if cond == "" {
+			t.Error("ReadyNoUnclosedBlockers(wf) returned empty string")
+		}
+	})
+
+	t.Run("it provides a non-empty no-open-children condition", func(t *testing.T) {
+		cond := ReadyNoOpenChildren(wf)
+		if cond == "" {
+			t.Error("ReadyNoOpenChildren(wf) returned empty string")
+		}
+	})
+
+	t.Run("it provides a non-empty no-blocked-ancestor condition", func(t *testing.T) {
+		cond := ReadyNoBlockedAncestor(wf)
+		if cond == "" {
+			t.Error("ReadyNoBlockedAncestor(wf) returned empty string")
+		}
+		if !strings.Contains(cond, "NOT EXISTS") {
+			t.Error("ReadyNoBlockedAncestor(wf) should contain NOT EXISTS")
+		}
+		if !strings.Contains(cond, "WITH RECURSIVE") {
+			t.Error("ReadyNoBlockedAncestor(wf) should contain WITH RECURSIVE")
+		}
+	})
+
+	t.Run("ReadyConditions returns status open plus all four conditions", func(t *testing.T) {
+		conditions := ReadyConditions(wf, time.Time{})
+		if len(conditions) != 4 {
+			t.Fatalf("ReadyConditions(wf) returned %d conditions, want 4", len(conditions))
+		}
+		if conditions[0] != `t.status IN ('open', 'in_progress')` {
+			t.Errorf("conditions[0] = %q, want %q", conditions[0], `t.status IN ('open', 'in_progress')`)
+		}
+		if conditions[1] != ReadyNoUnclosedBlockers(wf) {
+			t.Errorf("conditions[1] does not match ReadyNoUnclosedBlockers(wf)")
+		}
+		if conditions[2] != ReadyNoOpenChildren(wf) {
+			t.Errorf("conditions[2] does not match ReadyNoOpenChildren(wf)")
+		}
+		if conditions[3] != ReadyNoBlockedAncestor(wf) {
+			t.Errorf("conditions[3] does not match ReadyNoBlockedAncestor(wf)")
+		}
+	})
+
+	t.Run("BlockedCondition returns open AND negation of ready subconditions", func(t *testing.T) {
+		conditions := BlockedConditions(wf, time.Time{})
+		if len(conditions) != 2 {
+			t.Fatalf("BlockedConditions(wf) returned %d conditions, want 2", len(conditions))
+		}
+		if conditions[0] != `t.status IN ('open', 'in_progress')` {
+			t.Errorf("conditions[0] = %q, want %q", conditions[0], `t.status IN ('open', 'in_progress')`)
+		}
+		// The second condition should be the disjunction (EXISTS blockers OR EXISTS open children)
+		if conditions[1] == "" {
+			t.Error("conditions[1] is empty")
+		}
+	})
+
+	t.Run("BlockedConditions includes ancestor blocker in OR clause", func(t *testing.T) {
+		conditions := BlockedConditions(wf, time.Time{})
+		if len(conditions) != 2 {
+			t.Fatalf("BlockedConditions(wf) returned %d conditions, want 2", len(conditions))
+		}
+		if !strings.Contains(conditions[1], "ancestors") {
+			t.Error("conditions[1] should contain 'ancestors' for the ancestor blocker CTE")
+		}
+	})
+
+	t.Run("BlockedConditions derives subqueries from ReadyNo helpers", func(t *testing.T) {
+		conditions := BlockedConditions(wf, time.Time{})
+		if len(conditions) != 2 {
+			t.Fatalf("BlockedConditions(wf) returned %d conditions, want 2", len(conditions))
+		}
+		orClause := conditions[1]
+
+		// Each ReadyNo*() helper returns "NOT EXISTS (...)". The blocked
+		// condition should use "EXISTS (...)" with the identical inner body.
+		// Verify by stripping the "NOT " prefix from each helper and checking
+		// that the resulting "EXISTS (...)" string appears in the OR clause.
+		helpers := []struct {
+			name   string
+			output string
+		}{
+			{"ReadyNoUnclosedBlockers", ReadyNoUnclosedBlockers(wf)},
+			{"ReadyNoOpenChildren", ReadyNoOpenChildren(wf)},
+			{"ReadyNoBlockedAncestor", ReadyNoBlockedAncestor(wf)},
+		}
+		for _, h := range helpers {
+			negated := strings.TrimPrefix(h.output, "NOT ")
+			if negated == h.output {
+				t.Fatalf("%s() output does not start with 'NOT ': %q", h.name, h.output)
+			}
+			if !strings.Contains(orClause, negated) {
+				t.Errorf("BlockedConditions OR clause does not contain negated %s().\nWant substring:\n%s\nGot:\n%s", h.name, negated, orClause)
+			}
+		}
+	})
+
+	t.Run("BlockedConditions contains no SQL literals beyond status check", func(t *testing.T) {
+		conditions := BlockedConditions(wf, time.Time{})
+		if len(conditions) != 2 {
+			t.Fatalf("BlockedConditions(wf) returned %d conditions, want 2", len(conditions))
+		}
+		// The status condition is the only SQL literal allowed
+		if conditions[0] != `t.status IN ('open', 'in_progress')` {
+			t.Errorf("conditions[0] = %q, want %q", conditions[0], `t.status IN ('open', 'in_progress')`)
+		}
+		// The OR clause must not contain SELECT directly — it should be
+		// composed from the ReadyNo*() helpers, not hand-written SQL.
+		// If someone adds a hand-written EXISTS subquery, this catches it:
+		// count the EXISTS occurrences and ensure they match exactly 3
+		// (one per helper).
+		orClause := conditions[1]
+		existsCount := strings.Count(orClause, "EXISTS")
+		// Each helper contributes one "EXISTS" — exactly 3 total
+		if existsCount != 3 {
+			t.Errorf("expected exactly 3 EXISTS in OR clause, got %d", existsCount)
+		}
+	})
+
+	t.Run("it derives the status gates from the workflow's categories", func(t *testing.T) {
+		custom := task.DefaultWorkflow()
+		custom.States = append(custom.States,
+			task.State{Name: "testing", Category: task.CategoryActive},
+			task.State{Name: "in_review", Category: task.CategoryWaiting},
+			task.State{Name: "wont_fix", Category: task.CategoryTerminal},
+		)
+
+		ready := ReadyConditions(custom, time.Time{})
+		if ready[0] != `t.status IN ('open', 'in_progress', 'testing')` {
+			t.Errorf("ready status gate = %q", ready[0])
+		}
+		if !strings.Contains(ready[1], `blocker.status NOT IN ('done', 'cancelled', 'wont_fix')`) {
+			t.Errorf("unclosed blockers condition = %q", ready[1])
+		}
+		if !strings.Contains(ready[2], `child.status IN ('open', 'in_progress', 'testing', 'in_review')`) {
+			t.Errorf("open children condition = %q", ready[2])
+		}
+
+		blocked := BlockedConditions(custom, time.Time{})
+		if blocked[0] != `t.status IN ('open', 'in_progress', 'testing', 'in_review')` {
+			t.Errorf("blocked status gate = %q", blocked[0])
+		}
+		if !strings.HasPrefix(blocked[1], `(t.status IN ('in_review')`) {
+			t.Errorf("blocked clause should start with the waiting states: %q", blocked[1])
+		}
+	})
+
+	t.Run("it excludes tasks deferred past the given instant from ready and adds them to blocked", func(t *testing.T) {
+		at := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+
+		ready := ReadyConditions(wf, at)
+		if len(ready) != 5 {
+			t.Fatalf("ReadyConditions(wf, at) returned %d conditions, want 5", len(ready))
+		}
+		if want := `(t.defer_until IS NULL OR t.defer_until <= '2026-01-19T10:00:00Z')`; ready[4] != want {
+			t.Errorf("conditions[4] = %q, want %q", ready[4], want)
+		}
+
+		blocked := BlockedConditions(wf, at)
+		if !strings.HasSuffix(blocked[1], "OR t.defer_until > '2026-01-19T10:00:00Z')") {
+			t.Errorf("blocked clause should end with the deferral condition: %q", blocked[1])
+		}
+	})
+
+	t.Run("BlockedReason names the first cause that applies", func(t *testing.T) {
+		custom := task.DefaultWorkflow()
+		custom.States = append(custom.States, task.State{Name: "in_review", Category: task.CategoryWaiting})
+		at := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+
+		reason := BlockedReason(custom, at)
+		var last int
+		for _, want := range []string{
+			`WHEN t.status IN ('in_review') THEN 'waiting'`,
+			`WHEN ` + negateNotExists(ReadyNoUnclosedBlockers(custom)) + ` THEN 'blocked_by'`,
+			`WHEN ` + negateNotExists(ReadyNoOpenChildren(custom)) + ` THEN 'children'`,
+			`WHEN ` + negateNotExists(ReadyNoBlockedAncestor(custom)) + ` THEN 'ancestor'`,
+			`WHEN t.defer_until > '2026-01-19T10:00:00Z' THEN 'deferred'`,
+		} {
+			i := strings.Index(reason, want)
+			if i < last {
+				t.Fatalf("BlockedReason should contain %q after the previous cause:\n%s", want, reason)
+			}
+			last = i
+		}
+		if !strings.HasPrefix(reason, "CASE") || !strings.HasSuffix(reason, "END") {
+			t.Errorf("BlockedReason should be a CASE expression: %q", reason)
+		}
+
+		reason = BlockedReason(wf, time.Time{})
+		if strings.Contains(reason, "'waiting'") || strings.Contains(reason, "'deferred'") {
+			t.Errorf("BlockedReason without waiting states or deferral = %q", reason)
+		}
+	})
+
+	t.Run("ReadyWhereClause returns composable SQL WHERE fragment", func(t *testing.T) {
+		clause := ReadyWhereClause(wf, time.Time{})
+		if clause == "" {
+			t.Error("ReadyWhereClause(wf) returned empty string")
+		}
+	})
+}
@@ -0,0 +1,353 @@
+package storage
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// archiveFileName is the file under .tick/ that holds archived tasks, one JSON
+// object per line in the same form as tasks.jsonl, whatever the layout.
+const archiveFileName = "archive.jsonl"
+
+// errArchivedReadOnly is returned by mutations on a Store opened WithArchived.
+var errArchivedReadOnly = errors.New("cannot modify tasks while archived tasks are included (remove --include-archived)")
+
+// ArchiveResult holds the outcome of Archive or Unarchive.
+type ArchiveResult struct {
+	// Tasks are the tasks moved into or out of the archive.
+	Tasks []task.Task
+	// DepsUpdated lists tasks whose blocked_by or parent references to tasks on
+	// the other side of the archive were removed.
+	DepsUpdated []string
+}
+
+// Archive moves closed tasks out of the active task data into archive.jsonl. A
+// task is archived when it is in a terminal state (done, cancelled or one of
+// the project's own), was closed before cutoff (any time when cutoff is zero),
+// and every descendant is archived with it, so active tasks never have archived
+// parents. References from remaining tasks' blocked_by
+// to archived tasks are removed; archived blockers are closed and no longer block.
+// With dryRun, nothing is written and the result reports what would be archived.
+// The move is journaled, so undo restores the tasks and redo archives them again.
+func (s *Store) Archive(cutoff time.Time, dryRun bool) (ArchiveResult, error) {
+	if err := s.readOnly(); err != nil {
+		return ArchiveResult{}, err
+	}
+	if dryRun {
+		tasks, err := s.ReadTasks()
+		if err != nil {
+			return ArchiveResult{}, err
+		}
+		result, _ := splitArchivable(tasks, cutoff, s.config.Rules().Workflow)
+		return result, nil
+	}
+
+	unlock, err := s.acquireExclusive()
+	if err != nil {
+		return ArchiveResult{}, err
+	}
+	defer unlock()
+
+	archived, err := readArchive(s.archivePath)
+	if err != nil {
+		return ArchiveResult{}, err
+	}
+
+	var result ArchiveResult
+	if err := s.releaseLocked(); err != nil {
+		return ArchiveResult{}, err
+	}
+	err = s.mutateLocked(&JournalEntry{Op: JournalArchive}, func(tasks []task.Task) ([]task.Task, error) {
+		var kept []task.Task
+		result, kept = splitArchivable(tasks, cutoff, s.config.Rules().Workflow)
+		return kept, nil
+	})
+	if err != nil {
+		return ArchiveResult{}, err
+	}
+	if len(result.Tasks) == 0 {
+		return result, nil
+	}
+
+	// Like Unarchive, write the archive only once the active tasks are written: a
+	// failed write leaves the tasks active, and an interruption in between leaves
+	// them in the journal entry, from which undo restores them.
+	s.verbose(fmt.Sprintf("archiving %d tasks", len(result.Tasks)))
+	if err := WriteJSONL(s.archivePath, append(archived, result.Tasks...)); err != nil {
+		return ArchiveResult{}, fmt.Errorf("failed to write %s: %w", archiveFileName, err)
+	}
+	return result, nil
+}
+
+// Unarchive restores an archived task and its archived descendants to the active
+// task data. id may be a partial ID, resolved against archived tasks only. It is
+// refused when the task's parent is itself archived, or when a restored ID is
+// already active. References to tasks that are no longer active are dropped. The
+// move is journaled, so undo archives the tasks again and redo restores them.
+func (s *Store) Unarchive(id string) (ArchiveResult, error) {
+	unlock, err := s.acquireExclusive()
+	if err != nil {
+		return ArchiveResult{}, err
+	}
+	defer unlock()
+
+	archived, err := readArchive(s.archivePath)
+	if err != nil {
+		return ArchiveResult{}, err
+	}
+	target, err := s.resolveArchivedID(archived, id)
+	if err != nil {
+		return ArchiveResult{}, err
+	}
+
+	archivedIDs := make(map[string]bool, len(archived))
+	for _, t := range archived {
+		archivedIDs[task.NormalizeID(t.ID)] = true
+	}
+	for _, t := range archived {
+		if task.NormalizeID(t.ID) == target && t.Parent != "" && archivedIDs[task.NormalizeID(t.Parent)] {
+			return ArchiveResult{}, fmt.Errorf("cannot unarchive %s: its parent %s is archived (unarchive %s instead)", target, t.Parent, t.Parent)
+		}
+	}
+
+	restoreSet := subtreeIDs(archived, target)
+	var result ArchiveResult
+	var remaining []task.Task
+	for _, t := range archived {
+		if restoreSet[task.NormalizeID(t.ID)] {
+			result.Tasks = append(result.Tasks, t)
+		} else {
+			remaining = append(remaining, t)
+		}
+	}
+
+	if err := s.releaseLocked(); err != nil {
+		return ArchiveResult{}, err
+	}
+	err = s.mutateLocked(&JournalEntry{Op: JournalUnarchive}, func(tasks []task.Task) ([]task.Task, error) {
+		valid := make(map[string]bool, len(tasks)+len(result.Tasks))
+		for _, t := range tasks {
+			if restoreSet[task.NormalizeID(t.ID)] {
+				return nil, fmt.Errorf("cannot unarchive %s: task %s already exists", target, t.ID)
+			}
+			valid[task.NormalizeID(t.ID)] = true
+		}
+		for id := range restoreSet {
+			valid[id] = true
+		}
+
+		for i := range result.Tasks {
+			t := &result.Tasks[i]
+			changed := false
+			if t.Parent != "" && !valid[task.NormalizeID(t.Parent)] {
+				t.Parent = ""
+				changed = true
+			}
+			deps := slices.DeleteFunc(slices.Clone(t.BlockedBy), func(dep string) bool {
+				return !valid[task.NormalizeID(dep)]
+			})
+			if len(deps) != len(t.BlockedBy) {
+				t.BlockedBy = deps
+				changed = true
+			}
+			if changed {
+				result.DepsUpdated = append(result.DepsUpdated, t.ID)
+			}
+		}
+		return append(tasks, result.Tasks...), nil
+	})
+	if err != nil {
+		return ArchiveResult{}, err
+	}
+
+	// The restored tasks are now active; only now drop them from the archive.
+	if err := WriteJSONL(s.archivePath, remaining); err != nil {
+		return ArchiveResult{}, fmt.Errorf("failed to write %s: %w", archiveFileName, err)
+	}
+	return result, nil
+}
+
+// syncArchive keeps archive.jsonl in step after undo or redo applied the image
+// selected by applied from an archive or unarchive entry: tasks the image removed
+// from the active data are added to the archive from their other image, and tasks
+// it restored are dropped from the archive. Other entries leave the archive alone.
+func (s *Store) syncArchive(entry JournalEntry, applied, other func(TaskChange) json.RawMessage) error {
+	if entry.Op != JournalArchive && entry.Op != JournalUnarchive {
+		return nil
+	}
+
+	moved := map[string]bool{}
+	var added []task.Task
+	for _, c := range entry.Changes {
+		if applied(c) != nil && other(c) != nil {
+			continue // a task that stayed active and only lost references
+		}
+		moved[task.NormalizeID(c.ID)] = true
+		if applied(c) == nil {
+			var t task.Task
+			if err := json.Unmarshal(other(c), &t); err != nil {
+				return fmt.Errorf("failed to parse journal image for %s: %w", c.ID, err)
+			}
+			added = append(added, t)
+		}
+	}
+	if len(moved) == 0 {
+		return nil
+	}
+
+	archived, err := readArchive(s.archivePath)
+	if err != nil {
+		return err
+	}
+	kept := slices.DeleteFunc(archived, func(t task.Task) bool { return moved[task.NormalizeID(t.ID)] })
+	if err := WriteJSONL(s.archivePath, append(kept, added...)); err != nil {
+		return fmt.Errorf("failed to write %s: %w", archiveFileName, err)
+	}
+	return nil
+}
+
+// readArchive reads archive.jsonl. A missing archive holds no tasks.
+func readArchive(path string) ([]task.Task, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", archiveFileName, err)
+	}
+	tasks, err := ParseJSONL(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", archiveFileName, err)
+	}
+	return tasks, nil
+}
+
+// splitArchivable partitions tasks into those to archive and those to keep, and
+// strips references to archived tasks from the kept tasks' blocked_by.
+func splitArchivable(tasks []task.Task, cutoff time.Time, w task.Workflow) (ArchiveResult, []task.Task) {
+	children := make(map[string][]string)
+	byID := make(map[string]*task.Task, len(tasks))
+	for i := range tasks {
+		byID[task.NormalizeID(tasks[i].ID)] = &tasks[i]
+		if tasks[i].Parent != "" {
+			parent := task.NormalizeID(tasks[i].Parent)
+			children[parent] = append(children[parent], task.NormalizeID(tasks[i].ID))
+		}
+	}
+
+	// archivable is memoized: a task qualifies when it is closed before the cutoff
+	// and all of its children qualify.
+	memo := make(map[string]bool, len(tasks))
+	var archivable func(id string) bool
+	archivable = func(id string) bool {
+		if v, ok := memo[id]; ok {
+			return v
+		}
+		memo[id] = false // guards against parent cycles in hand-edited data
+		t := byID[id]
+		ok := isArchivable(*t, cutoff, w)
+		for _, child := range children[id] {
+			if !archivable(child) {
+				ok = false
+			}
+		}
+		memo[id] = ok
+		return ok
+	}
+
+	var result ArchiveResult
+	archiveSet := map[string]bool{}
+	kept := make([]task.Task, 0, len(tasks))
+	for _, t := range tasks {
+		if archivable(task.NormalizeID(t.ID)) {
+			archiveSet[task.NormalizeID(t.ID)] = true
+			result.Tasks = append(result.Tasks, t)
+		} else {
+			kept = append(kept, t)
+		}
+	}
+
+	for i := range kept {
+		deps := slices.DeleteFunc(slices.Clone(kept[i].BlockedBy), func(dep string) bool {
+			return archiveSet[task.NormalizeID(dep)]
+		})
+		if len(deps) != len(kept[i].BlockedBy) {
+			kept[i].BlockedBy = deps
+			result.DepsUpdated = append(result.DepsUpdated, kept[i].ID)
+		}
+	}
+	return result, kept
+}
+
+// isArchivable reports whether a task itself is closed, in a terminal state of
+// w, before cutoff. Tasks
+// without a closed timestamp are dated by their last update.
+func isArchivable(t task.Task, cutoff time.Time, w task.Workflow) bool {
+	if !w.IsTerminal(t.Status) {
+		return false
+	}
+	if cutoff.IsZero() {
+		return true
+	}
+	closed := t.Updated
+	if t.Closed != nil {
+		closed = *t.Closed
+	}
+	return closed.Before(cutoff)
+}
+
+// subtreeIDs returns the ID of root and of all its descendants within tasks.
+func subtreeIDs(tasks []task.Task, root string) map[string]bool {
+	result := map[string]bool{root: true}
+	for changed := true; changed; {
+		changed = false
+		for _, t := range tasks {
+			id := task.NormalizeID(t.ID)
+			if !result[id] && t.Parent != "" && result[task.NormalizeID(t.Parent)] {
+				result[id] = true
+				changed = true
+			}
+		}
+	}
+	return result
+}
+
+// resolveArchivedID resolves a user-supplied ID against archived tasks, with the
+// same rules as ResolveID: optional prefix, any case, and unique prefixes of at
+// least 3 hex characters.
+func (s *Store) resolveArchivedID(tasks []task.Task, input string) (string, error) {
+	prefix, hex := task.SplitIDInput(input)
+	if len(hex) < 3 {
+		return "", errors.New("partial ID must be at least 3 hex characters")
+	}
+
+	fullID := cmp.Or(prefix, s.config.IDPrefix) + hex
+	var matches []string
+	for _, t := range tasks {
+		id := task.NormalizeID(t.ID)
+		if id == fullID {
+			return id, nil
+		}
+		if task.MatchesIDInput(id, prefix, hex) {
+			matches = append(matches, id)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("task '%s' not found in archive", input)
+	case 1:
+		return matches[0], nil
+	default:
+		slices.Sort(matches)
+		return "", fmt.Errorf("ambiguous ID '%s' matches: %s", input, strings.Join(matches, ", "))
+	}
+}
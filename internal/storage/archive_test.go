@@ -0,0 +1,275 @@
+package storage
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+func TestArchive(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	old := now.AddDate(0, -2, 0)
+	recent := now.AddDate(0, 0, -3)
+	closedTask := func(id, parent string, status task.Status, closed time.Time) task.Task {
+		return task.Task{ID: id, Title: id, Status: status, Priority: 2, Parent: parent, Created: old, Updated: closed, Closed: &closed}
+	}
+	seed := func() []task.Task {
+		return []task.Task{
+			closedTask("tick-par111", "", task.StatusDone, old),
+			closedTask("tick-chd111", "tick-par111", task.StatusCancelled, old),
+			closedTask("tick-par222", "", task.StatusDone, old),
+			closedTask("tick-chd222", "tick-par222", task.StatusDone, recent),
+			{ID: "tick-opn111", Title: "Open", Status: task.StatusOpen, Priority: 2, BlockedBy: []string{"tick-par111", "tick-par222"}, Created: old, Updated: old},
+		}
+	}
+	ids := func(tasks []task.Task) []string {
+		var out []string
+		for _, tk := range tasks {
+			out = append(out, tk.ID)
+		}
+		return out
+	}
+
+	t.Run("it archives closed tasks whose whole subtree qualifies", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, seed())
+		store, _ := NewStore(tickDir)
+		defer store.Close()
+
+		result, err := store.Archive(now.AddDate(0, 0, -30), false)
+		if err != nil {
+			t.Fatalf("Archive returned error: %v", err)
+		}
+
+		// tick-par222 stays: its child was closed after the cutoff.
+		got := ids(result.Tasks)
+		if len(got) != 2 || got[0] != "tick-par111" || got[1] != "tick-chd111" {
+			t.Errorf("archived = %v, want [tick-par111 tick-chd111]", got)
+		}
+		if len(result.DepsUpdated) != 1 || result.DepsUpdated[0] != "tick-opn111" {
+			t.Errorf("deps updated = %v", result.DepsUpdated)
+		}
+
+		active, _ := ReadJSONL(filepath.Join(tickDir, "tasks.jsonl"))
+		if got := ids(active); len(got) != 3 {
+			t.Errorf("active = %v, want 3 tasks", got)
+		}
+		for _, tk := range active {
+			if tk.ID == "tick-opn111" && (len(tk.BlockedBy) != 1 || tk.BlockedBy[0] != "tick-par222") {
+				t.Errorf("blocked_by = %v, want [tick-par222]", tk.BlockedBy)
+			}
+		}
+		archived, _ := ReadJSONL(filepath.Join(tickDir, "archive.jsonl"))
+		if got := ids(archived); len(got) != 2 {
+			t.Errorf("archive = %v, want 2 tasks", got)
+		}
+	})
+
+	t.Run("it archives every closed task without a cutoff and appends to the archive", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, seed())
+		store, _ := NewStore(tickDir)
+		defer store.Close()
+
+		if _, err := store.Archive(now.AddDate(0, 0, -30), false); err != nil {
+			t.Fatalf("first Archive returned error: %v", err)
+		}
+		result, err := store.Archive(time.Time{}, false)
+		if err != nil {
+			t.Fatalf("second Archive returned error: %v", err)
+		}
+		if got := ids(result.Tasks); len(got) != 2 {
+			t.Errorf("archived = %v, want tick-par222 and tick-chd222", got)
+		}
+		archived, _ := ReadJSONL(filepath.Join(tickDir, "archive.jsonl"))
+		if len(archived) != 4 {
+			t.Errorf("archive has %d tasks, want 4", len(archived))
+		}
+	})
+
+	t.Run("it writes nothing on a dry run", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, seed())
+		store, _ := NewStore(tickDir)
+		defer store.Close()
+		before, _ := os.ReadFile(filepath.Join(tickDir, "tasks.jsonl"))
+
+		result, err := store.Archive(time.Time{}, true)
+		if err != nil {
+			t.Fatalf("Archive returned error: %v", err)
+		}
+		if len(result.Tasks) != 4 {
+			t.Errorf("candidates = %v, want 4", ids(result.Tasks))
+		}
+		after, _ := os.ReadFile(filepath.Join(tickDir, "tasks.jsonl"))
+		if string(before) != string(after) {
+			t.Error("dry run modified tasks.jsonl")
+		}
+		if _, err := os.Stat(filepath.Join(tickDir, "archive.jsonl")); !os.IsNotExist(err) {
+			t.Error("dry run created archive.jsonl")
+		}
+	})
+
+	t.Run("it unarchives a task with its subtree by partial ID", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, seed())
+		store, _ := NewStore(tickDir)
+		defer store.Close()
+		_, _ = store.Archive(time.Time{}, false)
+
+		result, err := store.Unarchive("par111")
+		if err != nil {
+			t.Fatalf("Unarchive returned error: %v", err)
+		}
+		if got := ids(result.Tasks); len(got) != 2 || got[0] != "tick-par111" || got[1] != "tick-chd111" {
+			t.Errorf("restored = %v", got)
+		}
+		if titleOf(t, tickDir, "tick-chd111") == "" {
+			t.Error("child was not restored")
+		}
+		archived, _ := ReadJSONL(filepath.Join(tickDir, "archive.jsonl"))
+		if got := ids(archived); len(got) != 2 || got[0] != "tick-par222" {
+			t.Errorf("archive after unarchive = %v", got)
+		}
+	})
+
+	t.Run("it refuses to unarchive a child of an archived parent", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, seed())
+		store, _ := NewStore(tickDir)
+		defer store.Close()
+		_, _ = store.Archive(time.Time{}, false)
+
+		_, err := store.Unarchive("tick-chd111")
+		want := "cannot unarchive tick-chd111: its parent tick-par111 is archived (unarchive tick-par111 instead)"
+		if err == nil || err.Error() != want {
+			t.Errorf("error = %v, want %q", err, want)
+		}
+		if _, err := store.Unarchive("tick-opn111"); err == nil || err.Error() != "task 'tick-opn111' not found in archive" {
+			t.Errorf("active task error = %v", err)
+		}
+	})
+
+	t.Run("it drops references to tasks that are no longer active on unarchive", func(t *testing.T) {
+		tasks := seed()
+		tasks[2].BlockedBy = []string{"tick-par111"}
+		tickDir := setupTickDirWithTasks(t, tasks)
+		store, _ := NewStore(tickDir)
+		defer store.Close()
+		_, _ = store.Archive(time.Time{}, false)
+
+		result, err := store.Unarchive("tick-par222")
+		if err != nil {
+			t.Fatalf("Unarchive returned error: %v", err)
+		}
+		if len(result.DepsUpdated) != 1 || result.DepsUpdated[0] != "tick-par222" {
+			t.Errorf("deps updated = %v", result.DepsUpdated)
+		}
+	})
+
+	t.Run("it undoes and redoes an archive", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, seed())
+		store, _ := NewStore(tickDir, WithCommand("tick archive"))
+		defer store.Close()
+		_, _ = store.Archive(now.AddDate(0, 0, -30), false)
+
+		entries, _ := store.Journal()
+		if len(entries) != 1 || entries[0].Op != JournalArchive {
+			t.Fatalf("entries = %+v", entries)
+		}
+		if _, err := store.Undo(0); err != nil {
+			t.Fatalf("Undo returned error: %v", err)
+		}
+		active, _ := ReadJSONL(filepath.Join(tickDir, "tasks.jsonl"))
+		if len(active) != 5 {
+			t.Errorf("active after undo = %v, want all 5 tasks", ids(active))
+		}
+		for _, tk := range active {
+			if tk.ID == "tick-opn111" && len(tk.BlockedBy) != 2 {
+				t.Errorf("blocked_by after undo = %v, want both blockers back", tk.BlockedBy)
+			}
+		}
+		if archived, _ := readArchive(filepath.Join(tickDir, archiveFileName)); len(archived) != 0 {
+			t.Errorf("archive after undo = %v, want empty", ids(archived))
+		}
+
+		if _, err := store.Redo(); err != nil {
+			t.Fatalf("Redo returned error: %v", err)
+		}
+		active, _ = ReadJSONL(filepath.Join(tickDir, "tasks.jsonl"))
+		if len(active) != 3 {
+			t.Errorf("active after redo = %v, want 3 tasks", ids(active))
+		}
+		archived, _ := readArchive(filepath.Join(tickDir, archiveFileName))
+		if got := ids(archived); len(got) != 2 || got[0] != "tick-chd111" || got[1] != "tick-par111" {
+			t.Errorf("archive after redo = %v, want [tick-chd111 tick-par111]", got)
+		}
+	})
+
+	t.Run("it undoes an unarchive by archiving the tasks again", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, seed())
+		store, _ := NewStore(tickDir)
+		defer store.Close()
+		_, _ = store.Archive(time.Time{}, false)
+		if _, err := store.Unarchive("tick-par111"); err != nil {
+			t.Fatalf("Unarchive returned error: %v", err)
+		}
+
+		undone, err := store.Undo(0)
+		if err != nil {
+			t.Fatalf("Undo returned error: %v", err)
+		}
+		if undone.Op != JournalUnarchive {
+			t.Errorf("undone op = %s, want unarchive", undone.Op)
+		}
+		active, _ := ReadJSONL(filepath.Join(tickDir, "tasks.jsonl"))
+		if got := ids(active); len(got) != 1 || got[0] != "tick-opn111" {
+			t.Errorf("active = %v, want [tick-opn111]", got)
+		}
+		archived, _ := readArchive(filepath.Join(tickDir, archiveFileName))
+		if len(archived) != 4 {
+			t.Errorf("archive = %v, want all 4 closed tasks", ids(archived))
+		}
+	})
+
+	t.Run("it refuses to undo an archive whose tasks were unarchived since", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, seed())
+		store, _ := NewStore(tickDir, WithCommand("tick unarchive tick-par111"))
+		defer store.Close()
+		_, _ = store.Archive(time.Time{}, false)
+		_, _ = store.Unarchive("tick-par111")
+
+		_, err := store.Undo(1)
+		if err == nil || err.Error() != "cannot undo #1: task tick-chd111 was changed by #2 (tick unarchive tick-par111)" {
+			t.Errorf("Undo(1) error = %v", err)
+		}
+	})
+
+	t.Run("it includes archived tasks in reads with WithArchived", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, seed())
+		store, _ := NewStore(tickDir)
+		_, _ = store.Archive(time.Time{}, false)
+		store.Close()
+
+		view, err := NewStore(tickDir, WithArchived())
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer view.Close()
+
+		var count int
+		err = view.Query(func(db *sql.DB) error {
+			return db.QueryRow("SELECT COUNT(*) FROM tasks").Scan(&count)
+		})
+		if err != nil || count != 5 {
+			t.Errorf("count = %d, %v; want 5", count, err)
+		}
+		if _, err := os.Stat(filepath.Join(tickDir, "cache-archived.db")); err != nil {
+			t.Errorf("archived view cache not created: %v", err)
+		}
+
+		err = view.Mutate(func(tasks []task.Task) ([]task.Task, error) { return tasks, nil })
+		if err != errArchivedReadOnly {
+			t.Errorf("Mutate error = %v, want read-only error", err)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+func TestWithAsOf(t *testing.T) {
+	created := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	started := created.Add(24 * time.Hour)
+	finished := created.Add(72 * time.Hour)
+	tasks := []task.Task{
+		{
+			ID: "tick-aaa111", Title: "Finished", Status: task.StatusDone, Priority: 2,
+			Created: created, Updated: finished, Closed: &finished,
+			Transitions: []task.TransitionRecord{
+				{From: task.StatusOpen, To: task.StatusInProgress, At: started},
+				{From: task.StatusInProgress, To: task.StatusDone, At: finished},
+			},
+		},
+		{
+			ID: "tick-bbb222", Title: "Later", Status: task.StatusOpen, Priority: 2,
+			Created: finished, Updated: finished,
+		},
+	}
+
+	t.Run("it reads tasks as they stood at the given instant", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, tasks)
+
+		view, err := NewStore(tickDir, WithAsOf(started.Add(time.Hour)))
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer view.Close()
+
+		var ids []string
+		var statuses []string
+		err = view.Query(func(db *sql.DB) error {
+			rows, err := db.Query("SELECT id, status FROM tasks ORDER BY id")
+			if err != nil {
+				return err
+			}
+			defer rows.Close()
+			for rows.Next() {
+				var id, status string
+				if err := rows.Scan(&id, &status); err != nil {
+					return err
+				}
+				ids = append(ids, id)
+				statuses = append(statuses, status)
+			}
+			return rows.Err()
+		})
+		if err != nil {
+			t.Fatalf("Query returned error: %v", err)
+		}
+		if len(ids) != 1 || ids[0] != "tick-aaa111" || statuses[0] != "in_progress" {
+			t.Errorf("tasks = %v %v, want [tick-aaa111] [in_progress]", ids, statuses)
+		}
+		if _, err := os.Stat(filepath.Join(tickDir, "cache-asof.db")); err != nil {
+			t.Errorf("as-of view cache not created: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(tickDir, "cache.db")); !os.IsNotExist(err) {
+			t.Errorf("main cache touched by as-of view: %v", err)
+		}
+	})
+
+	t.Run("it refuses mutations", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, tasks)
+
+		view, err := NewStore(tickDir, WithAsOf(started))
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer view.Close()
+
+		err = view.Mutate(func(tasks []task.Task) ([]task.Task, error) { return tasks, nil })
+		if err != errAsOfReadOnly {
+			t.Errorf("Mutate error = %v, want read-only error", err)
+		}
+
+		current, err := NewStore(tickDir)
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer current.Close()
+		read, err := current.ReadTasks()
+		if err != nil || len(read) != 2 || read[0].Status != task.StatusDone {
+			t.Errorf("current tasks changed: %v, %v", read, err)
+		}
+	})
+}
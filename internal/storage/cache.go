@@ -1,18 +1,23 @@
 package storage
 
 import (
+	"context"
 	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/leeovery/tick/internal/task"
 	_ "modernc.org/sqlite"
 )
 
-const schemaVersion = 2
+const schemaVersion = 11
 
 const schemaSQL = `
 CREATE TABLE IF NOT EXISTS tasks (
@@ -25,7 +30,15 @@ CREATE TABLE IF NOT EXISTS tasks (
   parent TEXT,
   created TEXT NOT NULL,
   updated TEXT NOT NULL,
-  closed TEXT
+  closed TEXT,
+  due TEXT,
+  defer_until TEXT,
+  estimate REAL,
+  recur TEXT,
+  recurred_from TEXT,
+  assignee TEXT,
+  lease_expires TEXT,
+  extra TEXT
 );
 
 CREATE TABLE IF NOT EXISTS dependencies (
@@ -46,6 +59,13 @@ CREATE TABLE IF NOT EXISTS task_refs (
   PRIMARY KEY (task_id, ref)
 );
 
+CREATE TABLE IF NOT EXISTS task_fields (
+  task_id TEXT NOT NULL,
+  key TEXT NOT NULL,
+  value TEXT NOT NULL,
+  PRIMARY KEY (task_id, key)
+);
+
 CREATE TABLE IF NOT EXISTS task_notes (
   task_id TEXT NOT NULL,
   text TEXT NOT NULL,
@@ -60,6 +80,13 @@ CREATE TABLE IF NOT EXISTS task_transitions (
   auto INTEGER NOT NULL DEFAULT 0
 );
 
+-- Each search index row has the rowid of its task's row in tasks.
+CREATE VIRTUAL TABLE IF NOT EXISTS tasks_fts USING fts5(
+  title,
+  description,
+  notes
+);
+
 CREATE TABLE IF NOT EXISTS metadata (
   key TEXT PRIMARY KEY,
   value TEXT
@@ -68,7 +95,9 @@ CREATE TABLE IF NOT EXISTS metadata (
 CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks(status);
 CREATE INDEX IF NOT EXISTS idx_tasks_priority ON tasks(priority);
 CREATE INDEX IF NOT EXISTS idx_tasks_parent ON tasks(parent);
+CREATE INDEX IF NOT EXISTS idx_tasks_due ON tasks(due);
 CREATE INDEX IF NOT EXISTS idx_task_tags_tag ON task_tags(tag);
+CREATE INDEX IF NOT EXISTS idx_task_fields_key ON task_fields(key);
 CREATE INDEX IF NOT EXISTS idx_task_notes_task_id ON task_notes(task_id);
 CREATE INDEX IF NOT EXISTS idx_task_transitions_task_id ON task_transitions(task_id);
 `
@@ -79,14 +108,20 @@ type Cache struct {
 	path string
 }
 
+// cacheBusyTimeout is how long a cache connection waits for another to finish
+// writing. Readers share the file lock, so two of them, such as a command and
+// tick watch, may refresh the cache at once.
+const cacheBusyTimeout = 5 * time.Second
+
 // OpenCache opens or creates a SQLite cache at the given path and ensures the schema exists.
 func OpenCache(path string) (*Cache, error) {
-	db, err := sql.Open("sqlite", path)
+	dsn := fmt.Sprintf("file:%s?_pragma=busy_timeout(%d)", path, cacheBusyTimeout.Milliseconds())
+	db, err := sql.Open("sqlite", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open cache database: %w", err)
 	}
 
-	if _, err := db.Exec(schemaSQL); err != nil {
+	if err := createSchema(db); err != nil {
 		db.Close()
 		return nil, fmt.Errorf("failed to create cache schema: %w", err)
 	}
@@ -94,6 +129,50 @@ func OpenCache(path string) (*Cache, error) {
 	return &Cache{db: db, path: path}, nil
 }
 
+// createSchema creates any missing cache tables. A new database is stamped with
+// the current schema version in the same transaction, so that another reader
+// opening it before its first rebuild does not take it for an incompatible cache
+// and delete it.
+func createSchema(db *sql.DB) error {
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return err
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_, _ = conn.ExecContext(ctx, "ROLLBACK")
+		}
+	}()
+
+	var objects int
+	if err := conn.QueryRowContext(ctx, "SELECT count(*) FROM sqlite_master").Scan(&objects); err != nil {
+		return err
+	}
+	if _, err := conn.ExecContext(ctx, schemaSQL); err != nil {
+		return err
+	}
+	if objects == 0 {
+		if _, err := conn.ExecContext(ctx,
+			`INSERT INTO metadata (key, value) VALUES ('schema_version', ?)`,
+			fmt.Sprintf("%d", schemaVersion),
+		); err != nil {
+			return err
+		}
+	}
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
+
 // Close closes the underlying database connection.
 func (c *Cache) Close() error {
 	return c.db.Close()
@@ -104,6 +183,9 @@ func (c *Cache) DB() *sql.DB {
 	return c.db
 }
 
+// childTables lists the per-task tables keyed by task_id, in deletion order.
+var childTables = []string{"task_transitions", "task_notes", "task_fields", "task_refs", "task_tags", "dependencies"}
+
 // Rebuild clears all existing data and repopulates the cache from the given tasks and raw JSONL content.
 // The entire operation runs in a single transaction for atomicity.
 func (c *Cache) Rebuild(tasks []task.Task, rawJSONL []byte) error {
@@ -114,134 +196,306 @@ func (c *Cache) Rebuild(tasks []task.Task, rawJSONL []byte) error {
 	defer func() { _ = tx.Rollback() }()
 
 	// Clear existing data.
-	if _, err := tx.Exec("DELETE FROM task_transitions"); err != nil {
-		return fmt.Errorf("failed to clear task_transitions: %w", err)
-	}
-	if _, err := tx.Exec("DELETE FROM task_notes"); err != nil {
-		return fmt.Errorf("failed to clear task_notes: %w", err)
+	for _, table := range append(slices.Clone(childTables), "tasks_fts", "tasks") {
+		if _, err := tx.Exec("DELETE FROM " + table); err != nil {
+			return fmt.Errorf("failed to clear %s: %w", table, err)
+		}
 	}
-	if _, err := tx.Exec("DELETE FROM task_refs"); err != nil {
-		return fmt.Errorf("failed to clear task_refs: %w", err)
+
+	// Insert all tasks.
+	ins, err := prepareTaskInserter(tx)
+	if err != nil {
+		return err
 	}
-	if _, err := tx.Exec("DELETE FROM task_tags"); err != nil {
-		return fmt.Errorf("failed to clear task_tags: %w", err)
+	defer ins.close()
+
+	for _, t := range tasks {
+		if err := ins.insert(t); err != nil {
+			return err
+		}
 	}
-	if _, err := tx.Exec("DELETE FROM dependencies"); err != nil {
-		return fmt.Errorf("failed to clear dependencies: %w", err)
+
+	if err := writeMetadata(tx, rawJSONL); err != nil {
+		return err
 	}
-	if _, err := tx.Exec("DELETE FROM tasks"); err != nil {
-		return fmt.Errorf("failed to clear tasks: %w", err)
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit rebuild transaction: %w", err)
 	}
 
-	// Insert all tasks.
-	insertTask, err := tx.Prepare(`INSERT INTO tasks (id, title, status, priority, description, type, parent, created, updated, closed) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	return nil
+}
+
+// Apply updates the cache incrementally from a per-task diff instead of rebuilding every table.
+// Rows belonging to removed and changed tasks are deleted, changed and added tasks are
+// inserted afresh, and the stored hash is advanced to newRawJSONL, all in one transaction.
+// The diff is only valid relative to prevRawJSONL, so if the stored hash does not match
+// it, nothing is written and applied is false — the caller should fall back to Rebuild.
+func (c *Cache) Apply(diff TaskDiff, prevRawJSONL, newRawJSONL []byte) (applied bool, err error) {
+	tx, err := c.db.Begin()
 	if err != nil {
-		return fmt.Errorf("failed to prepare task insert: %w", err)
+		return false, fmt.Errorf("failed to begin apply transaction: %w", err)
 	}
-	defer insertTask.Close()
+	defer func() { _ = tx.Rollback() }()
 
-	insertDep, err := tx.Prepare(`INSERT INTO dependencies (task_id, blocked_by) VALUES (?, ?)`)
+	var storedHash string
+	err = tx.QueryRow("SELECT value FROM metadata WHERE key = 'jsonl_hash'").Scan(&storedHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
 	if err != nil {
-		return fmt.Errorf("failed to prepare dependency insert: %w", err)
+		return false, fmt.Errorf("failed to read JSONL hash from metadata: %w", err)
+	}
+	if storedHash != computeHash(prevRawJSONL) {
+		return false, nil
 	}
-	defer insertDep.Close()
 
-	insertTag, err := tx.Prepare(`INSERT INTO task_tags (task_id, tag) VALUES (?, ?)`)
-	if err != nil {
-		return fmt.Errorf("failed to prepare tag insert: %w", err)
+	for _, id := range diff.Removed {
+		if err := deleteTaskRows(tx, id); err != nil {
+			return false, err
+		}
 	}
-	defer insertTag.Close()
 
-	insertRef, err := tx.Prepare(`INSERT INTO task_refs (task_id, ref) VALUES (?, ?)`)
+	ins, err := prepareTaskInserter(tx)
 	if err != nil {
-		return fmt.Errorf("failed to prepare ref insert: %w", err)
+		return false, err
 	}
-	defer insertRef.Close()
+	defer ins.close()
 
-	insertNote, err := tx.Prepare(`INSERT INTO task_notes (task_id, text, created) VALUES (?, ?, ?)`)
-	if err != nil {
-		return fmt.Errorf("failed to prepare note insert: %w", err)
+	for _, t := range diff.Upserted {
+		if err := deleteTaskRows(tx, t.ID); err != nil {
+			return false, err
+		}
+		if err := ins.insert(t); err != nil {
+			return false, err
+		}
 	}
-	defer insertNote.Close()
 
-	insertTransition, err := tx.Prepare(`INSERT INTO task_transitions (task_id, from_status, to_status, at, auto) VALUES (?, ?, ?, ?, ?)`)
-	if err != nil {
-		return fmt.Errorf("failed to prepare transition insert: %w", err)
+	if err := writeMetadata(tx, newRawJSONL); err != nil {
+		return false, err
 	}
-	defer insertTransition.Close()
 
-	for _, t := range tasks {
-		var closedStr *string
-		if t.Closed != nil {
-			s := task.FormatTimestamp(*t.Closed)
-			closedStr = &s
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit apply transaction: %w", err)
+	}
+
+	return true, nil
+}
+
+// deleteTaskRows removes a task's row, its full-text index entry, and all of its child-table rows.
+func deleteTaskRows(tx *sql.Tx, id string) error {
+	for _, table := range childTables {
+		if _, err := tx.Exec("DELETE FROM "+table+" WHERE task_id = ?", id); err != nil {
+			return fmt.Errorf("failed to delete %s rows for %s: %w", table, id, err)
 		}
+	}
+	if _, err := tx.Exec("DELETE FROM tasks_fts WHERE rowid = (SELECT rowid FROM tasks WHERE id = ?)", id); err != nil {
+		return fmt.Errorf("failed to delete search index entry for %s: %w", id, err)
+	}
+	if _, err := tx.Exec("DELETE FROM tasks WHERE id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete task %s: %w", id, err)
+	}
+	return nil
+}
 
-		var parentStr *string
-		if t.Parent != "" {
-			parentStr = &t.Parent
+// taskInserter holds the prepared statements used to write a task and its child rows.
+type taskInserter struct {
+	task       *sql.Stmt
+	dep        *sql.Stmt
+	tag        *sql.Stmt
+	ref        *sql.Stmt
+	field      *sql.Stmt
+	note       *sql.Stmt
+	transition *sql.Stmt
+	fts        *sql.Stmt
+}
+
+// prepareTaskInserter prepares all insert statements within tx.
+func prepareTaskInserter(tx *sql.Tx) (*taskInserter, error) {
+	ins := &taskInserter{}
+	stmts := []struct {
+		dst  **sql.Stmt
+		name string
+		sql  string
+	}{
+		{&ins.task, "task", `INSERT INTO tasks (id, title, status, priority, description, type, parent, created, updated, closed, due, defer_until, estimate, recur, recurred_from, assignee, lease_expires, extra) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`},
+		{&ins.dep, "dependency", `INSERT INTO dependencies (task_id, blocked_by) VALUES (?, ?)`},
+		{&ins.tag, "tag", `INSERT INTO task_tags (task_id, tag) VALUES (?, ?)`},
+		{&ins.ref, "ref", `INSERT INTO task_refs (task_id, ref) VALUES (?, ?)`},
+		{&ins.field, "field", `INSERT INTO task_fields (task_id, key, value) VALUES (?, ?, ?)`},
+		{&ins.note, "note", `INSERT INTO task_notes (task_id, text, created) VALUES (?, ?, ?)`},
+		{&ins.transition, "transition", `INSERT INTO task_transitions (task_id, from_status, to_status, at, auto) VALUES (?, ?, ?, ?, ?)`},
+		{&ins.fts, "search index", `INSERT INTO tasks_fts (rowid, title, description, notes) VALUES ((SELECT rowid FROM tasks WHERE id = ?), ?, ?, ?)`},
+	}
+	for _, st := range stmts {
+		stmt, err := tx.Prepare(st.sql)
+		if err != nil {
+			ins.close()
+			return nil, fmt.Errorf("failed to prepare %s insert: %w", st.name, err)
 		}
+		*st.dst = stmt
+	}
+	return ins, nil
+}
 
-		var typeStr *string
-		if t.Type != "" {
-			typeStr = &t.Type
+// close releases all prepared statements.
+func (ins *taskInserter) close() {
+	for _, stmt := range []*sql.Stmt{ins.task, ins.dep, ins.tag, ins.ref, ins.field, ins.note, ins.transition, ins.fts} {
+		if stmt != nil {
+			stmt.Close()
 		}
+	}
+}
 
-		var descStr *string
-		if t.Description != "" {
-			descStr = &t.Description
+// insert writes a single task row, all of its child rows, and its full-text index entry,
+// which takes the rowid of the task row.
+func (ins *taskInserter) insert(t task.Task) error {
+	var closedStr *string
+	if t.Closed != nil {
+		s := task.FormatTimestamp(*t.Closed)
+		closedStr = &s
+	}
+
+	var parentStr *string
+	if t.Parent != "" {
+		parentStr = &t.Parent
+	}
+
+	var typeStr *string
+	if t.Type != "" {
+		typeStr = &t.Type
+	}
+
+	var descStr *string
+	if t.Description != "" {
+		descStr = &t.Description
+	}
+
+	var dueStr *string
+	if t.Due != nil {
+		s := task.FormatTimestamp(*t.Due)
+		dueStr = &s
+	}
+
+	var deferStr *string
+	if t.DeferUntil != nil {
+		s := task.FormatTimestamp(*t.DeferUntil)
+		deferStr = &s
+	}
+
+	var estimate *float64
+	if t.Estimate != 0 {
+		estimate = &t.Estimate
+	}
+
+	var recurStr *string
+	if t.Recur != "" {
+		recurStr = &t.Recur
+	}
+
+	var recurredFromStr *string
+	if t.RecurredFrom != "" {
+		recurredFromStr = &t.RecurredFrom
+	}
+
+	var assigneeStr *string
+	if t.Assignee != "" {
+		assigneeStr = &t.Assignee
+	}
+
+	var leaseStr *string
+	if t.LeaseExpires != nil {
+		s := task.FormatTimestamp(*t.LeaseExpires)
+		leaseStr = &s
+	}
+
+	// Unrecognized fields are stored as a single JSON object for tick show.
+	var extraStr *string
+	if len(t.Extra) > 0 {
+		data, err := json.Marshal(t.Extra)
+		if err != nil {
+			return fmt.Errorf("failed to encode extra fields of task %s: %w", t.ID, err)
 		}
+		s := string(data)
+		extraStr = &s
+	}
 
-		if _, err := insertTask.Exec(
-			t.ID,
-			t.Title,
-			string(t.Status),
-			t.Priority,
-			descStr,
-			typeStr,
-			parentStr,
-			task.FormatTimestamp(t.Created),
-			task.FormatTimestamp(t.Updated),
-			closedStr,
-		); err != nil {
-			return fmt.Errorf("failed to insert task %s: %w", t.ID, err)
+	if _, err := ins.task.Exec(
+		t.ID,
+		t.Title,
+		string(t.Status),
+		t.Priority,
+		descStr,
+		typeStr,
+		parentStr,
+		task.FormatTimestamp(t.Created),
+		task.FormatTimestamp(t.Updated),
+		closedStr,
+		dueStr,
+		deferStr,
+		estimate,
+		recurStr,
+		recurredFromStr,
+		assigneeStr,
+		leaseStr,
+		extraStr,
+	); err != nil {
+		return fmt.Errorf("failed to insert task %s: %w", t.ID, err)
+	}
+
+	for _, dep := range t.BlockedBy {
+		if _, err := ins.dep.Exec(t.ID, dep); err != nil {
+			return fmt.Errorf("failed to insert dependency %s -> %s: %w", t.ID, dep, err)
 		}
+	}
 
-		for _, dep := range t.BlockedBy {
-			if _, err := insertDep.Exec(t.ID, dep); err != nil {
-				return fmt.Errorf("failed to insert dependency %s -> %s: %w", t.ID, dep, err)
-			}
+	for _, tag := range t.Tags {
+		if _, err := ins.tag.Exec(t.ID, tag); err != nil {
+			return fmt.Errorf("failed to insert tag %s -> %s: %w", t.ID, tag, err)
 		}
+	}
 
-		for _, tag := range t.Tags {
-			if _, err := insertTag.Exec(t.ID, tag); err != nil {
-				return fmt.Errorf("failed to insert tag %s -> %s: %w", t.ID, tag, err)
-			}
+	for _, ref := range t.Refs {
+		if _, err := ins.ref.Exec(t.ID, ref); err != nil {
+			return fmt.Errorf("failed to insert ref %s -> %s: %w", t.ID, ref, err)
 		}
+	}
 
-		for _, ref := range t.Refs {
-			if _, err := insertRef.Exec(t.ID, ref); err != nil {
-				return fmt.Errorf("failed to insert ref %s -> %s: %w", t.ID, ref, err)
-			}
+	for key, value := range t.Fields {
+		if _, err := ins.field.Exec(t.ID, key, value); err != nil {
+			return fmt.Errorf("failed to insert field %s -> %s: %w", t.ID, key, err)
 		}
+	}
 
-		for _, note := range t.Notes {
-			if _, err := insertNote.Exec(t.ID, note.Text, task.FormatTimestamp(note.Created)); err != nil {
-				return fmt.Errorf("failed to insert note for %s: %w", t.ID, err)
-			}
+	for _, note := range t.Notes {
+		if _, err := ins.note.Exec(t.ID, note.Text, task.FormatTimestamp(note.Created)); err != nil {
+			return fmt.Errorf("failed to insert note for %s: %w", t.ID, err)
 		}
+	}
 
-		for _, tr := range t.Transitions {
-			autoInt := 0
-			if tr.Auto {
-				autoInt = 1
-			}
-			if _, err := insertTransition.Exec(t.ID, string(tr.From), string(tr.To), task.FormatTimestamp(tr.At), autoInt); err != nil {
-				return fmt.Errorf("failed to insert transition for %s: %w", t.ID, err)
-			}
+	for _, tr := range t.Transitions {
+		autoInt := 0
+		if tr.Auto {
+			autoInt = 1
+		}
+		if _, err := ins.transition.Exec(t.ID, string(tr.From), string(tr.To), task.FormatTimestamp(tr.At), autoInt); err != nil {
+			return fmt.Errorf("failed to insert transition for %s: %w", t.ID, err)
 		}
 	}
 
+	noteTexts := make([]string, len(t.Notes))
+	for i, note := range t.Notes {
+		noteTexts[i] = note.Text
+	}
+	if _, err := ins.fts.Exec(t.ID, t.Title, t.Description, strings.Join(noteTexts, "\n")); err != nil {
+		return fmt.Errorf("failed to index task %s for search: %w", t.ID, err)
+	}
+
+	return nil
+}
+
+// writeMetadata stores the JSONL content hash and schema version within tx.
+func writeMetadata(tx *sql.Tx, rawJSONL []byte) error {
 	// Store the JSONL content hash.
 	hash := computeHash(rawJSONL)
 	if _, err := tx.Exec(
@@ -259,10 +513,6 @@ func (c *Cache) Rebuild(tasks []task.Task, rawJSONL []byte) error {
 		return fmt.Errorf("failed to store schema version: %w", err)
 	}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit rebuild transaction: %w", err)
-	}
-
 	return nil
 }
 
@@ -30,7 +30,9 @@ func TestCacheSchema(t *testing.T) {
 		expectedTaskCols := map[string]bool{
 			"id": true, "title": true, "status": true, "priority": true,
 			"type": true, "description": true, "parent": true, "created": true,
-			"updated": true, "closed": true,
+			"updated": true, "closed": true, "due": true, "defer_until": true, "estimate": true,
+			"recur": true, "recurred_from": true,
+			"assignee": true, "lease_expires": true, "extra": true,
 		}
 		if len(taskCols) != len(expectedTaskCols) {
 			t.Errorf("tasks table: expected %d columns, got %d: %v", len(expectedTaskCols), len(taskCols), taskCols)
@@ -71,6 +73,7 @@ func TestCacheSchema(t *testing.T) {
 			"idx_tasks_status":   true,
 			"idx_tasks_priority": true,
 			"idx_tasks_parent":   true,
+			"idx_tasks_due":      true,
 		}
 		for idx := range expectedIndexes {
 			if !indexes[idx] {
@@ -165,6 +168,28 @@ func TestCacheSchema(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("it creates task_fields table in schema", func(t *testing.T) {
+		dir := t.TempDir()
+		dbPath := filepath.Join(dir, "cache.db")
+
+		cache, err := OpenCache(dbPath)
+		if err != nil {
+			t.Fatalf("OpenCache returned error: %v", err)
+		}
+		defer cache.Close()
+
+		fieldCols := queryColumns(t, cache.DB(), "task_fields")
+		expectedFieldCols := map[string]bool{"task_id": true, "key": true, "value": true}
+		if len(fieldCols) != len(expectedFieldCols) {
+			t.Errorf("task_fields table: expected %d columns, got %d: %v", len(expectedFieldCols), len(fieldCols), fieldCols)
+		}
+		for col := range expectedFieldCols {
+			if !fieldCols[col] {
+				t.Errorf("task_fields table missing column %q", col)
+			}
+		}
+	})
 }
 
 // queryColumns returns a set of column names for the given table.
@@ -611,6 +636,152 @@ func TestCacheRebuild(t *testing.T) {
 		}
 	})
 
+	t.Run("it stores the due and defer dates and the estimate during rebuild", func(t *testing.T) {
+		dir := t.TempDir()
+		dbPath := filepath.Join(dir, "cache.db")
+
+		cache, err := OpenCache(dbPath)
+		if err != nil {
+			t.Fatalf("OpenCache returned error: %v", err)
+		}
+		defer cache.Close()
+
+		created := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+		due := time.Date(2026, 2, 1, 17, 0, 0, 0, time.UTC)
+		deferUntil := time.Date(2026, 1, 26, 9, 0, 0, 0, time.UTC)
+		tasks := []task.Task{
+			{ID: "tick-a1b2c3", Title: "Due", Status: task.StatusOpen, Priority: 2, Due: &due, DeferUntil: &deferUntil, Estimate: 2.5, Created: created, Updated: created},
+			{ID: "tick-d4e5f6", Title: "Undated", Status: task.StatusOpen, Priority: 2, Created: created, Updated: created},
+		}
+
+		if err := cache.Rebuild(tasks, []byte("raw")); err != nil {
+			t.Fatalf("Rebuild returned error: %v", err)
+		}
+
+		var got *string
+		if err := cache.DB().QueryRow("SELECT due FROM tasks WHERE id = ?", "tick-a1b2c3").Scan(&got); err != nil {
+			t.Fatalf("querying due: %v", err)
+		}
+		if got == nil || *got != "2026-02-01T17:00:00Z" {
+			t.Errorf("due = %v, want 2026-02-01T17:00:00Z", got)
+		}
+		if err := cache.DB().QueryRow("SELECT defer_until FROM tasks WHERE id = ?", "tick-a1b2c3").Scan(&got); err != nil {
+			t.Fatalf("querying defer_until: %v", err)
+		}
+		if got == nil || *got != "2026-01-26T09:00:00Z" {
+			t.Errorf("defer_until = %v, want 2026-01-26T09:00:00Z", got)
+		}
+		var estimate *float64
+		if err := cache.DB().QueryRow("SELECT estimate FROM tasks WHERE id = ?", "tick-a1b2c3").Scan(&estimate); err != nil {
+			t.Fatalf("querying estimate: %v", err)
+		}
+		if estimate == nil || *estimate != 2.5 {
+			t.Errorf("estimate = %v, want 2.5", estimate)
+		}
+		if err := cache.DB().QueryRow("SELECT estimate FROM tasks WHERE id = ?", "tick-d4e5f6").Scan(&estimate); err != nil {
+			t.Fatalf("querying estimate: %v", err)
+		}
+		if estimate != nil {
+			t.Errorf("estimate = %v, want NULL", *estimate)
+		}
+		if err := cache.DB().QueryRow("SELECT due FROM tasks WHERE id = ?", "tick-d4e5f6").Scan(&got); err != nil {
+			t.Fatalf("querying due: %v", err)
+		}
+		if got != nil {
+			t.Errorf("due = %q, want NULL", *got)
+		}
+	})
+
+	t.Run("it stores the recurrence rule and origin during rebuild", func(t *testing.T) {
+		dir := t.TempDir()
+		dbPath := filepath.Join(dir, "cache.db")
+
+		cache, err := OpenCache(dbPath)
+		if err != nil {
+			t.Fatalf("OpenCache returned error: %v", err)
+		}
+		defer cache.Close()
+
+		created := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+		tasks := []task.Task{
+			{ID: "tick-a1b2c3", Title: "Rotate keys", Status: task.StatusOpen, Priority: 2, Recur: "weekly", RecurredFrom: "tick-d4e5f6", Created: created, Updated: created},
+			{ID: "tick-d4e5f6", Title: "Rotate keys", Status: task.StatusDone, Priority: 2, Created: created, Updated: created, Closed: &created},
+		}
+
+		if err := cache.Rebuild(tasks, []byte("raw")); err != nil {
+			t.Fatalf("Rebuild returned error: %v", err)
+		}
+
+		var recur, recurredFrom *string
+		if err := cache.DB().QueryRow("SELECT recur, recurred_from FROM tasks WHERE id = ?", "tick-a1b2c3").Scan(&recur, &recurredFrom); err != nil {
+			t.Fatalf("querying recurrence: %v", err)
+		}
+		if recur == nil || *recur != "weekly" || recurredFrom == nil || *recurredFrom != "tick-d4e5f6" {
+			t.Errorf("recur, recurred_from = %v, %v, want weekly, tick-d4e5f6", recur, recurredFrom)
+		}
+		if err := cache.DB().QueryRow("SELECT recur, recurred_from FROM tasks WHERE id = ?", "tick-d4e5f6").Scan(&recur, &recurredFrom); err != nil {
+			t.Fatalf("querying recurrence: %v", err)
+		}
+		if recur != nil || recurredFrom != nil {
+			t.Errorf("recur, recurred_from = %v, %v, want NULL", recur, recurredFrom)
+		}
+	})
+
+	t.Run("it populates fields in task_fields during rebuild", func(t *testing.T) {
+		dir := t.TempDir()
+		dbPath := filepath.Join(dir, "cache.db")
+
+		cache, err := OpenCache(dbPath)
+		if err != nil {
+			t.Fatalf("OpenCache returned error: %v", err)
+		}
+		defer cache.Close()
+
+		created := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+		tasks := []task.Task{
+			{
+				ID:       "tick-a1b2c3",
+				Title:    "Task with fields",
+				Status:   task.StatusOpen,
+				Priority: 2,
+				Fields:   map[string]string{"estimate": "3d", "pr_url": "https://github.com/org/repo/pull/7"},
+				Created:  created,
+				Updated:  created,
+			},
+		}
+
+		if err := cache.Rebuild(tasks, []byte("raw")); err != nil {
+			t.Fatalf("Rebuild returned error: %v", err)
+		}
+
+		rows, err := cache.DB().Query("SELECT key, value FROM task_fields WHERE task_id = ? ORDER BY key", "tick-a1b2c3")
+		if err != nil {
+			t.Fatalf("querying task_fields: %v", err)
+		}
+		defer rows.Close()
+
+		got := map[string]string{}
+		var keys []string
+		for rows.Next() {
+			var key, value string
+			if err := rows.Scan(&key, &value); err != nil {
+				t.Fatalf("scanning field row: %v", err)
+			}
+			got[key] = value
+			keys = append(keys, key)
+		}
+
+		if len(keys) != 2 || keys[0] != "estimate" || keys[1] != "pr_url" {
+			t.Fatalf("field keys = %v, want [estimate pr_url]", keys)
+		}
+		if got["estimate"] != "3d" {
+			t.Errorf("estimate = %q, want %q", got["estimate"], "3d")
+		}
+		if got["pr_url"] != "https://github.com/org/repo/pull/7" {
+			t.Errorf("pr_url = %q, want %q", got["pr_url"], "https://github.com/org/repo/pull/7")
+		}
+	})
+
 	t.Run("it populates refs in task_refs during rebuild", func(t *testing.T) {
 		dir := t.TempDir()
 		dbPath := filepath.Join(dir, "cache.db")
@@ -1136,8 +1307,8 @@ func TestSchemaVersion(t *testing.T) {
 		if err != nil {
 			t.Fatalf("querying schema_version: %v", err)
 		}
-		if value != "2" {
-			t.Errorf("schema_version = %q, want %q", value, "2")
+		if value != "11" {
+			t.Errorf("schema_version = %q, want %q", value, "11")
 		}
 	})
 
@@ -1159,8 +1330,8 @@ func TestSchemaVersion(t *testing.T) {
 		if err != nil {
 			t.Fatalf("SchemaVersion returned error: %v", err)
 		}
-		if version != 2 {
-			t.Errorf("SchemaVersion() = %d, want %d", version, 2)
+		if version != 11 {
+			t.Errorf("SchemaVersion() = %d, want %d", version, 11)
 		}
 	})
 
@@ -1174,7 +1345,11 @@ func TestSchemaVersion(t *testing.T) {
 		}
 		defer cache.Close()
 
-		// No Rebuild() called — metadata table exists but has no schema_version row.
+		// The metadata table exists but has no schema_version row, as in caches
+		// written before the version was recorded.
+		if _, err := cache.DB().Exec("DELETE FROM metadata WHERE key = 'schema_version'"); err != nil {
+			t.Fatalf("failed to delete schema_version: %v", err)
+		}
 		version, err := cache.SchemaVersion()
 		if err != nil {
 			t.Fatalf("SchemaVersion returned error: %v", err)
@@ -1184,6 +1359,33 @@ func TestSchemaVersion(t *testing.T) {
 		}
 	})
 
+	t.Run("it stamps a new cache with the current schema version before any rebuild", func(t *testing.T) {
+		dbPath := filepath.Join(t.TempDir(), "cache.db")
+
+		cache, err := OpenCache(dbPath)
+		if err != nil {
+			t.Fatalf("OpenCache returned error: %v", err)
+		}
+		defer cache.Close()
+
+		// A second reader opening the new cache must not find it incompatible.
+		other, err := OpenCache(dbPath)
+		if err != nil {
+			t.Fatalf("second OpenCache returned error: %v", err)
+		}
+		defer other.Close()
+		version, err := other.SchemaVersion()
+		if err != nil {
+			t.Fatalf("SchemaVersion returned error: %v", err)
+		}
+		if version != CurrentSchemaVersion() {
+			t.Errorf("SchemaVersion() = %d, want %d", version, CurrentSchemaVersion())
+		}
+		if fresh, err := other.IsFresh([]byte("")); err != nil || fresh {
+			t.Errorf("IsFresh() = %v, %v; a new cache is never fresh", fresh, err)
+		}
+	})
+
 	t.Run("it stores schema_version in the same transaction as jsonl_hash", func(t *testing.T) {
 		dir := t.TempDir()
 		dbPath := filepath.Join(dir, "cache.db")
@@ -1240,8 +1442,8 @@ func TestSchemaVersion(t *testing.T) {
 		if err != nil {
 			t.Fatalf("SchemaVersion returned error: %v", err)
 		}
-		if version != 2 {
-			t.Errorf("SchemaVersion() = %d, want %d (original should be preserved)", version, 2)
+		if version != 11 {
+			t.Errorf("SchemaVersion() = %d, want %d (original should be preserved)", version, 11)
 		}
 
 		// Verify jsonl_hash was also NOT updated (still from valid rebuild).
@@ -1258,8 +1460,8 @@ func TestSchemaVersion(t *testing.T) {
 
 	t.Run("it returns compiled-in version via CurrentSchemaVersion()", func(t *testing.T) {
 		version := CurrentSchemaVersion()
-		if version != 2 {
-			t.Errorf("CurrentSchemaVersion() = %d, want %d", version, 2)
+		if version != 11 {
+			t.Errorf("CurrentSchemaVersion() = %d, want %d", version, 11)
 		}
 	})
 }
@@ -1750,10 +1952,10 @@ func TestTaskTransitionsSchema(t *testing.T) {
 	})
 
 	t.Run("it triggers rebuild on schema version mismatch", func(t *testing.T) {
-		// This test verifies the schema version constant changed to 2.
+		// This test verifies the schema version constant changed to 7.
 		version := CurrentSchemaVersion()
-		if version != 2 {
-			t.Errorf("CurrentSchemaVersion() = %d, want %d", version, 2)
+		if version != 11 {
+			t.Errorf("CurrentSchemaVersion() = %d, want %d", version, 11)
 		}
 	})
 }
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// TaskDiff describes the per-task changes between two task lists.
+// Upserted holds tasks that were added or whose serialized form changed;
+// Removed holds the IDs of tasks no longer present.
+type TaskDiff struct {
+	Upserted []task.Task
+	Removed  []string
+}
+
+// Empty reports whether the diff contains no changes.
+func (d TaskDiff) Empty() bool {
+	return len(d.Upserted) == 0 && len(d.Removed) == 0
+}
+
+// snapshotTasks captures the serialized form of each task keyed by ID, so that
+// a later diff is unaffected by in-place mutation of the task slice.
+// Returns ok=false when IDs are not unique, since a keyed diff cannot
+// represent such a list faithfully.
+func snapshotTasks(tasks []task.Task) (map[string][]byte, bool, error) {
+	snap := make(map[string][]byte, len(tasks))
+	for _, t := range tasks {
+		if _, dup := snap[t.ID]; dup {
+			return nil, false, nil
+		}
+		data, err := json.Marshal(t)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to marshal task %s: %w", t.ID, err)
+		}
+		snap[t.ID] = data
+	}
+	return snap, true, nil
+}
+
+// diffTasks compares a snapshot of the pre-mutation tasks against the mutated
+// list. Returns ok=false when the mutated list has duplicate IDs.
+func diffTasks(before map[string][]byte, after []task.Task) (TaskDiff, bool, error) {
+	var diff TaskDiff
+	seen := make(map[string]bool, len(after))
+	for _, t := range after {
+		if seen[t.ID] {
+			return TaskDiff{}, false, nil
+		}
+		seen[t.ID] = true
+
+		data, err := json.Marshal(t)
+		if err != nil {
+			return TaskDiff{}, false, fmt.Errorf("failed to marshal task %s: %w", t.ID, err)
+		}
+		if prev, ok := before[t.ID]; !ok || string(prev) != string(data) {
+			diff.Upserted = append(diff.Upserted, t)
+		}
+	}
+	for id := range before {
+		if !seen[id] {
+			diff.Removed = append(diff.Removed, id)
+		}
+	}
+	return diff, true, nil
+}
@@ -0,0 +1,216 @@
+package storage
+
+import (
+	"database/sql"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+func TestDiffTasks(t *testing.T) {
+	created := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+	base := []task.Task{
+		{ID: "tick-aaaaaa", Title: "Unchanged", Status: task.StatusOpen, Priority: 2, Created: created, Updated: created},
+		{ID: "tick-bbbbbb", Title: "Changed", Status: task.StatusOpen, Priority: 2, Created: created, Updated: created},
+		{ID: "tick-cccccc", Title: "Removed", Status: task.StatusOpen, Priority: 2, Created: created, Updated: created},
+	}
+
+	t.Run("it reports added, changed, and removed tasks but not unchanged ones", func(t *testing.T) {
+		before, ok, err := snapshotTasks(base)
+		if err != nil || !ok {
+			t.Fatalf("snapshotTasks = ok %v, err %v", ok, err)
+		}
+
+		after := slices.Clone(base[:2])
+		after[1].Status = task.StatusInProgress
+		after = append(after, task.Task{ID: "tick-dddddd", Title: "Added", Status: task.StatusOpen, Created: created, Updated: created})
+
+		diff, ok, err := diffTasks(before, after)
+		if err != nil || !ok {
+			t.Fatalf("diffTasks = ok %v, err %v", ok, err)
+		}
+
+		var upserted []string
+		for _, tk := range diff.Upserted {
+			upserted = append(upserted, tk.ID)
+		}
+		if !slices.Equal(upserted, []string{"tick-bbbbbb", "tick-dddddd"}) {
+			t.Errorf("upserted = %v, want [tick-bbbbbb tick-dddddd]", upserted)
+		}
+		if !slices.Equal(diff.Removed, []string{"tick-cccccc"}) {
+			t.Errorf("removed = %v, want [tick-cccccc]", diff.Removed)
+		}
+	})
+
+	t.Run("it is unaffected by in-place mutation after the snapshot", func(t *testing.T) {
+		tasks := slices.Clone(base)
+		before, _, _ := snapshotTasks(tasks)
+
+		tasks[0].Title = "Mutated in place"
+
+		diff, _, _ := diffTasks(before, tasks)
+		if len(diff.Upserted) != 1 || diff.Upserted[0].ID != "tick-aaaaaa" {
+			t.Errorf("upserted = %v, want only tick-aaaaaa", diff.Upserted)
+		}
+	})
+
+	t.Run("it returns an empty diff when nothing changed", func(t *testing.T) {
+		before, _, _ := snapshotTasks(base)
+
+		diff, ok, err := diffTasks(before, base)
+		if err != nil || !ok {
+			t.Fatalf("diffTasks = ok %v, err %v", ok, err)
+		}
+		if !diff.Empty() {
+			t.Errorf("diff = %+v, want empty", diff)
+		}
+	})
+
+	t.Run("it declines to diff when IDs are duplicated", func(t *testing.T) {
+		dup := append(slices.Clone(base), base[0])
+
+		if _, ok, _ := snapshotTasks(dup); ok {
+			t.Error("snapshotTasks ok = true for duplicate IDs, want false")
+		}
+		before, _, _ := snapshotTasks(base)
+		if _, ok, _ := diffTasks(before, dup); ok {
+			t.Error("diffTasks ok = true for duplicate IDs, want false")
+		}
+	})
+}
+
+func TestCacheApply(t *testing.T) {
+	created := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+	base := []task.Task{
+		{ID: "tick-aaaaaa", Title: "Keep", Status: task.StatusOpen, Priority: 2, Tags: []string{"api"}, Created: created, Updated: created},
+		{ID: "tick-bbbbbb", Title: "Change", Status: task.StatusOpen, Priority: 2, Tags: []string{"old"}, BlockedBy: []string{"tick-aaaaaa"}, Created: created, Updated: created},
+		{ID: "tick-cccccc", Title: "Remove", Status: task.StatusOpen, Priority: 2, Notes: []task.Note{{Text: "n", Created: created}}, Created: created, Updated: created},
+	}
+
+	openRebuilt := func(t *testing.T) (*Cache, []byte) {
+		t.Helper()
+		cache, err := OpenCache(filepath.Join(t.TempDir(), "cache.db"))
+		if err != nil {
+			t.Fatalf("OpenCache returned error: %v", err)
+		}
+		t.Cleanup(func() { cache.Close() })
+		raw, err := MarshalJSONL(base)
+		if err != nil {
+			t.Fatalf("MarshalJSONL returned error: %v", err)
+		}
+		if err := cache.Rebuild(base, raw); err != nil {
+			t.Fatalf("Rebuild returned error: %v", err)
+		}
+		return cache, raw
+	}
+
+	count := func(t *testing.T, db *sql.DB, query string, args ...any) int {
+		t.Helper()
+		var n int
+		if err := db.QueryRow(query, args...).Scan(&n); err != nil {
+			t.Fatalf("query %q failed: %v", query, err)
+		}
+		return n
+	}
+
+	t.Run("it applies upserts and removals to every table and advances the hash", func(t *testing.T) {
+		cache, prevRaw := openRebuilt(t)
+
+		changed := base[1]
+		changed.Title = "Changed"
+		changed.Tags = []string{"new"}
+		changed.BlockedBy = nil
+		added := task.Task{ID: "tick-dddddd", Title: "Added", Status: task.StatusOpen, Priority: 1, Refs: []string{"gh-1"}, Created: created, Updated: created}
+		after := []task.Task{base[0], changed, added}
+		newRaw, _ := MarshalJSONL(after)
+
+		applied, err := cache.Apply(TaskDiff{Upserted: []task.Task{changed, added}, Removed: []string{"tick-cccccc"}}, prevRaw, newRaw)
+		if err != nil {
+			t.Fatalf("Apply returned error: %v", err)
+		}
+		if !applied {
+			t.Fatal("applied = false, want true")
+		}
+
+		db := cache.DB()
+		if n := count(t, db, "SELECT COUNT(*) FROM tasks"); n != 3 {
+			t.Errorf("tasks = %d, want 3", n)
+		}
+		var title string
+		if err := db.QueryRow("SELECT title FROM tasks WHERE id = 'tick-bbbbbb'").Scan(&title); err != nil || title != "Changed" {
+			t.Errorf("title = %q (err %v), want Changed", title, err)
+		}
+		if n := count(t, db, "SELECT COUNT(*) FROM task_tags WHERE task_id = 'tick-bbbbbb' AND tag = 'new'"); n != 1 {
+			t.Errorf("new tag rows = %d, want 1", n)
+		}
+		if n := count(t, db, "SELECT COUNT(*) FROM task_tags WHERE tag = 'old'"); n != 0 {
+			t.Errorf("old tag rows = %d, want 0", n)
+		}
+		if n := count(t, db, "SELECT COUNT(*) FROM task_tags WHERE task_id = 'tick-aaaaaa'"); n != 1 {
+			t.Errorf("untouched task tag rows = %d, want 1", n)
+		}
+		if n := count(t, db, "SELECT COUNT(*) FROM dependencies"); n != 0 {
+			t.Errorf("dependencies = %d, want 0", n)
+		}
+		if n := count(t, db, "SELECT COUNT(*) FROM task_notes"); n != 0 {
+			t.Errorf("notes = %d, want 0 after removal", n)
+		}
+		if n := count(t, db, "SELECT COUNT(*) FROM task_refs WHERE task_id = 'tick-dddddd'"); n != 1 {
+			t.Errorf("refs for added task = %d, want 1", n)
+		}
+		if n := count(t, db, "SELECT COUNT(*) FROM tasks_fts"); n != 3 {
+			t.Errorf("search index rows = %d, want 3", n)
+		}
+		if n := count(t, db, "SELECT COUNT(*) FROM tasks_fts f JOIN tasks t ON t.rowid = f.rowid WHERE f.title = t.title"); n != 3 {
+			t.Errorf("search index rows matching their task = %d, want 3", n)
+		}
+		if n := count(t, db, "SELECT COUNT(*) FROM tasks_fts WHERE tasks_fts MATCH 'changed'"); n != 1 {
+			t.Errorf("search index rows for the changed title = %d, want 1", n)
+		}
+
+		fresh, err := cache.IsFresh(newRaw)
+		if err != nil || !fresh {
+			t.Errorf("IsFresh(newRaw) = %v (err %v), want true", fresh, err)
+		}
+	})
+
+	t.Run("it writes nothing and reports not applied when the stored hash does not match", func(t *testing.T) {
+		cache, _ := openRebuilt(t)
+
+		changed := base[0]
+		changed.Title = "Should not apply"
+
+		applied, err := cache.Apply(TaskDiff{Upserted: []task.Task{changed}}, []byte("other content"), []byte("new"))
+		if err != nil {
+			t.Fatalf("Apply returned error: %v", err)
+		}
+		if applied {
+			t.Fatal("applied = true, want false")
+		}
+
+		var title string
+		_ = cache.DB().QueryRow("SELECT title FROM tasks WHERE id = 'tick-aaaaaa'").Scan(&title)
+		if title != "Keep" {
+			t.Errorf("title = %q, want unchanged Keep", title)
+		}
+	})
+
+	t.Run("it reports not applied on an empty cache with no stored hash", func(t *testing.T) {
+		cache, err := OpenCache(filepath.Join(t.TempDir(), "cache.db"))
+		if err != nil {
+			t.Fatalf("OpenCache returned error: %v", err)
+		}
+		defer cache.Close()
+
+		applied, err := cache.Apply(TaskDiff{}, nil, nil)
+		if err != nil {
+			t.Fatalf("Apply returned error: %v", err)
+		}
+		if applied {
+			t.Error("applied = true, want false")
+		}
+	})
+}
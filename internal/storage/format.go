@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// FormatFileName is the file under .tick/ that records the data format version
+// of the project's task data as a plain integer.
+const FormatFileName = "format"
+
+// FormatVersion is the task data format this version of tick reads and writes.
+// Projects without a format file predate it and are at version 1.
+const FormatVersion = 4
+
+// upgradeStep migrates task data from one format version to the next. apply
+// receives every task, active and archived, and must be safe to run again on
+// data it has already upgraded, since an interrupted upgrade is repeated.
+type upgradeStep struct {
+	from        int
+	description string
+	apply       func(tasks []task.Task) ([]task.Task, error)
+}
+
+// upgradeSteps is the registry of format migrations, in order. Step i upgrades
+// from version i+1 to i+2, so len(upgradeSteps) == FormatVersion-1.
+var upgradeSteps = []upgradeStep{
+	{
+		from:        1,
+		description: "rewrite tasks in canonical form and record the format version",
+		apply: func(tasks []task.Task) ([]task.Task, error) {
+			// Parsing and re-marshaling is the whole upgrade: it orders fields,
+			// drops empty optional fields, and normalizes hand-edited lines.
+			return tasks, nil
+		},
+	},
+	{
+		from:        2,
+		description: "allow task statuses from the project's workflow states",
+		apply: func(tasks []task.Task) ([]task.Task, error) {
+			// Existing tasks use built-in statuses, which every workflow keeps.
+			// Versions of tick that know the format refuse the project from
+			// here on, so none rejects a custom status; checkWriteFormat holds
+			// custom statuses back until then.
+			return tasks, nil
+		},
+	},
+	{
+		from:        3,
+		description: "add defer dates to tasks",
+		apply: func(tasks []task.Task) ([]task.Task, error) {
+			// No task has a defer_until yet. Versions of tick that know the
+			// format refuse the project from here on, so none lists a deferred
+			// task as ready; checkWriteFormat holds defer dates back until then.
+			return tasks, nil
+		},
+	},
+}
+
+// UpgradeResult holds the outcome of Upgrade.
+type UpgradeResult struct {
+	// From and To are the format versions before and after the upgrade. They are
+	// equal when the project is already current.
+	From, To int
+	// Steps describes each step applied, or that would be applied on a dry run.
+	Steps []string
+}
+
+// ReadFormatVersion returns the format version recorded in tickDir, or 1 when
+// the project has no format file.
+func ReadFormatVersion(tickDir string) (int, error) {
+	data, err := os.ReadFile(filepath.Join(tickDir, FormatFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 1, nil
+		}
+		return 0, fmt.Errorf("failed to read %s: %w", FormatFileName, err)
+	}
+	version, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid format version in .tick/%s: %q", FormatFileName, strings.TrimSpace(string(data)))
+	}
+	return version, nil
+}
+
+// WriteFormatVersion records version as the format of the task data in tickDir.
+func WriteFormatVersion(tickDir string, version int) error {
+	return writeAtomic(filepath.Join(tickDir, FormatFileName), []byte(strconv.Itoa(version)+"\n"))
+}
+
+// checkFormatVersion refuses task data written in a format newer than this
+// version of tick understands, rather than risk rewriting it without the
+// fields or files it does not know about.
+func checkFormatVersion(tickDir string) error {
+	version, err := ReadFormatVersion(tickDir)
+	if err != nil {
+		return err
+	}
+	if version > FormatVersion {
+		return fmt.Errorf("this project uses tick data format %d, but this version of tick supports up to format %d; upgrade tick to use it", version, FormatVersion)
+	}
+	return nil
+}
+
+// checkWriteFormat refuses to change task data recorded in a format older than
+// FormatVersion. The data this version writes, such as workflow statuses and
+// defer dates, would be misread or dropped by the older versions of tick the
+// recorded format still admits; tick upgrade records the current format first.
+func checkWriteFormat(tickDir string) error {
+	version, err := ReadFormatVersion(tickDir)
+	if err != nil {
+		return err
+	}
+	if version < FormatVersion {
+		return fmt.Errorf("this project uses tick data format %d, but this version of tick writes format %d; run tick upgrade before changing tasks", version, FormatVersion)
+	}
+	return nil
+}
+
+// Upgrade migrates the Store's task data, including archived tasks, from its
+// recorded format version to FormatVersion by applying each registered step in
+// turn, then records the new version. The format file is written last, so an
+// interrupted upgrade is retried from the old version. With dryRun, nothing is
+// written and the result lists the steps that would be applied.
+func (s *Store) Upgrade(dryRun bool) (UpgradeResult, error) {
+	if err := s.readOnly(); err != nil {
+		return UpgradeResult{}, err
+	}
+
+	unlock, err := s.acquireExclusive()
+	if err != nil {
+		return UpgradeResult{}, err
+	}
+	defer unlock()
+
+	from, err := ReadFormatVersion(s.tickDir)
+	if err != nil {
+		return UpgradeResult{}, err
+	}
+	result := UpgradeResult{From: from, To: FormatVersion}
+	var steps []upgradeStep
+	for _, step := range upgradeSteps {
+		if step.from >= from {
+			steps = append(steps, step)
+			result.Steps = append(result.Steps, fmt.Sprintf("%d → %d: %s", step.from, step.from+1, step.description))
+		}
+	}
+	if len(steps) == 0 || dryRun {
+		return result, nil
+	}
+
+	apply := func(tasks []task.Task) ([]task.Task, error) {
+		for _, step := range steps {
+			s.verbose(fmt.Sprintf("upgrading format %d to %d", step.from, step.from+1))
+			var err error
+			if tasks, err = step.apply(tasks); err != nil {
+				return nil, fmt.Errorf("format upgrade %d → %d failed: %w", step.from, step.from+1, err)
+			}
+		}
+		return tasks, nil
+	}
+
+	archived, err := readArchive(s.archivePath)
+	if err != nil {
+		return UpgradeResult{}, err
+	}
+	if len(archived) > 0 {
+		if archived, err = apply(archived); err != nil {
+			return UpgradeResult{}, err
+		}
+		if err := WriteJSONL(s.archivePath, archived); err != nil {
+			return UpgradeResult{}, fmt.Errorf("failed to write %s: %w", archiveFileName, err)
+		}
+	}
+
+	if err := s.mutateLocked(&JournalEntry{Op: JournalUpgrade}, apply); err != nil {
+		return UpgradeResult{}, err
+	}
+
+	if err := WriteFormatVersion(s.tickDir, FormatVersion); err != nil {
+		return UpgradeResult{}, fmt.Errorf("failed to write %s: %w", FormatFileName, err)
+	}
+	return result, nil
+}
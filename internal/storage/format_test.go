@@ -0,0 +1,245 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+func TestFormatVersion(t *testing.T) {
+	t.Run("it treats a project without a format file as version 1", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		if err := os.Remove(filepath.Join(tickDir, FormatFileName)); err != nil {
+			t.Fatalf("failed to remove format file: %v", err)
+		}
+
+		version, err := ReadFormatVersion(tickDir)
+		if err != nil || version != 1 {
+			t.Errorf("ReadFormatVersion = %d, %v; want 1", version, err)
+		}
+	})
+
+	t.Run("it round-trips the recorded version", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		if err := WriteFormatVersion(tickDir, FormatVersion); err != nil {
+			t.Fatalf("WriteFormatVersion returned error: %v", err)
+		}
+
+		version, err := ReadFormatVersion(tickDir)
+		if err != nil || version != FormatVersion {
+			t.Errorf("ReadFormatVersion = %d, %v; want %d", version, err, FormatVersion)
+		}
+	})
+
+	t.Run("it rejects an invalid format file", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		if err := os.WriteFile(filepath.Join(tickDir, FormatFileName), []byte("two\n"), 0644); err != nil {
+			t.Fatalf("failed to write format file: %v", err)
+		}
+
+		_, err := ReadFormatVersion(tickDir)
+		if err == nil || err.Error() != `invalid format version in .tick/format: "two"` {
+			t.Errorf("error = %v", err)
+		}
+	})
+
+	t.Run("it refuses to open a project in a newer format", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		if err := WriteFormatVersion(tickDir, FormatVersion+1); err != nil {
+			t.Fatalf("WriteFormatVersion returned error: %v", err)
+		}
+
+		_, err := NewStore(tickDir)
+		if err == nil || !strings.Contains(err.Error(), fmt.Sprintf("supports up to format %d; upgrade tick", FormatVersion)) {
+			t.Errorf("NewStore error = %v", err)
+		}
+	})
+
+	t.Run("it refuses to change tasks in an older format until upgraded", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Task", Status: task.StatusOpen, Priority: 2, Created: time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC), Updated: time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)},
+		})
+		if err := WriteFormatVersion(tickDir, FormatVersion-1); err != nil {
+			t.Fatalf("WriteFormatVersion returned error: %v", err)
+		}
+		store, err := NewStore(tickDir)
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer store.Close()
+
+		if _, err := store.ReadTasks(); err != nil {
+			t.Errorf("ReadTasks returned error: %v; reads of older formats are allowed", err)
+		}
+		rename := func(tasks []task.Task) ([]task.Task, error) {
+			tasks[0].Title = "Renamed"
+			return tasks, nil
+		}
+		err = store.Mutate(rename)
+		if err == nil || !strings.Contains(err.Error(), "run tick upgrade before changing tasks") {
+			t.Fatalf("Mutate error = %v", err)
+		}
+		if tasks, _ := ReadJSONL(filepath.Join(tickDir, "tasks.jsonl")); tasks[0].Title != "Task" {
+			t.Errorf("refused mutation wrote title %q", tasks[0].Title)
+		}
+
+		if _, err := store.Upgrade(false); err != nil {
+			t.Fatalf("Upgrade returned error: %v", err)
+		}
+		if err := store.Mutate(rename); err != nil {
+			t.Errorf("Mutate after upgrade returned error: %v", err)
+		}
+	})
+
+	t.Run("it registers one upgrade step per version", func(t *testing.T) {
+		if len(upgradeSteps) != FormatVersion-1 {
+			t.Fatalf("len(upgradeSteps) = %d, want %d", len(upgradeSteps), FormatVersion-1)
+		}
+		for i, step := range upgradeSteps {
+			if step.from != i+1 {
+				t.Errorf("upgradeSteps[%d].from = %d, want %d", i, step.from, i+1)
+			}
+		}
+	})
+}
+
+func TestUpgrade(t *testing.T) {
+	// A hand-edited version 1 line: fields out of order, extra whitespace, and
+	// empty optional fields.
+	legacy := `{"title": "Legacy", "id": "tick-aaa111", "status": "open", "priority": 2, "description": "", "tags": [], "created": "2026-01-19T10:00:00Z", "updated": "2026-01-19T10:00:00Z"}` + "\n"
+	canonical := `{"id":"tick-aaa111","title":"Legacy","status":"open","priority":2,"created":"2026-01-19T10:00:00Z","updated":"2026-01-19T10:00:00Z"}` + "\n"
+
+	setupLegacy := func(t *testing.T) string {
+		t.Helper()
+		tickDir := setupTickDir(t)
+		if err := os.Remove(filepath.Join(tickDir, FormatFileName)); err != nil {
+			t.Fatalf("failed to remove format file: %v", err)
+		}
+		for _, name := range []string{"tasks.jsonl", archiveFileName} {
+			if err := os.WriteFile(filepath.Join(tickDir, name), []byte(legacy), 0644); err != nil {
+				t.Fatalf("failed to write %s: %v", name, err)
+			}
+		}
+		return tickDir
+	}
+	readFile := func(t *testing.T, path string) string {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+		return string(data)
+	}
+
+	t.Run("it upgrades active and archived tasks and records the version", func(t *testing.T) {
+		tickDir := setupLegacy(t)
+		store, _ := NewStore(tickDir)
+		defer store.Close()
+
+		result, err := store.Upgrade(false)
+		if err != nil {
+			t.Fatalf("Upgrade returned error: %v", err)
+		}
+		if result.From != 1 || result.To != FormatVersion || len(result.Steps) != FormatVersion-1 {
+			t.Errorf("result = %+v", result)
+		}
+		if got := readFile(t, filepath.Join(tickDir, "tasks.jsonl")); got != canonical {
+			t.Errorf("tasks.jsonl = %s, want %s", got, canonical)
+		}
+		if got := readFile(t, filepath.Join(tickDir, archiveFileName)); got != canonical {
+			t.Errorf("archive.jsonl = %s, want %s", got, canonical)
+		}
+		if got, want := readFile(t, filepath.Join(tickDir, FormatFileName)), fmt.Sprintf("%d\n", FormatVersion); got != want {
+			t.Errorf("format = %q, want %q", got, want)
+		}
+
+		again, err := store.Upgrade(false)
+		if err != nil || len(again.Steps) != 0 || again.From != FormatVersion {
+			t.Errorf("second Upgrade = %+v, %v; want no steps", again, err)
+		}
+	})
+
+	t.Run("it upgrades a format 2 project whose tasks use workflow states", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		config := "workflow:\n  states:\n    - {name: in_review, category: waiting}\n"
+		line := `{"id":"tick-aaa111","title":"Review","status":"in_review","priority":2,"created":"2026-01-19T10:00:00Z","updated":"2026-01-19T10:00:00Z"}` + "\n"
+		for name, content := range map[string]string{"config.yaml": config, "tasks.jsonl": line, FormatFileName: "2\n"} {
+			if err := os.WriteFile(filepath.Join(tickDir, name), []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write %s: %v", name, err)
+			}
+		}
+		store, err := NewStore(tickDir)
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer store.Close()
+
+		result, err := store.Upgrade(false)
+		if err != nil {
+			t.Fatalf("Upgrade returned error: %v", err)
+		}
+		if result.From != 2 || len(result.Steps) != FormatVersion-2 || !strings.HasPrefix(result.Steps[0], "2 → 3: ") {
+			t.Errorf("result = %+v", result)
+		}
+		if got := readFile(t, filepath.Join(tickDir, "tasks.jsonl")); got != line {
+			t.Errorf("tasks.jsonl = %s, want %s", got, line)
+		}
+		if version, _ := ReadFormatVersion(tickDir); version != FormatVersion {
+			t.Errorf("format version = %d, want %d", version, FormatVersion)
+		}
+	})
+
+	t.Run("it upgrades a format 3 project and keeps defer dates", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		line := `{"id":"tick-aaa111","title":"Later","status":"open","priority":2,"defer_until":"2099-01-01T09:00:00Z","created":"2026-01-19T10:00:00Z","updated":"2026-01-19T10:00:00Z"}` + "\n"
+		for name, content := range map[string]string{"tasks.jsonl": line, FormatFileName: "3\n"} {
+			if err := os.WriteFile(filepath.Join(tickDir, name), []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write %s: %v", name, err)
+			}
+		}
+		store, err := NewStore(tickDir)
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer store.Close()
+
+		result, err := store.Upgrade(false)
+		if err != nil {
+			t.Fatalf("Upgrade returned error: %v", err)
+		}
+		if result.From != 3 || len(result.Steps) != FormatVersion-3 || !strings.HasPrefix(result.Steps[0], "3 → 4: ") {
+			t.Errorf("result = %+v", result)
+		}
+		if got := readFile(t, filepath.Join(tickDir, "tasks.jsonl")); got != line {
+			t.Errorf("tasks.jsonl = %s, want %s", got, line)
+		}
+		if version, _ := ReadFormatVersion(tickDir); version != FormatVersion {
+			t.Errorf("format version = %d, want %d", version, FormatVersion)
+		}
+	})
+
+	t.Run("it writes nothing on a dry run", func(t *testing.T) {
+		tickDir := setupLegacy(t)
+		store, _ := NewStore(tickDir)
+		defer store.Close()
+
+		result, err := store.Upgrade(true)
+		if err != nil {
+			t.Fatalf("Upgrade returned error: %v", err)
+		}
+		if len(result.Steps) != FormatVersion-1 || !strings.HasPrefix(result.Steps[0], "1 → 2: ") {
+			t.Errorf("steps = %v", result.Steps)
+		}
+		if got := readFile(t, filepath.Join(tickDir, "tasks.jsonl")); got != legacy {
+			t.Errorf("dry run rewrote tasks.jsonl: %s", got)
+		}
+		if _, err := os.Stat(filepath.Join(tickDir, FormatFileName)); !os.IsNotExist(err) {
+			t.Error("dry run wrote the format file")
+		}
+	})
+}
@@ -0,0 +1,629 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// journalMaxEntries is how many of the most recent entries the journal keeps;
+// older ones are dropped, and can no longer be undone, when it is compacted.
+const journalMaxEntries = 1000
+
+// journalCompactInterval is how often, in entries, the journal is compacted, so
+// that appending does not read the whole journal on every mutation.
+const journalCompactInterval = 100
+
+// journalTailChunk is the size of the blocks read back from the end of the
+// journal to find its last entry.
+const journalTailChunk = 4096
+
+// JournalOp identifies the kind of operation a journal entry records.
+type JournalOp string
+
+const (
+	// JournalMutate records an ordinary mutation made through Store.Mutate.
+	JournalMutate JournalOp = "mutate"
+	// JournalUndo records the reversal of an earlier mutate entry.
+	JournalUndo JournalOp = "undo"
+	// JournalRedo records the re-application of an undone mutate entry.
+	JournalRedo JournalOp = "redo"
+	// JournalArchive records tasks moved to archive.jsonl. Undoing it moves them
+	// back.
+	JournalArchive JournalOp = "archive"
+	// JournalUnarchive records tasks restored from archive.jsonl. Undoing it
+	// archives them again.
+	JournalUnarchive JournalOp = "unarchive"
+	// JournalUpgrade records tasks rewritten by a format upgrade. It cannot be undone.
+	JournalUpgrade JournalOp = "upgrade"
+	// JournalRelease records expired leases released ahead of, or after, another
+	// change. It cannot be undone, so undoing that change never hands an
+	// expired claim back.
+	JournalRelease JournalOp = "release"
+)
+
+// Undoable reports whether entries with this operation can be undone and redone.
+func (op JournalOp) Undoable() bool {
+	return op == JournalMutate || op == JournalArchive || op == JournalUnarchive
+}
+
+// TaskChange holds the before and after images of one task touched by a journal
+// entry, in the same serialized form as a tasks.jsonl line. A nil Before means the
+// task was created; a nil After means it was removed.
+type TaskChange struct {
+	ID     string          `json:"id"`
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// JournalEntry is one line of .tick/journal.jsonl. Target is the Seq of the
+// mutate entry an undo or redo applies to.
+type JournalEntry struct {
+	Seq     int          `json:"seq"`
+	At      time.Time    `json:"at"`
+	Op      JournalOp    `json:"op"`
+	Target  int          `json:"target,omitempty"`
+	Command string       `json:"command,omitempty"`
+	Changes []TaskChange `json:"changes"`
+}
+
+// TaskIDs returns the IDs of the tasks the entry changed, in recorded order.
+func (e JournalEntry) TaskIDs() []string {
+	ids := make([]string, len(e.Changes))
+	for i, c := range e.Changes {
+		ids[i] = c.ID
+	}
+	return ids
+}
+
+// JournalState summarizes which undoable entries are currently undone.
+type JournalState struct {
+	undone map[int]bool
+	// active is the Seq of the entry that last applied each live undoable entry:
+	// the entry itself, or the redo that re-applied it.
+	active map[int]int
+}
+
+// Undone reports whether the entry with the given Seq is currently undone.
+func (st JournalState) Undone(seq int) bool {
+	return st.undone[seq]
+}
+
+// NewJournalState replays the undo and redo records in entries to determine
+// which undoable entries are currently undone.
+func NewJournalState(entries []JournalEntry) JournalState {
+	st := JournalState{undone: map[int]bool{}, active: map[int]int{}}
+	for _, e := range entries {
+		switch e.Op {
+		case JournalMutate, JournalArchive, JournalUnarchive:
+			st.active[e.Seq] = e.Seq
+		case JournalUndo:
+			st.undone[e.Target] = true
+			delete(st.active, e.Target)
+		case JournalRedo:
+			delete(st.undone, e.Target)
+			st.active[e.Target] = e.Seq
+		}
+	}
+	return st
+}
+
+// ReadJournal reads and parses a journal file. A missing file yields no entries.
+func ReadJournal(path string) ([]JournalEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	var entries []JournalEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var e JournalEntry
+		if err := json.Unmarshal(line, &e); err != nil {
+			return nil, fmt.Errorf("failed to parse journal line %d: %w", lineNum, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	return entries, nil
+}
+
+// appendJournal assigns the next sequence number to entry and appends it to the
+// journal file, creating the file if needed. Every journalCompactInterval entries
+// the journal is compacted to its last journalMaxEntries. The caller must hold
+// the exclusive lock.
+func appendJournal(path string, entry *JournalEntry) error {
+	seq, err := lastJournalSeq(path)
+	if err != nil {
+		return err
+	}
+	entry.Seq = seq + 1
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal entry: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open journal: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to append to journal: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to append to journal: %w", err)
+	}
+
+	if entry.Seq%journalCompactInterval == 0 {
+		return compactJournal(path, entry.Seq-journalMaxEntries)
+	}
+	return nil
+}
+
+// compactJournal rewrites the journal without the entries numbered up to and
+// including keepAfter. Undo and redo records only refer to earlier entries, so
+// dropping the oldest ones leaves the state of the rest unchanged.
+func compactJournal(path string, keepAfter int) error {
+	if keepAfter <= 0 {
+		return nil
+	}
+	entries, err := ReadJournal(path)
+	if err != nil {
+		return err
+	}
+	i := sort.Search(len(entries), func(i int) bool { return entries[i].Seq > keepAfter })
+	if i == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, e := range entries[i:] {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("failed to marshal journal entry: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	if err := writeAtomic(path, buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to compact journal: %w", err)
+	}
+	return nil
+}
+
+// lastJournalSeq returns the Seq of the final journal entry, or 0 when the
+// journal is missing or empty. Only the last line is read and decoded.
+func lastJournalSeq(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read journal: %w", err)
+	}
+	defer f.Close()
+
+	data, err := readLastLine(f)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read journal: %w", err)
+	}
+	if len(data) == 0 {
+		return 0, nil
+	}
+	var last struct {
+		Seq int `json:"seq"`
+	}
+	if err := json.Unmarshal(data, &last); err != nil {
+		return 0, fmt.Errorf("failed to parse last journal entry: %w", err)
+	}
+	return last.Seq, nil
+}
+
+// readLastLine returns the last non-blank line of f, trimmed, reading the file
+// backwards in journalTailChunk blocks until the line's start is found.
+func readLastLine(f *os.File) ([]byte, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	var tail []byte
+	for end := info.Size(); end > 0; {
+		start := max(end-journalTailChunk, 0)
+		chunk := make([]byte, end-start)
+		if _, err := f.ReadAt(chunk, start); err != nil {
+			return nil, err
+		}
+		tail = append(chunk, tail...)
+		end = start
+
+		trimmed := bytes.TrimSpace(tail)
+		if i := bytes.LastIndexByte(trimmed, '\n'); i >= 0 {
+			return trimmed[i+1:], nil
+		}
+	}
+	return bytes.TrimSpace(tail), nil
+}
+
+// journalChanges converts a cache diff into before/after task images, using the
+// pre-mutation snapshot for before images. Changes are ordered by task ID.
+func journalChanges(before map[string][]byte, diff TaskDiff) ([]TaskChange, error) {
+	changes := make([]TaskChange, 0, len(diff.Upserted)+len(diff.Removed))
+	for _, t := range diff.Upserted {
+		after, err := json.Marshal(t)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal task %s: %w", t.ID, err)
+		}
+		changes = append(changes, TaskChange{ID: t.ID, Before: before[t.ID], After: after})
+	}
+	for _, id := range diff.Removed {
+		changes = append(changes, TaskChange{ID: id, Before: before[id]})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].ID < changes[j].ID })
+	return changes, nil
+}
+
+// Journal returns all journal entries in the order they were recorded.
+func (s *Store) Journal() ([]JournalEntry, error) {
+	unlock, err := s.acquireShared()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return ReadJournal(s.journalPath)
+}
+
+// Undo reverts the journal entry with the given Seq, or the most recently applied
+// mutation that is not undone when seq is 0. Every task the entry changed is
+// restored to its before image, so cascaded changes are reverted with it. Undo is
+// refused when any of those tasks no longer matches the entry's after image, which
+// happens when a later mutation touched it. Undoing an archive or unarchive entry
+// also moves its tasks back into or out of archive.jsonl. Returns the reverted
+// entry.
+func (s *Store) Undo(seq int) (JournalEntry, error) {
+	unlock, err := s.acquireExclusive()
+	if err != nil {
+		return JournalEntry{}, err
+	}
+	defer unlock()
+
+	target, err := s.undoLocked(seq)
+	if err != nil {
+		return JournalEntry{}, err
+	}
+	// Release after the reversal, so leases it restores that have since expired
+	// are handed back too.
+	if err := s.releaseLocked(); err != nil {
+		return JournalEntry{}, err
+	}
+	return target, nil
+}
+
+// UndoLast reverts the count most recently applied mutations that are not
+// undone, newest first, under one lock. Each reversal is checked against the
+// tasks the reversals before it leave behind before any is applied, so either
+// all count are undone or, when one is refused or there are fewer than count
+// to undo, none is. Returns the reverted entries in the order undone.
+func (s *Store) UndoLast(count int) ([]JournalEntry, error) {
+	unlock, err := s.acquireExclusive()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	targets, err := s.planUndo(count)
+	if err != nil {
+		return nil, err
+	}
+	for _, target := range targets {
+		if _, err := s.undoLocked(target.Seq); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.releaseLocked(); err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
+// planUndo picks the entries UndoLast reverts, checking each one against the
+// tasks as the reversals picked before it would leave them.
+func (s *Store) planUndo(count int) ([]JournalEntry, error) {
+	_, tasks, err := s.readAndEnsureFresh()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := ReadJournal(s.journalPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []JournalEntry
+	for i := 0; i < count; i++ {
+		target, err := findUndoTarget(entries, NewJournalState(entries), 0)
+		if err == nil {
+			err = checkImages(tasks, target, afterImage, entries, "undo")
+		}
+		if err == nil {
+			tasks, err = applyImages(tasks, target.Changes, beforeImage)
+		}
+		switch {
+		case err == nil:
+		case i > 0 && errors.Is(err, errNothingToUndo):
+			return nil, fmt.Errorf("cannot undo %d mutations: only %d can be undone", count, i)
+		case i > 0:
+			return nil, fmt.Errorf("cannot undo %d mutations: %w", count, err)
+		default:
+			return nil, err
+		}
+		targets = append(targets, target)
+		// Mark the target undone for the next pick.
+		entries = append(entries, JournalEntry{Op: JournalUndo, Target: target.Seq})
+	}
+	return targets, nil
+}
+
+// undoLocked reverts the entry as Undo does, under the exclusive lock the
+// caller holds, without releasing expired leases.
+func (s *Store) undoLocked(seq int) (JournalEntry, error) {
+	var target JournalEntry
+	record := &JournalEntry{Op: JournalUndo}
+	err := s.mutateLocked(record, func(tasks []task.Task) ([]task.Task, error) {
+		entries, err := ReadJournal(s.journalPath)
+		if err != nil {
+			return nil, err
+		}
+		state := NewJournalState(entries)
+
+		target, err = findUndoTarget(entries, state, seq)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkImages(tasks, target, afterImage, entries, "undo"); err != nil {
+			return nil, err
+		}
+		record.Target = target.Seq
+		return applyImages(tasks, target.Changes, beforeImage)
+	})
+	if err != nil {
+		return JournalEntry{}, err
+	}
+	if err := s.syncArchive(target, beforeImage, afterImage); err != nil {
+		return JournalEntry{}, err
+	}
+	return target, nil
+}
+
+// Redo re-applies the most recently undone mutation. It is refused when any task
+// the mutation changed has been modified since it was undone. Returns the
+// re-applied entry.
+func (s *Store) Redo() (JournalEntry, error) {
+	unlock, err := s.acquireExclusive()
+	if err != nil {
+		return JournalEntry{}, err
+	}
+	defer unlock()
+
+	var target JournalEntry
+	record := &JournalEntry{Op: JournalRedo}
+	err = s.mutateLocked(record, func(tasks []task.Task) ([]task.Task, error) {
+		entries, err := ReadJournal(s.journalPath)
+		if err != nil {
+			return nil, err
+		}
+		state := NewJournalState(entries)
+
+		target, err = findRedoTarget(entries, state)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkImages(tasks, target, beforeImage, entries, "redo"); err != nil {
+			return nil, err
+		}
+		record.Target = target.Seq
+		return applyImages(tasks, target.Changes, afterImage)
+	})
+	if err != nil {
+		return JournalEntry{}, err
+	}
+	if err := s.syncArchive(target, afterImage, beforeImage); err != nil {
+		return JournalEntry{}, err
+	}
+	if err := s.releaseLocked(); err != nil {
+		return JournalEntry{}, err
+	}
+	return target, nil
+}
+
+// errNothingToUndo is returned when every undoable entry is already undone.
+var errNothingToUndo = errors.New("nothing to undo")
+
+// findUndoTarget selects the entry to undo: the one with the given Seq, or the
+// most recently applied live undoable entry when seq is 0.
+func findUndoTarget(entries []JournalEntry, state JournalState, seq int) (JournalEntry, error) {
+	if seq == 0 {
+		best := -1
+		for i, e := range entries {
+			if !e.Op.Undoable() || state.Undone(e.Seq) {
+				continue
+			}
+			if best < 0 || state.active[e.Seq] > state.active[entries[best].Seq] {
+				best = i
+			}
+		}
+		if best < 0 {
+			return JournalEntry{}, errNothingToUndo
+		}
+		return entries[best], nil
+	}
+
+	for _, e := range entries {
+		if e.Seq != seq {
+			continue
+		}
+		if !e.Op.Undoable() {
+			return JournalEntry{}, fmt.Errorf("journal entry #%d is an %s record and cannot be undone", seq, e.Op)
+		}
+		if state.Undone(seq) {
+			return JournalEntry{}, fmt.Errorf("journal entry #%d is already undone", seq)
+		}
+		return e, nil
+	}
+	return JournalEntry{}, fmt.Errorf("journal entry #%d not found", seq)
+}
+
+// findRedoTarget selects the entry undone most recently that is still undone.
+func findRedoTarget(entries []JournalEntry, state JournalState) (JournalEntry, error) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if e.Op != JournalUndo || !state.Undone(e.Target) {
+			continue
+		}
+		for _, t := range entries {
+			if t.Seq == e.Target {
+				return t, nil
+			}
+		}
+		return JournalEntry{}, fmt.Errorf("journal entry #%d not found", e.Target)
+	}
+	return JournalEntry{}, errors.New("nothing to redo")
+}
+
+// beforeImage and afterImage select one side of a TaskChange.
+func beforeImage(c TaskChange) json.RawMessage { return c.Before }
+func afterImage(c TaskChange) json.RawMessage  { return c.After }
+
+// checkImages verifies that every task changed by target currently matches the
+// image selected by want (absent when the image is nil). On mismatch it names the
+// latest journal entry that touched the task, or reports an outside edit.
+func checkImages(tasks []task.Task, target JournalEntry, want func(TaskChange) json.RawMessage, entries []JournalEntry, action string) error {
+	current := make(map[string]task.Task, len(tasks))
+	for _, t := range tasks {
+		current[t.ID] = t
+	}
+
+	for _, c := range target.Changes {
+		t, exists := current[c.ID]
+		matches, err := matchesImage(t, exists, want(c))
+		if err != nil {
+			return err
+		}
+		if matches {
+			continue
+		}
+		if later := lastTouch(entries, c.ID, target.Seq); later != nil {
+			return fmt.Errorf("cannot %s #%d: task %s was changed by #%d (%s)", action, target.Seq, c.ID, later.Seq, describeEntry(*later))
+		}
+		return fmt.Errorf("cannot %s #%d: task %s was modified outside tick", action, target.Seq, c.ID)
+	}
+	return nil
+}
+
+// matchesImage reports whether a task (or its absence) equals a recorded image.
+// Both sides are compared in canonical serialized form.
+func matchesImage(t task.Task, exists bool, image json.RawMessage) (bool, error) {
+	if image == nil || !exists {
+		return image == nil && !exists, nil
+	}
+	var recorded task.Task
+	if err := json.Unmarshal(image, &recorded); err != nil {
+		return false, fmt.Errorf("failed to parse journal image for %s: %w", t.ID, err)
+	}
+	want, err := json.Marshal(recorded)
+	if err != nil {
+		return false, err
+	}
+	got, err := json.Marshal(t)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(want, got), nil
+}
+
+// lastTouch returns the most recent entry after seq that changed the given task.
+func lastTouch(entries []JournalEntry, id string, seq int) *JournalEntry {
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Seq <= seq {
+			break
+		}
+		for _, c := range entries[i].Changes {
+			if c.ID == id {
+				return &entries[i]
+			}
+		}
+	}
+	return nil
+}
+
+// describeEntry returns the command recorded for an entry, or its operation and
+// target when no command was recorded.
+func describeEntry(e JournalEntry) string {
+	if e.Command != "" {
+		return e.Command
+	}
+	if e.Target != 0 {
+		return fmt.Sprintf("%s #%d", e.Op, e.Target)
+	}
+	return string(e.Op)
+}
+
+// applyImages sets each task in changes to the image selected by pick: tasks with
+// a nil image are removed, existing tasks are replaced in place, and missing tasks
+// are appended.
+func applyImages(tasks []task.Task, changes []TaskChange, pick func(TaskChange) json.RawMessage) ([]task.Task, error) {
+	index := make(map[string]int, len(tasks))
+	for i, t := range tasks {
+		index[t.ID] = i
+	}
+
+	remove := map[string]bool{}
+	for _, c := range changes {
+		image := pick(c)
+		if image == nil {
+			remove[c.ID] = true
+			continue
+		}
+		var t task.Task
+		if err := json.Unmarshal(image, &t); err != nil {
+			return nil, fmt.Errorf("failed to parse journal image for %s: %w", c.ID, err)
+		}
+		if i, ok := index[c.ID]; ok {
+			tasks[i] = t
+		} else {
+			index[c.ID] = len(tasks)
+			tasks = append(tasks, t)
+		}
+	}
+
+	if len(remove) == 0 {
+		return tasks, nil
+	}
+	kept := make([]task.Task, 0, len(tasks))
+	for _, t := range tasks {
+		if !remove[t.ID] {
+			kept = append(kept, t)
+		}
+	}
+	return kept, nil
+}
@@ -0,0 +1,302 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// setTitle returns a mutation that sets the title of the task with the given ID.
+func setTitle(id, title string) func([]task.Task) ([]task.Task, error) {
+	return func(tasks []task.Task) ([]task.Task, error) {
+		for i := range tasks {
+			if tasks[i].ID == id {
+				tasks[i].Title = title
+			}
+		}
+		return tasks, nil
+	}
+}
+
+// titleOf reads tasks.jsonl and returns the title of the task with the given ID,
+// or "" when the task is absent.
+func titleOf(t *testing.T, tickDir, id string) string {
+	t.Helper()
+	tasks, err := ReadJSONL(filepath.Join(tickDir, "tasks.jsonl"))
+	if err != nil {
+		t.Fatalf("ReadJSONL returned error: %v", err)
+	}
+	for _, tk := range tasks {
+		if tk.ID == id {
+			return tk.Title
+		}
+	}
+	return ""
+}
+
+func TestJournal(t *testing.T) {
+	now := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+	seed := func() []task.Task {
+		return []task.Task{
+			{ID: "tick-aaa111", Title: "Alpha", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+			{ID: "tick-bbb222", Title: "Beta", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		}
+	}
+
+	t.Run("it records each mutation with the command and before/after images", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, seed())
+		store, err := NewStore(tickDir, WithCommand("tick update tick-aaa111 --title Renamed"))
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer store.Close()
+
+		if err := store.Mutate(setTitle("tick-aaa111", "Renamed")); err != nil {
+			t.Fatalf("Mutate returned error: %v", err)
+		}
+
+		entries, err := store.Journal()
+		if err != nil {
+			t.Fatalf("Journal returned error: %v", err)
+		}
+		if len(entries) != 1 {
+			t.Fatalf("entries = %d, want 1", len(entries))
+		}
+		e := entries[0]
+		if e.Seq != 1 || e.Op != JournalMutate || e.Command != "tick update tick-aaa111 --title Renamed" {
+			t.Errorf("entry = %+v", e)
+		}
+		if len(e.Changes) != 1 || e.Changes[0].ID != "tick-aaa111" {
+			t.Fatalf("changes = %+v, want only tick-aaa111", e.Changes)
+		}
+		if !strings.Contains(string(e.Changes[0].Before), `"Alpha"`) || !strings.Contains(string(e.Changes[0].After), `"Renamed"`) {
+			t.Errorf("images = %s / %s", e.Changes[0].Before, e.Changes[0].After)
+		}
+	})
+
+	t.Run("it does not record mutations that change nothing", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, seed())
+		store, _ := NewStore(tickDir)
+		defer store.Close()
+
+		if err := store.Mutate(func(tasks []task.Task) ([]task.Task, error) { return tasks, nil }); err != nil {
+			t.Fatalf("Mutate returned error: %v", err)
+		}
+
+		if _, err := os.Stat(filepath.Join(tickDir, "journal.jsonl")); !os.IsNotExist(err) {
+			t.Errorf("journal.jsonl should not exist after a no-op mutation")
+		}
+	})
+
+	t.Run("it undoes the latest mutation including created and removed tasks", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, seed())
+		store, _ := NewStore(tickDir)
+		defer store.Close()
+
+		err := store.Mutate(func(tasks []task.Task) ([]task.Task, error) {
+			tasks = tasks[1:]
+			return append(tasks, task.Task{ID: "tick-ccc333", Title: "Gamma", Status: task.StatusOpen, Created: now, Updated: now}), nil
+		})
+		if err != nil {
+			t.Fatalf("Mutate returned error: %v", err)
+		}
+
+		target, err := store.Undo(0)
+		if err != nil {
+			t.Fatalf("Undo returned error: %v", err)
+		}
+		if target.Seq != 1 {
+			t.Errorf("undid #%d, want #1", target.Seq)
+		}
+		if titleOf(t, tickDir, "tick-aaa111") != "Alpha" {
+			t.Error("removed task tick-aaa111 was not restored")
+		}
+		if titleOf(t, tickDir, "tick-ccc333") != "" {
+			t.Error("created task tick-ccc333 was not removed")
+		}
+	})
+
+	t.Run("it redoes the most recently undone mutation", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, seed())
+		store, _ := NewStore(tickDir)
+		defer store.Close()
+
+		_ = store.Mutate(setTitle("tick-aaa111", "Renamed"))
+		if _, err := store.Undo(0); err != nil {
+			t.Fatalf("Undo returned error: %v", err)
+		}
+		target, err := store.Redo()
+		if err != nil {
+			t.Fatalf("Redo returned error: %v", err)
+		}
+		if target.Seq != 1 || titleOf(t, tickDir, "tick-aaa111") != "Renamed" {
+			t.Errorf("redo #%d, title = %q", target.Seq, titleOf(t, tickDir, "tick-aaa111"))
+		}
+
+		if _, err := store.Redo(); err == nil || err.Error() != "nothing to redo" {
+			t.Errorf("second Redo error = %v, want nothing to redo", err)
+		}
+		entries, _ := store.Journal()
+		if len(entries) != 3 || entries[1].Op != JournalUndo || entries[2].Op != JournalRedo || entries[2].Target != 1 {
+			t.Errorf("entries = %+v", entries)
+		}
+	})
+
+	t.Run("it undoes an earlier entry by number when later entries touched other tasks", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, seed())
+		store, _ := NewStore(tickDir)
+		defer store.Close()
+
+		_ = store.Mutate(setTitle("tick-aaa111", "A2"))
+		_ = store.Mutate(setTitle("tick-bbb222", "B2"))
+
+		if _, err := store.Undo(1); err != nil {
+			t.Fatalf("Undo(1) returned error: %v", err)
+		}
+		if titleOf(t, tickDir, "tick-aaa111") != "Alpha" || titleOf(t, tickDir, "tick-bbb222") != "B2" {
+			t.Errorf("titles = %q, %q", titleOf(t, tickDir, "tick-aaa111"), titleOf(t, tickDir, "tick-bbb222"))
+		}
+	})
+
+	t.Run("it refuses to undo when a later mutation touched the same task", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, seed())
+		store, _ := NewStore(tickDir, WithCommand("tick update"))
+		defer store.Close()
+
+		_ = store.Mutate(setTitle("tick-aaa111", "A2"))
+		_ = store.Mutate(setTitle("tick-aaa111", "A3"))
+
+		_, err := store.Undo(1)
+		if err == nil {
+			t.Fatal("expected Undo(1) to be refused")
+		}
+		want := "cannot undo #1: task tick-aaa111 was changed by #2 (tick update)"
+		if err.Error() != want {
+			t.Errorf("error = %q, want %q", err.Error(), want)
+		}
+		if titleOf(t, tickDir, "tick-aaa111") != "A3" {
+			t.Error("refused undo must not modify tasks")
+		}
+
+		// Undoing the later entry first makes the earlier one undoable again.
+		if _, err := store.Undo(0); err != nil {
+			t.Fatalf("Undo(0) returned error: %v", err)
+		}
+		if _, err := store.Undo(0); err != nil {
+			t.Fatalf("second Undo(0) returned error: %v", err)
+		}
+		if titleOf(t, tickDir, "tick-aaa111") != "Alpha" {
+			t.Errorf("title = %q, want Alpha", titleOf(t, tickDir, "tick-aaa111"))
+		}
+	})
+
+	t.Run("it refuses to undo after an edit outside tick", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, seed())
+		store, _ := NewStore(tickDir)
+		defer store.Close()
+
+		_ = store.Mutate(setTitle("tick-aaa111", "A2"))
+		tasks := seed()
+		tasks[0].Title = "Hand edited"
+		if err := WriteJSONL(filepath.Join(tickDir, "tasks.jsonl"), tasks); err != nil {
+			t.Fatalf("WriteJSONL returned error: %v", err)
+		}
+
+		_, err := store.Undo(0)
+		if err == nil || !strings.Contains(err.Error(), "modified outside tick") {
+			t.Errorf("error = %v, want outside-edit refusal", err)
+		}
+	})
+
+	t.Run("it undoes the last N mutations together, or none when one is refused", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, seed())
+		store, _ := NewStore(tickDir)
+		defer store.Close()
+
+		_ = store.Mutate(setTitle("tick-aaa111", "A2"))
+		_ = store.Mutate(setTitle("tick-aaa111", "A3"))
+		_ = store.Mutate(setTitle("tick-bbb222", "B2"))
+
+		// The newest two revert in turn: #3, then #2 against the tasks #3's
+		// reversal leaves.
+		undone, err := store.UndoLast(2)
+		if err != nil {
+			t.Fatalf("UndoLast(2) returned error: %v", err)
+		}
+		if len(undone) != 2 || undone[0].Seq != 3 || undone[1].Seq != 2 {
+			t.Errorf("undone = %+v, want #3 then #2", undone)
+		}
+		if titleOf(t, tickDir, "tick-aaa111") != "A2" || titleOf(t, tickDir, "tick-bbb222") != "Beta" {
+			t.Errorf("titles = %q, %q", titleOf(t, tickDir, "tick-aaa111"), titleOf(t, tickDir, "tick-bbb222"))
+		}
+
+		if _, err := store.UndoLast(2); err == nil || err.Error() != "cannot undo 2 mutations: only 1 can be undone" {
+			t.Errorf("UndoLast(2) error = %v, want only 1 can be undone", err)
+		}
+		if titleOf(t, tickDir, "tick-aaa111") != "A2" {
+			t.Error("refused UndoLast must not modify tasks")
+		}
+		entries, _ := store.Journal()
+		if len(entries) != 5 {
+			t.Errorf("journal has %d entries, want the 3 mutations and 2 undos", len(entries))
+		}
+	})
+
+	t.Run("it rejects unknown, non-mutation, and already undone entries", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, seed())
+		store, _ := NewStore(tickDir)
+		defer store.Close()
+
+		if _, err := store.Undo(0); err == nil || err.Error() != "nothing to undo" {
+			t.Errorf("empty journal error = %v", err)
+		}
+		_ = store.Mutate(setTitle("tick-aaa111", "A2"))
+		_, _ = store.Undo(1)
+
+		for seq, want := range map[int]string{
+			1: "journal entry #1 is already undone",
+			2: "journal entry #2 is an undo record and cannot be undone",
+			9: "journal entry #9 not found",
+		} {
+			if _, err := store.Undo(seq); err == nil || err.Error() != want {
+				t.Errorf("Undo(%d) error = %v, want %q", seq, err, want)
+			}
+		}
+	})
+
+	t.Run("it reads the last sequence number from the end of a long journal", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "journal.jsonl")
+		long := `{"seq":41,"op":"mutate","command":"` + strings.Repeat("x", 3*journalTailChunk) + `","changes":[]}`
+		content := `{"seq":40,"op":"mutate","changes":[]}` + "\n" + long + "\n\n"
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write journal: %v", err)
+		}
+
+		seq, err := lastJournalSeq(path)
+		if err != nil || seq != 41 {
+			t.Errorf("lastJournalSeq = %d, %v; want 41", seq, err)
+		}
+	})
+
+	t.Run("it compacts the journal to its most recent entries", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "journal.jsonl")
+		total := journalMaxEntries + journalCompactInterval
+		for range total {
+			if err := appendJournal(path, &JournalEntry{Op: JournalMutate}); err != nil {
+				t.Fatalf("appendJournal returned error: %v", err)
+			}
+		}
+
+		entries, err := ReadJournal(path)
+		if err != nil {
+			t.Fatalf("ReadJournal returned error: %v", err)
+		}
+		if len(entries) != journalMaxEntries || entries[0].Seq != total-journalMaxEntries+1 || entries[len(entries)-1].Seq != total {
+			t.Errorf("journal holds %d entries, #%d to #%d; want the last %d", len(entries), entries[0].Seq, entries[len(entries)-1].Seq, journalMaxEntries)
+		}
+	})
+}
@@ -327,6 +327,35 @@ func TestRoundTrip(t *testing.T) {
 			t.Errorf("Closed = %v, want %v", got.Closed, original[0].Closed)
 		}
 	})
+
+	t.Run("it preserves unrecognized fields through a store mutation", func(t *testing.T) {
+		tickDir := t.TempDir()
+		line := `{"id":"tick-a1b2c3","title":"Task","status":"open","priority":2,"created":"2026-01-19T10:00:00Z","updated":"2026-01-19T10:00:00Z","x_source":"import"}` + "\n"
+		if err := os.WriteFile(filepath.Join(tickDir, "tasks.jsonl"), []byte(line), 0644); err != nil {
+			t.Fatalf("failed to write tasks.jsonl: %v", err)
+		}
+		if err := WriteFormatVersion(tickDir, FormatVersion); err != nil {
+			t.Fatalf("failed to write format file: %v", err)
+		}
+		store, err := NewStore(tickDir)
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer store.Close()
+
+		if err := store.Mutate(setTitle("tick-a1b2c3", "Renamed")); err != nil {
+			t.Fatalf("Mutate returned error: %v", err)
+		}
+
+		data, err := os.ReadFile(filepath.Join(tickDir, "tasks.jsonl"))
+		if err != nil {
+			t.Fatalf("failed to read tasks.jsonl: %v", err)
+		}
+		want := `{"id":"tick-a1b2c3","title":"Renamed","status":"open","priority":2,"created":"2026-01-19T10:00:00Z","updated":"2026-01-19T10:00:00Z","x_source":"import"}` + "\n"
+		if string(data) != want {
+			t.Errorf("tasks.jsonl = %s, want %s", data, want)
+		}
+	})
 }
 
 func TestAtomicWrite(t *testing.T) {
@@ -0,0 +1,311 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// Layout identifies how a .tick directory stores its tasks on disk.
+type Layout string
+
+const (
+	// LayoutJSONL stores every task as one line of .tick/tasks.jsonl.
+	LayoutJSONL Layout = "jsonl"
+	// LayoutFiles stores each task in its own .tick/tasks/<id>.json file, so
+	// branches that touch different tasks never conflict.
+	LayoutFiles Layout = "files"
+)
+
+// TasksDirName is the directory under .tick/ that holds task files in the files layout.
+const TasksDirName = "tasks"
+
+// ParseLayout validates a layout name given on the command line.
+func ParseLayout(s string) (Layout, error) {
+	switch Layout(s) {
+	case LayoutJSONL, LayoutFiles:
+		return Layout(s), nil
+	}
+	return "", fmt.Errorf("invalid layout '%s': must be one of jsonl, files", s)
+}
+
+// DetectLayout reports the layout of the given .tick directory: files when a
+// tasks/ directory exists, jsonl otherwise.
+func DetectLayout(tickDir string) Layout {
+	info, err := os.Stat(filepath.Join(tickDir, TasksDirName))
+	if err == nil && info.IsDir() {
+		return LayoutFiles
+	}
+	return LayoutJSONL
+}
+
+// SourceName returns the path of the layout's task data relative to .tick/,
+// for use in messages.
+func (l Layout) SourceName() string {
+	if l == LayoutFiles {
+		return TasksDirName + "/"
+	}
+	return "tasks.jsonl"
+}
+
+// ReadRawTasks reads the task data of the given .tick directory in the canonical
+// JSONL form used for cache freshness hashing, whatever its layout.
+func ReadRawTasks(tickDir string) ([]byte, error) {
+	return newBackend(tickDir, DetectLayout(tickDir)).readRaw()
+}
+
+// backend abstracts the on-disk task storage for a layout. Both layouts expose
+// their content as JSONL bytes (readRaw/marshal), so parsing and cache hashing
+// are shared; only reading and writing the files differ.
+type backend interface {
+	// layout returns the Layout this backend implements.
+	layout() Layout
+	// exists reports whether the backend's task data is present.
+	exists() bool
+	// readRaw reads the task data as JSONL bytes.
+	readRaw() ([]byte, error)
+	// marshal returns the JSONL bytes that readRaw will produce after write(tasks).
+	marshal(tasks []task.Task) ([]byte, error)
+	// write persists tasks; raw is the result of marshal(tasks).
+	write(tasks []task.Task, raw []byte) error
+	// remove deletes the backend's task data.
+	remove() error
+}
+
+// newBackend returns the backend for the given layout rooted at tickDir.
+func newBackend(tickDir string, layout Layout) backend {
+	if layout == LayoutFiles {
+		return &filesBackend{dir: filepath.Join(tickDir, TasksDirName)}
+	}
+	return &jsonlBackend{path: filepath.Join(tickDir, "tasks.jsonl")}
+}
+
+// jsonlBackend stores all tasks in a single tasks.jsonl file.
+type jsonlBackend struct {
+	path string
+}
+
+func (b *jsonlBackend) layout() Layout { return LayoutJSONL }
+
+func (b *jsonlBackend) exists() bool {
+	_, err := os.Stat(b.path)
+	return err == nil
+}
+
+func (b *jsonlBackend) readRaw() ([]byte, error) {
+	return os.ReadFile(b.path)
+}
+
+func (b *jsonlBackend) marshal(tasks []task.Task) ([]byte, error) {
+	return MarshalJSONL(tasks)
+}
+
+func (b *jsonlBackend) write(_ []task.Task, raw []byte) error {
+	return WriteJSONLRaw(b.path, raw)
+}
+
+func (b *jsonlBackend) remove() error {
+	return os.Remove(b.path)
+}
+
+// filesBackend stores each task as indented JSON in tasks/<id>.json. Its raw form
+// is each file compacted to a single line, in file name order.
+type filesBackend struct {
+	dir string
+}
+
+func (b *filesBackend) layout() Layout { return LayoutFiles }
+
+func (b *filesBackend) exists() bool {
+	info, err := os.Stat(b.dir)
+	return err == nil && info.IsDir()
+}
+
+func (b *filesBackend) readRaw() ([]byte, error) {
+	names, err := taskFileNames(b.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(b.dir, name))
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(data)) == 0 {
+			continue
+		}
+		if err := json.Compact(&buf, data); err != nil {
+			return nil, fmt.Errorf("invalid JSON in %s/%s: %w", TasksDirName, name, err)
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+func (b *filesBackend) marshal(tasks []task.Task) ([]byte, error) {
+	sorted := make([]task.Task, len(tasks))
+	copy(sorted, tasks)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return taskFileName(sorted[i].ID) < taskFileName(sorted[j].ID)
+	})
+	return MarshalJSONL(sorted)
+}
+
+// write updates only files whose content changed and deletes files for tasks no
+// longer present. Each file is written atomically; the set as a whole is not.
+func (b *filesBackend) write(tasks []task.Task, _ []byte) error {
+	if err := os.MkdirAll(b.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", TasksDirName, err)
+	}
+
+	wanted := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		if t.ID == "" || strings.ContainsAny(t.ID, `/\`) || strings.HasPrefix(t.ID, ".") {
+			return fmt.Errorf("task ID %q cannot be stored as a file", t.ID)
+		}
+		name := taskFileName(t.ID)
+		wanted[name] = true
+
+		data, err := json.MarshalIndent(t, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal task %s: %w", t.ID, err)
+		}
+		data = append(data, '\n')
+
+		path := filepath.Join(b.dir, name)
+		if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, data) {
+			continue
+		}
+		if err := writeAtomic(path, data); err != nil {
+			return err
+		}
+	}
+
+	names, err := taskFileNames(b.dir)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if wanted[name] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(b.dir, name)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s/%s: %w", TasksDirName, name, err)
+		}
+	}
+	return nil
+}
+
+func (b *filesBackend) remove() error {
+	return os.RemoveAll(b.dir)
+}
+
+// taskFileName returns the file name for a task in the files layout.
+func taskFileName(id string) string {
+	return id + ".json"
+}
+
+// taskFileNames lists the task files in dir, sorted by name. Hidden files (such
+// as in-progress atomic writes) and non-.json files are ignored.
+func taskFileNames(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// TaskFileNames lists the task files of a files-layout .tick directory, as paths
+// relative to .tick/ (e.g. "tasks/tick-a1b2c3.json"), sorted by name.
+func TaskFileNames(tickDir string) ([]string, error) {
+	names, err := taskFileNames(filepath.Join(tickDir, TasksDirName))
+	if err != nil {
+		return nil, err
+	}
+	for i, name := range names {
+		names[i] = TasksDirName + "/" + name
+	}
+	return names, nil
+}
+
+// Convert rewrites the Store's tasks into the target layout under an exclusive
+// lock, removes the old layout's data, and rebuilds the cache. Tasks with
+// duplicate IDs cannot be converted, since the files layout keys files by ID,
+// nor can tasks in an older format. Returns the number of tasks converted.
+func (s *Store) Convert(to Layout) (int, error) {
+	unlock, err := s.acquireExclusive()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	if err := s.readOnly(); err != nil {
+		return 0, err
+	}
+	if err := checkWriteFormat(s.tickDir); err != nil {
+		return 0, err
+	}
+
+	from := s.backend
+	if from.layout() == to {
+		return 0, fmt.Errorf("already using the %s layout", to)
+	}
+
+	rawJSONL, err := s.readRaw()
+	if err != nil {
+		return 0, err
+	}
+	tasks, err := s.parseRaw(rawJSONL)
+	if err != nil {
+		return 0, err
+	}
+	if _, ok, err := snapshotTasks(tasks); err != nil {
+		return 0, err
+	} else if !ok {
+		return 0, errors.New("cannot convert: tasks have duplicate IDs (run tick doctor)")
+	}
+
+	target := newBackend(s.tickDir, to)
+	newRawJSONL, err := target.marshal(tasks)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal tasks: %w", err)
+	}
+	s.verbose(fmt.Sprintf("writing %d tasks to %s", len(tasks), to.SourceName()))
+	if err := target.write(tasks, newRawJSONL); err != nil {
+		return 0, fmt.Errorf("failed to write %s: %w", to.SourceName(), err)
+	}
+
+	// The new layout is complete; only now remove the old one.
+	s.verbose(fmt.Sprintf("removing %s", from.layout().SourceName()))
+	if err := from.remove(); err != nil {
+		return 0, fmt.Errorf("failed to remove %s: %w", from.layout().SourceName(), err)
+	}
+	s.backend = target
+
+	if _, err := s.checkFresh(newRawJSONL); err != nil {
+		return 0, fmt.Errorf("failed to open cache: %w", err)
+	}
+	s.verbose("rebuilding cache from JSONL")
+	if err := s.cache.Rebuild(tasks, newRawJSONL); err != nil {
+		return 0, fmt.Errorf("failed to rebuild cache: %w", err)
+	}
+
+	return len(tasks), nil
+}
@@ -0,0 +1,195 @@
+package storage
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// setupFilesTickDir creates a .tick directory in the files layout containing the given tasks.
+func setupFilesTickDir(t *testing.T, tasks []task.Task) string {
+	t.Helper()
+	tickDir := filepath.Join(t.TempDir(), ".tick")
+	b := newBackend(tickDir, LayoutFiles)
+	raw, err := b.marshal(tasks)
+	if err != nil {
+		t.Fatalf("marshal returned error: %v", err)
+	}
+	if err := b.write(tasks, raw); err != nil {
+		t.Fatalf("write returned error: %v", err)
+	}
+	if err := WriteFormatVersion(tickDir, FormatVersion); err != nil {
+		t.Fatalf("failed to write format file: %v", err)
+	}
+	return tickDir
+}
+
+func TestParseLayout(t *testing.T) {
+	t.Run("it accepts jsonl and files", func(t *testing.T) {
+		for _, s := range []string{"jsonl", "files"} {
+			l, err := ParseLayout(s)
+			if err != nil || string(l) != s {
+				t.Errorf("ParseLayout(%q) = %q, %v", s, l, err)
+			}
+		}
+	})
+
+	t.Run("it rejects unknown layouts", func(t *testing.T) {
+		_, err := ParseLayout("sqlite")
+		if err == nil || err.Error() != "invalid layout 'sqlite': must be one of jsonl, files" {
+			t.Errorf("error = %v", err)
+		}
+	})
+}
+
+func TestFilesLayout(t *testing.T) {
+	now := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+	seed := func() []task.Task {
+		return []task.Task{
+			{ID: "tick-bbb222", Title: "Beta", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+			{ID: "tick-aaa111", Title: "Alpha", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		}
+	}
+
+	t.Run("it detects the layout from the presence of the tasks directory", func(t *testing.T) {
+		if got := DetectLayout(setupFilesTickDir(t, seed())); got != LayoutFiles {
+			t.Errorf("DetectLayout = %q, want files", got)
+		}
+		if got := DetectLayout(setupTickDirWithTasks(t, seed())); got != LayoutJSONL {
+			t.Errorf("DetectLayout = %q, want jsonl", got)
+		}
+	})
+
+	t.Run("it writes one indented file per task", func(t *testing.T) {
+		tickDir := setupFilesTickDir(t, seed())
+
+		names, err := TaskFileNames(tickDir)
+		if err != nil {
+			t.Fatalf("TaskFileNames returned error: %v", err)
+		}
+		if len(names) != 2 || names[0] != "tasks/tick-aaa111.json" || names[1] != "tasks/tick-bbb222.json" {
+			t.Errorf("names = %v", names)
+		}
+		data, _ := os.ReadFile(filepath.Join(tickDir, "tasks", "tick-aaa111.json"))
+		want := "{\n  \"id\": \"tick-aaa111\",\n  \"title\": \"Alpha\","
+		if len(data) < len(want) || string(data[:len(want)]) != want {
+			t.Errorf("file content = %q", data)
+		}
+	})
+
+	t.Run("it reads tasks and keeps the cache fresh across mutations", func(t *testing.T) {
+		tickDir := setupFilesTickDir(t, seed())
+		store, err := NewStore(tickDir)
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer store.Close()
+
+		if store.Layout() != LayoutFiles {
+			t.Fatalf("Layout = %q, want files", store.Layout())
+		}
+		if err := store.Mutate(setTitle("tick-aaa111", "Renamed")); err != nil {
+			t.Fatalf("Mutate returned error: %v", err)
+		}
+
+		var title string
+		err = store.Query(func(db *sql.DB) error {
+			return db.QueryRow("SELECT title FROM tasks WHERE id = ?", "tick-aaa111").Scan(&title)
+		})
+		if err != nil || title != "Renamed" {
+			t.Errorf("cached title = %q, %v", title, err)
+		}
+
+		raw, _ := ReadRawTasks(tickDir)
+		fresh, err := store.cache.IsFresh(raw)
+		if err != nil || !fresh {
+			t.Errorf("cache fresh = %v, %v; want fresh after mutation", fresh, err)
+		}
+	})
+
+	t.Run("it rewrites only changed files and deletes removed tasks", func(t *testing.T) {
+		tickDir := setupFilesTickDir(t, seed())
+		untouched := filepath.Join(tickDir, "tasks", "tick-bbb222.json")
+		old := time.Now().Add(-time.Hour)
+		if err := os.Chtimes(untouched, old, old); err != nil {
+			t.Fatalf("Chtimes returned error: %v", err)
+		}
+
+		store, _ := NewStore(tickDir)
+		defer store.Close()
+
+		err := store.Mutate(func(tasks []task.Task) ([]task.Task, error) {
+			var kept []task.Task
+			for _, tk := range tasks {
+				if tk.ID != "tick-aaa111" {
+					kept = append(kept, tk)
+				}
+			}
+			return append(kept, task.Task{ID: "tick-ccc333", Title: "Gamma", Status: task.StatusOpen, Created: now, Updated: now}), nil
+		})
+		if err != nil {
+			t.Fatalf("Mutate returned error: %v", err)
+		}
+
+		names, _ := TaskFileNames(tickDir)
+		if len(names) != 2 || names[0] != "tasks/tick-bbb222.json" || names[1] != "tasks/tick-ccc333.json" {
+			t.Errorf("names = %v", names)
+		}
+		info, _ := os.Stat(untouched)
+		if !info.ModTime().Equal(old) {
+			t.Error("unchanged task file was rewritten")
+		}
+	})
+
+	t.Run("it converts between layouts and back", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, seed())
+		store, _ := NewStore(tickDir)
+		defer store.Close()
+
+		n, err := store.Convert(LayoutFiles)
+		if err != nil || n != 2 {
+			t.Fatalf("Convert(files) = %d, %v", n, err)
+		}
+		if _, err := os.Stat(filepath.Join(tickDir, "tasks.jsonl")); !os.IsNotExist(err) {
+			t.Error("tasks.jsonl should be removed after converting to files")
+		}
+		if err := store.Mutate(setTitle("tick-bbb222", "B2")); err != nil {
+			t.Fatalf("Mutate after convert returned error: %v", err)
+		}
+
+		if _, err := store.Convert(LayoutJSONL); err != nil {
+			t.Fatalf("Convert(jsonl) returned error: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(tickDir, "tasks")); !os.IsNotExist(err) {
+			t.Error("tasks/ should be removed after converting to jsonl")
+		}
+		if titleOf(t, tickDir, "tick-bbb222") != "B2" {
+			t.Errorf("title = %q, want B2", titleOf(t, tickDir, "tick-bbb222"))
+		}
+	})
+
+	t.Run("it refuses to convert to the current layout or with duplicate IDs", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, seed())
+		store, _ := NewStore(tickDir)
+		defer store.Close()
+
+		if _, err := store.Convert(LayoutJSONL); err == nil || err.Error() != "already using the jsonl layout" {
+			t.Errorf("same-layout error = %v", err)
+		}
+
+		dup := append(seed(), seed()[0])
+		if err := WriteJSONL(filepath.Join(tickDir, "tasks.jsonl"), dup); err != nil {
+			t.Fatalf("WriteJSONL returned error: %v", err)
+		}
+		if _, err := store.Convert(LayoutFiles); err == nil || err.Error() != "cannot convert: tasks have duplicate IDs (run tick doctor)" {
+			t.Errorf("duplicate error = %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(tickDir, "tasks")); !os.IsNotExist(err) {
+			t.Error("failed convert must not create tasks/")
+		}
+	})
+}
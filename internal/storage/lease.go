@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"errors"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// releaseLocked releases every lease that has expired (see releaseExpired) as
+// a change of its own, journaled as a JournalRelease entry, and records the
+// released IDs for Released. Every write that changes tasks calls it under its
+// exclusive lock, except a lease renewal, so an agent's lapsed claim is handed
+// back by the next change to the project, whichever command makes it. Nothing
+// is written when no lease has expired.
+func (s *Store) releaseLocked() error {
+	sm := task.StateMachine{Workflow: s.config.Rules().Workflow}
+	now := time.Now().UTC().Truncate(time.Second)
+	var released []string
+	err := s.mutateLocked(&JournalEntry{Op: JournalRelease}, func(tasks []task.Task) ([]task.Task, error) {
+		var err error
+		if released, err = releaseExpired(tasks, now, sm); err != nil {
+			return nil, err
+		}
+		if len(released) == 0 {
+			return nil, errNothingReleased
+		}
+		return tasks, nil
+	})
+	if err != nil && !errors.Is(err, errNothingReleased) {
+		return err
+	}
+	s.released = released
+	return nil
+}
+
+// errNothingReleased stops releaseLocked's write when no lease has expired.
+var errNothingReleased = errors.New("no expired leases")
+
+// Released returns the IDs of the tasks whose expired leases the Store's last
+// write released, in task order.
+func (s *Store) Released() []string {
+	return s.released
+}
+
+// releaseExpired moves every task in one of the workflow's started states whose
+// lease expired at or before now back to open, as an auto transition, and
+// clears its assignee. It returns the released task IDs in task order.
+func releaseExpired(tasks []task.Task, now time.Time, sm task.StateMachine) ([]string, error) {
+	var released []string
+	for i := range tasks {
+		t := &tasks[i]
+		if !sm.Workflow.IsStarted(t.Status) || t.LeaseExpires == nil || t.LeaseExpires.After(now) {
+			continue
+		}
+		if _, _, err := sm.ApplySystemTransition(tasks, t, "release"); err != nil {
+			return nil, err
+		}
+		t.Assignee = ""
+		released = append(released, t.ID)
+	}
+	return released, nil
+}
@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+func TestReleaseLeases(t *testing.T) {
+	created := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+	expired := time.Date(2026, 1, 19, 10, 30, 0, 0, time.UTC)
+
+	// setup writes a project whose workflow adds an in_review started state,
+	// with an expired claim in review, a task to change, and a done task old
+	// enough to archive.
+	setup := func(t *testing.T) *Store {
+		t.Helper()
+		tickDir := setupTickDirWithTasks(t, []task.Task{
+			{ID: "tick-aaa111", Title: "Claimed", Status: "in_review", Priority: 2, Assignee: "agent-1", LeaseExpires: &expired, Created: created, Updated: created},
+			{ID: "tick-bbb222", Title: "Other", Status: task.StatusOpen, Priority: 2, Created: created, Updated: created},
+			{ID: "tick-ccc333", Title: "Old", Status: task.StatusDone, Priority: 2, Created: created, Updated: created, Closed: &created},
+		})
+		config := "workflow:\n  states:\n    - name: in_review\n      category: active\n"
+		if err := os.WriteFile(filepath.Join(tickDir, "config.yaml"), []byte(config), 0644); err != nil {
+			t.Fatalf("failed to write config.yaml: %v", err)
+		}
+		store, err := NewStore(tickDir)
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		t.Cleanup(func() { store.Close() })
+		return store
+	}
+	rename := func(tasks []task.Task) ([]task.Task, error) {
+		tasks[1].Title = "Renamed"
+		return tasks, nil
+	}
+
+	writes := []struct {
+		name  string
+		write func(t *testing.T, s *Store)
+	}{
+		{"mutate", func(t *testing.T, s *Store) {
+			if err := s.Mutate(rename); err != nil {
+				t.Fatalf("Mutate returned error: %v", err)
+			}
+		}},
+		{"archive", func(t *testing.T, s *Store) {
+			if _, err := s.Archive(created.Add(time.Hour), false); err != nil {
+				t.Fatalf("Archive returned error: %v", err)
+			}
+		}},
+		{"undo", func(t *testing.T, s *Store) {
+			if err := s.MutateKeepingLeases(rename); err != nil {
+				t.Fatalf("MutateKeepingLeases returned error: %v", err)
+			}
+			if _, err := s.Undo(0); err != nil {
+				t.Fatalf("Undo returned error: %v", err)
+			}
+		}},
+	}
+	for _, w := range writes {
+		t.Run("it releases expired leases from any started state on "+w.name, func(t *testing.T) {
+			store := setup(t)
+
+			w.write(t, store)
+
+			tasks, err := store.ReadTasks()
+			if err != nil {
+				t.Fatalf("ReadTasks returned error: %v", err)
+			}
+			claimed := tasks[0]
+			if claimed.Status != task.StatusOpen || claimed.Assignee != "" || claimed.LeaseExpires != nil {
+				t.Errorf("expired task = %+v, want released to open and unassigned", claimed)
+			}
+			if tr := claimed.Transitions; len(tr) != 1 || tr[0].From != "in_review" || !tr[0].Auto {
+				t.Errorf("transitions = %+v, want one auto release from in_review", tr)
+			}
+			if got := store.Released(); len(got) != 1 || got[0] != "tick-aaa111" {
+				t.Errorf("Released = %v, want [tick-aaa111]", got)
+			}
+		})
+	}
+
+	t.Run("it journals the release apart from the change, so undo keeps it", func(t *testing.T) {
+		store := setup(t)
+
+		if err := store.Mutate(rename); err != nil {
+			t.Fatalf("Mutate returned error: %v", err)
+		}
+		entries, err := store.Journal()
+		if err != nil {
+			t.Fatalf("Journal returned error: %v", err)
+		}
+		if len(entries) != 2 || entries[0].Op != JournalRelease || entries[1].Op != JournalMutate {
+			t.Fatalf("journal = %+v, want a release entry then the mutation", entries)
+		}
+		if ids := entries[0].TaskIDs(); len(ids) != 1 || ids[0] != "tick-aaa111" {
+			t.Errorf("release entry tasks = %v, want [tick-aaa111]", ids)
+		}
+		if ids := entries[1].TaskIDs(); len(ids) != 1 || ids[0] != "tick-bbb222" {
+			t.Errorf("mutation entry tasks = %v, want [tick-bbb222]", ids)
+		}
+
+		undone, err := store.Undo(0)
+		if err != nil {
+			t.Fatalf("Undo returned error: %v", err)
+		}
+		if undone.Seq != entries[1].Seq {
+			t.Errorf("undid entry #%d, want the mutation #%d", undone.Seq, entries[1].Seq)
+		}
+		tasks, err := store.ReadTasks()
+		if err != nil {
+			t.Fatalf("ReadTasks returned error: %v", err)
+		}
+		if tasks[0].Status != task.StatusOpen || tasks[0].LeaseExpires != nil || tasks[1].Title != "Other" {
+			t.Errorf("tasks after undo = %+v, %+v; want the claim still released", tasks[0], tasks[1])
+		}
+		if _, err := store.Undo(entries[0].Seq); err == nil {
+			t.Error("undoing the release entry should fail")
+		}
+	})
+
+	t.Run("it keeps expired leases when renewing one", func(t *testing.T) {
+		store := setup(t)
+
+		if err := store.MutateKeepingLeases(rename); err != nil {
+			t.Fatalf("MutateKeepingLeases returned error: %v", err)
+		}
+
+		tasks, err := store.ReadTasks()
+		if err != nil {
+			t.Fatalf("ReadTasks returned error: %v", err)
+		}
+		if tasks[0].Status != "in_review" || tasks[0].LeaseExpires == nil {
+			t.Errorf("expired task = %+v, want its lease kept", tasks[0])
+		}
+		if got := store.Released(); got != nil {
+			t.Errorf("Released = %v, want none", got)
+		}
+	})
+}
@@ -0,0 +1,200 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/leeovery/tick/internal/task"
+)
+
+// lockHoldersDir is the directory next to the lock file where each process
+// holding the lock records who it is, one file per holder.
+const lockHoldersDir = "lock.holders"
+
+// LockMode is the kind of lock a holder took.
+type LockMode string
+
+const (
+	// LockExclusive is taken by mutations.
+	LockExclusive LockMode = "exclusive"
+	// LockShared is taken by reads; any number of readers can hold it at once.
+	LockShared LockMode = "shared"
+)
+
+// LockHolder describes a process that holds, or held, the .tick lock. Records
+// are removed when the lock is released, so one left behind by a process that
+// crashed describes a holder that is gone; see Alive.
+type LockHolder struct {
+	PID      int       `json:"pid"`
+	Hostname string    `json:"hostname"`
+	Command  string    `json:"command,omitempty"`
+	Mode     LockMode  `json:"mode"`
+	Started  time.Time `json:"started"`
+}
+
+// Alive reports whether the holder's process is still running. Processes on
+// other hosts cannot be checked and are assumed to be running.
+func (h LockHolder) Alive() bool {
+	if hostname, _ := os.Hostname(); h.Hostname != hostname {
+		return true
+	}
+	return processAlive(h.PID)
+}
+
+// String describes the holder, e.g. "pid 4242 on build-1 (tick done tick-a1b2c3),
+// exclusive since 2026-01-19T10:00:00Z".
+func (h LockHolder) String() string {
+	s := fmt.Sprintf("pid %d on %s", h.PID, h.Hostname)
+	if h.Command != "" {
+		s += fmt.Sprintf(" (%s)", h.Command)
+	}
+	return s + fmt.Sprintf(", %s since %s", h.Mode, task.FormatTimestamp(h.Started))
+}
+
+// LockStatus reports whether the lock in tickDir is held right now, by trying
+// to take it without waiting, and returns the recorded holders, oldest first.
+// Holders can be recorded while the lock is free when their process crashed.
+func LockStatus(tickDir string) (held bool, holders []LockHolder, err error) {
+	holders, err = readLockHolders(tickDir)
+	if err != nil {
+		return false, nil, err
+	}
+
+	// Without a lock file nothing has ever locked; don't create one.
+	lockPath := filepath.Join(tickDir, "lock")
+	if _, err := os.Stat(lockPath); os.IsNotExist(err) {
+		return false, holders, nil
+	}
+
+	fl := flock.New(lockPath)
+	locked, err := fl.TryLock()
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to check lock: %w", err)
+	}
+	if locked {
+		_ = fl.Unlock()
+	}
+	return !locked, holders, nil
+}
+
+// readLockHolders returns the holders recorded in tickDir, oldest first.
+// Unreadable records, such as one still being written, are skipped.
+func readLockHolders(tickDir string) ([]LockHolder, error) {
+	entries, err := os.ReadDir(filepath.Join(tickDir, lockHoldersDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock holders: %w", err)
+	}
+
+	var holders []LockHolder
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(tickDir, lockHoldersDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		var h LockHolder
+		if err := json.Unmarshal(data, &h); err != nil {
+			continue
+		}
+		holders = append(holders, h)
+	}
+	slices.SortStableFunc(holders, func(a, b LockHolder) int { return a.Started.Compare(b.Started) })
+	return holders, nil
+}
+
+// recordLockHolder records this process as a holder of the lock in the given
+// mode and returns a function that removes the record. It first removes the
+// records that cannot describe a current holder; see pruneLockHolders.
+// Recording is best effort; failures only lose diagnostics.
+func (s *Store) recordLockHolder(mode LockMode) func() {
+	dir := filepath.Join(s.tickDir, lockHoldersDir)
+	s.pruneLockHolders(dir, mode)
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		s.verbose(fmt.Sprintf("lock holder not recorded: %v", err))
+		return func() {}
+	}
+
+	hostname, _ := os.Hostname()
+	command := s.command
+	if command == "" {
+		command = strings.Join(os.Args, " ")
+	}
+	data, err := json.Marshal(LockHolder{
+		PID:      os.Getpid(),
+		Hostname: hostname,
+		Command:  command,
+		Mode:     mode,
+		Started:  time.Now().UTC().Truncate(time.Second),
+	})
+	if err != nil {
+		return func() {}
+	}
+
+	f, err := os.CreateTemp(dir, fmt.Sprintf("%d-*.json", os.Getpid()))
+	if err != nil {
+		s.verbose(fmt.Sprintf("lock holder not recorded: %v", err))
+		return func() {}
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		s.verbose(fmt.Sprintf("lock holder not recorded: %v", err))
+		return func() {}
+	}
+	return func() { os.Remove(f.Name()) }
+}
+
+// pruneLockHolders removes the records in dir that cannot describe a current
+// holder once this process holds the lock in the given mode: every record under
+// the exclusive lock, and exclusive records or records of dead processes under
+// the shared lock.
+func (s *Store) pruneLockHolders(dir string, mode LockMode) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if mode == LockShared {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			var h LockHolder
+			if err := json.Unmarshal(data, &h); err != nil || (h.Mode == LockShared && h.Alive()) {
+				// Unparseable records may still be being written by another reader.
+				continue
+			}
+		}
+		if os.Remove(path) == nil {
+			s.verbose("removed stale lock holder record " + entry.Name())
+		}
+	}
+}
+
+// lockError is the error returned when the lock cannot be acquired in time,
+// naming the recorded holders when there are any.
+func (s *Store) lockError() error {
+	holders, _ := readLockHolders(s.tickDir)
+	if len(holders) == 0 {
+		return errors.New(lockErrMsg)
+	}
+	descriptions := make([]string, len(holders))
+	for i, h := range holders {
+		descriptions[i] = h.String()
+	}
+	return fmt.Errorf("%s (held by %s)", lockErrMsg, strings.Join(descriptions, "; "))
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package storage
+
+// processAlive reports whether a process with the given PID exists on this host.
+// Liveness cannot be checked without signals, so any valid PID is assumed to be
+// running and its record is left for the next exclusive holder to clear.
+func processAlive(pid int) bool {
+	return pid > 0
+}
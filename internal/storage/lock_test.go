@@ -0,0 +1,211 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/leeovery/tick/internal/task"
+)
+
+// writeLockHolder writes a holder record into tickDir as a crashed or
+// concurrent process would have left it.
+func writeLockHolder(t *testing.T, tickDir string, name string, h LockHolder) {
+	t.Helper()
+	dir := filepath.Join(tickDir, lockHoldersDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create holders dir: %v", err)
+	}
+	data, err := json.Marshal(h)
+	if err != nil {
+		t.Fatalf("failed to marshal holder: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+		t.Fatalf("failed to write holder: %v", err)
+	}
+}
+
+func TestLockHolders(t *testing.T) {
+	hostname, _ := os.Hostname()
+	started := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+
+	t.Run("it records the holder while the lock is held and removes it on release", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		store, err := NewStore(tickDir, WithCommand("tick done tick-a1b2c3"))
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer store.Close()
+
+		err = store.Mutate(func(tasks []task.Task) ([]task.Task, error) {
+			held, holders, err := LockStatus(tickDir)
+			if err != nil {
+				t.Fatalf("LockStatus returned error: %v", err)
+			}
+			if !held {
+				t.Error("held = false during a mutation")
+			}
+			if len(holders) != 1 {
+				t.Fatalf("holders = %+v, want one", holders)
+			}
+			h := holders[0]
+			if h.PID != os.Getpid() || h.Hostname != hostname || h.Mode != LockExclusive || h.Command != "tick done tick-a1b2c3" {
+				t.Errorf("holder = %+v", h)
+			}
+			if !h.Alive() {
+				t.Error("this process's holder should be alive")
+			}
+			return tasks, nil
+		})
+		if err != nil {
+			t.Fatalf("Mutate returned error: %v", err)
+		}
+
+		held, holders, err := LockStatus(tickDir)
+		if err != nil {
+			t.Fatalf("LockStatus returned error: %v", err)
+		}
+		if held || len(holders) != 0 {
+			t.Errorf("after release: held = %v, holders = %+v", held, holders)
+		}
+	})
+
+	t.Run("it names the recorded holders in the timeout error", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		externalLock := flock.New(filepath.Join(tickDir, "lock"))
+		if err := externalLock.Lock(); err != nil {
+			t.Fatalf("failed to acquire external lock: %v", err)
+		}
+		defer func() { _ = externalLock.Unlock() }()
+		writeLockHolder(t, tickDir, "4242-1.json", LockHolder{
+			PID: 4242, Hostname: "build-1", Command: "tick rebuild", Mode: LockExclusive, Started: started,
+		})
+
+		store, err := NewStore(tickDir, WithLockTimeout(100*time.Millisecond))
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer store.Close()
+
+		err = store.Query(func(db *sql.DB) error { return nil })
+		if err == nil {
+			t.Fatal("expected lock timeout error, got nil")
+		}
+		want := lockErrMsg + " (held by pid 4242 on build-1 (tick rebuild), exclusive since 2026-01-19T10:00:00Z)"
+		if err.Error() != want {
+			t.Errorf("error = %q, want %q", err.Error(), want)
+		}
+	})
+
+	t.Run("it removes records of dead processes and exclusive holders when reading", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, nil)
+		writeLockHolder(t, tickDir, "dead.json", LockHolder{PID: 0, Hostname: hostname, Mode: LockShared, Started: started})
+		writeLockHolder(t, tickDir, "exclusive.json", LockHolder{PID: 4242, Hostname: "build-1", Mode: LockExclusive, Started: started})
+		writeLockHolder(t, tickDir, "reader.json", LockHolder{PID: 4243, Hostname: "build-1", Mode: LockShared, Started: started})
+
+		store, err := NewStore(tickDir)
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer store.Close()
+		if _, err := store.ReadTasks(); err != nil {
+			t.Fatalf("ReadTasks returned error: %v", err)
+		}
+
+		_, holders, err := LockStatus(tickDir)
+		if err != nil {
+			t.Fatalf("LockStatus returned error: %v", err)
+		}
+		if len(holders) != 1 || holders[0].PID != 4243 {
+			t.Errorf("holders = %+v, want only the other host's reader", holders)
+		}
+	})
+
+	t.Run("it records readers as holders, so a writer waiting on one names it", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, nil)
+		reader, err := NewStore(tickDir, WithCommand("tick watch"))
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer reader.Close()
+		writer, err := NewStore(tickDir, WithLockTimeout(100*time.Millisecond))
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer writer.Close()
+
+		err = reader.Query(func(db *sql.DB) error {
+			_, holders, err := LockStatus(tickDir)
+			if err != nil {
+				t.Fatalf("LockStatus returned error: %v", err)
+			}
+			if len(holders) != 1 || holders[0].PID != os.Getpid() || holders[0].Mode != LockShared || holders[0].Command != "tick watch" {
+				t.Errorf("holders = %+v, want this process reading", holders)
+			}
+
+			err = writer.Mutate(func(tasks []task.Task) ([]task.Task, error) { return tasks, nil })
+			if err == nil {
+				t.Fatal("expected lock timeout error, got nil")
+			}
+			if want := "(tick watch), shared since"; !strings.Contains(err.Error(), want) {
+				t.Errorf("error = %q, want it to contain %q", err.Error(), want)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Query returned error: %v", err)
+		}
+
+		if _, holders, _ := LockStatus(tickDir); len(holders) != 0 {
+			t.Errorf("after release: holders = %+v, want none", holders)
+		}
+	})
+
+	t.Run("it removes every other record when taking the exclusive lock", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, nil)
+		writeLockHolder(t, tickDir, "reader.json", LockHolder{PID: 4243, Hostname: "build-1", Mode: LockShared, Started: started})
+
+		store, err := NewStore(tickDir)
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer store.Close()
+		if err := store.Mutate(func(tasks []task.Task) ([]task.Task, error) { return tasks, nil }); err != nil {
+			t.Fatalf("Mutate returned error: %v", err)
+		}
+
+		if _, holders, _ := LockStatus(tickDir); len(holders) != 0 {
+			t.Errorf("holders = %+v, want none", holders)
+		}
+	})
+
+	t.Run("it reports a free lock without creating the lock file", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		held, holders, err := LockStatus(tickDir)
+		if err != nil || held || holders != nil {
+			t.Errorf("LockStatus = %v, %+v, %v; want free with no holders", held, holders, err)
+		}
+		if _, err := os.Stat(filepath.Join(tickDir, "lock")); !os.IsNotExist(err) {
+			t.Error("LockStatus created the lock file")
+		}
+	})
+
+	t.Run("it treats holders on other hosts as alive", func(t *testing.T) {
+		remote := LockHolder{PID: 0, Hostname: "elsewhere-" + hostname}
+		if !remote.Alive() {
+			t.Error("remote holder should be assumed alive")
+		}
+		local := LockHolder{PID: 0, Hostname: hostname}
+		if local.Alive() {
+			t.Error("local holder with no process should be dead")
+		}
+		if s := (LockHolder{PID: 7, Hostname: "h", Mode: LockShared, Started: started}).String(); !strings.Contains(s, "pid 7 on h, shared since") {
+			t.Errorf("String() = %q", s)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
 	"errors"
@@ -12,18 +13,35 @@ import (
 	"time"
 
 	"github.com/gofrs/flock"
+	"github.com/leeovery/tick/internal/config"
 	"github.com/leeovery/tick/internal/task"
 )
 
-const defaultLockTimeout = 5 * time.Second
-
 const lockErrMsg = "could not acquire lock on .tick/lock - another process may be using tick"
 
-// Store orchestrates JSONL persistence and SQLite cache with file locking.
+// Store orchestrates task persistence and SQLite cache with file locking.
+// Tasks are stored in either layout (see Layout); the backend is chosen when
+// the Store is created.
 type Store struct {
 	tickDir     string
-	jsonlPath   string
+	backend     backend
 	cachePath   string
+	journalPath string
+	archivePath string
+	// includeArchived makes reads cover archived tasks too (see WithArchived).
+	includeArchived bool
+	// asOf, when non-zero, makes reads reconstruct the tasks at that instant
+	// (see WithAsOf).
+	asOf time.Time
+	// config is the project configuration, loaded once when the Store is created
+	// unless given with WithConfig.
+	config    config.Config
+	hasConfig bool
+	// command is the command line recorded with each journal entry.
+	command string
+	// released holds the IDs of the tasks whose leases were released under the
+	// current or last exclusive lock.
+	released    []string
 	lockTimeout time.Duration
 	fileLock    *flock.Flock
 	cache       *Cache
@@ -35,13 +53,23 @@ type Store struct {
 // StoreOption configures a Store.
 type StoreOption func(*Store)
 
-// WithLockTimeout sets the lock acquisition timeout.
+// WithLockTimeout sets the lock acquisition timeout, overriding the project's
+// lock_timeout setting.
 func WithLockTimeout(d time.Duration) StoreOption {
 	return func(s *Store) {
 		s.lockTimeout = d
 	}
 }
 
+// WithConfig gives the Store the project configuration, already loaded from
+// the project's .tick/config.yaml, so NewStore does not parse the file again.
+func WithConfig(cfg config.Config) StoreOption {
+	return func(s *Store) {
+		s.config = cfg
+		s.hasConfig = true
+	}
+}
+
 // WithVerbose sets a logging function for verbose debug output.
 // Key operations (lock, cache, hash, write) will call this function.
 func WithVerbose(fn func(msg string)) StoreOption {
@@ -50,29 +78,161 @@ func WithVerbose(fn func(msg string)) StoreOption {
 	}
 }
 
-// NewStore creates a Store that orchestrates JSONL and SQLite cache operations.
-// The tickDir must be an existing .tick/ directory containing a tasks.jsonl file.
+// WithCommand sets the command line recorded in the journal for mutations
+// made through this Store.
+func WithCommand(command string) StoreOption {
+	return func(s *Store) {
+		s.command = command
+	}
+}
+
+// WithArchived makes reads include archived tasks alongside active ones. The
+// combined view is cached separately in cache-archived.db so the main cache is
+// unaffected. A Store opened with this option is read-only: mutations fail.
+func WithArchived() StoreOption {
+	return func(s *Store) {
+		s.includeArchived = true
+	}
+}
+
+// WithAsOf makes reads return the tasks as they stood at the instant at,
+// replayed from their transition history (see task.AsOf). The reconstructed
+// view is cached separately in cache-asof.db so the main cache is unaffected.
+// A Store opened with this option is read-only: mutations fail.
+func WithAsOf(at time.Time) StoreOption {
+	return func(s *Store) {
+		s.asOf = at
+	}
+}
+
+// errAsOfReadOnly is returned by mutations on a Store opened WithAsOf.
+var errAsOfReadOnly = errors.New("cannot modify tasks in a point-in-time view (remove --as-of)")
+
+// readOnly returns the error mutations fail with when the Store presents a view
+// other than the current tasks, or nil when it can be modified.
+func (s *Store) readOnly() error {
+	switch {
+	case !s.asOf.IsZero():
+		return errAsOfReadOnly
+	case s.includeArchived:
+		return errArchivedReadOnly
+	}
+	return nil
+}
+
+// NewStore creates a Store that orchestrates task storage and SQLite cache operations.
+// The tickDir must be an existing .tick/ directory containing a tasks.jsonl file
+// or, for the files layout, a tasks/ directory. A project whose format version
+// is newer than FormatVersion, or whose config.yaml is invalid, is refused.
 func NewStore(tickDir string, opts ...StoreOption) (*Store, error) {
-	jsonlPath := filepath.Join(tickDir, "tasks.jsonl")
-	if _, err := os.Stat(jsonlPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("tasks.jsonl not found in %s", tickDir)
+	layout := DetectLayout(tickDir)
+	b := newBackend(tickDir, layout)
+	if !b.exists() {
+		return nil, fmt.Errorf("%s not found in %s", layout.SourceName(), tickDir)
+	}
+	if err := checkFormatVersion(tickDir); err != nil {
+		return nil, err
 	}
 
 	s := &Store{
 		tickDir:     tickDir,
-		jsonlPath:   jsonlPath,
+		backend:     b,
 		cachePath:   filepath.Join(tickDir, "cache.db"),
-		lockTimeout: defaultLockTimeout,
+		journalPath: filepath.Join(tickDir, "journal.jsonl"),
+		archivePath: filepath.Join(tickDir, archiveFileName),
 		fileLock:    flock.New(filepath.Join(tickDir, "lock")),
 	}
 
 	for _, opt := range opts {
 		opt(s)
 	}
+	if !s.hasConfig {
+		cfg, err := config.Load(tickDir)
+		if err != nil {
+			return nil, err
+		}
+		s.config = cfg
+	}
+	if s.lockTimeout == 0 {
+		s.lockTimeout = s.config.LockTimeout
+	}
+	switch {
+	case !s.asOf.IsZero():
+		s.cachePath = filepath.Join(tickDir, "cache-asof.db")
+	case s.includeArchived:
+		s.cachePath = filepath.Join(tickDir, "cache-archived.db")
+	}
 
 	return s, nil
 }
 
+// Config returns the project configuration from .tick/config.yaml.
+func (s *Store) Config() config.Config {
+	return s.config
+}
+
+// Dir returns the Store's .tick directory.
+func (s *Store) Dir() string {
+	return s.tickDir
+}
+
+// Layout returns the storage layout of the Store's .tick directory.
+func (s *Store) Layout() Layout {
+	return s.backend.layout()
+}
+
+// readRaw reads the task data in its JSONL form. With WithArchived, the contents
+// of archive.jsonl follow the active tasks. With WithAsOf, the data is the
+// reconstructed tasks, so the cache is keyed on the view it holds.
+func (s *Store) readRaw() ([]byte, error) {
+	raw, err := s.backend.readRaw()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", s.Layout().SourceName(), err)
+	}
+	if s.includeArchived {
+		archived, err := os.ReadFile(s.archivePath)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read %s: %w", archiveFileName, err)
+		}
+		if len(raw) > 0 && raw[len(raw)-1] != '\n' {
+			raw = append(raw, '\n')
+		}
+		raw = append(raw, archived...)
+	}
+	if s.asOf.IsZero() {
+		return raw, nil
+	}
+
+	tasks, err := s.parseRaw(raw)
+	if err != nil {
+		return nil, err
+	}
+	return MarshalJSONL(s.config.Rules().Workflow.AsOf(tasks, s.asOf))
+}
+
+// parseRaw parses task data read by readRaw. With WithArchived, an archived task
+// whose ID is also active is dropped in favour of the active one.
+func (s *Store) parseRaw(raw []byte) ([]task.Task, error) {
+	tasks, err := ParseJSONL(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", s.Layout().SourceName(), err)
+	}
+	if !s.includeArchived {
+		return tasks, nil
+	}
+
+	seen := make(map[string]bool, len(tasks))
+	unique := tasks[:0]
+	for _, t := range tasks {
+		if seen[t.ID] {
+			continue
+		}
+		seen[t.ID] = true
+		unique = append(unique, t)
+	}
+	return unique, nil
+}
+
 // verbose logs a message if verbose logging is enabled.
 func (s *Store) verbose(msg string) {
 	if s.verboseLog != nil {
@@ -88,7 +248,8 @@ func (s *Store) Close() error {
 	return nil
 }
 
-// acquireExclusive acquires an exclusive file lock and returns an unlock function.
+// acquireExclusive acquires an exclusive file lock, records this process as
+// its holder, and returns an unlock function.
 func (s *Store) acquireExclusive() (func(), error) {
 	ctx, cancel := context.WithTimeout(context.Background(), s.lockTimeout)
 	defer cancel()
@@ -96,16 +257,20 @@ func (s *Store) acquireExclusive() (func(), error) {
 	s.verbose("acquiring exclusive lock")
 	locked, err := s.fileLock.TryLockContext(ctx, 50*time.Millisecond)
 	if err != nil || !locked {
-		return nil, errors.New(lockErrMsg)
+		return nil, s.lockError()
 	}
 	s.verbose("lock acquired")
+	s.released = nil
+	release := s.recordLockHolder(LockExclusive)
 	return func() {
+		release()
 		_ = s.fileLock.Unlock()
 		s.verbose("lock released")
 	}, nil
 }
 
-// acquireShared acquires a shared file lock and returns an unlock function.
+// acquireShared acquires a shared file lock, records this process as one of
+// its holders, and returns an unlock function.
 func (s *Store) acquireShared() (func(), error) {
 	ctx, cancel := context.WithTimeout(context.Background(), s.lockTimeout)
 	defer cancel()
@@ -113,10 +278,12 @@ func (s *Store) acquireShared() (func(), error) {
 	s.verbose("acquiring shared lock")
 	locked, err := s.fileLock.TryRLockContext(ctx, 50*time.Millisecond)
 	if err != nil || !locked {
-		return nil, errors.New(lockErrMsg)
+		return nil, s.lockError()
 	}
 	s.verbose("lock acquired")
+	release := s.recordLockHolder(LockShared)
 	return func() {
+		release()
 		_ = s.fileLock.Unlock()
 		s.verbose("lock released")
 	}, nil
@@ -156,29 +323,119 @@ func (s *Store) ReadTasks() ([]task.Task, error) {
 	defer unlock()
 
 	s.verbose("reading JSONL (read-only)")
-	rawJSONL, err := os.ReadFile(s.jsonlPath)
+	rawJSONL, err := s.readRaw()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read tasks.jsonl: %w", err)
+		return nil, err
 	}
 
-	tasks, err := ParseJSONL(rawJSONL)
+	return s.parseRaw(rawJSONL)
+}
+
+// PollTasks is ReadTasks for callers that re-read on an interval: it reads the
+// task data under a shared lock but parses it only when its hash differs from
+// lastHash. It returns the data's hash, and nil tasks when it is unchanged. When
+// the data changed and fn is not nil, fn is called under the same lock with the
+// cache database, fresh for the returned tasks.
+func (s *Store) PollTasks(lastHash string, fn func(db *sql.DB) error) ([]task.Task, string, error) {
+	unlock, err := s.acquireShared()
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse tasks.jsonl: %w", err)
+		return nil, "", err
 	}
+	defer unlock()
 
-	return tasks, nil
+	rawJSONL, err := s.readRaw()
+	if err != nil {
+		return nil, "", err
+	}
+	hash := computeHash(rawJSONL)
+	if hash == lastHash {
+		return nil, hash, nil
+	}
+
+	tasks, err := s.parseRaw(rawJSONL)
+	if err != nil {
+		return nil, "", err
+	}
+	if tasks == nil {
+		tasks = []task.Task{}
+	}
+	if fn != nil {
+		if err := s.ensureFresh(rawJSONL, tasks); err != nil {
+			return nil, "", fmt.Errorf("failed to ensure cache freshness: %w", err)
+		}
+		if err := fn(s.cache.DB()); err != nil {
+			return nil, "", err
+		}
+	}
+	return tasks, hash, nil
 }
 
 // Mutate executes a write mutation with exclusive file locking.
-// The full flow: lock -> read JSONL -> freshness check -> mutate -> atomic write -> update cache -> unlock.
+// The full flow: lock -> read JSONL -> freshness check -> release expired leases -> mutate -> atomic write -> journal -> update cache -> unlock.
+// The cache is updated incrementally from a per-task diff of the pre- and post-mutation
+// tasks; a full rebuild is used only when the diff cannot be applied. The same diff is
+// recorded in the journal so the mutation can later be undone.
 func (s *Store) Mutate(fn func(tasks []task.Task) ([]task.Task, error)) error {
+	return s.mutate(&JournalEntry{Op: JournalMutate}, fn)
+}
+
+// MutateWithCache is Mutate for mutations that select tasks with SQL: fn also
+// receives the cache database, which is fresh for the tasks as read and does not
+// reflect fn's own changes. The read and the write share one exclusive lock, so
+// no other process can change the tasks between them.
+func (s *Store) MutateWithCache(fn func(db *sql.DB, tasks []task.Task) ([]task.Task, error)) error {
+	return s.mutate(&JournalEntry{Op: JournalMutate}, func(tasks []task.Task) ([]task.Task, error) {
+		return fn(s.cache.DB(), tasks)
+	})
+}
+
+// MutateKeepingLeases is Mutate without the release of expired leases, for
+// renewing a lease: an agent that overran its lease can still renew it until
+// another change hands the task back.
+func (s *Store) MutateKeepingLeases(fn func(tasks []task.Task) ([]task.Task, error)) error {
+	unlock, err := s.acquireExclusive()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return s.mutateLocked(&JournalEntry{Op: JournalMutate}, fn)
+}
+
+// mutate implements Mutate, recording the change under the given journal entry.
+// fn may fill in fields of record (such as Target) before it is appended.
+func (s *Store) mutate(record *JournalEntry, fn func(tasks []task.Task) ([]task.Task, error)) error {
 	unlock, err := s.acquireExclusive()
 	if err != nil {
 		return err
 	}
 	defer unlock()
 
-	_, tasks, err := s.readAndEnsureFresh()
+	if err := s.releaseLocked(); err != nil {
+		return err
+	}
+	return s.mutateLocked(record, fn)
+}
+
+// mutateLocked is mutate for callers that already hold the exclusive lock.
+// Task data in an older format is refused, except by the upgrade itself.
+func (s *Store) mutateLocked(record *JournalEntry, fn func(tasks []task.Task) ([]task.Task, error)) error {
+	if err := s.readOnly(); err != nil {
+		return err
+	}
+	if record.Op != JournalUpgrade {
+		if err := checkWriteFormat(s.tickDir); err != nil {
+			return err
+		}
+	}
+
+	rawJSONL, tasks, err := s.readAndEnsureFresh()
+	if err != nil {
+		return err
+	}
+
+	// Snapshot serialized tasks before fn can mutate the slice in place.
+	before, diffable, err := snapshotTasks(tasks)
 	if err != nil {
 		return err
 	}
@@ -189,21 +446,37 @@ func (s *Store) Mutate(fn func(tasks []task.Task) ([]task.Task, error)) error {
 		return err
 	}
 
-	// Marshal to bytes once — used for both atomic write and cache rebuild (no re-read).
-	newRawJSONL, err := MarshalJSONL(mutated)
+	// Diff against the snapshot; nil when IDs are not unique.
+	var diff *TaskDiff
+	if diffable {
+		d, ok, err := diffTasks(before, mutated)
+		if err != nil {
+			return err
+		}
+		if ok {
+			diff = &d
+		}
+	}
+
+	// Marshal to bytes once — used for both atomic write and cache update (no re-read).
+	newRawJSONL, err := s.backend.marshal(mutated)
 	if err != nil {
 		return fmt.Errorf("failed to marshal tasks: %w", err)
 	}
 
-	// Atomic write to JSONL.
+	// Atomic write to JSONL (or to each changed task file).
 	s.verbose("writing JSONL atomically")
-	if err := WriteJSONLRaw(s.jsonlPath, newRawJSONL); err != nil {
-		return fmt.Errorf("failed to write tasks.jsonl: %w", err)
+	if err := s.backend.write(mutated, newRawJSONL); err != nil {
+		return fmt.Errorf("failed to write %s: %w", s.Layout().SourceName(), err)
 	}
 
-	// Rebuild cache from the same bytes that were written — no re-read needed.
-	s.verbose("rebuilding cache from JSONL")
-	if err := s.cache.Rebuild(mutated, newRawJSONL); err != nil {
+	// The write has succeeded; journal failures are warnings, like cache failures.
+	if err := s.recordJournal(record, before, diff); err != nil {
+		log.Printf("warning: failed to record journal entry: %v", err)
+	}
+
+	// Update cache from the same bytes that were written — no re-read needed.
+	if err := s.updateCache(diff, mutated, rawJSONL, newRawJSONL); err != nil {
 		log.Printf("warning: failed to update cache after write: %v", err)
 		// Close the corrupted cache so it will be recreated on next use.
 		s.cache.Close()
@@ -214,6 +487,55 @@ func (s *Store) Mutate(fn func(tasks []task.Task) ([]task.Task, error)) error {
 	return nil
 }
 
+// recordJournal appends record with the before/after images from diff. Entries
+// that changed nothing are not recorded, except undo and redo records, which mark
+// their target; nor are mutations without a diff, since tasks with duplicate IDs
+// cannot be restored individually.
+func (s *Store) recordJournal(record *JournalEntry, before map[string][]byte, diff *TaskDiff) error {
+	if diff == nil {
+		s.verbose("journal skipped: duplicate task IDs")
+		return nil
+	}
+	if diff.Empty() && record.Op != JournalUndo && record.Op != JournalRedo {
+		return nil
+	}
+
+	changes, err := journalChanges(before, *diff)
+	if err != nil {
+		return err
+	}
+	record.At = time.Now().UTC().Truncate(time.Second)
+	record.Command = s.command
+	record.Changes = changes
+
+	if err := appendJournal(s.journalPath, record); err != nil {
+		return err
+	}
+	s.verbose(fmt.Sprintf("journal entry #%d recorded: %d task(s)", record.Seq, len(changes)))
+	return nil
+}
+
+// updateCache brings the cache in line with a just-written mutation. It applies the
+// per-task diff when available, and falls back to a full rebuild when diff is nil
+// (duplicate IDs) or the cache's stored hash does not match prevRawJSONL, the state
+// the diff was computed against.
+func (s *Store) updateCache(diff *TaskDiff, mutated []task.Task, prevRawJSONL, newRawJSONL []byte) error {
+	if diff != nil {
+		s.verbose(fmt.Sprintf("applying cache diff: %d upserted, %d removed", len(diff.Upserted), len(diff.Removed)))
+		applied, err := s.cache.Apply(*diff, prevRawJSONL, newRawJSONL)
+		if err != nil {
+			return err
+		}
+		if applied {
+			return nil
+		}
+		s.verbose("cache hash does not match prior state")
+	}
+
+	s.verbose("rebuilding cache from JSONL")
+	return s.cache.Rebuild(mutated, newRawJSONL)
+}
+
 // Rebuild forces a complete cache rebuild from JSONL. It acquires an exclusive lock,
 // deletes the existing cache.db, reads tasks.jsonl, creates a fresh cache, and populates it.
 // Returns the number of tasks rebuilt.
@@ -238,14 +560,14 @@ func (s *Store) Rebuild() (int, error) {
 
 	// Read JSONL.
 	s.verbose("reading JSONL")
-	rawJSONL, err := os.ReadFile(s.jsonlPath)
+	rawJSONL, err := s.readRaw()
 	if err != nil {
-		return 0, fmt.Errorf("failed to read tasks.jsonl: %w", err)
+		return 0, err
 	}
 
-	tasks, err := ParseJSONL(rawJSONL)
+	tasks, err := s.parseRaw(rawJSONL)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse tasks.jsonl: %w", err)
+		return 0, err
 	}
 
 	// Open fresh cache (creates schema).
@@ -274,26 +596,19 @@ func (s *Store) Query(fn func(db *sql.DB) error) error {
 	}
 	defer unlock()
 
-	_, _, err = s.readAndEnsureFresh()
-	if err != nil {
+	if err := s.readAndEnsureFreshLazy(); err != nil {
 		return err
 	}
 
 	return fn(s.cache.DB())
 }
 
-// ResolveID resolves a user-supplied ID input (with or without tick- prefix, any case)
-// to a canonical full task ID. Exact full-ID match bypasses prefix search. Minimum 3 hex
-// chars required for prefix matching. Returns an error for ambiguous or not-found inputs.
+// ResolveID resolves a user-supplied ID input (with or without its prefix, any case)
+// to a canonical full task ID. Exact full-ID match bypasses prefix search; a bare
+// ID is tried with the configured ID prefix first. Minimum 3 hex chars required for
+// prefix matching. Returns an error for ambiguous or not-found inputs.
 func (s *Store) ResolveID(input string) (string, error) {
-	originalInput := input
-
-	// Strip tick- prefix (case-insensitive).
-	lower := strings.ToLower(input)
-	hex := lower
-	if strings.HasPrefix(lower, "tick-") {
-		hex = lower[5:]
-	}
+	prefix, hex := task.SplitIDInput(input)
 
 	// Minimum length check.
 	if len(hex) < 3 {
@@ -303,10 +618,9 @@ func (s *Store) ResolveID(input string) (string, error) {
 	// Single query call: exact match first (6 hex chars), then prefix search fallback.
 	var resolved string
 	err := s.Query(func(db *sql.DB) error {
-		fullID := "tick-" + hex
-
 		// Exact full-ID match: 6 hex chars -> try exact match first.
 		if len(hex) == 6 {
+			fullID := cmp.Or(prefix, s.config.IDPrefix) + hex
 			var found string
 			scanErr := db.QueryRow("SELECT id FROM tasks WHERE id = ?", fullID).Scan(&found)
 			if scanErr == nil {
@@ -316,8 +630,8 @@ func (s *Store) ResolveID(input string) (string, error) {
 			// If not found, fall through to prefix search.
 		}
 
-		// Prefix search.
-		rows, err := db.Query("SELECT id FROM tasks WHERE id LIKE ? ORDER BY id", fullID+"%")
+		// Prefix search: candidates contain the hex, matches start with it.
+		rows, err := db.Query("SELECT id FROM tasks WHERE instr(id, ?) > 0 ORDER BY id", prefix+hex)
 		if err != nil {
 			return err
 		}
@@ -329,7 +643,9 @@ func (s *Store) ResolveID(input string) (string, error) {
 			if err := rows.Scan(&id); err != nil {
 				return err
 			}
-			matches = append(matches, id)
+			if task.MatchesIDInput(id, prefix, hex) {
+				matches = append(matches, id)
+			}
 		}
 		if err := rows.Err(); err != nil {
 			return err
@@ -337,12 +653,12 @@ func (s *Store) ResolveID(input string) (string, error) {
 
 		switch len(matches) {
 		case 0:
-			return fmt.Errorf("task '%s' not found", originalInput)
+			return fmt.Errorf("task '%s' not found", input)
 		case 1:
 			resolved = matches[0]
 			return nil
 		default:
-			return fmt.Errorf("ambiguous ID '%s' matches: %s", originalInput, strings.Join(matches, ", "))
+			return fmt.Errorf("ambiguous ID '%s' matches: %s", input, strings.Join(matches, ", "))
 		}
 	})
 	if err != nil {
@@ -355,14 +671,14 @@ func (s *Store) ResolveID(input string) (string, error) {
 // readAndEnsureFresh reads JSONL once, parses tasks, and ensures the SQLite cache is up-to-date.
 // The file is read exactly once — the same bytes are used for both parsing and hash computation.
 func (s *Store) readAndEnsureFresh() ([]byte, []task.Task, error) {
-	rawJSONL, err := os.ReadFile(s.jsonlPath)
+	rawJSONL, err := s.readRaw()
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to read tasks.jsonl: %w", err)
+		return nil, nil, err
 	}
 
-	tasks, err := ParseJSONL(rawJSONL)
+	tasks, err := s.parseRaw(rawJSONL)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to parse tasks.jsonl: %w", err)
+		return nil, nil, err
 	}
 
 	if err := s.ensureFresh(rawJSONL, tasks); err != nil {
@@ -372,9 +688,56 @@ func (s *Store) readAndEnsureFresh() ([]byte, []task.Task, error) {
 	return rawJSONL, tasks, nil
 }
 
-// ensureFresh checks if the persistent cache is up-to-date with the given JSONL content.
-// It opens the cache on first use (lazy init) and handles corruption by closing, deleting, and reopening.
+// readAndEnsureFreshLazy is the read-path variant of readAndEnsureFresh. It hashes the
+// JSONL against the cache first and parses tasks only when the cache is stale, so a
+// query against a fresh cache never pays for parsing.
+func (s *Store) readAndEnsureFreshLazy() error {
+	rawJSONL, err := s.readRaw()
+	if err != nil {
+		return err
+	}
+
+	fresh, err := s.checkFresh(rawJSONL)
+	if err != nil {
+		return fmt.Errorf("failed to ensure cache freshness: %w", err)
+	}
+	if fresh {
+		return nil
+	}
+
+	tasks, err := s.parseRaw(rawJSONL)
+	if err != nil {
+		return err
+	}
+
+	s.verbose("rebuilding cache from JSONL")
+	if err := s.cache.Rebuild(tasks, rawJSONL); err != nil {
+		return fmt.Errorf("failed to ensure cache freshness: failed to rebuild cache: %w", err)
+	}
+	return nil
+}
+
+// ensureFresh checks if the persistent cache is up-to-date with the given JSONL content
+// and rebuilds it from tasks when it is not.
 func (s *Store) ensureFresh(rawJSONL []byte, tasks []task.Task) error {
+	fresh, err := s.checkFresh(rawJSONL)
+	if err != nil {
+		return err
+	}
+
+	if !fresh {
+		s.verbose("rebuilding cache from JSONL")
+		if err := s.cache.Rebuild(tasks, rawJSONL); err != nil {
+			return fmt.Errorf("failed to rebuild cache: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// checkFresh reports whether the persistent cache is up-to-date with the given JSONL content.
+// It opens the cache on first use (lazy init) and handles corruption by closing, deleting, and reopening.
+func (s *Store) checkFresh(rawJSONL []byte) (bool, error) {
 	// Lazy init: open cache on first use.
 	if s.cache == nil {
 		cache, err := OpenCache(s.cachePath)
@@ -382,11 +745,11 @@ func (s *Store) ensureFresh(rawJSONL []byte, tasks []task.Task) error {
 			// Cache file might be corrupted — delete and recreate.
 			log.Printf("warning: cache open failed, recreating: %v", err)
 			if rmErr := s.removeCache(); rmErr != nil {
-				return rmErr
+				return false, rmErr
 			}
 			cache, err = OpenCache(s.cachePath)
 			if err != nil {
-				return fmt.Errorf("failed to recreate cache: %w", err)
+				return false, fmt.Errorf("failed to recreate cache: %w", err)
 			}
 		}
 		s.cache = cache
@@ -395,17 +758,17 @@ func (s *Store) ensureFresh(rawJSONL []byte, tasks []task.Task) error {
 	// Schema version check: detect incompatible or pre-versioning caches
 	// before querying. A mismatch (or missing row returning 0) triggers
 	// delete+reopen; the fresh empty cache has no hash, so IsFresh() below
-	// will return false and Rebuild() will repopulate it.
+	// will return false and the caller will repopulate it.
 	ver, err := s.cache.SchemaVersion()
 	if err != nil {
 		log.Printf("warning: schema version check failed, recreating: %v", err)
 		if err := s.recreateCache("schema version error"); err != nil {
-			return err
+			return false, err
 		}
 	} else if ver != CurrentSchemaVersion() {
 		s.verbose(fmt.Sprintf("schema version mismatch: got %d, want %d", ver, CurrentSchemaVersion()))
 		if err := s.recreateCache("schema version mismatch"); err != nil {
-			return err
+			return false, err
 		}
 	}
 
@@ -413,7 +776,7 @@ func (s *Store) ensureFresh(rawJSONL []byte, tasks []task.Task) error {
 	if err != nil {
 		log.Printf("warning: cache freshness check failed, recreating: %v", err)
 		if err := s.recreateCache("freshness check corruption"); err != nil {
-			return err
+			return false, err
 		}
 		fresh = false
 	}
@@ -423,11 +786,7 @@ func (s *Store) ensureFresh(rawJSONL []byte, tasks []task.Task) error {
 		s.verbose("cache is fresh")
 	} else {
 		s.verbose("hash match: no")
-		s.verbose("rebuilding cache from JSONL")
-		if err := s.cache.Rebuild(tasks, rawJSONL); err != nil {
-			return fmt.Errorf("failed to rebuild cache: %w", err)
-		}
 	}
 
-	return nil
+	return fresh, nil
 }
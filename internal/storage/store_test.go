@@ -5,15 +5,18 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/gofrs/flock"
+	"github.com/leeovery/tick/internal/config"
 	"github.com/leeovery/tick/internal/task"
 )
 
-// setupTickDir creates a .tick/ directory with an empty tasks.jsonl file for testing.
+// setupTickDir creates a .tick/ directory with an empty tasks.jsonl file in the
+// current format for testing.
 func setupTickDir(t *testing.T) string {
 	t.Helper()
 	dir := t.TempDir()
@@ -25,6 +28,9 @@ func setupTickDir(t *testing.T) string {
 	if err := os.WriteFile(jsonlPath, []byte{}, 0644); err != nil {
 		t.Fatalf("failed to create tasks.jsonl: %v", err)
 	}
+	if err := WriteFormatVersion(tickDir, FormatVersion); err != nil {
+		t.Fatalf("failed to write format file: %v", err)
+	}
 	return tickDir
 }
 
@@ -65,6 +71,49 @@ func TestStoreMutate(t *testing.T) {
 	})
 }
 
+func TestStoreMutateWithCache(t *testing.T) {
+	t.Run("it passes a fresh cache alongside the tasks and writes the result", func(t *testing.T) {
+		now := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+		tickDir := setupTickDirWithTasks(t, []task.Task{
+			{ID: "tick-aaaaaa", Title: "Low", Status: task.StatusOpen, Priority: 3, Created: now, Updated: now},
+			{ID: "tick-bbbbbb", Title: "High", Status: task.StatusOpen, Priority: 1, Created: now, Updated: now},
+		})
+
+		store, err := NewStore(tickDir)
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer store.Close()
+
+		err = store.MutateWithCache(func(db *sql.DB, tasks []task.Task) ([]task.Task, error) {
+			var id string
+			if err := db.QueryRow(`SELECT id FROM tasks ORDER BY priority LIMIT 1`).Scan(&id); err != nil {
+				return nil, err
+			}
+			for i := range tasks {
+				if tasks[i].ID == id {
+					tasks[i].Assignee = "agent-1"
+				}
+			}
+			return tasks, nil
+		})
+		if err != nil {
+			t.Fatalf("MutateWithCache returned error: %v", err)
+		}
+
+		var assignee string
+		err = store.Query(func(db *sql.DB) error {
+			return db.QueryRow(`SELECT assignee FROM tasks WHERE id = 'tick-bbbbbb'`).Scan(&assignee)
+		})
+		if err != nil {
+			t.Fatalf("Query returned error: %v", err)
+		}
+		if assignee != "agent-1" {
+			t.Errorf("assignee = %q, want %q", assignee, "agent-1")
+		}
+	})
+}
+
 func TestStoreQuery(t *testing.T) {
 	t.Run("it acquires shared lock for read operations", func(t *testing.T) {
 		tickDir := setupTickDir(t)
@@ -156,6 +205,43 @@ func TestStoreLockTimeout(t *testing.T) {
 	})
 }
 
+func TestStoreWithConfig(t *testing.T) {
+	t.Run("it uses the given config without reading config.yaml", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		if err := os.WriteFile(filepath.Join(tickDir, "config.yaml"), []byte("types: []\n"), 0644); err != nil {
+			t.Fatalf("failed to write config.yaml: %v", err)
+		}
+		if _, err := NewStore(tickDir); err == nil {
+			t.Fatal("NewStore should refuse the invalid config.yaml")
+		}
+
+		cfg := config.Default()
+		cfg.LockTimeout = 2 * time.Second
+		store, err := NewStore(tickDir, WithConfig(cfg))
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer store.Close()
+		if store.Config().LockTimeout != 2*time.Second || store.lockTimeout != 2*time.Second {
+			t.Errorf("lock timeout = %v (config %v), want 2s", store.lockTimeout, store.Config().LockTimeout)
+		}
+	})
+
+	t.Run("it lets WithLockTimeout override the config in any order", func(t *testing.T) {
+		tickDir := setupTickDir(t)
+		cfg := config.Default()
+		cfg.LockTimeout = 2 * time.Second
+		store, err := NewStore(tickDir, WithLockTimeout(time.Second), WithConfig(cfg))
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer store.Close()
+		if store.lockTimeout != time.Second {
+			t.Errorf("lock timeout = %v, want 1s", store.lockTimeout)
+		}
+	})
+}
+
 func TestStoreConcurrentLocks(t *testing.T) {
 	t.Run("it allows concurrent shared locks (multiple readers)", func(t *testing.T) {
 		tickDir := setupTickDir(t)
@@ -1119,6 +1205,78 @@ func TestReadTasks(t *testing.T) {
 	})
 }
 
+func TestPollTasks(t *testing.T) {
+	t.Run("it parses tasks only when the data changed", func(t *testing.T) {
+		created := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+		tickDir := setupTickDirWithTasks(t, []task.Task{
+			{ID: "tick-aaaaaa", Title: "Task A", Status: task.StatusOpen, Priority: 2, Created: created, Updated: created},
+		})
+
+		store, err := NewStore(tickDir)
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer store.Close()
+
+		got, hash, err := store.PollTasks("", nil)
+		if err != nil || len(got) != 1 || hash == "" {
+			t.Fatalf("first PollTasks = %d tasks, %q, %v", len(got), hash, err)
+		}
+
+		got, again, err := store.PollTasks(hash, nil)
+		if err != nil || got != nil || again != hash {
+			t.Errorf("unchanged PollTasks = %v, %q, %v; want nil tasks and the same hash", got, again, err)
+		}
+
+		if err := store.Mutate(func(tasks []task.Task) ([]task.Task, error) {
+			tasks[0].Title = "Renamed"
+			return tasks, nil
+		}); err != nil {
+			t.Fatalf("Mutate returned error: %v", err)
+		}
+		got, changed, err := store.PollTasks(hash, nil)
+		if err != nil || changed == hash || len(got) != 1 || got[0].Title != "Renamed" {
+			t.Errorf("changed PollTasks = %v, %q, %v", got, changed, err)
+		}
+	})
+
+	t.Run("it queries the cache fresh for the returned tasks only when the data changed", func(t *testing.T) {
+		created := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+		tickDir := setupTickDirWithTasks(t, []task.Task{
+			{ID: "tick-aaaaaa", Title: "Task A", Status: task.StatusOpen, Priority: 2, Created: created, Updated: created},
+		})
+
+		store, err := NewStore(tickDir)
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer store.Close()
+
+		calls := 0
+		var title string
+		fn := func(db *sql.DB) error {
+			calls++
+			return db.QueryRow(`SELECT title FROM tasks WHERE id = 'tick-aaaaaa'`).Scan(&title)
+		}
+		_, hash, err := store.PollTasks("", fn)
+		if err != nil || calls != 1 || title != "Task A" {
+			t.Fatalf("first PollTasks: calls = %d, title = %q, err = %v", calls, title, err)
+		}
+		if _, _, err := store.PollTasks(hash, fn); err != nil || calls != 1 {
+			t.Errorf("unchanged PollTasks: calls = %d, err = %v; want fn not called", calls, err)
+		}
+
+		// A hand edit leaves the cache stale; fn must still see the new data.
+		edited := []task.Task{{ID: "tick-aaaaaa", Title: "Edited", Status: task.StatusOpen, Priority: 2, Created: created, Updated: created}}
+		if err := WriteJSONL(filepath.Join(tickDir, "tasks.jsonl"), edited); err != nil {
+			t.Fatalf("WriteJSONL returned error: %v", err)
+		}
+		if _, _, err := store.PollTasks(hash, fn); err != nil || calls != 2 || title != "Edited" {
+			t.Errorf("changed PollTasks: calls = %d, title = %q, err = %v", calls, title, err)
+		}
+	})
+}
+
 func TestStoreStaleCacheRebuild(t *testing.T) {
 	t.Run("it rebuilds stale cache during write before applying mutation", func(t *testing.T) {
 		created := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
@@ -1744,3 +1902,99 @@ func TestStoreSchemaVersionUpgrade(t *testing.T) {
 func containsSubstring(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && strings.Contains(s, substr))
 }
+
+func TestStoreIncrementalCacheUpdate(t *testing.T) {
+	created := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+	initial := []task.Task{
+		{ID: "tick-aaaaaa", Title: "Untouched", Status: task.StatusOpen, Priority: 2, Created: created, Updated: created},
+		{ID: "tick-bbbbbb", Title: "Target", Status: task.StatusOpen, Priority: 2, Created: created, Updated: created},
+	}
+
+	// markUntouched writes a sentinel title directly into the cache row of the untouched
+	// task. The sentinel survives an incremental update but not a full rebuild.
+	markUntouched := func(t *testing.T, store *Store) {
+		t.Helper()
+		if err := store.Query(func(db *sql.DB) error {
+			_, err := db.Exec("UPDATE tasks SET title = 'sentinel' WHERE id = 'tick-aaaaaa'")
+			return err
+		}); err != nil {
+			t.Fatalf("failed to write sentinel: %v", err)
+		}
+	}
+
+	untouchedTitle := func(t *testing.T, store *Store) string {
+		t.Helper()
+		var title string
+		if err := store.Query(func(db *sql.DB) error {
+			return db.QueryRow("SELECT title FROM tasks WHERE id = 'tick-aaaaaa'").Scan(&title)
+		}); err != nil {
+			t.Fatalf("query failed: %v", err)
+		}
+		return title
+	}
+
+	startTarget := func(tasks []task.Task) ([]task.Task, error) {
+		for i := range tasks {
+			if tasks[i].ID == "tick-bbbbbb" {
+				tasks[i].Status = task.StatusInProgress
+			}
+		}
+		return tasks, nil
+	}
+
+	t.Run("it updates only the changed task's rows after a mutation", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, initial)
+		var logs []string
+		store, err := NewStore(tickDir, WithVerbose(func(msg string) { logs = append(logs, msg) }))
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer store.Close()
+
+		markUntouched(t, store)
+		if err := store.Mutate(startTarget); err != nil {
+			t.Fatalf("Mutate returned error: %v", err)
+		}
+
+		if got := untouchedTitle(t, store); got != "sentinel" {
+			t.Errorf("untouched title = %q, want sentinel (no full rebuild)", got)
+		}
+		var status string
+		if err := store.Query(func(db *sql.DB) error {
+			return db.QueryRow("SELECT status FROM tasks WHERE id = 'tick-bbbbbb'").Scan(&status)
+		}); err != nil {
+			t.Fatalf("query failed: %v", err)
+		}
+		if status != "in_progress" {
+			t.Errorf("status = %q, want in_progress", status)
+		}
+		if !slices.Contains(logs, "applying cache diff: 1 upserted, 0 removed") {
+			t.Errorf("verbose logs = %v, want diff application message", logs)
+		}
+	})
+
+	t.Run("it falls back to full rebuild when the stored hash does not match the prior state", func(t *testing.T) {
+		tickDir := setupTickDirWithTasks(t, initial)
+		store, err := NewStore(tickDir)
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		defer store.Close()
+
+		markUntouched(t, store)
+		err = store.Mutate(func(tasks []task.Task) ([]task.Task, error) {
+			// Simulate the cache drifting from the JSONL state the diff is based on.
+			if _, err := store.cache.DB().Exec("UPDATE metadata SET value = 'drifted' WHERE key = 'jsonl_hash'"); err != nil {
+				t.Fatalf("failed to corrupt hash: %v", err)
+			}
+			return startTarget(tasks)
+		})
+		if err != nil {
+			t.Fatalf("Mutate returned error: %v", err)
+		}
+
+		if got := untouchedTitle(t, store); got != "Untouched" {
+			t.Errorf("untouched title = %q, want Untouched (full rebuild)", got)
+		}
+	})
+}
@@ -16,7 +16,9 @@ func (sm StateMachine) ApplyUserTransition(tasks []Task, target *Task, action st
 // ApplySystemTransition applies a system-initiated transition to target and processes all
 // resulting cascades. The primary target's TransitionRecord is recorded with Auto: true
 // since this transition was triggered automatically (e.g., Rule 6 parent reopen, Rule 3
-// auto-completion). Cascade transitions are always recorded with Auto: true.
+// auto-completion). Cascade transitions are always recorded with Auto: true. Like a
+// cascade, a system transition moves target to the state of the action's command
+// whatever the command's from-states, which a project workflow may narrow.
 func (sm StateMachine) ApplySystemTransition(tasks []Task, target *Task, action string) (TransitionResult, []CascadeChange, error) {
 	return sm.applyWithCascades(tasks, target, action, true)
 }
@@ -36,12 +38,12 @@ func (sm StateMachine) ApplySystemTransition(tasks []Task, target *Task, action
 // On error (invalid primary transition), no tasks are mutated.
 func (sm StateMachine) applyWithCascades(tasks []Task, target *Task, action string, auto bool) (TransitionResult, []CascadeChange, error) {
 	// Rule 9: block reopen if direct parent is cancelled.
-	if action == "reopen" && target.Parent != "" {
+	if cmd, ok := sm.workflow().Command(action); ok && sm.workflow().reopens(cmd) && target.Parent != "" {
 		parentNorm := NormalizeID(target.Parent)
 		for i := range tasks {
 			if NormalizeID(tasks[i].ID) == parentNorm {
-				if tasks[i].Status == StatusCancelled {
-					return TransitionResult{}, nil, fmt.Errorf("cannot reopen task under cancelled parent, reopen parent first")
+				if sm.workflow().IsCancelled(tasks[i].Status) {
+					return TransitionResult{}, nil, fmt.Errorf("cannot reopen task under %s parent, reopen parent first", tasks[i].Status)
 				}
 				break
 			}
@@ -49,9 +51,19 @@ func (sm StateMachine) applyWithCascades(tasks []Task, target *Task, action stri
 	}
 
 	// Step 1: Apply the primary transition.
-	result, err := sm.Transition(target, action)
-	if err != nil {
-		return TransitionResult{}, nil, err
+	var result TransitionResult
+	if auto {
+		cmd, ok := sm.workflow().Command(action)
+		if !ok {
+			return TransitionResult{}, nil, fmt.Errorf("unknown command %q", action)
+		}
+		result = sm.moveTo(target, cmd.To)
+	} else {
+		var err error
+		result, err = sm.Transition(target, action)
+		if err != nil {
+			return TransitionResult{}, nil, err
+		}
 	}
 
 	// Step 2: Record transition history on the primary task.
@@ -83,17 +95,14 @@ func (sm StateMachine) applyWithCascades(tasks []Task, target *Task, action stri
 		queue = queue[1:]
 
 		nid := NormalizeID(change.Task.ID)
-		if seen[nid] {
+		if seen[nid] || change.Task.Status != change.OldStatus {
 			continue
 		}
 		seen[nid] = true
 
-		// Apply the cascaded transition.
-		cResult, cErr := sm.Transition(change.Task, change.Action)
-		if cErr != nil {
-			// Cascade transitions should always be valid given the rules, but skip if not.
-			continue
-		}
+		// Apply the cascaded status directly: cascades follow the rules above
+		// rather than the from-states of the command that caused them.
+		cResult := sm.moveTo(change.Task, change.NewStatus)
 
 		// Record transition history with Auto: true.
 		change.Task.Transitions = append(change.Task.Transitions, TransitionRecord{
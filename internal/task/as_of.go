@@ -0,0 +1,121 @@
+package task
+
+import (
+	"slices"
+	"time"
+)
+
+// AsOf reconstructs tasks as they stood at the instant at. Tasks created after
+// at are dropped, along with parent and dependency references to them. Each
+// remaining task's status is replayed from its transition history, and its
+// Closed, Updated, Transitions and Notes are cut back to at. Other fields keep
+// their current values, since only status changes are recorded.
+//
+// A task without transition history keeps its current status, unless it was
+// closed after at, in which case it is taken to have been open.
+//
+// AsOf replays statuses under DefaultWorkflow; see Workflow.AsOf.
+func AsOf(tasks []Task, at time.Time) []Task {
+	return DefaultWorkflow().AsOf(tasks, at)
+}
+
+// AsOf reconstructs tasks as they stood at the instant at, as the package-level
+// AsOf does, taking the terminal states of w as the ones that close a task.
+func (w Workflow) AsOf(tasks []Task, at time.Time) []Task {
+	existed := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		if !t.Created.After(at) {
+			existed[t.ID] = true
+		}
+	}
+
+	var out []Task
+	for _, t := range tasks {
+		if !existed[t.ID] {
+			continue
+		}
+		out = append(out, w.taskAsOf(t, at, existed))
+	}
+	return out
+}
+
+// taskAsOf reconstructs a single task at the instant at. existed holds the IDs
+// of the tasks that existed then.
+func (w Workflow) taskAsOf(t Task, at time.Time, existed map[string]bool) Task {
+	transitions := slices.Clone(t.Transitions)
+	slices.SortStableFunc(transitions, func(a, b TransitionRecord) int { return a.At.Compare(b.At) })
+
+	var past []TransitionRecord
+	for _, tr := range transitions {
+		if !tr.At.After(at) {
+			past = append(past, tr)
+		}
+	}
+
+	status := t.Status
+	closed := t.Closed
+	switch {
+	case len(past) > 0:
+		last := past[len(past)-1]
+		status = last.To
+		closed = nil
+		if w.IsTerminal(status) {
+			closed = new(last.At)
+		}
+	case len(transitions) > 0:
+		status = transitions[0].From
+		closed = nil
+	case closed != nil && closed.After(at):
+		status = StatusOpen
+		closed = nil
+	}
+
+	updated := t.Updated
+	if updated.After(at) {
+		updated = t.Created
+		for _, tr := range past {
+			updated = latest(updated, tr.At)
+		}
+		for _, n := range t.Notes {
+			if !n.Created.After(at) {
+				updated = latest(updated, n.Created)
+			}
+		}
+	}
+
+	var notes []Note
+	for _, n := range t.Notes {
+		if !n.Created.After(at) {
+			notes = append(notes, n)
+		}
+	}
+
+	var blockedBy []string
+	for _, id := range t.BlockedBy {
+		if existed[id] {
+			blockedBy = append(blockedBy, id)
+		}
+	}
+
+	t.Status = status
+	t.Closed = closed
+	t.Updated = updated
+	t.Transitions = past
+	t.Notes = notes
+	t.BlockedBy = blockedBy
+	if !existed[t.Parent] {
+		t.Parent = ""
+	}
+	if !w.IsStarted(status) {
+		t.LeaseExpires = nil
+	}
+	return t
+}
+
+// latest returns the later of a and b.
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
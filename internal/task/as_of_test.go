@@ -0,0 +1,84 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAsOf(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 10, 0, 0, 0, time.UTC) }
+
+	tasks := []Task{
+		{
+			ID: "tick-aaa111", Title: "Started then done", Status: StatusDone, Priority: 2,
+			Created: day(1), Updated: day(5), Closed: new(day(5)),
+			Transitions: []TransitionRecord{
+				{From: StatusOpen, To: StatusInProgress, At: day(2)},
+				{From: StatusInProgress, To: StatusDone, At: day(5)},
+			},
+			Notes: []Note{{Text: "early", Created: day(2)}, {Text: "late", Created: day(4)}},
+		},
+		{
+			ID: "tick-bbb222", Title: "Created later", Status: StatusOpen, Priority: 2,
+			Created: day(4), Updated: day(4),
+		},
+		{
+			ID: "tick-ccc333", Title: "Blocked by later task", Status: StatusOpen, Priority: 2,
+			Parent: "tick-bbb222", BlockedBy: []string{"tick-aaa111", "tick-bbb222"},
+			Created: day(1), Updated: day(4),
+		},
+		{
+			ID: "tick-ddd444", Title: "Closed without history", Status: StatusCancelled, Priority: 2,
+			Created: day(1), Updated: day(6), Closed: new(day(6)),
+		},
+	}
+
+	t.Run("it replays statuses and drops later tasks and references", func(t *testing.T) {
+		got := AsOf(tasks, day(3))
+
+		if len(got) != 3 {
+			t.Fatalf("got %d tasks, want 3 (tick-bbb222 did not exist yet)", len(got))
+		}
+
+		a := got[0]
+		if a.Status != StatusInProgress || a.Closed != nil || len(a.Transitions) != 1 {
+			t.Errorf("tick-aaa111 = status %s, closed %v, %d transitions; want in_progress, open, 1", a.Status, a.Closed, len(a.Transitions))
+		}
+		if len(a.Notes) != 1 || a.Notes[0].Text != "early" || !a.Updated.Equal(day(2)) {
+			t.Errorf("tick-aaa111 notes = %v, updated = %v", a.Notes, a.Updated)
+		}
+
+		c := got[1]
+		if c.Parent != "" || len(c.BlockedBy) != 1 || c.BlockedBy[0] != "tick-aaa111" {
+			t.Errorf("tick-ccc333 parent = %q, blocked_by = %v", c.Parent, c.BlockedBy)
+		}
+
+		d := got[2]
+		if d.Status != StatusOpen || d.Closed != nil {
+			t.Errorf("tick-ddd444 = %s closed %v, want open", d.Status, d.Closed)
+		}
+	})
+
+	t.Run("it uses the first transition's from status before any transition", func(t *testing.T) {
+		got := AsOf(tasks[:1], day(1))
+		if got[0].Status != StatusOpen || len(got[0].Transitions) != 0 {
+			t.Errorf("status = %s, transitions = %v", got[0].Status, got[0].Transitions)
+		}
+	})
+
+	t.Run("it keeps the closed time of the transition into a closed status", func(t *testing.T) {
+		got := AsOf(tasks, day(7))
+		if len(got) != 4 {
+			t.Fatalf("got %d tasks, want 4", len(got))
+		}
+		if got[0].Status != StatusDone || got[0].Closed == nil || !got[0].Closed.Equal(day(5)) {
+			t.Errorf("tick-aaa111 = %s closed %v", got[0].Status, got[0].Closed)
+		}
+		if got[3].Status != StatusCancelled || !got[3].Updated.Equal(day(6)) {
+			t.Errorf("tick-ddd444 = %+v, want unchanged", got[3])
+		}
+		if tasks[0].Status != StatusDone || len(tasks[0].Notes) != 2 {
+			t.Error("AsOf modified its input")
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxAssigneeLength = 100
+
+// NormalizeAssignee trims whitespace from an assignee name. Names are matched
+// exactly otherwise, so "Ann" and "ann" are different assignees.
+func NormalizeAssignee(name string) string {
+	return strings.TrimSpace(name)
+}
+
+// ValidateAssignee checks that an assignee name is non-empty, a single line,
+// and at most 100 characters. The name should be normalized first.
+func ValidateAssignee(name string) error {
+	if name == "" {
+		return errors.New("assignee cannot be empty")
+	}
+	if strings.ContainsAny(name, "\n\r") {
+		return errors.New("assignee must be a single line (no newlines)")
+	}
+	if utf8.RuneCountInString(name) > maxAssigneeLength {
+		return fmt.Errorf("assignee exceeds maximum length of %d characters", maxAssigneeLength)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package task
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateAssignee(t *testing.T) {
+	t.Run("it accepts a normalized name", func(t *testing.T) {
+		name := NormalizeAssignee("  Ann Lee ")
+		if name != "Ann Lee" {
+			t.Fatalf("NormalizeAssignee = %q, want %q", name, "Ann Lee")
+		}
+		if err := ValidateAssignee(name); err != nil {
+			t.Errorf("ValidateAssignee(%q) returned error: %v", name, err)
+		}
+	})
+
+	t.Run("it rejects empty, multi-line and overlong names", func(t *testing.T) {
+		for _, name := range []string{"", "ann\nlee", strings.Repeat("a", 101)} {
+			if err := ValidateAssignee(name); err == nil {
+				t.Errorf("ValidateAssignee(%q) returned nil, want error", name)
+			}
+		}
+	})
+}
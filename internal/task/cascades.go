@@ -11,30 +11,38 @@ type CascadeChange struct {
 
 // Cascades computes the cascade changes triggered by a status transition on the changed task.
 // It is pure — it does not mutate any tasks. The caller is responsible for applying the changes.
+// What cascades depends on the state the action's command moves to, so project commands
+// cascade like the built-in ones.
 //
-// For action "start" (Rule 2): walks up the parent chain and emits a CascadeChange for each
-// open ancestor, setting it to in_progress. Ancestors already in_progress are skipped but the
-// chain continues. Terminal ancestors (done/cancelled) stop the chain.
+// For a command into a started state (active other than open), such as "start" (Rule 2):
+// walks up the parent chain and emits a CascadeChange for each open ancestor, setting it to
+// in_progress. Other live ancestors are skipped but the chain continues. Terminal ancestors
+// (done/cancelled) stop the chain.
 //
-// For action "done" or "cancel": first applies Rule 4 (downward cascade to non-terminal
-// descendants via BFS), then applies Rule 3 (upward completion cascade — if the changed task
-// has a parent and all siblings are terminal, the parent auto-completes: done if any child is
-// done, cancelled if all children are cancelled). Only cascades to non-terminal parents.
+// For a command into a terminal state, such as "done" or "cancel": first applies Rule 4
+// (downward cascade of the same state to non-terminal descendants via BFS), then applies
+// Rule 3 (upward completion cascade — if the changed task has a parent and all siblings are
+// terminal, the parent auto-completes; see EvaluateParentCompletion). Only cascades to
+// non-terminal parents.
 //
-// For action "reopen" (Rule 5): walks up the parent chain and emits a CascadeChange for each
-// done ancestor, reopening it to open. Non-done ancestors (open, in_progress, cancelled) stop
-// the chain.
+// For a command from terminal states to a live one, such as "reopen" (Rule 5): walks up the
+// parent chain and emits a CascadeChange for each completed ancestor, reopening it to open.
+// Other ancestors (live or cancelled) stop the chain.
 //
 // For other actions, returns nil.
 func (sm StateMachine) Cascades(tasks []Task, changed *Task, action string) []CascadeChange {
-	switch action {
-	case "start":
+	wf := sm.workflow()
+	cmd, ok := wf.Command(action)
+	switch {
+	case !ok:
+		return nil
+	case wf.IsStarted(cmd.To):
 		return sm.cascadeUpwardStart(tasks, changed)
-	case "done", "cancel":
-		changes := sm.cascadeDownwardTerminal(tasks, changed, action)
+	case wf.IsTerminal(cmd.To):
+		changes := sm.cascadeDownwardTerminal(tasks, changed, cmd)
 		changes = append(changes, sm.cascadeUpwardCompletion(tasks, changed)...)
 		return changes
-	case "reopen":
+	case wf.reopens(cmd):
 		return sm.cascadeUpwardReopen(tasks, changed)
 	default:
 		return nil
@@ -44,6 +52,7 @@ func (sm StateMachine) Cascades(tasks []Task, changed *Task, action string) []Ca
 // cascadeUpwardStart walks up the parent chain from changed, emitting CascadeChange entries
 // for open ancestors (Rule 2). Terminal ancestors stop the walk.
 func (sm StateMachine) cascadeUpwardStart(tasks []Task, changed *Task) []CascadeChange {
+	wf := sm.workflow()
 	taskMap := buildTaskMap(tasks)
 
 	var changes []CascadeChange
@@ -55,19 +64,19 @@ func (sm StateMachine) cascadeUpwardStart(tasks []Task, changed *Task) []Cascade
 			break
 		}
 
-		switch parent.Status {
-		case StatusOpen:
+		switch {
+		case parent.Status == StatusOpen:
 			changes = append(changes, CascadeChange{
 				Task:      parent,
 				Action:    "start",
 				OldStatus: StatusOpen,
 				NewStatus: StatusInProgress,
 			})
-		case StatusInProgress:
-			// Already in_progress — skip but continue walking up
-		default:
+		case wf.IsTerminal(parent.Status):
 			// Terminal state (done/cancelled) — stop the chain
 			return changes
+		default:
+			// Already started or waiting — skip but continue walking up
 		}
 
 		current = parent
@@ -77,10 +86,11 @@ func (sm StateMachine) cascadeUpwardStart(tasks []Task, changed *Task) []Cascade
 }
 
 // cascadeDownwardTerminal walks downward from changed via BFS, emitting CascadeChange entries
-// for all non-terminal descendants (Rule 4). Terminal children are skipped.
-func (sm StateMachine) cascadeDownwardTerminal(tasks []Task, changed *Task, action string) []CascadeChange {
+// that move all non-terminal descendants to the terminal state of cmd (Rule 4). Terminal
+// children are skipped.
+func (sm StateMachine) cascadeDownwardTerminal(tasks []Task, changed *Task, cmd Command) []CascadeChange {
+	wf := sm.workflow()
 	childrenMap := buildChildrenMap(tasks)
-	targetStatus := transitions[action].to
 
 	var changes []CascadeChange
 	queue := []string{NormalizeID(changed.ID)}
@@ -90,14 +100,14 @@ func (sm StateMachine) cascadeDownwardTerminal(tasks []Task, changed *Task, acti
 		queue = queue[1:]
 
 		for _, child := range childrenMap[parentID] {
-			if child.Status == StatusDone || child.Status == StatusCancelled {
+			if wf.IsTerminal(child.Status) {
 				continue
 			}
 			changes = append(changes, CascadeChange{
 				Task:      child,
-				Action:    action,
+				Action:    cmd.Name,
 				OldStatus: child.Status,
-				NewStatus: targetStatus,
+				NewStatus: cmd.To,
 			})
 			queue = append(queue, NormalizeID(child.ID))
 		}
@@ -107,40 +117,38 @@ func (sm StateMachine) cascadeDownwardTerminal(tasks []Task, changed *Task, acti
 }
 
 // cascadeUpwardCompletion checks if the changed task's parent should auto-complete (Rule 3).
-// If the changed task has a parent and all children of that parent are terminal,
-// the parent transitions to done (if any child is done) or cancelled (if all cancelled).
-// Only cascades if the parent is non-terminal.
+// If the changed task has a parent and all children of that parent are terminal, the parent
+// moves to the state of the command EvaluateParentCompletion picks. Only cascades if the
+// parent is non-terminal.
 func (sm StateMachine) cascadeUpwardCompletion(tasks []Task, changed *Task) []CascadeChange {
 	if changed.Parent == "" {
 		return nil
 	}
 
-	action, shouldComplete := EvaluateParentCompletion(tasks, changed.Parent)
+	action, shouldComplete := sm.EvaluateParentCompletion(tasks, changed.Parent)
 	if !shouldComplete {
 		return nil
 	}
+	cmd, ok := sm.workflow().Command(action)
+	if !ok {
+		return nil
+	}
 
 	taskMap := buildTaskMap(tasks)
 	parent := taskMap[NormalizeID(changed.Parent)]
 
-	var newStatus Status
-	if action == "done" {
-		newStatus = StatusDone
-	} else {
-		newStatus = StatusCancelled
-	}
-
 	return []CascadeChange{{
 		Task:      parent,
 		Action:    action,
 		OldStatus: parent.Status,
-		NewStatus: newStatus,
+		NewStatus: cmd.To,
 	}}
 }
 
 // cascadeUpwardReopen walks up the parent chain from changed, emitting CascadeChange entries
-// for done ancestors (Rule 5). Non-done ancestors (open, in_progress, cancelled) stop the walk.
+// for completed ancestors (Rule 5). Other ancestors (live or cancelled) stop the walk.
 func (sm StateMachine) cascadeUpwardReopen(tasks []Task, changed *Task) []CascadeChange {
+	wf := sm.workflow()
 	taskMap := buildTaskMap(tasks)
 
 	var changes []CascadeChange
@@ -152,15 +160,15 @@ func (sm StateMachine) cascadeUpwardReopen(tasks []Task, changed *Task) []Cascad
 			break
 		}
 
-		if parent.Status == StatusDone {
+		if wf.IsCompleted(parent.Status) {
 			changes = append(changes, CascadeChange{
 				Task:      parent,
 				Action:    "reopen",
-				OldStatus: StatusDone,
+				OldStatus: parent.Status,
 				NewStatus: StatusOpen,
 			})
 		} else {
-			// open, in_progress, or cancelled — stop the chain
+			// live or cancelled — stop the chain
 			break
 		}
 
@@ -171,11 +179,21 @@ func (sm StateMachine) cascadeUpwardReopen(tasks []Task, changed *Task) []Cascad
 }
 
 // EvaluateParentCompletion checks whether a parent task should auto-complete based on its
-// children's statuses (Rule 3). It returns the action ("done" or "cancel") and whether
-// completion should occur. Completion triggers when: the parent exists, is non-terminal,
-// has children, and all children are terminal. Action is "done" if any child is done,
-// "cancel" if all children are cancelled.
+// children's statuses (Rule 3), under DefaultWorkflow. See StateMachine.EvaluateParentCompletion.
 func EvaluateParentCompletion(tasks []Task, parentID string) (action string, shouldComplete bool) {
+	var sm StateMachine
+	return sm.EvaluateParentCompletion(tasks, parentID)
+}
+
+// EvaluateParentCompletion checks whether a parent task should auto-complete based on its
+// children's statuses (Rule 3). It returns the command to move the parent with and whether
+// completion should occur. Completion triggers when: the parent exists, is non-terminal,
+// has children, and all children are terminal. When every child is in the same terminal
+// state, the command is the first that moves tasks to it, such as "wont_fix" for a
+// project's wont_fix state. Otherwise it is "done" if any child is completed (see
+// Workflow.IsCompleted) and "cancel" if none is.
+func (sm StateMachine) EvaluateParentCompletion(tasks []Task, parentID string) (action string, shouldComplete bool) {
+	wf := sm.workflow()
 	normalizedParentID := NormalizeID(parentID)
 
 	// Find parent.
@@ -191,7 +209,7 @@ func EvaluateParentCompletion(tasks []Task, parentID string) (action string, sho
 	}
 
 	// Parent must be non-terminal.
-	if parent.Status == StatusDone || parent.Status == StatusCancelled {
+	if wf.IsTerminal(parent.Status) {
 		return "", false
 	}
 
@@ -199,24 +217,36 @@ func EvaluateParentCompletion(tasks []Task, parentID string) (action string, sho
 	allTerminal := true
 	anyDone := false
 	hasChildren := false
+	sameState := true
+	var state Status
 	for i := range tasks {
 		if NormalizeID(tasks[i].Parent) != normalizedParentID {
 			continue
 		}
 		hasChildren = true
-		if tasks[i].Status != StatusDone && tasks[i].Status != StatusCancelled {
+		if !wf.IsTerminal(tasks[i].Status) {
 			allTerminal = false
 			break
 		}
-		if tasks[i].Status == StatusDone {
+		if wf.IsCompleted(tasks[i].Status) {
 			anyDone = true
 		}
+		if state != "" && tasks[i].Status != state {
+			sameState = false
+		}
+		state = tasks[i].Status
 	}
 
 	if !hasChildren || !allTerminal {
 		return "", false
 	}
 
+	if sameState {
+		if cmd, ok := wf.commandInto(state); ok {
+			return cmd.Name, true
+		}
+	}
+
 	if anyDone {
 		return "done", true
 	}
@@ -0,0 +1,8 @@
+package task
+
+import "time"
+
+// IsDeferred reports whether t is deferred past now and so not yet ready.
+func (t Task) IsDeferred(now time.Time) bool {
+	return t.DeferUntil != nil && t.DeferUntil.After(now)
+}
@@ -0,0 +1,56 @@
+package task
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIsDeferred(t *testing.T) {
+	now := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name string
+		task Task
+		want bool
+	}{
+		{"never deferred", Task{}, false},
+		{"deferred into the future", Task{DeferUntil: &future}, true},
+		{"defer date passed", Task{DeferUntil: &past}, false},
+		{"defer date is now", Task{DeferUntil: &now}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.task.IsDeferred(now); got != tc.want {
+				t.Errorf("IsDeferred = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTaskDeferUntilJSON(t *testing.T) {
+	created := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+
+	data, err := json.Marshal(Task{ID: "tick-a1b2c3", Title: "Later", Status: StatusOpen, DeferUntil: &until, Created: created, Updated: created})
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	if !strings.Contains(string(data), `"defer_until":"2026-02-01T09:00:00Z"`) {
+		t.Errorf("serialized task = %s, want defer_until timestamp", data)
+	}
+
+	var got Task
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if got.DeferUntil == nil || !got.DeferUntil.Equal(until) {
+		t.Errorf("DeferUntil = %v, want %v", got.DeferUntil, until)
+	}
+	if got.Extra != nil {
+		t.Errorf("Extra = %v, want nil", got.Extra)
+	}
+}
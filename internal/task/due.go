@@ -0,0 +1,13 @@
+package task
+
+import "time"
+
+// IsOverdue reports whether t has a due date before now and is not closed.
+func (t Task) IsOverdue(now time.Time) bool {
+	return t.Due != nil && t.Closed == nil && t.Due.Before(now)
+}
+
+// NormalizeDue returns due in UTC, truncated to the second as it is stored.
+func NormalizeDue(due time.Time) time.Time {
+	return due.UTC().Truncate(time.Second)
+}
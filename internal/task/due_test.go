@@ -0,0 +1,63 @@
+package task
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIsOverdue(t *testing.T) {
+	now := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name string
+		task Task
+		want bool
+	}{
+		{"no due date", Task{Status: StatusOpen}, false},
+		{"due in the future", Task{Status: StatusOpen, Due: &future}, false},
+		{"past due and open", Task{Status: StatusInProgress, Due: &past}, true},
+		{"past due but closed", Task{Status: StatusDone, Due: &past, Closed: &now}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.task.IsOverdue(now); got != tc.want {
+				t.Errorf("IsOverdue = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTaskDueJSON(t *testing.T) {
+	created := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+	due := time.Date(2026, 2, 1, 17, 30, 0, 0, time.UTC)
+
+	t.Run("it round-trips the due date as a timestamp", func(t *testing.T) {
+		data, err := json.Marshal(Task{ID: "tick-a1b2c3", Title: "Due", Status: StatusOpen, Due: &due, Created: created, Updated: created})
+		if err != nil {
+			t.Fatalf("Marshal returned error: %v", err)
+		}
+		if !strings.Contains(string(data), `"due":"2026-02-01T17:30:00Z"`) {
+			t.Errorf("serialized task = %s, want due timestamp", data)
+		}
+
+		var got Task
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal returned error: %v", err)
+		}
+		if got.Due == nil || !got.Due.Equal(due) {
+			t.Errorf("Due = %v, want %v", got.Due, due)
+		}
+	})
+
+	t.Run("it rejects an invalid due timestamp", func(t *testing.T) {
+		var got Task
+		err := json.Unmarshal([]byte(`{"id":"tick-a1b2c3","title":"Due","status":"open","priority":2,"due":"tomorrow","created":"2026-01-19T10:00:00Z","updated":"2026-01-19T10:00:00Z"}`), &got)
+		if err == nil || !strings.Contains(err.Error(), "invalid due timestamp") {
+			t.Errorf("Unmarshal error = %v, want invalid due timestamp", err)
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package task
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const maxEstimate = 10000
+
+// ValidateEstimate checks that an estimate is a finite number between 0 and
+// 10000. Zero means the task is unestimated.
+func ValidateEstimate(estimate float64) error {
+	if math.IsNaN(estimate) || estimate < 0 || estimate > maxEstimate {
+		return fmt.Errorf("estimate must be between 0 and %d, got %s", maxEstimate, FormatEstimate(estimate))
+	}
+	return nil
+}
+
+// ParseEstimate parses an estimate such as "3" or "0.5" and validates it.
+func ParseEstimate(s string) (float64, error) {
+	estimate, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid estimate '%s': must be a number", s)
+	}
+	if err := ValidateEstimate(estimate); err != nil {
+		return 0, err
+	}
+	return estimate, nil
+}
+
+// FormatEstimate renders an estimate with as few digits as represent it
+// exactly, so 3 prints as "3" and 2.5 as "2.5".
+func FormatEstimate(estimate float64) string {
+	return strconv.FormatFloat(estimate, 'f', -1, 64)
+}
@@ -0,0 +1,71 @@
+package task
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseEstimate(t *testing.T) {
+	t.Run("it parses whole and fractional estimates", func(t *testing.T) {
+		for input, want := range map[string]float64{"3": 3, "0.5": 0.5, " 8 ": 8, "0": 0, "10000": 10000} {
+			got, err := ParseEstimate(input)
+			if err != nil {
+				t.Errorf("ParseEstimate(%q) returned error: %v", input, err)
+				continue
+			}
+			if got != want {
+				t.Errorf("ParseEstimate(%q) = %v, want %v", input, got, want)
+			}
+		}
+	})
+
+	t.Run("it rejects non-numeric, negative and oversized estimates", func(t *testing.T) {
+		for _, input := range []string{"", "3d", "-1", "10001", "NaN", "Inf"} {
+			if _, err := ParseEstimate(input); err == nil {
+				t.Errorf("ParseEstimate(%q) returned nil, want error", input)
+			}
+		}
+	})
+}
+
+func TestFormatEstimate(t *testing.T) {
+	for estimate, want := range map[float64]string{3: "3", 2.5: "2.5", 0.25: "0.25", 13: "13"} {
+		if got := FormatEstimate(estimate); got != want {
+			t.Errorf("FormatEstimate(%v) = %q, want %q", estimate, got, want)
+		}
+	}
+}
+
+func TestTaskEstimateJSON(t *testing.T) {
+	created := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+
+	t.Run("it round-trips the estimate", func(t *testing.T) {
+		data, err := json.Marshal(Task{ID: "tick-a1b2c3", Title: "Sized", Status: StatusOpen, Estimate: 2.5, Created: created, Updated: created})
+		if err != nil {
+			t.Fatalf("Marshal returned error: %v", err)
+		}
+		if !strings.Contains(string(data), `"estimate":2.5`) {
+			t.Errorf("serialized task = %s, want estimate 2.5", data)
+		}
+
+		var got Task
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal returned error: %v", err)
+		}
+		if got.Estimate != 2.5 {
+			t.Errorf("Estimate = %v, want 2.5", got.Estimate)
+		}
+	})
+
+	t.Run("it omits the estimate when unset", func(t *testing.T) {
+		data, err := json.Marshal(Task{ID: "tick-a1b2c3", Title: "Plain", Status: StatusOpen, Created: created, Updated: created})
+		if err != nil {
+			t.Fatalf("Marshal returned error: %v", err)
+		}
+		if strings.Contains(string(data), "estimate") {
+			t.Errorf("serialized task = %s, want no estimate key", data)
+		}
+	})
+}
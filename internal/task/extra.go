@@ -0,0 +1,70 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// knownFields is the set of top-level JSON keys tick understands, taken from the
+// tags of taskJSON so it cannot drift from the serialized form.
+var knownFields = func() map[string]bool {
+	fields := map[string]bool{}
+	typ := reflect.TypeFor[taskJSON]()
+	for i := range typ.NumField() {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		fields[name] = true
+	}
+	return fields
+}()
+
+// IsKnownField reports whether name is a top-level task field this version of
+// tick understands. Other fields are kept in Task.Extra.
+func IsKnownField(name string) bool {
+	return knownFields[name]
+}
+
+// extraFields returns the top-level fields of a serialized task that are not
+// known fields, or nil when there are none.
+func extraFields(data []byte) (map[string]json.RawMessage, error) {
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	var extra map[string]json.RawMessage
+	for name, value := range all {
+		if knownFields[name] {
+			continue
+		}
+		if extra == nil {
+			extra = map[string]json.RawMessage{}
+		}
+		extra[name] = value
+	}
+	return extra, nil
+}
+
+// appendExtra adds extra fields to a serialized JSON object, sorted by name and
+// compacted. Fields that shadow known fields are ignored.
+func appendExtra(object []byte, extra map[string]json.RawMessage) ([]byte, error) {
+	unknown := make(map[string]json.RawMessage, len(extra))
+	for name, value := range extra {
+		if !knownFields[name] {
+			unknown[name] = value
+		}
+	}
+	if len(unknown) == 0 {
+		return object, nil
+	}
+
+	// Marshaling the map sorts keys and validates and compacts each value.
+	fields, err := json.Marshal(unknown)
+	if err != nil {
+		return nil, fmt.Errorf("invalid extra field: %w", err)
+	}
+	out := make([]byte, 0, len(object)+len(fields))
+	out = append(out, object[:len(object)-1]...)
+	out = append(out, ',')
+	return append(out, fields[1:]...), nil
+}
@@ -0,0 +1,88 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxFieldKeyLength   = 40
+	maxFieldValueLength = 500
+	maxFieldsPerTask    = 20
+)
+
+// fieldKeyPattern matches a field key: snake_case like pr_url.
+var fieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+
+// NormalizeFieldKey trims whitespace and lowercases a field key.
+func NormalizeFieldKey(key string) string {
+	return strings.ToLower(strings.TrimSpace(key))
+}
+
+// ValidateFieldKey checks that a field key is snake_case and at most 40
+// characters.
+func ValidateFieldKey(key string) error {
+	if key == "" {
+		return errors.New("field key cannot be empty")
+	}
+	if !fieldKeyPattern.MatchString(key) {
+		return fmt.Errorf("invalid field key %q: must be snake_case (lowercase alphanumeric words separated by underscores)", key)
+	}
+	if len(key) > maxFieldKeyLength {
+		return fmt.Errorf("field key %q exceeds maximum length of %d characters", key, maxFieldKeyLength)
+	}
+	return nil
+}
+
+// ValidateFieldValue checks that the value of the field key is non-empty, a
+// single line, and at most 500 characters. The value should be trimmed first.
+func ValidateFieldValue(key, value string) error {
+	if value == "" {
+		return fmt.Errorf("field %s: value cannot be empty", key)
+	}
+	if strings.ContainsAny(value, "\n\r") {
+		return fmt.Errorf("field %s: value must be a single line (no newlines)", key)
+	}
+	if utf8.RuneCountInString(value) > maxFieldValueLength {
+		return fmt.Errorf("field %s: value exceeds maximum length of %d characters", key, maxFieldValueLength)
+	}
+	return nil
+}
+
+// ValidateFields validates every key and value of fields and checks there are
+// at most 20.
+func ValidateFields(fields map[string]string) error {
+	for key, value := range fields {
+		if err := ValidateFieldKey(key); err != nil {
+			return err
+		}
+		if err := ValidateFieldValue(key, value); err != nil {
+			return err
+		}
+	}
+	if len(fields) > maxFieldsPerTask {
+		return fmt.Errorf("too many fields: %d exceeds maximum of %d per task", len(fields), maxFieldsPerTask)
+	}
+	return nil
+}
+
+// ParseField splits "key=value" input into a normalized key and a trimmed
+// value, and validates both.
+func ParseField(input string) (key, value string, err error) {
+	key, value, ok := strings.Cut(input, "=")
+	if !ok {
+		return "", "", fmt.Errorf("invalid field %q: use key=value", input)
+	}
+	key = NormalizeFieldKey(key)
+	value = strings.TrimSpace(value)
+	if err := ValidateFieldKey(key); err != nil {
+		return "", "", err
+	}
+	if err := ValidateFieldValue(key, value); err != nil {
+		return "", "", err
+	}
+	return key, value, nil
+}
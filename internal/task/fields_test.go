@@ -0,0 +1,138 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateFieldKey(t *testing.T) {
+	t.Run("it accepts snake_case keys", func(t *testing.T) {
+		for _, key := range []string{"estimate", "pr_url", "sprint2"} {
+			if err := ValidateFieldKey(key); err != nil {
+				t.Errorf("ValidateFieldKey(%q) returned error: %v", key, err)
+			}
+		}
+	})
+
+	t.Run("it rejects keys that are not snake_case", func(t *testing.T) {
+		for _, key := range []string{"", "PR", "pr-url", "_pr", "pr__url", "2fast", "pr url"} {
+			if err := ValidateFieldKey(key); err == nil {
+				t.Errorf("ValidateFieldKey(%q) returned nil, want error", key)
+			}
+		}
+	})
+
+	t.Run("it rejects keys over 40 characters", func(t *testing.T) {
+		if err := ValidateFieldKey(strings.Repeat("a", 40)); err != nil {
+			t.Errorf("40-char key returned error: %v", err)
+		}
+		if err := ValidateFieldKey(strings.Repeat("a", 41)); err == nil {
+			t.Error("41-char key returned nil, want error")
+		}
+	})
+}
+
+func TestValidateFieldValue(t *testing.T) {
+	t.Run("it rejects empty and multi-line values", func(t *testing.T) {
+		for _, value := range []string{"", "a\nb", "a\rb"} {
+			if err := ValidateFieldValue("estimate", value); err == nil {
+				t.Errorf("ValidateFieldValue(%q) returned nil, want error", value)
+			}
+		}
+	})
+
+	t.Run("it rejects values over 500 characters", func(t *testing.T) {
+		if err := ValidateFieldValue("notes", strings.Repeat("é", 500)); err != nil {
+			t.Errorf("500-char value returned error: %v", err)
+		}
+		if err := ValidateFieldValue("notes", strings.Repeat("a", 501)); err == nil {
+			t.Error("501-char value returned nil, want error")
+		}
+	})
+}
+
+func TestValidateFields(t *testing.T) {
+	t.Run("it rejects more than 20 fields", func(t *testing.T) {
+		fields := map[string]string{}
+		for i := range 20 {
+			fields[fmt.Sprintf("f%d", i)] = "x"
+		}
+		if err := ValidateFields(fields); err != nil {
+			t.Fatalf("20 fields returned error: %v", err)
+		}
+		fields["f20"] = "x"
+		if err := ValidateFields(fields); err == nil {
+			t.Error("21 fields returned nil, want error")
+		}
+	})
+}
+
+func TestParseField(t *testing.T) {
+	t.Run("it splits on the first equals sign and normalizes", func(t *testing.T) {
+		key, value, err := ParseField(" PR_URL = https://example.com/pull/1?a=b ")
+		if err != nil {
+			t.Fatalf("ParseField returned error: %v", err)
+		}
+		if key != "pr_url" {
+			t.Errorf("key = %q, want %q", key, "pr_url")
+		}
+		if value != "https://example.com/pull/1?a=b" {
+			t.Errorf("value = %q, want %q", value, "https://example.com/pull/1?a=b")
+		}
+	})
+
+	t.Run("it rejects input without a value", func(t *testing.T) {
+		for _, input := range []string{"estimate", "estimate=", "=3d"} {
+			if _, _, err := ParseField(input); err == nil {
+				t.Errorf("ParseField(%q) returned nil, want error", input)
+			}
+		}
+	})
+}
+
+func TestTaskFieldsJSON(t *testing.T) {
+	created := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+
+	t.Run("it round-trips fields", func(t *testing.T) {
+		tk := Task{
+			ID:       "tick-a1b2c3",
+			Title:    "With fields",
+			Status:   StatusOpen,
+			Priority: 2,
+			Fields:   map[string]string{"estimate": "3d", "component": "api"},
+			Created:  created,
+			Updated:  created,
+		}
+		data, err := json.Marshal(tk)
+		if err != nil {
+			t.Fatalf("Marshal returned error: %v", err)
+		}
+		if !strings.Contains(string(data), `"fields":{"component":"api","estimate":"3d"}`) {
+			t.Errorf("serialized task = %s, want sorted fields object", data)
+		}
+
+		var got Task
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal returned error: %v", err)
+		}
+		if len(got.Fields) != 2 || got.Fields["estimate"] != "3d" || got.Fields["component"] != "api" {
+			t.Errorf("Fields = %v, want estimate and component", got.Fields)
+		}
+		if got.Extra != nil {
+			t.Errorf("Extra = %v, want nil", got.Extra)
+		}
+	})
+
+	t.Run("it omits fields when empty", func(t *testing.T) {
+		data, err := json.Marshal(Task{ID: "tick-a1b2c3", Title: "Plain", Status: StatusOpen, Created: created, Updated: created})
+		if err != nil {
+			t.Fatalf("Marshal returned error: %v", err)
+		}
+		if strings.Contains(string(data), "fields") {
+			t.Errorf("serialized task = %s, want no fields key", data)
+		}
+	})
+}
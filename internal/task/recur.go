@@ -0,0 +1,153 @@
+package task
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRecurInterval bounds the N of an "every N days/weeks/months" rule.
+const maxRecurInterval = 999
+
+// Recurrence is a parsed recurrence rule: a task repeats every Days days or
+// every Months months. Day, for a monthly rule, is the day of the month its
+// dates keep; zero keeps the day of each date moved.
+type Recurrence struct {
+	Days   int
+	Months int
+	Day    int
+}
+
+// ParseRecurrence parses a recurrence rule: daily, weekly, monthly, or
+// "every N days", "every N weeks" or "every N months" (singular units are
+// accepted too). A monthly rule may end in "on day D" to keep its dates on
+// that day of the month. Matching is case-insensitive.
+func ParseRecurrence(s string) (Recurrence, error) {
+	rule := strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	invalid := fmt.Errorf("invalid recurrence '%s': use daily, weekly, monthly, or every N days, weeks or months, with months optionally on day D", s)
+
+	day := 0
+	if base, d, ok := strings.Cut(rule, " on day "); ok {
+		n, err := strconv.Atoi(d)
+		if err != nil || n < 1 || n > 31 {
+			return Recurrence{}, invalid
+		}
+		rule, day = base, n
+	}
+	r, ok := parseInterval(rule)
+	if !ok || (day > 0 && r.Months == 0) {
+		return Recurrence{}, invalid
+	}
+	r.Day = day
+	return r, nil
+}
+
+// parseInterval parses the interval of a normalized rule, without its day.
+func parseInterval(rule string) (Recurrence, bool) {
+	switch rule {
+	case "daily":
+		return Recurrence{Days: 1}, true
+	case "weekly":
+		return Recurrence{Days: 7}, true
+	case "monthly":
+		return Recurrence{Months: 1}, true
+	}
+
+	parts := strings.Fields(rule)
+	if len(parts) != 3 || parts[0] != "every" {
+		return Recurrence{}, false
+	}
+	n, err := strconv.Atoi(parts[1])
+	if err != nil || n < 1 || n > maxRecurInterval {
+		return Recurrence{}, false
+	}
+	switch strings.TrimSuffix(parts[2], "s") {
+	case "day":
+		return Recurrence{Days: n}, true
+	case "week":
+		return Recurrence{Days: 7 * n}, true
+	case "month":
+		return Recurrence{Months: n}, true
+	}
+	return Recurrence{}, false
+}
+
+// NormalizeRecur parses rule and returns it in canonical form, as stored on a
+// task. An empty rule stays empty.
+func NormalizeRecur(rule string) (string, error) {
+	if strings.TrimSpace(rule) == "" {
+		return "", nil
+	}
+	r, err := ParseRecurrence(rule)
+	if err != nil {
+		return "", err
+	}
+	return r.String(), nil
+}
+
+// String returns the canonical form of r: daily, weekly, monthly, or
+// "every N days", "every N weeks" or "every N months", with "on day D" after
+// a monthly rule that keeps a day.
+func (r Recurrence) String() string {
+	if r.Months > 0 && r.Day > 0 {
+		return fmt.Sprintf("%s on day %d", Recurrence{Months: r.Months}, r.Day)
+	}
+	switch {
+	case r.Months == 1:
+		return "monthly"
+	case r.Months > 1:
+		return fmt.Sprintf("every %d months", r.Months)
+	case r.Days == 1:
+		return "daily"
+	case r.Days == 7:
+		return "weekly"
+	case r.Days%7 == 0:
+		return fmt.Sprintf("every %d weeks", r.Days/7)
+	default:
+		return fmt.Sprintf("every %d days", r.Days)
+	}
+}
+
+// Add returns t moved forward by n intervals of r. Days and months are
+// calendar days and months in local time, so a task due at the end of a day
+// stays due at the end of a day across daylight saving changes. Months land on
+// r.Day, or on t's own day when r.Day is zero, clamped to the last day of a
+// shorter month: monthly from January 31 gives February 28, then March 31 if
+// r.Day is 31.
+func (r Recurrence) Add(t time.Time, n int) time.Time {
+	lt := t.In(time.Local)
+	if r.Months == 0 {
+		return lt.AddDate(0, 0, n*r.Days).In(t.Location())
+	}
+	first := time.Date(lt.Year(), lt.Month()+time.Month(n*r.Months), 1, lt.Hour(), lt.Minute(), lt.Second(), lt.Nanosecond(), time.Local)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	day := min(cmp.Or(r.Day, lt.Day()), lastDay)
+	return first.AddDate(0, 0, day-1).In(t.Location())
+}
+
+// AnchoredTo returns r keeping the day of the month of t when r is monthly
+// without a day and t falls after the 28th, where shorter months would
+// otherwise pull later dates earlier for good.
+func (r Recurrence) AnchoredTo(t time.Time) Recurrence {
+	if r.Months > 0 && r.Day == 0 {
+		if day := t.In(time.Local).Day(); day > 28 {
+			r.Day = day
+		}
+	}
+	return r
+}
+
+// Steps returns the smallest number of intervals of r that moves anchor past
+// now, at least one. A zero r returns one.
+func (r Recurrence) Steps(anchor, now time.Time) int {
+	n := 1
+	if r.Days == 0 && r.Months == 0 {
+		return n
+	}
+	for !r.Add(anchor, n).After(now) {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,129 @@
+package task
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNormalizeRecur(t *testing.T) {
+	t.Run("it normalizes rules to their canonical form", func(t *testing.T) {
+		for input, want := range map[string]string{
+			"daily":                     "daily",
+			"Weekly":                    "weekly",
+			" monthly ":                 "monthly",
+			"every 1 day":               "daily",
+			"every 3 days":              "every 3 days",
+			"every 7 days":              "weekly",
+			"every 14 days":             "every 2 weeks",
+			"every 2 weeks":             "every 2 weeks",
+			"every 1 week":              "weekly",
+			"every  6 Months":           "every 6 months",
+			"Monthly on Day 31":         "monthly on day 31",
+			"every 1 month on day 5":    "monthly on day 5",
+			"every 3 months  on day 30": "every 3 months on day 30",
+			"":                          "",
+		} {
+			got, err := NormalizeRecur(input)
+			if err != nil {
+				t.Errorf("NormalizeRecur(%q) returned error: %v", input, err)
+				continue
+			}
+			if got != want {
+				t.Errorf("NormalizeRecur(%q) = %q, want %q", input, got, want)
+			}
+		}
+	})
+
+	t.Run("it rejects unknown rules and intervals out of range", func(t *testing.T) {
+		for _, input := range []string{"hourly", "every day", "every 0 days", "every -1 weeks", "every 1000 days", "every 2 fortnights", "0 9 * * 1", "weekly on day 3", "monthly on day 32", "monthly on day"} {
+			if _, err := NormalizeRecur(input); err == nil || !strings.Contains(err.Error(), "invalid recurrence") {
+				t.Errorf("NormalizeRecur(%q) error = %v, want invalid recurrence", input, err)
+			}
+		}
+	})
+}
+
+func TestRecurrence(t *testing.T) {
+	t.Run("it adds days, weeks and months", func(t *testing.T) {
+		start := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+		for rule, want := range map[string]time.Time{
+			"daily":         time.Date(2026, 1, 16, 12, 0, 0, 0, time.UTC),
+			"every 2 weeks": time.Date(2026, 1, 29, 12, 0, 0, 0, time.UTC),
+			"monthly":       time.Date(2026, 2, 15, 12, 0, 0, 0, time.UTC),
+		} {
+			r, err := ParseRecurrence(rule)
+			if err != nil {
+				t.Fatalf("ParseRecurrence(%q) returned error: %v", rule, err)
+			}
+			if got := r.Add(start, 1); !got.Equal(want) {
+				t.Errorf("%s: Add = %v, want %v", rule, got, want)
+			}
+		}
+	})
+
+	t.Run("it clamps months to the last day and returns to the kept day", func(t *testing.T) {
+		jan31 := time.Date(2026, 1, 31, 23, 59, 59, 0, time.Local)
+		feb28 := time.Date(2026, 2, 28, 23, 59, 59, 0, time.Local)
+		if got := (Recurrence{Months: 1}).Add(jan31, 1); !got.Equal(feb28) {
+			t.Errorf("monthly from Jan 31 = %v, want %v", got, feb28)
+		}
+		anchored := Recurrence{Months: 1}.AnchoredTo(jan31)
+		if anchored.String() != "monthly on day 31" {
+			t.Fatalf("AnchoredTo = %q, want monthly on day 31", anchored)
+		}
+		for n, want := range map[int]time.Time{
+			1: feb28,
+			2: time.Date(2026, 3, 31, 23, 59, 59, 0, time.Local),
+			3: time.Date(2026, 4, 30, 23, 59, 59, 0, time.Local),
+		} {
+			if got := anchored.Add(feb28, n-1); n > 1 && !got.Equal(want) {
+				t.Errorf("anchored Add(Feb 28, %d) = %v, want %v", n-1, got, want)
+			}
+			if got := anchored.Add(jan31, n); !got.Equal(want) {
+				t.Errorf("anchored Add(Jan 31, %d) = %v, want %v", n, got, want)
+			}
+		}
+		if got := (Recurrence{Months: 1}).AnchoredTo(time.Date(2026, 1, 15, 0, 0, 0, 0, time.Local)); got.Day != 0 {
+			t.Errorf("AnchoredTo(Jan 15).Day = %d, want 0", got.Day)
+		}
+	})
+
+	t.Run("it counts the intervals needed to pass now", func(t *testing.T) {
+		r := Recurrence{Days: 7}
+		anchor := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		for now, want := range map[time.Time]int{
+			time.Date(2025, 12, 30, 0, 0, 0, 0, time.UTC): 1,
+			time.Date(2026, 1, 8, 12, 0, 0, 0, time.UTC):  2,
+			time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC):  3,
+		} {
+			if got := r.Steps(anchor, now); got != want {
+				t.Errorf("Steps(%v) = %d, want %d", now, got, want)
+			}
+		}
+	})
+}
+
+func TestTaskRecurJSON(t *testing.T) {
+	created := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+
+	t.Run("it round-trips the rule and origin", func(t *testing.T) {
+		tk := Task{ID: "tick-a1b2c3", Title: "Rotate keys", Status: StatusOpen, Priority: 2, Recur: "weekly", RecurredFrom: "tick-d4e5f6", Created: created, Updated: created}
+		data, err := json.Marshal(tk)
+		if err != nil {
+			t.Fatalf("Marshal returned error: %v", err)
+		}
+		if !strings.Contains(string(data), `"recur":"weekly","recurred_from":"tick-d4e5f6"`) {
+			t.Errorf("serialized task = %s, want recur and recurred_from", data)
+		}
+
+		var got Task
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal returned error: %v", err)
+		}
+		if got.Recur != "weekly" || got.RecurredFrom != "tick-d4e5f6" || got.Extra != nil {
+			t.Errorf("Recur, RecurredFrom, Extra = %q, %q, %v", got.Recur, got.RecurredFrom, got.Extra)
+		}
+	})
+}
@@ -0,0 +1,155 @@
+package task
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Rules are the limits and defaults tasks are created and validated with. A
+// project can change them in .tick/config.yaml; the package-level functions
+// such as ValidateType and GenerateID apply DefaultRules.
+type Rules struct {
+	// IDPrefix starts every generated task ID, such as "tick-".
+	IDPrefix string
+	// DefaultPriority is the priority of tasks created without one.
+	DefaultPriority int
+	// Types are the allowed task type values.
+	Types []string
+	// TypePriority maps types to the priority their tasks are created with
+	// when none is given, overriding DefaultPriority.
+	TypePriority map[string]int
+	// MaxTags is the maximum number of tags per task.
+	MaxTags int
+	// MaxTagLength is the maximum length of a tag in characters.
+	MaxTagLength int
+	// Workflow holds the statuses tasks can have and the commands that move
+	// them between statuses.
+	Workflow Workflow
+}
+
+// DefaultRules returns the rules of a project without configuration.
+func DefaultRules() Rules {
+	return Rules{
+		IDPrefix:        idPrefix,
+		DefaultPriority: defaultPriority,
+		Types:           slices.Clone(allowedTypes),
+		MaxTags:         maxTagsPerTask,
+		MaxTagLength:    maxTagLength,
+		Workflow:        DefaultWorkflow(),
+	}
+}
+
+// PriorityFor returns the priority a task of type typ is created with when none
+// is given.
+func (r Rules) PriorityFor(typ string) int {
+	if p, ok := r.TypePriority[typ]; ok {
+		return p
+	}
+	return r.DefaultPriority
+}
+
+// idPrefixPattern matches a valid ID prefix: a lowercase letter, then lowercase
+// letters or digits, ending in a hyphen.
+var idPrefixPattern = regexp.MustCompile(`^[a-z][a-z0-9]{0,15}-$`)
+
+// ValidateIDPrefix checks that prefix is lowercase letters and digits ending in
+// a hyphen, such as "tick-".
+func ValidateIDPrefix(prefix string) error {
+	if !idPrefixPattern.MatchString(prefix) {
+		return fmt.Errorf("invalid ID prefix %q: must be lowercase letters and digits ending in '-', such as tick-", prefix)
+	}
+	return nil
+}
+
+// GenerateID creates a new task ID of IDPrefix followed by 6 hex chars using
+// crypto/rand. The exists function is called to check for collisions; up to 5
+// retries are attempted.
+func (r Rules) GenerateID(exists func(id string) bool) (string, error) {
+	for range maxIDRetries {
+		b := make([]byte, idByteLength)
+		if _, err := rand.Read(b); err != nil {
+			return "", fmt.Errorf("failed to generate random bytes: %w", err)
+		}
+
+		id := r.IDPrefix + hex.EncodeToString(b)
+
+		if !exists(id) {
+			return id, nil
+		}
+	}
+
+	return "", errors.New("failed to generate unique ID after 5 attempts - task list may be too large")
+}
+
+// ValidateType checks that typ is one of Types or empty (optional).
+func (r Rules) ValidateType(typ string) error {
+	if typ == "" {
+		return nil
+	}
+	if slices.Contains(r.Types, typ) {
+		return nil
+	}
+	return fmt.Errorf("invalid type %q: must be one of %s", typ, strings.Join(r.Types, ", "))
+}
+
+// ValidateTag checks that a single tag is non-empty, matches kebab-case, and is
+// at most MaxTagLength chars.
+func (r Rules) ValidateTag(tag string) error {
+	if tag == "" {
+		return fmt.Errorf("tag cannot be empty")
+	}
+	if len(tag) > r.MaxTagLength {
+		return fmt.Errorf("tag %q exceeds maximum length of %d characters", tag, r.MaxTagLength)
+	}
+	if !tagPattern.MatchString(tag) {
+		return fmt.Errorf("tag %q must be kebab-case (lowercase alphanumeric segments separated by single hyphens)", tag)
+	}
+	return nil
+}
+
+// ValidateTags normalizes, filters empties, deduplicates, validates each tag,
+// and checks the count is at most MaxTags.
+func (r Rules) ValidateTags(tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	deduped := DeduplicateTags(tags)
+
+	for _, tag := range deduped {
+		if err := r.ValidateTag(tag); err != nil {
+			return err
+		}
+	}
+
+	if len(deduped) > r.MaxTags {
+		return fmt.Errorf("too many tags: %d exceeds maximum of %d per task", len(deduped), r.MaxTags)
+	}
+
+	return nil
+}
+
+// SplitIDInput splits a full or partial task ID as typed by a user, lowercased,
+// into its prefix ("tick-" in "tick-a1b2", or "" for a bare "a1b2") and the hex
+// characters after it.
+func SplitIDInput(input string) (prefix, hex string) {
+	lower := strings.ToLower(input)
+	if i := strings.LastIndex(lower, "-"); i >= 0 {
+		return lower[:i+1], lower[i+1:]
+	}
+	return "", lower
+}
+
+// MatchesIDInput reports whether the task ID id matches a partial ID split by
+// SplitIDInput: the hex characters of id start with hex and, when the input
+// had a prefix, id has that prefix. IDs with any prefix match a bare input, so
+// tasks created before the project's ID prefix changed still resolve.
+func MatchesIDInput(id, prefix, hex string) bool {
+	idPrefix, idHex := SplitIDInput(id)
+	return (prefix == "" || idPrefix == prefix) && strings.HasPrefix(idHex, hex)
+}
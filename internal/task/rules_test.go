@@ -0,0 +1,79 @@
+package task
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestRules(t *testing.T) {
+	t.Run("it generates IDs with the configured prefix", func(t *testing.T) {
+		r := DefaultRules()
+		r.IDPrefix = "api-"
+		id, err := r.GenerateID(func(string) bool { return false })
+		if err != nil {
+			t.Fatalf("GenerateID: %v", err)
+		}
+		if !regexp.MustCompile(`^api-[0-9a-f]{6}$`).MatchString(id) {
+			t.Errorf("ID %q does not match api-{6 hex}", id)
+		}
+	})
+
+	t.Run("it validates types against the configured list", func(t *testing.T) {
+		r := DefaultRules()
+		r.Types = []string{"bug", "spike"}
+		if err := r.ValidateType("spike"); err != nil {
+			t.Errorf("ValidateType(spike): %v", err)
+		}
+		err := r.ValidateType("chore")
+		if err == nil || err.Error() != `invalid type "chore": must be one of bug, spike` {
+			t.Errorf("ValidateType(chore) = %v", err)
+		}
+	})
+
+	t.Run("it validates tags against the configured limits", func(t *testing.T) {
+		r := DefaultRules()
+		r.MaxTags = 2
+		r.MaxTagLength = 5
+		if err := r.ValidateTags([]string{"ui", "api"}); err != nil {
+			t.Errorf("ValidateTags: %v", err)
+		}
+		if err := r.ValidateTags([]string{"ui", "api", "db"}); err == nil {
+			t.Error("expected error for 3 tags with MaxTags 2")
+		}
+		if err := r.ValidateTag("backend"); err == nil {
+			t.Error("expected error for a 7-char tag with MaxTagLength 5")
+		}
+	})
+}
+
+func TestValidateIDPrefix(t *testing.T) {
+	for _, prefix := range []string{"tick-", "api-", "t2-"} {
+		if err := ValidateIDPrefix(prefix); err != nil {
+			t.Errorf("ValidateIDPrefix(%q): %v", prefix, err)
+		}
+	}
+	for _, prefix := range []string{"", "tick", "-", "2a-", "Tick-", "a_b-", "tick--"} {
+		if err := ValidateIDPrefix(prefix); err == nil {
+			t.Errorf("ValidateIDPrefix(%q) should fail", prefix)
+		}
+	}
+}
+
+func TestMatchesIDInput(t *testing.T) {
+	tests := []struct {
+		id, input string
+		want      bool
+	}{
+		{"tick-a1b2c3", "a1b", true},
+		{"api-a1b2c3", "a1b", true},
+		{"tick-a1b2c3", "TICK-A1B", true},
+		{"api-a1b2c3", "tick-a1b", false},
+		{"tick-a1b2c3", "b2c", false},
+	}
+	for _, tt := range tests {
+		prefix, hex := SplitIDInput(tt.input)
+		if got := MatchesIDInput(tt.id, prefix, hex); got != tt.want {
+			t.Errorf("MatchesIDInput(%q, %q) = %v, want %v", tt.id, tt.input, got, tt.want)
+		}
+	}
+}
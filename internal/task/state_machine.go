@@ -6,71 +6,87 @@ import (
 	"time"
 )
 
-// StateMachine consolidates task status transition logic.
-// It is stateless — no fields, no constructor needed — serving as a method grouping.
-type StateMachine struct{}
-
-// transitionRule defines a valid status transition: the set of valid source statuses and the target.
-type transitionRule struct {
-	from []Status
-	to   Status
+// StateMachine consolidates task status transition logic over a workflow. The
+// zero value uses DefaultWorkflow, so no constructor is needed.
+type StateMachine struct {
+	// Workflow holds the states and commands transitions follow. Left empty, it
+	// is DefaultWorkflow.
+	Workflow Workflow
 }
 
-// transitions maps each command to its transition rule.
-var transitions = map[string]transitionRule{
-	"start":  {from: []Status{StatusOpen}, to: StatusInProgress},
-	"done":   {from: []Status{StatusOpen, StatusInProgress}, to: StatusDone},
-	"cancel": {from: []Status{StatusOpen, StatusInProgress}, to: StatusCancelled},
-	"reopen": {from: []Status{StatusDone, StatusCancelled}, to: StatusOpen},
+// workflow returns the workflow the state machine follows.
+func (sm StateMachine) workflow() Workflow {
+	if len(sm.Workflow.States) == 0 {
+		return DefaultWorkflow()
+	}
+	return sm.Workflow
+}
+
+// IsCompleted reports whether s closes a task as finished in the state
+// machine's workflow; see Workflow.IsCompleted.
+func (sm StateMachine) IsCompleted(s Status) bool {
+	return sm.workflow().IsCompleted(s)
 }
 
-// Transition applies a status transition to the given task by action name.
-// Valid actions: "start", "done", "cancel", "reopen".
+// Transition applies a status transition to the given task by action name:
+// one of the workflow's commands, such as "start", "done", "cancel", "reopen"
+// or "release".
 //
-// On success, the task's Status, Updated, and Closed fields are mutated in place,
-// and a TransitionResult is returned with the old and new status.
+// On success, the task's Status, Updated, and Closed fields are mutated in place
+// (a lease is cleared when the task leaves the started states), and a TransitionResult
+// is returned with the old and new status.
 //
 // On failure (unknown action or invalid transition), the task is not modified
 // and an error is returned.
 func (sm StateMachine) Transition(t *Task, action string) (TransitionResult, error) {
-	rule, ok := transitions[action]
+	cmd, ok := sm.workflow().Command(action)
 	if !ok {
 		return TransitionResult{}, fmt.Errorf("unknown command %q", action)
 	}
 
-	if !statusIn(t.Status, rule.from) {
+	if !statusIn(t.Status, cmd.From) {
 		return TransitionResult{}, fmt.Errorf(
 			"cannot %s task %s — status is '%s'",
 			action, t.ID, t.Status,
 		)
 	}
 
+	return sm.moveTo(t, cmd.To), nil
+}
+
+// moveTo sets the status of t to status without checking that a command allows
+// it. Entering a terminal state sets Closed and any other state clears it; a
+// lease is cleared when the task leaves in_progress.
+func (sm StateMachine) moveTo(t *Task, status Status) TransitionResult {
 	oldStatus := t.Status
 	now := time.Now().UTC().Truncate(time.Second)
 
-	t.Status = rule.to
+	t.Status = status
 	t.Updated = now
 
-	switch rule.to {
-	case StatusDone, StatusCancelled:
+	if sm.workflow().IsTerminal(status) {
 		t.Closed = &now
-	case StatusOpen:
+	} else {
 		// reopen clears closed
 		t.Closed = nil
 	}
+	if !sm.workflow().IsStarted(status) {
+		t.LeaseExpires = nil
+	}
 
 	return TransitionResult{
 		OldStatus: oldStatus,
-		NewStatus: rule.to,
-	}, nil
+		NewStatus: status,
+	}
 }
 
 // ValidateAddChild checks whether a child task can be added to the given parent.
-// It returns an error if the parent is cancelled (Rule 7); nil otherwise.
+// It returns an error if the parent is cancelled, or in another state that closes
+// it without finishing it (Rule 7; see Workflow.IsCancelled); nil otherwise.
 // Note: the done-parent reopen (Rule 6) is the caller's responsibility.
 func (sm StateMachine) ValidateAddChild(parent *Task) error {
-	if parent.Status == StatusCancelled {
-		return fmt.Errorf("cannot add child to cancelled task, reopen it first")
+	if sm.workflow().IsCancelled(parent.Status) {
+		return fmt.Errorf("cannot add child to %s task, reopen it first", parent.Status)
 	}
 	return nil
 }
@@ -86,8 +102,8 @@ func (sm StateMachine) ValidateAddDep(tasks []Task, taskID, blockerID string) er
 	// Rule 8: reject dependency on a cancelled blocker.
 	for i := range tasks {
 		if NormalizeID(tasks[i].ID) == blockerID {
-			if tasks[i].Status == StatusCancelled {
-				return fmt.Errorf("cannot add dependency on cancelled task, reopen it first")
+			if sm.workflow().IsCancelled(tasks[i].Status) {
+				return fmt.Errorf("cannot add dependency on %s task, reopen it first", tasks[i].Status)
 			}
 			break
 		}
@@ -63,6 +63,13 @@ func TestStateMachine_Transition_ValidTransitions(t *testing.T) {
 			toStatus:   StatusOpen,
 			closed:     closedTime(),
 		},
+		{
+			name:       "it transitions in_progress to open via release",
+			command:    "release",
+			fromStatus: StatusInProgress,
+			toStatus:   StatusOpen,
+			closed:     nil,
+		},
 	}
 
 	var sm StateMachine
@@ -94,6 +101,28 @@ func TestStateMachine_Transition_ValidTransitions(t *testing.T) {
 	}
 }
 
+func TestStateMachine_Transition_ClearsLease(t *testing.T) {
+	var sm StateMachine
+	for _, command := range []string{"done", "cancel", "release"} {
+		t.Run("it clears the lease on "+command, func(t *testing.T) {
+			task := makeTask(StatusInProgress, nil)
+			expires := time.Now().UTC().Add(time.Hour)
+			task.Assignee = "agent-1"
+			task.LeaseExpires = &expires
+
+			if _, err := sm.Transition(task, command); err != nil {
+				t.Fatalf("Transition returned unexpected error: %v", err)
+			}
+			if task.LeaseExpires != nil {
+				t.Errorf("LeaseExpires = %v, want nil", task.LeaseExpires)
+			}
+			if task.Assignee != "agent-1" {
+				t.Errorf("Assignee = %q, want it kept", task.Assignee)
+			}
+		})
+	}
+}
+
 func TestStateMachine_Transition_InvalidTransitions(t *testing.T) {
 	tests := []struct {
 		name       string
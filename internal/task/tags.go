@@ -1,7 +1,6 @@
 package task
 
 import (
-	"fmt"
 	"regexp"
 	"strings"
 )
@@ -22,16 +21,7 @@ func NormalizeTag(tag string) string {
 
 // ValidateTag checks that a single tag is non-empty, matches kebab-case, and is at most 30 chars.
 func ValidateTag(tag string) error {
-	if tag == "" {
-		return fmt.Errorf("tag cannot be empty")
-	}
-	if len(tag) > maxTagLength {
-		return fmt.Errorf("tag %q exceeds maximum length of %d characters", tag, maxTagLength)
-	}
-	if !tagPattern.MatchString(tag) {
-		return fmt.Errorf("tag %q must be kebab-case (lowercase alphanumeric segments separated by single hyphens)", tag)
-	}
-	return nil
+	return DefaultRules().ValidateTag(tag)
 }
 
 // DeduplicateTags normalizes tags, filters empties, and returns unique tags in first-occurrence order.
@@ -41,21 +31,5 @@ func DeduplicateTags(tags []string) []string {
 
 // ValidateTags normalizes, filters empties, deduplicates, validates each tag, and checks count <= 10.
 func ValidateTags(tags []string) error {
-	if len(tags) == 0 {
-		return nil
-	}
-
-	deduped := DeduplicateTags(tags)
-
-	for _, tag := range deduped {
-		if err := ValidateTag(tag); err != nil {
-			return err
-		}
-	}
-
-	if len(deduped) > maxTagsPerTask {
-		return fmt.Errorf("too many tags: %d exceeds maximum of %d per task", len(deduped), maxTagsPerTask)
-	}
-
-	return nil
+	return DefaultRules().ValidateTags(tags)
 }
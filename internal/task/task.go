@@ -2,12 +2,9 @@
 package task
 
 import (
-	"crypto/rand"
-	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"slices"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -57,57 +54,116 @@ type Task struct {
 	Created     time.Time          `json:"-"`
 	Updated     time.Time          `json:"-"`
 	Closed      *time.Time         `json:"-"`
+	// Due is the task's deadline; a task still open past it is overdue.
+	Due *time.Time `json:"-"`
+	// DeferUntil keeps the task out of the ready list until it passes.
+	DeferUntil *time.Time `json:"-"`
+	// Estimate is the expected effort in points or hours; zero means unestimated.
+	Estimate float64 `json:"estimate,omitempty"`
+	// Recur is the task's recurrence rule in canonical form (see
+	// ParseRecurrence); completing the task creates its next occurrence.
+	Recur string `json:"recur,omitempty"`
+	// RecurredFrom is the ID of the completed occurrence this task was
+	// created from.
+	RecurredFrom string `json:"recurred_from,omitempty"`
+	// Assignee is who owns the task: set with --assignee, or the agent that
+	// claimed it with tick claim.
+	Assignee string `json:"assignee,omitempty"`
+	// LeaseExpires is when the assignee's claim lapses; a started task whose
+	// lease has expired is released back to open by the next change.
+	LeaseExpires *time.Time `json:"-"`
+	// BlockedReason says why a task in a blocked list is blocked, such as
+	// deferred or blocked_by (see query.BlockedReason). It is set only by
+	// blocked lists and never stored.
+	BlockedReason string `json:"-"`
+	// Fields holds custom key/value metadata, such as sprint or pr_url.
+	Fields map[string]string `json:"fields,omitempty"`
+	// Extra holds top-level fields tick does not recognize (written by a newer
+	// version or another tool), keyed by name with their raw JSON values. They
+	// are written back unchanged so no command drops them.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // taskJSON is the JSON serialization form with string timestamps and string status.
 type taskJSON struct {
-	ID          string             `json:"id"`
-	Title       string             `json:"title"`
-	Status      string             `json:"status"`
-	Priority    int                `json:"priority"`
-	Type        string             `json:"type,omitempty"`
-	Tags        []string           `json:"tags,omitempty"`
-	Refs        []string           `json:"refs,omitempty"`
-	Description string             `json:"description,omitempty"`
-	Notes       []Note             `json:"notes,omitempty"`
-	Transitions []TransitionRecord `json:"transitions,omitempty"`
-	BlockedBy   []string           `json:"blocked_by,omitempty"`
-	Parent      string             `json:"parent,omitempty"`
-	Created     string             `json:"created"`
-	Updated     string             `json:"updated"`
-	Closed      string             `json:"closed,omitempty"`
+	ID           string             `json:"id"`
+	Title        string             `json:"title"`
+	Status       string             `json:"status"`
+	Priority     int                `json:"priority"`
+	Type         string             `json:"type,omitempty"`
+	Tags         []string           `json:"tags,omitempty"`
+	Refs         []string           `json:"refs,omitempty"`
+	Fields       map[string]string  `json:"fields,omitempty"`
+	Description  string             `json:"description,omitempty"`
+	Notes        []Note             `json:"notes,omitempty"`
+	Transitions  []TransitionRecord `json:"transitions,omitempty"`
+	BlockedBy    []string           `json:"blocked_by,omitempty"`
+	Parent       string             `json:"parent,omitempty"`
+	Due          string             `json:"due,omitempty"`
+	DeferUntil   string             `json:"defer_until,omitempty"`
+	Estimate     float64            `json:"estimate,omitempty"`
+	Recur        string             `json:"recur,omitempty"`
+	RecurredFrom string             `json:"recurred_from,omitempty"`
+	Assignee     string             `json:"assignee,omitempty"`
+	LeaseExpires string             `json:"lease_expires,omitempty"`
+	Created      string             `json:"created"`
+	Updated      string             `json:"updated"`
+	Closed       string             `json:"closed,omitempty"`
 }
 
 // MarshalJSON serializes a Task with timestamps formatted as ISO 8601 strings.
 func (t Task) MarshalJSON() ([]byte, error) {
 	jt := taskJSON{
-		ID:          t.ID,
-		Title:       t.Title,
-		Status:      string(t.Status),
-		Priority:    t.Priority,
-		Type:        t.Type,
-		Tags:        t.Tags,
-		Refs:        t.Refs,
-		Description: t.Description,
-		Notes:       t.Notes,
-		Transitions: t.Transitions,
-		BlockedBy:   t.BlockedBy,
-		Parent:      t.Parent,
-		Created:     FormatTimestamp(t.Created),
-		Updated:     FormatTimestamp(t.Updated),
+		ID:           t.ID,
+		Title:        t.Title,
+		Status:       string(t.Status),
+		Priority:     t.Priority,
+		Type:         t.Type,
+		Tags:         t.Tags,
+		Refs:         t.Refs,
+		Fields:       t.Fields,
+		Description:  t.Description,
+		Notes:        t.Notes,
+		Transitions:  t.Transitions,
+		BlockedBy:    t.BlockedBy,
+		Parent:       t.Parent,
+		Estimate:     t.Estimate,
+		Recur:        t.Recur,
+		RecurredFrom: t.RecurredFrom,
+		Assignee:     t.Assignee,
+		Created:      FormatTimestamp(t.Created),
+		Updated:      FormatTimestamp(t.Updated),
+	}
+	if t.Due != nil {
+		jt.Due = FormatTimestamp(*t.Due)
+	}
+	if t.DeferUntil != nil {
+		jt.DeferUntil = FormatTimestamp(*t.DeferUntil)
+	}
+	if t.LeaseExpires != nil {
+		jt.LeaseExpires = FormatTimestamp(*t.LeaseExpires)
 	}
 	if t.Closed != nil {
 		jt.Closed = FormatTimestamp(*t.Closed)
 	}
-	return json.Marshal(jt)
+	data, err := json.Marshal(jt)
+	if err != nil {
+		return nil, err
+	}
+	return appendExtra(data, t.Extra)
 }
 
 // UnmarshalJSON deserializes a Task, parsing ISO 8601 timestamp strings.
+// Unrecognized top-level fields are kept in Extra.
 func (t *Task) UnmarshalJSON(data []byte) error {
 	var jt taskJSON
 	if err := json.Unmarshal(data, &jt); err != nil {
 		return err
 	}
+	extra, err := extraFields(data)
+	if err != nil {
+		return err
+	}
 
 	created, err := time.Parse(TimestampFormat, jt.Created)
 	if err != nil {
@@ -125,13 +181,19 @@ func (t *Task) UnmarshalJSON(data []byte) error {
 	t.Type = jt.Type
 	t.Tags = jt.Tags
 	t.Refs = jt.Refs
+	t.Fields = jt.Fields
 	t.Description = jt.Description
 	t.Notes = jt.Notes
 	t.Transitions = jt.Transitions
 	t.BlockedBy = jt.BlockedBy
 	t.Parent = jt.Parent
+	t.Estimate = jt.Estimate
+	t.Recur = jt.Recur
+	t.RecurredFrom = jt.RecurredFrom
+	t.Assignee = jt.Assignee
 	t.Created = created
 	t.Updated = updated
+	t.Extra = extra
 
 	if jt.Closed != "" {
 		closed, err := time.Parse(TimestampFormat, jt.Closed)
@@ -141,26 +203,37 @@ func (t *Task) UnmarshalJSON(data []byte) error {
 		t.Closed = &closed
 	}
 
-	return nil
-}
-
-// GenerateID creates a new task ID in the format tick-{6 hex chars} using crypto/rand.
-// The exists function is called to check for collisions; up to 5 retries are attempted.
-func GenerateID(exists func(id string) bool) (string, error) {
-	for range maxIDRetries {
-		b := make([]byte, idByteLength)
-		if _, err := rand.Read(b); err != nil {
-			return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	if jt.Due != "" {
+		due, err := time.Parse(TimestampFormat, jt.Due)
+		if err != nil {
+			return fmt.Errorf("invalid due timestamp %q: %w", jt.Due, err)
 		}
+		t.Due = &due
+	}
 
-		id := idPrefix + hex.EncodeToString(b)
+	if jt.DeferUntil != "" {
+		until, err := time.Parse(TimestampFormat, jt.DeferUntil)
+		if err != nil {
+			return fmt.Errorf("invalid defer_until timestamp %q: %w", jt.DeferUntil, err)
+		}
+		t.DeferUntil = &until
+	}
 
-		if !exists(id) {
-			return id, nil
+	if jt.LeaseExpires != "" {
+		expires, err := time.Parse(TimestampFormat, jt.LeaseExpires)
+		if err != nil {
+			return fmt.Errorf("invalid lease_expires timestamp %q: %w", jt.LeaseExpires, err)
 		}
+		t.LeaseExpires = &expires
 	}
 
-	return "", errors.New("failed to generate unique ID after 5 attempts - task list may be too large")
+	return nil
+}
+
+// GenerateID creates a new task ID in the format tick-{6 hex chars} using crypto/rand.
+// The exists function is called to check for collisions; up to 5 retries are attempted.
+func GenerateID(exists func(id string) bool) (string, error) {
+	return DefaultRules().GenerateID(exists)
 }
 
 // NormalizeID converts a task ID to lowercase for case-insensitive matching.
@@ -237,13 +310,7 @@ var allowedTypes = []string{"bug", "feature", "task", "chore"}
 
 // ValidateType checks that typ is one of the allowed task types or empty (optional).
 func ValidateType(typ string) error {
-	if typ == "" {
-		return nil
-	}
-	if slices.Contains(allowedTypes, typ) {
-		return nil
-	}
-	return fmt.Errorf("invalid type %q: must be one of bug, feature, task, chore", typ)
+	return DefaultRules().ValidateType(typ)
 }
 
 // NormalizeType trims whitespace and lowercases a type string.
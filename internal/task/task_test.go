@@ -435,6 +435,43 @@ func TestTaskMarshalJSON(t *testing.T) {
 		}
 	})
 
+	t.Run("it round-trips assignee and lease expiry", func(t *testing.T) {
+		created := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+		expires := time.Date(2026, 1, 19, 10, 30, 0, 0, time.UTC)
+		original := Task{
+			ID:           "tick-c3d4e5",
+			Title:        "Claimed",
+			Status:       StatusInProgress,
+			Priority:     2,
+			Assignee:     "agent-1",
+			LeaseExpires: &expires,
+			Created:      created,
+			Updated:      created,
+		}
+
+		data, err := json.Marshal(original)
+		if err != nil {
+			t.Fatalf("Marshal error: %v", err)
+		}
+		if !strings.Contains(string(data), `"assignee":"agent-1","lease_expires":"2026-01-19T10:30:00Z"`) {
+			t.Errorf("JSON should contain assignee and lease_expires, got: %s", data)
+		}
+
+		var got Task
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal error: %v", err)
+		}
+		if got.Assignee != "agent-1" {
+			t.Errorf("Assignee = %q, want %q", got.Assignee, "agent-1")
+		}
+		if got.LeaseExpires == nil || !got.LeaseExpires.Equal(expires) {
+			t.Errorf("LeaseExpires = %v, want %v", got.LeaseExpires, expires)
+		}
+		if got.Extra != nil {
+			t.Errorf("Extra = %v, want nil", got.Extra)
+		}
+	})
+
 	t.Run("it produces correct timestamp format in JSON output", func(t *testing.T) {
 		created := time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
 		t1 := Task{
@@ -477,7 +514,7 @@ func TestTaskMarshalJSON(t *testing.T) {
 		}
 
 		s := string(data)
-		for _, field := range []string{"description", "blocked_by", "parent", "closed", "type"} {
+		for _, field := range []string{"description", "blocked_by", "parent", "closed", "type", "assignee", "lease_expires"} {
 			if strings.Contains(s, `"`+field+`"`) {
 				t.Errorf("optional field %q should be omitted, got: %s", field, s)
 			}
@@ -557,6 +594,49 @@ func TestTaskMarshalJSON(t *testing.T) {
 			t.Errorf("Type = %q, want empty string for backward compat", tk.Type)
 		}
 	})
+
+	t.Run("it preserves unrecognized fields through a round trip", func(t *testing.T) {
+		jsonStr := `{"id":"tick-a1b2c3","title":"Newer","status":"open","priority":2,"created":"2026-01-19T10:00:00Z","updated":"2026-01-19T10:00:00Z","sprint":12,"owner": {"team": "api"}}`
+		var tk Task
+		if err := json.Unmarshal([]byte(jsonStr), &tk); err != nil {
+			t.Fatalf("Unmarshal error: %v", err)
+		}
+		if len(tk.Extra) != 2 || string(tk.Extra["sprint"]) != "12" {
+			t.Errorf("Extra = %v, want owner and sprint", tk.Extra)
+		}
+
+		tk.Title = "Edited"
+		data, err := json.Marshal(tk)
+		if err != nil {
+			t.Fatalf("Marshal error: %v", err)
+		}
+		want := `{"id":"tick-a1b2c3","title":"Edited","status":"open","priority":2,"created":"2026-01-19T10:00:00Z","updated":"2026-01-19T10:00:00Z","owner":{"team":"api"},"sprint":12}`
+		if string(data) != want {
+			t.Errorf("Marshal = %s, want %s", data, want)
+		}
+	})
+
+	t.Run("it leaves Extra nil and ignores extra fields that shadow known fields", func(t *testing.T) {
+		var tk Task
+		if err := json.Unmarshal([]byte(`{"id":"tick-a1b2c3","title":"Plain","status":"open","priority":2,"created":"2026-01-19T10:00:00Z","updated":"2026-01-19T10:00:00Z"}`), &tk); err != nil {
+			t.Fatalf("Unmarshal error: %v", err)
+		}
+		if tk.Extra != nil {
+			t.Errorf("Extra = %v, want nil", tk.Extra)
+		}
+
+		tk.Extra = map[string]json.RawMessage{"title": json.RawMessage(`"Shadow"`)}
+		data, err := json.Marshal(tk)
+		if err != nil {
+			t.Fatalf("Marshal error: %v", err)
+		}
+		if strings.Count(string(data), `"title"`) != 1 {
+			t.Errorf("Marshal = %s, want a single title", data)
+		}
+		if !IsKnownField("blocked_by") || IsKnownField("sprint") {
+			t.Error("IsKnownField misclassified blocked_by or sprint")
+		}
+	})
 }
 
 func TestValidateType(t *testing.T) {
@@ -10,7 +10,7 @@ type TransitionResult struct {
 }
 
 // Transition applies a status transition to the given task by command name.
-// Valid commands: "start", "done", "cancel", "reopen".
+// Valid commands: "start", "done", "cancel", "reopen", "release".
 //
 // On success, the task's Status, Updated, and Closed fields are mutated in place,
 // and a TransitionResult is returned with the old and new status.
@@ -0,0 +1,162 @@
+package task
+
+import "slices"
+
+// Category groups workflow states by how tick treats the tasks in them.
+type Category string
+
+const (
+	// CategoryActive states are live and workable: their tasks can be ready.
+	CategoryActive Category = "active"
+	// CategoryWaiting states are live but on hold: their tasks are never ready
+	// and count as blocked.
+	CategoryWaiting Category = "waiting"
+	// CategoryTerminal states close a task.
+	CategoryTerminal Category = "terminal"
+)
+
+// State is a status a task can have in a workflow.
+type State struct {
+	Name     Status
+	Category Category
+}
+
+// Command is a named transition, such as "start", that moves a task in one of
+// the From states to the To state.
+type Command struct {
+	Name string
+	From []Status
+	To   Status
+}
+
+// Workflow is the set of states and transition commands of a project. The
+// built-in statuses and commands are always part of it; a project can add its
+// own in .tick/config.yaml.
+type Workflow struct {
+	// States are listed open and in_progress first, then any project states,
+	// then done and cancelled.
+	States   []State
+	Commands []Command
+}
+
+// DefaultWorkflow returns the workflow of a project without configuration:
+// open and in_progress are active, done and cancelled terminal.
+func DefaultWorkflow() Workflow {
+	return Workflow{
+		States: []State{
+			{Name: StatusOpen, Category: CategoryActive},
+			{Name: StatusInProgress, Category: CategoryActive},
+			{Name: StatusDone, Category: CategoryTerminal},
+			{Name: StatusCancelled, Category: CategoryTerminal},
+		},
+		Commands: []Command{
+			{Name: "start", From: []Status{StatusOpen}, To: StatusInProgress},
+			{Name: "done", From: []Status{StatusOpen, StatusInProgress}, To: StatusDone},
+			{Name: "cancel", From: []Status{StatusOpen, StatusInProgress}, To: StatusCancelled},
+			{Name: "reopen", From: []Status{StatusDone, StatusCancelled}, To: StatusOpen},
+			// release returns a claimed task whose lease expired to the ready pool.
+			{Name: "release", From: []Status{StatusInProgress}, To: StatusOpen},
+		},
+	}
+}
+
+// IsBuiltinStatus reports whether s is one of the four statuses every workflow
+// has.
+func IsBuiltinStatus(s Status) bool {
+	return slices.Contains([]Status{StatusOpen, StatusInProgress, StatusDone, StatusCancelled}, s)
+}
+
+// State returns the state named s.
+func (w Workflow) State(s Status) (State, bool) {
+	i := slices.IndexFunc(w.States, func(st State) bool { return st.Name == s })
+	if i < 0 {
+		return State{}, false
+	}
+	return w.States[i], true
+}
+
+// Command returns the command named name.
+func (w Workflow) Command(name string) (Command, bool) {
+	i := slices.IndexFunc(w.Commands, func(c Command) bool { return c.Name == name })
+	if i < 0 {
+		return Command{}, false
+	}
+	return w.Commands[i], true
+}
+
+// IsTerminal reports whether s is a terminal state. Statuses the workflow does
+// not define are not terminal.
+func (w Workflow) IsTerminal(s Status) bool {
+	st, ok := w.State(s)
+	return ok && st.Category == CategoryTerminal
+}
+
+// IsCompleted reports whether s closes a task as finished: any terminal state
+// but cancelled. A parent whose children are all closed is completed if any of
+// them is.
+func (w Workflow) IsCompleted(s Status) bool {
+	return s != StatusCancelled && w.IsTerminal(s)
+}
+
+// IsCancelled reports whether s closes a task without finishing it: a terminal
+// state IsCompleted does not count. Closed tasks in such a state take no new
+// children or dependents until reopened.
+func (w Workflow) IsCancelled(s Status) bool {
+	return w.IsTerminal(s) && !w.IsCompleted(s)
+}
+
+// StartedStates returns the active states other than open, the state tasks are
+// created in: the states of work under way, in workflow order.
+func (w Workflow) StartedStates() []Status {
+	return slices.DeleteFunc(w.StatesIn(CategoryActive), func(s Status) bool { return s == StatusOpen })
+}
+
+// IsStarted reports whether s is one of the StartedStates.
+func (w Workflow) IsStarted(s Status) bool {
+	return slices.Contains(w.StartedStates(), s)
+}
+
+// commandInto returns the first command that moves tasks to s.
+func (w Workflow) commandInto(s Status) (Command, bool) {
+	i := slices.IndexFunc(w.Commands, func(c Command) bool { return c.To == s })
+	if i < 0 {
+		return Command{}, false
+	}
+	return w.Commands[i], true
+}
+
+// StatesIn returns the names of the states in any of the given categories, in
+// workflow order.
+func (w Workflow) StatesIn(categories ...Category) []Status {
+	var names []Status
+	for _, st := range w.States {
+		if slices.Contains(categories, st.Category) {
+			names = append(names, st.Name)
+		}
+	}
+	return names
+}
+
+// StateNames returns the names of all states, in workflow order.
+func (w Workflow) StateNames() []string {
+	names := make([]string, len(w.States))
+	for i, st := range w.States {
+		names[i] = string(st.Name)
+	}
+	return names
+}
+
+// reopens reports whether cmd takes closed tasks back to a live state, as
+// reopen does. Such commands reopen completed ancestors and are refused under a
+// cancelled parent.
+func (w Workflow) reopens(cmd Command) bool {
+	if w.IsTerminal(cmd.To) {
+		return false
+	}
+	for _, from := range cmd.From {
+		if !w.IsTerminal(from) {
+			return false
+		}
+	}
+	return true
+}
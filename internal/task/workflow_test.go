@@ -0,0 +1,206 @@
+package task
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// reviewWorkflow returns the built-in workflow with an in_review step, a
+// blocked_external holding state and a wont_fix terminal state.
+func reviewWorkflow() Workflow {
+	wf := DefaultWorkflow()
+	wf.States = []State{
+		{Name: StatusOpen, Category: CategoryActive},
+		{Name: StatusInProgress, Category: CategoryActive},
+		{Name: "in_review", Category: CategoryActive},
+		{Name: "blocked_external", Category: CategoryWaiting},
+		{Name: "wont_fix", Category: CategoryTerminal},
+		{Name: StatusDone, Category: CategoryTerminal},
+		{Name: StatusCancelled, Category: CategoryTerminal},
+	}
+	wf.Commands = append(wf.Commands,
+		Command{Name: "review", From: []Status{StatusInProgress}, To: "in_review"},
+		Command{Name: "wait", From: []Status{StatusOpen, StatusInProgress}, To: "blocked_external"},
+		Command{Name: "wont-fix", From: []Status{StatusOpen, StatusInProgress, "in_review"}, To: "wont_fix"},
+		Command{Name: "revive", From: []Status{"wont_fix"}, To: StatusOpen},
+	)
+	return wf
+}
+
+func TestWorkflow(t *testing.T) {
+	wf := reviewWorkflow()
+
+	t.Run("it groups states by category in workflow order", func(t *testing.T) {
+		if got := wf.StatesIn(CategoryActive, CategoryWaiting); len(got) != 4 || got[2] != "in_review" || got[3] != "blocked_external" {
+			t.Errorf("StatesIn(active, waiting) = %v", got)
+		}
+		if !wf.IsTerminal("wont_fix") || wf.IsTerminal("in_review") || wf.IsTerminal("unknown") {
+			t.Error("IsTerminal should hold for wont_fix only")
+		}
+		if !wf.IsCompleted("wont_fix") || wf.IsCompleted(StatusCancelled) {
+			t.Error("IsCompleted should hold for wont_fix but not cancelled")
+		}
+	})
+
+	t.Run("it derives started and cancelled states from the categories", func(t *testing.T) {
+		if got := wf.StartedStates(); len(got) != 2 || got[0] != StatusInProgress || got[1] != "in_review" {
+			t.Errorf("StartedStates = %v, want in_progress, in_review", got)
+		}
+		if wf.IsStarted(StatusOpen) || wf.IsStarted("blocked_external") || !wf.IsStarted("in_review") {
+			t.Error("IsStarted should hold for active states other than open")
+		}
+		if !wf.IsCancelled(StatusCancelled) || wf.IsCancelled("wont_fix") || wf.IsCancelled(StatusOpen) {
+			t.Error("IsCancelled should hold for terminal states that are not completed")
+		}
+	})
+
+	t.Run("it treats a command from terminal to live states as a reopen", func(t *testing.T) {
+		revive, _ := wf.Command("revive")
+		review, _ := wf.Command("review")
+		if !wf.reopens(revive) || wf.reopens(review) {
+			t.Error("reopens should hold for revive only")
+		}
+	})
+}
+
+func TestStateMachine_Workflow(t *testing.T) {
+	sm := StateMachine{Workflow: reviewWorkflow()}
+	now := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	makeTask := func(id string, status Status, parent string) Task {
+		return Task{ID: id, Title: "Task " + id, Status: status, Parent: parent, Created: now, Updated: now}
+	}
+
+	t.Run("it moves a task with a project command", func(t *testing.T) {
+		tk := makeTask("tick-aaa111", StatusInProgress, "")
+		tk.LeaseExpires = &now
+
+		result, err := sm.Transition(&tk, "review")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.OldStatus != StatusInProgress || result.NewStatus != "in_review" {
+			t.Errorf("result = %+v", result)
+		}
+		if tk.Closed != nil || tk.LeaseExpires == nil {
+			t.Errorf("Closed = %v, LeaseExpires = %v; want the lease kept in a started state", tk.Closed, tk.LeaseExpires)
+		}
+
+		if _, err := sm.Transition(&tk, "wont-fix"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tk.LeaseExpires != nil {
+			t.Errorf("LeaseExpires = %v; want it cleared outside the started states", tk.LeaseExpires)
+		}
+	})
+
+	t.Run("it refuses a command from a state it does not list", func(t *testing.T) {
+		tk := makeTask("tick-aaa111", StatusOpen, "")
+
+		_, err := sm.Transition(&tk, "review")
+		if err == nil || !strings.Contains(err.Error(), "cannot review task tick-aaa111 — status is 'open'") {
+			t.Errorf("err = %v", err)
+		}
+		if _, err := (StateMachine{}).Transition(&tk, "review"); err == nil || !strings.Contains(err.Error(), `unknown command "review"`) {
+			t.Errorf("default workflow err = %v", err)
+		}
+	})
+
+	t.Run("it closes a task entering a project terminal state and cascades it to children", func(t *testing.T) {
+		tasks := []Task{
+			makeTask("tick-parent", StatusOpen, ""),
+			makeTask("tick-child1", "in_review", "tick-parent"),
+			makeTask("tick-child2", StatusDone, "tick-parent"),
+		}
+
+		result, cascades, err := sm.ApplyUserTransition(tasks, &tasks[0], "wont-fix")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.NewStatus != "wont_fix" || tasks[0].Closed == nil {
+			t.Errorf("parent = %+v, want wont_fix and closed", tasks[0])
+		}
+		if len(cascades) != 1 || cascades[0].Task.ID != "tick-child1" || cascades[0].NewStatus != "wont_fix" {
+			t.Fatalf("cascades = %+v, want tick-child1 to wont_fix", cascades)
+		}
+		if tasks[1].Closed == nil {
+			t.Error("cascaded child should be closed")
+		}
+		assertTransition(t, tasks[1], 0, "in_review", "wont_fix", true)
+		if tasks[2].Status != StatusDone {
+			t.Errorf("done child status = %q, want it untouched", tasks[2].Status)
+		}
+	})
+
+	t.Run("it completes the parent when the last child enters a project terminal state", func(t *testing.T) {
+		tasks := []Task{
+			makeTask("tick-parent", "in_review", ""),
+			makeTask("tick-child1", StatusOpen, "tick-parent"),
+			makeTask("tick-child2", StatusCancelled, "tick-parent"),
+		}
+
+		_, cascades, err := sm.ApplyUserTransition(tasks, &tasks[1], "wont-fix")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(cascades) != 1 || tasks[0].Status != StatusDone {
+			t.Errorf("cascades = %+v, parent status = %q; want the parent done", cascades, tasks[0].Status)
+		}
+	})
+
+	t.Run("it reopens completed ancestors on a project reopen command", func(t *testing.T) {
+		tasks := []Task{
+			makeTask("tick-parent", "wont_fix", ""),
+			makeTask("tick-child1", "wont_fix", "tick-parent"),
+		}
+
+		_, cascades, err := sm.ApplyUserTransition(tasks, &tasks[1], "revive")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(cascades) != 1 || tasks[0].Status != StatusOpen || tasks[0].Closed != nil {
+			t.Errorf("cascades = %+v, parent = %+v; want the parent reopened", cascades, tasks[0])
+		}
+	})
+
+	t.Run("it starts open ancestors but skips waiting ones", func(t *testing.T) {
+		tasks := []Task{
+			makeTask("tick-grand", StatusOpen, ""),
+			makeTask("tick-parent", "blocked_external", "tick-grand"),
+			makeTask("tick-child1", StatusOpen, "tick-parent"),
+		}
+
+		_, cascades, err := sm.ApplyUserTransition(tasks, &tasks[2], "start")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(cascades) != 1 || cascades[0].Task.ID != "tick-grand" {
+			t.Errorf("cascades = %+v, want only tick-grand started", cascades)
+		}
+		if tasks[1].Status != "blocked_external" {
+			t.Errorf("waiting parent status = %q, want it untouched", tasks[1].Status)
+		}
+	})
+
+	t.Run("it applies system transitions whatever the command's from-states", func(t *testing.T) {
+		narrowed := reviewWorkflow()
+		for i := range narrowed.Commands {
+			if narrowed.Commands[i].Name == "done" {
+				narrowed.Commands[i].From = []Status{"in_review"}
+			}
+		}
+		sm := StateMachine{Workflow: narrowed}
+		tasks := []Task{makeTask("tick-aaa111", StatusOpen, "")}
+
+		if _, _, err := sm.ApplyUserTransition(tasks, &tasks[0], "done"); err == nil {
+			t.Fatal("user done from open should fail under the narrowed workflow")
+		}
+		if _, _, err := sm.ApplySystemTransition(tasks, &tasks[0], "done"); err != nil {
+			t.Fatalf("system done: %v", err)
+		}
+		if tasks[0].Status != StatusDone {
+			t.Errorf("status = %q, want done", tasks[0].Status)
+		}
+	})
+}
@@ -0,0 +1,268 @@
+// Package template loads task templates from .tick/templates/. A template
+// describes a tree of tasks, the dependencies between them and the variables
+// their titles, descriptions and tags take, and expands into the tasks that
+// instantiate it in creation order.
+package template
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Dir is the directory under .tick/ that holds the templates, one
+// <name>.yaml file each.
+const Dir = "templates"
+
+// Template is a parsed template file.
+type Template struct {
+	// Name is the template's file name without the .yaml extension.
+	Name string `yaml:"-"`
+	// Description says what the template is for.
+	Description string `yaml:"description"`
+	// Vars declares the variables the tasks use as {{name}}.
+	Vars map[string]Var `yaml:"vars"`
+	// Tasks are the top-level tasks of the tree.
+	Tasks []Node `yaml:"tasks"`
+}
+
+// Var declares a template variable. A variable without a default must be
+// given a value when the template is instantiated.
+type Var struct {
+	Description string  `yaml:"description"`
+	Default     *string `yaml:"default"`
+}
+
+// Node is a task in a template. Key names it within the template, for the
+// blocked_by lists of other nodes. Title, Description and Tags may use
+// {{name}} variables.
+type Node struct {
+	Key         string   `yaml:"key"`
+	Title       string   `yaml:"title"`
+	Description string   `yaml:"description"`
+	Type        string   `yaml:"type"`
+	Priority    *int     `yaml:"priority"`
+	Tags        []string `yaml:"tags"`
+	Estimate    float64  `yaml:"estimate"`
+	BlockedBy   []string `yaml:"blocked_by"`
+	Children    []Node   `yaml:"children"`
+}
+
+// Task is a node expanded with the variable values, ready to create. Parent
+// is the key of its parent node, empty for a top-level task.
+type Task struct {
+	Key         string
+	Parent      string
+	Title       string
+	Description string
+	Type        string
+	Priority    *int
+	Tags        []string
+	Estimate    float64
+	BlockedBy   []string
+}
+
+// namePattern matches template names, node keys and variable names.
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_-]*$`)
+
+// varPattern matches a {{name}} variable reference, allowing spaces inside
+// the braces.
+var varPattern = regexp.MustCompile(`\{\{\s*([^{}\s]*)\s*\}\}`)
+
+// Load reads and validates the template name from the templates directory of
+// the project in tickDir.
+func Load(tickDir, name string) (Template, error) {
+	if !namePattern.MatchString(name) {
+		return Template{}, fmt.Errorf("invalid template name '%s'", name)
+	}
+	path := filepath.Join(tickDir, Dir, name+".yaml")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Template{}, notFoundError(tickDir, name)
+		}
+		return Template{}, fmt.Errorf("failed to read .tick/%s/%s.yaml: %w", Dir, name, err)
+	}
+
+	t := Template{Name: name}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&t); err != nil && !errors.Is(err, io.EOF) {
+		return Template{}, fmt.Errorf("invalid .tick/%s/%s.yaml: %w", Dir, name, err)
+	}
+	if err := t.Validate(); err != nil {
+		return Template{}, fmt.Errorf("invalid .tick/%s/%s.yaml: %w", Dir, name, err)
+	}
+	return t, nil
+}
+
+// notFoundError reports a missing template, listing the ones the project has.
+func notFoundError(tickDir, name string) error {
+	names, err := Names(tickDir)
+	if err != nil || len(names) == 0 {
+		return fmt.Errorf("template '%s' not found: add .tick/%s/%s.yaml", name, Dir, name)
+	}
+	return fmt.Errorf("template '%s' not found (available: %s)", name, strings.Join(names, ", "))
+}
+
+// Names returns the names of the project's templates, sorted. A project
+// without a templates directory has none.
+func Names(tickDir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(tickDir, Dir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read .tick/%s: %w", Dir, err)
+	}
+	var names []string
+	for _, e := range entries {
+		if name, ok := strings.CutSuffix(e.Name(), ".yaml"); ok && !e.IsDir() && namePattern.MatchString(name) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// Validate checks that the template has tasks, that every node has a unique
+// key and a title, that blocked_by lists name other nodes, and that only
+// declared variables are used.
+func (t Template) Validate() error {
+	if len(t.Tasks) == 0 {
+		return errors.New("tasks must list at least one task")
+	}
+	for name := range t.Vars {
+		if !namePattern.MatchString(name) {
+			return fmt.Errorf("vars: invalid variable name '%s'", name)
+		}
+	}
+
+	keys := map[string]bool{}
+	var nodes []Node
+	var collect func(ns []Node) error
+	collect = func(ns []Node) error {
+		for _, n := range ns {
+			if n.Key == "" {
+				return fmt.Errorf("task titled '%s' has no key", n.Title)
+			}
+			if !namePattern.MatchString(n.Key) {
+				return fmt.Errorf("invalid key '%s': use letters, digits, hyphens and underscores", n.Key)
+			}
+			if keys[n.Key] {
+				return fmt.Errorf("key '%s' is used by more than one task", n.Key)
+			}
+			keys[n.Key] = true
+			nodes = append(nodes, n)
+			if err := collect(n.Children); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := collect(t.Tasks); err != nil {
+		return err
+	}
+
+	for _, n := range nodes {
+		if strings.TrimSpace(n.Title) == "" {
+			return fmt.Errorf("task '%s': title is required", n.Key)
+		}
+		for _, b := range n.BlockedBy {
+			if b == n.Key {
+				return fmt.Errorf("task '%s': cannot be blocked by itself", n.Key)
+			}
+			if !keys[b] {
+				return fmt.Errorf("task '%s': blocked_by '%s' is not a task in the template", n.Key, b)
+			}
+		}
+		for _, text := range append([]string{n.Title, n.Description}, n.Tags...) {
+			for _, m := range varPattern.FindAllStringSubmatch(text, -1) {
+				if _, ok := t.Vars[m[1]]; !ok {
+					return fmt.Errorf("task '%s': variable '%s' is not declared in vars", n.Key, m[1])
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Expand fills in the variables from values and the declared defaults, and
+// returns the tasks in the order they can be created: every task after its
+// parent and its blockers, otherwise in file order. Every variable without a
+// default needs a value, and values must name declared variables.
+func (t Template) Expand(values map[string]string) ([]Task, error) {
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		if _, ok := t.Vars[name]; !ok {
+			return nil, fmt.Errorf("template '%s' has no variable '%s'", t.Name, name)
+		}
+	}
+	vars := map[string]string{}
+	for _, name := range slices.Sorted(maps.Keys(t.Vars)) {
+		v, ok := values[name]
+		switch {
+		case ok:
+			vars[name] = v
+		case t.Vars[name].Default != nil:
+			vars[name] = *t.Vars[name].Default
+		default:
+			return nil, fmt.Errorf("template '%s' needs a value for variable '%s'", t.Name, name)
+		}
+	}
+	expand := func(s string) string {
+		return varPattern.ReplaceAllStringFunc(s, func(ref string) string {
+			return vars[varPattern.FindStringSubmatch(ref)[1]]
+		})
+	}
+
+	var pending []Task
+	var flatten func(ns []Node, parent string)
+	flatten = func(ns []Node, parent string) {
+		for _, n := range ns {
+			tk := Task{
+				Key:         n.Key,
+				Parent:      parent,
+				Title:       expand(n.Title),
+				Description: expand(n.Description),
+				Type:        n.Type,
+				Priority:    n.Priority,
+				Estimate:    n.Estimate,
+				BlockedBy:   slices.Clone(n.BlockedBy),
+			}
+			for _, tag := range n.Tags {
+				tk.Tags = append(tk.Tags, expand(tag))
+			}
+			pending = append(pending, tk)
+			flatten(n.Children, n.Key)
+		}
+	}
+	flatten(t.Tasks, "")
+
+	// Repeatedly take the first task whose parent and blockers are placed.
+	ordered := make([]Task, 0, len(pending))
+	placed := map[string]bool{}
+	for len(pending) > 0 {
+		i := slices.IndexFunc(pending, func(tk Task) bool {
+			return (tk.Parent == "" || placed[tk.Parent]) && !slices.ContainsFunc(tk.BlockedBy, func(b string) bool { return !placed[b] })
+		})
+		if i < 0 {
+			keys := make([]string, len(pending))
+			for j, tk := range pending {
+				keys[j] = tk.Key
+			}
+			return nil, fmt.Errorf("blocked_by forms a cycle: cannot order tasks %s", strings.Join(keys, ", "))
+		}
+		placed[pending[i].Key] = true
+		ordered = append(ordered, pending[i])
+		pending = slices.Delete(pending, i, i+1)
+	}
+	return ordered, nil
+}
@@ -0,0 +1,164 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func writeTemplateFile(t *testing.T, tickDir, name, content string) {
+	t.Helper()
+	dir := filepath.Join(tickDir, Dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", Dir, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".yaml"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s.yaml: %v", name, err)
+	}
+}
+
+const endpointTemplate = `description: A new API endpoint
+vars:
+  name:
+    description: Endpoint name
+  method:
+    default: POST
+tasks:
+  - key: endpoint
+    title: "Endpoint: {{method}} /{{ name }}"
+    type: feature
+    tags: [api, "{{name}}"]
+    children:
+      - key: implement
+        title: Implement {{name}}
+        blocked_by: [design]
+        estimate: 3
+      - key: design
+        title: Design {{name}}
+        priority: 1
+      - key: docs
+        title: Document {{name}}
+        blocked_by: [implement]
+`
+
+func TestLoad(t *testing.T) {
+	t.Run("it loads a template tree", func(t *testing.T) {
+		tickDir := t.TempDir()
+		writeTemplateFile(t, tickDir, "endpoint", endpointTemplate)
+
+		tmpl, err := Load(tickDir, "endpoint")
+		if err != nil {
+			t.Fatalf("Load returned error: %v", err)
+		}
+		if tmpl.Name != "endpoint" || tmpl.Description != "A new API endpoint" || len(tmpl.Tasks) != 1 || len(tmpl.Tasks[0].Children) != 3 {
+			t.Errorf("template = %+v", tmpl)
+		}
+		if d := tmpl.Vars["method"].Default; d == nil || *d != "POST" {
+			t.Errorf("method default = %v, want POST", d)
+		}
+	})
+
+	t.Run("it lists the available templates when one is missing", func(t *testing.T) {
+		tickDir := t.TempDir()
+		if _, err := Load(tickDir, "endpoint"); err == nil || !strings.Contains(err.Error(), "add .tick/templates/endpoint.yaml") {
+			t.Errorf("Load error = %v, want a hint to add the file", err)
+		}
+
+		writeTemplateFile(t, tickDir, "release", "tasks: [{key: a, title: A}]")
+		writeTemplateFile(t, tickDir, "bugfix", "tasks: [{key: a, title: A}]")
+		if _, err := Load(tickDir, "endpoint"); err == nil || !strings.Contains(err.Error(), "(available: bugfix, release)") {
+			t.Errorf("Load error = %v, want the available templates", err)
+		}
+	})
+
+	t.Run("it rejects invalid names and templates", func(t *testing.T) {
+		tickDir := t.TempDir()
+		if _, err := Load(tickDir, "../config"); err == nil || !strings.Contains(err.Error(), "invalid template name") {
+			t.Errorf("Load error = %v, want invalid template name", err)
+		}
+
+		for content, want := range map[string]string{
+			"description: empty":                                              "at least one task",
+			"tasks: [{key: a, title: A, owner: me}]":                          "field owner not found",
+			"tasks: [{title: A}]":                                             "task titled 'A' has no key",
+			"tasks: [{key: a, title: A, children: [{key: a, title: B}]}]":     "key 'a' is used by more than one task",
+			"tasks: [{key: a}]":                                               "task 'a': title is required",
+			"tasks: [{key: a, title: A, blocked_by: [b]}]":                    "blocked_by 'b' is not a task in the template",
+			"tasks: [{key: a, title: A, blocked_by: [a]}]":                    "cannot be blocked by itself",
+			"tasks: [{key: a, title: '{{name}}'}]":                            "variable 'name' is not declared",
+			"vars: {name: {}}\ntasks: [{key: a, title: A, tags: ['{{id}}']}]": "variable 'id' is not declared",
+		} {
+			writeTemplateFile(t, tickDir, "bad", content)
+			_, err := Load(tickDir, "bad")
+			if err == nil || !strings.Contains(err.Error(), "invalid .tick/templates/bad.yaml") || !strings.Contains(err.Error(), want) {
+				t.Errorf("Load(%q) error = %v, want %q", content, err, want)
+			}
+		}
+	})
+}
+
+func TestExpand(t *testing.T) {
+	load := func(t *testing.T, content string) Template {
+		t.Helper()
+		tickDir := t.TempDir()
+		writeTemplateFile(t, tickDir, "endpoint", content)
+		tmpl, err := Load(tickDir, "endpoint")
+		if err != nil {
+			t.Fatalf("Load returned error: %v", err)
+		}
+		return tmpl
+	}
+
+	t.Run("it fills in variables and orders tasks after their parent and blockers", func(t *testing.T) {
+		tasks, err := load(t, endpointTemplate).Expand(map[string]string{"name": "login"})
+		if err != nil {
+			t.Fatalf("Expand returned error: %v", err)
+		}
+		var keys []string
+		for _, tk := range tasks {
+			keys = append(keys, tk.Key)
+		}
+		if !slices.Equal(keys, []string{"endpoint", "design", "implement", "docs"}) {
+			t.Fatalf("order = %v, want endpoint, design, implement, docs", keys)
+		}
+		root, implement := tasks[0], tasks[2]
+		if root.Title != "Endpoint: POST /login" || !slices.Equal(root.Tags, []string{"api", "login"}) || root.Type != "feature" || root.Parent != "" {
+			t.Errorf("root = %+v", root)
+		}
+		if implement.Title != "Implement login" || implement.Parent != "endpoint" || !slices.Equal(implement.BlockedBy, []string{"design"}) || implement.Estimate != 3 {
+			t.Errorf("implement = %+v", implement)
+		}
+		if p := tasks[1].Priority; p == nil || *p != 1 {
+			t.Errorf("design priority = %v, want 1", p)
+		}
+	})
+
+	t.Run("it lets values override defaults", func(t *testing.T) {
+		tasks, err := load(t, endpointTemplate).Expand(map[string]string{"name": "users", "method": "GET"})
+		if err != nil {
+			t.Fatalf("Expand returned error: %v", err)
+		}
+		if tasks[0].Title != "Endpoint: GET /users" {
+			t.Errorf("title = %q, want %q", tasks[0].Title, "Endpoint: GET /users")
+		}
+	})
+
+	t.Run("it rejects missing and unknown variables", func(t *testing.T) {
+		tmpl := load(t, endpointTemplate)
+		if _, err := tmpl.Expand(nil); err == nil || !strings.Contains(err.Error(), "needs a value for variable 'name'") {
+			t.Errorf("Expand error = %v, want missing name", err)
+		}
+		if _, err := tmpl.Expand(map[string]string{"name": "login", "verb": "GET"}); err == nil || !strings.Contains(err.Error(), "has no variable 'verb'") {
+			t.Errorf("Expand error = %v, want unknown verb", err)
+		}
+	})
+
+	t.Run("it rejects a dependency cycle", func(t *testing.T) {
+		tmpl := load(t, "tasks:\n  - {key: a, title: A, blocked_by: [b]}\n  - {key: b, title: B, blocked_by: [a]}\n  - {key: c, title: C}\n")
+		if _, err := tmpl.Expand(nil); err == nil || !strings.Contains(err.Error(), "cycle: cannot order tasks a, b") {
+			t.Errorf("Expand error = %v, want a cycle between a and b", err)
+		}
+	})
+}
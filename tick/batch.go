@@ -0,0 +1,345 @@
+package tick
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/leeovery/tick/internal/config"
+	"github.com/leeovery/tick/internal/task"
+)
+
+// BatchKind is the kind of change a BatchOp makes.
+type BatchKind string
+
+// Batch operation kinds, named after the tick commands they mirror.
+const (
+	BatchCreate     BatchKind = "create"
+	BatchUpdate     BatchKind = "update"
+	BatchTransition BatchKind = "transition"
+	BatchAddDep     BatchKind = "dep add"
+	BatchAddNote    BatchKind = "note add"
+)
+
+// BatchOp is one change in a Batch. Only the fields of its Kind are used.
+//
+// Wherever an op takes a task ID — ID, BlockedBy, and the Parent, BlockedBy and
+// Blocks options — it also accepts "$name" to refer to the task created by an
+// earlier create op with Ref "name".
+type BatchOp struct {
+	Kind BatchKind
+	// Ref names the task a create op makes, for "$Ref" references in later ops.
+	Ref string
+	// ID is the task an update, transition, dep add or note add applies to.
+	ID string
+	// Create describes the task a create op makes.
+	Create CreateOptions
+	// Update describes the changes an update op makes.
+	Update UpdateOptions
+	// Action is the transition action: start, done, cancel or reopen.
+	Action string
+	// BlockedBy is the blocker a dep add records.
+	BlockedBy string
+	// Text is the note a note add appends.
+	Text string
+}
+
+// BatchResult holds the outcome of a Batch.
+type BatchResult struct {
+	// Ops holds one result per op, in order.
+	Ops []BatchOpResult
+	// IDs maps each create op's Ref to the ID of the task it made.
+	IDs map[string]string
+}
+
+// BatchOpResult holds the outcome of one BatchOp.
+type BatchOpResult struct {
+	Kind BatchKind
+	// Ref is the op's Ref, for create ops that set one.
+	Ref string
+	// TaskID is the task the op created or changed.
+	TaskID string
+	// Transition is the status change of a transition op and its cascades.
+	Transition *CascadeResult
+	// ParentReopened and ParentCompleted are the parent status changes of a
+	// create or update op, as in MutationResult.
+	ParentReopened  *CascadeResult
+	ParentCompleted *CascadeResult
+}
+
+// batchRefPrefix marks a reference to a task created earlier in the batch.
+const batchRefPrefix = "$"
+
+// preparedOp is a BatchOp whose own fields are validated and normalized. Task
+// references are resolved when it is applied.
+type preparedOp struct {
+	op     BatchOp
+	create createSpec
+	update UpdateOptions
+	text   string
+}
+
+// Batch validates ops and applies them in order as a single change: one lock,
+// one write and one journal entry, so `tick undo` reverts the whole batch. If
+// any op fails, nothing is written and the error names the op.
+func (p *Project) Batch(ops []BatchOp) (BatchResult, error) {
+	return p.batch(ops, batchOpError)
+}
+
+// batch is Batch with opError wrapping the error of a failing op, so callers
+// can name ops their own way.
+func (p *Project) batch(ops []BatchOp, opError func(i int, op BatchOp, err error) error) (BatchResult, error) {
+	if len(ops) == 0 {
+		return BatchResult{}, errors.New("batch has no operations")
+	}
+
+	cfg := p.store.Config()
+	prepared := make([]preparedOp, len(ops))
+	defined := map[string]bool{}
+	for i, op := range ops {
+		pop, err := prepareBatchOp(op, defined, cfg)
+		if err != nil {
+			return BatchResult{}, opError(i, op, err)
+		}
+		prepared[i] = pop
+		if op.Ref != "" {
+			defined[op.Ref] = true
+		}
+	}
+
+	var result BatchResult
+
+	err := p.store.Mutate(func(tasks []task.Task) ([]task.Task, error) {
+		result = BatchResult{Ops: make([]BatchOpResult, len(prepared)), IDs: map[string]string{}}
+		for i, pop := range prepared {
+			var opResult BatchOpResult
+			var err error
+			tasks, opResult, err = applyBatchOp(tasks, pop, result.IDs, cfg.Rules())
+			if err != nil {
+				return nil, opError(i, pop.op, err)
+			}
+			result.Ops[i] = opResult
+		}
+		return tasks, nil
+	})
+	if err != nil {
+		return BatchResult{}, err
+	}
+	return result, nil
+}
+
+// batchOpError prefixes err with the 1-based position and kind of the op.
+func batchOpError(i int, op BatchOp, err error) error {
+	if op.Kind == "" {
+		return fmt.Errorf("op %d: %w", i+1, err)
+	}
+	return fmt.Errorf("op %d (%s): %w", i+1, op.Kind, err)
+}
+
+// prepareBatchOp validates the fields of op that do not depend on the tasks,
+// including that its "$name" references name tasks created by earlier ops.
+func prepareBatchOp(op BatchOp, defined map[string]bool, cfg config.Config) (preparedOp, error) {
+	pop := preparedOp{op: op}
+
+	var refs []string
+	var err error
+	switch op.Kind {
+	case BatchCreate:
+		if op.Ref != "" {
+			if strings.HasPrefix(op.Ref, batchRefPrefix) || strings.ContainsAny(op.Ref, " \t\n") {
+				return pop, fmt.Errorf("invalid ref %q: use a name without spaces or a leading %s", op.Ref, batchRefPrefix)
+			}
+			if defined[op.Ref] {
+				return pop, fmt.Errorf("ref %q is already defined by an earlier op", op.Ref)
+			}
+		}
+		pop.create, err = prepareCreate(op.Create, cfg)
+		refs = append(refs, op.Create.Parent)
+		refs = append(refs, op.Create.BlockedBy...)
+		refs = append(refs, op.Create.Blocks...)
+	case BatchUpdate:
+		pop.update, err = prepareUpdate(op.Update, cfg.Rules())
+		refs = append(refs, op.ID)
+		if op.Update.Parent != nil {
+			refs = append(refs, *op.Update.Parent)
+		}
+		refs = append(refs, op.Update.Blocks...)
+	case BatchTransition:
+		if op.Action == "" {
+			err = errors.New("action is required")
+		}
+		refs = append(refs, op.ID)
+	case BatchAddDep:
+		if op.BlockedBy == "" {
+			err = errors.New("blocked_by is required")
+		}
+		refs = append(refs, op.ID, op.BlockedBy)
+	case BatchAddNote:
+		pop.text = task.TrimNoteText(op.Text)
+		err = task.ValidateNoteText(pop.text)
+		refs = append(refs, op.ID)
+	case "":
+		return pop, errors.New("op is required")
+	default:
+		return pop, fmt.Errorf("unknown op %q (use create, update, transition, dep add or note add)", op.Kind)
+	}
+	if err != nil {
+		return pop, err
+	}
+
+	if op.Kind != BatchCreate && op.Ref != "" {
+		return pop, errors.New("ref is only allowed on create")
+	}
+	if op.Kind != BatchCreate && op.ID == "" {
+		return pop, errors.New("id is required")
+	}
+	for _, ref := range refs {
+		name, ok := strings.CutPrefix(ref, batchRefPrefix)
+		if ok && !defined[name] {
+			return pop, fmt.Errorf("%s%s does not refer to a task created by an earlier op", batchRefPrefix, name)
+		}
+	}
+	return pop, nil
+}
+
+// applyBatchOp resolves the task references of pop against tasks and the IDs
+// created so far, and applies it under rules. A create records its ID in ids
+// under its Ref.
+func applyBatchOp(tasks []task.Task, pop preparedOp, ids map[string]string, rules task.Rules) ([]task.Task, BatchOpResult, error) {
+	op := pop.op
+	sm := task.StateMachine{Workflow: rules.Workflow}
+	opResult := BatchOpResult{Kind: op.Kind, Ref: op.Ref}
+	resolve := func(ref string) (string, error) {
+		if name, ok := strings.CutPrefix(ref, batchRefPrefix); ok {
+			return ids[name], nil
+		}
+		return resolveIDIn(tasks, ref, rules.IDPrefix)
+	}
+	resolveAll := func(refs []string) ([]string, error) {
+		if len(refs) == 0 {
+			return nil, nil
+		}
+		resolved := make([]string, len(refs))
+		for i, ref := range refs {
+			var err error
+			if resolved[i], err = resolve(ref); err != nil {
+				return nil, err
+			}
+		}
+		return resolved, nil
+	}
+
+	if op.Kind == BatchCreate {
+		parent := ""
+		if op.Create.Parent != "" {
+			var err error
+			if parent, err = resolve(op.Create.Parent); err != nil {
+				return nil, opResult, err
+			}
+		}
+		blockedBy, err := resolveAll(op.Create.BlockedBy)
+		if err != nil {
+			return nil, opResult, err
+		}
+		blocks, err := resolveAll(op.Create.Blocks)
+		if err != nil {
+			return nil, opResult, err
+		}
+		var created MutationResult
+		tasks, created, err = applyCreate(tasks, pop.create, parent, blockedBy, blocks)
+		if err != nil {
+			return nil, opResult, err
+		}
+		if op.Ref != "" {
+			ids[op.Ref] = created.Task.ID
+		}
+		opResult.TaskID = created.Task.ID
+		opResult.ParentReopened = created.ParentReopened
+		return tasks, opResult, nil
+	}
+
+	id, err := resolve(op.ID)
+	if err != nil {
+		return nil, opResult, err
+	}
+	opResult.TaskID = id
+
+	switch op.Kind {
+	case BatchUpdate:
+		opts := pop.update
+		if opts.Parent != nil && *opts.Parent != "" {
+			parent, err := resolve(*opts.Parent)
+			if err != nil {
+				return nil, opResult, err
+			}
+			opts.Parent = &parent
+		}
+		blocks, err := resolveAll(opts.Blocks)
+		if err != nil {
+			return nil, opResult, err
+		}
+		updated, err := applyUpdate(tasks, id, opts, blocks, sm)
+		if err != nil {
+			return nil, opResult, err
+		}
+		if updated.ParentCompleted != nil {
+			if tasks, err = spawnOccurrences(tasks, updated.ParentCompleted, rules); err != nil {
+				return nil, opResult, err
+			}
+		}
+		opResult.ParentReopened = updated.ParentReopened
+		opResult.ParentCompleted = updated.ParentCompleted
+	case BatchTransition:
+		var cr CascadeResult
+		if tasks, cr, err = applyTransition(tasks, id, op.Action, rules); err != nil {
+			return nil, opResult, err
+		}
+		opResult.Transition = &cr
+	case BatchAddDep:
+		blockedBy, err := resolve(op.BlockedBy)
+		if err != nil {
+			return nil, opResult, err
+		}
+		if err := applyAddDep(tasks, id, blockedBy); err != nil {
+			return nil, opResult, err
+		}
+	case BatchAddNote:
+		if err := applyAddNote(tasks, id, pop.text); err != nil {
+			return nil, opResult, err
+		}
+	}
+	return tasks, opResult, nil
+}
+
+// resolveIDIn resolves a full or partial task ID against tasks, with the same
+// rules and errors as Project.ResolveID, so that batch ops can refer to tasks
+// created earlier in the same batch. A bare ID is tried with idPrefix first.
+func resolveIDIn(tasks []task.Task, input, idPrefix string) (string, error) {
+	prefix, hex := task.SplitIDInput(input)
+	if len(hex) < 3 {
+		return "", errors.New("partial ID must be at least 3 hex characters")
+	}
+
+	fullID := cmp.Or(prefix, idPrefix) + hex
+	var matches []string
+	for _, t := range tasks {
+		if t.ID == fullID {
+			return t.ID, nil
+		}
+		if task.MatchesIDInput(t.ID, prefix, hex) {
+			matches = append(matches, t.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("task '%s' not found", input)
+	case 1:
+		return matches[0], nil
+	default:
+		slices.Sort(matches)
+		return "", fmt.Errorf("ambiguous ID '%s' matches: %s", input, strings.Join(matches, ", "))
+	}
+}
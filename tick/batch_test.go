@@ -0,0 +1,123 @@
+package tick
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	t.Run("it applies ops in order with references to tasks created earlier", func(t *testing.T) {
+		p := openProject(t)
+		existing := mustCreate(t, p, CreateOptions{Title: "Existing"})
+
+		result, err := p.Batch([]BatchOp{
+			{Kind: BatchCreate, Ref: "epic", Create: CreateOptions{Title: "Epic"}},
+			{Kind: BatchCreate, Ref: "db", Create: CreateOptions{Title: "Schema", Parent: "$epic"}},
+			{Kind: BatchCreate, Ref: "api", Create: CreateOptions{Title: "API", Parent: "$epic", BlockedBy: []string{"$db"}}},
+			{Kind: BatchAddDep, ID: "$api", BlockedBy: existing.ID[:8]},
+			{Kind: BatchUpdate, ID: "$db", Update: UpdateOptions{Priority: new(0)}},
+			{Kind: BatchAddNote, ID: "$db", Text: "Start here"},
+			{Kind: BatchTransition, ID: "$db", Action: "start"},
+		})
+		if err != nil {
+			t.Fatalf("Batch returned error: %v", err)
+		}
+
+		if len(result.Ops) != 7 || len(result.IDs) != 3 {
+			t.Fatalf("result = %+v, want 7 ops and 3 IDs", result)
+		}
+		epic, db, api := result.IDs["epic"], result.IDs["db"], result.IDs["api"]
+		if result.Ops[1].TaskID != db || result.Ops[6].TaskID != db {
+			t.Errorf("op task IDs = %+v", result.Ops)
+		}
+
+		tr := result.Ops[6].Transition
+		if tr == nil || tr.NewStatus != "in_progress" || len(tr.Cascaded) != 1 || tr.Cascaded[0].ID != epic {
+			t.Errorf("transition = %+v, want start cascading to the epic", tr)
+		}
+
+		detail, err := p.Show(api)
+		if err != nil {
+			t.Fatalf("Show returned error: %v", err)
+		}
+		if detail.Task.Parent != epic || len(detail.BlockedBy) != 2 {
+			t.Errorf("api = %+v", detail.Task)
+		}
+		detail, _ = p.Show(db)
+		if detail.Task.Priority != 0 || len(detail.Notes) != 1 || detail.Task.Status != StatusInProgress {
+			t.Errorf("db = %+v", detail.Task)
+		}
+	})
+
+	t.Run("it writes nothing when any op fails", func(t *testing.T) {
+		p := openProject(t)
+		existing := mustCreate(t, p, CreateOptions{Title: "Existing"})
+
+		_, err := p.Batch([]BatchOp{
+			{Kind: BatchCreate, Ref: "a", Create: CreateOptions{Title: "A"}},
+			{Kind: BatchAddDep, ID: existing.ID, BlockedBy: "$a"},
+			{Kind: BatchAddDep, ID: "$a", BlockedBy: existing.ID},
+		})
+		if err == nil || !strings.HasPrefix(err.Error(), "op 3 (dep add): ") {
+			t.Fatalf("error = %v, want op 3 cycle error", err)
+		}
+
+		tasks, err := p.List(Filter{})
+		if err != nil {
+			t.Fatalf("List returned error: %v", err)
+		}
+		if len(tasks) != 1 || len(tasks[0].BlockedBy) != 0 {
+			t.Errorf("tasks = %+v, want only the untouched existing task", tasks)
+		}
+	})
+
+	t.Run("it validates every op before applying any", func(t *testing.T) {
+		p := openProject(t)
+
+		tests := []struct {
+			name string
+			ops  []BatchOp
+			want string
+		}{
+			{"no ops", nil, "batch has no operations"},
+			{"unknown kind", []BatchOp{{Kind: "delete", ID: "abc"}}, `op 1 (delete): unknown op "delete"`},
+			{"invalid field", []BatchOp{{Kind: BatchCreate, Create: CreateOptions{Title: "A"}}, {Kind: BatchCreate, Create: CreateOptions{Title: "B", Priority: new(9)}}}, "op 2 (create): "},
+			{"undefined reference", []BatchOp{{Kind: BatchTransition, ID: "$later", Action: "start"}, {Kind: BatchCreate, Ref: "later", Create: CreateOptions{Title: "Later"}}}, "op 1 (transition): $later does not refer to a task created by an earlier op"},
+			{"duplicate ref", []BatchOp{{Kind: BatchCreate, Ref: "a", Create: CreateOptions{Title: "A"}}, {Kind: BatchCreate, Ref: "a", Create: CreateOptions{Title: "B"}}}, `op 2 (create): ref "a" is already defined`},
+			{"missing id", []BatchOp{{Kind: BatchAddNote, Text: "Hi"}}, "op 1 (note add): id is required"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := p.Batch(tt.ops)
+				if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+					t.Errorf("error = %v, want prefix %q", err, tt.want)
+				}
+			})
+		}
+
+		tasks, _ := p.List(Filter{})
+		if len(tasks) != 0 {
+			t.Errorf("tasks = %+v, want none", tasks)
+		}
+	})
+}
+
+func TestResolveIDIn(t *testing.T) {
+	tasks := []Task{{ID: "tick-abc123"}, {ID: "tick-abc456"}, {ID: "tick-def789"}}
+
+	tests := []struct {
+		input, want, err string
+	}{
+		{"tick-abc123", "tick-abc123", ""},
+		{"DEF", "tick-def789", ""},
+		{"abc", "", "ambiguous ID 'abc' matches: tick-abc123, tick-abc456"},
+		{"fff", "", "task 'fff' not found"},
+		{"ab", "", "partial ID must be at least 3 hex characters"},
+	}
+	for _, tt := range tests {
+		got, err := resolveIDIn(tasks, tt.input, "tick-")
+		if got != tt.want || (err == nil) != (tt.err == "") || (err != nil && err.Error() != tt.err) {
+			t.Errorf("resolveIDIn(%q) = %q, %v; want %q, %q", tt.input, got, err, tt.want, tt.err)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package tick
+
+import (
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// CascadeEntry holds a single cascaded status change.
+type CascadeEntry struct {
+	ID       string
+	Title    string
+	ParentID string
+	// OldStatus and NewStatus are the task's status before and after the change.
+	OldStatus string
+	NewStatus string
+}
+
+// CascadeResult holds a status transition and the changes it cascaded to
+// other tasks.
+type CascadeResult struct {
+	TaskID    string
+	TaskTitle string
+	OldStatus string
+	NewStatus string
+	Cascaded  []CascadeEntry
+	// Recurred holds the next occurrences created for the recurring tasks the
+	// change completed, whether the task itself or one it cascaded to, in the
+	// order they were completed.
+	Recurred []Task
+}
+
+// buildCascadeResult constructs a CascadeResult from the primary transition, cascade
+// changes, and the full task list. It populates ParentID on each cascade entry from the
+// task's Parent field.
+func buildCascadeResult(id, title string, result task.TransitionResult, cascades []task.CascadeChange, tasks []task.Task) CascadeResult {
+	cr := CascadeResult{
+		TaskID:    id,
+		TaskTitle: title,
+		OldStatus: string(result.OldStatus),
+		NewStatus: string(result.NewStatus),
+	}
+
+	// Detect upward cascade: if any cascaded task is the primary task's parent,
+	// the cascade went upward (child start triggers parent/grandparent start).
+	// Find the primary task's parent.
+	var primaryParent string
+	for i := range tasks {
+		if task.NormalizeID(tasks[i].ID) == task.NormalizeID(id) {
+			primaryParent = tasks[i].Parent
+			break
+		}
+	}
+	isUpward := false
+	for _, c := range cascades {
+		if task.NormalizeID(c.Task.ID) == task.NormalizeID(primaryParent) {
+			isUpward = true
+			break
+		}
+	}
+
+	for _, c := range cascades {
+		parentID := c.Task.Parent
+		if isUpward {
+			// Upward cascades render flat: all entries are roots relative to the primary task.
+			parentID = id
+		}
+		cr.Cascaded = append(cr.Cascaded, CascadeEntry{
+			ID:        c.Task.ID,
+			Title:     c.Task.Title,
+			ParentID:  parentID,
+			OldStatus: string(c.OldStatus),
+			NewStatus: string(c.NewStatus),
+		})
+	}
+
+	return cr
+}
+
+// reopenParent validates that parentID can take a new child and reopens it when
+// it is done, building the cascade result while the tasks slice is valid. Returns
+// nil when the parent was not reopened.
+func reopenParent(tasks []task.Task, parentID string, sm *task.StateMachine) (*CascadeResult, error) {
+	r, c, reopened, err := validateAndReopenParent(tasks, parentID, sm)
+	if err != nil || !reopened {
+		return nil, err
+	}
+	normalizedParent := task.NormalizeID(parentID)
+	var parentTitle string
+	for _, tk := range tasks {
+		if task.NormalizeID(tk.ID) == normalizedParent {
+			parentID = tk.ID
+			parentTitle = tk.Title
+			break
+		}
+	}
+	cr := buildCascadeResult(parentID, parentTitle, r, c, tasks)
+	return &cr, nil
+}
+
+// validateAndReopenParent finds the parent task in tasks by parentID, validates that
+// a child can be added (Rule 7: blocks cancelled parent), and if the parent is
+// completed (done or another terminal state but cancelled), triggers a reopen
+// cascade (Rule 6). Returns the transition result, cascade changes,
+// whether a reopen occurred, and any error.
+func validateAndReopenParent(tasks []task.Task, parentID string, sm *task.StateMachine) (task.TransitionResult, []task.CascadeChange, bool, error) {
+	normalizedParent := task.NormalizeID(parentID)
+	for i := range tasks {
+		if task.NormalizeID(tasks[i].ID) != normalizedParent {
+			continue
+		}
+		if err := sm.ValidateAddChild(&tasks[i]); err != nil {
+			return task.TransitionResult{}, nil, false, err
+		}
+		if sm.IsCompleted(tasks[i].Status) {
+			r, c, err := sm.ApplySystemTransition(tasks, &tasks[i], "reopen")
+			if err != nil {
+				return task.TransitionResult{}, nil, false, err
+			}
+			return r, c, true, nil
+		}
+		return task.TransitionResult{}, nil, false, nil
+	}
+	return task.TransitionResult{}, nil, false, nil
+}
+
+// rule3Result holds the output of a Rule 3 evaluation for cascade display.
+type rule3Result struct {
+	parentID    string
+	parentTitle string
+	result      task.TransitionResult
+	cascades    []task.CascadeChange
+}
+
+// autoCompleteParentIfTerminal checks if the original parent's remaining children are all
+// terminal after a child was reparented away. If so, it triggers auto-completion via
+// ApplySystemTransition: done if at least one child is done, cancelled if all children
+// are cancelled. Returns nil if auto-completion does not apply.
+func autoCompleteParentIfTerminal(tasks []task.Task, origParentID string, sm *task.StateMachine) *rule3Result {
+	action, shouldComplete := sm.EvaluateParentCompletion(tasks, origParentID)
+	if !shouldComplete {
+		return nil
+	}
+
+	// Find the parent to apply the transition.
+	normalizedParentID := task.NormalizeID(origParentID)
+	parentIdx := -1
+	for i := range tasks {
+		if task.NormalizeID(tasks[i].ID) == normalizedParentID {
+			parentIdx = i
+			break
+		}
+	}
+	if parentIdx < 0 {
+		return nil
+	}
+
+	oldStatus := tasks[parentIdx].Status
+	_, cascades, err := sm.ApplySystemTransition(tasks, &tasks[parentIdx], action)
+	if err != nil {
+		return nil
+	}
+
+	return &rule3Result{
+		parentID:    tasks[parentIdx].ID,
+		parentTitle: tasks[parentIdx].Title,
+		result: task.TransitionResult{
+			OldStatus: oldStatus,
+			NewStatus: tasks[parentIdx].Status,
+		},
+		cascades: cascades,
+	}
+}
+
+// applyBlocks iterates tasks and for each task whose ID appears in blockIDs,
+// appends sourceID to its BlockedBy slice and sets its Updated timestamp.
+// Skips the append if sourceID is already present in BlockedBy.
+func applyBlocks(tasks []task.Task, sourceID string, blockIDs []string, now time.Time) {
+	for i := range tasks {
+		for _, blockID := range blockIDs {
+			if task.NormalizeID(tasks[i].ID) == task.NormalizeID(blockID) {
+				alreadyPresent := false
+				for _, dep := range tasks[i].BlockedBy {
+					if task.NormalizeID(dep) == task.NormalizeID(sourceID) {
+						alreadyPresent = true
+						break
+					}
+				}
+				if !alreadyPresent {
+					tasks[i].BlockedBy = append(tasks[i].BlockedBy, sourceID)
+					tasks[i].Updated = now
+				}
+			}
+		}
+	}
+}
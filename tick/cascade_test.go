@@ -0,0 +1,312 @@
+package tick
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+func TestBuildCascadeResult(t *testing.T) {
+	now := time.Now()
+
+	t.Run("it populates ParentID on cascade entries", func(t *testing.T) {
+		parent := task.Task{ID: "tick-parent1", Title: "Parent", Status: task.StatusCancelled, Created: now, Updated: now}
+		child1 := task.Task{ID: "tick-child1", Title: "Login", Status: task.StatusCancelled, Parent: "tick-parent1", Created: now, Updated: now}
+		child2 := task.Task{ID: "tick-child2", Title: "Signup", Status: task.StatusCancelled, Parent: "tick-parent1", Created: now, Updated: now}
+		tasks := []task.Task{parent, child1, child2}
+
+		cascades := []task.CascadeChange{
+			{Task: &tasks[1], Action: "cancel", OldStatus: task.StatusInProgress, NewStatus: task.StatusCancelled},
+			{Task: &tasks[2], Action: "cancel", OldStatus: task.StatusOpen, NewStatus: task.StatusCancelled},
+		}
+
+		result := task.TransitionResult{OldStatus: task.StatusInProgress, NewStatus: task.StatusCancelled}
+		cr := buildCascadeResult("tick-parent1", "Parent", result, cascades, tasks)
+
+		if len(cr.Cascaded) != 2 {
+			t.Fatalf("cascaded length = %d, want 2", len(cr.Cascaded))
+		}
+		if cr.Cascaded[0].ParentID != "tick-parent1" {
+			t.Errorf("cascaded[0].ParentID = %q, want %q", cr.Cascaded[0].ParentID, "tick-parent1")
+		}
+		if cr.Cascaded[1].ParentID != "tick-parent1" {
+			t.Errorf("cascaded[1].ParentID = %q, want %q", cr.Cascaded[1].ParentID, "tick-parent1")
+		}
+	})
+
+	t.Run("buildCascadeResult sets flat ParentIDs for 3-level upward cascade", func(t *testing.T) {
+		// 3-level hierarchy: grandparent > parent > child.
+		// Child is the primary task; parent and grandparent cascaded upward.
+		// All cascade entries should have ParentID = primary task ID (child),
+		// making them flat roots in the tree (no nesting).
+		grandparent := task.Task{
+			ID: "tick-ggg111", Title: "Grandparent", Status: task.StatusOpen,
+			Priority: 2, Parent: "", Created: now, Updated: now,
+		}
+		parent := task.Task{
+			ID: "tick-ppp111", Title: "Parent", Status: task.StatusOpen,
+			Priority: 2, Parent: "tick-ggg111", Created: now, Updated: now,
+		}
+		child := task.Task{
+			ID: "tick-ccc111", Title: "Child", Status: task.StatusOpen,
+			Priority: 2, Parent: "tick-ppp111", Created: now, Updated: now,
+		}
+
+		primaryResult := task.TransitionResult{
+			OldStatus: task.StatusOpen,
+			NewStatus: task.StatusInProgress,
+		}
+		cascades := []task.CascadeChange{
+			{Task: &parent, OldStatus: task.StatusOpen, NewStatus: task.StatusInProgress},
+			{Task: &grandparent, OldStatus: task.StatusOpen, NewStatus: task.StatusInProgress},
+		}
+		allTasks := []task.Task{grandparent, parent, child}
+
+		cr := buildCascadeResult("tick-ccc111", "Child", primaryResult, cascades, allTasks)
+
+		if len(cr.Cascaded) != 2 {
+			t.Fatalf("expected 2 cascaded entries, got %d", len(cr.Cascaded))
+		}
+		for _, entry := range cr.Cascaded {
+			if entry.ParentID != "tick-ccc111" {
+				t.Errorf("cascade entry %s: ParentID = %q, want %q (primary task ID for flat rendering)",
+					entry.ID, entry.ParentID, "tick-ccc111")
+			}
+		}
+	})
+}
+
+func TestApplyBlocks(t *testing.T) {
+	t.Run("it appends sourceID to matching tasks BlockedBy", func(t *testing.T) {
+		now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+		tasks := []task.Task{
+			{ID: "tick-aaa111", Title: "Task A", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+			{ID: "tick-bbb222", Title: "Task B", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		}
+
+		applyNow := time.Date(2026, 2, 10, 13, 0, 0, 0, time.UTC)
+		applyBlocks(tasks, "tick-src001", []string{"tick-aaa111"}, applyNow)
+
+		if len(tasks[0].BlockedBy) != 1 || tasks[0].BlockedBy[0] != "tick-src001" {
+			t.Errorf("tasks[0].BlockedBy = %v, want [tick-src001]", tasks[0].BlockedBy)
+		}
+		// Unmatched task should not be modified
+		if len(tasks[1].BlockedBy) != 0 {
+			t.Errorf("tasks[1].BlockedBy = %v, want empty", tasks[1].BlockedBy)
+		}
+	})
+
+	t.Run("it sets Updated timestamp on modified tasks", func(t *testing.T) {
+		now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+		tasks := []task.Task{
+			{ID: "tick-aaa111", Title: "Task A", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+			{ID: "tick-bbb222", Title: "Task B", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		}
+
+		applyNow := time.Date(2026, 2, 10, 13, 0, 0, 0, time.UTC)
+		applyBlocks(tasks, "tick-src001", []string{"tick-aaa111"}, applyNow)
+
+		if !tasks[0].Updated.Equal(applyNow) {
+			t.Errorf("tasks[0].Updated = %v, want %v", tasks[0].Updated, applyNow)
+		}
+		// Unmatched task should retain original Updated
+		if !tasks[1].Updated.Equal(now) {
+			t.Errorf("tasks[1].Updated = %v, want %v (unchanged)", tasks[1].Updated, now)
+		}
+	})
+
+	t.Run("it is a no-op with non-existent blockIDs", func(t *testing.T) {
+		now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+		tasks := []task.Task{
+			{ID: "tick-aaa111", Title: "Task A", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		}
+
+		applyNow := time.Date(2026, 2, 10, 13, 0, 0, 0, time.UTC)
+		applyBlocks(tasks, "tick-src001", []string{"tick-nonexist"}, applyNow)
+
+		if len(tasks[0].BlockedBy) != 0 {
+			t.Errorf("tasks[0].BlockedBy = %v, want empty", tasks[0].BlockedBy)
+		}
+		if !tasks[0].Updated.Equal(now) {
+			t.Errorf("tasks[0].Updated = %v, want %v (unchanged)", tasks[0].Updated, now)
+		}
+	})
+
+	t.Run("it skips duplicate when sourceID already in BlockedBy", func(t *testing.T) {
+		now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+		tasks := []task.Task{
+			{ID: "tick-aaa111", Title: "Task A", Status: task.StatusOpen, Priority: 2,
+				BlockedBy: []string{"tick-src001"}, Created: now, Updated: now},
+		}
+
+		applyNow := time.Date(2026, 2, 10, 13, 0, 0, 0, time.UTC)
+		applyBlocks(tasks, "tick-src001", []string{"tick-aaa111"}, applyNow)
+
+		if len(tasks[0].BlockedBy) != 1 {
+			t.Errorf("tasks[0].BlockedBy = %v, want [tick-src001] (no duplicate)", tasks[0].BlockedBy)
+		}
+		if tasks[0].BlockedBy[0] != "tick-src001" {
+			t.Errorf("tasks[0].BlockedBy[0] = %q, want %q", tasks[0].BlockedBy[0], "tick-src001")
+		}
+		// Updated should NOT be changed since no new dependency was added
+		if !tasks[0].Updated.Equal(now) {
+			t.Errorf("tasks[0].Updated = %v, want %v (unchanged)", tasks[0].Updated, now)
+		}
+	})
+
+	t.Run("it matches blockIDs case-insensitively", func(t *testing.T) {
+		now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+		tasks := []task.Task{
+			{ID: "tick-aaa111", Title: "Task A", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		}
+
+		applyNow := time.Date(2026, 2, 10, 13, 0, 0, 0, time.UTC)
+		applyBlocks(tasks, "tick-src001", []string{"TICK-AAA111"}, applyNow)
+
+		if len(tasks[0].BlockedBy) != 1 || tasks[0].BlockedBy[0] != "tick-src001" {
+			t.Errorf("tasks[0].BlockedBy = %v, want [tick-src001]", tasks[0].BlockedBy)
+		}
+	})
+
+	t.Run("it detects existing dep case-insensitively", func(t *testing.T) {
+		now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+		tasks := []task.Task{
+			{ID: "tick-aaa111", Title: "Task A", Status: task.StatusOpen, Priority: 2,
+				BlockedBy: []string{"TICK-SRC001"}, Created: now, Updated: now},
+		}
+
+		applyNow := time.Date(2026, 2, 10, 13, 0, 0, 0, time.UTC)
+		applyBlocks(tasks, "tick-src001", []string{"tick-aaa111"}, applyNow)
+
+		if len(tasks[0].BlockedBy) != 1 {
+			t.Errorf("tasks[0].BlockedBy = %v, want [TICK-SRC001] (no duplicate)", tasks[0].BlockedBy)
+		}
+	})
+
+	t.Run("it handles multiple blockIDs", func(t *testing.T) {
+		now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+		tasks := []task.Task{
+			{ID: "tick-aaa111", Title: "Task A", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+			{ID: "tick-bbb222", Title: "Task B", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+			{ID: "tick-ccc333", Title: "Task C", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		}
+
+		applyNow := time.Date(2026, 2, 10, 13, 0, 0, 0, time.UTC)
+		applyBlocks(tasks, "tick-src001", []string{"tick-aaa111", "tick-ccc333"}, applyNow)
+
+		if len(tasks[0].BlockedBy) != 1 || tasks[0].BlockedBy[0] != "tick-src001" {
+			t.Errorf("tasks[0].BlockedBy = %v, want [tick-src001]", tasks[0].BlockedBy)
+		}
+		if len(tasks[1].BlockedBy) != 0 {
+			t.Errorf("tasks[1].BlockedBy = %v, want empty", tasks[1].BlockedBy)
+		}
+		if len(tasks[2].BlockedBy) != 1 || tasks[2].BlockedBy[0] != "tick-src001" {
+			t.Errorf("tasks[2].BlockedBy = %v, want [tick-src001]", tasks[2].BlockedBy)
+		}
+	})
+}
+
+func TestValidateAndReopenParent(t *testing.T) {
+	now := time.Date(2026, 3, 7, 12, 0, 0, 0, time.UTC)
+
+	t.Run("it returns no-op when parent is open", func(t *testing.T) {
+		tasks := []task.Task{
+			{ID: "tick-parent1", Title: "Parent", Status: task.StatusOpen, Priority: 2, Created: now, Updated: now},
+		}
+		var sm task.StateMachine
+
+		_, _, reopened, err := validateAndReopenParent(tasks, "tick-parent1", &sm)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if reopened {
+			t.Error("reopened should be false for open parent")
+		}
+	})
+
+	t.Run("it returns no-op when parent is in_progress", func(t *testing.T) {
+		tasks := []task.Task{
+			{ID: "tick-parent1", Title: "Parent", Status: task.StatusInProgress, Priority: 2, Created: now, Updated: now},
+		}
+		var sm task.StateMachine
+
+		_, _, reopened, err := validateAndReopenParent(tasks, "tick-parent1", &sm)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if reopened {
+			t.Error("reopened should be false for in_progress parent")
+		}
+	})
+
+	t.Run("it returns error when parent is cancelled (Rule 7)", func(t *testing.T) {
+		tasks := []task.Task{
+			{ID: "tick-parent1", Title: "Parent", Status: task.StatusCancelled, Priority: 2, Created: now, Updated: now},
+		}
+		var sm task.StateMachine
+
+		_, _, _, err := validateAndReopenParent(tasks, "tick-parent1", &sm)
+		if err == nil {
+			t.Fatal("expected error for cancelled parent")
+		}
+		if !strings.Contains(err.Error(), "cancelled") {
+			t.Errorf("error = %q, want it to mention cancelled", err.Error())
+		}
+	})
+
+	t.Run("it reopens done parent (Rule 6)", func(t *testing.T) {
+		tasks := []task.Task{
+			{ID: "tick-parent1", Title: "Parent", Status: task.StatusDone, Priority: 2, Created: now, Updated: now},
+		}
+		var sm task.StateMachine
+
+		result, _, reopened, err := validateAndReopenParent(tasks, "tick-parent1", &sm)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reopened {
+			t.Error("reopened should be true for done parent")
+		}
+		if result.OldStatus != task.StatusDone {
+			t.Errorf("result.OldStatus = %v, want done", result.OldStatus)
+		}
+		if result.NewStatus != task.StatusOpen {
+			t.Errorf("result.NewStatus = %v, want open", result.NewStatus)
+		}
+		if tasks[0].Status != task.StatusOpen {
+			t.Errorf("tasks[0].Status = %v, want open after reopen", tasks[0].Status)
+		}
+	})
+
+	t.Run("it finds parent by normalized ID", func(t *testing.T) {
+		tasks := []task.Task{
+			{ID: "tick-parent1", Title: "Parent", Status: task.StatusDone, Priority: 2, Created: now, Updated: now},
+		}
+		var sm task.StateMachine
+
+		_, _, reopened, err := validateAndReopenParent(tasks, "TICK-PARENT1", &sm)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reopened {
+			t.Error("reopened should be true")
+		}
+	})
+
+	t.Run("it returns no-op when parent ID not found in tasks", func(t *testing.T) {
+		tasks := []task.Task{
+			{ID: "tick-parent1", Title: "Parent", Status: task.StatusDone, Priority: 2, Created: now, Updated: now},
+		}
+		var sm task.StateMachine
+
+		_, _, reopened, err := validateAndReopenParent(tasks, "tick-nonexist", &sm)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if reopened {
+			t.Error("reopened should be false when parent not found")
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package tick
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/leeovery/tick/internal/query"
+	"github.com/leeovery/tick/internal/task"
+)
+
+// DefaultLease is how long a claim lasts when no lease is given.
+const DefaultLease = 30 * time.Minute
+
+// ErrNothingToClaim is returned by Claim when no ready task matches the filter.
+var ErrNothingToClaim = errors.New("no ready task to claim")
+
+// ClaimResult describes a successful claim.
+type ClaimResult struct {
+	// Task is the claimed task, now started with Assignee and LeaseExpires set.
+	Task Task
+	// Transition is the task's start, with any parents it started too.
+	Transition CascadeResult
+	// Released lists the tasks whose expired leases were released by this claim.
+	Released []string
+}
+
+// Claim atomically picks the top ready task matching f (by priority, then
+// creation time), starts it, and assigns it to agent with a lease that expires
+// after lease. Open tasks that are unassigned or assigned to agent are
+// claimable, as are started tasks whose lease has expired. Before claiming,
+// every expired lease is released, as by any other change to the project.
+// Selection and update happen under one exclusive lock, so concurrent claims
+// never return the same task.
+// f may use Priority, Type, TagGroups, Fields and Parent.
+func (p *Project) Claim(f Filter, agent string, lease time.Duration) (ClaimResult, error) {
+	agent = task.NormalizeAssignee(agent)
+	if agent == "" {
+		return ClaimResult{}, errors.New("agent name is required")
+	}
+	if err := task.ValidateAssignee(agent); err != nil {
+		return ClaimResult{}, err
+	}
+	if lease <= 0 {
+		return ClaimResult{}, errors.New("lease must be positive")
+	}
+	if f.Ready || f.Blocked || f.Status != "" || f.HasCount || f.Assignee != "" || f.Unassigned {
+		return ClaimResult{}, errors.New("claim does not support the ready, blocked, status, count or assignee filters")
+	}
+	if err := f.ValidateFor(p.store.Config().Rules()); err != nil {
+		return ClaimResult{}, err
+	}
+	if f.Parent != "" {
+		var err error
+		if f.Parent, err = p.store.ResolveID(f.Parent); err != nil {
+			return ClaimResult{}, err
+		}
+	}
+
+	var result ClaimResult
+	sm := p.stateMachine()
+	err := p.store.MutateWithCache(func(db *sql.DB, tasks []task.Task) ([]task.Task, error) {
+		now := time.Now().UTC().Truncate(time.Second)
+
+		id, err := selectClaimable(db, f, agent, now, sm.Workflow)
+		if err != nil {
+			return nil, err
+		}
+
+		if id == "" {
+			// Still write the releases, but report that nothing was claimed.
+			return tasks, nil
+		}
+
+		for i := range tasks {
+			if tasks[i].ID != id {
+				continue
+			}
+			t := &tasks[i]
+			r, c, err := sm.ApplyUserTransition(tasks, t, "start")
+			if err != nil {
+				return nil, err
+			}
+			expires := now.Add(lease)
+			t.Assignee = agent
+			t.LeaseExpires = &expires
+			result.Task = *t
+			result.Transition = buildCascadeResult(id, t.Title, r, c, tasks)
+			return tasks, nil
+		}
+		return nil, fmt.Errorf("task '%s' not found", id)
+	})
+	if err != nil {
+		return ClaimResult{}, err
+	}
+	result.Released = p.store.Released()
+	if result.Task.ID == "" {
+		return result, ErrNothingToClaim
+	}
+	return result, nil
+}
+
+// selectClaimable returns the ID of the top ready task matching f that is open
+// and unassigned or assigned to agent, or holds a lease expired at now, or ""
+// when there is none. Readiness and claimability follow the state categories
+// of w.
+func selectClaimable(db *sql.DB, f Filter, agent string, now time.Time, w task.Workflow) (string, error) {
+	var descendantIDs []string
+	if f.Parent != "" {
+		var err error
+		if descendantIDs, err = query.DescendantIDs(db, f.Parent); err != nil {
+			return "", err
+		}
+	}
+
+	f.Ready = true
+	f.Now = now
+	conditions, args := query.Conditions(f, descendantIDs, w)
+	claimable, claimableArgs := query.ClaimableCondition(w, agent, now)
+	conditions = append(conditions, claimable)
+	args = append(args, claimableArgs...)
+
+	q := `SELECT t.id FROM tasks t WHERE ` + strings.Join(conditions, " AND ") +
+		` ORDER BY t.priority ASC, t.created ASC LIMIT 1`
+	var id string
+	err := db.QueryRow(q, args...).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to select task to claim: %w", err)
+	}
+	return id, nil
+}
+
+// Heartbeat extends the lease on a claimed task so that it expires lease from
+// now. The task must be started with a lease; when agent is non-empty it
+// must also be the task's assignee. A lease that has expired but has not yet
+// been released by another change can still be renewed.
+func (p *Project) Heartbeat(id, agent string, lease time.Duration) (Task, error) {
+	if lease <= 0 {
+		return Task{}, errors.New("lease must be positive")
+	}
+	id, err := p.store.ResolveID(id)
+	if err != nil {
+		return Task{}, err
+	}
+	agent = strings.TrimSpace(agent)
+
+	var renewed Task
+	w := p.Workflow()
+	err = p.store.MutateKeepingLeases(func(tasks []task.Task) ([]task.Task, error) {
+		for i := range tasks {
+			if tasks[i].ID != id {
+				continue
+			}
+			t := &tasks[i]
+			if !w.IsStarted(t.Status) || t.LeaseExpires == nil {
+				return nil, fmt.Errorf("task %s has no active lease; claim it first", id)
+			}
+			if agent != "" && t.Assignee != agent {
+				return nil, fmt.Errorf("task %s is claimed by %q, not %q", id, t.Assignee, agent)
+			}
+			now := time.Now().UTC().Truncate(time.Second)
+			expires := now.Add(lease)
+			t.LeaseExpires = &expires
+			t.Updated = now
+			renewed = *t
+			return tasks, nil
+		}
+		return nil, fmt.Errorf("task '%s' not found", id)
+	})
+	if err != nil {
+		return Task{}, err
+	}
+	return renewed, nil
+}
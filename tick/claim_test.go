@@ -0,0 +1,252 @@
+package tick
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClaim(t *testing.T) {
+	t.Run("it claims the top ready task and assigns it with a lease", func(t *testing.T) {
+		p := openProject(t)
+		low := mustCreate(t, p, CreateOptions{Title: "Low", Priority: new(3)})
+		high := mustCreate(t, p, CreateOptions{Title: "High", Priority: new(1)})
+		blocked := mustCreate(t, p, CreateOptions{Title: "Blocked", Priority: new(0), BlockedBy: []string{low.ID}})
+
+		before := time.Now().UTC().Truncate(time.Second)
+		result, err := p.Claim(Filter{}, "agent-1", 10*time.Minute)
+		if err != nil {
+			t.Fatalf("Claim returned error: %v", err)
+		}
+		if result.Task.ID != high.ID {
+			t.Fatalf("claimed %s, want %s (blocked %s must be skipped)", result.Task.ID, high.ID, blocked.ID)
+		}
+		if result.Task.Status != StatusInProgress || result.Task.Assignee != "agent-1" {
+			t.Errorf("claimed task = %+v", result.Task)
+		}
+		if result.Task.LeaseExpires == nil || result.Task.LeaseExpires.Before(before.Add(10*time.Minute)) {
+			t.Errorf("LeaseExpires = %v, want about 10m from now", result.Task.LeaseExpires)
+		}
+
+		detail, err := p.Show(high.ID)
+		if err != nil {
+			t.Fatalf("Show returned error: %v", err)
+		}
+		if detail.Task.Assignee != "agent-1" || detail.Task.LeaseExpires == nil {
+			t.Errorf("persisted task = %+v", detail.Task)
+		}
+
+		// The claimed task is in progress with a live lease, so the next claim
+		// takes the remaining ready task.
+		next, err := p.Claim(Filter{}, "agent-2", 10*time.Minute)
+		if err != nil {
+			t.Fatalf("second Claim returned error: %v", err)
+		}
+		if next.Task.ID != low.ID {
+			t.Errorf("second claim = %s, want %s", next.Task.ID, low.ID)
+		}
+
+		if _, err := p.Claim(Filter{}, "agent-3", 10*time.Minute); !errors.Is(err, ErrNothingToClaim) {
+			t.Errorf("third Claim error = %v, want ErrNothingToClaim", err)
+		}
+	})
+
+	t.Run("it never hands the same task to concurrent claims", func(t *testing.T) {
+		dir := setupProjectDir(t)
+		p, err := Open(dir)
+		if err != nil {
+			t.Fatalf("Open returned error: %v", err)
+		}
+		for range 3 {
+			mustCreate(t, p, CreateOptions{Title: "Task"})
+		}
+		p.Close()
+
+		// Each agent opens the project itself, as separate processes would.
+		var wg sync.WaitGroup
+		claimed := make(chan string, 6)
+		for i := range 6 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				agent, err := Open(dir)
+				if err != nil {
+					t.Errorf("Open %d returned error: %v", i, err)
+					return
+				}
+				defer agent.Close()
+				result, err := agent.Claim(Filter{}, "agent", time.Hour)
+				if err == nil {
+					claimed <- result.Task.ID
+				} else if !errors.Is(err, ErrNothingToClaim) {
+					t.Errorf("Claim %d returned error: %v", i, err)
+				}
+			}()
+		}
+		wg.Wait()
+		close(claimed)
+
+		seen := map[string]bool{}
+		for id := range claimed {
+			if seen[id] {
+				t.Errorf("task %s claimed twice", id)
+			}
+			seen[id] = true
+		}
+		if len(seen) != 3 {
+			t.Errorf("claimed %d distinct tasks, want 3", len(seen))
+		}
+	})
+
+	t.Run("it releases expired leases as auto transitions and reclaims them", func(t *testing.T) {
+		p := openProject(t)
+		created := mustCreate(t, p, CreateOptions{Title: "Task"})
+
+		// A lease shorter than the timestamp resolution has expired by the next claim.
+		if _, err := p.Claim(Filter{}, "agent-1", time.Nanosecond); err != nil {
+			t.Fatalf("Claim returned error: %v", err)
+		}
+		result, err := p.Claim(Filter{}, "agent-2", time.Hour)
+		if err != nil {
+			t.Fatalf("reclaim returned error: %v", err)
+		}
+		if result.Task.ID != created.ID || result.Task.Assignee != "agent-2" {
+			t.Errorf("reclaimed task = %+v", result.Task)
+		}
+		if len(result.Released) != 1 || result.Released[0] != created.ID {
+			t.Errorf("Released = %v, want [%s]", result.Released, created.ID)
+		}
+
+		transitions := result.Task.Transitions
+		if len(transitions) != 3 {
+			t.Fatalf("transitions = %+v, want start, release, start", transitions)
+		}
+		release := transitions[1]
+		if release.From != StatusInProgress || release.To != StatusOpen || !release.Auto {
+			t.Errorf("release transition = %+v, want auto in_progress -> open", release)
+		}
+	})
+
+	t.Run("it releases expired leases on any change", func(t *testing.T) {
+		dir := setupProjectDir(t)
+		lines := `{"id":"tick-aaa111","title":"Claimed","status":"in_progress","priority":2,"assignee":"agent-1","lease_expires":"2026-01-19T10:30:00Z","created":"2026-01-19T10:00:00Z","updated":"2026-01-19T10:00:00Z"}` + "\n" +
+			`{"id":"tick-bbb222","title":"Other","status":"open","priority":2,"created":"2026-01-19T10:00:00Z","updated":"2026-01-19T10:00:00Z"}` + "\n"
+		if err := os.WriteFile(filepath.Join(dir, ".tick", "tasks.jsonl"), []byte(lines), 0644); err != nil {
+			t.Fatalf("failed to write tasks.jsonl: %v", err)
+		}
+		p, err := Open(dir)
+		if err != nil {
+			t.Fatalf("Open returned error: %v", err)
+		}
+		defer p.Close()
+
+		if err := p.AddNote("tick-bbb222", "Unrelated change"); err != nil {
+			t.Fatalf("AddNote returned error: %v", err)
+		}
+		detail, err := p.Show("tick-aaa111")
+		if err != nil {
+			t.Fatalf("Show returned error: %v", err)
+		}
+		if detail.Task.Status != StatusOpen || detail.Task.Assignee != "" || detail.Task.LeaseExpires != nil {
+			t.Errorf("expired task = %+v, want released to open and unassigned", detail.Task)
+		}
+		stored, err := p.store.ReadTasks()
+		if err != nil {
+			t.Fatalf("ReadTasks returned error: %v", err)
+		}
+		if tr := stored[0].Transitions; len(tr) != 1 || tr[0].To != StatusOpen || !tr[0].Auto {
+			t.Errorf("transitions = %+v, want one auto release", tr)
+		}
+	})
+
+	t.Run("it applies filters and rejects unsupported ones", func(t *testing.T) {
+		p := openProject(t)
+		mustCreate(t, p, CreateOptions{Title: "Feature", Priority: new(0), Type: "feature"})
+		bug := mustCreate(t, p, CreateOptions{Title: "Bug", Type: "bug"})
+
+		result, err := p.Claim(Filter{Type: "bug"}, "agent-1", time.Hour)
+		if err != nil || result.Task.ID != bug.ID {
+			t.Errorf("Claim(type bug) = %s, %v; want %s", result.Task.ID, err, bug.ID)
+		}
+
+		if _, err := p.Claim(Filter{Status: "open"}, "agent-1", time.Hour); err == nil {
+			t.Error("Claim with a status filter should fail")
+		}
+		if _, err := p.Claim(Filter{}, " ", time.Hour); err == nil {
+			t.Error("Claim without an agent should fail")
+		}
+		if _, err := p.Claim(Filter{Assignee: "agent-1"}, "agent-1", time.Hour); err == nil {
+			t.Error("Claim with an assignee filter should fail")
+		}
+	})
+
+	t.Run("it skips open tasks assigned to another agent", func(t *testing.T) {
+		p := openProject(t)
+		theirs := mustCreate(t, p, CreateOptions{Title: "Theirs", Priority: new(0), Assignee: "agent-2"})
+		mine := mustCreate(t, p, CreateOptions{Title: "Mine", Priority: new(1), Assignee: "agent-1"})
+
+		result, err := p.Claim(Filter{}, "agent-1", time.Hour)
+		if err != nil {
+			t.Fatalf("Claim returned error: %v", err)
+		}
+		if result.Task.ID != mine.ID {
+			t.Errorf("claimed %s, want %s (%s is assigned to agent-2)", result.Task.ID, mine.ID, theirs.ID)
+		}
+		if _, err := p.Claim(Filter{}, "agent-1", time.Hour); !errors.Is(err, ErrNothingToClaim) {
+			t.Errorf("second Claim error = %v, want ErrNothingToClaim", err)
+		}
+
+		result, err = p.Claim(Filter{}, "agent-2", time.Hour)
+		if err != nil || result.Task.ID != theirs.ID {
+			t.Errorf("Claim(agent-2) = %s, %v; want %s", result.Task.ID, err, theirs.ID)
+		}
+	})
+}
+
+func TestHeartbeat(t *testing.T) {
+	t.Run("it extends the lease of the assignee's task", func(t *testing.T) {
+		p := openProject(t)
+		mustCreate(t, p, CreateOptions{Title: "Task"})
+		claimed, err := p.Claim(Filter{}, "agent-1", time.Minute)
+		if err != nil {
+			t.Fatalf("Claim returned error: %v", err)
+		}
+
+		renewed, err := p.Heartbeat(claimed.Task.ID, "agent-1", time.Hour)
+		if err != nil {
+			t.Fatalf("Heartbeat returned error: %v", err)
+		}
+		if !renewed.LeaseExpires.After(*claimed.Task.LeaseExpires) {
+			t.Errorf("LeaseExpires = %v, want later than %v", renewed.LeaseExpires, claimed.Task.LeaseExpires)
+		}
+
+		if _, err := p.Heartbeat(claimed.Task.ID, "agent-2", time.Hour); err == nil {
+			t.Error("Heartbeat from another agent should fail")
+		}
+	})
+
+	t.Run("it fails for a task without a lease and clears leases on close", func(t *testing.T) {
+		p := openProject(t)
+		created := mustCreate(t, p, CreateOptions{Title: "Task"})
+		if _, err := p.Heartbeat(created.ID, "", time.Hour); err == nil {
+			t.Error("Heartbeat on an unclaimed task should fail")
+		}
+
+		if _, err := p.Claim(Filter{}, "agent-1", time.Hour); err != nil {
+			t.Fatalf("Claim returned error: %v", err)
+		}
+		if _, err := p.Transition(created.ID, "done"); err != nil {
+			t.Fatalf("Transition returned error: %v", err)
+		}
+		detail, _ := p.Show(created.ID)
+		if detail.Task.LeaseExpires != nil || detail.Task.Assignee != "agent-1" {
+			t.Errorf("done task = %+v, want lease cleared and assignee kept", detail.Task)
+		}
+		if _, err := p.Heartbeat(created.ID, "", time.Hour); err == nil {
+			t.Error("Heartbeat on a done task should fail")
+		}
+	})
+}
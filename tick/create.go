@@ -0,0 +1,337 @@
+package tick
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/leeovery/tick/internal/config"
+	"github.com/leeovery/tick/internal/task"
+)
+
+// CreateOptions describes a new task. Only Title is required.
+type CreateOptions struct {
+	Title       string
+	Description string
+	// Priority is 0 (highest) to 4. Nil means the project's default: the
+	// priority of the task's type if .tick/config.yaml declares one, otherwise
+	// create.priority, which is 2 unless configured.
+	Priority *int
+	// Type and Tags default to the project's create.type and create.tags when
+	// empty and nil respectively.
+	Type string
+	Tags []string
+	Refs []string
+	// Fields holds custom key/value metadata; keys are normalized to lowercase.
+	Fields map[string]string
+	// Assignee is who owns the task; empty leaves it unassigned.
+	Assignee string
+	// Due is the task's deadline; nil or a zero time leaves it without one.
+	Due *time.Time
+	// Estimate is the expected effort in points or hours; zero leaves the task
+	// unestimated.
+	Estimate float64
+	// Recur is a recurrence rule such as "weekly" or "every 3 days" (see
+	// task.ParseRecurrence); empty makes a one-off task.
+	Recur string
+	// Parent, BlockedBy and Blocks reference existing tasks by full or partial ID.
+	Parent    string
+	BlockedBy []string
+	Blocks    []string
+}
+
+// MutationResult holds the outcome of Create and Update: the task as written,
+// plus any parent status changes the change triggered.
+type MutationResult struct {
+	Task Task
+	// ParentReopened is set when the task was placed under a done parent, which
+	// is reopened.
+	ParentReopened *CascadeResult
+	// ParentCompleted is set when Update moved the task away from a parent whose
+	// remaining children are all closed, which completes it.
+	ParentCompleted *CascadeResult
+}
+
+// Create validates opts, adds a new open task with a generated ID, and wires up
+// its parent and dependencies. A done parent is reopened.
+func (p *Project) Create(opts CreateOptions) (MutationResult, error) {
+	spec, err := prepareCreate(opts, p.store.Config())
+	if err != nil {
+		return MutationResult{}, err
+	}
+
+	// Resolve partial IDs.
+	parent, err := p.resolveOptional(opts.Parent)
+	if err != nil {
+		return MutationResult{}, err
+	}
+	blockedBy, err := p.resolveAll(opts.BlockedBy)
+	if err != nil {
+		return MutationResult{}, err
+	}
+	blocks, err := p.resolveAll(opts.Blocks)
+	if err != nil {
+		return MutationResult{}, err
+	}
+
+	var result MutationResult
+
+	err = p.store.Mutate(func(tasks []task.Task) ([]task.Task, error) {
+		var err error
+		tasks, result, err = applyCreate(tasks, spec, parent, blockedBy, blocks)
+		return tasks, err
+	})
+	if err != nil {
+		return MutationResult{}, err
+	}
+	return result, nil
+}
+
+// createSpec holds the validated, normalized fields of a new task. Its task
+// references are resolved separately.
+type createSpec struct {
+	title       string
+	description string
+	priority    int
+	taskType    string
+	tags        []string
+	refs        []string
+	fields      map[string]string
+	assignee    string
+	due         *time.Time
+	estimate    float64
+	recur       string
+	// rules are the project rules, whose ID prefix the new task's ID takes and
+	// whose workflow reopens a completed parent.
+	rules task.Rules
+}
+
+// prepareCreate validates and normalizes the fields of opts that do not
+// reference other tasks, filling in the defaults and applying the rules of cfg.
+func prepareCreate(opts CreateOptions, cfg config.Config) (createSpec, error) {
+	rules := cfg.Rules()
+	title := task.TrimTitle(opts.Title)
+	if err := task.ValidateTitle(title); err != nil {
+		return createSpec{}, err
+	}
+
+	taskType := task.NormalizeType(cmp.Or(opts.Type, cfg.Create.Type))
+	if err := rules.ValidateType(taskType); err != nil {
+		return createSpec{}, err
+	}
+
+	priority := rules.PriorityFor(taskType)
+	if opts.Priority != nil {
+		priority = *opts.Priority
+	}
+	if err := task.ValidatePriority(priority); err != nil {
+		return createSpec{}, err
+	}
+
+	tags := opts.Tags
+	if tags == nil {
+		tags = cfg.Create.Tags
+	}
+	tags = task.DeduplicateTags(tags)
+	if err := rules.ValidateTags(tags); err != nil {
+		return createSpec{}, err
+	}
+
+	refs := task.DeduplicateRefs(opts.Refs)
+	if err := task.ValidateRefs(refs); err != nil {
+		return createSpec{}, err
+	}
+
+	fields, err := normalizeFields(opts.Fields)
+	if err != nil {
+		return createSpec{}, err
+	}
+
+	assignee := task.NormalizeAssignee(opts.Assignee)
+	if assignee != "" {
+		if err := task.ValidateAssignee(assignee); err != nil {
+			return createSpec{}, err
+		}
+	}
+
+	var due *time.Time
+	if opts.Due != nil && !opts.Due.IsZero() {
+		due = new(task.NormalizeDue(*opts.Due))
+	}
+
+	if err := task.ValidateEstimate(opts.Estimate); err != nil {
+		return createSpec{}, err
+	}
+
+	recur, err := task.NormalizeRecur(opts.Recur)
+	if err != nil {
+		return createSpec{}, err
+	}
+
+	return createSpec{
+		title:       title,
+		description: task.TrimDescription(opts.Description),
+		priority:    priority,
+		taskType:    taskType,
+		tags:        tags,
+		refs:        refs,
+		fields:      fields,
+		assignee:    assignee,
+		due:         due,
+		estimate:    opts.Estimate,
+		recur:       recur,
+		rules:       rules,
+	}, nil
+}
+
+// normalizeFields returns fields with normalized keys and trimmed values,
+// validated as a task's fields. Nil or empty fields return nil.
+func normalizeFields(fields map[string]string) (map[string]string, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	normalized := make(map[string]string, len(fields))
+	for key, value := range fields {
+		normalized[task.NormalizeFieldKey(key)] = strings.TrimSpace(value)
+	}
+	if err := task.ValidateFields(normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// applyCreate adds the task described by spec to tasks with a generated ID,
+// under parent and with the given dependencies, all of which are full IDs.
+// A done parent is reopened.
+func applyCreate(tasks []task.Task, spec createSpec, parent string, blockedBy, blocks []string) ([]task.Task, MutationResult, error) {
+	var result MutationResult
+
+	// Build an ID existence checker with normalized keys.
+	idSet := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		idSet[task.NormalizeID(t.ID)] = true
+	}
+	exists := func(id string) bool {
+		return idSet[id]
+	}
+
+	// Generate unique ID.
+	id, err := spec.rules.GenerateID(exists)
+	if err != nil {
+		return nil, result, err
+	}
+
+	// Validate all referenced IDs exist and no self-references.
+	if err := validateRefs(id, parent, blockedBy, blocks, idSet); err != nil {
+		return nil, result, err
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	newTask := task.Task{
+		ID:          id,
+		Title:       spec.title,
+		Status:      task.StatusOpen,
+		Priority:    spec.priority,
+		Type:        spec.taskType,
+		Tags:        spec.tags,
+		Refs:        spec.refs,
+		Fields:      spec.fields,
+		Assignee:    spec.assignee,
+		Due:         spec.due,
+		Estimate:    spec.estimate,
+		Recur:       spec.recur,
+		Description: spec.description,
+		BlockedBy:   blockedBy,
+		Parent:      parent,
+		Created:     now,
+		Updated:     now,
+	}
+
+	sm := task.StateMachine{Workflow: spec.rules.Workflow}
+
+	// Validate parent allows adding children (Rule 7: blocks cancelled parent).
+	// If parent is done, trigger reopen cascade (Rule 6).
+	if parent != "" {
+		result.ParentReopened, err = reopenParent(tasks, parent, &sm)
+		if err != nil {
+			return nil, result, err
+		}
+	}
+
+	// For Blocks: add new task's ID to target tasks' blocked_by and refresh updated.
+	if len(blocks) > 0 {
+		applyBlocks(tasks, id, blocks, now)
+	}
+
+	tasks = append(tasks, newTask)
+
+	// Validate dependencies (cycle detection + child-blocked-by-parent + cancelled blocker) against full task list.
+	for _, depID := range blockedBy {
+		if err := sm.ValidateAddDep(tasks, id, depID); err != nil {
+			return nil, result, err
+		}
+	}
+	for _, blockID := range blocks {
+		if err := sm.ValidateAddDep(tasks, blockID, id); err != nil {
+			return nil, result, err
+		}
+	}
+
+	result.Task = newTask
+	return tasks, result, nil
+}
+
+// validateRefs checks that all referenced IDs (blocked-by, blocks, parent) exist
+// in the task set and that none reference the new task itself.
+func validateRefs(newID, parent string, blockedBy, blocks []string, idSet map[string]bool) error {
+	for _, depID := range blockedBy {
+		if depID == newID {
+			return fmt.Errorf("task %s cannot be blocked by itself", newID)
+		}
+		if !idSet[depID] {
+			return fmt.Errorf("task %q not found (referenced in --blocked-by)", depID)
+		}
+	}
+	for _, blockID := range blocks {
+		if blockID == newID {
+			return fmt.Errorf("task %s cannot block itself", newID)
+		}
+		if !idSet[blockID] {
+			return fmt.Errorf("task %q not found (referenced in --blocks)", blockID)
+		}
+	}
+	if parent != "" {
+		if parent == newID {
+			return fmt.Errorf("task %s cannot be its own parent", newID)
+		}
+		if !idSet[parent] {
+			return fmt.Errorf("task %q not found (referenced in --parent)", parent)
+		}
+	}
+	return nil
+}
+
+// resolveOptional resolves id to a full task ID, passing an empty id through.
+func (p *Project) resolveOptional(id string) (string, error) {
+	if id == "" {
+		return "", nil
+	}
+	return p.store.ResolveID(id)
+}
+
+// resolveAll resolves each of ids to a full task ID.
+func (p *Project) resolveAll(ids []string) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	resolved := make([]string, len(ids))
+	for i, id := range ids {
+		var err error
+		if resolved[i], err = p.store.ResolveID(id); err != nil {
+			return nil, err
+		}
+	}
+	return resolved, nil
+}
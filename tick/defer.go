@@ -0,0 +1,61 @@
+package tick
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// Defer keeps the task with the given ID out of the ready list until the
+// instant until, which must be in the future. The task is listed as blocked
+// until then. Deferring a deferred task moves its date; closed tasks cannot be
+// deferred.
+func (p *Project) Defer(id string, until time.Time) (Task, error) {
+	until = until.UTC().Truncate(time.Second)
+	if !until.After(time.Now()) {
+		return Task{}, fmt.Errorf("defer date %s is not in the future", task.FormatTimestamp(until))
+	}
+	return p.setDeferUntil(id, &until)
+}
+
+// Undefer makes the task with the given ID eligible for the ready list again.
+// Undeferring a task that is not deferred is a no-op.
+func (p *Project) Undefer(id string) (Task, error) {
+	return p.setDeferUntil(id, nil)
+}
+
+// setDeferUntil sets the defer date of the task with the given ID, clearing it
+// when until is nil.
+func (p *Project) setDeferUntil(id string, until *time.Time) (Task, error) {
+	id, err := p.store.ResolveID(id)
+	if err != nil {
+		return Task{}, err
+	}
+
+	var changed Task
+	err = p.store.Mutate(func(tasks []task.Task) ([]task.Task, error) {
+		for i := range tasks {
+			if tasks[i].ID != id {
+				continue
+			}
+			t := &tasks[i]
+			if until != nil && t.Closed != nil {
+				return nil, fmt.Errorf("task %s is %s and cannot be deferred", id, t.Status)
+			}
+			if until == nil && t.DeferUntil == nil {
+				changed = *t
+				return tasks, nil
+			}
+			t.DeferUntil = until
+			t.Updated = time.Now().UTC().Truncate(time.Second)
+			changed = *t
+			return tasks, nil
+		}
+		return nil, fmt.Errorf("task '%s' not found", id)
+	})
+	if err != nil {
+		return Task{}, err
+	}
+	return changed, nil
+}
@@ -0,0 +1,109 @@
+package tick
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDefer(t *testing.T) {
+	t.Run("it moves a deferred task from ready to blocked until undeferred", func(t *testing.T) {
+		p := openProject(t)
+		deferred := mustCreate(t, p, CreateOptions{Title: "Wait for release"})
+		other := mustCreate(t, p, CreateOptions{Title: "Other"})
+
+		got, err := p.Defer(deferred.ID, time.Now().Add(48*time.Hour))
+		if err != nil {
+			t.Fatalf("Defer returned error: %v", err)
+		}
+		if got.DeferUntil == nil {
+			t.Fatal("DeferUntil = nil, want a date")
+		}
+
+		ready, err := p.Ready()
+		if err != nil {
+			t.Fatalf("Ready returned error: %v", err)
+		}
+		if len(ready) != 1 || ready[0].ID != other.ID {
+			t.Errorf("ready = %v, want only %s", ready, other.ID)
+		}
+		blocked, err := p.Blocked()
+		if err != nil {
+			t.Fatalf("Blocked returned error: %v", err)
+		}
+		if len(blocked) != 1 || blocked[0].ID != deferred.ID || blocked[0].DeferUntil == nil {
+			t.Errorf("blocked = %v, want %s with its defer date", blocked, deferred.ID)
+		}
+		if blocked[0].BlockedReason != "deferred" {
+			t.Errorf("BlockedReason = %q, want deferred", blocked[0].BlockedReason)
+		}
+
+		withDeferred, err := p.List(Filter{Ready: true, IncludeDeferred: true})
+		if err != nil {
+			t.Fatalf("List returned error: %v", err)
+		}
+		if len(withDeferred) != 2 {
+			t.Errorf("ready with IncludeDeferred = %v, want both tasks", withDeferred)
+		}
+
+		if _, err := p.Undefer(deferred.ID); err != nil {
+			t.Fatalf("Undefer returned error: %v", err)
+		}
+		if ready, _ = p.Ready(); len(ready) != 2 {
+			t.Errorf("ready after Undefer = %v, want both tasks", ready)
+		}
+	})
+
+	t.Run("it treats a task as ready once its defer date has passed", func(t *testing.T) {
+		p := openProject(t)
+		tk := mustCreate(t, p, CreateOptions{Title: "Soon"})
+		until := time.Now().Add(time.Hour)
+		if _, err := p.Defer(tk.ID, until); err != nil {
+			t.Fatalf("Defer returned error: %v", err)
+		}
+
+		ready, err := p.List(Filter{Ready: true, Now: until.Add(time.Minute)})
+		if err != nil {
+			t.Fatalf("List returned error: %v", err)
+		}
+		if len(ready) != 1 {
+			t.Errorf("ready after the defer date = %v, want the task", ready)
+		}
+	})
+
+	t.Run("it does not claim a deferred task", func(t *testing.T) {
+		p := openProject(t)
+		tk := mustCreate(t, p, CreateOptions{Title: "Later"})
+		if _, err := p.Defer(tk.ID, time.Now().Add(time.Hour)); err != nil {
+			t.Fatalf("Defer returned error: %v", err)
+		}
+
+		if _, err := p.Claim(Filter{}, "agent-1", time.Hour); !errors.Is(err, ErrNothingToClaim) {
+			t.Errorf("Claim error = %v, want ErrNothingToClaim", err)
+		}
+	})
+
+	t.Run("it rejects a defer date that is not in the future", func(t *testing.T) {
+		p := openProject(t)
+		tk := mustCreate(t, p, CreateOptions{Title: "Task"})
+
+		_, err := p.Defer(tk.ID, time.Now().Add(-time.Hour))
+		if err == nil || !strings.Contains(err.Error(), "is not in the future") {
+			t.Errorf("Defer error = %v, want not-in-the-future error", err)
+		}
+	})
+
+	t.Run("it rejects deferring a closed task", func(t *testing.T) {
+		p := openProject(t)
+		tk := mustCreate(t, p, CreateOptions{Title: "Task"})
+		if _, err := p.Transition(tk.ID, "done"); err != nil {
+			t.Fatalf("Transition returned error: %v", err)
+		}
+
+		_, err := p.Defer(tk.ID, time.Now().Add(time.Hour))
+		if err == nil || !strings.Contains(err.Error(), "cannot be deferred") {
+			t.Errorf("Defer error = %v, want cannot-be-deferred error", err)
+		}
+	})
+}
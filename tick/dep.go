@@ -0,0 +1,124 @@
+package tick
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// AddDep records that the task with taskID is blocked by blockedByID. It
+// refuses duplicates, self-references, cycles, a child blocked by its own
+// parent, and cancelled blockers.
+func (p *Project) AddDep(taskID, blockedByID string) error {
+	taskID, blockedByID, err := p.resolvePair(taskID, blockedByID)
+	if err != nil {
+		return err
+	}
+
+	return p.store.Mutate(func(tasks []task.Task) ([]task.Task, error) {
+		return tasks, applyAddDep(tasks, taskID, blockedByID)
+	})
+}
+
+// applyAddDep records in tasks that taskID is blocked by blockedByID, both
+// full IDs.
+func applyAddDep(tasks []task.Task, taskID, blockedByID string) error {
+	// Find task_id.
+	taskIdx := -1
+	for i := range tasks {
+		if tasks[i].ID == taskID {
+			taskIdx = i
+			break
+		}
+	}
+	if taskIdx == -1 {
+		return fmt.Errorf("task '%s' not found", taskID)
+	}
+
+	// Find blocked_by_id.
+	blockedByFound := false
+	for i := range tasks {
+		if tasks[i].ID == blockedByID {
+			blockedByFound = true
+			break
+		}
+	}
+	if !blockedByFound {
+		return fmt.Errorf("task '%s' not found", blockedByID)
+	}
+
+	// Check duplicate.
+	if slices.Contains(tasks[taskIdx].BlockedBy, blockedByID) {
+		return fmt.Errorf("dependency already exists: %s is already blocked by %s", taskID, blockedByID)
+	}
+
+	// Validate dependency (self-ref, cycle, child-blocked-by-parent, cancelled blocker).
+	var sm task.StateMachine
+	if err := sm.ValidateAddDep(tasks, taskID, blockedByID); err != nil {
+		return err
+	}
+
+	// Add dependency and update timestamp.
+	tasks[taskIdx].BlockedBy = append(tasks[taskIdx].BlockedBy, blockedByID)
+	tasks[taskIdx].Updated = time.Now().UTC().Truncate(time.Second)
+
+	return nil
+}
+
+// RemoveDep removes blockedByID from the blockers of the task with taskID.
+func (p *Project) RemoveDep(taskID, blockedByID string) error {
+	taskID, blockedByID, err := p.resolvePair(taskID, blockedByID)
+	if err != nil {
+		return err
+	}
+
+	return p.store.Mutate(func(tasks []task.Task) ([]task.Task, error) {
+		// Find task_id.
+		taskIdx := -1
+		for i := range tasks {
+			if tasks[i].ID == taskID {
+				taskIdx = i
+				break
+			}
+		}
+		if taskIdx == -1 {
+			return nil, fmt.Errorf("task '%s' not found", taskID)
+		}
+
+		// Check blocked_by_id exists in the blocked_by array.
+		depIdx := -1
+		for i, dep := range tasks[taskIdx].BlockedBy {
+			if dep == blockedByID {
+				depIdx = i
+				break
+			}
+		}
+		if depIdx == -1 {
+			return nil, fmt.Errorf("%s is not a dependency of %s", blockedByID, taskID)
+		}
+
+		// Remove from blocked_by (preserve order).
+		tasks[taskIdx].BlockedBy = append(
+			tasks[taskIdx].BlockedBy[:depIdx],
+			tasks[taskIdx].BlockedBy[depIdx+1:]...,
+		)
+		tasks[taskIdx].Updated = time.Now().UTC().Truncate(time.Second)
+
+		return tasks, nil
+	})
+}
+
+// resolvePair resolves a task ID and a blocker ID to full task IDs.
+func (p *Project) resolvePair(taskID, blockedByID string) (string, string, error) {
+	taskID, err := p.store.ResolveID(taskID)
+	if err != nil {
+		return "", "", err
+	}
+	blockedByID, err = p.store.ResolveID(blockedByID)
+	if err != nil {
+		return "", "", err
+	}
+	return taskID, blockedByID, nil
+}
@@ -1,4 +1,4 @@
-package cli
+package tick
 
 import (
 	"errors"
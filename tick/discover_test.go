@@ -0,0 +1,45 @@
+package tick
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiscoverTickDir(t *testing.T) {
+	t.Run("it discovers .tick/ directory by walking up from cwd", func(t *testing.T) {
+		root := t.TempDir()
+		tickDir := filepath.Join(root, ".tick")
+		if err := os.Mkdir(tickDir, 0755); err != nil {
+			t.Fatalf("failed to create .tick/: %v", err)
+		}
+
+		// Create a nested subdirectory
+		nested := filepath.Join(root, "sub", "deep")
+		if err := os.MkdirAll(nested, 0755); err != nil {
+			t.Fatalf("failed to create nested dir: %v", err)
+		}
+
+		found, err := DiscoverTickDir(nested)
+		if err != nil {
+			t.Fatalf("DiscoverTickDir returned error: %v", err)
+		}
+
+		absTickDir, _ := filepath.Abs(tickDir)
+		if found != absTickDir {
+			t.Errorf("found = %q, want %q", found, absTickDir)
+		}
+	})
+
+	t.Run("it errors when no .tick/ directory found (not a tick project)", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := DiscoverTickDir(dir)
+		if err == nil {
+			t.Fatal("expected error when no .tick/ found, got nil")
+		}
+		expected := "not a tick project (no .tick directory found)"
+		if err.Error() != expected {
+			t.Errorf("error = %q, want %q", err.Error(), expected)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package tick
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEstimate(t *testing.T) {
+	t.Run("it sets, changes and clears the estimate", func(t *testing.T) {
+		p := openProject(t)
+		tk := mustCreate(t, p, CreateOptions{Title: "Sized", Estimate: 3})
+		if tk.Estimate != 3 {
+			t.Fatalf("Estimate after Create = %v, want 3", tk.Estimate)
+		}
+
+		result, err := p.Update(tk.ID, UpdateOptions{Estimate: new(1.5)})
+		if err != nil {
+			t.Fatalf("Update returned error: %v", err)
+		}
+		if result.Task.Estimate != 1.5 {
+			t.Errorf("Estimate after Update = %v, want 1.5", result.Task.Estimate)
+		}
+
+		result, err = p.Update(tk.ID, UpdateOptions{Estimate: new(0.0)})
+		if err != nil {
+			t.Fatalf("Update returned error: %v", err)
+		}
+		if result.Task.Estimate != 0 {
+			t.Errorf("Estimate after clearing = %v, want 0", result.Task.Estimate)
+		}
+	})
+
+	t.Run("it rejects a negative estimate", func(t *testing.T) {
+		p := openProject(t)
+		_, err := p.Create(CreateOptions{Title: "Sized", Estimate: -1})
+		if err == nil || !strings.Contains(err.Error(), "estimate must be between") {
+			t.Errorf("Create error = %v, want estimate range error", err)
+		}
+	})
+
+	t.Run("it rolls up the estimates of a task's subtree in Show", func(t *testing.T) {
+		p := openProject(t)
+		epic := mustCreate(t, p, CreateOptions{Title: "Epic", Estimate: 1})
+		story := mustCreate(t, p, CreateOptions{Title: "Story", Parent: epic.ID, Estimate: 5})
+		mustCreate(t, p, CreateOptions{Title: "Subtask", Parent: story.ID, Estimate: 2})
+		done := mustCreate(t, p, CreateOptions{Title: "Done", Parent: epic.ID, Estimate: 3})
+		mustCreate(t, p, CreateOptions{Title: "Unrelated", Estimate: 8})
+		if _, err := p.Transition(done.ID, "done"); err != nil {
+			t.Fatalf("Transition returned error: %v", err)
+		}
+
+		d, err := p.Show(epic.ID)
+		if err != nil {
+			t.Fatalf("Show returned error: %v", err)
+		}
+		if d.Task.Estimate != 1 {
+			t.Errorf("Estimate = %v, want 1", d.Task.Estimate)
+		}
+		if d.Rollup == nil || d.Rollup.Total != 11 || d.Rollup.Remaining != 8 {
+			t.Errorf("Rollup = %+v, want total 11 and remaining 8", d.Rollup)
+		}
+	})
+
+	t.Run("it leaves out the rollup for a leaf task or an unestimated subtree", func(t *testing.T) {
+		p := openProject(t)
+		leaf := mustCreate(t, p, CreateOptions{Title: "Leaf", Estimate: 2})
+		parent := mustCreate(t, p, CreateOptions{Title: "Parent"})
+		mustCreate(t, p, CreateOptions{Title: "Child", Parent: parent.ID})
+
+		for _, id := range []string{leaf.ID, parent.ID} {
+			d, err := p.Show(id)
+			if err != nil {
+				t.Fatalf("Show returned error: %v", err)
+			}
+			if d.Rollup != nil {
+				t.Errorf("Rollup of %s = %+v, want nil", id, d.Rollup)
+			}
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package tick
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// AddNote appends a timestamped note to the task with the given ID.
+func (p *Project) AddNote(id, text string) error {
+	text = task.TrimNoteText(text)
+	if err := task.ValidateNoteText(text); err != nil {
+		return err
+	}
+
+	id, err := p.store.ResolveID(id)
+	if err != nil {
+		return err
+	}
+
+	return p.store.Mutate(func(tasks []task.Task) ([]task.Task, error) {
+		return tasks, applyAddNote(tasks, id, text)
+	})
+}
+
+// applyAddNote appends a note with the already validated text to the task with
+// the given full ID in tasks.
+func applyAddNote(tasks []task.Task, id, text string) error {
+	for i := range tasks {
+		if tasks[i].ID == id {
+			now := time.Now().UTC().Truncate(time.Second)
+			note := task.Note{
+				Text:    text,
+				Created: now,
+			}
+			tasks[i].Notes = append(tasks[i].Notes, note)
+			tasks[i].Updated = now
+			return nil
+		}
+	}
+	return fmt.Errorf("task '%s' not found", id)
+}
+
+// RemoveNote removes the note at the 1-based index from the task with the
+// given ID.
+func (p *Project) RemoveNote(id string, index int) error {
+	if index < 1 {
+		return fmt.Errorf("index must be >= 1, got %d", index)
+	}
+
+	id, err := p.store.ResolveID(id)
+	if err != nil {
+		return err
+	}
+
+	return p.store.Mutate(func(tasks []task.Task) ([]task.Task, error) {
+		for i := range tasks {
+			if tasks[i].ID == id {
+				if len(tasks[i].Notes) == 0 {
+					return nil, fmt.Errorf("task has no notes to remove")
+				}
+				if index > len(tasks[i].Notes) {
+					return nil, fmt.Errorf("index %d out of range: task has %d note(s)", index, len(tasks[i].Notes))
+				}
+				idx := index - 1
+				tasks[i].Notes = append(tasks[i].Notes[:idx], tasks[i].Notes[idx+1:]...)
+				tasks[i].Updated = time.Now().UTC().Truncate(time.Second)
+				return tasks, nil
+			}
+		}
+		return nil, fmt.Errorf("task '%s' not found", id)
+	})
+}
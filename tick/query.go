@@ -0,0 +1,143 @@
+package tick
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/leeovery/tick/internal/query"
+	"github.com/leeovery/tick/internal/task"
+)
+
+// List returns the tasks matching f, ordered by priority then creation time.
+// Ready lists put in-progress tasks first. Listed tasks carry only their ID,
+// Title, Status, Priority, Type, Assignee, Due, DeferUntil, Recur and Closed,
+// and in blocked lists their BlockedReason; use Show for the rest.
+func (p *Project) List(f Filter) ([]Task, error) {
+	rules := p.store.Config().Rules()
+	if err := f.ValidateFor(rules); err != nil {
+		return nil, err
+	}
+
+	if f.Parent != "" {
+		var err error
+		f.Parent, err = p.store.ResolveID(f.Parent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var tasks []Task
+	err := p.store.Query(func(db *sql.DB) error {
+		var descendantIDs []string
+
+		if f.Parent != "" {
+			// Collect descendant IDs via recursive CTE.
+			var err error
+			descendantIDs, err = query.DescendantIDs(db, f.Parent)
+			if err != nil {
+				return err
+			}
+		}
+
+		q, queryArgs := buildListQuery(f, descendantIDs, rules.Workflow)
+
+		rows, err := db.Query(q, queryArgs...)
+		if err != nil {
+			return fmt.Errorf("failed to query tasks: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var t Task
+			var status string
+			var taskType, assignee, due, deferUntil, recur, closed, reason *string
+			if err := rows.Scan(&t.ID, &status, &t.Priority, &t.Title, &taskType, &assignee, &due, &deferUntil, &recur, &closed, &reason); err != nil {
+				return fmt.Errorf("failed to scan task row: %w", err)
+			}
+			t.Status = task.Status(status)
+			if taskType != nil {
+				t.Type = *taskType
+			}
+			if assignee != nil {
+				t.Assignee = *assignee
+			}
+			if due != nil {
+				dueTime, _ := time.Parse(task.TimestampFormat, *due)
+				t.Due = &dueTime
+			}
+			if deferUntil != nil {
+				deferTime, _ := time.Parse(task.TimestampFormat, *deferUntil)
+				t.DeferUntil = &deferTime
+			}
+			if recur != nil {
+				t.Recur = *recur
+			}
+			if closed != nil {
+				closedTime, _ := time.Parse(task.TimestampFormat, *closed)
+				t.Closed = &closedTime
+			}
+			if reason != nil {
+				t.BlockedReason = *reason
+			}
+			tasks = append(tasks, t)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// Ready returns the tasks that can be worked on now: in an active state such as
+// open or in progress, with no unclosed blockers, no open children, no blocked
+// ancestor, and not deferred.
+func (p *Project) Ready() ([]Task, error) {
+	return p.List(Filter{Ready: true})
+}
+
+// Blocked returns the live tasks, in an active or waiting state, that are not
+// ready, including those deferred into the future. Each carries the
+// BlockedReason it is blocked for.
+func (p *Project) Blocked() ([]Task, error) {
+	return p.List(Filter{Blocked: true})
+}
+
+// buildListQuery composes a SQL query string and args based on the filter.
+// When descendantIDs is non-empty, results are restricted to those IDs. The
+// ready and blocked filters follow the state categories of w. The last column
+// is each task's blocked reason for a blocked filter, NULL otherwise.
+func buildListQuery(f Filter, descendantIDs []string, w task.Workflow) (string, []any) {
+	if f.Now.IsZero() {
+		f.Now = time.Now()
+	}
+	conditions, args := query.Conditions(f, descendantIDs, w)
+
+	reason := "NULL"
+	if f.Blocked {
+		reason = query.BlockedReason(w, f.DeferredAt(f.Now))
+	}
+	q := `SELECT t.id, t.status, t.priority, t.title, t.type, t.assignee, t.due, t.defer_until, t.recur, t.closed, ` + reason + ` FROM tasks t`
+	if len(conditions) > 0 {
+		q += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	if f.Ready {
+		// Resume-first ordering for the ready view: started states such as
+		// in_progress float to the top as a band; within each band the existing
+		// priority ASC, created ASC holds. With zero started rows the band term is
+		// uniformly false (no-op), so ordering is byte-identical to the neutral
+		// clause below.
+		q += " ORDER BY " + query.StartedFirst(w) + ", t.priority ASC, t.created ASC"
+	} else {
+		q += " ORDER BY t.priority ASC, t.created ASC"
+	}
+
+	if f.HasCount {
+		q += " LIMIT ?"
+		args = append(args, f.Count)
+	}
+
+	return q, args
+}
@@ -0,0 +1,114 @@
+package tick
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// spawnOccurrences creates the next occurrence of every recurring task that cr
+// completed (moved to done, or to any other state the workflow counts as
+// completed), the primary task first, then the cascaded ones in order, and
+// records them in cr.Recurred.
+func spawnOccurrences(tasks []task.Task, cr *CascadeResult, rules task.Rules) ([]task.Task, error) {
+	var completed []string
+	if rules.Workflow.IsCompleted(task.Status(cr.NewStatus)) {
+		completed = append(completed, cr.TaskID)
+	}
+	for _, c := range cr.Cascaded {
+		if rules.Workflow.IsCompleted(task.Status(c.NewStatus)) {
+			completed = append(completed, c.ID)
+		}
+	}
+	for _, id := range completed {
+		idx := slices.IndexFunc(tasks, func(t task.Task) bool { return t.ID == id })
+		if idx < 0 || tasks[idx].Recur == "" {
+			continue
+		}
+		var next task.Task
+		var err error
+		if tasks, next, err = spawnOccurrence(tasks, idx, rules); err != nil {
+			return nil, err
+		}
+		cr.Recurred = append(cr.Recurred, next)
+	}
+	return tasks, nil
+}
+
+// spawnOccurrence appends the next occurrence of the recurring task at idx in
+// tasks, which has just been completed, and moves the recurrence rule to it.
+// The occurrence copies the task's title, description, type, priority, tags
+// and parent, and records the task as the one it recurred from. Its due and
+// defer dates are the task's own moved forward by whole intervals until they
+// are past the completion time; a task with neither is deferred for one
+// interval from completion. A parent that is closed by then is not kept. A
+// monthly rule anchored on a date after the 28th is stored with that day, so
+// later occurrences return to it after a shorter month.
+func spawnOccurrence(tasks []task.Task, idx int, rules task.Rules) ([]task.Task, task.Task, error) {
+	done := &tasks[idx]
+	r, err := task.ParseRecurrence(done.Recur)
+	if err != nil {
+		return nil, task.Task{}, err
+	}
+
+	idSet := make(map[string]bool, len(tasks))
+	parentOpen := false
+	for _, t := range tasks {
+		idSet[task.NormalizeID(t.ID)] = true
+		if t.ID == done.Parent && t.Closed == nil {
+			parentOpen = true
+		}
+	}
+	id, err := rules.GenerateID(func(id string) bool { return idSet[id] })
+	if err != nil {
+		return nil, task.Task{}, err
+	}
+
+	completed := time.Now().UTC().Truncate(time.Second)
+	if done.Closed != nil {
+		completed = *done.Closed
+	}
+
+	next := task.Task{
+		ID:           id,
+		Title:        done.Title,
+		Status:       task.StatusOpen,
+		Priority:     done.Priority,
+		Type:         done.Type,
+		Tags:         slices.Clone(done.Tags),
+		Description:  done.Description,
+		Recur:        done.Recur,
+		RecurredFrom: done.ID,
+		Created:      completed,
+		Updated:      completed,
+	}
+	if parentOpen {
+		next.Parent = done.Parent
+	}
+
+	anchor := cmp.Or(done.Due, done.DeferUntil)
+	if anchor == nil {
+		next.DeferUntil = new(r.Add(completed, 1))
+	} else {
+		r = r.AnchoredTo(*anchor)
+		next.Recur = r.String()
+		n := r.Steps(*anchor, completed)
+		if done.Due != nil {
+			next.Due = new(r.Add(*done.Due, n))
+		}
+		switch {
+		case done.DeferUntil == nil:
+		case r.Day > 0 && done.Due != nil:
+			// The day belongs to the due date; keep the defer date as far
+			// before it as it was.
+			next.DeferUntil = new(done.DeferUntil.Add(next.Due.Sub(*done.Due)))
+		default:
+			next.DeferUntil = new(r.Add(*done.DeferUntil, n))
+		}
+	}
+
+	done.Recur = ""
+	return append(tasks, next), next, nil
+}
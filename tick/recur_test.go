@@ -0,0 +1,270 @@
+package tick
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+func TestRecurrence(t *testing.T) {
+	t.Run("it creates the next occurrence when a recurring task is done", func(t *testing.T) {
+		p := openProject(t)
+		parent := mustCreate(t, p, CreateOptions{Title: "Ops"})
+		mustCreate(t, p, CreateOptions{Title: "Other chore", Parent: parent.ID})
+		chore := mustCreate(t, p, CreateOptions{
+			Title: "Rotate credentials", Description: "Use the vault", Type: "chore",
+			Priority: new(1), Tags: []string{"security"}, Parent: parent.ID,
+			Assignee: "alice", Estimate: 2, Recur: "Every 2 Weeks",
+		})
+		if chore.Recur != "every 2 weeks" {
+			t.Fatalf("Recur after Create = %q, want canonical %q", chore.Recur, "every 2 weeks")
+		}
+
+		cr, err := p.Transition(chore.ID, "done")
+		if err != nil {
+			t.Fatalf("Transition returned error: %v", err)
+		}
+		if len(cr.Recurred) != 1 {
+			t.Fatalf("Recurred = %+v, want the next occurrence", cr.Recurred)
+		}
+		next := cr.Recurred[0]
+		if next.ID == chore.ID || next.Status != task.StatusOpen || next.RecurredFrom != chore.ID || next.Recur != "every 2 weeks" {
+			t.Errorf("next = %+v, want a new open task recurring every 2 weeks from %s", next, chore.ID)
+		}
+		if next.Title != chore.Title || next.Description != chore.Description || next.Type != "chore" || next.Priority != 1 ||
+			!slices.Equal(next.Tags, []string{"security"}) || next.Parent != parent.ID {
+			t.Errorf("next = %+v, want title, description, type, priority, tags and parent copied", next)
+		}
+		if next.Assignee != "" || next.Estimate != 0 {
+			t.Errorf("next assignee, estimate = %q, %v, want neither copied", next.Assignee, next.Estimate)
+		}
+		if next.DeferUntil == nil || next.Due != nil {
+			t.Fatalf("next due, defer = %v, %v, want deferred with no due date", next.Due, next.DeferUntil)
+		}
+		if d := next.DeferUntil.Sub(next.Created); d < 13*24*time.Hour || d > 15*24*time.Hour {
+			t.Errorf("next deferred for %v, want about two weeks", d)
+		}
+
+		d, err := p.Show(chore.ID)
+		if err != nil {
+			t.Fatalf("Show returned error: %v", err)
+		}
+		if d.Task.Recur != "" {
+			t.Errorf("completed task Recur = %q, want the rule moved to the next occurrence", d.Task.Recur)
+		}
+		d, err = p.Show(next.ID)
+		if err != nil {
+			t.Fatalf("Show returned error: %v", err)
+		}
+		if d.Task.Recur != "every 2 weeks" || d.Task.RecurredFrom != chore.ID {
+			t.Errorf("shown next Recur, RecurredFrom = %q, %q", d.Task.Recur, d.Task.RecurredFrom)
+		}
+	})
+
+	t.Run("it moves the due and defer dates forward by whole intervals", func(t *testing.T) {
+		p := openProject(t)
+		due := time.Now().UTC().Truncate(time.Second).Add(-10 * 24 * time.Hour)
+		chore := mustCreate(t, p, CreateOptions{Title: "Update dependencies", Due: &due, Recur: "weekly"})
+		deferUntil := due.Add(12 * 24 * time.Hour)
+		if _, err := p.Defer(chore.ID, deferUntil); err != nil {
+			t.Fatalf("Defer returned error: %v", err)
+		}
+
+		cr, err := p.Transition(chore.ID, "done")
+		if err != nil {
+			t.Fatalf("Transition returned error: %v", err)
+		}
+		if len(cr.Recurred) != 1 || cr.Recurred[0].Due == nil || cr.Recurred[0].DeferUntil == nil {
+			t.Fatalf("Recurred = %+v, want due and defer dates", cr.Recurred)
+		}
+		next := cr.Recurred[0]
+		weekly := task.Recurrence{Days: 7}
+		if want := weekly.Add(due, 2); !next.Due.Equal(want) {
+			t.Errorf("next due = %v, want %v", next.Due, want)
+		}
+		if want := weekly.Add(deferUntil, 2); !next.DeferUntil.Equal(want) {
+			t.Errorf("next defer = %v, want %v", next.DeferUntil, want)
+		}
+	})
+
+	t.Run("it keeps a monthly task on its day after a shorter month", func(t *testing.T) {
+		p := openProject(t)
+		now := time.Now()
+		due := time.Date(now.Year()-1, time.January, 31, 23, 59, 59, 0, time.Local)
+		chore := mustCreate(t, p, CreateOptions{Title: "Close the books", Due: &due, Recur: "monthly"})
+
+		cr, err := p.Transition(chore.ID, "done")
+		if err != nil {
+			t.Fatalf("Transition returned error: %v", err)
+		}
+		next := cr.Recurred[0]
+		if next.Recur != "monthly on day 31" {
+			t.Errorf("next Recur = %q, want monthly on day 31", next.Recur)
+		}
+		got := next.Due.In(time.Local)
+		if lastDay := time.Date(got.Year(), got.Month()+1, 0, 0, 0, 0, 0, time.Local).Day(); got.Day() != lastDay {
+			t.Errorf("next due = %v, want the last day of its month", got)
+		}
+	})
+
+	t.Run("it drops a parent that the completion closed", func(t *testing.T) {
+		p := openProject(t)
+		parent := mustCreate(t, p, CreateOptions{Title: "Ops"})
+		chore := mustCreate(t, p, CreateOptions{Title: "Rotate credentials", Parent: parent.ID, Recur: "daily"})
+
+		cr, err := p.Transition(chore.ID, "done")
+		if err != nil {
+			t.Fatalf("Transition returned error: %v", err)
+		}
+		if len(cr.Cascaded) != 1 || cr.Cascaded[0].ID != parent.ID {
+			t.Fatalf("Cascaded = %+v, want the parent completed", cr.Cascaded)
+		}
+		if len(cr.Recurred) != 1 || cr.Recurred[0].Parent != "" {
+			t.Errorf("Recurred = %+v, want a top-level next occurrence", cr.Recurred)
+		}
+	})
+
+	t.Run("it does not recur on cancel or for one-off tasks", func(t *testing.T) {
+		p := openProject(t)
+		chore := mustCreate(t, p, CreateOptions{Title: "Rotate credentials", Recur: "daily"})
+		oneOff := mustCreate(t, p, CreateOptions{Title: "One-off"})
+
+		for id, action := range map[string]string{chore.ID: "cancel", oneOff.ID: "done"} {
+			cr, err := p.Transition(id, action)
+			if err != nil {
+				t.Fatalf("Transition returned error: %v", err)
+			}
+			if len(cr.Recurred) != 0 {
+				t.Errorf("%s %s: Recurred = %+v, want nil", action, id, cr.Recurred)
+			}
+		}
+		if tasks, _ := p.List(Filter{}); len(tasks) != 2 {
+			t.Errorf("List = %v, want no new tasks", ids(tasks))
+		}
+	})
+
+	t.Run("it recurs on a workflow command into a completing state", func(t *testing.T) {
+		dir := setupProjectDir(t)
+		config := "workflow:\n  states:\n    - {name: shipped, category: terminal}\n  commands:\n    - {name: ship, from: [open, in_progress], to: shipped}\n"
+		if err := os.WriteFile(filepath.Join(dir, ".tick", "config.yaml"), []byte(config), 0644); err != nil {
+			t.Fatalf("failed to write config.yaml: %v", err)
+		}
+		p, err := Open(dir)
+		if err != nil {
+			t.Fatalf("Open returned error: %v", err)
+		}
+		defer p.Close()
+		release := mustCreate(t, p, CreateOptions{Title: "Cut a release", Recur: "weekly"})
+
+		cr, err := p.Transition(release.ID, "ship")
+		if err != nil {
+			t.Fatalf("Transition returned error: %v", err)
+		}
+		if len(cr.Recurred) != 1 || cr.Recurred[0].RecurredFrom != release.ID {
+			t.Errorf("Recurred = %+v, want an occurrence of %s", cr.Recurred, release.ID)
+		}
+	})
+
+	t.Run("it recurs within a batch", func(t *testing.T) {
+		p := openProject(t)
+		result, err := p.Batch([]BatchOp{
+			{Kind: BatchCreate, Ref: "chore", Create: CreateOptions{Title: "Rotate credentials", Recur: "monthly"}},
+			{Kind: BatchTransition, ID: "$chore", Action: "done"},
+		})
+		if err != nil {
+			t.Fatalf("Batch returned error: %v", err)
+		}
+		next := result.Ops[1].Transition.Recurred
+		if len(next) != 1 || next[0].RecurredFrom != result.IDs["chore"] {
+			t.Errorf("Recurred = %+v, want an occurrence from %s", next, result.IDs["chore"])
+		}
+	})
+
+	t.Run("it recurs tasks completed by a cascade", func(t *testing.T) {
+		p := openProject(t)
+		parent := mustCreate(t, p, CreateOptions{Title: "Ops"})
+		chore := mustCreate(t, p, CreateOptions{Title: "Rotate credentials", Parent: parent.ID, Recur: "weekly"})
+
+		cr, err := p.Transition(parent.ID, "done")
+		if err != nil {
+			t.Fatalf("Transition returned error: %v", err)
+		}
+		if len(cr.Cascaded) != 1 || cr.Cascaded[0].ID != chore.ID || cr.Cascaded[0].NewStatus != string(task.StatusDone) {
+			t.Fatalf("Cascaded = %+v, want %s completed", cr.Cascaded, chore.ID)
+		}
+		if len(cr.Recurred) != 1 || cr.Recurred[0].RecurredFrom != chore.ID || cr.Recurred[0].Parent != "" {
+			t.Fatalf("Recurred = %+v, want a top-level occurrence of %s", cr.Recurred, chore.ID)
+		}
+		if tasks, _ := p.List(Filter{Recurring: true}); len(tasks) != 1 || tasks[0].ID != cr.Recurred[0].ID {
+			t.Errorf("List(Recurring) = %v, want only %s", ids(tasks), cr.Recurred[0].ID)
+		}
+	})
+
+	t.Run("it recurs a parent and its child completed together", func(t *testing.T) {
+		p := openProject(t)
+		review := mustCreate(t, p, CreateOptions{Title: "Weekly review", Recur: "weekly"})
+		inbox := mustCreate(t, p, CreateOptions{Title: "Clear inbox", Parent: review.ID, Recur: "daily"})
+
+		cr, err := p.Transition(inbox.ID, "done")
+		if err != nil {
+			t.Fatalf("Transition returned error: %v", err)
+		}
+		if len(cr.Recurred) != 2 || cr.Recurred[0].RecurredFrom != inbox.ID || cr.Recurred[1].RecurredFrom != review.ID {
+			t.Errorf("Recurred = %+v, want occurrences of %s then %s", cr.Recurred, inbox.ID, review.ID)
+		}
+	})
+
+	t.Run("it recurs a parent completed by moving its last open child away", func(t *testing.T) {
+		p := openProject(t)
+		review := mustCreate(t, p, CreateOptions{Title: "Weekly review", Recur: "weekly"})
+		done := mustCreate(t, p, CreateOptions{Title: "Clear inbox", Parent: review.ID})
+		moved := mustCreate(t, p, CreateOptions{Title: "Plan sprint", Parent: review.ID})
+		if _, err := p.Transition(done.ID, "done"); err != nil {
+			t.Fatalf("Transition returned error: %v", err)
+		}
+
+		result, err := p.Update(moved.ID, UpdateOptions{Parent: new("")})
+		if err != nil {
+			t.Fatalf("Update returned error: %v", err)
+		}
+		cr := result.ParentCompleted
+		if cr == nil || cr.TaskID != review.ID || len(cr.Recurred) != 1 || cr.Recurred[0].RecurredFrom != review.ID {
+			t.Errorf("ParentCompleted = %+v, want %s completed and recurred", cr, review.ID)
+		}
+	})
+
+	t.Run("it lists recurring tasks and clears the rule on update", func(t *testing.T) {
+		p := openProject(t)
+		chore := mustCreate(t, p, CreateOptions{Title: "Rotate credentials", Recur: "weekly"})
+		mustCreate(t, p, CreateOptions{Title: "One-off"})
+
+		tasks, err := p.List(Filter{Recurring: true})
+		if err != nil {
+			t.Fatalf("List returned error: %v", err)
+		}
+		if len(tasks) != 1 || tasks[0].ID != chore.ID || tasks[0].Recur != "weekly" {
+			t.Errorf("List(Recurring) = %+v, want only %s", tasks, chore.ID)
+		}
+
+		result, err := p.Update(chore.ID, UpdateOptions{Recur: new("")})
+		if err != nil {
+			t.Fatalf("Update returned error: %v", err)
+		}
+		if result.Task.Recur != "" {
+			t.Errorf("Recur after clearing = %q, want empty", result.Task.Recur)
+		}
+	})
+
+	t.Run("it rejects an invalid rule", func(t *testing.T) {
+		p := openProject(t)
+		_, err := p.Create(CreateOptions{Title: "Chore", Recur: "hourly"})
+		if err == nil || !strings.Contains(err.Error(), "invalid recurrence") {
+			t.Errorf("Create error = %v, want invalid recurrence", err)
+		}
+	})
+}
@@ -0,0 +1,260 @@
+package tick
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/leeovery/tick/internal/query"
+	"github.com/leeovery/tick/internal/task"
+)
+
+// RelatedTask is a task referenced from another task's details, as a blocker
+// or a child.
+type RelatedTask struct {
+	ID     string
+	Title  string
+	Status string
+}
+
+// Field is a custom key/value field of a task.
+type Field struct {
+	Key   string
+	Value string
+}
+
+// TaskDetail holds a task together with its related context: blockers,
+// children, parent title, tags, refs, fields, notes and estimate rollup.
+type TaskDetail struct {
+	Task        Task
+	BlockedBy   []RelatedTask
+	Children    []RelatedTask
+	ParentTitle string
+	Tags        []string
+	Refs        []string
+	// Fields are ordered by key.
+	Fields []Field
+	Notes  []Note
+	// Rollup totals the estimates of the task and all its descendants. It is
+	// nil unless the task has children and the subtree has an estimate.
+	Rollup *Rollup
+}
+
+// Show returns the full details of the task with the given ID.
+func (p *Project) Show(id string) (TaskDetail, error) {
+	id, err := p.store.ResolveID(id)
+	if err != nil {
+		return TaskDetail{}, err
+	}
+
+	var d TaskDetail
+	err = p.store.Query(func(db *sql.DB) error {
+		var status, created, updated string
+		var descPtr, parentPtr, closedPtr, duePtr, deferPtr, recurPtr, recurredFromPtr, typePtr, assigneePtr, leasePtr, extraPtr *string
+		var estimatePtr *float64
+		err := db.QueryRow(
+			`SELECT id, title, status, priority, type, description, parent, created, updated, closed, due, defer_until, estimate, recur, recurred_from, assignee, lease_expires, extra FROM tasks WHERE id = ?`,
+			id,
+		).Scan(&d.Task.ID, &d.Task.Title, &status, &d.Task.Priority, &typePtr, &descPtr, &parentPtr, &created, &updated, &closedPtr, &duePtr, &deferPtr, &estimatePtr, &recurPtr, &recurredFromPtr, &assigneePtr, &leasePtr, &extraPtr)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("task '%s' not found", id)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to query task: %w", err)
+		}
+
+		d.Task.Status = task.Status(status)
+		d.Task.Created, _ = time.Parse(task.TimestampFormat, created)
+		d.Task.Updated, _ = time.Parse(task.TimestampFormat, updated)
+		if typePtr != nil {
+			d.Task.Type = *typePtr
+		}
+		if descPtr != nil {
+			d.Task.Description = *descPtr
+		}
+		if parentPtr != nil {
+			d.Task.Parent = *parentPtr
+		}
+		if closedPtr != nil {
+			closedTime, _ := time.Parse(task.TimestampFormat, *closedPtr)
+			d.Task.Closed = &closedTime
+		}
+		if duePtr != nil {
+			dueTime, _ := time.Parse(task.TimestampFormat, *duePtr)
+			d.Task.Due = &dueTime
+		}
+		if deferPtr != nil {
+			deferTime, _ := time.Parse(task.TimestampFormat, *deferPtr)
+			d.Task.DeferUntil = &deferTime
+		}
+		if estimatePtr != nil {
+			d.Task.Estimate = *estimatePtr
+		}
+		if recurPtr != nil {
+			d.Task.Recur = *recurPtr
+		}
+		if recurredFromPtr != nil {
+			d.Task.RecurredFrom = *recurredFromPtr
+		}
+		if assigneePtr != nil {
+			d.Task.Assignee = *assigneePtr
+		}
+		if leasePtr != nil {
+			leaseTime, _ := time.Parse(task.TimestampFormat, *leasePtr)
+			d.Task.LeaseExpires = &leaseTime
+		}
+		if extraPtr != nil {
+			_ = json.Unmarshal([]byte(*extraPtr), &d.Task.Extra)
+		}
+
+		// Query parent title if parent is set.
+		if d.Task.Parent != "" {
+			var parentTitle string
+			err := db.QueryRow(`SELECT title FROM tasks WHERE id = ?`, d.Task.Parent).Scan(&parentTitle)
+			if err == nil {
+				d.ParentTitle = parentTitle
+			}
+			// If parent not found, we still show the parent ID without title
+		}
+
+		// Query blocked_by dependencies with context.
+		d.BlockedBy, err = queryRelatedTasks(db,
+			`SELECT t.id, t.title, t.status FROM dependencies d JOIN tasks t ON d.blocked_by = t.id WHERE d.task_id = ? ORDER BY t.id`,
+			id,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to query dependencies: %w", err)
+		}
+
+		// Query children with context.
+		d.Children, err = queryRelatedTasks(db,
+			`SELECT id, title, status FROM tasks WHERE parent = ? ORDER BY id`,
+			id,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to query children: %w", err)
+		}
+
+		// Roll up the estimates of the subtree.
+		if len(d.Children) > 0 {
+			rollup, err := query.SubtreeRollup(db, id)
+			if err != nil {
+				return err
+			}
+			if rollup.Total > 0 {
+				d.Rollup = &rollup
+			}
+		}
+
+		// Query tags.
+		d.Tags, err = queryStringColumn(db,
+			`SELECT tag FROM task_tags WHERE task_id = ? ORDER BY tag`,
+			id,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to query tags: %w", err)
+		}
+
+		// Query refs.
+		d.Refs, err = queryStringColumn(db,
+			`SELECT ref FROM task_refs WHERE task_id = ? ORDER BY ref`,
+			id,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to query refs: %w", err)
+		}
+
+		// Query fields.
+		d.Fields, err = queryFields(db, id)
+		if err != nil {
+			return fmt.Errorf("failed to query fields: %w", err)
+		}
+
+		// Query notes.
+		noteRows, err := db.Query(
+			`SELECT text, created FROM task_notes WHERE task_id = ? ORDER BY created ASC`,
+			id,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to query notes: %w", err)
+		}
+		defer noteRows.Close()
+
+		for noteRows.Next() {
+			var text, createdStr string
+			if err := noteRows.Scan(&text, &createdStr); err != nil {
+				return fmt.Errorf("failed to scan note row: %w", err)
+			}
+			created, err := time.Parse(task.TimestampFormat, createdStr)
+			if err != nil {
+				return fmt.Errorf("failed to parse note timestamp: %w", err)
+			}
+			d.Notes = append(d.Notes, task.Note{Text: text, Created: created})
+		}
+		return noteRows.Err()
+	})
+	if err != nil {
+		return TaskDetail{}, err
+	}
+	return d, nil
+}
+
+// queryStringColumn executes query with id and scans a single string column per row.
+func queryStringColumn(db *sql.DB, query string, id string) ([]string, error) {
+	rows, err := db.Query(query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []string
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return result, rows.Err()
+}
+
+// queryFields returns the custom fields of the task with the given ID, ordered
+// by key.
+func queryFields(db *sql.DB, id string) ([]Field, error) {
+	rows, err := db.Query(`SELECT key, value FROM task_fields WHERE task_id = ? ORDER BY key`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Field
+	for rows.Next() {
+		var f Field
+		if err := rows.Scan(&f.Key, &f.Value); err != nil {
+			return nil, err
+		}
+		result = append(result, f)
+	}
+	return result, rows.Err()
+}
+
+// queryRelatedTasks executes query with id and scans (id, title, status) per row.
+func queryRelatedTasks(db *sql.DB, query string, id string) ([]RelatedTask, error) {
+	rows, err := db.Query(query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []RelatedTask
+	for rows.Next() {
+		var r RelatedTask
+		if err := rows.Scan(&r.ID, &r.Title, &r.Status); err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+	return result, rows.Err()
+}
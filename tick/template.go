@@ -0,0 +1,58 @@
+package tick
+
+import (
+	"fmt"
+
+	"github.com/leeovery/tick/internal/template"
+)
+
+// TemplateOptions describes how CreateFromTemplate instantiates a template.
+type TemplateOptions struct {
+	// Vars are the values of the template's variables. Variables with a
+	// default may be left out.
+	Vars map[string]string
+	// Parent, when set, places the template's top-level tasks under an existing
+	// task, by full or partial ID.
+	Parent string
+}
+
+// CreateFromTemplate creates the task tree described by the template name in
+// .tick/templates/, filling in its variables from opts. The tasks are created
+// as a single Batch, with the same validation and parent cascades as Create:
+// if any task is invalid, nothing is written. Each op result's Ref is the key
+// of the template task it created.
+func (p *Project) CreateFromTemplate(name string, opts TemplateOptions) (BatchResult, error) {
+	tmpl, err := template.Load(p.store.Dir(), name)
+	if err != nil {
+		return BatchResult{}, err
+	}
+	tasks, err := tmpl.Expand(opts.Vars)
+	if err != nil {
+		return BatchResult{}, err
+	}
+
+	ops := make([]BatchOp, len(tasks))
+	for i, t := range tasks {
+		parent := opts.Parent
+		if t.Parent != "" {
+			parent = batchRefPrefix + t.Parent
+		}
+		blockedBy := make([]string, len(t.BlockedBy))
+		for j, key := range t.BlockedBy {
+			blockedBy[j] = batchRefPrefix + key
+		}
+		ops[i] = BatchOp{Kind: BatchCreate, Ref: t.Key, Create: CreateOptions{
+			Title:       t.Title,
+			Description: t.Description,
+			Priority:    t.Priority,
+			Type:        t.Type,
+			Tags:        t.Tags,
+			Estimate:    t.Estimate,
+			Parent:      parent,
+			BlockedBy:   blockedBy,
+		}}
+	}
+	return p.batch(ops, func(_ int, op BatchOp, err error) error {
+		return fmt.Errorf("template '%s', task '%s': %w", name, op.Ref, err)
+	})
+}
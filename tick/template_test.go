@@ -0,0 +1,112 @@
+package tick
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// openProjectWithTemplate opens a new project whose .tick/templates/ holds
+// content as the template name.
+func openProjectWithTemplate(t *testing.T, name, content string) *Project {
+	t.Helper()
+	dir := setupProjectDir(t)
+	templates := filepath.Join(dir, ".tick", "templates")
+	if err := os.Mkdir(templates, 0755); err != nil {
+		t.Fatalf("failed to create templates/: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(templates, name+".yaml"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	p, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	t.Cleanup(func() { p.Close() })
+	return p
+}
+
+const endpointTemplate = `vars:
+  name: {}
+tasks:
+  - key: endpoint
+    title: "Endpoint: {{name}}"
+    type: feature
+    tags: [api]
+    children:
+      - key: design
+        title: Design {{name}}
+      - key: implement
+        title: Implement {{name}}
+        blocked_by: [design]
+        estimate: 3
+`
+
+func TestCreateFromTemplate(t *testing.T) {
+	t.Run("it creates the task tree with its dependencies", func(t *testing.T) {
+		p := openProjectWithTemplate(t, "endpoint", endpointTemplate)
+
+		result, err := p.CreateFromTemplate("endpoint", TemplateOptions{Vars: map[string]string{"name": "login"}})
+		if err != nil {
+			t.Fatalf("CreateFromTemplate returned error: %v", err)
+		}
+		if len(result.Ops) != 3 || result.Ops[0].Ref != "endpoint" {
+			t.Fatalf("Ops = %+v, want three creates starting with endpoint", result.Ops)
+		}
+
+		root, err := p.Show(result.IDs["endpoint"])
+		if err != nil {
+			t.Fatalf("Show returned error: %v", err)
+		}
+		if root.Task.Title != "Endpoint: login" || root.Task.Type != "feature" || !slices.Equal(root.Tags, []string{"api"}) {
+			t.Errorf("root = %+v, tags %v", root.Task, root.Tags)
+		}
+		implement, err := p.Show(result.IDs["implement"])
+		if err != nil {
+			t.Fatalf("Show returned error: %v", err)
+		}
+		if implement.Task.Title != "Implement login" || implement.Task.Parent != result.IDs["endpoint"] ||
+			len(implement.BlockedBy) != 1 || implement.BlockedBy[0].ID != result.IDs["design"] || implement.Task.Estimate != 3 {
+			t.Errorf("implement = %+v, blocked by %+v", implement.Task, implement.BlockedBy)
+		}
+	})
+
+	t.Run("it places the tree under a parent and reopens it", func(t *testing.T) {
+		p := openProjectWithTemplate(t, "endpoint", endpointTemplate)
+		epic := mustCreate(t, p, CreateOptions{Title: "API"})
+		if _, err := p.Transition(epic.ID, "done"); err != nil {
+			t.Fatalf("Transition returned error: %v", err)
+		}
+
+		result, err := p.CreateFromTemplate("endpoint", TemplateOptions{Vars: map[string]string{"name": "login"}, Parent: epic.ID[len("tick-"):]})
+		if err != nil {
+			t.Fatalf("CreateFromTemplate returned error: %v", err)
+		}
+		if cr := result.Ops[0].ParentReopened; cr == nil || cr.TaskID != epic.ID || cr.NewStatus != string(task.StatusOpen) {
+			t.Errorf("ParentReopened = %+v, want %s reopened", cr, epic.ID)
+		}
+		root, err := p.Show(result.IDs["endpoint"])
+		if err != nil {
+			t.Fatalf("Show returned error: %v", err)
+		}
+		if root.Task.Parent != epic.ID {
+			t.Errorf("root parent = %q, want %s", root.Task.Parent, epic.ID)
+		}
+	})
+
+	t.Run("it writes nothing when a task is invalid", func(t *testing.T) {
+		p := openProjectWithTemplate(t, "endpoint", endpointTemplate)
+
+		_, err := p.CreateFromTemplate("endpoint", TemplateOptions{Vars: map[string]string{"name": strings.Repeat("x", 600)}})
+		if err == nil || !strings.Contains(err.Error(), "template 'endpoint', task 'endpoint': ") {
+			t.Fatalf("CreateFromTemplate error = %v, want the failing template task named", err)
+		}
+		if tasks, _ := p.List(Filter{}); len(tasks) != 0 {
+			t.Errorf("List = %v, want no tasks", ids(tasks))
+		}
+	})
+}
@@ -0,0 +1,153 @@
+// Package tick is the Go API for reading and changing the tasks of a tick
+// project. It applies the same validation, status cascades and ready/blocked
+// rules as the tick CLI, which is built on it.
+package tick
+
+import (
+	"time"
+
+	"github.com/leeovery/tick/internal/config"
+	"github.com/leeovery/tick/internal/query"
+	"github.com/leeovery/tick/internal/storage"
+	"github.com/leeovery/tick/internal/task"
+)
+
+// Task is a single tick task.
+type Task = task.Task
+
+// Status is the lifecycle state of a task.
+type Status = task.Status
+
+// Task statuses.
+const (
+	StatusOpen       = task.StatusOpen
+	StatusInProgress = task.StatusInProgress
+	StatusDone       = task.StatusDone
+	StatusCancelled  = task.StatusCancelled
+)
+
+// Workflow is the set of states and transition commands of a project.
+type Workflow = task.Workflow
+
+// Note is a timestamped note attached to a task.
+type Note = task.Note
+
+// Filter restricts the tasks returned by List.
+type Filter = query.Filter
+
+// FieldFilter matches tasks by a custom field in a Filter.
+type FieldFilter = query.FieldFilter
+
+// Rollup totals the estimates of a task and its descendants.
+type Rollup = query.Rollup
+
+// Config is a project's configuration, from its .tick/config.yaml.
+type Config = config.Config
+
+// Project is an open tick project. All reads and writes go through the same
+// lock, journal and cache as the CLI, so a Project is safe to use alongside
+// tick commands. Callers must Close it when done.
+type Project struct {
+	store *storage.Store
+}
+
+// Option configures a Project at Open time.
+type Option func(*[]storage.StoreOption)
+
+// WithVerbose routes debug messages about locking, cache and journal activity
+// to log.
+func WithVerbose(log func(msg string)) Option {
+	return func(opts *[]storage.StoreOption) {
+		*opts = append(*opts, storage.WithVerbose(log))
+	}
+}
+
+// WithCommand sets the command line recorded with each change in the
+// operation journal.
+func WithCommand(command string) Option {
+	return func(opts *[]storage.StoreOption) {
+		*opts = append(*opts, storage.WithCommand(command))
+	}
+}
+
+// WithConfig opens the project with a configuration already loaded from its
+// .tick/config.yaml with LoadConfig, instead of reading the file again.
+func WithConfig(cfg Config) Option {
+	return func(opts *[]storage.StoreOption) {
+		*opts = append(*opts, storage.WithConfig(cfg))
+	}
+}
+
+// WithLockTimeout sets how long to wait for the project lock before giving up.
+func WithLockTimeout(d time.Duration) Option {
+	return func(opts *[]storage.StoreOption) {
+		*opts = append(*opts, storage.WithLockTimeout(d))
+	}
+}
+
+// WithArchived includes archived tasks in reads. A Project opened this way
+// refuses all changes.
+func WithArchived() Option {
+	return func(opts *[]storage.StoreOption) {
+		*opts = append(*opts, storage.WithArchived())
+	}
+}
+
+// WithAsOf makes reads show the tasks as they stood at the instant at, with
+// statuses replayed from their transition history. A Project opened this way
+// refuses all changes.
+func WithAsOf(at time.Time) Option {
+	return func(opts *[]storage.StoreOption) {
+		*opts = append(*opts, storage.WithAsOf(at))
+	}
+}
+
+// LoadConfig discovers the .tick directory from dir like Open, and loads the
+// project's configuration. An invalid config.yaml is an error.
+func LoadConfig(dir string) (Config, error) {
+	tickDir, err := DiscoverTickDir(dir)
+	if err != nil {
+		return Config{}, err
+	}
+	return config.Load(tickDir)
+}
+
+// Open discovers the .tick directory from dir, walking up like the CLI does,
+// and opens the project.
+func Open(dir string, opts ...Option) (*Project, error) {
+	tickDir, err := DiscoverTickDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var storeOpts []storage.StoreOption
+	for _, opt := range opts {
+		opt(&storeOpts)
+	}
+	store, err := storage.NewStore(tickDir, storeOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Project{store: store}, nil
+}
+
+// Workflow returns the project's workflow: the built-in states and commands
+// and any it adds in .tick/config.yaml.
+func (p *Project) Workflow() Workflow {
+	return p.store.Config().Rules().Workflow
+}
+
+// stateMachine returns the state machine of the project's workflow.
+func (p *Project) stateMachine() task.StateMachine {
+	return task.StateMachine{Workflow: p.store.Config().Rules().Workflow}
+}
+
+// Close releases the project's resources.
+func (p *Project) Close() error {
+	return p.store.Close()
+}
+
+// ResolveID resolves a full or partial (prefix) task ID to the full ID of an
+// existing task. All Project methods that take task IDs accept partial IDs.
+func (p *Project) ResolveID(id string) (string, error) {
+	return p.store.ResolveID(id)
+}
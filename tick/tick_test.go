@@ -0,0 +1,306 @@
+package tick
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/storage"
+)
+
+// setupProjectDir creates an empty tick project in the current format in a
+// temp dir and returns the dir.
+func setupProjectDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	tickDir := filepath.Join(dir, ".tick")
+	if err := os.Mkdir(tickDir, 0755); err != nil {
+		t.Fatalf("failed to create .tick/: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tickDir, "tasks.jsonl"), []byte{}, 0644); err != nil {
+		t.Fatalf("failed to create tasks.jsonl: %v", err)
+	}
+	if err := storage.WriteFormatVersion(tickDir, storage.FormatVersion); err != nil {
+		t.Fatalf("failed to write format file: %v", err)
+	}
+	return dir
+}
+
+// openProject creates an empty tick project in a temp dir and opens it.
+func openProject(t *testing.T) *Project {
+	t.Helper()
+	p, err := Open(setupProjectDir(t))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	t.Cleanup(func() { p.Close() })
+	return p
+}
+
+func mustCreate(t *testing.T, p *Project, opts CreateOptions) Task {
+	t.Helper()
+	result, err := p.Create(opts)
+	if err != nil {
+		t.Fatalf("Create(%q) returned error: %v", opts.Title, err)
+	}
+	return result.Task
+}
+
+func ids(tasks []Task) []string {
+	var out []string
+	for _, t := range tasks {
+		out = append(out, t.ID)
+	}
+	return out
+}
+
+func TestProject(t *testing.T) {
+	t.Run("it creates tasks with defaults and validated fields", func(t *testing.T) {
+		p := openProject(t)
+
+		created := mustCreate(t, p, CreateOptions{Title: "  Write docs  ", Type: "Feature", Tags: []string{"Docs", "docs"}})
+		if created.Title != "Write docs" || created.Priority != 2 || created.Status != StatusOpen {
+			t.Errorf("created = %+v", created)
+		}
+		if created.Type != "feature" || len(created.Tags) != 1 || created.Tags[0] != "docs" {
+			t.Errorf("type = %q, tags = %v", created.Type, created.Tags)
+		}
+
+		if _, err := p.Create(CreateOptions{Title: ""}); err == nil {
+			t.Error("Create with an empty title should fail")
+		}
+		if _, err := p.Create(CreateOptions{Title: "Bad", Priority: new(7)}); err == nil {
+			t.Error("Create with priority 7 should fail")
+		}
+	})
+
+	t.Run("it reports cascades from transitions", func(t *testing.T) {
+		p := openProject(t)
+		parent := mustCreate(t, p, CreateOptions{Title: "Parent"})
+		child := mustCreate(t, p, CreateOptions{Title: "Child", Parent: parent.ID})
+
+		cr, err := p.Transition(child.ID, "start")
+		if err != nil {
+			t.Fatalf("Transition returned error: %v", err)
+		}
+		if cr.TaskID != child.ID || cr.OldStatus != "open" || cr.NewStatus != "in_progress" {
+			t.Errorf("result = %+v", cr)
+		}
+		if len(cr.Cascaded) != 1 || cr.Cascaded[0].ID != parent.ID || cr.Cascaded[0].NewStatus != "in_progress" {
+			t.Errorf("cascaded = %+v, want parent started", cr.Cascaded)
+		}
+
+		if _, err := p.Transition(child.ID, "done"); err != nil {
+			t.Fatalf("Transition returned error: %v", err)
+		}
+		detail, err := p.Show(parent.ID)
+		if err != nil {
+			t.Fatalf("Show returned error: %v", err)
+		}
+		if detail.Task.Status != StatusDone {
+			t.Errorf("parent status = %q, want done", detail.Task.Status)
+		}
+
+		// Adding a child to a done parent reopens it.
+		result, err := p.Create(CreateOptions{Title: "Follow-up", Parent: parent.ID})
+		if err != nil {
+			t.Fatalf("Create returned error: %v", err)
+		}
+		if result.ParentReopened == nil || result.ParentReopened.NewStatus != "open" {
+			t.Errorf("ParentReopened = %+v, want parent reopened", result.ParentReopened)
+		}
+	})
+
+	t.Run("it updates and clears fields", func(t *testing.T) {
+		p := openProject(t)
+		created := mustCreate(t, p, CreateOptions{Title: "Task", Description: "Details", Type: "bug"})
+
+		result, err := p.Update(created.ID[:8], UpdateOptions{Title: new("Renamed"), Description: new(""), Type: new("")})
+		if err != nil {
+			t.Fatalf("Update returned error: %v", err)
+		}
+		if result.Task.Title != "Renamed" || result.Task.Description != "" || result.Task.Type != "" {
+			t.Errorf("updated = %+v", result.Task)
+		}
+
+		if _, err := p.Update(created.ID, UpdateOptions{Title: new("")}); err == nil {
+			t.Error("Update to an empty title should fail")
+		}
+	})
+
+	t.Run("it lists ready and blocked tasks by dependency", func(t *testing.T) {
+		p := openProject(t)
+		blocker := mustCreate(t, p, CreateOptions{Title: "Blocker", Priority: new(1)})
+		blocked := mustCreate(t, p, CreateOptions{Title: "Blocked"})
+
+		if err := p.AddDep(blocked.ID, blocker.ID); err != nil {
+			t.Fatalf("AddDep returned error: %v", err)
+		}
+		if err := p.AddDep(blocked.ID, blocker.ID); err == nil {
+			t.Error("AddDep should refuse a duplicate dependency")
+		}
+
+		ready, err := p.Ready()
+		if err != nil || len(ready) != 1 || ready[0].ID != blocker.ID {
+			t.Errorf("Ready = %v, %v; want [%s]", ids(ready), err, blocker.ID)
+		}
+		blockedTasks, err := p.Blocked()
+		if err != nil || len(blockedTasks) != 1 || blockedTasks[0].ID != blocked.ID {
+			t.Errorf("Blocked = %v, %v; want [%s]", ids(blockedTasks), err, blocked.ID)
+		}
+		detail, err := p.Show(blocked.ID)
+		if err != nil || len(detail.BlockedBy) != 1 || detail.BlockedBy[0].ID != blocker.ID {
+			t.Errorf("Show BlockedBy = %+v, %v", detail.BlockedBy, err)
+		}
+
+		if err := p.RemoveDep(blocked.ID, blocker.ID); err != nil {
+			t.Fatalf("RemoveDep returned error: %v", err)
+		}
+		ready, _ = p.Ready()
+		if len(ready) != 2 {
+			t.Errorf("Ready after RemoveDep = %v, want both tasks", ids(ready))
+		}
+
+		all, err := p.List(Filter{Priority: 1, HasPriority: true})
+		if err != nil || len(all) != 1 || all[0].ID != blocker.ID {
+			t.Errorf("List priority 1 = %v, %v", ids(all), err)
+		}
+		if _, err := p.List(Filter{Ready: true, Blocked: true}); err == nil {
+			t.Error("List should reject Ready with Blocked")
+		}
+	})
+
+	t.Run("it adds and removes notes", func(t *testing.T) {
+		p := openProject(t)
+		created := mustCreate(t, p, CreateOptions{Title: "Task"})
+
+		if err := p.AddNote(created.ID, "First note"); err != nil {
+			t.Fatalf("AddNote returned error: %v", err)
+		}
+		detail, _ := p.Show(created.ID)
+		if len(detail.Notes) != 1 || detail.Notes[0].Text != "First note" {
+			t.Errorf("notes = %+v", detail.Notes)
+		}
+
+		if err := p.RemoveNote(created.ID, 2); err == nil {
+			t.Error("RemoveNote should reject an out-of-range index")
+		}
+		if err := p.RemoveNote(created.ID, 1); err != nil {
+			t.Fatalf("RemoveNote returned error: %v", err)
+		}
+	})
+}
+
+// customWorkflow adds an active testing state, entered from open by qa or from
+// in_progress by test, and a terminal wont_fix state.
+const customWorkflow = `workflow:
+  states:
+    - {name: testing, category: active}
+    - {name: wont_fix, category: terminal}
+  commands:
+    - {name: qa, from: [open], to: testing}
+    - {name: test, from: [in_progress], to: testing}
+    - {name: wont-fix, from: [open, in_progress, testing], to: wont_fix}
+    - {name: done, from: [open, in_progress, testing], to: done}
+`
+
+func TestCustomWorkflow(t *testing.T) {
+	open := func(t *testing.T) *Project {
+		t.Helper()
+		dir := setupProjectDir(t)
+		if err := os.WriteFile(filepath.Join(dir, ".tick", "config.yaml"), []byte(customWorkflow), 0644); err != nil {
+			t.Fatalf("failed to write config.yaml: %v", err)
+		}
+		p, err := Open(dir)
+		if err != nil {
+			t.Fatalf("Open returned error: %v", err)
+		}
+		t.Cleanup(func() { p.Close() })
+		return p
+	}
+	transition := func(t *testing.T, p *Project, id string, actions ...string) CascadeResult {
+		t.Helper()
+		var cr CascadeResult
+		for _, action := range actions {
+			var err error
+			if cr, err = p.Transition(id, action); err != nil {
+				t.Fatalf("Transition(%s, %s) returned error: %v", id, action, err)
+			}
+		}
+		return cr
+	}
+
+	t.Run("it lists tasks in any started state first in ready and claims only open ones", func(t *testing.T) {
+		p := open(t)
+		urgent := mustCreate(t, p, CreateOptions{Title: "Urgent", Priority: new(0)})
+		inTesting := mustCreate(t, p, CreateOptions{Title: "Testing", Priority: new(3)})
+		transition(t, p, inTesting.ID, "start", "test")
+
+		ready, err := p.Ready()
+		if err != nil {
+			t.Fatalf("Ready returned error: %v", err)
+		}
+		if got := ids(ready); len(got) != 2 || got[0] != inTesting.ID || got[1] != urgent.ID {
+			t.Errorf("ready = %v, want %s (testing) before %s", got, inTesting.ID, urgent.ID)
+		}
+
+		claimed, err := p.Claim(Filter{Priority: 3, HasPriority: true}, "agent-1", time.Hour)
+		if !errors.Is(err, ErrNothingToClaim) {
+			t.Errorf("Claim of the testing task = %+v, %v; want ErrNothingToClaim", claimed.Task, err)
+		}
+		if claimed, err = p.Claim(Filter{}, "agent-1", time.Hour); err != nil || claimed.Task.ID != urgent.ID {
+			t.Errorf("Claim = %+v, %v; want %s", claimed.Task, err, urgent.ID)
+		}
+	})
+
+	t.Run("it starts open ancestors of a task moved to a started state", func(t *testing.T) {
+		p := open(t)
+		parent := mustCreate(t, p, CreateOptions{Title: "Parent"})
+		child := mustCreate(t, p, CreateOptions{Title: "Child", Parent: parent.ID})
+
+		cr := transition(t, p, child.ID, "qa")
+		if len(cr.Cascaded) != 1 || cr.Cascaded[0].ID != parent.ID || cr.Cascaded[0].NewStatus != string(StatusInProgress) {
+			t.Errorf("Cascaded = %+v, want %s started", cr.Cascaded, parent.ID)
+		}
+	})
+
+	t.Run("it moves a parent to the terminal state all its children share", func(t *testing.T) {
+		p := open(t)
+		parent := mustCreate(t, p, CreateOptions{Title: "Parent"})
+		first := mustCreate(t, p, CreateOptions{Title: "First", Parent: parent.ID})
+		second := mustCreate(t, p, CreateOptions{Title: "Second", Parent: parent.ID})
+		transition(t, p, first.ID, "wont-fix")
+
+		cr := transition(t, p, second.ID, "wont-fix")
+		if len(cr.Cascaded) != 1 || cr.Cascaded[0].ID != parent.ID || cr.Cascaded[0].NewStatus != "wont_fix" {
+			t.Errorf("Cascaded = %+v, want %s moved to wont_fix", cr.Cascaded, parent.ID)
+		}
+	})
+
+	t.Run("it opens a project with a config loaded by LoadConfig", func(t *testing.T) {
+		dir := setupProjectDir(t)
+		configPath := filepath.Join(dir, ".tick", "config.yaml")
+		if err := os.WriteFile(configPath, []byte(customWorkflow), 0644); err != nil {
+			t.Fatalf("failed to write config.yaml: %v", err)
+		}
+		cfg, err := LoadConfig(dir)
+		if err != nil {
+			t.Fatalf("LoadConfig returned error: %v", err)
+		}
+		// The project must use the loaded config, not read the file again.
+		if err := os.Remove(configPath); err != nil {
+			t.Fatalf("failed to remove config.yaml: %v", err)
+		}
+
+		p, err := Open(dir, WithConfig(cfg))
+		if err != nil {
+			t.Fatalf("Open returned error: %v", err)
+		}
+		defer p.Close()
+		if _, ok := p.Workflow().State("testing"); !ok {
+			t.Errorf("workflow states = %v, want the configured testing state", p.Workflow().StateNames())
+		}
+	})
+}
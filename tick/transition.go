@@ -0,0 +1,53 @@
+package tick
+
+import (
+	"fmt"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// Transition applies a status action (start, done, cancel, reopen, or a command
+// of the project's workflow) to the task with the given ID, along with the
+// status changes it cascades to the task's parent and children. The result always describes the primary transition;
+// Cascaded is empty when nothing else changed. Completing a recurring task,
+// directly or by cascade, creates its next occurrence, returned in Recurred.
+func (p *Project) Transition(id, action string) (CascadeResult, error) {
+	id, err := p.store.ResolveID(id)
+	if err != nil {
+		return CascadeResult{}, err
+	}
+
+	var cr CascadeResult
+	rules := p.store.Config().Rules()
+
+	err = p.store.Mutate(func(tasks []task.Task) ([]task.Task, error) {
+		var err error
+		tasks, cr, err = applyTransition(tasks, id, action, rules)
+		return tasks, err
+	})
+	if err != nil {
+		return CascadeResult{}, err
+	}
+	return cr, nil
+}
+
+// applyTransition applies a status action to the task with the given full ID
+// in tasks under the workflow of rules, along with its cascades. The next
+// occurrence of every recurring task it completes is appended to tasks.
+func applyTransition(tasks []task.Task, id, action string, rules task.Rules) ([]task.Task, CascadeResult, error) {
+	sm := task.StateMachine{Workflow: rules.Workflow}
+	for i := range tasks {
+		if tasks[i].ID == id {
+			r, c, err := sm.ApplyUserTransition(tasks, &tasks[i], action)
+			if err != nil {
+				return nil, CascadeResult{}, err
+			}
+			cr := buildCascadeResult(id, tasks[i].Title, r, c, tasks)
+			if tasks, err = spawnOccurrences(tasks, &cr, rules); err != nil {
+				return nil, CascadeResult{}, err
+			}
+			return tasks, cr, nil
+		}
+	}
+	return nil, CascadeResult{}, fmt.Errorf("task '%s' not found", id)
+}
@@ -0,0 +1,297 @@
+package tick
+
+import (
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+// UpdateOptions describes changes to an existing task. Nil fields are left
+// unchanged; a non-nil pointer to an empty value clears the field.
+type UpdateOptions struct {
+	Title       *string
+	Description *string
+	Priority    *int
+	Type        *string
+	Tags        *[]string
+	Refs        *[]string
+	// Fields sets each listed field, replacing any existing value.
+	Fields map[string]string
+	// ClearFields removes each listed field from the task.
+	ClearFields []string
+	// Assignee reassigns the task; empty unassigns it. Either way, a lease
+	// held through tick claim is dropped when the assignee changes.
+	Assignee *string
+	// Due sets the deadline; a zero time removes it.
+	Due *time.Time
+	// Estimate sets the expected effort; zero removes it.
+	Estimate *float64
+	// Recur sets the recurrence rule; empty makes the task a one-off.
+	Recur *string
+	// Parent moves the task under another task, referenced by full or partial ID.
+	Parent *string
+	// Blocks adds the task as a blocker of each listed task.
+	Blocks []string
+}
+
+// Update validates opts and applies them to the task with the given ID. Moving
+// the task under a done parent reopens it; moving it away from a parent whose
+// remaining children are all closed completes that parent, which recurs if it
+// is a recurring task.
+func (p *Project) Update(id string, opts UpdateOptions) (MutationResult, error) {
+	opts, err := prepareUpdate(opts, p.store.Config().Rules())
+	if err != nil {
+		return MutationResult{}, err
+	}
+
+	// Resolve partial IDs.
+	id, err = p.store.ResolveID(id)
+	if err != nil {
+		return MutationResult{}, err
+	}
+	if opts.Parent != nil {
+		parent, err := p.resolveOptional(*opts.Parent)
+		if err != nil {
+			return MutationResult{}, err
+		}
+		opts.Parent = &parent
+	}
+	blocks, err := p.resolveAll(opts.Blocks)
+	if err != nil {
+		return MutationResult{}, err
+	}
+
+	var result MutationResult
+	rules := p.store.Config().Rules()
+	sm := task.StateMachine{Workflow: rules.Workflow}
+
+	err = p.store.Mutate(func(tasks []task.Task) ([]task.Task, error) {
+		var err error
+		if result, err = applyUpdate(tasks, id, opts, blocks, sm); err != nil {
+			return nil, err
+		}
+		if result.ParentCompleted != nil {
+			tasks, err = spawnOccurrences(tasks, result.ParentCompleted, rules)
+		}
+		return tasks, err
+	})
+	if err != nil {
+		return MutationResult{}, err
+	}
+	return result, nil
+}
+
+// prepareUpdate validates the fields of opts that do not reference other tasks
+// against rules and returns opts with its type, tags, refs, field keys,
+// assignee, due date and recurrence rule normalized.
+func prepareUpdate(opts UpdateOptions, rules task.Rules) (UpdateOptions, error) {
+	if opts.Title != nil {
+		if err := task.ValidateTitle(task.TrimTitle(*opts.Title)); err != nil {
+			return opts, err
+		}
+	}
+	if opts.Priority != nil {
+		if err := task.ValidatePriority(*opts.Priority); err != nil {
+			return opts, err
+		}
+	}
+	if opts.Type != nil {
+		normalized := task.NormalizeType(*opts.Type)
+		if err := rules.ValidateType(normalized); err != nil {
+			return opts, err
+		}
+		opts.Type = &normalized
+	}
+	if opts.Tags != nil {
+		deduped := task.DeduplicateTags(*opts.Tags)
+		if err := rules.ValidateTags(deduped); err != nil {
+			return opts, err
+		}
+		opts.Tags = &deduped
+	}
+	if opts.Refs != nil {
+		deduped := task.DeduplicateRefs(*opts.Refs)
+		if err := task.ValidateRefs(deduped); err != nil {
+			return opts, err
+		}
+		opts.Refs = &deduped
+	}
+	fields, err := normalizeFields(opts.Fields)
+	if err != nil {
+		return opts, err
+	}
+	opts.Fields = fields
+	var cleared []string
+	for _, key := range opts.ClearFields {
+		key = task.NormalizeFieldKey(key)
+		if err := task.ValidateFieldKey(key); err != nil {
+			return opts, err
+		}
+		if _, ok := fields[key]; ok {
+			return opts, fmt.Errorf("field %s cannot be both set and cleared", key)
+		}
+		cleared = append(cleared, key)
+	}
+	opts.ClearFields = cleared
+	if opts.Assignee != nil {
+		assignee := task.NormalizeAssignee(*opts.Assignee)
+		if assignee != "" {
+			if err := task.ValidateAssignee(assignee); err != nil {
+				return opts, err
+			}
+		}
+		opts.Assignee = &assignee
+	}
+	if opts.Due != nil && !opts.Due.IsZero() {
+		opts.Due = new(task.NormalizeDue(*opts.Due))
+	}
+	if opts.Estimate != nil {
+		if err := task.ValidateEstimate(*opts.Estimate); err != nil {
+			return opts, err
+		}
+	}
+	if opts.Recur != nil {
+		recur, err := task.NormalizeRecur(*opts.Recur)
+		if err != nil {
+			return opts, err
+		}
+		opts.Recur = &recur
+	}
+	return opts, nil
+}
+
+// applyUpdate applies prepared opts to the task with the given ID in tasks.
+// The ID, opts.Parent and blocks are full IDs; opts.Blocks is ignored in favour
+// of blocks. Parent status changes follow sm.
+func applyUpdate(tasks []task.Task, id string, opts UpdateOptions, blocks []string, sm task.StateMachine) (MutationResult, error) {
+	var result MutationResult
+
+	// Build ID set for reference validation with normalized keys.
+	idSet := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		idSet[task.NormalizeID(t.ID)] = true
+	}
+
+	// Validate referenced IDs exist and handle Rule 6 (reopen done parent).
+	if opts.Parent != nil && *opts.Parent != "" {
+		if *opts.Parent == id {
+			return result, fmt.Errorf("task %s cannot be its own parent", id)
+		}
+		if !idSet[*opts.Parent] {
+			return result, fmt.Errorf("task %q not found (referenced in --parent)", *opts.Parent)
+		}
+		// Validate parent allows adding children (Rule 7: blocks cancelled parent).
+		// If parent is done, trigger reopen cascade (Rule 6).
+		var err error
+		result.ParentReopened, err = reopenParent(tasks, *opts.Parent, &sm)
+		if err != nil {
+			return result, err
+		}
+	}
+	for _, blockID := range blocks {
+		if !idSet[blockID] {
+			return result, fmt.Errorf("task %q not found (referenced in --blocks)", blockID)
+		}
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	// Find and update the target task.
+	idx := -1
+	for i := range tasks {
+		if task.NormalizeID(tasks[i].ID) == id {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return result, fmt.Errorf("task '%s' not found", id)
+	}
+
+	t := &tasks[idx]
+	if opts.Title != nil {
+		t.Title = task.TrimTitle(*opts.Title)
+	}
+	if opts.Description != nil {
+		t.Description = task.TrimDescription(*opts.Description)
+	}
+	if opts.Priority != nil {
+		t.Priority = *opts.Priority
+	}
+	if opts.Type != nil {
+		t.Type = *opts.Type
+	}
+	if opts.Tags != nil {
+		t.Tags = *opts.Tags
+	}
+	if opts.Refs != nil {
+		t.Refs = *opts.Refs
+	}
+	if len(opts.Fields) > 0 || len(opts.ClearFields) > 0 {
+		fields := maps.Clone(t.Fields)
+		if fields == nil {
+			fields = make(map[string]string, len(opts.Fields))
+		}
+		maps.Copy(fields, opts.Fields)
+		for _, key := range opts.ClearFields {
+			delete(fields, key)
+		}
+		if err := task.ValidateFields(fields); err != nil {
+			return result, err
+		}
+		if len(fields) == 0 {
+			fields = nil
+		}
+		t.Fields = fields
+	}
+	if opts.Assignee != nil && *opts.Assignee != t.Assignee {
+		t.Assignee = *opts.Assignee
+		t.LeaseExpires = nil
+	}
+	if opts.Due != nil {
+		t.Due = nil
+		if !opts.Due.IsZero() {
+			t.Due = new(*opts.Due)
+		}
+	}
+	if opts.Estimate != nil {
+		t.Estimate = *opts.Estimate
+	}
+	if opts.Recur != nil {
+		t.Recur = *opts.Recur
+	}
+
+	// Capture original parent before updating.
+	originalParent := t.Parent
+
+	if opts.Parent != nil {
+		t.Parent = *opts.Parent
+	}
+	t.Updated = now
+	updated := *t
+
+	// Evaluate Rule 3 on original parent if parent changed and original was non-empty.
+	if opts.Parent != nil && originalParent != *opts.Parent && originalParent != "" {
+		if r3 := autoCompleteParentIfTerminal(tasks, originalParent, &sm); r3 != nil {
+			cr := buildCascadeResult(r3.parentID, r3.parentTitle, r3.result, r3.cascades, tasks)
+			result.ParentCompleted = &cr
+		}
+	}
+
+	// For Blocks: add this task's ID to target tasks' blocked_by and refresh updated.
+	if len(blocks) > 0 {
+		applyBlocks(tasks, id, blocks, now)
+
+		// Validate dependencies (cycle detection + child-blocked-by-parent + cancelled blocker) against full task list.
+		for _, blockID := range blocks {
+			if err := sm.ValidateAddDep(tasks, blockID, id); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	result.Task = updated
+	return result, nil
+}
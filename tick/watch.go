@@ -0,0 +1,290 @@
+package tick
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/leeovery/tick/internal/query"
+	"github.com/leeovery/tick/internal/task"
+)
+
+// EventKind identifies the kind of change a watch Event reports.
+type EventKind string
+
+const (
+	// EventCreated reports a new task; Event.Task holds it.
+	EventCreated EventKind = "created"
+	// EventUpdated reports changed fields; Event.Fields names them.
+	EventUpdated EventKind = "updated"
+	// EventTransition reports a status change; From, To and Auto describe it.
+	EventTransition EventKind = "transition"
+	// EventNoteAdded reports a new note; Event.Note holds its text.
+	EventNoteAdded EventKind = "note_added"
+	// EventRemoved reports a task that no longer exists, including archived tasks.
+	EventRemoved EventKind = "removed"
+)
+
+// Event is a single task change observed by Watch.
+type Event struct {
+	Kind EventKind
+	ID   string
+	// At is when the change happened, taken from the task data where it is
+	// recorded and from the time it was observed otherwise.
+	At     time.Time
+	Task   *Task
+	Fields []string
+	From   Status
+	To     Status
+	// Auto is set on transitions made by a cascade rather than directly.
+	Auto bool
+	Note string
+}
+
+// eventJSON is the serialized form of an Event: one object per line, with only
+// the fields that apply to its kind.
+type eventJSON struct {
+	Event  EventKind `json:"event"`
+	ID     string    `json:"id"`
+	At     string    `json:"at"`
+	Task   *Task     `json:"task,omitempty"`
+	Fields []string  `json:"fields,omitempty"`
+	From   Status    `json:"from,omitempty"`
+	To     Status    `json:"to,omitempty"`
+	Auto   *bool     `json:"auto,omitempty"`
+	Note   string    `json:"note,omitempty"`
+}
+
+// MarshalJSON serializes the event with its timestamp in tick's ISO 8601 form.
+func (e Event) MarshalJSON() ([]byte, error) {
+	out := eventJSON{
+		Event:  e.Kind,
+		ID:     e.ID,
+		At:     task.FormatTimestamp(e.At),
+		Task:   e.Task,
+		Fields: e.Fields,
+		From:   e.From,
+		To:     e.To,
+		Note:   e.Note,
+	}
+	if e.Kind == EventTransition {
+		out.Auto = &e.Auto
+	}
+	return json.Marshal(out)
+}
+
+// watchIgnoredFields are serialized task fields that never produce an updated
+// event: they are identity, bookkeeping, or reported by their own event kinds.
+var watchIgnoredFields = map[string]bool{
+	"id":          true,
+	"created":     true,
+	"updated":     true,
+	"status":      true,
+	"closed":      true,
+	"transitions": true,
+	"notes":       true,
+}
+
+// Watch polls the project's task data every interval and calls emit with each
+// change between successive snapshots, in task order, until ctx is done or emit
+// returns an error. Tasks present when Watch starts produce no events. f
+// restricts events to tasks that match it before or after the change, evaluated
+// against the cache with the same conditions as List; Ready, Blocked, Overdue
+// and Count are not supported. Reads take the shared lock, so a snapshot is
+// never a half-written file.
+func (p *Project) Watch(ctx context.Context, f Filter, interval time.Duration, emit func(Event) error) error {
+	if f.Ready || f.Blocked || f.Overdue || f.HasCount {
+		return errors.New("watch does not support the ready, blocked, overdue or count filters")
+	}
+	if err := f.ValidateFor(p.store.Config().Rules()); err != nil {
+		return err
+	}
+	if f.Parent != "" {
+		var err error
+		if f.Parent, err = p.store.ResolveID(f.Parent); err != nil {
+			return err
+		}
+	}
+
+	// matches holds the tasks of the latest snapshot that match f, queried from
+	// the cache under the same lock as the snapshot is read.
+	w := p.store.Config().Rules().Workflow
+	var matches map[string]bool
+	match := func(db *sql.DB) error {
+		var err error
+		matches, err = matchingIDs(db, f, w)
+		return err
+	}
+
+	prev, hash, err := p.store.PollTasks("", match)
+	if err != nil {
+		return err
+	}
+	prevMatches := matches
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		next, nextHash, err := p.store.PollTasks(hash, match)
+		if err != nil {
+			return err
+		}
+		if next == nil {
+			continue
+		}
+		now := time.Now().UTC().Truncate(time.Second)
+		for _, event := range diffSnapshots(prev, next, prevMatches, matches, now) {
+			if err := emit(event); err != nil {
+				return err
+			}
+		}
+		prev, prevMatches, hash = next, matches, nextHash
+	}
+}
+
+// diffSnapshots returns the events that turn the old snapshot into the new one:
+// for each task in new, in order, created or else updated, transition and
+// note_added events; then removed events for tasks missing from new. Only tasks
+// in oldMatches or newMatches, the IDs matching the filter in each snapshot
+// (nil for every task), are reported. now stamps events whose time is not
+// recorded in the task data.
+func diffSnapshots(old, new []Task, oldMatches, newMatches map[string]bool, now time.Time) []Event {
+	oldByID := indexTasks(old)
+	newByID := indexTasks(new)
+
+	var events []Event
+	for i := range new {
+		t := &new[i]
+		before, existed := oldByID[t.ID]
+		if !matched(newMatches, t.ID) && (!existed || !matched(oldMatches, t.ID)) {
+			continue
+		}
+
+		if !existed {
+			events = append(events, Event{Kind: EventCreated, ID: t.ID, At: t.Created, Task: t})
+			continue
+		}
+
+		if fields := changedFields(before, t); len(fields) > 0 {
+			events = append(events, Event{Kind: EventUpdated, ID: t.ID, At: t.Updated, Fields: fields})
+		}
+
+		if len(t.Transitions) > len(before.Transitions) {
+			for _, tr := range t.Transitions[len(before.Transitions):] {
+				events = append(events, Event{Kind: EventTransition, ID: t.ID, At: tr.At, From: tr.From, To: tr.To, Auto: tr.Auto})
+			}
+		} else if t.Status != before.Status {
+			// A status edited by hand has no transition record.
+			events = append(events, Event{Kind: EventTransition, ID: t.ID, At: t.Updated, From: before.Status, To: t.Status})
+		}
+
+		if len(t.Notes) > len(before.Notes) {
+			for _, note := range t.Notes[len(before.Notes):] {
+				events = append(events, Event{Kind: EventNoteAdded, ID: t.ID, At: note.Created, Note: note.Text})
+			}
+		}
+	}
+
+	for i := range old {
+		t := &old[i]
+		if _, ok := newByID[t.ID]; ok || !matched(oldMatches, t.ID) {
+			continue
+		}
+		events = append(events, Event{Kind: EventRemoved, ID: t.ID, At: now})
+	}
+	return events
+}
+
+// indexTasks maps each task's ID to the task.
+func indexTasks(tasks []Task) map[string]*Task {
+	byID := make(map[string]*Task, len(tasks))
+	for i := range tasks {
+		byID[tasks[i].ID] = &tasks[i]
+	}
+	return byID
+}
+
+// changedFields returns the sorted names of the serialized fields, including
+// unknown fields, whose values differ between before and after.
+func changedFields(before, after *Task) []string {
+	a, errA := fieldMap(before)
+	b, errB := fieldMap(after)
+	if errA != nil || errB != nil {
+		return nil
+	}
+
+	var fields []string
+	for name, value := range b {
+		if !watchIgnoredFields[name] && string(a[name]) != string(value) {
+			fields = append(fields, name)
+		}
+	}
+	for name := range a {
+		if _, ok := b[name]; !ok && !watchIgnoredFields[name] {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// fieldMap returns the task's serialized top-level fields.
+func fieldMap(t *Task) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(data, &fields)
+	return fields, err
+}
+
+// matchingIDs returns the IDs of the tasks in db that match f, evaluated with
+// the same conditions as List, or nil when f sets no conditions and every task
+// matches.
+func matchingIDs(db *sql.DB, f Filter, w task.Workflow) (map[string]bool, error) {
+	var descendantIDs []string
+	if f.Parent != "" {
+		var err error
+		if descendantIDs, err = query.DescendantIDs(db, f.Parent); err != nil {
+			return nil, err
+		}
+	}
+
+	conditions, args := query.Conditions(f, descendantIDs, w)
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+	rows, err := db.Query(`SELECT t.id FROM tasks t WHERE `+strings.Join(conditions, " AND "), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tasks: %w", err)
+	}
+	defer rows.Close()
+
+	ids := map[string]bool{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan task row: %w", err)
+		}
+		ids[id] = true
+	}
+	return ids, rows.Err()
+}
+
+// matched reports whether the task with the given ID is in ids, where nil ids
+// match every task.
+func matched(ids map[string]bool, id string) bool {
+	return ids == nil || ids[id]
+}
@@ -0,0 +1,285 @@
+package tick
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/leeovery/tick/internal/task"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	created := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	later := created.Add(time.Hour)
+	now := created.Add(2 * time.Hour)
+
+	base := func(id string) Task {
+		return Task{ID: id, Title: "Task " + id, Status: StatusOpen, Priority: 2, Created: created, Updated: created}
+	}
+
+	kinds := func(events []Event) []string {
+		var out []string
+		for _, e := range events {
+			out = append(out, string(e.Kind)+":"+e.ID)
+		}
+		return out
+	}
+
+	t.Run("it reports created and removed tasks", func(t *testing.T) {
+		old := []Task{base("tick-aaaaaa")}
+		next := []Task{base("tick-bbbbbb")}
+
+		events := diffSnapshots(old, next, nil, nil, now)
+		want := []string{"created:tick-bbbbbb", "removed:tick-aaaaaa"}
+		if got := kinds(events); !slices.Equal(got, want) {
+			t.Fatalf("events = %v, want %v", got, want)
+		}
+		if events[0].Task == nil || events[0].Task.Title != "Task tick-bbbbbb" || !events[0].At.Equal(created) {
+			t.Errorf("created event = %+v", events[0])
+		}
+		if !events[1].At.Equal(now) {
+			t.Errorf("removed At = %v, want %v", events[1].At, now)
+		}
+	})
+
+	t.Run("it reports changed fields including unknown ones", func(t *testing.T) {
+		before := base("tick-aaaaaa")
+		after := base("tick-aaaaaa")
+		after.Title = "Renamed"
+		after.Tags = []string{"ui"}
+		after.Extra = map[string]json.RawMessage{"milestone": json.RawMessage(`3`)}
+		after.Updated = later
+
+		events := diffSnapshots([]Task{before}, []Task{after}, nil, nil, now)
+		if len(events) != 1 || events[0].Kind != EventUpdated {
+			t.Fatalf("events = %v, want one updated", kinds(events))
+		}
+		if want := []string{"milestone", "tags", "title"}; !slices.Equal(events[0].Fields, want) {
+			t.Errorf("fields = %v, want %v", events[0].Fields, want)
+		}
+		if !events[0].At.Equal(later) {
+			t.Errorf("At = %v, want %v", events[0].At, later)
+		}
+	})
+
+	t.Run("it reports each new transition record with its auto flag", func(t *testing.T) {
+		before := base("tick-aaaaaa")
+		after := base("tick-aaaaaa")
+		after.Status = StatusDone
+		after.Closed = &later
+		after.Updated = later
+		after.Transitions = []task.TransitionRecord{
+			{From: StatusOpen, To: StatusInProgress, At: later},
+			{From: StatusInProgress, To: StatusDone, At: later, Auto: true},
+		}
+
+		events := diffSnapshots([]Task{before}, []Task{after}, nil, nil, now)
+		want := []string{"transition:tick-aaaaaa", "transition:tick-aaaaaa"}
+		if got := kinds(events); !slices.Equal(got, want) {
+			t.Fatalf("events = %v, want %v", got, want)
+		}
+		if events[0].Auto || events[0].To != StatusInProgress {
+			t.Errorf("first transition = %+v", events[0])
+		}
+		if !events[1].Auto || events[1].From != StatusInProgress || events[1].To != StatusDone {
+			t.Errorf("second transition = %+v", events[1])
+		}
+	})
+
+	t.Run("it reports a status change without a record as a manual transition", func(t *testing.T) {
+		before := base("tick-aaaaaa")
+		after := base("tick-aaaaaa")
+		after.Status = StatusCancelled
+
+		events := diffSnapshots([]Task{before}, []Task{after}, nil, nil, now)
+		if len(events) != 1 || events[0].Kind != EventTransition || events[0].From != StatusOpen || events[0].To != StatusCancelled || events[0].Auto {
+			t.Errorf("events = %+v", events)
+		}
+	})
+
+	t.Run("it reports each added note", func(t *testing.T) {
+		before := base("tick-aaaaaa")
+		before.Notes = []task.Note{{Text: "first", Created: created}}
+		after := base("tick-aaaaaa")
+		after.Notes = []task.Note{{Text: "first", Created: created}, {Text: "second", Created: later}}
+
+		events := diffSnapshots([]Task{before}, []Task{after}, nil, nil, now)
+		if len(events) != 1 || events[0].Kind != EventNoteAdded || events[0].Note != "second" || !events[0].At.Equal(later) {
+			t.Errorf("events = %+v", events)
+		}
+	})
+
+	t.Run("it reports nothing for identical snapshots", func(t *testing.T) {
+		tasks := []Task{base("tick-aaaaaa"), base("tick-bbbbbb")}
+		if events := diffSnapshots(tasks, slices.Clone(tasks), nil, nil, now); len(events) != 0 {
+			t.Errorf("events = %v, want none", kinds(events))
+		}
+	})
+
+	t.Run("it reports tasks that match the filter before or after the change", func(t *testing.T) {
+		parent := base("tick-pppppp")
+		child := base("tick-cccccc")
+		child.Parent = parent.ID
+		grandchild := base("tick-gggggg")
+		grandchild.Parent = child.ID
+		other := base("tick-oooooo")
+
+		old := []Task{parent, child, other}
+		movedOut := child
+		movedOut.Parent = ""
+		movedOut.Updated = later
+		otherEdited := other
+		otherEdited.Title = "Edited"
+		next := []Task{parent, movedOut, grandchild, otherEdited}
+
+		// Filtered by parent: the moved child matched before; the grandchild is
+		// under a task that is no longer a descendant; the other task never matched.
+		events := diffSnapshots(old, next, map[string]bool{child.ID: true}, map[string]bool{}, now)
+		want := []string{"updated:tick-cccccc"}
+		if got := kinds(events); !slices.Equal(got, want) {
+			t.Errorf("events = %v, want %v", got, want)
+		}
+
+		removed := diffSnapshots(old, []Task{parent, child}, map[string]bool{other.ID: true}, map[string]bool{}, now)
+		if got, want := kinds(removed), []string{"removed:tick-oooooo"}; !slices.Equal(got, want) {
+			t.Errorf("removed events = %v, want %v", got, want)
+		}
+
+		if events := diffSnapshots(old, next, map[string]bool{}, map[string]bool{}, now); len(events) != 0 {
+			t.Errorf("events = %v, want none when nothing matches", kinds(events))
+		}
+	})
+}
+
+func TestMatchingIDs(t *testing.T) {
+	p := openProject(t)
+	parent := mustCreate(t, p, CreateOptions{Title: "Parent"})
+	child := mustCreate(t, p, CreateOptions{Title: "Child", Parent: parent.ID})
+	grandchild := mustCreate(t, p, CreateOptions{Title: "Grandchild", Parent: child.ID})
+	other := mustCreate(t, p, CreateOptions{Title: "Other", Priority: new(1), Fields: map[string]string{"component": "api"}})
+
+	match := func(f Filter) map[string]bool {
+		t.Helper()
+		var ids map[string]bool
+		err := p.store.Query(func(db *sql.DB) error {
+			var err error
+			ids, err = matchingIDs(db, f, p.Workflow())
+			return err
+		})
+		if err != nil {
+			t.Fatalf("matchingIDs(%+v) returned error: %v", f, err)
+		}
+		return ids
+	}
+
+	if got := match(Filter{}); got != nil {
+		t.Errorf("unfiltered = %v, want nil (every task)", got)
+	}
+	if got := match(Filter{Parent: parent.ID}); len(got) != 2 || !got[child.ID] || !got[grandchild.ID] {
+		t.Errorf("parent-filtered = %v, want %s and %s", got, child.ID, grandchild.ID)
+	}
+	if got := match(Filter{Parent: grandchild.ID}); got == nil || len(got) != 0 {
+		t.Errorf("childless parent = %v, want an empty set", got)
+	}
+	if got := match(Filter{Status: "open", HasPriority: true, Priority: 1}); len(got) != 1 || !got[other.ID] {
+		t.Errorf("status and priority = %v, want %s", got, other.ID)
+	}
+	if got := match(Filter{TagGroups: [][]string{{"none"}}}); len(got) != 0 {
+		t.Errorf("tag-filtered = %v, want none", got)
+	}
+	if got := match(Filter{Fields: []FieldFilter{{Key: "component", Value: "api"}}}); len(got) != 1 || !got[other.ID] {
+		t.Errorf("field-filtered = %v, want %s", got, other.ID)
+	}
+}
+
+func TestEventMarshalJSON(t *testing.T) {
+	at := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("it includes auto only on transitions", func(t *testing.T) {
+		data, err := json.Marshal(Event{Kind: EventTransition, ID: "tick-aaaaaa", At: at, From: StatusOpen, To: StatusDone})
+		if err != nil {
+			t.Fatalf("Marshal returned error: %v", err)
+		}
+		want := `{"event":"transition","id":"tick-aaaaaa","at":"2026-01-01T09:00:00Z","from":"open","to":"done","auto":false}`
+		if string(data) != want {
+			t.Errorf("got %s, want %s", data, want)
+		}
+
+		data, _ = json.Marshal(Event{Kind: EventUpdated, ID: "tick-aaaaaa", At: at, Fields: []string{"title"}})
+		want = `{"event":"updated","id":"tick-aaaaaa","at":"2026-01-01T09:00:00Z","fields":["title"]}`
+		if string(data) != want {
+			t.Errorf("got %s, want %s", data, want)
+		}
+	})
+}
+
+func TestWatch(t *testing.T) {
+	t.Run("it emits changes made after it starts until cancelled", func(t *testing.T) {
+		p := openProject(t)
+		existing := mustCreate(t, p, CreateOptions{Title: "Existing"})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		var mu sync.Mutex
+		var events []Event
+		done := make(chan error, 1)
+		go func() {
+			done <- p.Watch(ctx, Filter{}, 10*time.Millisecond, func(e Event) error {
+				mu.Lock()
+				defer mu.Unlock()
+				events = append(events, e)
+				return nil
+			})
+		}()
+
+		// Let the baseline snapshot be taken before changing anything.
+		time.Sleep(50 * time.Millisecond)
+		added := mustCreate(t, p, CreateOptions{Title: "Added"})
+		if _, err := p.Transition(existing.ID, "start"); err != nil {
+			t.Fatalf("Transition returned error: %v", err)
+		}
+
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			mu.Lock()
+			n := len(events)
+			mu.Unlock()
+			if n >= 2 || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		cancel()
+		if err := <-done; err != nil {
+			t.Fatalf("Watch returned error: %v", err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		var got []string
+		for _, e := range events {
+			got = append(got, string(e.Kind)+":"+e.ID)
+		}
+		joined := strings.Join(got, ",")
+		if !strings.Contains(joined, "created:"+added.ID) || !strings.Contains(joined, "transition:"+existing.ID) {
+			t.Errorf("events = %v, want created %s and transition %s", got, added.ID, existing.ID)
+		}
+		if strings.Contains(joined, "created:"+existing.ID) {
+			t.Errorf("events = %v, existing task should not be reported as created", got)
+		}
+	})
+
+	t.Run("it rejects ready, blocked and count filters", func(t *testing.T) {
+		p := openProject(t)
+		for _, f := range []Filter{{Ready: true}, {Blocked: true}, {Count: 1, HasCount: true}} {
+			err := p.Watch(context.Background(), f, time.Millisecond, func(Event) error { return nil })
+			if err == nil {
+				t.Errorf("Watch(%+v) should fail", f)
+			}
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package tick
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WorkspaceFileName is the file, usually at a monorepo root, that lists the
+// directories of the tick projects making up a workspace.
+const WorkspaceFileName = ".tick-workspace"
+
+// Workspace is a set of tick projects queried together, as listed in a
+// .tick-workspace file. The file holds one member directory per line, relative
+// to the file; blank lines and lines starting with # are ignored. A member is
+// named after its directory unless the line gives a name first, as in
+// "api = tools/api".
+type Workspace struct {
+	// Root is the directory holding the .tick-workspace file.
+	Root string
+	// Members are the workspace's projects in file order.
+	Members []WorkspaceMember
+}
+
+// WorkspaceMember is one project of a Workspace.
+type WorkspaceMember struct {
+	// Name qualifies the member's task IDs, as in "billing/tick-a1b2".
+	Name string
+	// Dir is the absolute path of the directory holding the member's .tick
+	// directory.
+	Dir string
+}
+
+// DiscoverWorkspace walks up from startDir looking for a .tick-workspace file
+// and loads it.
+func DiscoverWorkspace(startDir string) (*Workspace, error) {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		candidate := filepath.Join(dir, WorkspaceFileName)
+		info, err := os.Stat(candidate)
+		if err == nil && !info.IsDir() {
+			return LoadWorkspace(candidate)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, errors.New("not in a tick workspace (no " + WorkspaceFileName + " file found)")
+		}
+		dir = parent
+	}
+}
+
+// LoadWorkspace reads the workspace file at path. Every member directory must
+// contain a .tick directory, and member names must be unique.
+func LoadWorkspace(path string) (*Workspace, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", WorkspaceFileName, err)
+	}
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Workspace{Root: filepath.Dir(path)}
+	seen := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, rel, named := strings.Cut(line, "=")
+		if named {
+			name, rel = strings.TrimSpace(name), strings.TrimSpace(rel)
+		} else {
+			rel = line
+		}
+		dir := filepath.Join(w.Root, rel)
+		if !named {
+			name = filepath.Base(dir)
+		}
+		if name == "" || rel == "" || strings.ContainsAny(name, "/ \t") {
+			return nil, fmt.Errorf("%s line %d: invalid member %q: use a directory, or name = directory", WorkspaceFileName, lineNum, line)
+		}
+		if other, ok := seen[strings.ToLower(name)]; ok {
+			return nil, fmt.Errorf("%s line %d: member name '%s' is already used by %s (name it explicitly: <name> = %s)", WorkspaceFileName, lineNum, name, other, rel)
+		}
+		if info, err := os.Stat(filepath.Join(dir, ".tick")); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("%s line %d: %s is not a tick project (no .tick directory)", WorkspaceFileName, lineNum, rel)
+		}
+
+		seen[strings.ToLower(name)] = rel
+		w.Members = append(w.Members, WorkspaceMember{Name: name, Dir: dir})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", WorkspaceFileName, err)
+	}
+	if len(w.Members) == 0 {
+		return nil, fmt.Errorf("%s lists no projects", WorkspaceFileName)
+	}
+	return w, nil
+}
+
+// Member returns the member named name, ignoring case.
+func (w *Workspace) Member(name string) (WorkspaceMember, error) {
+	names := make([]string, len(w.Members))
+	for i, m := range w.Members {
+		if strings.EqualFold(m.Name, name) {
+			return m, nil
+		}
+		names[i] = m.Name
+	}
+	return WorkspaceMember{}, fmt.Errorf("no project '%s' in workspace (projects: %s)", name, strings.Join(names, ", "))
+}
+
+// Open opens the member's project.
+func (m WorkspaceMember) Open(opts ...Option) (*Project, error) {
+	return Open(m.Dir, opts...)
+}
+
+// QualifyID prefixes a task ID with the name of its workspace project.
+func QualifyID(project, id string) string {
+	return project + "/" + id
+}
+
+// SplitQualifiedID splits a workspace-qualified ID such as "billing/tick-a1b2"
+// into its project name and task ID. ok is false for an unqualified ID.
+func SplitQualifiedID(id string) (project, taskID string, ok bool) {
+	project, taskID, ok = strings.Cut(id, "/")
+	if !ok || project == "" || taskID == "" {
+		return "", id, false
+	}
+	return project, taskID, true
+}
@@ -0,0 +1,104 @@
+package tick
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupWorkspace creates a workspace root holding a .tick/ directory for each
+// member path and a .tick-workspace file with the given contents.
+func setupWorkspace(t *testing.T, file string, members ...string) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, m := range members {
+		if err := os.MkdirAll(filepath.Join(root, m, ".tick"), 0755); err != nil {
+			t.Fatalf("failed to create member %s: %v", m, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, WorkspaceFileName), []byte(file), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", WorkspaceFileName, err)
+	}
+	return root
+}
+
+func TestDiscoverWorkspace(t *testing.T) {
+	t.Run("it loads the workspace file found by walking up", func(t *testing.T) {
+		root := setupWorkspace(t, "# services\nservices/billing\n\napi = tools/api\n", "services/billing", "tools/api")
+
+		ws, err := DiscoverWorkspace(filepath.Join(root, "services", "billing"))
+		if err != nil {
+			t.Fatalf("DiscoverWorkspace returned error: %v", err)
+		}
+		if ws.Root != root {
+			t.Errorf("Root = %q, want %q", ws.Root, root)
+		}
+		want := []WorkspaceMember{
+			{Name: "billing", Dir: filepath.Join(root, "services", "billing")},
+			{Name: "api", Dir: filepath.Join(root, "tools", "api")},
+		}
+		if len(ws.Members) != len(want) || ws.Members[0] != want[0] || ws.Members[1] != want[1] {
+			t.Errorf("Members = %v, want %v", ws.Members, want)
+		}
+
+		m, err := ws.Member("Billing")
+		if err != nil || m.Name != "billing" {
+			t.Errorf("Member(Billing) = %v, %v", m, err)
+		}
+		if _, err := ws.Member("web"); err == nil || err.Error() != "no project 'web' in workspace (projects: billing, api)" {
+			t.Errorf("Member(web) error = %v", err)
+		}
+	})
+
+	t.Run("it errors when no workspace file is found", func(t *testing.T) {
+		_, err := DiscoverWorkspace(t.TempDir())
+		if err == nil || err.Error() != "not in a tick workspace (no .tick-workspace file found)" {
+			t.Errorf("error = %v", err)
+		}
+	})
+
+	t.Run("it rejects invalid workspace files", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			file    string
+			members []string
+			want    string
+		}{
+			{"no members", "# nothing yet\n", nil, ".tick-workspace lists no projects"},
+			{"member without .tick", "billing\n", nil, ".tick-workspace line 1: billing is not a tick project"},
+			{"duplicate names", "a/api\nb/api\n", []string{"a/api", "b/api"}, "member name 'api' is already used by a/api"},
+			{"invalid name", "my api = api\n", []string{"api"}, `invalid member "my api = api"`},
+		}
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				root := setupWorkspace(t, tc.file, tc.members...)
+				_, err := LoadWorkspace(filepath.Join(root, WorkspaceFileName))
+				if err == nil || !strings.Contains(err.Error(), tc.want) {
+					t.Errorf("error = %v, want %q", err, tc.want)
+				}
+			})
+		}
+	})
+}
+
+func TestSplitQualifiedID(t *testing.T) {
+	tests := []struct {
+		in, project, id string
+		ok              bool
+	}{
+		{"billing/tick-a1b2", "billing", "tick-a1b2", true},
+		{"tick-a1b2", "", "tick-a1b2", false},
+		{"/tick-a1b2", "", "/tick-a1b2", false},
+		{"billing/", "", "billing/", false},
+	}
+	for _, tc := range tests {
+		project, id, ok := SplitQualifiedID(tc.in)
+		if project != tc.project || id != tc.id || ok != tc.ok {
+			t.Errorf("SplitQualifiedID(%q) = %q, %q, %v", tc.in, project, id, ok)
+		}
+	}
+	if got := QualifyID("billing", "tick-a1b2"); got != "billing/tick-a1b2" {
+		t.Errorf("QualifyID = %q", got)
+	}
+}